@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/zeebo/errs"
+)
+
+const (
+	defaultLogLevel = "info"
+)
+
+type Config struct {
+	LogFormat string `hcl:"log_format"`
+	LogLevel  string `hcl:"log_level"`
+	LogPath   string `hcl:"log_path"`
+
+	// SocketPath is the path of the socket the helper listens on. It must
+	// match the privileged_helper socket_path of the agent.
+	SocketPath string `hcl:"socket_path"`
+
+	// AgentUID is the UID the agent runs as. Only the agent can connect to
+	// the helper.
+	AgentUID *int `hcl:"agent_uid"`
+
+	// Plugins configures the workload attestors run by the helper. Only
+	// WorkloadAttestor plugins can be configured.
+	Plugins catalog.HCLPluginConfigMap `hcl:"plugins"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	hclBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.New("unable to load configuration: %v", err)
+	}
+	return ParseConfig(string(hclBytes))
+}
+
+func ParseConfig(hclConfig string) (_ *Config, err error) {
+	c := new(Config)
+	if err := hcl.Decode(c, hclConfig); err != nil {
+		return nil, errs.New("unable to decode configuration: %v", err)
+	}
+
+	if c.LogLevel == "" {
+		c.LogLevel = defaultLogLevel
+	}
+
+	if c.SocketPath == "" {
+		return nil, errs.New("socket_path must be configured")
+	}
+	if c.AgentUID == nil {
+		return nil, errs.New("agent_uid must be configured")
+	}
+	if *c.AgentUID < 0 {
+		return nil, errs.New("agent_uid must not be negative")
+	}
+	if len(c.Plugins) == 0 {
+		return nil, errs.New("plugins must be configured")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	minimalConfig = `
+		socket_path = "/run/spire/helper/helper.sock"
+		agent_uid = 1000
+		plugins {
+			WorkloadAttestor "docker" {
+				plugin_data {}
+			}
+		}
+`
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	confPath := filepath.Join(dir, "test.conf")
+
+	_, err := LoadConfig(confPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to load configuration:")
+
+	require.NoError(t, ioutil.WriteFile(confPath, []byte(minimalConfig), 0600))
+
+	config, err := LoadConfig(confPath)
+	require.NoError(t, err)
+	require.Equal(t, defaultLogLevel, config.LogLevel)
+	require.Equal(t, "/run/spire/helper/helper.sock", config.SocketPath)
+	require.Equal(t, 1000, *config.AgentUID)
+	require.Contains(t, config.Plugins["WorkloadAttestor"], "docker")
+}
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "malformed HCL",
+			in:   `BAD`,
+			err:  "unable to decode configuration",
+		},
+		{
+			name: "no socket path configured",
+			in: `
+				agent_uid = 1000
+				plugins {
+					WorkloadAttestor "docker" {}
+				}
+			`,
+			err: "socket_path must be configured",
+		},
+		{
+			name: "no agent UID configured",
+			in: `
+				socket_path = "/run/spire/helper/helper.sock"
+				plugins {
+					WorkloadAttestor "docker" {}
+				}
+			`,
+			err: "agent_uid must be configured",
+		},
+		{
+			name: "negative agent UID",
+			in: `
+				socket_path = "/run/spire/helper/helper.sock"
+				agent_uid = -1
+				plugins {
+					WorkloadAttestor "docker" {}
+				}
+			`,
+			err: "agent_uid must not be negative",
+		},
+		{
+			name: "no plugins configured",
+			in: `
+				socket_path = "/run/spire/helper/helper.sock"
+				agent_uid = 1000
+			`,
+			err: "plugins must be configured",
+		},
+		{
+			name: "root agent",
+			in: `
+				socket_path = "/run/spire/helper/helper.sock"
+				agent_uid = 0
+				log_level = "debug"
+				plugins {
+					WorkloadAttestor "unix" {}
+				}
+			`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseConfig(testCase.in)
+			if testCase.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/helper"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/zeebo/errs"
+)
+
+var (
+	configFlag = flag.String("config", "spire-agent-helper.conf", "configuration file")
+)
+
+// The privileged helper runs the workload attestors that require privileges
+// (e.g. to inspect the processes of other users or to reach the docker
+// socket) on behalf of the agent, so the agent can run unprivileged.
+func main() {
+	flag.Parse()
+	if err := run(*configFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	log, err := log.NewLogger(log.WithLevel(config.LogLevel), log.WithFormat(config.LogFormat), log.WithOutputFile(config.LogPath))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer log.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	util.SignalListener(ctx, cancel)
+
+	repo, err := catalog.LoadWorkloadAttestors(ctx, catalog.Config{
+		Log:          log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		GlobalConfig: &catalog.GlobalConfig{},
+		PluginConfig: config.Plugins,
+	})
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	attestors := make(map[string]workloadattestor.WorkloadAttestor)
+	for _, attestor := range repo.WorkloadAttestors {
+		attestors[attestor.Name()] = attestor
+	}
+
+	return helper.New(helper.Config{
+		BindAddr: &net.UnixAddr{
+			Name: config.SocketPath,
+			Net:  "unix",
+		},
+		AgentUID:  *config.AgentUID,
+		Attestors: attestors,
+		Log:       log,
+	}).ListenAndServe(ctx)
+}
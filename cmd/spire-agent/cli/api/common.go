@@ -36,14 +36,20 @@ func newWorkloadClient(ctx context.Context, socketPath string, timeout time.Dura
 }
 
 func (c *workloadClient) prepareContext(ctx context.Context) (context.Context, func()) {
-	header := metadata.Pairs("workload.spiffe.io", "true")
-	ctx = metadata.NewOutgoingContext(ctx, header)
+	ctx = prepareStreamContext(ctx)
 	if c.timeout > 0 {
 		return context.WithTimeout(ctx, c.timeout)
 	}
 	return ctx, func() {}
 }
 
+// prepareStreamContext prepares the context of a long-lived stream, which,
+// unlike other calls, is not bound by the client timeout.
+func prepareStreamContext(ctx context.Context) context.Context {
+	header := metadata.Pairs("workload.spiffe.io", "true")
+	return metadata.NewOutgoingContext(ctx, header)
+}
+
 // command is a common interface for commands in this package. the adapter
 // can adapter this interface to the Command interface from github.com/mitchellh/cli.
 type command interface {
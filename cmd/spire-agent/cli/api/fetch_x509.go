@@ -8,8 +8,11 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
 	"path"
+	"syscall"
 	"time"
 
 	"github.com/mitchellh/cli"
@@ -18,8 +21,13 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/diskutil"
 )
 
+// watchRetryInterval is the time to wait before subscribing again to the
+// Workload API after the stream fails in watch mode.
+const watchRetryInterval = 5 * time.Second
+
 func NewFetchX509Command() cli.Command {
 	return newFetchX509Command(common_cli.DefaultEnv, newWorkloadClient)
 }
@@ -31,6 +39,8 @@ func newFetchX509Command(env *common_cli.Env, clientMaker workloadClientMaker) c
 type fetchX509Command struct {
 	silent    bool
 	writePath string
+	watch     bool
+	command   string
 }
 
 func (*fetchX509Command) name() string {
@@ -42,6 +52,14 @@ func (*fetchX509Command) synopsis() string {
 }
 
 func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	if c.command != "" && c.writePath == "" {
+		return errors.New("-command requires -write")
+	}
+
+	if c.watch {
+		return c.watchX509SVIDs(ctx, env, client)
+	}
+
 	start := time.Now()
 	resp, err := c.fetchX509SVID(ctx, client)
 	respTime := time.Since(start)
@@ -49,6 +67,19 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 		return err
 	}
 
+	return c.handleResponse(ctx, env, resp, respTime)
+}
+
+func (c *fetchX509Command) appendFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
+	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
+	fs.BoolVar(&c.watch, "watch", false, "Stay subscribed to the Workload API and handle every SVID update until interrupted")
+	fs.StringVar(&c.command, "command", "", "Shell command to run after SVID data is written (optional, requires -write)")
+}
+
+// handleResponse prints the SVIDs of the response and, if requested, writes
+// them to disk and runs the command hook.
+func (c *fetchX509Command) handleResponse(ctx context.Context, env *common_cli.Env, resp *workload.X509SVIDResponse, respTime time.Duration) error {
 	svids, err := parseAndValidateX509SVIDResponse(resp)
 	if err != nil {
 		return err
@@ -62,14 +93,81 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 		if err := c.writeResponse(svids); err != nil {
 			return err
 		}
+		if c.command != "" {
+			if err := c.runCommand(ctx, env); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func (c *fetchX509Command) appendFlags(fs *flag.FlagSet) {
-	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
-	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
+// watchX509SVIDs stays subscribed to the Workload API and handles every
+// X509-SVID update until the command is interrupted. The subscription is
+// renewed if the stream fails, e.g. while the agent restarts.
+func (c *fetchX509Command) watchX509SVIDs(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := c.watchStream(ctx, env, client)
+		if ctx.Err() != nil {
+			return nil
+		}
+		_ = env.ErrPrintf("Failed to watch X509-SVIDs: %v; retrying in %s\n", err, watchRetryInterval)
+
+		select {
+		case <-time.After(watchRetryInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *fetchX509Command) watchStream(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	start := time.Now()
+	stream, err := client.FetchX509SVID(prepareStreamContext(ctx), &workload.X509SVIDRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		// A bad update does not end the watch, since the next one may be
+		// handled successfully.
+		if err := c.handleResponse(ctx, env, resp, time.Since(start)); err != nil {
+			_ = env.ErrPrintln(err)
+		}
+		start = time.Now()
+	}
+}
+
+// runCommand runs the command hook, e.g. to have a workload reload the SVID
+// data that was just written.
+func (c *fetchX509Command) runCommand(ctx context.Context, env *common_cli.Env) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", c.command) // nolint: gosec // the command is provided by the operator
+	cmd.Stdout = env.Stdout
+	cmd.Stderr = env.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run command %q: %v", c.command, err)
+	}
+	return nil
 }
 
 func (c *fetchX509Command) fetchX509SVID(ctx context.Context, client *workloadClient) (*workload.X509SVIDResponse, error) {
@@ -153,12 +251,13 @@ func (c *fetchX509Command) writeKey(filename string, privateKey crypto.PrivateKe
 		Bytes: data,
 	}
 
-	return ioutil.WriteFile(filename, pem.EncodeToMemory(b), 0600)
+	return diskutil.AtomicWriteFile(filename, pem.EncodeToMemory(b), 0600)
 }
 
-// writeFile creates or truncates filename, and writes data to it
+// writeFile atomically replaces filename with data, so that readers never
+// observe partially written SVID data when it is rotated
 func (c *fetchX509Command) writeFile(filename string, data []byte) error {
-	return ioutil.WriteFile(filename, data, 0644) // nolint: gosec // expected permission for certificates
+	return diskutil.AtomicWriteFile(filename, data, 0644)
 }
 
 type X509SVID struct {
@@ -8,6 +8,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/validate"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -20,7 +21,11 @@ type CLI struct {
 func (cc *CLI) Run(args []string) int {
 	c := cli.NewCLI("spire-agent", version.Version())
 	c.Args = args
+	c.Autocomplete = true
 	c.Commands = map[string]cli.CommandFactory{
+		"completion": func() (cli.Command, error) {
+			return common_cli.NewCompletionCommand("spire-agent"), nil
+		},
 		"api fetch": func() (cli.Command, error) {
 			return api.NewFetchX509Command(), nil
 		},
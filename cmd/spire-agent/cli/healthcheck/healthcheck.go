@@ -2,15 +2,18 @@ package healthcheck
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/api/workload/dial"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debugv1 "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
@@ -27,9 +30,23 @@ func newHealthCheckCommand(env *common_cli.Env) *healthCheckCommand {
 type healthCheckCommand struct {
 	env *common_cli.Env
 
-	socketPath string
-	shallow    bool
-	verbose    bool
+	socketPath      string
+	adminSocketPath string
+	shallow         bool
+	verbose         bool
+}
+
+// componentsStatus is the JSON representation of the agent component health
+// printed in verbose mode.
+type componentsStatus struct {
+	Healthy    bool              `json:"healthy"`
+	Components []componentStatus `json:"components"`
+}
+
+type componentStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Details string `json:"details,omitempty"`
 }
 
 func (c *healthCheckCommand) Help() string {
@@ -62,6 +79,7 @@ func (c *healthCheckCommand) parseFlags(args []string) error {
 	fs := flag.NewFlagSet("health", flag.ContinueOnError)
 	fs.SetOutput(c.env.Stderr)
 	fs.StringVar(&c.socketPath, "socketPath", common.DefaultSocketPath, "Path to Workload API socket")
+	fs.StringVar(&c.adminSocketPath, "adminSocketPath", "", "Path to the agent admin API socket. When set in verbose mode, the status of each agent component is printed as JSON")
 	fs.BoolVar(&c.shallow, "shallow", false, "Perform a less stringent health check")
 	fs.BoolVar(&c.verbose, "verbose", false, "Print verbose information")
 	return fs.Parse(args)
@@ -96,5 +114,60 @@ func (c *healthCheckCommand) run() error {
 		return fmt.Errorf("agent returned status %q", resp.Status)
 	}
 
+	if c.verbose && c.adminSocketPath != "" {
+		return c.checkComponents()
+	}
+
+	return nil
+}
+
+// checkComponents prints the health status of each agent component, as
+// reported by the agent admin API, and fails if any of them is unhealthy.
+func (c *healthCheckCommand) checkComponents() error {
+	conn, err := dial.Dial(context.Background(), &net.UnixAddr{
+		Name: c.adminSocketPath,
+		Net:  "unix",
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	debugClient := debugv1.NewDebugClient(conn)
+	resp, err := debugClient.GetHealthStatus(context.Background(), &debugv1.GetHealthStatusRequest{})
+	if err != nil {
+		// Ignore error since a failure to write to stderr cannot very well
+		// be reported
+		_ = c.env.ErrPrintf("Failed to get component health: %v\n", err)
+		return errors.New("unable to determine component health")
+	}
+
+	status := componentsStatus{
+		Healthy:    resp.Healthy,
+		Components: []componentStatus{},
+	}
+	var unhealthy []string
+	for _, component := range resp.Components {
+		status.Components = append(status.Components, componentStatus{
+			Name:    component.Name,
+			Healthy: component.Healthy,
+			Details: component.Details,
+		})
+		if !component.Healthy {
+			unhealthy = append(unhealthy, component.Name)
+		}
+	}
+
+	out, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := c.env.Println(string(out)); err != nil {
+		return err
+	}
+
+	if !resp.Healthy {
+		return fmt.Errorf("unhealthy components: %s", strings.Join(unhealthy, ", "))
+	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mitchellh/cli"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debugv1 "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
@@ -46,6 +47,8 @@ func (s *HealthCheckSuite) TestSynopsis() {
 func (s *HealthCheckSuite) TestHelp() {
 	s.Equal("", s.cmd.Help())
 	s.Equal(`Usage of health:
+  -adminSocketPath string
+    	Path to the agent admin API socket. When set in verbose mode, the status of each agent component is printed as JSON
   -shallow
     	Perform a less stringent health check
   -socketPath string
@@ -61,6 +64,8 @@ func (s *HealthCheckSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Equal(`flag provided but not defined: -badflag
 Usage of health:
+  -adminSocketPath string
+    	Path to the agent admin API socket. When set in verbose mode, the status of each agent component is printed as JSON
   -shallow
     	Perform a less stringent health check
   -socketPath string
@@ -109,6 +114,88 @@ Agent is healthy.
 	s.Equal("", s.stderr.String(), "stderr")
 }
 
+func (s *HealthCheckSuite) TestPrintsComponentStatusVerbose() {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(srv, withStatus(grpc_health_v1.HealthCheckResponse_SERVING))
+	})
+	adminSocketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		debugv1.RegisterDebugServer(srv, debugServer{
+			resp: &debugv1.GetHealthStatusResponse{
+				Healthy: true,
+				Components: []*debugv1.GetHealthStatusResponse_Component{
+					{Name: "attestation", Healthy: true, Details: "attested"},
+					{Name: "cache", Healthy: true},
+				},
+			},
+		})
+	})
+	code := s.cmd.Run([]string{"--socketPath", socketPath, "--adminSocketPath", adminSocketPath, "--verbose"})
+	s.Equal(0, code, "exit code")
+	s.Equal(`Checking agent health...
+{
+  "healthy": true,
+  "components": [
+    {
+      "name": "attestation",
+      "healthy": true,
+      "details": "attested"
+    },
+    {
+      "name": "cache",
+      "healthy": true
+    }
+  ]
+}
+Agent is healthy.
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestFailsIfComponentUnhealthyVerbose() {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(srv, withStatus(grpc_health_v1.HealthCheckResponse_SERVING))
+	})
+	adminSocketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		debugv1.RegisterDebugServer(srv, debugServer{
+			resp: &debugv1.GetHealthStatusResponse{
+				Components: []*debugv1.GetHealthStatusResponse_Component{
+					{Name: "attestation", Healthy: true},
+					{Name: "server_connectivity", Details: "agent has not synced with the server"},
+				},
+			},
+		})
+	})
+	code := s.cmd.Run([]string{"--socketPath", socketPath, "--adminSocketPath", adminSocketPath, "--verbose"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal(`Checking agent health...
+{
+  "healthy": false,
+  "components": [
+    {
+      "name": "attestation",
+      "healthy": true
+    },
+    {
+      "name": "server_connectivity",
+      "healthy": false,
+      "details": "agent has not synced with the server"
+    }
+  ]
+}
+`, s.stdout.String(), "stdout")
+	s.Equal("Agent is unhealthy: unhealthy components: server_connectivity\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestIgnoresAdminSocketIfNotVerbose() {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(srv, withStatus(grpc_health_v1.HealthCheckResponse_SERVING))
+	})
+	code := s.cmd.Run([]string{"--socketPath", socketPath, "--adminSocketPath", "doesnotexist.sock"})
+	s.Equal(0, code, "exit code")
+	s.Equal("Agent is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
 func (s *HealthCheckSuite) TestFailsIfServiceStatusOther() {
 	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
 		grpc_health_v1.RegisterHealthServer(srv, withStatus(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
@@ -138,3 +225,12 @@ func (s healthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest)
 		Status: s.status,
 	}, nil
 }
+
+type debugServer struct {
+	debugv1.UnimplementedDebugServer
+	resp *debugv1.GetHealthStatusResponse
+}
+
+func (s debugServer) GetHealthStatus(context.Context, *debugv1.GetHealthStatusRequest) (*debugv1.GetHealthStatusResponse, error) {
+	return s.resp, nil
+}
@@ -23,12 +23,16 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 )
@@ -43,6 +47,10 @@ const (
 	defaultLogLevel          = "INFO"
 	defaultDefaultSVIDName   = "default"
 	defaultDefaultBundleName = "ROOTCA"
+
+	// defaultMaxKeyAge is the default time a key is reused for when key
+	// reuse is enabled
+	defaultMaxKeyAge = 24 * time.Hour
 )
 
 // Config contains all available configurables, arranged by section
@@ -55,20 +63,41 @@ type Config struct {
 }
 
 type agentConfig struct {
-	DataDir           string    `hcl:"data_dir"`
-	AdminSocketPath   string    `hcl:"admin_socket_path"`
-	InsecureBootstrap bool      `hcl:"insecure_bootstrap"`
-	JoinToken         string    `hcl:"join_token"`
-	LogFile           string    `hcl:"log_file"`
-	LogFormat         string    `hcl:"log_format"`
-	LogLevel          string    `hcl:"log_level"`
-	SDS               sdsConfig `hcl:"sds"`
-	ServerAddress     string    `hcl:"server_address"`
-	ServerPort        int       `hcl:"server_port"`
-	SocketPath        string    `hcl:"socket_path"`
-	TrustBundlePath   string    `hcl:"trust_bundle_path"`
-	TrustBundleURL    string    `hcl:"trust_bundle_url"`
-	TrustDomain       string    `hcl:"trust_domain"`
+	DataDir               string    `hcl:"data_dir"`
+	AdminSocketPath       string    `hcl:"admin_socket_path"`
+	DNSMinResolveInterval string    `hcl:"dns_min_resolve_interval"`
+	DNSResolverAddress    string    `hcl:"dns_resolver_address"`
+	InsecureBootstrap     bool      `hcl:"insecure_bootstrap"`
+	JoinToken             string    `hcl:"join_token"`
+	LockMemory            bool      `hcl:"lock_memory"`
+	LogFile               string    `hcl:"log_file"`
+	LogFormat             string    `hcl:"log_format"`
+	LogLevel              string    `hcl:"log_level"`
+	SDS                   sdsConfig `hcl:"sds"`
+	ServerAddress         string    `hcl:"server_address"`
+	ServerIPs             []string  `hcl:"server_ips"`
+	ServerPort            int       `hcl:"server_port"`
+	SocketGroup           string    `hcl:"socket_group"`
+	SocketMode            string    `hcl:"socket_mode"`
+	SocketOwner           string    `hcl:"socket_owner"`
+	SocketPath            string    `hcl:"socket_path"`
+	SocketSELinuxContext  string    `hcl:"socket_selinux_context"`
+	TrustBundlePath       string    `hcl:"trust_bundle_path"`
+	TrustBundleURL        string    `hcl:"trust_bundle_url"`
+	TrustDomain           string    `hcl:"trust_domain"`
+
+	PluginPolicy      *catalog.HCLPluginPolicy `hcl:"plugin_policy"`
+	WorkloadAPILimits workloadAPILimitsConfig  `hcl:"workload_api_limits"`
+	WorkloadAPIAudit  workloadAPIAuditConfig   `hcl:"workload_api_audit"`
+	SelectorRedaction *selectorRedactionConfig `hcl:"selector_redaction"`
+	PrivilegedHelper  *privilegedHelperConfig  `hcl:"privileged_helper"`
+	KeyReuse          *keyReuseConfig          `hcl:"key_reuse"`
+
+	// TrustBundleSecondaryURL is an additional source for the trust bundle.
+	// Its certificates are merged with the ones from trust_bundle_path or
+	// trust_bundle_url so that old and new roots can coexist while the
+	// upstream CA is in transition.
+	TrustBundleSecondaryURL string `hcl:"trust_bundle_secondary_url"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -83,13 +112,52 @@ type agentConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type workloadAPILimitsConfig struct {
+	MaxConcurrentAttestations int    `hcl:"max_concurrent_attestations"`
+	AttestationQueueTimeout   string `hcl:"attestation_queue_timeout"`
+	MaxStreams                int    `hcl:"max_streams"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type workloadAPIAuditConfig struct {
+	Enabled            bool `hcl:"enabled"`
+	MaxEventsPerSecond int  `hcl:"max_events_per_second"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type selectorRedactionConfig struct {
+	Mask []string `hcl:"mask"`
+	Hash []string `hcl:"hash"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type privilegedHelperConfig struct {
+	SocketPath        string   `hcl:"socket_path"`
+	WorkloadAttestors []string `hcl:"workload_attestors"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type keyReuseConfig struct {
+	MaxRenewals int    `hcl:"max_renewals"`
+	MaxKeyAge   string `hcl:"max_key_age"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 type sdsConfig struct {
 	DefaultSVIDName   string `hcl:"default_svid_name"`
 	DefaultBundleName string `hcl:"default_bundle_name"`
 }
 
 type experimentalConfig struct {
-	SyncInterval string `hcl:"sync_interval"`
+	BundleSyncInterval string `hcl:"bundle_sync_interval"`
+	HedgeDelay         string `hcl:"hedge_delay"`
+	MaxSyncInterval    string `hcl:"max_sync_interval"`
+	SyncInterval       string `hcl:"sync_interval"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -310,32 +378,70 @@ func downloadTrustBundle(trustBundleURL string) ([]*x509.Certificate, error) {
 		return nil, err
 	}
 
+	if len(bundle) == 0 {
+		return nil, fmt.Errorf("no certificates found in trust bundle URL %s", trustBundleURL)
+	}
+
 	return bundle, nil
 }
 
 func setupTrustBundle(ac *agent.Config, c *Config) error {
 	// Either download the turst bundle if TrustBundleURL is set, or read it
-	// from disk if TrustBundlePath is set
+	// from disk if TrustBundlePath is set. The secondary URL, if set, is
+	// merged with the result.
 	ac.InsecureBootstrap = c.Agent.InsecureBootstrap
 
+	var bundle []*x509.Certificate
+	var err error
 	switch {
 	case c.Agent.TrustBundleURL != "":
-		bundle, err := downloadTrustBundle(c.Agent.TrustBundleURL)
+		bundle, err = downloadTrustBundle(c.Agent.TrustBundleURL)
 		if err != nil {
-			return err
+			if c.Agent.TrustBundleSecondaryURL == "" {
+				return err
+			}
+			ac.Log.WithError(err).Warn("Failed to download trust bundle; relying on the secondary trust bundle URL")
 		}
-		ac.TrustBundle = bundle
 	case c.Agent.TrustBundlePath != "":
-		bundle, err := parseTrustBundle(c.Agent.TrustBundlePath)
+		bundle, err = parseTrustBundle(c.Agent.TrustBundlePath)
 		if err != nil {
 			return fmt.Errorf("could not parse trust bundle: %v", err)
 		}
-		ac.TrustBundle = bundle
 	}
 
+	if c.Agent.TrustBundleSecondaryURL != "" {
+		secondary, secondaryErr := downloadTrustBundle(c.Agent.TrustBundleSecondaryURL)
+		switch {
+		case secondaryErr == nil:
+			bundle = mergeTrustBundles(bundle, secondary)
+		case len(bundle) == 0:
+			return fmt.Errorf("unable to get trust bundle from any source: %v; %v", err, secondaryErr)
+		default:
+			ac.Log.WithError(secondaryErr).Warn("Failed to download trust bundle from the secondary trust bundle URL")
+		}
+	}
+
+	ac.TrustBundle = bundle
 	return nil
 }
 
+// mergeTrustBundles returns the certificates in a followed by the
+// certificates in b that are not already in a.
+func mergeTrustBundles(a, b []*x509.Certificate) []*x509.Certificate {
+	merged := append([]*x509.Certificate(nil), a...)
+	seen := make(map[string]bool, len(a))
+	for _, cert := range a {
+		seen[string(cert.Raw)] = true
+	}
+	for _, cert := range b {
+		if !seen[string(cert.Raw)] {
+			seen[string(cert.Raw)] = true
+			merged = append(merged, cert)
+		}
+	}
+	return merged
+}
+
 func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool) (*agent.Config, error) {
 	ac := &agent.Config{}
 
@@ -351,9 +457,45 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		}
 	}
 
+	if c.Agent.Experimental.BundleSyncInterval != "" {
+		var err error
+		ac.BundleSyncInterval, err = time.ParseDuration(c.Agent.Experimental.BundleSyncInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse bundle synchronization interval: %v", err)
+		}
+	}
+
+	if c.Agent.Experimental.MaxSyncInterval != "" {
+		var err error
+		ac.MaxSyncInterval, err = time.ParseDuration(c.Agent.Experimental.MaxSyncInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse maximum synchronization interval: %v", err)
+		}
+		if ac.SyncInterval != 0 && ac.MaxSyncInterval < ac.SyncInterval {
+			return nil, errors.New("max_sync_interval must not be lower than sync_interval")
+		}
+	}
+
+	if c.Agent.Experimental.HedgeDelay != "" {
+		var err error
+		ac.HedgeDelay, err = time.ParseDuration(c.Agent.Experimental.HedgeDelay)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse hedge delay: %v", err)
+		}
+	}
+
 	serverHostPort := net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))
 	ac.ServerAddress = fmt.Sprintf("dns:///%s", serverHostPort)
 
+	serverResolver, err := parseServerResolverConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if serverResolver != nil {
+		ac.ServerAddress = fmt.Sprintf("%s:///%s", client.ResolverScheme, serverHostPort)
+		ac.ServerResolver = serverResolver
+	}
+
 	td, err := idutil.ParseSpiffeID("spiffe://"+c.Agent.TrustDomain, idutil.AllowAnyTrustDomain())
 	if err != nil {
 		return nil, fmt.Errorf("could not parse trust_domain %q: %v", c.Agent.TrustDomain, err)
@@ -389,6 +531,51 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 	ac.DefaultSVIDName = c.Agent.SDS.DefaultSVIDName
 	ac.DefaultBundleName = c.Agent.SDS.DefaultBundleName
 
+	if c.Agent.SocketMode != "" {
+		mode, err := strconv.ParseUint(c.Agent.SocketMode, 8, 32)
+		if err != nil || mode == 0 || mode > 0777 {
+			return nil, fmt.Errorf("could not parse socket_mode %q: must be an octal permission value such as \"0770\"", c.Agent.SocketMode)
+		}
+		ac.WorkloadAPISocket.Mode = os.FileMode(mode)
+	}
+	ac.WorkloadAPISocket.Owner = c.Agent.SocketOwner
+	ac.WorkloadAPISocket.Group = c.Agent.SocketGroup
+	ac.WorkloadAPISocket.SELinuxContext = c.Agent.SocketSELinuxContext
+
+	ac.WorkloadAPILimits.MaxConcurrentAttestations = c.Agent.WorkloadAPILimits.MaxConcurrentAttestations
+	ac.WorkloadAPILimits.MaxStreams = c.Agent.WorkloadAPILimits.MaxStreams
+	if c.Agent.WorkloadAPILimits.AttestationQueueTimeout != "" {
+		ac.WorkloadAPILimits.AttestationQueueTimeout, err = time.ParseDuration(c.Agent.WorkloadAPILimits.AttestationQueueTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse attestation_queue_timeout: %v", err)
+		}
+	}
+
+	ac.WorkloadAPIAudit.Enabled = c.Agent.WorkloadAPIAudit.Enabled
+	ac.WorkloadAPIAudit.MaxEventsPerSecond = c.Agent.WorkloadAPIAudit.MaxEventsPerSecond
+
+	if redaction := c.Agent.SelectorRedaction; redaction != nil {
+		ac.SelectorRedactor, err = selector.NewRedactor(redaction.Mask, redaction.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector_redaction: %w", err)
+		}
+		ac.WorkloadAPIAudit.SelectorRedactor = ac.SelectorRedactor
+	}
+
+	if c.Agent.PrivilegedHelper != nil {
+		ac.PrivilegedHelper, err = parsePrivilegedHelperConfig(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Agent.KeyReuse != nil {
+		ac.KeyReuse, err = parseKeyReuseConfig(c.Agent.KeyReuse)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	logOptions = append(logOptions,
 		log.WithLevel(c.Agent.LogLevel),
 		log.WithFormat(c.Agent.LogFormat),
@@ -405,12 +592,18 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		return nil, err
 	}
 
+	ac.LockMemory = c.Agent.LockMemory
+
 	ac.ProfilingEnabled = c.Agent.ProfilingEnabled
 	ac.ProfilingPort = c.Agent.ProfilingPort
 	ac.ProfilingFreq = c.Agent.ProfilingFreq
 	ac.ProfilingNames = c.Agent.ProfilingNames
 
 	ac.PluginConfigs = *c.Plugins
+	ac.PluginPolicy, err = catalog.PluginPolicyFromHCL(c.Agent.PluginPolicy)
+	if err != nil {
+		return nil, err
+	}
 	ac.Telemetry = c.Telemetry
 	ac.HealthChecks = c.HealthChecks
 
@@ -423,6 +616,119 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 	return ac, nil
 }
 
+// parseServerResolverConfig returns the configuration controlling how the
+// server address is resolved, or nil if the default DNS resolution should be
+// used.
+func parseServerResolverConfig(c *Config) (*client.ResolverConfig, error) {
+	if len(c.Agent.ServerIPs) == 0 && c.Agent.DNSResolverAddress == "" && c.Agent.DNSMinResolveInterval == "" {
+		return nil, nil
+	}
+
+	config := &client.ResolverConfig{}
+	for _, serverIP := range c.Agent.ServerIPs {
+		ip := net.ParseIP(serverIP)
+		if ip == nil {
+			return nil, fmt.Errorf("could not parse server_ips entry %q", serverIP)
+		}
+		config.StaticAddrs = append(config.StaticAddrs, net.JoinHostPort(ip.String(), strconv.Itoa(c.Agent.ServerPort)))
+	}
+
+	if c.Agent.DNSResolverAddress != "" {
+		if len(config.StaticAddrs) > 0 {
+			return nil, errors.New("dns_resolver_address cannot be used with server_ips")
+		}
+		addr := c.Agent.DNSResolverAddress
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, "53")
+		}
+		config.DNSServer = addr
+	}
+
+	if c.Agent.DNSMinResolveInterval != "" {
+		if len(config.StaticAddrs) > 0 {
+			return nil, errors.New("dns_min_resolve_interval cannot be used with server_ips")
+		}
+		interval, err := time.ParseDuration(c.Agent.DNSMinResolveInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse dns_min_resolve_interval %q: %v", c.Agent.DNSMinResolveInterval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("dns_min_resolve_interval %q must be positive", c.Agent.DNSMinResolveInterval)
+		}
+		config.MinResolveInterval = interval
+	}
+
+	return config, nil
+}
+
+// parsePrivilegedHelperConfig returns the configuration of the workload
+// attestors run by the privileged helper.
+func parsePrivilegedHelperConfig(c *Config) (*agent.PrivilegedHelperConfig, error) {
+	helper := c.Agent.PrivilegedHelper
+	if helper.SocketPath == "" {
+		return nil, errors.New("privileged_helper socket_path must be configured")
+	}
+	if len(helper.WorkloadAttestors) == 0 {
+		return nil, errors.New("privileged_helper workload_attestors must be configured")
+	}
+
+	socketPathAbs, err := filepath.Abs(c.Agent.SocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for socket_path: %v", err)
+	}
+	helperSocketPathAbs, err := filepath.Abs(helper.SocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for privileged_helper socket_path: %v", err)
+	}
+	if strings.HasPrefix(helperSocketPathAbs, filepath.Dir(socketPathAbs)+"/") {
+		return nil, errors.New("privileged helper socket cannot be in the same directory or a subdirectory as that containing the Workload API socket")
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range helper.WorkloadAttestors {
+		if seen[name] {
+			return nil, fmt.Errorf("privileged_helper workload attestor %q is listed more than once", name)
+		}
+		seen[name] = true
+		if _, ok := (*c.Plugins)[workloadattestor.Type][name]; ok {
+			return nil, fmt.Errorf("workload attestor %q cannot be configured both as a plugin and in privileged_helper", name)
+		}
+	}
+
+	return &agent.PrivilegedHelperConfig{
+		BindAddress: &net.UnixAddr{
+			Name: helper.SocketPath,
+			Net:  "unix",
+		},
+		WorkloadAttestors: helper.WorkloadAttestors,
+	}, nil
+}
+
+// parseKeyReuseConfig returns the policy controlling the reuse of the agent
+// and workload SVID keys across renewals.
+func parseKeyReuseConfig(c *keyReuseConfig) (keyreuse.Policy, error) {
+	if c.MaxRenewals < 0 {
+		return keyreuse.Policy{}, errors.New("key_reuse max_renewals cannot be negative")
+	}
+
+	maxKeyAge := defaultMaxKeyAge
+	if c.MaxKeyAge != "" {
+		var err error
+		maxKeyAge, err = time.ParseDuration(c.MaxKeyAge)
+		if err != nil {
+			return keyreuse.Policy{}, fmt.Errorf("could not parse key_reuse max_key_age %q: %v", c.MaxKeyAge, err)
+		}
+		if maxKeyAge <= 0 {
+			return keyreuse.Policy{}, fmt.Errorf("key_reuse max_key_age %q must be positive", c.MaxKeyAge)
+		}
+	}
+
+	return keyreuse.Policy{
+		MaxRenewals: c.MaxRenewals,
+		MaxAge:      maxKeyAge,
+	}, nil
+}
+
 func validateConfig(c *Config) error {
 	if c.Agent == nil {
 		return errors.New("agent section must be configured")
@@ -462,6 +768,28 @@ func validateConfig(c *Config) error {
 			return errors.New("trust bundle URL must start with https://")
 		}
 	}
+
+	if c.Agent.TrustBundleSecondaryURL != "" {
+		if c.Agent.TrustBundlePath == "" && c.Agent.TrustBundleURL == "" {
+			return errors.New("trust_bundle_secondary_url requires trust_bundle_path or trust_bundle_url to be configured")
+		}
+		u, err := url.Parse(c.Agent.TrustBundleSecondaryURL)
+		if err != nil {
+			return fmt.Errorf("unable to parse secondary trust bundle URL: %v", err)
+		}
+		if u.Scheme != "https" {
+			return errors.New("secondary trust bundle URL must start with https://")
+		}
+	}
+
+	if limits := c.Agent.WorkloadAPILimits; limits.MaxConcurrentAttestations < 0 || limits.MaxStreams < 0 {
+		return errors.New("workload_api_limits values cannot be negative")
+	}
+
+	if c.Agent.WorkloadAPIAudit.MaxEventsPerSecond < 0 {
+		return errors.New("workload_api_audit max_events_per_second cannot be negative")
+	}
+
 	if c.Plugins == nil {
 		return errors.New("plugins section must be configured")
 	}
@@ -486,6 +814,30 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 		detectedUnknown("agent", a.UnusedKeys)
 	}
 
+	if a := c.Agent; a != nil && a.PrivilegedHelper != nil && len(a.PrivilegedHelper.UnusedKeys) != 0 {
+		detectedUnknown("privileged_helper", a.PrivilegedHelper.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && len(a.WorkloadAPILimits.UnusedKeys) != 0 {
+		detectedUnknown("workload_api_limits", a.WorkloadAPILimits.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.PluginPolicy != nil && len(a.PluginPolicy.UnusedKeys) != 0 {
+		detectedUnknown("plugin_policy", a.PluginPolicy.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && len(a.WorkloadAPIAudit.UnusedKeys) != 0 {
+		detectedUnknown("workload_api_audit", a.WorkloadAPIAudit.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.SelectorRedaction != nil && len(a.SelectorRedaction.UnusedKeys) != 0 {
+		detectedUnknown("selector_redaction", a.SelectorRedaction.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.KeyReuse != nil && len(a.KeyReuse.UnusedKeys) != 0 {
+		detectedUnknown("key_reuse", a.KeyReuse.UnusedKeys)
+	}
+
 	// TODO: Re-enable unused key detection for telemetry. See
 	// https://github.com/spiffe/spire/issues/1101 for more information
 	//
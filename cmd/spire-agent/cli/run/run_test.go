@@ -2,22 +2,31 @@ package run
 
 import (
 	"bytes"
+	"crypto/x509"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,6 +95,109 @@ func TestDownloadTrustBundle(t *testing.T) {
 	}
 }
 
+func TestSetupTrustBundle(t *testing.T) {
+	primaryPath := path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt")
+	primary, err := pemutil.LoadCertificates(primaryPath)
+	require.NoError(t, err)
+	newRoot := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org")).X509Authorities()[0]
+
+	bundleServer := func(status int, certs ...*x509.Certificate) string {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				_, _ = w.Write(pemutil.EncodeCertificates(certs))
+			}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	for _, tt := range []struct {
+		name         string
+		path         string
+		url          string
+		secondaryURL string
+		expectBundle []*x509.Certificate
+		expectErr    string
+		expectLogs   []spiretest.LogEntry
+	}{
+		{
+			name:         "path only",
+			path:         primaryPath,
+			expectBundle: primary,
+		},
+		{
+			name:         "path and secondary URL are merged",
+			path:         primaryPath,
+			secondaryURL: bundleServer(http.StatusOK, append([]*x509.Certificate{newRoot}, primary...)...),
+			expectBundle: append(append([]*x509.Certificate{}, primary...), newRoot),
+		},
+		{
+			name:         "URL and secondary URL are merged",
+			url:          bundleServer(http.StatusOK, primary...),
+			secondaryURL: bundleServer(http.StatusOK, newRoot),
+			expectBundle: append(append([]*x509.Certificate{}, primary...), newRoot),
+		},
+		{
+			name:         "URL fails and secondary URL succeeds",
+			url:          bundleServer(http.StatusNotFound),
+			secondaryURL: bundleServer(http.StatusOK, newRoot),
+			expectBundle: []*x509.Certificate{newRoot},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Failed to download trust bundle; relying on the secondary trust bundle URL",
+					Data:    logrus.Fields{logrus.ErrorKey: "error downloading trust bundle: 404 Not Found"},
+				},
+			},
+		},
+		{
+			name:         "secondary URL fails",
+			path:         primaryPath,
+			secondaryURL: bundleServer(http.StatusInternalServerError),
+			expectBundle: primary,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Failed to download trust bundle from the secondary trust bundle URL",
+					Data:    logrus.Fields{logrus.ErrorKey: "error downloading trust bundle: 500 Internal Server Error"},
+				},
+			},
+		},
+		{
+			name:         "all sources fail",
+			url:          bundleServer(http.StatusNotFound),
+			secondaryURL: bundleServer(http.StatusInternalServerError),
+			expectErr:    "unable to get trust bundle from any source: error downloading trust bundle: 404 Not Found; error downloading trust bundle: 500 Internal Server Error",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Failed to download trust bundle; relying on the secondary trust bundle URL",
+					Data:    logrus.Fields{logrus.ErrorKey: "error downloading trust bundle: 404 Not Found"},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			ac := &agent.Config{Log: log}
+			c := defaultValidConfig()
+			c.Agent.TrustBundlePath = tt.path
+			c.Agent.TrustBundleURL = tt.url
+			c.Agent.TrustBundleSecondaryURL = tt.secondaryURL
+
+			err := setupTrustBundle(ac, c)
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectBundle, ac.TrustBundle)
+		})
+	}
+}
+
 func TestParseConfigGood(t *testing.T) {
 	c, err := ParseFile("../../../../test/fixture/config/agent_good.conf", false)
 	require.NoError(t, err)
@@ -628,6 +740,78 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Equal(t, "dns:///192.168.1.1:1337", c.ServerAddress)
 			},
 		},
+		{
+			msg: "server_address uses the default DNS resolution",
+			input: func(c *Config) {
+				c.Agent.ServerAddress = "spire-server"
+				c.Agent.ServerPort = 8081
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "dns:///spire-server:8081", c.ServerAddress)
+				require.Nil(t, c.ServerResolver)
+			},
+		},
+		{
+			msg: "server_ips pins the server addresses",
+			input: func(c *Config) {
+				c.Agent.ServerAddress = "spire-server"
+				c.Agent.ServerPort = 8081
+				c.Agent.ServerIPs = []string{"10.0.0.1", "::1"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "spire-server:///spire-server:8081", c.ServerAddress)
+				require.Equal(t, &client.ResolverConfig{
+					StaticAddrs: []string{"10.0.0.1:8081", "[::1]:8081"},
+				}, c.ServerResolver)
+			},
+		},
+		{
+			msg:         "invalid server_ips should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerIPs = []string{"spire-server"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "dns_resolver_address and dns_min_resolve_interval configure the DNS resolution",
+			input: func(c *Config) {
+				c.Agent.ServerAddress = "spire-server"
+				c.Agent.ServerPort = 8081
+				c.Agent.DNSResolverAddress = "10.0.0.53"
+				c.Agent.DNSMinResolveInterval = "10s"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "spire-server:///spire-server:8081", c.ServerAddress)
+				require.Equal(t, &client.ResolverConfig{
+					DNSServer:          "10.0.0.53:53",
+					MinResolveInterval: 10 * time.Second,
+				}, c.ServerResolver)
+			},
+		},
+		{
+			msg:         "dns_resolver_address cannot be used with server_ips",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerIPs = []string{"10.0.0.1"}
+				c.Agent.DNSResolverAddress = "10.0.0.53:53"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid dns_min_resolve_interval should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.DNSMinResolveInterval = "-1s"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "trust_domain should be correctly parsed",
 			input: func(c *Config) {
@@ -675,6 +859,22 @@ func TestNewAgentConfig(t *testing.T) {
 				require.True(t, c.InsecureBootstrap)
 			},
 		},
+		{
+			msg:   "lock_memory is disabled by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.False(t, c.LockMemory)
+			},
+		},
+		{
+			msg: "lock_memory should be correctly configured",
+			input: func(c *Config) {
+				c.Agent.LockMemory = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.LockMemory)
+			},
+		},
 		{
 			msg: "join_token should be correctly configured",
 			input: func(c *Config) {
@@ -732,6 +932,28 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg:         "trust_bundle_secondary_url requires a primary trust bundle source",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundlePath = ""
+				c.Agent.InsecureBootstrap = true
+				c.Agent.TrustBundleSecondaryURL = "https://example.org/bundle"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "trust_bundle_secondary_url must use https",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundleSecondaryURL = "http://example.org/bundle"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "insecure_bootstrap and trust_bundle_url cannot both be set",
 			expectError: true,
@@ -782,6 +1004,401 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "bundle_sync_interval parses a duration",
+			input: func(c *Config) {
+				c.Agent.Experimental.BundleSyncInterval = "30s"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 30*time.Second, c.BundleSyncInterval)
+			},
+		},
+		{
+			msg:         "invalid bundle_sync_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.BundleSyncInterval = "moo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "max_sync_interval parses a duration",
+			input: func(c *Config) {
+				c.Agent.Experimental.SyncInterval = "5s"
+				c.Agent.Experimental.MaxSyncInterval = "1m"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 5*time.Second, c.SyncInterval)
+				require.Equal(t, time.Minute, c.MaxSyncInterval)
+			},
+		},
+		{
+			msg:         "invalid max_sync_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.MaxSyncInterval = "moo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "max_sync_interval lower than sync_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.SyncInterval = "1m"
+				c.Agent.Experimental.MaxSyncInterval = "5s"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "hedge_delay parses a duration",
+			input: func(c *Config) {
+				c.Agent.Experimental.HedgeDelay = "500ms"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 500*time.Millisecond, c.HedgeDelay)
+			},
+		},
+		{
+			msg:         "invalid hedge_delay returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.HedgeDelay = "moo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "workload_api_limits are correctly configured",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPILimits.MaxConcurrentAttestations = 16
+				c.Agent.WorkloadAPILimits.AttestationQueueTimeout = "500ms"
+				c.Agent.WorkloadAPILimits.MaxStreams = 1024
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 16, c.WorkloadAPILimits.MaxConcurrentAttestations)
+				require.Equal(t, 500*time.Millisecond, c.WorkloadAPILimits.AttestationQueueTimeout)
+				require.Equal(t, 1024, c.WorkloadAPILimits.MaxStreams)
+			},
+		},
+		{
+			msg: "workload_api_limits are not enforced by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Zero(t, c.WorkloadAPILimits)
+			},
+		},
+		{
+			msg:         "invalid attestation_queue_timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPILimits.AttestationQueueTimeout = "moo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative workload_api_limits return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPILimits.MaxStreams = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "workload_api_audit is correctly configured",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIAudit.Enabled = true
+				c.Agent.WorkloadAPIAudit.MaxEventsPerSecond = 10
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.WorkloadAPIAudit.Enabled)
+				require.Equal(t, 10, c.WorkloadAPIAudit.MaxEventsPerSecond)
+			},
+		},
+		{
+			msg: "workload_api_audit is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Zero(t, c.WorkloadAPIAudit)
+			},
+		},
+		{
+			msg:         "negative workload_api_audit max_events_per_second returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIAudit.MaxEventsPerSecond = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "selector_redaction is correctly configured",
+			input: func(c *Config) {
+				c.Agent.SelectorRedaction = &selectorRedactionConfig{
+					Mask: []string{"docker:label:secret"},
+					Hash: []string{"unix:path:"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.NotNil(t, c.SelectorRedactor)
+				require.Equal(t, c.SelectorRedactor, c.WorkloadAPIAudit.SelectorRedactor)
+				require.Equal(t, "docker:label:secret[REDACTED]", c.SelectorRedactor.Redact(&common.Selector{Type: "docker", Value: "label:secret_key:value"}))
+				require.Regexp(t, `^unix:path:sha256:`, c.SelectorRedactor.Redact(&common.Selector{Type: "unix", Value: "path:/bin/app"}))
+			},
+		},
+		{
+			msg: "selector_redaction is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.SelectorRedactor)
+			},
+		},
+		{
+			msg:         "invalid selector_redaction prefix returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SelectorRedaction = &selectorRedactionConfig{
+					Mask: []string{"docker"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "privileged_helper is correctly configured",
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/tmp/workload/workload.sock"
+				c.Agent.PrivilegedHelper = &privilegedHelperConfig{
+					SocketPath:        "/tmp/helper/helper.sock",
+					WorkloadAttestors: []string{"docker", "unix"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, &agent.PrivilegedHelperConfig{
+					BindAddress:       &net.UnixAddr{Name: "/tmp/helper/helper.sock", Net: "unix"},
+					WorkloadAttestors: []string{"docker", "unix"},
+				}, c.PrivilegedHelper)
+			},
+		},
+		{
+			msg: "privileged_helper is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.PrivilegedHelper)
+			},
+		},
+		{
+			msg:         "privileged_helper requires socket_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.PrivilegedHelper = &privilegedHelperConfig{
+					WorkloadAttestors: []string{"docker"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "privileged_helper requires workload_attestors",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.PrivilegedHelper = &privilegedHelperConfig{
+					SocketPath: "/tmp/helper/helper.sock",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "privileged_helper socket cannot be in the Workload API socket directory",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/tmp/workload/workload.sock"
+				c.Agent.PrivilegedHelper = &privilegedHelperConfig{
+					SocketPath:        "/tmp/workload/helper.sock",
+					WorkloadAttestors: []string{"docker"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "privileged_helper workload attestors cannot also be configured as plugins",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/tmp/workload/workload.sock"
+				c.Agent.PrivilegedHelper = &privilegedHelperConfig{
+					SocketPath:        "/tmp/helper/helper.sock",
+					WorkloadAttestors: []string{"docker"},
+				}
+				c.Plugins = &catalog.HCLPluginConfigMap{
+					"WorkloadAttestor": {"docker": {}},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "key_reuse is correctly configured",
+			input: func(c *Config) {
+				c.Agent.KeyReuse = &keyReuseConfig{
+					MaxRenewals: 3,
+					MaxKeyAge:   "12h",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keyreuse.Policy{MaxRenewals: 3, MaxAge: 12 * time.Hour}, c.KeyReuse)
+			},
+		},
+		{
+			msg: "key_reuse max_key_age defaults to 24h",
+			input: func(c *Config) {
+				c.Agent.KeyReuse = &keyReuseConfig{
+					MaxRenewals: 3,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keyreuse.Policy{MaxRenewals: 3, MaxAge: 24 * time.Hour}, c.KeyReuse)
+			},
+		},
+		{
+			msg: "key_reuse is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Zero(t, c.KeyReuse)
+			},
+		},
+		{
+			msg:         "key_reuse max_renewals cannot be negative",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.KeyReuse = &keyReuseConfig{
+					MaxRenewals: -1,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid key_reuse max_key_age returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.KeyReuse = &keyReuseConfig{
+					MaxRenewals: 3,
+					MaxKeyAge:   "forever",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "key_reuse max_key_age must be positive",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.KeyReuse = &keyReuseConfig{
+					MaxRenewals: 3,
+					MaxKeyAge:   "-1h",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "plugin_policy is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.PluginPolicy = &catalog.HCLPluginPolicy{
+					Allow:           []string{"WorkloadAttestor", "NodeAttestor:k8s_psat"},
+					Deny:            []string{"WorkloadAttestor:docker"},
+					RequireChecksum: true,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, catalog.PluginPolicy{
+					Allow:           []string{"WorkloadAttestor", "NodeAttestor:k8s_psat"},
+					Deny:            []string{"WorkloadAttestor:docker"},
+					RequireChecksum: true,
+				}, c.PluginPolicy)
+			},
+		},
+		{
+			msg:         "invalid plugin_policy reference returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.PluginPolicy = &catalog.HCLPluginPolicy{
+					Allow: []string{":docker"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "socket permissions are correctly configured",
+			input: func(c *Config) {
+				c.Agent.SocketMode = "0770"
+				c.Agent.SocketOwner = "spire"
+				c.Agent.SocketGroup = "workloads"
+				c.Agent.SocketSELinuxContext = "system_u:object_r:spire_agent_sock_t:s0"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, os.FileMode(0770), c.WorkloadAPISocket.Mode)
+				require.Equal(t, "spire", c.WorkloadAPISocket.Owner)
+				require.Equal(t, "workloads", c.WorkloadAPISocket.Group)
+				require.Equal(t, "system_u:object_r:spire_agent_sock_t:s0", c.WorkloadAPISocket.SELinuxContext)
+			},
+		},
+		{
+			msg: "socket permissions are left to the defaults when not configured",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Zero(t, c.WorkloadAPISocket)
+			},
+		},
+		{
+			msg:         "invalid socket_mode returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketMode = "rw-rw----"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "out of range socket_mode returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketMode = "1777"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "admin_socket_path should be correctly configured",
 			input: func(c *Config) {
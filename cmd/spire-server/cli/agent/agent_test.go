@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
@@ -106,6 +107,14 @@ func TestListHelp(t *testing.T) {
 
 	test.client.Help()
 	require.Equal(t, `Usage of agent list:
+  -attestationType string
+    	List only the agents attested with this attestation type
+  -banned string
+    	List only the agents that are banned (true) or not banned (false)
+  -expiresAfter string
+    	List only the agents whose SVID expires at or after this time, in RFC3339 format
+  -expiresBefore string
+    	List only the agents whose SVID expires before this time, in RFC3339 format
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, test.stderr.String())
@@ -118,7 +127,9 @@ func TestList(t *testing.T) {
 		expectedReturnCode int
 		expectedStdout     string
 		expectedStderr     string
+		expectedFilter     *agentpb.ListAgentsRequest_Filter
 		existentAgents     []*types.Agent
+		secondPage         []*types.Agent
 		serverErr          error
 	}{
 		{
@@ -126,16 +137,63 @@ func TestList(t *testing.T) {
 			expectedReturnCode: 0,
 			existentAgents:     testAgents,
 			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedFilter:     &agentpb.ListAgentsRequest_Filter{},
 		},
 		{
 			name:               "no agents",
 			expectedReturnCode: 0,
+			expectedFilter:     &agentpb.ListAgentsRequest_Filter{},
+		},
+		{
+			name:               "multiple pages",
+			expectedReturnCode: 0,
+			existentAgents:     testAgents,
+			secondPage:         []*types.Agent{{Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent2"}}},
+			expectedStdout:     "Found 2 attested agents:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedFilter:     &agentpb.ListAgentsRequest_Filter{},
+		},
+		{
+			name: "with filters",
+			args: []string{
+				"-attestationType", "join_token",
+				"-banned", "false",
+				"-expiresAfter", "2021-01-01T00:00:00Z",
+				"-expiresBefore", "2021-01-02T00:00:00Z",
+			},
+			expectedReturnCode: 0,
+			existentAgents:     testAgents,
+			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedFilter: &agentpb.ListAgentsRequest_Filter{
+				ByAttestationType:  "join_token",
+				ByBanned:           wrapperspb.Bool(false),
+				ByExpiresAtOrAfter: wrapperspb.Int64(1609459200),
+				ByExpiresBefore:    wrapperspb.Int64(1609545600),
+			},
+		},
+		{
+			name:               "invalid banned",
+			args:               []string{"-banned", "maybe"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: the -banned flag must be true or false\n",
+		},
+		{
+			name:               "invalid expires before",
+			args:               []string{"-expiresBefore", "tomorrow"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: invalid -expiresBefore flag: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\"\n",
+		},
+		{
+			name:               "invalid expiry window",
+			args:               []string{"-expiresAfter", "2021-01-02T00:00:00Z", "-expiresBefore", "2021-01-01T00:00:00Z"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: the -expiresAfter flag must be before the -expiresBefore flag\n",
 		},
 		{
 			name:               "server error",
 			expectedReturnCode: 1,
 			serverErr:          status.Error(codes.Internal, "internal server error"),
 			expectedStderr:     "Error: rpc error: code = Internal desc = internal server error\n",
+			expectedFilter:     &agentpb.ListAgentsRequest_Filter{},
 		},
 		{
 			name:               "wrong UDS path",
@@ -148,11 +206,13 @@ func TestList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			test := setupTest(t, agent.NewListCommandWithEnv)
 			test.server.agents = tt.existentAgents
+			test.server.secondPage = tt.secondPage
 			test.server.err = tt.serverErr
 			returnCode := test.client.Run(append(test.args, tt.args...))
 			require.Contains(t, test.stdout.String(), tt.expectedStdout)
 			require.Equal(t, tt.expectedStderr, test.stderr.String())
 			require.Equal(t, tt.expectedReturnCode, returnCode)
+			spiretest.AssertProtoEqual(t, tt.expectedFilter, test.server.lastFilter)
 		})
 	}
 }
@@ -256,8 +316,10 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *agent
 type fakeAgentServer struct {
 	agentpb.UnimplementedAgentServer
 
-	agents []*types.Agent
-	err    error
+	agents     []*types.Agent
+	secondPage []*types.Agent
+	lastFilter *agentpb.ListAgentsRequest_Filter
+	err        error
 }
 
 func (s *fakeAgentServer) DeleteAgent(ctx context.Context, req *agentpb.DeleteAgentRequest) (*emptypb.Empty, error) {
@@ -265,9 +327,24 @@ func (s *fakeAgentServer) DeleteAgent(ctx context.Context, req *agentpb.DeleteAg
 }
 
 func (s *fakeAgentServer) ListAgents(ctx context.Context, req *agentpb.ListAgentsRequest) (*agentpb.ListAgentsResponse, error) {
-	return &agentpb.ListAgentsResponse{
+	s.lastFilter = req.Filter
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	// The second page, if any, is returned for the token of the first one
+	if req.PageToken == "page2" {
+		return &agentpb.ListAgentsResponse{
+			Agents: s.secondPage,
+		}, nil
+	}
+	resp := &agentpb.ListAgentsResponse{
 		Agents: s.agents,
-	}, s.err
+	}
+	if len(s.secondPage) > 0 {
+		resp.NextPageToken = "page2"
+	}
+	return resp, nil
 }
 
 func (s *fakeAgentServer) GetAgent(ctx context.Context, req *agentpb.GetAgentRequest) (*types.Agent, error) {
@@ -1,8 +1,10 @@
 package agent
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/mitchellh/cli"
@@ -12,11 +14,25 @@ import (
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"golang.org/x/net/context"
 )
 
-type listCommand struct{}
+// listPageSize is the number of agents requested per page
+const listPageSize = 1000
+
+type listCommand struct {
+	// Attestation type of the agents to list
+	attestationType string
+
+	// Banned state of the agents to list, if set
+	banned string
+
+	// Expiry window of the agents to list, in RFC3339 format
+	expiresBefore string
+	expiresAfter  string
+}
 
 // NewListCommand creates a new "list" subcommand for "agent" command.
 func NewListCommand() cli.Command {
@@ -39,24 +55,84 @@ func (listCommand) Synopsis() string {
 
 //Run lists attested agents
 func (c *listCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
-	agentClient := serverClient.NewAgentClient()
-	listResponse, err := agentClient.ListAgents(ctx, &agent.ListAgentsRequest{})
+	filter, err := c.filter()
 	if err != nil {
 		return err
 	}
 
-	if len(listResponse.Agents) == 0 {
+	agentClient := serverClient.NewAgentClient()
+	var agents []*types.Agent
+	pageToken := ""
+	for {
+		listResponse, err := agentClient.ListAgents(ctx, &agent.ListAgentsRequest{
+			Filter:    filter,
+			PageSize:  listPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		agents = append(agents, listResponse.Agents...)
+		pageToken = listResponse.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if len(agents) == 0 {
 		return env.Printf("No attested agents found\n")
 	}
 
-	msg := fmt.Sprintf("Found %d attested ", len(listResponse.Agents))
-	msg = util.Pluralizer(msg, "agent", "agents", len(listResponse.Agents))
+	msg := fmt.Sprintf("Found %d attested ", len(agents))
+	msg = util.Pluralizer(msg, "agent", "agents", len(agents))
 	env.Printf(msg + ":\n\n")
 
-	return printAgents(env, listResponse.Agents...)
+	return printAgents(env, agents...)
 }
 
 func (c *listCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.attestationType, "attestationType", "", "List only the agents attested with this attestation type")
+	fs.StringVar(&c.banned, "banned", "", "List only the agents that are banned (true) or not banned (false)")
+	fs.StringVar(&c.expiresBefore, "expiresBefore", "", "List only the agents whose SVID expires before this time, in RFC3339 format")
+	fs.StringVar(&c.expiresAfter, "expiresAfter", "", "List only the agents whose SVID expires at or after this time, in RFC3339 format")
+}
+
+// filter returns the filter of the agents to list from the flags
+func (c *listCommand) filter() (*agent.ListAgentsRequest_Filter, error) {
+	filter := &agent.ListAgentsRequest_Filter{
+		ByAttestationType: c.attestationType,
+	}
+
+	if c.banned != "" {
+		banned, err := strconv.ParseBool(c.banned)
+		if err != nil {
+			return nil, errors.New("the -banned flag must be true or false")
+		}
+		filter.ByBanned = wrapperspb.Bool(banned)
+	}
+
+	if c.expiresBefore != "" {
+		expiresBefore, err := time.Parse(time.RFC3339, c.expiresBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -expiresBefore flag: %w", err)
+		}
+		filter.ByExpiresBefore = wrapperspb.Int64(expiresBefore.Unix())
+	}
+
+	if c.expiresAfter != "" {
+		expiresAfter, err := time.Parse(time.RFC3339, c.expiresAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -expiresAfter flag: %w", err)
+		}
+		filter.ByExpiresAtOrAfter = wrapperspb.Int64(expiresAfter.Unix())
+	}
+
+	if filter.ByExpiresBefore != nil && filter.ByExpiresAtOrAfter != nil &&
+		filter.ByExpiresAtOrAfter.Value >= filter.ByExpiresBefore.Value {
+		return nil, errors.New("the -expiresAfter flag must be before the -expiresBefore flag")
+	}
+
+	return filter, nil
 }
 
 func printAgents(env *common_cli.Env, agents ...*types.Agent) error {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
@@ -654,3 +655,80 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagationHelp(t *testing.T) {
+	test := setupTest(t, newPropagationCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of bundle propagation:
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestPropagationSynopsis(t *testing.T) {
+	test := setupTest(t, newPropagationCommand)
+	require.Equal(t, "Prints how many agents have synced the current server CA bundle", test.client.Synopsis())
+}
+
+func TestPropagation(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		resp          *debug.GetInfoResponse
+		serverErr     error
+		expectedOut   string
+		expectedError string
+	}{
+		{
+			name: "all agents synced",
+			resp: &debug.GetInfoResponse{
+				AgentsCount:                     3,
+				BundleDigest:                    "abcd",
+				InstanceSyncedAgentsCount:       2,
+				InstanceBundleSyncedAgentsCount: 2,
+			},
+			expectedOut: `Bundle digest: abcd
+Attested agents: 3
+Agents synced with this server since it started: 2
+Agents synced with this server that have the current bundle: 2
+All agents synced with this server have the current bundle
+`,
+		},
+		{
+			name: "some agents pending",
+			resp: &debug.GetInfoResponse{
+				AgentsCount:                     3,
+				BundleDigest:                    "abcd",
+				InstanceSyncedAgentsCount:       3,
+				InstanceBundleSyncedAgentsCount: 1,
+			},
+			expectedOut: `Bundle digest: abcd
+Attested agents: 3
+Agents synced with this server since it started: 3
+Agents synced with this server that have the current bundle: 1
+2 agent(s) synced with this server have not synced the current bundle yet
+`,
+		},
+		{
+			name:          "server fails",
+			serverErr:     errors.New("some error"),
+			expectedError: "Error: rpc error: code = Unknown desc = some error\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newPropagationCommand)
+			test.debugServer.resp = tt.resp
+			test.debugServer.err = tt.serverErr
+
+			rc := test.client.Run(test.args)
+			if tt.expectedError != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expectedError, test.stderr.String())
+				return
+			}
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expectedOut, test.stdout.String())
+		})
+	}
+}
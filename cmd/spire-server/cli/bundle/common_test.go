@@ -10,6 +10,7 @@ import (
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
@@ -182,9 +183,11 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *bundl
 	require.NoError(t, err)
 
 	server := &fakeBundleServer{t: t}
+	debugServer := &fakeDebugServer{}
 
 	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
 		bundle.RegisterBundleServer(s, server)
+		debug.RegisterDebugServer(s, debugServer)
 	})
 
 	stdin := new(bytes.Buffer)
@@ -198,15 +201,16 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *bundl
 	})
 
 	test := &bundleTest{
-		cert1:    cert1,
-		cert2:    cert2,
-		key1Pkix: key1Pkix,
-		stdin:    stdin,
-		stdout:   stdout,
-		stderr:   stderr,
-		args:     []string{"-registrationUDSPath", socketPath},
-		server:   server,
-		client:   client,
+		cert1:       cert1,
+		cert2:       cert2,
+		key1Pkix:    key1Pkix,
+		stdin:       stdin,
+		stdout:      stdout,
+		stderr:      stderr,
+		args:        []string{"-registrationUDSPath", socketPath},
+		server:      server,
+		debugServer: debugServer,
+		client:      client,
 	}
 
 	t.Cleanup(func() {
@@ -225,8 +229,9 @@ type bundleTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	args   []string
-	server *fakeBundleServer
+	args        []string
+	server      *fakeBundleServer
+	debugServer *fakeDebugServer
 
 	client cli.Command
 }
@@ -304,3 +309,17 @@ func (f *fakeBundleServer) BatchDeleteFederatedBundle(ctx context.Context, req *
 		Results: f.deleteResults,
 	}, nil
 }
+
+type fakeDebugServer struct {
+	debug.DebugServer
+
+	resp *debug.GetInfoResponse
+	err  error
+}
+
+func (f *fakeDebugServer) GetInfo(ctx context.Context, in *debug.GetInfoRequest) (*debug.GetInfoResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.resp, nil
+}
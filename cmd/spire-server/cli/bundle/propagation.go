@@ -0,0 +1,60 @@
+package bundle
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+)
+
+// NewPropagationCommand creates a new "propagation" subcommand for "bundle" command.
+func NewPropagationCommand() cli.Command {
+	return newPropagationCommand(common_cli.DefaultEnv)
+}
+
+func newPropagationCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(propagationCommand))
+}
+
+type propagationCommand struct{}
+
+func (c *propagationCommand) Name() string {
+	return "bundle propagation"
+}
+
+func (c *propagationCommand) Synopsis() string {
+	return "Prints how many agents have synced the current server CA bundle"
+}
+
+func (c *propagationCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (c *propagationCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	debugClient := serverClient.NewDebugClient()
+	resp, err := debugClient.GetInfo(ctx, &debug.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	if err := env.Printf("Bundle digest: %s\n", resp.BundleDigest); err != nil {
+		return err
+	}
+	if err := env.Printf("Attested agents: %d\n", resp.AgentsCount); err != nil {
+		return err
+	}
+	// The server only knows about the agents that synced with it since it
+	// started, so the counts below do not cover the other servers.
+	if err := env.Printf("Agents synced with this server since it started: %d\n", resp.InstanceSyncedAgentsCount); err != nil {
+		return err
+	}
+	if err := env.Printf("Agents synced with this server that have the current bundle: %d\n", resp.InstanceBundleSyncedAgentsCount); err != nil {
+		return err
+	}
+	if resp.InstanceBundleSyncedAgentsCount < resp.InstanceSyncedAgentsCount {
+		return env.Printf("%d agent(s) synced with this server have not synced the current bundle yet\n", resp.InstanceSyncedAgentsCount-resp.InstanceBundleSyncedAgentsCount)
+	}
+	return env.Println("All agents synced with this server have the current bundle")
+}
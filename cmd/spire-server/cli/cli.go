@@ -6,13 +6,17 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/datastore"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
+	"github.com/spiffe/spire/cmd/spire-server/cli/experimental"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
+	"github.com/spiffe/spire/cmd/spire-server/cli/logger"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
 	"github.com/spiffe/spire/cmd/spire-server/cli/validate"
 	"github.com/spiffe/spire/cmd/spire-server/cli/x509"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -25,7 +29,11 @@ type CLI struct {
 func (cc *CLI) Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
+	c.Autocomplete = true
 	c.Commands = map[string]cli.CommandFactory{
+		"completion": func() (cli.Command, error) {
+			return common_cli.NewCompletionCommand("spire-server"), nil
+		},
 		"agent evict": func() (cli.Command, error) {
 			return agent.NewEvictCommand(), nil
 		},
@@ -47,6 +55,9 @@ func (cc *CLI) Run(args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"bundle propagation": func() (cli.Command, error) {
+			return bundle.NewPropagationCommand(), nil
+		},
 		"entry create": func() (cli.Command, error) {
 			return entry.NewCreateCommand(), nil
 		},
@@ -59,12 +70,39 @@ func (cc *CLI) Run(args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return entry.NewShowCommand(), nil
 		},
+		"logger show": func() (cli.Command, error) {
+			return logger.NewShowCommand(), nil
+		},
+		"logger set": func() (cli.Command, error) {
+			return logger.NewSetCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(cc.LogOptions, cc.AllowUnknownConfig), nil
 		},
 		"token generate": func() (cli.Command, error) {
 			return token.NewGenerateCommand(), nil
 		},
+		"token list": func() (cli.Command, error) {
+			return token.NewListCommand(), nil
+		},
+		"token prune": func() (cli.Command, error) {
+			return token.NewPruneCommand(), nil
+		},
+		"datastore export": func() (cli.Command, error) {
+			return datastore.NewExportCommand(), nil
+		},
+		"datastore import": func() (cli.Command, error) {
+			return datastore.NewImportCommand(), nil
+		},
+		"datastore check": func() (cli.Command, error) {
+			return datastore.NewCheckCommand(), nil
+		},
+		"datastore check-bundles": func() (cli.Command, error) {
+			return datastore.NewCheckBundlesCommand(), nil
+		},
+		"experimental loadtest": func() (cli.Command, error) {
+			return experimental.NewLoadTestCommand(), nil
+		},
 		"healthcheck": func() (cli.Command, error) {
 			return healthcheck.NewHealthCheckCommand(), nil
 		},
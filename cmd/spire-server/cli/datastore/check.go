@@ -0,0 +1,97 @@
+package datastore
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+const checkCommandName = "datastore check"
+
+func NewCheckCommand() cli.Command {
+	return newCheckCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newCheckCommand(env *common_cli.Env, loader dataStoreLoader) *checkCommand {
+	return &checkCommand{
+		env:    env,
+		loader: loader,
+	}
+}
+
+type checkCommand struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+
+	configFlags
+	fix bool
+}
+
+func (c *checkCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *checkCommand) Synopsis() string {
+	return "Checks the datastore for orphaned rows and other inconsistencies"
+}
+
+func (c *checkCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	unresolved, err := c.run(context.Background())
+	if err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Failed to check datastore: %v\n", err)
+		return 1
+	}
+	if unresolved > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c *checkCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet(checkCommandName, flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	c.configFlags.addFlags(fs)
+	fs.BoolVar(&c.fix, "fix", false, "Fix the issues found instead of only reporting them")
+	return fs.Parse(args)
+}
+
+// run checks the datastore and returns the number of issues that were not
+// fixed.
+func (c *checkCommand) run(ctx context.Context) (int, error) {
+	ds, err := c.loader(ctx, checkCommandName, c.configPath, c.expandEnv, c.env.Stderr)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := ds.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{
+		Repair: c.fix,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var fixed int
+	for _, issue := range resp.Issues {
+		if issue.Repaired {
+			fixed++
+		}
+		if err := c.env.Printf("%s: %s\n", issue.Kind, issue.Description); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := c.env.Printf("%d issues found, %d fixed.\n", len(resp.Issues), fixed); err != nil {
+		return 0, err
+	}
+	return len(resp.Issues) - fixed, nil
+}
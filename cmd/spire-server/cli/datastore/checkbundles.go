@@ -0,0 +1,300 @@
+package datastore
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/protobuf/proto"
+)
+
+const checkBundlesCommandName = "datastore check-bundles"
+
+func NewCheckBundlesCommand() cli.Command {
+	return newCheckBundlesCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newCheckBundlesCommand(env *common_cli.Env, loader dataStoreLoader) *checkBundlesCommand {
+	return &checkBundlesCommand{
+		env:    env,
+		loader: loader,
+		now:    time.Now,
+	}
+}
+
+type checkBundlesCommand struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+	now    func() time.Time
+
+	configFlags
+	fix bool
+}
+
+func (c *checkBundlesCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *checkBundlesCommand) Synopsis() string {
+	return "Checks the bundles stored in the datastore for legacy or malformed data"
+}
+
+func (c *checkBundlesCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	unresolved, err := c.run(context.Background())
+	if err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Failed to check bundles: %v\n", err)
+		return 1
+	}
+	if unresolved > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c *checkBundlesCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet(checkBundlesCommandName, flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	c.configFlags.addFlags(fs)
+	fs.BoolVar(&c.fix, "fix", false, "Fix the issues found, when possible, instead of only reporting them")
+	return fs.Parse(args)
+}
+
+// run checks every stored bundle and returns the number of issues that were
+// not fixed.
+func (c *checkBundlesCommand) run(ctx context.Context) (int, error) {
+	ds, err := c.loader(ctx, checkBundlesCommandName, c.configPath, c.expandEnv, c.env.Stderr)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	var found, fixed int
+	for _, bundle := range resp.Bundles {
+		check := c.checkBundle(bundle)
+		if len(check.issues) == 0 {
+			continue
+		}
+		found += len(check.issues)
+		for _, issue := range check.issues {
+			if err := c.env.Printf("%s: %s\n", bundle.TrustDomainId, issue); err != nil {
+				return 0, err
+			}
+		}
+		if !c.fix || check.fixable == 0 {
+			continue
+		}
+
+		if err := c.fixBundle(ctx, ds, bundle, check); err != nil {
+			if err := c.env.Printf("%s: failed to fix bundle: %v\n", bundle.TrustDomainId, err); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if check.remove {
+			// Removing the bundle resolves every issue
+			fixed += len(check.issues)
+		} else {
+			fixed += check.fixable
+		}
+		if err := c.env.Printf("%s: %s\n", bundle.TrustDomainId, check.fixDescription()); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := c.env.Printf("Checked %d bundles: %d issues found, %d fixed.\n", len(resp.Bundles), found, fixed); err != nil {
+		return 0, err
+	}
+	return found - fixed, nil
+}
+
+// bundleCheck holds the issues found in a bundle and how to fix them
+type bundleCheck struct {
+	issues []string
+
+	// fixable is the number of issues that can be fixed
+	fixable int
+
+	// remove is true if the bundle must be removed since its trust domain
+	// ID cannot be parsed
+	remove bool
+
+	// fixed is the fixed bundle, if the bundle can be fixed
+	fixed *common.Bundle
+}
+
+func (c *bundleCheck) addIssue(fixable bool, format string, args ...interface{}) {
+	c.issues = append(c.issues, fmt.Sprintf(format, args...))
+	if fixable {
+		c.fixable++
+	}
+}
+
+func (c *bundleCheck) fixDescription() string {
+	if c.remove {
+		return "bundle removed"
+	}
+	return "bundle fixed"
+}
+
+func (c *checkBundlesCommand) checkBundle(bundle *common.Bundle) *bundleCheck {
+	check := &bundleCheck{}
+	fixed := proto.Clone(bundle).(*common.Bundle)
+
+	td, err := spiffeid.TrustDomainFromString(bundle.TrustDomainId)
+	switch {
+	case err != nil:
+		check.addIssue(true, "malformed trust domain ID: %v", err)
+		check.remove = true
+	case td.IDString() != bundle.TrustDomainId:
+		check.addIssue(true, "non-canonical trust domain ID; expected %q", td.IDString())
+		fixed.TrustDomainId = td.IDString()
+	}
+
+	fixed.RootCas = nil
+	seenRootCAs := make(map[string]bool)
+	var expired, unexpired int
+	for i, rootCA := range bundle.RootCas {
+		certs, canonical, err := parseStoredCertificates(rootCA.DerBytes)
+		switch {
+		case err != nil:
+			check.addIssue(true, "malformed X.509 authority %d is dropped: %v", i, err)
+			continue
+		case !canonical:
+			check.addIssue(true, "X.509 authority %d is not stored as a single DER certificate", i)
+		}
+		for _, cert := range certs {
+			if seenRootCAs[string(cert.Raw)] {
+				check.addIssue(true, "duplicate X.509 authority %d is dropped", i)
+				continue
+			}
+			seenRootCAs[string(cert.Raw)] = true
+			fixed.RootCas = append(fixed.RootCas, &common.Certificate{DerBytes: cert.Raw})
+			if cert.NotAfter.Before(c.now()) {
+				expired++
+			} else {
+				unexpired++
+			}
+		}
+	}
+
+	fixed.JwtSigningKeys = nil
+	seenKeys := make(map[string]bool)
+	for _, key := range bundle.JwtSigningKeys {
+		if _, err := x509.ParsePKIXPublicKey(key.PkixBytes); err != nil {
+			check.addIssue(true, "malformed JWT authority %q is dropped: %v", key.Kid, err)
+			continue
+		}
+		id := key.Kid + "\x00" + string(key.PkixBytes)
+		if seenKeys[id] {
+			check.addIssue(true, "duplicate JWT authority %q is dropped", key.Kid)
+			continue
+		}
+		seenKeys[id] = true
+		fixed.JwtSigningKeys = append(fixed.JwtSigningKeys, key)
+		if key.NotAfter != 0 && key.NotAfter < c.now().Unix() {
+			expired++
+		} else {
+			unexpired++
+		}
+	}
+
+	// Expired authorities are not removed, since they may still be needed
+	// (e.g. to validate an old SVID), and a bundle without authorities is not
+	// useful either. Operators are left to decide what to do.
+	if expired > 0 && unexpired == 0 {
+		check.addIssue(false, "every authority has expired; update or delete the bundle")
+	}
+
+	if check.fixable > 0 && !check.remove {
+		check.fixed = fixed
+	}
+	return check
+}
+
+func (c *checkBundlesCommand) fixBundle(ctx context.Context, ds datastore.DataStore, bundle *common.Bundle, check *bundleCheck) error {
+	if check.remove {
+		// Registration entries federating with the bundle are not dissociated
+		// silently; the bundle must then be deleted by the operator.
+		_, err := ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+			TrustDomainId: bundle.TrustDomainId,
+			Mode:          datastore.DeleteBundleRequest_RESTRICT,
+		})
+		return err
+	}
+
+	if check.fixed.TrustDomainId == bundle.TrustDomainId {
+		_, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+			Bundle: check.fixed,
+		})
+		return err
+	}
+
+	// The stored trust domain ID cannot be updated in place, so the bundle
+	// is recreated under the canonical ID, unless a different bundle is
+	// already stored under it.
+	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: check.fixed.TrustDomainId,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Bundle != nil && resp.Bundle.TrustDomainId != bundle.TrustDomainId {
+		return fmt.Errorf("another bundle is stored for %q; the bundles must be reconciled manually", check.fixed.TrustDomainId)
+	}
+
+	if _, err := ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		Mode:          datastore.DeleteBundleRequest_RESTRICT,
+	}); err != nil {
+		return err
+	}
+	if _, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: check.fixed,
+	}); err != nil {
+		// Put the original bundle back
+		if _, restoreErr := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: bundle,
+		}); restoreErr != nil {
+			return fmt.Errorf("%v; the original bundle could not be restored: %v", err, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// parseStoredCertificates parses the certificates stored as a single X.509
+// authority. Legacy data may hold several concatenated certificates, in which
+// case canonical is false.
+func parseStoredCertificates(der []byte) (certs []*x509.Certificate, canonical bool, err error) {
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		return []*x509.Certificate{cert}, true, nil
+	}
+
+	certs, err = x509.ParseCertificates(der)
+	switch {
+	case err != nil:
+		return nil, false, err
+	case len(certs) == 0:
+		return nil, false, errors.New("no certificate found")
+	}
+	return certs, false, nil
+}
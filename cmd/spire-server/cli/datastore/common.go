@@ -0,0 +1,43 @@
+package datastore
+
+import (
+	"context"
+	"flag"
+	"io"
+
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// dataStoreLoader loads the datastore configured in the SPIRE server
+// configuration file at configPath.
+type dataStoreLoader func(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error)
+
+func loadDataStore(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error) {
+	args := []string{}
+	if configPath != "" {
+		args = append(args, "-config", configPath)
+	}
+	if expandEnv {
+		args = append(args, "-expandEnv")
+	}
+
+	config, err := run.LoadConfig(name, args, nil, output, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.LoadDataStore(ctx, config.Log, config.PluginConfigs)
+}
+
+// configFlags holds the flags used to locate the server configuration.
+type configFlags struct {
+	configPath string
+	expandEnv  bool
+}
+
+func (c *configFlags) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.configPath, "config", "", "Path to a SPIRE server config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+}
@@ -0,0 +1,297 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImport(t *testing.T) {
+	source := fakedatastore.New(t)
+	_, err := source.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: "spiffe://example.org",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("root")}},
+		},
+	})
+	require.NoError(t, err)
+
+	snapshotPath := filepath.Join(spiretest.TempDir(t), "snapshot.json")
+
+	env, stdout, stderr := newTestEnv()
+	code := newExportCommand(env, fakeLoader(source, nil)).Run([]string{"-config", "server.conf", "-output", snapshotPath})
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "Exported 1 bundles, 0 registration entries, 0 attested nodes and 0 join tokens.\n", stderr.String())
+
+	target := fakedatastore.New(t)
+	env, stdout, stderr = newTestEnv()
+	code = newImportCommand(env, fakeLoader(target, nil)).Run([]string{"-config", "server.conf", "-input", snapshotPath})
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Equal(t, "Imported 1 bundles, 0 registration entries, 0 attested nodes and 0 join tokens.\n", stdout.String())
+
+	resp, err := target.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Bundle)
+
+	// Importing a second time fails since the datastore is no longer empty
+	env, _, stderr = newTestEnv()
+	code = newImportCommand(env, fakeLoader(target, nil)).Run([]string{"-input", snapshotPath})
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to import datastore: datastore is not empty; snapshots can only be imported into a fresh datastore\n", stderr.String())
+}
+
+func TestExportToStdout(t *testing.T) {
+	env, stdout, stderr := newTestEnv()
+	code := newExportCommand(env, fakeLoader(fakedatastore.New(t), nil)).Run(nil)
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Contains(t, stdout.String(), `"version": 1`)
+}
+
+func TestExportFailsToLoadDataStore(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	code := newExportCommand(env, fakeLoader(nil, errors.New("oh no"))).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to export datastore: oh no\n", stderr.String())
+}
+
+func TestImportRequiresInput(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	code := newImportCommand(env, fakeLoader(fakedatastore.New(t), nil)).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to import datastore: an input path is required\n", stderr.String())
+}
+
+func TestImportRejectsCorruptedSnapshot(t *testing.T) {
+	snapshotPath := filepath.Join(spiretest.TempDir(t), "snapshot.json")
+	require.NoError(t, ioutil.WriteFile(snapshotPath, []byte(`{"version": 2}`), 0600))
+
+	env, _, stderr := newTestEnv()
+	code := newImportCommand(env, fakeLoader(fakedatastore.New(t), nil)).Run([]string{"-input", snapshotPath})
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to import datastore: unsupported snapshot version 2; expected 1\n", stderr.String())
+}
+
+func newTestEnv() (*common_cli.Env, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	return &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, stdout, stderr
+}
+
+func fakeLoader(ds datastore.DataStore, err error) dataStoreLoader {
+	return func(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error) {
+		if err != nil {
+			return nil, err
+		}
+		return ds, nil
+	}
+}
+
+func TestCheckBundles(t *testing.T) {
+	now := time.Now()
+	cert1 := createCertificate(t, now.Add(time.Hour))
+	cert2 := createCertificate(t, now.Add(time.Hour))
+	cert3 := createCertificate(t, now.Add(time.Hour))
+	expiredCert := createCertificate(t, now.Add(-time.Hour))
+	key := createPublicKey(t)
+
+	ds := &invalidBundleDS{DataStore: fakedatastore.New(t)}
+	for _, bundle := range []*common.Bundle{
+		{
+			TrustDomainId: "spiffe://good.org",
+			RootCas:       []*common.Certificate{{DerBytes: cert1}},
+		},
+		{
+			TrustDomainId: "spiffe://EXAMPLE.org",
+			RootCas: []*common.Certificate{
+				{DerBytes: cert1},
+				{DerBytes: cert1},
+				{DerBytes: append(append([]byte{}, cert2...), cert3...)},
+				{DerBytes: []byte{}},
+			},
+			JwtSigningKeys: []*common.PublicKey{
+				{Kid: "KID", PkixBytes: key},
+				{Kid: "KID", PkixBytes: key},
+				{Kid: "BAD"},
+			},
+		},
+		{
+			TrustDomainId: "spiffe://expired.org",
+			RootCas:       []*common.Certificate{{DerBytes: expiredCert}},
+		},
+	} {
+		_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{Bundle: bundle})
+		require.NoError(t, err)
+	}
+
+	expectedIssues := `spiffe://EXAMPLE.org: non-canonical trust domain ID; expected "spiffe://example.org"
+spiffe://EXAMPLE.org: duplicate X.509 authority 1 is dropped
+spiffe://EXAMPLE.org: X.509 authority 2 is not stored as a single DER certificate
+spiffe://EXAMPLE.org: malformed X.509 authority 3 is dropped: no certificate found
+spiffe://EXAMPLE.org: duplicate JWT authority "KID" is dropped
+spiffe://EXAMPLE.org: malformed JWT authority "BAD" is dropped: asn1: syntax error: sequence truncated
+spiffe://expired.org: every authority has expired; update or delete the bundle
+invalid TD: malformed trust domain ID: spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name
+`
+
+	// Issues are only reported by default
+	env, stdout, stderr := newTestEnv()
+	code := newCheckBundlesCommand(env, fakeLoader(ds, nil)).Run(nil)
+	require.Equal(t, 1, code, "stderr: %s", stderr.String())
+	assert.Equal(t, expectedIssues+"Checked 4 bundles: 8 issues found, 0 fixed.\n", stdout.String())
+	assert.False(t, ds.invalidDeleted)
+
+	// Issues are fixed, when possible, with -fix
+	env, stdout, stderr = newTestEnv()
+	code = newCheckBundlesCommand(env, fakeLoader(ds, nil)).Run([]string{"-fix"})
+	require.Equal(t, 1, code, "stderr: %s", stderr.String())
+	assert.Equal(t, expectedIssues[:strings.Index(expectedIssues, "spiffe://expired.org")]+
+		"spiffe://EXAMPLE.org: bundle fixed\n"+
+		"spiffe://expired.org: every authority has expired; update or delete the bundle\n"+
+		`invalid TD: malformed trust domain ID: spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name`+"\n"+
+		"invalid TD: bundle removed\n"+
+		"Checked 4 bundles: 8 issues found, 7 fixed.\n", stdout.String())
+	assert.True(t, ds.invalidDeleted)
+
+	resp, err := ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: cert1},
+			{DerBytes: cert2},
+			{DerBytes: cert3},
+		},
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "KID", PkixBytes: key},
+		},
+	}, resp.Bundle)
+
+	// Only the expired bundle is reported afterwards
+	env, stdout, _ = newTestEnv()
+	code = newCheckBundlesCommand(env, fakeLoader(ds, nil)).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "spiffe://expired.org: every authority has expired; update or delete the bundle\n"+
+		"Checked 3 bundles: 1 issues found, 0 fixed.\n", stdout.String())
+}
+
+func TestCheckBundlesFailsToLoadDataStore(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	code := newCheckBundlesCommand(env, fakeLoader(nil, errors.New("oh no"))).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to check bundles: oh no\n", stderr.String())
+}
+
+func TestCheck(t *testing.T) {
+	ds := fakedatastore.New(t)
+	_, err := ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/spire/agent/test/node",
+			AttestationDataType: "test",
+			NewCertSerialNumber: "1234",
+		},
+	})
+	require.NoError(t, err)
+
+	expectedIssues := `NODE_WITHOUT_SERIAL: attested node "spiffe://example.org/spire/agent/test/node" has a pending SVID serial number but no current one` + "\n"
+
+	// Issues are only reported by default
+	env, stdout, stderr := newTestEnv()
+	code := newCheckCommand(env, fakeLoader(ds, nil)).Run(nil)
+	require.Equal(t, 1, code, "stderr: %s", stderr.String())
+	assert.Equal(t, expectedIssues+"1 issues found, 0 fixed.\n", stdout.String())
+
+	// Issues are fixed with -fix
+	env, stdout, stderr = newTestEnv()
+	code = newCheckCommand(env, fakeLoader(ds, nil)).Run([]string{"-fix"})
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Equal(t, expectedIssues+"1 issues found, 1 fixed.\n", stdout.String())
+
+	env, stdout, stderr = newTestEnv()
+	code = newCheckCommand(env, fakeLoader(ds, nil)).Run(nil)
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Equal(t, "0 issues found, 0 fixed.\n", stdout.String())
+}
+
+func TestCheckFailsToLoadDataStore(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	code := newCheckCommand(env, fakeLoader(nil, errors.New("oh no"))).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Failed to check datastore: oh no\n", stderr.String())
+}
+
+// invalidBundleDS lists an additional bundle with a malformed trust domain
+// ID, which the datastore would not accept today, until it is deleted.
+type invalidBundleDS struct {
+	datastore.DataStore
+	invalidDeleted bool
+}
+
+func (ds *invalidBundleDS) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	resp, err := ds.DataStore.ListBundles(ctx, req)
+	if err != nil || ds.invalidDeleted {
+		return resp, err
+	}
+	resp.Bundles = append(resp.Bundles, &common.Bundle{TrustDomainId: "invalid TD"})
+	return resp, nil
+}
+
+func (ds *invalidBundleDS) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	if req.TrustDomainId == "invalid TD" {
+		ds.invalidDeleted = true
+		return &datastore.DeleteBundleResponse{}, nil
+	}
+	return ds.DataStore.DeleteBundle(ctx, req)
+}
+
+func createCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return der
+}
+
+func createPublicKey(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return pkix
+}
@@ -0,0 +1,93 @@
+package datastore
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+)
+
+const exportCommandName = "datastore export"
+
+func NewExportCommand() cli.Command {
+	return newExportCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newExportCommand(env *common_cli.Env, loader dataStoreLoader) *exportCommand {
+	return &exportCommand{
+		env:    env,
+		loader: loader,
+	}
+}
+
+type exportCommand struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+
+	configFlags
+	outputPath string
+}
+
+func (c *exportCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *exportCommand) Synopsis() string {
+	return "Exports the contents of the datastore into a snapshot"
+}
+
+func (c *exportCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Failed to export datastore: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *exportCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet(exportCommandName, flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	c.configFlags.addFlags(fs)
+	fs.StringVar(&c.outputPath, "output", "", "Path to write the snapshot to. Defaults to stdout")
+	return fs.Parse(args)
+}
+
+func (c *exportCommand) run(ctx context.Context) error {
+	ds, err := c.loader(ctx, exportCommandName, c.configPath, c.expandEnv, c.env.Stderr)
+	if err != nil {
+		return err
+	}
+
+	s, err := snapshot.Export(ctx, ds)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = c.env.Stdout
+	if c.outputPath != "" {
+		f, err := os.OpenFile(c.outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := snapshot.Write(out, s); err != nil {
+		return err
+	}
+
+	return c.env.ErrPrintf("Exported %d bundles, %d registration entries, %d attested nodes and %d join tokens.\n",
+		len(s.Bundles), len(s.Entries), len(s.AttestedNodes), len(s.JoinTokens))
+}
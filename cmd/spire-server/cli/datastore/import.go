@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+)
+
+const importCommandName = "datastore import"
+
+func NewImportCommand() cli.Command {
+	return newImportCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newImportCommand(env *common_cli.Env, loader dataStoreLoader) *importCommand {
+	return &importCommand{
+		env:    env,
+		loader: loader,
+	}
+}
+
+type importCommand struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+
+	configFlags
+	inputPath string
+}
+
+func (c *importCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *importCommand) Synopsis() string {
+	return "Restores a snapshot into a fresh datastore"
+}
+
+func (c *importCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Failed to import datastore: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *importCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet(importCommandName, flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	c.configFlags.addFlags(fs)
+	fs.StringVar(&c.inputPath, "input", "", "Path to the snapshot to import")
+	return fs.Parse(args)
+}
+
+func (c *importCommand) run(ctx context.Context) error {
+	if c.inputPath == "" {
+		return errors.New("an input path is required")
+	}
+
+	f, err := os.Open(c.inputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Read and verify the snapshot before touching the datastore
+	s, err := snapshot.Read(f)
+	if err != nil {
+		return err
+	}
+
+	ds, err := c.loader(ctx, importCommandName, c.configPath, c.expandEnv, c.env.Stderr)
+	if err != nil {
+		return err
+	}
+
+	if err := snapshot.Import(ctx, ds, s); err != nil {
+		return err
+	}
+
+	return c.env.Printf("Imported %d bundles, %d registration entries, %d attested nodes and %d join tokens.\n",
+		len(s.Bundles), len(s.Entries), len(s.AttestedNodes), len(s.JoinTokens))
+}
@@ -33,6 +33,9 @@ type createCommand struct {
 	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
 	selectors StringsFlag
 
+	// ID of the entry, optional. Makes retries of the creation idempotent.
+	entryID string
+
 	// Workload parent spiffeID
 	parentID string
 
@@ -59,6 +62,15 @@ type createCommand struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
+
+	// Name presented first in X509-SVIDs based on this entry
+	x509SVIDPrimaryName string
+
+	// Type of key generated by the agent for X509-SVIDs based on this entry
+	x509SVIDKeyType string
+
+	// Whether or not the entries are created all together or not at all
+	atomic bool
 }
 
 func (*createCommand) Name() string {
@@ -70,6 +82,7 @@ func (*createCommand) Synopsis() string {
 }
 
 func (c *createCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.entryID, "entryID", "", "A custom ID for this registration entry (optional). It must be a UUID. If an entry with this ID already exists, it is not created again")
 	f.StringVar(&c.parentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
 	f.IntVar(&c.ttl, "ttl", 0, "The lifetime, in seconds, for SVIDs issued based on this registration entry")
@@ -81,6 +94,9 @@ func (c *createCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	f.StringVar(&c.x509SVIDPrimaryName, "x509SVIDPrimaryName", "", "The name presented first in X509-SVIDs issued based on this entry, <dns_name|spiffe_id>. Defaults to the server configuration")
+	f.StringVar(&c.x509SVIDKeyType, "x509SVIDKeyType", "", "The type of key generated by the agent for X509-SVIDs issued based on this entry, <ec-p256|ec-p384|rsa-2048|rsa-3072>. Defaults to ec-p256")
+	f.BoolVar(&c.atomic, "atomic", false, "If set, either all the entries are created or none is, and the command fails on the first entry that cannot be created")
 }
 
 func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -99,7 +115,7 @@ func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 		return err
 	}
 
-	succeeded, failed, err := createEntries(ctx, serverClient.NewEntryClient(), entries)
+	succeeded, failed, err := createEntries(ctx, serverClient.NewEntryClient(), entries, c.atomic)
 	if err != nil {
 		return err
 	}
@@ -118,7 +134,7 @@ func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 	}
 
 	if len(failed) > 0 {
-		return errors.New("failed to create one or more entries")
+		return util.NewBatchError("failed to create one or more entries", len(succeeded), len(failed))
 	}
 
 	return nil
@@ -188,12 +204,15 @@ func (c *createCommand) parseConfig() ([]*types.Entry, error) {
 	}
 
 	e := &types.Entry{
-		ParentId:   parentID,
-		SpiffeId:   spiffeID,
-		Ttl:        int32(c.ttl),
-		Downstream: c.downstream,
-		ExpiresAt:  c.entryExpiry,
-		DnsNames:   c.dnsNames,
+		Id:                  c.entryID,
+		ParentId:            parentID,
+		SpiffeId:            spiffeID,
+		Ttl:                 int32(c.ttl),
+		Downstream:          c.downstream,
+		ExpiresAt:           c.entryExpiry,
+		DnsNames:            c.dnsNames,
+		X509SvidPrimaryName: c.x509SVIDPrimaryName,
+		X509SvidKeyType:     c.x509SVIDKeyType,
 	}
 
 	selectors := []*types.Selector{}
@@ -212,8 +231,11 @@ func (c *createCommand) parseConfig() ([]*types.Entry, error) {
 	return []*types.Entry{e}, nil
 }
 
-func createEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry) (succeeded, failed []*entry.BatchCreateEntryResponse_Result, err error) {
-	resp, err := c.BatchCreateEntry(ctx, &entry.BatchCreateEntryRequest{Entries: entries})
+func createEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry, atomic bool) (succeeded, failed []*entry.BatchCreateEntryResponse_Result, err error) {
+	resp, err := c.BatchCreateEntry(ctx, &entry.BatchCreateEntryRequest{
+		Entries: entries,
+		Atomic:  atomic,
+	})
 	if err != nil {
 		return nil, nil, err
 	}
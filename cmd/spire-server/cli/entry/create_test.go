@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCreateHelp(t *testing.T) {
@@ -19,6 +21,8 @@ func TestCreateHelp(t *testing.T) {
 	require.Equal(t, `Usage of entry create:
   -admin
     	If set, the SPIFFE ID in this entry will be granted access to the Registration API
+  -atomic
+    	If set, either all the entries are created or none is, and the command fails on the first entry that cannot be created
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -dns value
@@ -27,6 +31,8 @@ func TestCreateHelp(t *testing.T) {
     	A boolean value that, when set, indicates that the entry describes a downstream SPIRE server
   -entryExpiry int
     	An expiry, from epoch in seconds, for the resulting registration entry to be pruned
+  -entryID string
+    	A custom ID for this registration entry (optional). It must be a UUID. If an entry with this ID already exists, it is not created again
   -federatesWith value
     	SPIFFE ID of a trust domain to federate with. Can be used more than once
   -node
@@ -41,6 +47,10 @@ func TestCreateHelp(t *testing.T) {
     	The SPIFFE ID that this record represents
   -ttl int
     	The lifetime, in seconds, for SVIDs issued based on this registration entry
+  -x509SVIDKeyType string
+    	The type of key generated by the agent for X509-SVIDs issued based on this entry, <ec-p256|ec-p384|rsa-2048|rsa-3072>. Defaults to ec-p256
+  -x509SVIDPrimaryName string
+    	The name presented first in X509-SVIDs issued based on this entry, <dns_name|spiffe_id>. Defaults to the server configuration
 `, test.stderr.String())
 }
 
@@ -61,12 +71,14 @@ func TestCreate(t *testing.T) {
 						{Type: "zebra", Value: "zebra:2000"},
 						{Type: "alpha", Value: "alpha:2000"},
 					},
-					Ttl:           60,
-					FederatesWith: []string{"spiffe://domaina.test", "spiffe://domainb.test"},
-					Admin:         true,
-					ExpiresAt:     1552410266,
-					DnsNames:      []string{"unu1000", "ung1000"},
-					Downstream:    true,
+					Ttl:                 60,
+					FederatesWith:       []string{"spiffe://domaina.test", "spiffe://domainb.test"},
+					Admin:               true,
+					ExpiresAt:           1552410266,
+					DnsNames:            []string{"unu1000", "ung1000"},
+					Downstream:          true,
+					X509SvidPrimaryName: "spiffe_id",
+					X509SvidKeyType:     "rsa-2048",
 				},
 				Status: &types.Status{
 					Code:    int32(codes.OK),
@@ -119,6 +131,18 @@ func TestCreate(t *testing.T) {
 		},
 	}
 
+	fakeRespPartialFromFile := &entry.BatchCreateEntryResponse{
+		Results: []*entry.BatchCreateEntryResponse_Result{
+			fakeRespOKFromFile.Results[0],
+			{
+				Status: &types.Status{
+					Code:    int32(codes.AlreadyExists),
+					Message: "similar entry already exists",
+				},
+			},
+		},
+	}
+
 	for _, tt := range []struct {
 		name string
 		args []string
@@ -127,8 +151,9 @@ func TestCreate(t *testing.T) {
 		fakeResp  *entry.BatchCreateEntryResponse
 		serverErr error
 
-		expOut string
-		expErr string
+		expOut  string
+		expErr  string
+		expCode int
 	}{
 		{
 			name:   "Missing selectors",
@@ -190,6 +215,7 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Create succeeds using command line arguments",
 			args: []string{
+				"-entryID", "entry-id",
 				"-spiffeID", "spiffe://example.org/workload",
 				"-parentID", "spiffe://example.org/parent",
 				"-selector", "zebra:zebra:2000",
@@ -202,22 +228,27 @@ func TestCreate(t *testing.T) {
 				"-dns", "unu1000",
 				"-dns", "ung1000",
 				"-downstream",
+				"-x509SVIDPrimaryName", "spiffe_id",
+				"-x509SVIDKeyType", "rsa-2048",
 			},
 			expReq: &entry.BatchCreateEntryRequest{
 				Entries: []*types.Entry{
 					{
+						Id:       "entry-id",
 						SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
 						ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
 						Selectors: []*types.Selector{
 							{Type: "zebra", Value: "zebra:2000"},
 							{Type: "alpha", Value: "alpha:2000"},
 						},
-						Ttl:           60,
-						FederatesWith: []string{"spiffe://domaina.test", "spiffe://domainb.test"},
-						Admin:         true,
-						ExpiresAt:     1552410266,
-						DnsNames:      []string{"unu1000", "ung1000"},
-						Downstream:    true,
+						Ttl:                 60,
+						FederatesWith:       []string{"spiffe://domaina.test", "spiffe://domainb.test"},
+						Admin:               true,
+						ExpiresAt:           1552410266,
+						DnsNames:            []string{"unu1000", "ung1000"},
+						Downstream:          true,
+						X509SvidPrimaryName: "spiffe_id",
+						X509SvidKeyType:     "rsa-2048",
 					},
 				},
 			},
@@ -235,6 +266,8 @@ FederatesWith    : spiffe://domaina.test
 FederatesWith    : spiffe://domainb.test
 DNS name         : unu1000
 DNS name         : ung1000
+Primary name     : spiffe_id
+Key type         : rsa-2048
 Admin            : true
 
 `, time.Unix(1552410266, 0).UTC()),
@@ -301,6 +334,77 @@ Selector         : unix:uid:1
 Error: failed to create one or more entries
 `,
 		},
+		{
+			name: "Create atomically using data file fails",
+			args: []string{
+				"-data", "../../../../test/fixture/registration/good.json",
+				"-atomic",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Blog"},
+						ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenBlog"},
+						Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+						Ttl:       200,
+						Admin:     true,
+					},
+					{
+						SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Database"},
+						ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenDatabase"},
+						Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+						Ttl:       200,
+					},
+				},
+				Atomic: true,
+			},
+			serverErr: status.Error(codes.AlreadyExists, "similar entry already exists"),
+			expErr:    "Error: rpc error: code = AlreadyExists desc = similar entry already exists\n",
+		},
+		{
+			name: "Create partially succeeds using data file",
+			args: []string{
+				"-data", "../../../../test/fixture/registration/good.json",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Blog"},
+						ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenBlog"},
+						Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+						Ttl:       200,
+						Admin:     true,
+					},
+					{
+						SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Database"},
+						ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenDatabase"},
+						Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+						Ttl:       200,
+					},
+				},
+			},
+			fakeResp: fakeRespPartialFromFile,
+			expOut: `Entry ID         : entry-id-1
+SPIFFE ID        : spiffe://example.org/Blog
+Parent ID        : spiffe://example.org/spire/agent/join_token/TokenBlog
+Revision         : 0
+TTL              : 200
+Selector         : unix:uid:1111
+Admin            : true
+
+`,
+			expErr: `Failed to create the following entry (code: AlreadyExists, msg: "similar entry already exists"):
+Entry ID         : (none)
+SPIFFE ID        : spiffe://example.org/Database
+Parent ID        : spiffe://example.org/spire/agent/join_token/TokenDatabase
+Revision         : 0
+TTL              : 200
+Selector         : unix:uid:1111
+
+Error: failed to create one or more entries
+`,
+			expCode: util.ExitCodePartialFailure,
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -312,8 +416,13 @@ Error: failed to create one or more entries
 			args := append(test.args, tt.args...)
 			rc := test.client.Run(args)
 			if tt.expErr != "" {
-				require.Equal(t, 1, rc)
+				expCode := tt.expCode
+				if expCode == 0 {
+					expCode = util.ExitCodeFailure
+				}
+				require.Equal(t, expCode, rc)
 				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
 				return
 			}
 
@@ -4,13 +4,16 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"golang.org/x/net/context"
 )
@@ -21,7 +24,11 @@ func NewShowCommand() cli.Command {
 }
 
 func newShowCommand(env *common_cli.Env) cli.Command {
-	return util.AdaptCommand(env, new(showCommand))
+	return newShowCommandWithClock(env, clock.New())
+}
+
+func newShowCommandWithClock(env *common_cli.Env, clk clock.Clock) cli.Command {
+	return util.AdaptCommand(env, &showCommand{clk: clk})
 }
 
 type showCommand struct {
@@ -43,6 +50,15 @@ type showCommand struct {
 
 	// Whether or not the entry is for a downstream SPIRE server
 	downstream bool
+
+	// Minimum and maximum TTL, in seconds, of the entries to show
+	minTTL int
+	maxTTL int
+
+	// Window from now in which the entries to show expire
+	expiresWithin time.Duration
+
+	clk clock.Clock
 }
 
 func (c *showCommand) Name() string {
@@ -60,6 +76,9 @@ func (c *showCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Var(&c.selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
 	f.Var(&c.federatesWith, "federatesWith", "SPIFFE ID of a trust domain an entry is federate with. Can be used more than once")
+	f.IntVar(&c.minTTL, "minTTL", 0, "Show only entries with a TTL, in seconds, greater than or equal to this value")
+	f.IntVar(&c.maxTTL, "maxTTL", 0, "Show only entries with a TTL, in seconds, less than or equal to this value")
+	f.DurationVar(&c.expiresWithin, "expiresWithin", 0, "Show only entries that expire within this duration from now (e.g. 24h)")
 }
 
 // Run executes all logic associated with a single invocation of the
@@ -74,9 +93,8 @@ func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		return err
 	}
 
-	filteredEntries := c.filterByFederatedWith(entries)
-	commonutil.SortTypesEntries(filteredEntries)
-	printEntries(filteredEntries, env)
+	commonutil.SortTypesEntries(entries)
+	printEntries(entries, env)
 	return nil
 }
 
@@ -84,11 +102,25 @@ func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 func (c *showCommand) validate() error {
 	// If entryID is given, it should be the only constraint
 	if c.entryID != "" {
-		if c.parentID != "" || c.spiffeID != "" || len(c.selectors) > 0 {
+		if c.parentID != "" || c.spiffeID != "" || len(c.selectors) > 0 || len(c.federatesWith) > 0 ||
+			c.minTTL != 0 || c.maxTTL != 0 || c.expiresWithin != 0 {
 			return errors.New("the -entryID flag can't be combined with others")
 		}
 	}
 
+	if c.minTTL < 0 {
+		return errors.New("the -minTTL flag cannot be negative")
+	}
+	if c.maxTTL < 0 {
+		return errors.New("the -maxTTL flag cannot be negative")
+	}
+	if c.maxTTL > 0 && c.minTTL > c.maxTTL {
+		return errors.New("the -minTTL flag cannot be greater than -maxTTL")
+	}
+	if c.expiresWithin < 0 {
+		return errors.New("the -expiresWithin flag cannot be negative")
+	}
+
 	return nil
 }
 
@@ -134,6 +166,25 @@ func (c *showCommand) fetchEntries(ctx context.Context, client entry.EntryClient
 		}
 	}
 
+	if len(c.federatesWith) > 0 {
+		filter.ByFederatesWith = &types.FederatesWithMatch{
+			TrustDomains: c.federatesWith,
+			Match:        types.FederatesWithMatch_MATCH_ANY,
+		}
+	}
+
+	if c.minTTL > 0 {
+		filter.ByMinTtl = &wrapperspb.Int32Value{Value: int32(c.minTTL)}
+	}
+
+	if c.maxTTL > 0 {
+		filter.ByMaxTtl = &wrapperspb.Int32Value{Value: int32(c.maxTTL)}
+	}
+
+	if c.expiresWithin > 0 {
+		filter.ByExpiresBefore = &wrapperspb.Int64Value{Value: c.clk.Now().Add(c.expiresWithin).Unix()}
+	}
+
 	resp, err := client.ListEntries(ctx, &entry.ListEntriesRequest{
 		Filter: filter,
 	})
@@ -154,46 +205,6 @@ func (c *showCommand) fetchByEntryID(ctx context.Context, id string, client entr
 	return entry, nil
 }
 
-// filterByFederatedWith evicts any value from the given entries slice that does
-// not contain at least one of the federated trust domains specified in the
-// federatesWith slice.
-func (c *showCommand) filterByFederatedWith(entries []*types.Entry) []*types.Entry {
-	// Build map for quick search
-	var federatedIDs map[string]bool
-	if len(c.federatesWith) > 0 {
-		federatedIDs = make(map[string]bool)
-		for _, federatesWith := range c.federatesWith {
-			federatedIDs[federatesWith] = true
-		}
-	}
-
-	// Filter slice in place
-	idx := 0
-	for _, e := range entries {
-		if keepEntry(e, federatedIDs) {
-			entries[idx] = e
-			idx++
-		}
-	}
-
-	return entries[:idx]
-}
-
-func keepEntry(e *types.Entry, federatedIDs map[string]bool) bool {
-	// If FederatesWith was specified, discard entries that don't match
-	if federatedIDs == nil {
-		return true
-	}
-
-	for _, federatesWith := range e.FederatesWith {
-		if federatedIDs[federatesWith] {
-			return true
-		}
-	}
-
-	return false
-}
-
 func printEntries(entries []*types.Entry, env *common_cli.Env) {
 	msg := fmt.Sprintf("Found %v ", len(entries))
 	msg = util.Pluralizer(msg, "entry", "entries", len(entries))
@@ -5,11 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andres-erbsen/clock"
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestShowHelp(t *testing.T) {
@@ -21,8 +25,14 @@ func TestShowHelp(t *testing.T) {
     	A boolean value that, when set, indicates that the entry describes a downstream SPIRE server
   -entryID string
     	The Entry ID of the records to show
+  -expiresWithin duration
+    	Show only entries that expire within this duration from now (e.g. 24h)
   -federatesWith value
     	SPIFFE ID of a trust domain an entry is federate with. Can be used more than once
+  -maxTTL int
+    	Show only entries with a TTL, in seconds, less than or equal to this value
+  -minTTL int
+    	Show only entries with a TTL, in seconds, greater than or equal to this value
   -parentID string
     	The Parent ID of the records to show
   -registrationUDSPath string
@@ -40,6 +50,11 @@ func TestShowSynopsis(t *testing.T) {
 }
 
 func TestShow(t *testing.T) {
+	clk := clock.NewMock()
+	newShowCommandWithMockClock := func(env *common_cli.Env) cli.Command {
+		return newShowCommandWithClock(env, clk)
+	}
+
 	fakeRespAll := &entry.ListEntriesResponse{
 		Entries: getEntries(4),
 	}
@@ -176,18 +191,79 @@ func TestShow(t *testing.T) {
 			name: "List by Federates With",
 			args: []string{"-federatesWith", "spiffe://domain.test"},
 			expListReq: &entry.ListEntriesRequest{
-				// Filter is empty because federatesWith filtering is done on the client side
-				Filter: &entry.ListEntriesRequest_Filter{},
+				Filter: &entry.ListEntriesRequest_Filter{
+					ByFederatesWith: &types.FederatesWithMatch{
+						TrustDomains: []string{"spiffe://domain.test"},
+						Match:        types.FederatesWithMatch_MATCH_ANY,
+					},
+				},
+			},
+			fakeListResp: &entry.ListEntriesResponse{
+				Entries: getEntries(3)[2:],
 			},
-			fakeListResp: fakeRespAll,
 			expOut: fmt.Sprintf("Found 1 entry\n%s",
 				getPrintedEntry(2),
 			),
 		},
+		{
+			name: "List by TTL range",
+			args: []string{"-minTTL", "60", "-maxTTL", "3600"},
+			expListReq: &entry.ListEntriesRequest{
+				Filter: &entry.ListEntriesRequest_Filter{
+					ByMinTtl: &wrapperspb.Int32Value{Value: 60},
+					ByMaxTtl: &wrapperspb.Int32Value{Value: 3600},
+				},
+			},
+			fakeListResp: fakeRespFather,
+			expOut: fmt.Sprintf("Found 2 entries\n%s%s",
+				getPrintedEntry(1),
+				getPrintedEntry(0),
+			),
+		},
+		{
+			name:   "List by negative minimum TTL",
+			args:   []string{"-minTTL", "-1"},
+			expErr: "Error: the -minTTL flag cannot be negative\n",
+		},
+		{
+			name:   "List by negative maximum TTL",
+			args:   []string{"-maxTTL", "-1"},
+			expErr: "Error: the -maxTTL flag cannot be negative\n",
+		},
+		{
+			name:   "List by minimum TTL greater than maximum TTL",
+			args:   []string{"-minTTL", "60", "-maxTTL", "30"},
+			expErr: "Error: the -minTTL flag cannot be greater than -maxTTL\n",
+		},
+		{
+			name: "List by expiry window",
+			args: []string{"-expiresWithin", "24h"},
+			expListReq: &entry.ListEntriesRequest{
+				Filter: &entry.ListEntriesRequest_Filter{
+					ByExpiresBefore: &wrapperspb.Int64Value{Value: clk.Now().Add(24 * time.Hour).Unix()},
+				},
+			},
+			fakeListResp: &entry.ListEntriesResponse{
+				Entries: getEntries(4)[3:],
+			},
+			expOut: fmt.Sprintf("Found 1 entry\n%s",
+				getPrintedEntry(3),
+			),
+		},
+		{
+			name:   "List by negative expiry window",
+			args:   []string{"-expiresWithin", "-1h"},
+			expErr: "Error: the -expiresWithin flag cannot be negative\n",
+		},
+		{
+			name:   "List by entry ID and expiry window",
+			args:   []string{"-entryID", "entry-id", "-expiresWithin", "1h"},
+			expErr: "Error: the -entryID flag can't be combined with others\n",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			test := setupTest(t, newShowCommand)
+			test := setupTest(t, newShowCommandWithMockClock)
 			test.server.err = tt.serverErr
 			test.server.expListEntriesReq = tt.expListReq
 			test.server.listEntriesResp = tt.fakeListResp
@@ -241,6 +317,10 @@ func getEntries(count int) []*types.Entry {
 			Selectors: []*types.Selector{selectors[2]},
 			ExpiresAt: 1552410266,
 			Id:        "00000000-0000-0000-0000-000000000003",
+			CreatedBy: "spiffe://example.org/admin",
+			CreatedAt: 1552400000,
+			UpdatedBy: "spiffe://example.org/admin",
+			UpdatedAt: 1552410000,
 		},
 	}
 
@@ -292,8 +372,12 @@ Revision         : 0
 TTL              : default
 Expiration time  : %s
 Selector         : baz:bat
+Created by       : spiffe://example.org/admin
+Created at       : %s
+Updated by       : spiffe://example.org/admin
+Updated at       : %s
 
-`, time.Unix(1552410266, 0).UTC())
+`, time.Unix(1552410266, 0).UTC(), time.Unix(1552400000, 0).UTC(), time.Unix(1552410000, 0).UTC())
 	default:
 		return "index should be lower than 4"
 	}
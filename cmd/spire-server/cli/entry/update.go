@@ -59,6 +59,15 @@ type updateCommand struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
+
+	// Name presented first in X509-SVIDs based on this entry
+	x509SVIDPrimaryName string
+
+	// Type of key generated by the agent for X509-SVIDs based on this entry
+	x509SVIDKeyType string
+
+	// Whether or not the entries are updated all together or not at all
+	atomic bool
 }
 
 func (*updateCommand) Name() string {
@@ -81,6 +90,9 @@ func (c *updateCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	f.StringVar(&c.x509SVIDPrimaryName, "x509SVIDPrimaryName", "", "The name presented first in X509-SVIDs issued based on this entry, <dns_name|spiffe_id>. Defaults to the server configuration")
+	f.StringVar(&c.x509SVIDKeyType, "x509SVIDKeyType", "", "The type of key generated by the agent for X509-SVIDs issued based on this entry, <ec-p256|ec-p384|rsa-2048|rsa-3072>. Defaults to ec-p256")
+	f.BoolVar(&c.atomic, "atomic", false, "If set, either all the entries are updated or none is, and the command fails on the first entry that cannot be updated")
 }
 
 func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -99,7 +111,7 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 		return err
 	}
 
-	succeeded, failed, err := updateEntries(ctx, serverClient.NewEntryClient(), entries)
+	succeeded, failed, err := updateEntries(ctx, serverClient.NewEntryClient(), entries, c.atomic)
 	if err != nil {
 		return err
 	}
@@ -118,7 +130,7 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 	}
 
 	if len(failed) > 0 {
-		return errors.New("failed to update one or more entries")
+		return util.NewBatchError("failed to update one or more entries", len(succeeded), len(failed))
 	}
 
 	return nil
@@ -183,13 +195,15 @@ func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
 	}
 
 	e := &types.Entry{
-		Id:         c.entryID,
-		ParentId:   parentID,
-		SpiffeId:   spiffeID,
-		Ttl:        int32(c.ttl),
-		Downstream: c.downstream,
-		ExpiresAt:  c.entryExpiry,
-		DnsNames:   c.dnsNames,
+		Id:                  c.entryID,
+		ParentId:            parentID,
+		SpiffeId:            spiffeID,
+		Ttl:                 int32(c.ttl),
+		Downstream:          c.downstream,
+		ExpiresAt:           c.entryExpiry,
+		DnsNames:            c.dnsNames,
+		X509SvidPrimaryName: c.x509SVIDPrimaryName,
+		X509SvidKeyType:     c.x509SVIDKeyType,
 	}
 
 	selectors := []*types.Selector{}
@@ -208,9 +222,10 @@ func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
 	return []*types.Entry{e}, nil
 }
 
-func updateEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry) (succeeded, failed []*entry.BatchUpdateEntryResponse_Result, err error) {
+func updateEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry, atomic bool) (succeeded, failed []*entry.BatchUpdateEntryResponse_Result, err error) {
 	resp, err := c.BatchUpdateEntry(ctx, &entry.BatchUpdateEntryRequest{
 		Entries: entries,
+		Atomic:  atomic,
 	})
 	if err != nil {
 		return nil, nil, err
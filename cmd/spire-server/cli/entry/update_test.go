@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestUpdateHelp(t *testing.T) {
@@ -19,6 +20,8 @@ func TestUpdateHelp(t *testing.T) {
 	require.Equal(t, `Usage of entry update:
   -admin
     	If true, the SPIFFE ID in this entry will be granted access to the Registration API
+  -atomic
+    	If set, either all the entries are updated or none is, and the command fails on the first entry that cannot be updated
   -data string
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -dns value
@@ -41,6 +44,10 @@ func TestUpdateHelp(t *testing.T) {
     	The SPIFFE ID that this record represents
   -ttl int
     	The lifetime, in seconds, for SVIDs issued based on this registration entry
+  -x509SVIDKeyType string
+    	The type of key generated by the agent for X509-SVIDs issued based on this entry, <ec-p256|ec-p384|rsa-2048|rsa-3072>. Defaults to ec-p256
+  -x509SVIDPrimaryName string
+    	The name presented first in X509-SVIDs issued based on this entry, <dns_name|spiffe_id>. Defaults to the server configuration
 `, test.stderr.String())
 }
 
@@ -58,12 +65,14 @@ func TestUpdate(t *testing.T) {
 			{Type: "zebra", Value: "zebra:2000"},
 			{Type: "alpha", Value: "alpha:2000"},
 		},
-		Ttl:           60,
-		FederatesWith: []string{"spiffe://domaina.test", "spiffe://domainb.test"},
-		Admin:         true,
-		ExpiresAt:     1552410266,
-		DnsNames:      []string{"unu1000", "ung1000"},
-		Downstream:    true,
+		Ttl:                 60,
+		FederatesWith:       []string{"spiffe://domaina.test", "spiffe://domainb.test"},
+		Admin:               true,
+		ExpiresAt:           1552410266,
+		DnsNames:            []string{"unu1000", "ung1000"},
+		Downstream:          true,
+		X509SvidPrimaryName: "spiffe_id",
+		X509SvidKeyType:     "rsa-2048",
 	}
 
 	fakeRespOKFromCmd := &entry.BatchUpdateEntryResponse{
@@ -204,6 +213,8 @@ func TestUpdate(t *testing.T) {
 				"-dns", "unu1000",
 				"-dns", "ung1000",
 				"-downstream",
+				"-x509SVIDPrimaryName", "spiffe_id",
+				"-x509SVIDKeyType", "rsa-2048",
 			},
 			expReq: &entry.BatchUpdateEntryRequest{
 				Entries: []*types.Entry{entry1},
@@ -222,6 +233,8 @@ FederatesWith    : spiffe://domaina.test
 FederatesWith    : spiffe://domainb.test
 DNS name         : unu1000
 DNS name         : ung1000
+Primary name     : spiffe_id
+Key type         : rsa-2048
 Admin            : true
 
 `, time.Unix(1552410266, 0).UTC()),
@@ -252,6 +265,19 @@ Selector         : unix:uid:1111
 
 `,
 		},
+		{
+			name: "Update atomically using data file fails",
+			args: []string{
+				"-data", "../../../../test/fixture/registration/good-for-update.json",
+				"-atomic",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{entry2, entry3},
+				Atomic:  true,
+			},
+			serverErr: status.Error(codes.NotFound, "failed to update entries: datastore-sql: record not found"),
+			expErr:    "Error: rpc error: code = NotFound desc = failed to update entries: datastore-sql: record not found\n",
+		},
 		{
 			name: "Entry not found",
 			args: []string{"-entryID", "non-existent-id", "-spiffeID", "spiffe://example.org/workload", "-parentID", "spiffe://example.org/parent", "-selector", "unix:uid:1"},
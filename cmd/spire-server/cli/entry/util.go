@@ -61,6 +61,27 @@ func printEntry(e *types.Entry, printf func(string, ...interface{}) error) {
 		printf("DNS name         : %s\n", dnsName)
 	}
 
+	if e.X509SvidPrimaryName != "" {
+		printf("Primary name     : %s\n", e.X509SvidPrimaryName)
+	}
+
+	if e.X509SvidKeyType != "" {
+		printf("Key type         : %s\n", e.X509SvidKeyType)
+	}
+
+	if e.CreatedBy != "" {
+		printf("Created by       : %s\n", e.CreatedBy)
+	}
+	if e.CreatedAt != 0 {
+		printf("Created at       : %s\n", time.Unix(e.CreatedAt, 0).UTC())
+	}
+	if e.UpdatedBy != "" {
+		printf("Updated by       : %s\n", e.UpdatedBy)
+	}
+	if e.UpdatedAt != 0 {
+		printf("Updated at       : %s\n", time.Unix(e.UpdatedAt, 0).UTC())
+	}
+
 	// admin is rare, so only show admin if true to keep
 	// from muddying the output.
 	if e.Admin {
@@ -0,0 +1,471 @@
+package experimental
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	loadTestCommandName = "experimental loadtest"
+
+	// loadTestTrustDomain is the trust domain of the simulated agents and
+	// registration entries, so they do not collide with real ones
+	loadTestTrustDomain = "loadtest.invalid"
+
+	// loadTestAttestationType is the attestation type of the simulated
+	// agents
+	loadTestAttestationType = "loadtest"
+
+	// loadTestSVIDTTL is the lifetime recorded for the simulated agent SVIDs
+	loadTestSVIDTTL = time.Hour
+)
+
+// dataStoreLoader loads the datastore configured in the SPIRE server
+// configuration file at configPath.
+type dataStoreLoader func(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error)
+
+func loadDataStore(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error) {
+	args := []string{}
+	if configPath != "" {
+		args = append(args, "-config", configPath)
+	}
+	if expandEnv {
+		args = append(args, "-expandEnv")
+	}
+
+	config, err := run.LoadConfig(name, args, nil, output, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.LoadDataStore(ctx, config.Log, config.PluginConfigs)
+}
+
+func NewLoadTestCommand() cli.Command {
+	return newLoadTestCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newLoadTestCommand(env *common_cli.Env, loader dataStoreLoader) *loadTestCommand {
+	return &loadTestCommand{
+		env:    env,
+		loader: loader,
+	}
+}
+
+type loadTestCommand struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+
+	configPath      string
+	expandEnv       bool
+	agents          int
+	entriesPerAgent int
+	duration        time.Duration
+	syncInterval    time.Duration
+	renewInterval   time.Duration
+}
+
+func (c *loadTestCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *loadTestCommand) Synopsis() string {
+	return "Simulates agents attesting, syncing and renewing against the datastore"
+}
+
+func (c *loadTestCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Load test failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *loadTestCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet(loadTestCommandName, flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.configPath, "config", "", "Path to a SPIRE server config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	fs.IntVar(&c.agents, "agents", 100, "Number of agents to simulate")
+	fs.IntVar(&c.entriesPerAgent, "entriesPerAgent", 10, "Number of registration entries authorized for each simulated agent")
+	fs.DurationVar(&c.duration, "duration", time.Minute, "How long the simulated agents sync and renew after attesting")
+	fs.DurationVar(&c.syncInterval, "syncInterval", 5*time.Second, "How often each simulated agent syncs its authorized entries")
+	fs.DurationVar(&c.renewInterval, "renewInterval", 30*time.Second, "How often each simulated agent renews its SVID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case c.agents <= 0:
+		return c.flagError("-agents must be greater than zero")
+	case c.entriesPerAgent < 0:
+		return c.flagError("-entriesPerAgent cannot be negative")
+	case c.duration <= 0:
+		return c.flagError("-duration must be greater than zero")
+	case c.syncInterval <= 0:
+		return c.flagError("-syncInterval must be greater than zero")
+	case c.renewInterval <= 0:
+		return c.flagError("-renewInterval must be greater than zero")
+	}
+	return nil
+}
+
+func (c *loadTestCommand) flagError(msg string) error {
+	_ = c.env.ErrPrintln(msg)
+	return errors.New(msg)
+}
+
+func (c *loadTestCommand) run(ctx context.Context) (err error) {
+	ds, err := c.loader(ctx, loadTestCommandName, c.configPath, c.expandEnv, c.env.Stderr)
+	if err != nil {
+		return err
+	}
+
+	// The simulated data is scoped to this run, so concurrent or aborted runs
+	// do not interfere with each other
+	runID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	agents := make([]*simulatedAgent, 0, c.agents)
+	for i := 0; i < c.agents; i++ {
+		id, err := spiffeid.New(loadTestTrustDomain, "spire", "agent", loadTestAttestationType, runID, strconv.Itoa(i))
+		if err != nil {
+			return err
+		}
+		agents = append(agents, &simulatedAgent{
+			id:    id,
+			stats: newLoadTestStats(),
+		})
+	}
+
+	defer func() {
+		if cleanupErr := c.cleanup(ctx, ds, agents); cleanupErr != nil && err == nil {
+			err = cleanupErr
+		}
+	}()
+
+	if err := c.createEntries(ctx, ds, agents); err != nil {
+		return fmt.Errorf("failed to create registration entries: %w", err)
+	}
+
+	if err := c.env.Printf("Simulating %d agents with %d registration entries each for %s...\n", c.agents, c.entriesPerAgent, c.duration); err != nil {
+		return err
+	}
+
+	counter := &countingDataStore{DataStore: ds}
+	start := time.Now()
+	deadline := start.Add(c.duration)
+	var wg sync.WaitGroup
+	for i, agent := range agents {
+		// Syncs and renewals are spread evenly over their intervals, as a
+		// fleet of agents would be after running for a while
+		syncOffset := c.syncInterval * time.Duration(i) / time.Duration(len(agents))
+		renewOffset := c.renewInterval * time.Duration(i) / time.Duration(len(agents))
+
+		wg.Add(1)
+		go func(agent *simulatedAgent) {
+			defer wg.Done()
+			agent.run(ctx, counter, deadline, syncOffset, c.syncInterval, renewOffset, c.renewInterval)
+		}(agent)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	stats := newLoadTestStats()
+	for _, agent := range agents {
+		stats.merge(agent.stats)
+	}
+	return c.report(stats, counter.calls(), elapsed)
+}
+
+// createEntries creates the registration entries parented by the simulated
+// agents, which are returned when the agents sync
+func (c *loadTestCommand) createEntries(ctx context.Context, ds datastore.DataStore, agents []*simulatedAgent) error {
+	for _, agent := range agents {
+		for i := 0; i < c.entriesPerAgent; i++ {
+			resp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+				Entry: &common.RegistrationEntry{
+					ParentId:  agent.id.String(),
+					SpiffeId:  agent.id.String() + "/workload/" + strconv.Itoa(i),
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:" + strconv.Itoa(1000+i)}},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			agent.entryIDs = append(agent.entryIDs, resp.Entry.EntryId)
+		}
+	}
+	return nil
+}
+
+// cleanup removes the data created by the simulated agents
+func (c *loadTestCommand) cleanup(ctx context.Context, ds datastore.DataStore, agents []*simulatedAgent) error {
+	var failed int
+	for _, agent := range agents {
+		for _, entryID := range agent.entryIDs {
+			if _, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+				EntryId: entryID,
+			}); err != nil {
+				failed++
+			}
+		}
+		if !agent.attested {
+			continue
+		}
+		if _, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{SpiffeId: agent.id.String()},
+		}); err != nil {
+			failed++
+		}
+		if _, err := ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+			SpiffeId: agent.id.String(),
+		}); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to remove %d records created by the load test; they are under the %q trust domain", failed, loadTestTrustDomain)
+	}
+	return nil
+}
+
+func (c *loadTestCommand) report(stats *loadTestStats, dataStoreCalls int64, elapsed time.Duration) error {
+	w := tabwriter.NewWriter(c.env.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "\nOPERATION\tCOUNT\tERRORS\tP50\tP90\tP99\tMAX")
+	for _, op := range loadTestOperations {
+		latencies := stats.latencies[op]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", op, len(latencies), stats.errors[op],
+			percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 99), percentile(latencies, 100))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return c.env.Printf("\nDatastore calls: %d in %s (%.1f per second)\n", dataStoreCalls, elapsed.Round(time.Millisecond), float64(dataStoreCalls)/elapsed.Seconds())
+}
+
+const (
+	opAttest = "attest"
+	opSync   = "sync"
+	opRenew  = "renew"
+)
+
+// loadTestOperations are the simulated agent operations, in report order
+var loadTestOperations = []string{opAttest, opSync, opRenew}
+
+// simulatedAgent performs the datastore operations the server performs on
+// behalf of an agent
+type simulatedAgent struct {
+	id       spiffeid.ID
+	entryIDs []string
+	attested bool
+	serial   int64
+	stats    *loadTestStats
+}
+
+func (a *simulatedAgent) run(ctx context.Context, ds datastore.DataStore, deadline time.Time, syncOffset, syncInterval, renewOffset, renewInterval time.Duration) {
+	if err := a.measure(opAttest, func() error { return a.attest(ctx, ds) }); err != nil {
+		return
+	}
+
+	nextSync := time.Now().Add(syncOffset)
+	nextRenew := time.Now().Add(renewOffset + renewInterval)
+	for {
+		next, op := nextSync, opSync
+		if nextRenew.Before(nextSync) {
+			next, op = nextRenew, opRenew
+		}
+		if !next.Before(deadline) {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		switch op {
+		case opSync:
+			_ = a.measure(opSync, func() error {
+				_, err := regentryutil.FetchRegistrationEntries(ctx, ds, a.id)
+				return err
+			})
+			nextSync = nextSync.Add(syncInterval)
+		case opRenew:
+			_ = a.measure(opRenew, func() error { return a.renew(ctx, ds) })
+			nextRenew = nextRenew.Add(renewInterval)
+		}
+	}
+}
+
+func (a *simulatedAgent) measure(op string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	a.stats.observe(op, time.Since(start), err)
+	return err
+}
+
+// attest creates the attested node of the agent and sets its selectors, as
+// done when an agent attests
+func (a *simulatedAgent) attest(ctx context.Context, ds datastore.DataStore) error {
+	a.serial++
+	if _, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            a.id.String(),
+			AttestationDataType: loadTestAttestationType,
+			CertSerialNumber:    strconv.FormatInt(a.serial, 10),
+			CertNotAfter:        time.Now().Add(loadTestSVIDTTL).Unix(),
+		},
+	}); err != nil {
+		return err
+	}
+	a.attested = true
+
+	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  a.id.String(),
+			Selectors: []*common.Selector{{Type: loadTestAttestationType, Value: "agent"}},
+		},
+	})
+	return err
+}
+
+// renew fetches the attested node of the agent and records its new SVID, as
+// done when an agent renews its SVID
+func (a *simulatedAgent) renew(ctx context.Context, ds datastore.DataStore) error {
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: a.id.String(),
+	})
+	switch {
+	case err != nil:
+		return err
+	case resp.Node == nil:
+		return errors.New("attested node not found")
+	}
+
+	a.serial++
+	_, err = ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:            a.id.String(),
+		NewCertSerialNumber: strconv.FormatInt(a.serial, 10),
+		NewCertNotAfter:     time.Now().Add(loadTestSVIDTTL).Unix(),
+		InputMask: &common.AttestedNodeMask{
+			NewCertSerialNumber: true,
+			NewCertNotAfter:     true,
+		},
+	})
+	return err
+}
+
+// loadTestStats holds the latencies and errors of the simulated operations
+type loadTestStats struct {
+	latencies map[string][]time.Duration
+	errors    map[string]int
+}
+
+func newLoadTestStats() *loadTestStats {
+	return &loadTestStats{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+}
+
+func (s *loadTestStats) observe(op string, latency time.Duration, err error) {
+	s.latencies[op] = append(s.latencies[op], latency)
+	if err != nil {
+		s.errors[op]++
+	}
+}
+
+func (s *loadTestStats) merge(other *loadTestStats) {
+	for op, latencies := range other.latencies {
+		s.latencies[op] = append(s.latencies[op], latencies...)
+	}
+	for op, errs := range other.errors {
+		s.errors[op] += errs
+	}
+}
+
+// percentile returns the given percentile of the latencies using the
+// nearest-rank method. The latencies are sorted in place.
+func percentile(latencies []time.Duration, p int) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rank := (p*len(latencies) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return latencies[rank-1]
+}
+
+// countingDataStore counts the datastore calls made by the simulated agents
+type countingDataStore struct {
+	datastore.DataStore
+	n int64
+}
+
+func (ds *countingDataStore) calls() int64 {
+	return atomic.LoadInt64(&ds.n)
+}
+
+func (ds *countingDataStore) count() {
+	atomic.AddInt64(&ds.n, 1)
+}
+
+func (ds *countingDataStore) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	ds.count()
+	return ds.DataStore.CreateAttestedNode(ctx, req)
+}
+
+func (ds *countingDataStore) FetchAttestedNode(ctx context.Context, req *datastore.FetchAttestedNodeRequest) (*datastore.FetchAttestedNodeResponse, error) {
+	ds.count()
+	return ds.DataStore.FetchAttestedNode(ctx, req)
+}
+
+func (ds *countingDataStore) UpdateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	ds.count()
+	return ds.DataStore.UpdateAttestedNode(ctx, req)
+}
+
+func (ds *countingDataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	ds.count()
+	return ds.DataStore.SetNodeSelectors(ctx, req)
+}
+
+func (ds *countingDataStore) GetNodeSelectors(ctx context.Context, req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
+	ds.count()
+	return ds.DataStore.GetNodeSelectors(ctx, req)
+}
+
+func (ds *countingDataStore) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	ds.count()
+	return ds.DataStore.ListRegistrationEntries(ctx, req)
+}
@@ -0,0 +1,104 @@
+package experimental
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTest(t *testing.T) {
+	ds := fakedatastore.New(t)
+
+	env, stdout, stderr := newTestEnv()
+	code := newLoadTestCommand(env, fakeLoader(ds, nil)).Run([]string{
+		"-agents", "3",
+		"-entriesPerAgent", "2",
+		"-duration", "200ms",
+		"-syncInterval", "50ms",
+		"-renewInterval", "100ms",
+	})
+	require.Equal(t, 0, code, "stderr: %s", stderr.String())
+	assert.Empty(t, stderr.String())
+
+	out := stdout.String()
+	assert.Contains(t, out, "Simulating 3 agents with 2 registration entries each for 200ms...\n")
+	assert.Regexp(t, `\nattest +3 +0 `, out)
+	assert.Regexp(t, `\nsync +\d+ +0 `, out)
+	assert.Regexp(t, `\nrenew +\d+ +0 `, out)
+	assert.Regexp(t, `\nDatastore calls: \d+ in \S+ \(\d+\.\d per second\)\n$`, out)
+
+	// The data created by the load test is removed
+	nodes, err := ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, nodes.Nodes)
+	entries, err := ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, entries.Entries)
+}
+
+func TestLoadTestFailsToLoadDataStore(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	code := newLoadTestCommand(env, fakeLoader(nil, errors.New("oh no"))).Run(nil)
+	require.Equal(t, 1, code)
+	assert.Equal(t, "Load test failed: oh no\n", stderr.String())
+}
+
+func TestLoadTestValidatesFlags(t *testing.T) {
+	for _, tt := range []struct {
+		args      []string
+		expectErr string
+	}{
+		{args: []string{"-agents", "0"}, expectErr: "-agents must be greater than zero\n"},
+		{args: []string{"-entriesPerAgent", "-1"}, expectErr: "-entriesPerAgent cannot be negative\n"},
+		{args: []string{"-duration", "0s"}, expectErr: "-duration must be greater than zero\n"},
+		{args: []string{"-syncInterval", "0s"}, expectErr: "-syncInterval must be greater than zero\n"},
+		{args: []string{"-renewInterval", "0s"}, expectErr: "-renewInterval must be greater than zero\n"},
+	} {
+		env, _, stderr := newTestEnv()
+		code := newLoadTestCommand(env, fakeLoader(fakedatastore.New(t), nil)).Run(tt.args)
+		assert.Equal(t, 1, code)
+		assert.Equal(t, tt.expectErr, stderr.String())
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 90*time.Millisecond, percentile(latencies, 90))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 50))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+func newTestEnv() (*common_cli.Env, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	return &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, stdout, stderr
+}
+
+func fakeLoader(ds datastore.DataStore, err error) dataStoreLoader {
+	return func(ctx context.Context, name, configPath string, expandEnv bool, output io.Writer) (datastore.DataStore, error) {
+		if err != nil {
+			return nil, err
+		}
+		return ds, nil
+	}
+}
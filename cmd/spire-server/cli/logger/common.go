@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"sort"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// printLevels prints the log level of the server followed by the log level
+// of each subsystem, sorted by subsystem name.
+func printLevels(env *common_cli.Env, level string, subsystemLevels map[string]string) error {
+	if err := env.Printf("Server: %s\n", level); err != nil {
+		return err
+	}
+
+	subsystems := make([]string, 0, len(subsystemLevels))
+	for subsystem := range subsystemLevels {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	for _, subsystem := range subsystems {
+		if err := env.Printf("%s: %s\n", subsystem, subsystemLevels[subsystem]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestShowHelp(t *testing.T) {
+	test := setupTest(t, newShowCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of logger show:
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestShowSynopsis(t *testing.T) {
+	test := setupTest(t, newShowCommand)
+	require.Equal(t, "Prints the log levels of the server and its subsystems", test.client.Synopsis())
+}
+
+func TestShow(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		serverErr     error
+		expectedOut   string
+		expectedError string
+	}{
+		{
+			name: "success",
+			expectedOut: `Server: info
+DataStore:sql: debug
+ca: info
+`,
+		},
+		{
+			name:          "server fails",
+			serverErr:     errors.New("some error"),
+			expectedError: "Error: rpc error: code = Unknown desc = some error\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newShowCommand)
+			test.server.err = tt.serverErr
+
+			rc := test.client.Run(test.args)
+			if tt.expectedError != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expectedError, test.stderr.String())
+				return
+			}
+			require.Equal(t, 0, rc)
+			require.Empty(t, test.stderr.String())
+			require.Equal(t, tt.expectedOut, test.stdout.String())
+		})
+	}
+}
+
+func TestSetHelp(t *testing.T) {
+	test := setupTest(t, newSetCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of logger set:
+  -level string
+    	Log level to set (e.g. "debug"). If unset, the subsystem is reset to the server log level
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -subsystem string
+    	Subsystem to set the log level of (e.g. "ca" or "DataStore:sql"). If unset, the server log level is set
+`, test.stderr.String())
+}
+
+func TestSetSynopsis(t *testing.T) {
+	test := setupTest(t, newSetCommand)
+	require.Equal(t, "Sets the log level of the server or one of its subsystems", test.client.Synopsis())
+}
+
+func TestSet(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		args          []string
+		serverErr     error
+		expectedReq   *debug.SetLogLevelRequest
+		expectedOut   string
+		expectedError string
+	}{
+		{
+			name:        "set subsystem level",
+			args:        []string{"-subsystem", "ca", "-level", "debug"},
+			expectedReq: &debug.SetLogLevelRequest{Subsystem: "ca", Level: "debug"},
+			expectedOut: `Server: info
+DataStore:sql: debug
+ca: debug
+`,
+		},
+		{
+			name:        "set server level",
+			args:        []string{"-level", "warn"},
+			expectedReq: &debug.SetLogLevelRequest{Level: "warn"},
+			expectedOut: `Server: warning
+DataStore:sql: debug
+ca: info
+`,
+		},
+		{
+			name:        "reset subsystem level",
+			args:        []string{"-subsystem", "DataStore:sql"},
+			expectedReq: &debug.SetLogLevelRequest{Subsystem: "DataStore:sql"},
+			expectedOut: `Server: info
+DataStore:sql: info
+ca: info
+`,
+		},
+		{
+			name:          "server fails",
+			args:          []string{"-level", "loud"},
+			serverErr:     errors.New("invalid log level"),
+			expectedReq:   &debug.SetLogLevelRequest{Level: "loud"},
+			expectedError: "Error: rpc error: code = Unknown desc = invalid log level\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newSetCommand)
+			test.server.err = tt.serverErr
+
+			rc := test.client.Run(append(test.args, tt.args...))
+			spiretest.AssertProtoEqual(t, tt.expectedReq, test.server.setReq)
+			if tt.expectedError != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expectedError, test.stderr.String())
+				return
+			}
+			require.Equal(t, 0, rc)
+			require.Empty(t, test.stderr.String())
+			require.Equal(t, tt.expectedOut, test.stdout.String())
+		})
+	}
+}
+
+type loggerTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	args   []string
+	server *fakeDebugServer
+
+	client cli.Command
+}
+
+func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *loggerTest {
+	server := &fakeDebugServer{
+		level: "info",
+		subsystemLevels: map[string]string{
+			"ca":            "info",
+			"DataStore:sql": "debug",
+		},
+	}
+
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		debug.RegisterDebugServer(s, server)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newClient(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return &loggerTest{
+		stdout: stdout,
+		stderr: stderr,
+		args:   []string{"-registrationUDSPath", socketPath},
+		server: server,
+		client: client,
+	}
+}
+
+type fakeDebugServer struct {
+	debug.DebugServer
+
+	level           string
+	subsystemLevels map[string]string
+	setReq          *debug.SetLogLevelRequest
+	err             error
+}
+
+func (f *fakeDebugServer) GetLogLevels(ctx context.Context, req *debug.GetLogLevelsRequest) (*debug.GetLogLevelsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &debug.GetLogLevelsResponse{
+		Level:           f.level,
+		SubsystemLevels: f.subsystemLevels,
+	}, nil
+}
+
+func (f *fakeDebugServer) SetLogLevel(ctx context.Context, req *debug.SetLogLevelRequest) (*debug.SetLogLevelResponse, error) {
+	f.setReq = req
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	switch {
+	case req.Subsystem == "":
+		f.level = req.Level
+		if f.level == "warn" {
+			f.level = "warning"
+		}
+	case req.Level == "":
+		f.subsystemLevels[req.Subsystem] = f.level
+	default:
+		f.subsystemLevels[req.Subsystem] = req.Level
+	}
+	return &debug.SetLogLevelResponse{
+		Level:           f.level,
+		SubsystemLevels: f.subsystemLevels,
+	}, nil
+}
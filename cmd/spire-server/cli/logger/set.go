@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+)
+
+// NewSetCommand creates a new "set" subcommand for "logger" command.
+func NewSetCommand() cli.Command {
+	return newSetCommand(common_cli.DefaultEnv)
+}
+
+func newSetCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(setCommand))
+}
+
+type setCommand struct {
+	// Subsystem to set the log level of. The server log level is set if
+	// empty.
+	subsystem string
+
+	// Log level to set. The subsystem log level is reset if empty.
+	level string
+}
+
+func (c *setCommand) Name() string {
+	return "logger set"
+}
+
+func (c *setCommand) Synopsis() string {
+	return "Sets the log level of the server or one of its subsystems"
+}
+
+func (c *setCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.subsystem, "subsystem", "", "Subsystem to set the log level of (e.g. \"ca\" or \"DataStore:sql\"). If unset, the server log level is set")
+	fs.StringVar(&c.level, "level", "", "Log level to set (e.g. \"debug\"). If unset, the subsystem is reset to the server log level")
+}
+
+func (c *setCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	debugClient := serverClient.NewDebugClient()
+	resp, err := debugClient.SetLogLevel(ctx, &debug.SetLogLevelRequest{
+		Subsystem: c.subsystem,
+		Level:     c.level,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printLevels(env, resp.Level, resp.SubsystemLevels)
+}
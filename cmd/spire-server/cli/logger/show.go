@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+)
+
+// NewShowCommand creates a new "show" subcommand for "logger" command.
+func NewShowCommand() cli.Command {
+	return newShowCommand(common_cli.DefaultEnv)
+}
+
+func newShowCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(showCommand))
+}
+
+type showCommand struct{}
+
+func (c *showCommand) Name() string {
+	return "logger show"
+}
+
+func (c *showCommand) Synopsis() string {
+	return "Prints the log levels of the server and its subsystems"
+}
+
+func (c *showCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	debugClient := serverClient.NewDebugClient()
+	resp, err := debugClient.GetLogLevels(ctx, &debug.GetLogLevelsRequest{})
+	if err != nil {
+		return err
+	}
+
+	return printLevels(env, resp.Level, resp.SubsystemLevels)
+}
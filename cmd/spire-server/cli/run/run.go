@@ -12,6 +12,8 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,13 +22,17 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server"
+	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
@@ -61,22 +67,34 @@ type Config struct {
 }
 
 type serverConfig struct {
-	BindAddress         string             `hcl:"bind_address"`
-	BindPort            int                `hcl:"bind_port"`
-	CAKeyType           string             `hcl:"ca_key_type"`
-	CASubject           *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL               string             `hcl:"ca_ttl"`
-	DataDir             string             `hcl:"data_dir"`
-	Experimental        experimentalConfig `hcl:"experimental"`
-	Federation          *federationConfig  `hcl:"federation"`
-	JWTIssuer           string             `hcl:"jwt_issuer"`
-	LogFile             string             `hcl:"log_file"`
-	LogLevel            string             `hcl:"log_level"`
-	LogFormat           string             `hcl:"log_format"`
-	RateLimit           rateLimitConfig    `hcl:"ratelimit"`
-	RegistrationUDSPath string             `hcl:"registration_uds_path"`
-	DefaultSVIDTTL      string             `hcl:"default_svid_ttl"`
-	TrustDomain         string             `hcl:"trust_domain"`
+	BindAddress         string                         `hcl:"bind_address"`
+	BindPort            int                            `hcl:"bind_port"`
+	BundleLimits        bundleLimitsConfig             `hcl:"bundle_limits"`
+	CAKeyType           string                         `hcl:"ca_key_type"`
+	CAPrePublication    string                         `hcl:"ca_pre_publication_window"`
+	CASubject           *caSubjectConfig               `hcl:"ca_subject"`
+	CATTL               string                         `hcl:"ca_ttl"`
+	ClockSkewTolerance  string                         `hcl:"clock_skew_tolerance"`
+	DataDir             string                         `hcl:"data_dir"`
+	DataStoreTimeout    string                         `hcl:"datastore_timeout"`
+	EntryQuotas         map[string]entryQuotaConfig    `hcl:"entry_quota"`
+	Experimental        experimentalConfig             `hcl:"experimental"`
+	Federation          *federationConfig              `hcl:"federation"`
+	JWTIssuer           string                         `hcl:"jwt_issuer"`
+	LogFile             string                         `hcl:"log_file"`
+	LogLevel            string                         `hcl:"log_level"`
+	LogFormat           string                         `hcl:"log_format"`
+	LogLevels           map[string]string              `hcl:"log_levels"`
+	PluginPolicy        *catalog.HCLPluginPolicy       `hcl:"plugin_policy"`
+	RateLimit           rateLimitConfig                `hcl:"ratelimit"`
+	RegistrationUDSPath string                         `hcl:"registration_uds_path"`
+	AdminUDSPath        string                         `hcl:"admin_uds_path"`
+	AdminUDSMode        string                         `hcl:"admin_uds_mode"`
+	DefaultSVIDTTL      string                         `hcl:"default_svid_ttl"`
+	SVIDTTLPolicies     map[string]svidTTLPolicyConfig `hcl:"svid_ttl_policy"`
+	TrustDomain         string                         `hcl:"trust_domain"`
+	UpstreamBundlePoll  string                         `hcl:"upstream_bundle_poll_interval"`
+	X509SVIDPrimaryName string                         `hcl:"x509_svid_primary_name"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -91,11 +109,30 @@ type serverConfig struct {
 }
 
 type experimentalConfig struct {
-	AllowAgentlessNodeAttestors bool `hcl:"allow_agentless_node_attestors"`
+	AllowAgentlessNodeAttestors bool                `hcl:"allow_agentless_node_attestors"`
+	EC2Inventory                *ec2InventoryConfig `hcl:"ec2_inventory"`
+	APIGateway                  *apiGatewayConfig   `hcl:"api_gateway"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type ec2InventoryConfig struct {
+	Regions         []string          `hcl:"regions"`
+	AccessKeyID     string            `hcl:"access_key_id"`
+	SecretAccessKey string            `hcl:"secret_access_key"`
+	GroupTagKey     string            `hcl:"group_tag_key"`
+	FilterTags      map[string]string `hcl:"filter_tags"`
+	PathPrefix      string            `hcl:"path_prefix"`
+	PollInterval    string            `hcl:"poll_interval"`
+	UnusedKeys      []string          `hcl:",unusedKeys"`
+}
+
+type apiGatewayConfig struct {
+	Address    string   `hcl:"address"`
+	Port       int      `hcl:"port"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 type caSubjectConfig struct {
 	Country      []string `hcl:"country"`
 	Organization []string `hcl:"organization"`
@@ -104,16 +141,20 @@ type caSubjectConfig struct {
 }
 
 type federationConfig struct {
-	BundleEndpoint *bundleEndpointConfig          `hcl:"bundle_endpoint"`
-	FederatesWith  map[string]federatesWithConfig `hcl:"federates_with"`
-	UnusedKeys     []string                       `hcl:",unusedKeys"`
+	BundleEndpoint               *bundleEndpointConfig          `hcl:"bundle_endpoint"`
+	FederatesWith                map[string]federatesWithConfig `hcl:"federates_with"`
+	StaleBundleRefreshHints      int                            `hcl:"stale_bundle_refresh_hints"`
+	BundleExpiryWarningThreshold string                         `hcl:"bundle_expiry_warning_threshold"`
+	UnusedKeys                   []string                       `hcl:",unusedKeys"`
 }
 
 type bundleEndpointConfig struct {
-	Address    string                    `hcl:"address"`
-	Port       int                       `hcl:"port"`
-	ACME       *bundleEndpointACMEConfig `hcl:"acme"`
-	UnusedKeys []string                  `hcl:",unusedKeys"`
+	Address                string                    `hcl:"address"`
+	Port                   int                       `hcl:"port"`
+	ACME                   *bundleEndpointACMEConfig `hcl:"acme"`
+	MirrorFederatedBundles bool                      `hcl:"mirror_federated_bundles"`
+	SigningKeyPath         string                    `hcl:"signing_key_path"`
+	UnusedKeys             []string                  `hcl:",unusedKeys"`
 }
 
 type bundleEndpointACMEConfig struct {
@@ -137,9 +178,31 @@ type federatesWithBundleEndpointConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type svidTTLPolicyConfig struct {
+	X509SVIDMaxTTL string   `hcl:"x509_svid_max_ttl"`
+	JWTSVIDMaxTTL  string   `hcl:"jwt_svid_max_ttl"`
+	UnusedKeys     []string `hcl:",unusedKeys"`
+}
+
+type entryQuotaConfig struct {
+	MaxEntries int      `hcl:"max_entries"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type bundleLimitsConfig struct {
+	MaxX509Authorities int      `hcl:"max_x509_authorities"`
+	MaxJWTAuthorities  int      `hcl:"max_jwt_authorities"`
+	MaxBundleBytes     int      `hcl:"max_bundle_bytes"`
+	MinRefreshHint     string   `hcl:"min_refresh_hint"`
+	MaxRefreshHint     string   `hcl:"max_refresh_hint"`
+	UnusedKeys         []string `hcl:",unusedKeys"`
+}
+
 type rateLimitConfig struct {
-	Attestation *bool    `hcl:"attestation"`
-	UnusedKeys  []string `hcl:",unusedKeys"`
+	Attestation      *bool    `hcl:"attestation"`
+	StreamsPerCaller int      `hcl:"streams_per_caller"`
+	Bundle           int      `hcl:"bundle"`
+	UnusedKeys       []string `hcl:",unusedKeys"`
 }
 
 func NewRunCommand(logOptions []log.Option, allowUnknownConfig bool) cli.Command {
@@ -332,6 +395,27 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		Net:  "unix",
 	}
 
+	if c.Server.AdminUDSPath != "" {
+		if c.Server.AdminUDSPath == c.Server.RegistrationUDSPath {
+			return nil, errors.New("admin_uds_path must be different from registration_uds_path")
+		}
+		sc.AdminBindUDSAddress = &net.UnixAddr{
+			Name: c.Server.AdminUDSPath,
+			Net:  "unix",
+		}
+	}
+
+	if c.Server.AdminUDSMode != "" {
+		if c.Server.AdminUDSPath == "" {
+			return nil, errors.New("admin_uds_mode requires admin_uds_path to be configured")
+		}
+		mode, err := strconv.ParseUint(c.Server.AdminUDSMode, 8, 32)
+		if err != nil || mode > 0777 {
+			return nil, fmt.Errorf("could not parse admin_uds_mode %q: must be an octal file mode", c.Server.AdminUDSMode)
+		}
+		sc.AdminUDSMode = os.FileMode(mode)
+	}
+
 	sc.DataDir = c.Server.DataDir
 
 	trustDomain, err := spiffeid.TrustDomainFromString(c.Server.TrustDomain)
@@ -351,12 +435,100 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 	}
 	sc.Log = logger
 
+	sc.LogLevels = log.NewLevels(logger.Logger)
+	for subsystem, level := range c.Server.LogLevels {
+		logLevel, err := logrus.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse log level %q of %q: %w", level, subsystem, err)
+		}
+		sc.LogLevels.SetLevel(subsystem, logLevel)
+	}
+
 	if c.Server.RateLimit.Attestation == nil {
 		c.Server.RateLimit.Attestation = &defaultRateLimitAttestation
 	}
 	sc.RateLimit.Attestation = *c.Server.RateLimit.Attestation
 
+	if c.Server.RateLimit.StreamsPerCaller < 0 {
+		return nil, errors.New("ratelimit streams_per_caller must not be negative")
+	}
+	sc.RateLimit.StreamsPerCaller = c.Server.RateLimit.StreamsPerCaller
+
+	if c.Server.RateLimit.Bundle < 0 {
+		return nil, errors.New("ratelimit bundle must not be negative")
+	}
+	sc.RateLimit.Bundle = c.Server.RateLimit.Bundle
+
+	bl := c.Server.BundleLimits
+	if bl.MaxX509Authorities < 0 || bl.MaxJWTAuthorities < 0 || bl.MaxBundleBytes < 0 {
+		return nil, errors.New("bundle_limits must not be negative")
+	}
+	sc.BundleLimits.MaxX509Authorities = bl.MaxX509Authorities
+	sc.BundleLimits.MaxJWTAuthorities = bl.MaxJWTAuthorities
+	sc.BundleLimits.MaxBundleBytes = bl.MaxBundleBytes
+
+	minRefreshHint, maxRefreshHint := bundleutil.MinimumRefreshHint, bundleutil.MaximumRefreshHint
+	if bl.MinRefreshHint != "" {
+		minRefreshHint, err = time.ParseDuration(bl.MinRefreshHint)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse bundle_limits min_refresh_hint %q: %v", bl.MinRefreshHint, err)
+		}
+		if minRefreshHint < bundleutil.MinimumRefreshHint {
+			return nil, fmt.Errorf("bundle_limits min_refresh_hint %q must be at least %s", bl.MinRefreshHint, bundleutil.MinimumRefreshHint)
+		}
+		sc.BundleLimits.MinRefreshHint = minRefreshHint
+	}
+	if bl.MaxRefreshHint != "" {
+		maxRefreshHint, err = time.ParseDuration(bl.MaxRefreshHint)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse bundle_limits max_refresh_hint %q: %v", bl.MaxRefreshHint, err)
+		}
+		sc.BundleLimits.MaxRefreshHint = maxRefreshHint
+	}
+	if maxRefreshHint < minRefreshHint {
+		return nil, fmt.Errorf("bundle_limits max_refresh_hint %s must not be less than min_refresh_hint %s", maxRefreshHint, minRefreshHint)
+	}
+
 	sc.Experimental.AllowAgentlessNodeAttestors = c.Server.Experimental.AllowAgentlessNodeAttestors
+	if ec2Config := c.Server.Experimental.EC2Inventory; ec2Config != nil {
+		if len(ec2Config.Regions) == 0 {
+			return nil, errors.New("ec2_inventory requires at least one region")
+		}
+		if (ec2Config.AccessKeyID == "") != (ec2Config.SecretAccessKey == "") {
+			return nil, errors.New("ec2_inventory access_key_id and secret_access_key must be configured together")
+		}
+		sc.Experimental.EC2Inventory = &server.EC2InventoryConfig{
+			Regions:         ec2Config.Regions,
+			AccessKeyID:     ec2Config.AccessKeyID,
+			SecretAccessKey: ec2Config.SecretAccessKey,
+			GroupTagKey:     ec2Config.GroupTagKey,
+			FilterTags:      ec2Config.FilterTags,
+			PathPrefix:      ec2Config.PathPrefix,
+		}
+		if ec2Config.PollInterval != "" {
+			interval, err := time.ParseDuration(ec2Config.PollInterval)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse ec2_inventory poll_interval %q: %v", ec2Config.PollInterval, err)
+			}
+			sc.Experimental.EC2Inventory.PollInterval = interval
+		}
+	}
+	if gwConfig := c.Server.Experimental.APIGateway; gwConfig != nil {
+		ip := net.ParseIP(gwConfig.Address)
+		if ip == nil {
+			return nil, fmt.Errorf("could not parse api_gateway address %q", gwConfig.Address)
+		}
+		if gwConfig.Port == 0 {
+			return nil, errors.New("api_gateway port must be configured")
+		}
+		if ip.Equal(sc.BindAddress.IP) && gwConfig.Port == sc.BindAddress.Port {
+			return nil, errors.New("api_gateway address must be different from the server bind address")
+		}
+		sc.Experimental.APIGatewayAddress = &net.TCPAddr{
+			IP:   ip,
+			Port: gwConfig.Port,
+		}
+	}
 	if c.Server.Federation != nil {
 		if c.Server.Federation.BundleEndpoint != nil {
 			sc.Federation.BundleEndpoint = &bundle.EndpointConfig{
@@ -375,6 +547,18 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 					ToSAccepted:  acme.ToSAccepted,
 				}
 			}
+
+			if path := c.Server.Federation.BundleEndpoint.SigningKeyPath; path != "" {
+				key, err := pemutil.LoadSigner(path)
+				if err != nil {
+					return nil, fmt.Errorf("could not load federation.bundle_endpoint.signing_key_path: %w", err)
+				}
+				signer, err := bundle.NewSigner(key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid federation.bundle_endpoint.signing_key_path: %w", err)
+				}
+				sc.Federation.BundleEndpoint.Signer = signer
+			}
 		}
 
 		federatesWith := map[spiffeid.TrustDomain]bundleClient.TrustDomainConfig{}
@@ -407,6 +591,29 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 			}
 		}
 		sc.Federation.FederatesWith = federatesWith
+
+		if sc.Federation.BundleEndpoint != nil && c.Server.Federation.BundleEndpoint.MirrorFederatedBundles {
+			mirrored := make([]spiffeid.TrustDomain, 0, len(federatesWith))
+			for td := range federatesWith {
+				mirrored = append(mirrored, td)
+			}
+			sort.Slice(mirrored, func(i, j int) bool {
+				return mirrored[i].String() < mirrored[j].String()
+			})
+			sc.Federation.BundleEndpoint.MirroredTrustDomains = mirrored
+		}
+
+		if c.Server.Federation.StaleBundleRefreshHints < 0 {
+			return nil, errors.New("federation stale_bundle_refresh_hints must not be negative")
+		}
+		sc.Federation.StaleBundleRefreshHints = c.Server.Federation.StaleBundleRefreshHints
+		if c.Server.Federation.BundleExpiryWarningThreshold != "" {
+			threshold, err := time.ParseDuration(c.Server.Federation.BundleExpiryWarningThreshold)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse federation bundle_expiry_warning_threshold %q: %v", c.Server.Federation.BundleExpiryWarningThreshold, err)
+			}
+			sc.Federation.BundleExpiryWarningThreshold = threshold
+		}
 	}
 
 	sc.ProfilingEnabled = c.Server.ProfilingEnabled
@@ -422,6 +629,20 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.SVIDTTL = ttl
 	}
 
+	if len(c.Server.SVIDTTLPolicies) > 0 {
+		sc.SVIDTTLPolicies, err = parseSVIDTTLPolicies(c.Server.SVIDTTLPolicies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.Server.EntryQuotas) > 0 {
+		sc.EntryQuotas, err = parseEntryQuotas(c.Server.EntryQuotas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if c.Server.CATTL != "" {
 		ttl, err := time.ParseDuration(c.Server.CATTL)
 		if err != nil {
@@ -430,6 +651,54 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.CATTL = ttl
 	}
 
+	if c.Server.CAPrePublication != "" {
+		window, err := time.ParseDuration(c.Server.CAPrePublication)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse CA pre-publication window %q: %v", c.Server.CAPrePublication, err)
+		}
+		caTTL := sc.CATTL
+		if caTTL == 0 {
+			caTTL = ca.DefaultCATTL
+		}
+		if window < 0 || window >= caTTL {
+			return nil, fmt.Errorf("CA pre-publication window %q must be positive and shorter than the CA TTL", c.Server.CAPrePublication)
+		}
+		sc.CAPrePublicationWindow = window
+	}
+
+	if c.Server.UpstreamBundlePoll != "" {
+		interval, err := time.ParseDuration(c.Server.UpstreamBundlePoll)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse upstream bundle poll interval %q: %v", c.Server.UpstreamBundlePoll, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("upstream bundle poll interval %q must be positive", c.Server.UpstreamBundlePoll)
+		}
+		sc.UpstreamBundlePollInterval = interval
+	}
+
+	if c.Server.DataStoreTimeout != "" {
+		timeout, err := time.ParseDuration(c.Server.DataStoreTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse datastore timeout %q: %v", c.Server.DataStoreTimeout, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("datastore timeout %q must be positive", c.Server.DataStoreTimeout)
+		}
+		sc.DataStoreTimeout = timeout
+	}
+
+	if c.Server.ClockSkewTolerance != "" {
+		tolerance, err := time.ParseDuration(c.Server.ClockSkewTolerance)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse clock skew tolerance %q: %v", c.Server.ClockSkewTolerance, err)
+		}
+		if tolerance < 0 {
+			return nil, fmt.Errorf("clock skew tolerance %q cannot be negative", c.Server.ClockSkewTolerance)
+		}
+		sc.ClockSkewTolerance = tolerance
+	}
+
 	if !hasExpectedTTLs(sc.CATTL, sc.SVIDTTL) {
 		sc.Log.Warnf("The configured SVID TTL cannot be guaranteed in all cases - SVIDs with shorter TTLs may be issued if the signing key is expiring soon. Set a CA TTL of at least 6x or reduce SVID TTL below 6x to avoid issuing SVIDs with a smaller TTL than specified")
 	}
@@ -441,6 +710,11 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		}
 	}
 
+	sc.X509SVIDPrimaryName, err = x509svid.ParsePrimaryName(c.Server.X509SVIDPrimaryName)
+	if err != nil {
+		return nil, err
+	}
+
 	sc.JWTIssuer = c.Server.JWTIssuer
 
 	if subject := c.Server.CASubject; subject != nil {
@@ -459,6 +733,10 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 	}
 
 	sc.PluginConfigs = *c.Plugins
+	sc.PluginPolicy, err = catalog.PluginPolicyFromHCL(c.Server.PluginPolicy)
+	if err != nil {
+		return nil, err
+	}
 	sc.Telemetry = c.Telemetry
 	sc.HealthChecks = c.HealthChecks
 
@@ -546,6 +824,10 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 			detectedUnknown("ratelimit", rl.UnusedKeys)
 		}
 
+		if bl := c.Server.BundleLimits; len(bl.UnusedKeys) != 0 {
+			detectedUnknown("bundle_limits", bl.UnusedKeys)
+		}
+
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -577,6 +859,22 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 				}
 			}
 		}
+
+		for k, v := range c.Server.SVIDTTLPolicies {
+			if len(v.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("svid_ttl_policy %q", k), v.UnusedKeys)
+			}
+		}
+
+		for k, v := range c.Server.EntryQuotas {
+			if len(v.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("entry_quota %q", k), v.UnusedKeys)
+			}
+		}
+
+		if p := c.Server.PluginPolicy; p != nil && len(p.UnusedKeys) != 0 {
+			detectedUnknown("plugin_policy", p.UnusedKeys)
+		}
 	}
 
 	// TODO: Re-enable unused key detection for telemetry. See
@@ -647,6 +945,54 @@ func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
 	}
 }
 
+// parseSVIDTTLPolicies parses the SVID TTL policies, keyed by SPIFFE ID path
+// prefix. The policies are sorted by prefix for a deterministic order.
+func parseSVIDTTLPolicies(configs map[string]svidTTLPolicyConfig) ([]ca.TTLPolicy, error) {
+	parseMaxTTL := func(prefix, name, value string) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse svid_ttl_policy[%q].%s %q: %v", prefix, name, value, err)
+		}
+		if ttl <= 0 {
+			return 0, fmt.Errorf("svid_ttl_policy[%q].%s %q must be positive", prefix, name, value)
+		}
+		return ttl, nil
+	}
+
+	var policies []ca.TTLPolicy
+	for prefix, config := range configs {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("svid_ttl_policy path prefix %q must start with a slash", prefix)
+		}
+
+		x509SVIDMaxTTL, err := parseMaxTTL(prefix, "x509_svid_max_ttl", config.X509SVIDMaxTTL)
+		if err != nil {
+			return nil, err
+		}
+		jwtSVIDMaxTTL, err := parseMaxTTL(prefix, "jwt_svid_max_ttl", config.JWTSVIDMaxTTL)
+		if err != nil {
+			return nil, err
+		}
+		if x509SVIDMaxTTL == 0 && jwtSVIDMaxTTL == 0 {
+			return nil, fmt.Errorf("svid_ttl_policy[%q] must configure x509_svid_max_ttl, jwt_svid_max_ttl or both", prefix)
+		}
+
+		policies = append(policies, ca.TTLPolicy{
+			PathPrefix:     prefix,
+			X509SVIDMaxTTL: x509SVIDMaxTTL,
+			JWTSVIDMaxTTL:  jwtSVIDMaxTTL,
+		})
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].PathPrefix < policies[j].PathPrefix
+	})
+	return policies, nil
+}
+
 // hasExpectedTTLs is a function that checks if ca_ttl is less than default_svid_ttl * 6. SPIRE Server prepares a new CA certificate when 1/2 of the CA lifetime has elapsed in order to give ample time for the new trust bundle to propagate. However, it does not start using it until 5/6th of the CA lifetime. So its normal for an SVID TTL to be capped to 1/6th of the CA TTL. In order to get the expected lifetime on SVID TTLs, the CA TTL should be 6x.
 func hasExpectedTTLs(caTTL, svidTTL time.Duration) bool {
 	if caTTL == 0 {
@@ -667,3 +1013,26 @@ func isPKIXNameEmpty(name pkix.Name) bool {
 	// use.
 	return reflect.DeepEqual(name, pkix.Name{})
 }
+
+// parseEntryQuotas parses the registration entry quotas, keyed by SPIFFE ID
+// path prefix. The quotas are sorted by prefix for a deterministic order.
+func parseEntryQuotas(configs map[string]entryQuotaConfig) ([]entryv1.Quota, error) {
+	var quotas []entryv1.Quota
+	for prefix, config := range configs {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("entry_quota path prefix %q must start with a slash", prefix)
+		}
+		if config.MaxEntries <= 0 {
+			return nil, fmt.Errorf("entry_quota[%q].max_entries must be positive", prefix)
+		}
+		quotas = append(quotas, entryv1.Quota{
+			PathPrefix: prefix,
+			MaxEntries: config.MaxEntries,
+		})
+	}
+
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].PathPrefix < quotas[j].PathPrefix
+	})
+	return quotas, nil
+}
@@ -15,8 +15,11 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server"
+	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -522,6 +525,80 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, "unix", c.BindUDSAddress.Net)
 			},
 		},
+		{
+			msg:   "admin_uds_path is not configured by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.AdminBindUDSAddress)
+				require.Zero(t, c.AdminUDSMode)
+			},
+		},
+		{
+			msg: "admin_uds_path and admin_uds_mode should be correctly configured",
+			input: func(c *Config) {
+				c.Server.AdminUDSPath = "admin"
+				c.Server.AdminUDSMode = "0750"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "admin", c.AdminBindUDSAddress.Name)
+				require.Equal(t, "unix", c.AdminBindUDSAddress.Net)
+				require.Equal(t, os.FileMode(0750), c.AdminUDSMode)
+			},
+		},
+		{
+			msg:         "admin_uds_path must differ from registration_uds_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.RegistrationUDSPath = "foo"
+				c.Server.AdminUDSPath = "foo"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "admin_uds_mode requires admin_uds_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AdminUDSMode = "0700"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid admin_uds_mode should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AdminUDSPath = "admin"
+				c.Server.AdminUDSMode = "rwx"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "admin_uds_mode that is not octal should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AdminUDSPath = "admin"
+				c.Server.AdminUDSMode = "0780"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "admin_uds_mode beyond the permission bits should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AdminUDSPath = "admin"
+				c.Server.AdminUDSMode = "01700"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "data_dir should be correctly configured",
 			input: func(c *Config) {
@@ -607,6 +684,35 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "log_levels are configured per subsystem",
+			input: func(c *Config) {
+				c.Server.LogLevel = "INFO"
+				c.Server.LogLevels = map[string]string{
+					"ca":            "debug",
+					"DataStore:sql": "WARN",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.LogLevels)
+				require.Equal(t, logrus.InfoLevel, c.LogLevels.BaseLevel())
+				require.Equal(t, map[string]logrus.Level{
+					"ca":            logrus.DebugLevel,
+					"DataStore:sql": logrus.WarnLevel,
+				}, c.LogLevels.SubsystemLevels())
+				require.Equal(t, logrus.InfoLevel, c.LogLevels.Logger("endpoints").GetLevel())
+			},
+		},
+		{
+			msg:         "invalid log_levels returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.LogLevels = map[string]string{"ca": "not-a-valid-level"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "allow_agentless_node_attestors is configured correctly",
 			input: func(c *Config) {
@@ -616,6 +722,128 @@ func TestNewServerConfig(t *testing.T) {
 				require.True(t, c.Experimental.AllowAgentlessNodeAttestors)
 			},
 		},
+		{
+			msg: "api_gateway is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Experimental.APIGateway = &apiGatewayConfig{
+					Address: "127.0.0.1",
+					Port:    8443,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "127.0.0.1:8443", c.Experimental.APIGatewayAddress.String())
+			},
+		},
+		{
+			msg:         "api_gateway with an invalid address should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.APIGateway = &apiGatewayConfig{
+					Address: "localhost",
+					Port:    8443,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "api_gateway without a port should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.APIGateway = &apiGatewayConfig{
+					Address: "127.0.0.1",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "api_gateway must not use the server bind address",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.APIGateway = &apiGatewayConfig{
+					Address: "0.0.0.0",
+					Port:    8081,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ec2_inventory is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Experimental.EC2Inventory = &ec2InventoryConfig{
+					Regions:      []string{"us-east-1", "us-west-2"},
+					GroupTagKey:  "team",
+					FilterTags:   map[string]string{"env": "prod"},
+					PathPrefix:   "/aws/prod",
+					PollInterval: "1m",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &server.EC2InventoryConfig{
+					Regions:      []string{"us-east-1", "us-west-2"},
+					GroupTagKey:  "team",
+					FilterTags:   map[string]string{"env": "prod"},
+					PathPrefix:   "/aws/prod",
+					PollInterval: time.Minute,
+				}, c.Experimental.EC2Inventory)
+			},
+		},
+		{
+			msg: "ec2_inventory accepts static credentials",
+			input: func(c *Config) {
+				c.Server.Experimental.EC2Inventory = &ec2InventoryConfig{
+					Regions:         []string{"us-east-1"},
+					AccessKeyID:     "ACCESSKEYID",
+					SecretAccessKey: "SECRETACCESSKEY",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "ACCESSKEYID", c.Experimental.EC2Inventory.AccessKeyID)
+				require.Equal(t, "SECRETACCESSKEY", c.Experimental.EC2Inventory.SecretAccessKey)
+				require.Zero(t, c.Experimental.EC2Inventory.PollInterval)
+			},
+		},
+		{
+			msg:         "ec2_inventory without regions should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.EC2Inventory = &ec2InventoryConfig{}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "ec2_inventory with an access key ID but no secret should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.EC2Inventory = &ec2InventoryConfig{
+					Regions:     []string{"us-east-1"},
+					AccessKeyID: "ACCESSKEYID",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "ec2_inventory with an invalid poll_interval should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.EC2Inventory = &ec2InventoryConfig{
+					Regions:      []string{"us-east-1"},
+					PollInterval: "often",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle endpoint is parsed and configured correctly",
 			input: func(c *Config) {
@@ -631,6 +859,102 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, 1337, c.Federation.BundleEndpoint.Address.Port)
 			},
 		},
+		{
+			msg: "bundle endpoint mirrors federated bundles when enabled",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address:                "192.168.1.1",
+						Port:                   1337,
+						MirrorFederatedBundles: true,
+					},
+					FederatesWith: map[string]federatesWithConfig{
+						"domain2.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.2",
+							},
+						},
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.1",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []spiffeid.TrustDomain{
+					spiffeid.RequireTrustDomainFromString("domain1.test"),
+					spiffeid.RequireTrustDomainFromString("domain2.test"),
+				}, c.Federation.BundleEndpoint.MirroredTrustDomains)
+			},
+		},
+		{
+			msg: "bundle endpoint does not mirror federated bundles by default",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address: "192.168.1.1",
+						Port:    1337,
+					},
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.1",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Empty(t, c.Federation.BundleEndpoint.MirroredTrustDomains)
+			},
+		},
+		{
+			msg: "bundle endpoint signs bundle documents with the configured key",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address:        "192.168.1.1",
+						Port:           1337,
+						SigningKeyPath: "../../../../test/fixture/certs/base_key.pem",
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.Federation.BundleEndpoint.Signer)
+			},
+		},
+		{
+			msg: "bundle endpoint does not sign bundle documents by default",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address: "192.168.1.1",
+						Port:    1337,
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.Federation.BundleEndpoint.Signer)
+			},
+		},
+		{
+			msg:         "bundle endpoint signing key that cannot be loaded should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address:        "192.168.1.1",
+						Port:           1337,
+						SigningKeyPath: "../../../../test/fixture/certs/does_not_exist.pem",
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle federates with section is parsed and configured correctly",
 			input: func(c *Config) {
@@ -690,84 +1014,390 @@ func TestNewServerConfig(t *testing.T) {
 			},
 		},
 		{
-			msg: "default_svid_ttl is correctly parsed",
+			msg: "federation staleness settings are parsed and configured correctly",
 			input: func(c *Config) {
-				c.Server.DefaultSVIDTTL = "1m"
+				c.Server.Federation = &federationConfig{
+					StaleBundleRefreshHints:      5,
+					BundleExpiryWarningThreshold: "12h",
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, time.Minute, c.SVIDTTL)
+				require.Equal(t, 5, c.Federation.StaleBundleRefreshHints)
+				require.Equal(t, 12*time.Hour, c.Federation.BundleExpiryWarningThreshold)
 			},
 		},
 		{
-			msg:         "invalid default_svid_ttl returns an error",
+			msg:         "federation stale_bundle_refresh_hints must not be negative",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.DefaultSVIDTTL = "b"
+				c.Server.Federation = &federationConfig{
+					StaleBundleRefreshHints: -1,
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "rsa-2048 ca_key_type is correctly parsed",
+			msg:         "invalid federation bundle_expiry_warning_threshold returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-2048"
+				c.Server.Federation = &federationConfig{
+					BundleExpiryWarningThreshold: "abc",
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "rsa-4096 ca_key_type is correctly parsed",
+			msg: "default_svid_ttl is correctly parsed",
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-4096"
+				c.Server.DefaultSVIDTTL = "1m"
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+				require.Equal(t, time.Minute, c.SVIDTTL)
 			},
 		},
 		{
-			msg: "ec-p256 ca_key_type is correctly parsed",
+			msg:         "invalid default_svid_ttl returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p256"
+				c.Server.DefaultSVIDTTL = "b"
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ec-p384 ca_key_type is correctly parsed",
+			msg: "svid_ttl_policy is correctly parsed",
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p384"
+				c.Server.SVIDTTLPolicies = map[string]svidTTLPolicyConfig{
+					"/admin/": {X509SVIDMaxTTL: "5m", JWTSVIDMaxTTL: "1m"},
+					"/ci/":    {JWTSVIDMaxTTL: "30s"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+				require.Equal(t, []ca.TTLPolicy{
+					{PathPrefix: "/admin/", X509SVIDMaxTTL: 5 * time.Minute, JWTSVIDMaxTTL: time.Minute},
+					{PathPrefix: "/ci/", JWTSVIDMaxTTL: 30 * time.Second},
+				}, c.SVIDTTLPolicies)
 			},
 		},
 		{
-			msg:         "unsupported ca_key_type is rejected",
+			msg:         "svid_ttl_policy path prefix must start with a slash",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-1024"
+				c.Server.SVIDTTLPolicies = map[string]svidTTLPolicyConfig{
+					"admin/": {X509SVIDMaxTTL: "5m"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_ttl is correctly parsed",
+			msg:         "invalid svid_ttl_policy max TTL returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CATTL = "1h"
+				c.Server.SVIDTTLPolicies = map[string]svidTTLPolicyConfig{
+					"/admin/": {X509SVIDMaxTTL: "b"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, time.Hour, c.CATTL)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg:         "invalid ca_ttl returns an error",
+			msg:         "svid_ttl_policy without max TTLs returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CATTL = "b"
+				c.Server.SVIDTTLPolicies = map[string]svidTTLPolicyConfig{
+					"/admin/": {},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "entry_quota is correctly parsed",
+			input: func(c *Config) {
+				c.Server.EntryQuotas = map[string]entryQuotaConfig{
+					"/team-b/": {MaxEntries: 10},
+					"/team-a/": {MaxEntries: 100},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []entryv1.Quota{
+					{PathPrefix: "/team-a/", MaxEntries: 100},
+					{PathPrefix: "/team-b/", MaxEntries: 10},
+				}, c.EntryQuotas)
+			},
+		},
+		{
+			msg:         "entry_quota path prefix must start with a slash",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.EntryQuotas = map[string]entryQuotaConfig{
+					"team-a/": {MaxEntries: 100},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "entry_quota without max_entries returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.EntryQuotas = map[string]entryQuotaConfig{
+					"/team-a/": {},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "rsa-2048 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+			},
+		},
+		{
+			msg: "rsa-4096 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-4096"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p384 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+			},
+		},
+		{
+			msg:         "unsupported ca_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "x509_svid_primary_name is correctly parsed",
+			input: func(c *Config) {
+				c.Server.X509SVIDPrimaryName = "spiffe_id"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, x509svid.PrimaryNameSPIFFEID, c.X509SVIDPrimaryName)
+			},
+		},
+		{
+			msg:         "invalid x509_svid_primary_name is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.X509SVIDPrimaryName = "common_name"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_ttl is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CATTL = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Hour, c.CATTL)
+			},
+		},
+		{
+			msg:         "invalid ca_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CATTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_pre_publication_window is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAPrePublication = "6h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 6*time.Hour, c.CAPrePublicationWindow)
+			},
+		},
+		{
+			msg:         "invalid ca_pre_publication_window returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAPrePublication = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "ca_pre_publication_window must be shorter than the CA TTL",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CATTL = "1h"
+				c.Server.CAPrePublication = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "datastore_timeout is correctly parsed",
+			input: func(c *Config) {
+				c.Server.DataStoreTimeout = "5s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 5*time.Second, c.DataStoreTimeout)
+			},
+		},
+		{
+			msg:         "invalid datastore_timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.DataStoreTimeout = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "non-positive datastore_timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.DataStoreTimeout = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "clock_skew_tolerance is correctly parsed",
+			input: func(c *Config) {
+				c.Server.ClockSkewTolerance = "2m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 2*time.Minute, c.ClockSkewTolerance)
+			},
+		},
+		{
+			msg:   "clock_skew_tolerance is disabled by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Zero(t, c.ClockSkewTolerance)
+			},
+		},
+		{
+			msg:         "invalid clock_skew_tolerance returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.ClockSkewTolerance = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative clock_skew_tolerance returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.ClockSkewTolerance = "-1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "plugin_policy is correctly parsed",
+			input: func(c *Config) {
+				c.Server.PluginPolicy = &catalog.HCLPluginPolicy{
+					Allow:           []string{"NodeAttestor", "KeyManager:disk"},
+					Deny:            []string{"NodeAttestor:join_token"},
+					RequireChecksum: true,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, catalog.PluginPolicy{
+					Allow:           []string{"NodeAttestor", "KeyManager:disk"},
+					Deny:            []string{"NodeAttestor:join_token"},
+					RequireChecksum: true,
+				}, c.PluginPolicy)
+			},
+		},
+		{
+			msg:   "plugin_policy is unrestricted by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Zero(t, c.PluginPolicy)
+			},
+		},
+		{
+			msg:         "invalid plugin_policy reference returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PluginPolicy = &catalog.HCLPluginPolicy{
+					Deny: []string{"KeyManager:"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "upstream_bundle_poll_interval is correctly parsed",
+			input: func(c *Config) {
+				c.Server.UpstreamBundlePoll = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Hour, c.UpstreamBundlePollInterval)
+			},
+		},
+		{
+			msg:         "invalid upstream_bundle_poll_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.UpstreamBundlePoll = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "non-positive upstream_bundle_poll_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.UpstreamBundlePoll = "0s"
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
@@ -836,6 +1466,133 @@ func TestNewServerConfig(t *testing.T) {
 				require.True(t, c.RateLimit.Attestation)
 			},
 		},
+		{
+			msg: "stream limits are disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 0, c.RateLimit.StreamsPerCaller)
+			},
+		},
+		{
+			msg: "stream limits can be configured",
+			input: func(c *Config) {
+				c.Server.RateLimit.StreamsPerCaller = 5
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 5, c.RateLimit.StreamsPerCaller)
+			},
+		},
+		{
+			msg:         "negative stream limits are rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.RateLimit.StreamsPerCaller = -1
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle rate limits are disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 0, c.RateLimit.Bundle)
+			},
+		},
+		{
+			msg: "bundle rate limits can be configured",
+			input: func(c *Config) {
+				c.Server.RateLimit.Bundle = 10
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10, c.RateLimit.Bundle)
+			},
+		},
+		{
+			msg:         "negative bundle rate limits are rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.RateLimit.Bundle = -1
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle limits are disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Zero(t, c.BundleLimits)
+			},
+		},
+		{
+			msg: "bundle limits can be configured",
+			input: func(c *Config) {
+				c.Server.BundleLimits.MaxX509Authorities = 10
+				c.Server.BundleLimits.MaxJWTAuthorities = 20
+				c.Server.BundleLimits.MaxBundleBytes = 65536
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10, c.BundleLimits.MaxX509Authorities)
+				require.Equal(t, 20, c.BundleLimits.MaxJWTAuthorities)
+				require.Equal(t, 65536, c.BundleLimits.MaxBundleBytes)
+			},
+		},
+		{
+			msg: "bundle refresh hint bounds can be configured",
+			input: func(c *Config) {
+				c.Server.BundleLimits.MinRefreshHint = "5m"
+				c.Server.BundleLimits.MaxRefreshHint = "24h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 5*time.Minute, c.BundleLimits.MinRefreshHint)
+				require.Equal(t, 24*time.Hour, c.BundleLimits.MaxRefreshHint)
+			},
+		},
+		{
+			msg:         "bundle min refresh hint below the client minimum is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.BundleLimits.MinRefreshHint = "30s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "bundle max refresh hint below the min refresh hint is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.BundleLimits.MinRefreshHint = "1h"
+				c.Server.BundleLimits.MaxRefreshHint = "30m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "unparsable bundle refresh hint bounds are rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.BundleLimits.MaxRefreshHint = "forever"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative bundle limits are rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.BundleLimits.MaxBundleBytes = -1
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -2,6 +2,8 @@ package token
 
 import (
 	"flag"
+	"fmt"
+	"strings"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
@@ -27,6 +29,15 @@ type generateCommand struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Optional token value to use instead of a generated one
+	Token string
+
+	// Optional selectors to add to the entry created for the SPIFFE ID
+	Selectors common_cli.StringsFlag
+
+	// Optional X509-SVID TTL in seconds of the entry created for the SPIFFE ID
+	SVIDTTL int
 }
 
 func (g *generateCommand) Name() string {
@@ -43,10 +54,22 @@ func (g *generateCommand) Run(ctx context.Context, env *common_cli.Env, serverCl
 		return err
 	}
 
+	var selectors []*types.Selector
+	for _, s := range g.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return err
+		}
+		selectors = append(selectors, selector)
+	}
+
 	c := serverClient.NewAgentClient()
 	resp, err := c.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{
-		AgentId: id,
-		Ttl:     int32(g.TTL),
+		AgentId:        id,
+		Ttl:            int32(g.TTL),
+		Token:          g.Token,
+		AgentSelectors: selectors,
+		AgentTtl:       int32(g.SVIDTTL),
 	})
 	if err != nil {
 		return err
@@ -79,7 +102,19 @@ func getID(spiffeID string) (*types.SPIFFEID, error) {
 	}, nil
 }
 
+// parseSelector parses a CLI string from type:value into a selector type.
+func parseSelector(str string) (*types.Selector, error) {
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector %q must be formatted as type:value", str)
+	}
+	return &types.Selector{Type: parts[0], Value: parts[1]}, nil
+}
+
 func (g *generateCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.IntVar(&g.TTL, "ttl", 600, "Token TTL in seconds")
-	fs.StringVar(&g.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
+	fs.StringVar(&g.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional). The path may reference the token value as {{ .Token }}")
+	fs.StringVar(&g.Token, "token", "", "Token value to use instead of a generated one (optional). Generating an existing token again does not fail, which makes the command safe to retry")
+	fs.Var(&g.Selectors, "selector", "A colon-delimited type:value selector to add to the entry created for the SPIFFE ID (optional). Can be used more than once")
+	fs.IntVar(&g.SVIDTTL, "svidTTL", 0, "TTL in seconds of the SVIDs issued for the SPIFFE ID (optional)")
 }
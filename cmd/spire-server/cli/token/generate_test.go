@@ -14,6 +14,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestSynopsis(t *testing.T) {
@@ -44,6 +46,36 @@ func TestCreateToken(t *testing.T) {
 			expectedStdout: "Token: token\n",
 			token:          "token",
 		},
+		{
+			name: "create token with entry template",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/host/{{.Token}}",
+				"-token", "bootstrap",
+				"-selector", "type:value:with:colons",
+				"-selector", "other:value",
+				"-svidTTL", "60",
+			},
+			expectedReq: &agent.CreateJoinTokenRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host/{{.Token}}"},
+				Ttl:     600,
+				Token:   "bootstrap",
+				AgentSelectors: []*types.Selector{
+					{Type: "type", Value: "value:with:colons"},
+					{Type: "other", Value: "value"},
+				},
+				AgentTtl: 60,
+			},
+			expectedStdout: "Token: bootstrap\n",
+			token:          "bootstrap",
+		},
+		{
+			name: "malformed selector",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/agent",
+				"-selector", "invalid",
+			},
+			expectedStderr: "Error: selector \"invalid\" must be formatted as type:value\n",
+		},
 		{
 			name:           "without spiffe ID",
 			expectedStdout: "Token: token\nWarning: Missing SPIFFE ID.\n",
@@ -75,7 +107,7 @@ func TestCreateToken(t *testing.T) {
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			test := setupTest(t)
+			test := setupTest(t, newGenerateCommand)
 			args := append(test.args, tt.args...)
 			test.server.token = tt.token
 			test.server.expectReq = tt.expectedReq
@@ -106,7 +138,7 @@ type tokenTest struct {
 	client cli.Command
 }
 
-func setupTest(t *testing.T) *tokenTest {
+func setupTest(t *testing.T, newCommand func(env *common_cli.Env) cli.Command) *tokenTest {
 	server := &fakeAgentServer{t: t}
 
 	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
@@ -117,7 +149,7 @@ func setupTest(t *testing.T) *tokenTest {
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
 
-	client := newGenerateCommand(&common_cli.Env{
+	client := newCommand(&common_cli.Env{
 		Stdin:  stdin,
 		Stdout: stdout,
 		Stderr: stderr,
@@ -140,6 +172,10 @@ type fakeAgentServer struct {
 	expectReq *agent.CreateJoinTokenRequest
 	err       error
 	token     string
+
+	joinTokens     []*agent.JoinTokenInfo
+	listReqs       []*agent.ListJoinTokensRequest
+	expectPruneReq *agent.PruneJoinTokensRequest
 }
 
 func (f *fakeAgentServer) CreateJoinToken(ctx context.Context, req *agent.CreateJoinTokenRequest) (*types.JoinToken, error) {
@@ -152,3 +188,35 @@ func (f *fakeAgentServer) CreateJoinToken(ctx context.Context, req *agent.Create
 		Value: f.token,
 	}, nil
 }
+
+func (f *fakeAgentServer) ListJoinTokens(ctx context.Context, req *agent.ListJoinTokensRequest) (*agent.ListJoinTokensResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.listReqs = append(f.listReqs, req)
+
+	// Serve one token per page to exercise pagination
+	start := 0
+	if req.PageToken != "" {
+		start = int(req.PageToken[0] - '0')
+	}
+	resp := &agent.ListJoinTokensResponse{}
+	if start < len(f.joinTokens) {
+		joinToken := proto.Clone(f.joinTokens[start]).(*agent.JoinTokenInfo)
+		if !req.IncludeValues {
+			joinToken.Value = ""
+		}
+		resp.JoinTokens = []*agent.JoinTokenInfo{joinToken}
+		resp.NextPageToken = string(rune('0' + start + 1))
+	}
+	return resp, nil
+}
+
+func (f *fakeAgentServer) PruneJoinTokens(ctx context.Context, req *agent.PruneJoinTokensRequest) (*emptypb.Empty, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expectPruneReq, req)
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,133 @@
+package token
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"golang.org/x/net/context"
+)
+
+// listPageSize is the number of join tokens requested per page
+const listPageSize = 500
+
+func NewListCommand() cli.Command {
+	return newListCommand(common_cli.DefaultEnv)
+}
+
+func newListCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(listCommand))
+}
+
+type listCommand struct {
+	// Only list tokens that have expired
+	expired bool
+
+	// Only list tokens that have not expired
+	valid bool
+
+	// Only list tokens that have been used
+	used bool
+
+	// Only list tokens that have not been used
+	unused bool
+
+	// Print the secret token values
+	showValues bool
+}
+
+func (c *listCommand) Name() string {
+	return "token list"
+}
+
+func (c *listCommand) Synopsis() string {
+	return "Lists join tokens"
+}
+
+func (c *listCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.expired && c.valid {
+		return errors.New("the -expired and -valid flags are mutually exclusive")
+	}
+	if c.used && c.unused {
+		return errors.New("the -used and -unused flags are mutually exclusive")
+	}
+
+	req := &agent.ListJoinTokensRequest{
+		PageSize:      listPageSize,
+		IncludeValues: c.showValues,
+	}
+	filter := &agent.ListJoinTokensRequest_Filter{}
+	if c.expired || c.valid {
+		filter.ByExpired = &wrapperspb.BoolValue{Value: c.expired}
+	}
+	if c.used || c.unused {
+		filter.ByUsed = &wrapperspb.BoolValue{Value: c.used}
+	}
+	if filter.ByExpired != nil || filter.ByUsed != nil {
+		req.Filter = filter
+	}
+
+	client := serverClient.NewAgentClient()
+	var joinTokens []*agent.JoinTokenInfo
+	for {
+		resp, err := client.ListJoinTokens(ctx, req)
+		if err != nil {
+			return err
+		}
+		joinTokens = append(joinTokens, resp.JoinTokens...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if len(joinTokens) == 0 {
+		return env.Printf("No join tokens found\n")
+	}
+
+	msg := fmt.Sprintf("Found %d join ", len(joinTokens))
+	msg = util.Pluralizer(msg, "token", "tokens", len(joinTokens))
+	if err := env.Printf(msg + ":\n\n"); err != nil {
+		return err
+	}
+
+	for _, joinToken := range joinTokens {
+		if err := env.Printf("ID              : %s\n", joinToken.Id); err != nil {
+			return err
+		}
+		if c.showValues {
+			if err := env.Printf("Token           : %s\n", joinToken.Value); err != nil {
+				return err
+			}
+		}
+		if err := env.Printf("Expiration time : %s\n", time.Unix(joinToken.ExpiresAt, 0).UTC()); err != nil {
+			return err
+		}
+		usedAt := "never"
+		if joinToken.UsedAt != 0 {
+			usedAt = time.Unix(joinToken.UsedAt, 0).UTC().String()
+		}
+		if err := env.Printf("Used at         : %s\n", usedAt); err != nil {
+			return err
+		}
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *listCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.expired, "expired", false, "Only list tokens that have expired")
+	fs.BoolVar(&c.valid, "valid", false, "Only list tokens that have not expired")
+	fs.BoolVar(&c.used, "used", false, "Only list tokens that have been used")
+	fs.BoolVar(&c.unused, "unused", false, "Only list tokens that have not been used")
+	fs.BoolVar(&c.showValues, "showValues", false, "Print the secret token values")
+}
@@ -0,0 +1,143 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestListSynopsis(t *testing.T) {
+	require.Equal(t, "Lists join tokens", NewListCommand().Synopsis())
+}
+
+func TestListTokens(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+
+		args           []string
+		joinTokens     []*agent.JoinTokenInfo
+		expectedFilter *agent.ListJoinTokensRequest_Filter
+		expectValues   bool
+		expectedStderr string
+		expectedStdout string
+		serverErr      error
+	}{
+		{
+			name: "list tokens",
+			joinTokens: []*agent.JoinTokenInfo{
+				{Id: "id1", Value: "token1", ExpiresAt: 1},
+				{Id: "id2", Value: "token2", ExpiresAt: 2, UsedAt: 1},
+			},
+			expectedStdout: `Found 2 join tokens:
+
+ID              : id1
+Expiration time : 1970-01-01 00:00:01 +0000 UTC
+Used at         : never
+
+ID              : id2
+Expiration time : 1970-01-01 00:00:02 +0000 UTC
+Used at         : 1970-01-01 00:00:01 +0000 UTC
+
+`,
+		},
+		{
+			name: "list tokens with values",
+			args: []string{"-showValues"},
+			joinTokens: []*agent.JoinTokenInfo{
+				{Id: "id1", Value: "token1", ExpiresAt: 1},
+			},
+			expectValues: true,
+			expectedStdout: `Found 1 join token:
+
+ID              : id1
+Token           : token1
+Expiration time : 1970-01-01 00:00:01 +0000 UTC
+Used at         : never
+
+`,
+		},
+		{
+			name:           "no tokens",
+			expectedStdout: "No join tokens found\n",
+		},
+		{
+			name: "expired tokens",
+			args: []string{"-expired"},
+			expectedFilter: &agent.ListJoinTokensRequest_Filter{
+				ByExpired: &wrapperspb.BoolValue{Value: true},
+			},
+			expectedStdout: "No join tokens found\n",
+		},
+		{
+			name: "valid tokens",
+			args: []string{"-valid"},
+			expectedFilter: &agent.ListJoinTokensRequest_Filter{
+				ByExpired: &wrapperspb.BoolValue{Value: false},
+			},
+			expectedStdout: "No join tokens found\n",
+		},
+		{
+			name: "used tokens",
+			args: []string{"-used"},
+			expectedFilter: &agent.ListJoinTokensRequest_Filter{
+				ByUsed: &wrapperspb.BoolValue{Value: true},
+			},
+			expectedStdout: "No join tokens found\n",
+		},
+		{
+			name: "unused expired tokens",
+			args: []string{"-unused", "-expired"},
+			expectedFilter: &agent.ListJoinTokensRequest_Filter{
+				ByExpired: &wrapperspb.BoolValue{Value: true},
+				ByUsed:    &wrapperspb.BoolValue{Value: false},
+			},
+			expectedStdout: "No join tokens found\n",
+		},
+		{
+			name:           "used and unused",
+			args:           []string{"-used", "-unused"},
+			expectedStderr: "Error: the -used and -unused flags are mutually exclusive\n",
+		},
+		{
+			name:           "expired and valid",
+			args:           []string{"-expired", "-valid"},
+			expectedStderr: "Error: the -expired and -valid flags are mutually exclusive\n",
+		},
+		{
+			name:           "server fails to list tokens",
+			expectedStderr: "Error: rpc error: code = Internal desc = server error\n",
+			serverErr:      status.New(codes.Internal, "server error").Err(),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newListCommand)
+			args := append(test.args, tt.args...)
+			test.server.joinTokens = tt.joinTokens
+			test.server.err = tt.serverErr
+
+			rc := test.client.Run(args)
+			if tt.expectedStderr != "" {
+				require.Equal(t, tt.expectedStderr, test.stderr.String())
+				require.Equal(t, 1, rc)
+				return
+			}
+
+			require.Empty(t, test.stderr.String())
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+
+			require.Len(t, test.server.listReqs, len(tt.joinTokens)+1)
+			for _, req := range test.server.listReqs {
+				require.Equal(t, int32(listPageSize), req.PageSize)
+				require.Equal(t, tt.expectValues, req.IncludeValues)
+				spiretest.AssertProtoEqual(t, tt.expectedFilter, req.Filter)
+			}
+		})
+	}
+}
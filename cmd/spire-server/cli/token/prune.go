@@ -0,0 +1,60 @@
+package token
+
+import (
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+
+	"golang.org/x/net/context"
+)
+
+func NewPruneCommand() cli.Command {
+	return newPruneCommand(common_cli.DefaultEnv, time.Now)
+}
+
+func newPruneCommand(env *common_cli.Env, now func() time.Time) cli.Command {
+	return util.AdaptCommand(env, &pruneCommand{now: now})
+}
+
+type pruneCommand struct {
+	now func() time.Time
+
+	// Only prune tokens that expired at least this long ago
+	expiredFor time.Duration
+}
+
+func (c *pruneCommand) Name() string {
+	return "token prune"
+}
+
+func (c *pruneCommand) Synopsis() string {
+	return "Deletes expired join tokens"
+}
+
+func (c *pruneCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.expiredFor < 0 {
+		return errors.New("-expiredFor cannot be negative")
+	}
+
+	// Leaving the time unset lets the server prune based on its own clock
+	req := &agent.PruneJoinTokensRequest{}
+	if c.expiredFor > 0 {
+		req.ExpiresBefore = c.now().Add(-c.expiredFor).Unix()
+	}
+
+	client := serverClient.NewAgentClient()
+	if _, err := client.PruneJoinTokens(ctx, req); err != nil {
+		return err
+	}
+
+	return env.Printf("Expired join tokens pruned\n")
+}
+
+func (c *pruneCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.expiredFor, "expiredFor", 0, "Only prune tokens that expired at least this long ago (e.g. 24h)")
+}
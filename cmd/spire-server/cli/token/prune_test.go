@@ -0,0 +1,75 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPruneSynopsis(t *testing.T) {
+	require.Equal(t, "Deletes expired join tokens", NewPruneCommand().Synopsis())
+}
+
+func TestPruneTokens(t *testing.T) {
+	now := time.Unix(1000, 0)
+	newCommand := func(env *common_cli.Env) cli.Command {
+		return newPruneCommand(env, func() time.Time { return now })
+	}
+
+	for _, tt := range []struct {
+		name string
+
+		args           []string
+		expectedReq    *agent.PruneJoinTokensRequest
+		expectedStderr string
+		expectedStdout string
+		serverErr      error
+	}{
+		{
+			name:           "prune using server time",
+			expectedReq:    &agent.PruneJoinTokensRequest{},
+			expectedStdout: "Expired join tokens pruned\n",
+		},
+		{
+			name:           "prune tokens expired for a while",
+			args:           []string{"-expiredFor", "1m"},
+			expectedReq:    &agent.PruneJoinTokensRequest{ExpiresBefore: 940},
+			expectedStdout: "Expired join tokens pruned\n",
+		},
+		{
+			name:           "negative duration",
+			args:           []string{"-expiredFor", "-1m"},
+			expectedStderr: "Error: -expiredFor cannot be negative\n",
+		},
+		{
+			name:           "server fails to prune tokens",
+			expectedStderr: "Error: rpc error: code = Internal desc = server error\n",
+			serverErr:      status.New(codes.Internal, "server error").Err(),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newCommand)
+			args := append(test.args, tt.args...)
+			test.server.expectPruneReq = tt.expectedReq
+			test.server.err = tt.serverErr
+
+			rc := test.client.Run(args)
+			if tt.expectedStderr != "" {
+				require.Equal(t, tt.expectedStderr, test.stderr.String())
+				require.Equal(t, 1, rc)
+				return
+			}
+
+			require.Empty(t, test.stderr.String())
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+		})
+	}
+}
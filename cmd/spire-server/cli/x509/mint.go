@@ -47,6 +47,7 @@ type mintCommand struct {
 	spiffeID string
 	ttl      time.Duration
 	dnsNames common_cli.StringsFlag
+	csrPath  string
 	write    string
 }
 
@@ -62,32 +63,23 @@ func (c *mintCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID of the X509-SVID")
 	fs.DurationVar(&c.ttl, "ttl", 0, "TTL of the X509-SVID")
 	fs.Var(&c.dnsNames, "dns", "DNS name that will be included in SVID. Can be used more than once.")
+	fs.StringVar(&c.csrPath, "csr", "", "Path to a PEM or DER encoded CSR holding the SPIFFE ID as its URI SAN. The private key stays with the requester")
 	fs.StringVar(&c.write, "write", "", "Directory to write output to instead of stdout")
 }
 
 func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
-	if c.spiffeID == "" {
-		return errors.New("spiffeID must be specified")
+	var key crypto.Signer
+	var csr []byte
+	var err error
+	if c.csrPath != "" {
+		csr, err = c.loadCSR(env)
+	} else {
+		key, csr, err = c.generateCSR()
 	}
-
-	id, err := spiffeid.FromString(c.spiffeID)
 	if err != nil {
 		return err
 	}
 
-	key, err := c.generateKey()
-	if err != nil {
-		return fmt.Errorf("unable to generate key: %v", err)
-	}
-
-	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
-		URIs:     []*url.URL{id.URL()},
-		DNSNames: c.dnsNames,
-	}, key)
-	if err != nil {
-		return fmt.Errorf("unable to generate CSR: %v", err)
-	}
-
 	client := serverClient.NewSVIDClient()
 	resp, err := client.MintX509SVID(ctx, &svid.MintX509SVIDRequest{
 		Csr: csr,
@@ -116,11 +108,6 @@ func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		env.ErrPrintf("X509-SVID lifetime was capped shorter than specified ttl; expires %q\n", eol.UTC().Format(time.RFC3339))
 	}
 
-	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
-	if err != nil {
-		return err
-	}
-
 	svidPEM := new(bytes.Buffer)
 	for _, certDER := range resp.Svid.CertChain {
 		_ = pem.Encode(svidPEM, &pem.Block{
@@ -129,11 +116,18 @@ func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		})
 	}
 
-	keyPEM := new(bytes.Buffer)
-	_ = pem.Encode(keyPEM, &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: keyBytes,
-	})
+	var keyPEM *bytes.Buffer
+	if key != nil {
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return err
+		}
+		keyPEM = new(bytes.Buffer)
+		_ = pem.Encode(keyPEM, &pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: keyBytes,
+		})
+	}
 
 	bundlePEM := new(bytes.Buffer)
 	for _, rootCA := range ca.X509Authorities {
@@ -147,8 +141,10 @@ func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		if err := env.Printf("X509-SVID:\n%s\n", svidPEM.String()); err != nil {
 			return err
 		}
-		if err := env.Printf("Private key:\n%s\n", keyPEM.String()); err != nil {
-			return err
+		if keyPEM != nil {
+			if err := env.Printf("Private key:\n%s\n", keyPEM.String()); err != nil {
+				return err
+			}
 		}
 		if err := env.Printf("Root CAs:\n%s\n", bundlePEM.String()); err != nil {
 			return err
@@ -167,11 +163,13 @@ func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		return err
 	}
 
-	if err := ioutil.WriteFile(keyPath, keyPEM.Bytes(), 0600); err != nil {
-		return fmt.Errorf("unable to write key: %v", err)
-	}
-	if err := env.Printf("Private key written to %s\n", keyPath); err != nil {
-		return err
+	if keyPEM != nil {
+		if err := ioutil.WriteFile(keyPath, keyPEM.Bytes(), 0600); err != nil {
+			return fmt.Errorf("unable to write key: %v", err)
+		}
+		if err := env.Printf("Private key written to %s\n", keyPath); err != nil {
+			return err
+		}
 	}
 
 	if err := ioutil.WriteFile(bundlePath, bundlePEM.Bytes(), 0644); err != nil { // nolint: gosec // expected permission
@@ -184,6 +182,74 @@ func (c *mintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 	return nil
 }
 
+// generateCSR generates a key and a CSR for the SPIFFE ID and DNS names
+// provided through the flags.
+func (c *mintCommand) generateCSR() (crypto.Signer, []byte, error) {
+	if c.spiffeID == "" {
+		return nil, nil, errors.New("spiffeID must be specified")
+	}
+
+	id, err := spiffeid.FromString(c.spiffeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := c.generateKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate key: %v", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		URIs:     []*url.URL{id.URL()},
+		DNSNames: c.dnsNames,
+	}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate CSR: %v", err)
+	}
+	return key, csr, nil
+}
+
+// loadCSR loads an externally generated CSR. The CSR must carry the SPIFFE ID
+// as its only URI SAN. If the spiffeID flag is set, it must match.
+func (c *mintCommand) loadCSR(env *common_cli.Env) ([]byte, error) {
+	if len(c.dnsNames) > 0 {
+		return nil, errors.New("DNS names cannot be specified with a CSR; include them in the CSR instead")
+	}
+
+	csrBytes, err := ioutil.ReadFile(env.JoinPath(c.csrPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSR: %v", err)
+	}
+	if block, _ := pem.Decode(csrBytes); block != nil {
+		if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+			return nil, fmt.Errorf("unexpected PEM block type %q in CSR file", block.Type)
+		}
+		csrBytes = block.Bytes
+	}
+
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CSR: %v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid CSR signature: %v", err)
+	}
+	if len(csr.URIs) != 1 {
+		return nil, errors.New("CSR must have exactly one URI SAN holding the SPIFFE ID")
+	}
+
+	if c.spiffeID != "" {
+		id, err := spiffeid.FromString(c.spiffeID)
+		if err != nil {
+			return nil, err
+		}
+		if csr.URIs[0].String() != id.String() {
+			return nil, fmt.Errorf("CSR URI SAN %q does not match SPIFFE ID %q", csr.URIs[0], id)
+		}
+	}
+	return csrBytes, nil
+}
+
 // ttlToSeconds returns the number of seconds in a duration, rounded up to
 // the nearest second
 func ttlToSeconds(ttl time.Duration) int32 {
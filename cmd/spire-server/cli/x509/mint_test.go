@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net/url"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -32,6 +34,8 @@ import (
 
 const (
 	expectedUsage = `Usage of x509 mint:
+  -csr string
+    	Path to a PEM or DER encoded CSR holding the SPIFFE ID as its URI SAN. The private key stays with the requester
   -dns value
     	DNS name that will be included in SVID. Can be used more than once.
   -registrationUDSPath string
@@ -107,6 +111,26 @@ func TestMintRun(t *testing.T) {
 		Bytes: certDER,
 	}))
 
+	writeCSR := func(name string, uris ...string) {
+		tmpl := &x509.CertificateRequest{}
+		for _, uri := range uris {
+			u, err := url.Parse(uri)
+			require.NoError(t, err)
+			tmpl.URIs = append(tmpl.URIs, u)
+		}
+		csrDER, err := x509.CreateCertificateRequest(rand.Reader, tmpl, testKey)
+		require.NoError(t, err)
+		csrPEM := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE REQUEST",
+			Bytes: csrDER,
+		})
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), csrPEM, 0600))
+	}
+	writeCSR("csr.pem", "spiffe://domain.test/appliance")
+	writeCSR("csr-no-uri.pem")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "not-a-csr.pem"), []byte(testKeyPEM), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "csr-out"), 0755))
+
 	server := new(fakeSVIDServer)
 	spiretest.StartGRPCSocketServer(t, util.DefaultSocketPath, func(s *grpc.Server) {
 		svidpb.RegisterSVIDServer(s, server)
@@ -136,6 +160,7 @@ func TestMintRun(t *testing.T) {
 		spiffeID   string
 		ttl        time.Duration
 		dnsNames   []string
+		csr        string
 		socketPath string
 		write      string
 		extraArgs  []string
@@ -167,6 +192,43 @@ func TestMintRun(t *testing.T) {
 			stderr:            "Error: spiffeid: invalid scheme\n",
 			noRequestExpected: true,
 		},
+		{
+			name:              "CSR with DNS names",
+			code:              1,
+			csr:               "csr.pem",
+			dnsNames:          []string{"appliance.domain.test"},
+			stderr:            "Error: DNS names cannot be specified with a CSR; include them in the CSR instead\n",
+			noRequestExpected: true,
+		},
+		{
+			name:              "CSR file does not exist",
+			code:              1,
+			csr:               "missing.pem",
+			stderr:            fmt.Sprintf("Error: unable to read CSR: open %s: no such file or directory\n", filepath.Join(dir, "missing.pem")),
+			noRequestExpected: true,
+		},
+		{
+			name:              "CSR file holds another PEM block",
+			code:              1,
+			csr:               "not-a-csr.pem",
+			stderr:            "Error: unexpected PEM block type \"PRIVATE KEY\" in CSR file\n",
+			noRequestExpected: true,
+		},
+		{
+			name:              "CSR without URI SAN",
+			code:              1,
+			csr:               "csr-no-uri.pem",
+			stderr:            "Error: CSR must have exactly one URI SAN holding the SPIFFE ID\n",
+			noRequestExpected: true,
+		},
+		{
+			name:              "CSR URI SAN does not match spiffeID",
+			code:              1,
+			csr:               "csr.pem",
+			spiffeID:          "spiffe://domain.test/workload",
+			stderr:            "Error: CSR URI SAN \"spiffe://domain.test/appliance\" does not match SPIFFE ID \"spiffe://domain.test/workload\"\n",
+			noRequestExpected: true,
+		},
 		{
 			name:              "invalid flag",
 			code:              1,
@@ -249,6 +311,32 @@ func TestMintRun(t *testing.T) {
 			bundle: bundle,
 			stderr: fmt.Sprintf("X509-SVID lifetime was capped shorter than specified ttl; expires %q\n", notAfter.UTC().Format(time.RFC3339)),
 		},
+		{
+			name:     "success with CSR",
+			spiffeID: "spiffe://domain.test/appliance",
+			csr:      "csr.pem",
+			code:     0,
+			resp: &svidpb.MintX509SVIDResponse{
+				Svid: &types.X509SVID{
+					CertChain: [][]byte{certDER},
+					ExpiresAt: time.Now().Add(time.Minute).Unix(),
+				},
+			},
+			bundle: bundle,
+		},
+		{
+			name:  "success with CSR, written to directory",
+			csr:   "csr.pem",
+			code:  0,
+			write: "csr-out",
+			resp: &svidpb.MintX509SVIDResponse{
+				Svid: &types.X509SVID{
+					CertChain: [][]byte{certDER},
+					ExpiresAt: time.Now().Add(time.Minute).Unix(),
+				},
+			},
+			bundle: bundle,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -290,6 +378,9 @@ func TestMintRun(t *testing.T) {
 			for _, dnsName := range testCase.dnsNames {
 				args = append(args, "-dns", dnsName)
 			}
+			if testCase.csr != "" {
+				args = append(args, "-csr", testCase.csr)
+			}
 			args = append(args, testCase.extraArgs...)
 
 			code := cmd.Run(args)
@@ -308,7 +399,11 @@ func TestMintRun(t *testing.T) {
 				csr, err := x509.ParseCertificateRequest(req.Csr)
 				require.NoError(t, err)
 
-				id := spiffeid.RequireFromString(testCase.spiffeID)
+				spiffeID := testCase.spiffeID
+				if testCase.csr != "" {
+					spiffeID = "spiffe://domain.test/appliance"
+				}
+				id := spiffeid.RequireFromString(spiffeID)
 				require.Equal(t, id.URL(), csr.URIs[0])
 
 				require.Equal(t, testCase.dnsNames, csr.DNSNames)
@@ -316,7 +411,24 @@ func TestMintRun(t *testing.T) {
 			}
 
 			// assert output file contents
-			if code == 0 {
+			if code == 0 && testCase.csr != "" {
+				if testCase.write != "" {
+					outDir := filepath.Join(dir, testCase.write)
+					assert.Equal(t, fmt.Sprintf(`X509-SVID written to %s
+Root CAs written to %s
+`, filepath.Join(outDir, "svid.pem"), filepath.Join(outDir, "bundle.pem")),
+						stdout.String(), "stdout does not write output paths")
+					assertFileData(t, filepath.Join(outDir, "svid.pem"), svidPEM)
+					assertFileData(t, filepath.Join(outDir, "bundle.pem"), testX509Authority)
+					assert.NoFileExists(t, filepath.Join(outDir, "key.pem"))
+				} else {
+					assert.Equal(t, fmt.Sprintf(`X509-SVID:
+%s
+Root CAs:
+%s
+`, svidPEM, testX509Authority), stdout.String(), "stdout does not write out PEM")
+				}
+			} else if code == 0 {
 				if testCase.write != "" {
 					assert.Equal(t, fmt.Sprintf(`X509-SVID written to %s
 Private key written to %s
@@ -2,14 +2,17 @@ package util
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 
+	"github.com/spiffe/spire/pkg/common/api"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/svid/v1"
 	"google.golang.org/grpc"
@@ -20,6 +23,15 @@ const (
 	DefaultSocketPath = "/tmp/spire-registration.sock"
 )
 
+// Exit codes returned by commands. Scripts can rely on these to tell a batch
+// operation that was only partially applied apart from one that failed
+// entirely (e.g. invalid arguments, server unreachable, every item rejected).
+const (
+	ExitCodeOK             = 0
+	ExitCodeFailure        = 1
+	ExitCodePartialFailure = 2
+)
+
 func NewRegistrationClient(socketPath string) (registration.RegistrationClient, error) {
 	conn, err := Dial(socketPath)
 	if err != nil {
@@ -32,12 +44,17 @@ func Dial(socketPath string) (*grpc.ClientConn, error) {
 	if socketPath == "" {
 		socketPath = DefaultSocketPath
 	}
+	// Report the CLI version so that operators can tell which versions of
+	// the tooling are still calling the server.
+	clientVersionUnary, clientVersionStream := api.ClientVersionInterceptors("spire-server")
 	return grpc.Dial(socketPath,
 		grpc.WithInsecure(),
 		grpc.WithContextDialer(dialer),
 		grpc.WithBlock(),
 		grpc.FailOnNonTempDialError(true),
-		grpc.WithReturnConnectionError())
+		grpc.WithReturnConnectionError(),
+		grpc.WithChainUnaryInterceptor(clientVersionUnary),
+		grpc.WithChainStreamInterceptor(clientVersionStream))
 }
 
 func dialer(ctx context.Context, addr string) (net.Conn, error) {
@@ -48,6 +65,7 @@ type ServerClient interface {
 	Release()
 	NewAgentClient() agent.AgentClient
 	NewBundleClient() bundle.BundleClient
+	NewDebugClient() debug.DebugClient
 	NewEntryClient() entry.EntryClient
 	NewSVIDClient() svid.SVIDClient
 	NewHealthClient() grpc_health_v1.HealthClient
@@ -77,6 +95,10 @@ func (c *serverClient) NewBundleClient() bundle.BundleClient {
 	return bundle.NewBundleClient(c.conn)
 }
 
+func (c *serverClient) NewDebugClient() debug.DebugClient {
+	return debug.NewDebugClient(c.conn)
+}
+
 func (c *serverClient) NewEntryClient() entry.EntryClient {
 	return entry.NewEntryClient(c.conn)
 }
@@ -112,6 +134,40 @@ type Command interface {
 	Run(context.Context, *common_cli.Env, ServerClient) error
 }
 
+// BatchError is returned by commands operating on a batch of items when one or
+// more of those items failed.
+type BatchError struct {
+	Msg       string
+	Succeeded int
+	Failed    int
+}
+
+// NewBatchError returns a BatchError with the given message and result counts.
+func NewBatchError(msg string, succeeded, failed int) *BatchError {
+	return &BatchError{
+		Msg:       msg,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+func (e *BatchError) Error() string {
+	return e.Msg
+}
+
+// ExitCode returns the exit code that should be reported for the given
+// command error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) && batchErr.Succeeded > 0 {
+		return ExitCodePartialFailure
+	}
+	return ExitCodeFailure
+}
+
 type Adapter struct {
 	env *common_cli.Env
 	cmd Command
@@ -141,22 +197,22 @@ func (a *Adapter) Run(args []string) int {
 
 	if err := a.flags.Parse(args); err != nil {
 		fmt.Fprintln(a.env.Stderr)
-		return 1
+		return ExitCodeFailure
 	}
 
 	client, err := NewServerClient(a.registrationUDSPath)
 	if err != nil {
 		fmt.Fprintln(a.env.Stderr, "Error: "+err.Error())
-		return 1
+		return ExitCodeFailure
 	}
 	defer client.Release()
 
 	if err := a.cmd.Run(ctx, a.env, client); err != nil {
 		fmt.Fprintln(a.env.Stderr, "Error: "+err.Error())
-		return 1
+		return ExitCode(err)
 	}
 
-	return 0
+	return ExitCodeOK
 }
 
 func (a *Adapter) Help() string {
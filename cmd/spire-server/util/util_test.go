@@ -0,0 +1,17 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	require.Equal(t, ExitCodeOK, ExitCode(nil))
+	require.Equal(t, ExitCodeFailure, ExitCode(errors.New("oh no")))
+	require.Equal(t, ExitCodeFailure, ExitCode(NewBatchError("all failed", 0, 2)))
+	require.Equal(t, ExitCodePartialFailure, ExitCode(NewBatchError("some failed", 1, 1)))
+	require.Equal(t, ExitCodePartialFailure, ExitCode(fmt.Errorf("wrapped: %w", NewBatchError("some failed", 1, 1))))
+}
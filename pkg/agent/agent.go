@@ -4,22 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
 	"os"
 	"path"
 	"runtime"
 	"sync"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	workload_pb "github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	api_workload "github.com/spiffe/spire/api/workload"
 	admin_api "github.com/spiffe/spire/pkg/agent/api"
 	node_attestor "github.com/spiffe/spire/pkg/agent/attestor/node"
 	workload_attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/helper"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	common_catalog "github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
@@ -32,6 +39,8 @@ import (
 	_ "golang.org/x/net/trace" // registers handlers on the DefaultServeMux
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
 )
 
@@ -48,6 +57,13 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	if a.c.LockMemory {
+		if err := cryptoutil.LockMemory(); err != nil {
+			return fmt.Errorf("failed to lock memory: %w", err)
+		}
+		a.c.Log.Info("Memory locked; key material will not be swapped to disk")
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -69,6 +85,8 @@ func (a *Agent) Run(ctx context.Context) error {
 	})
 
 	telemetry.EmitVersion(metrics)
+	a.emitLifecycleStarted(metrics)
+	defer a.emitLifecycleStopped(metrics)
 	uptime.ReportMetrics(ctx, metrics)
 
 	cat, err := catalog.Load(ctx, catalog.Config{
@@ -77,6 +95,7 @@ func (a *Agent) Run(ctx context.Context) error {
 			TrustDomain: a.c.TrustDomain.Host,
 		},
 		PluginConfig: a.c.PluginConfigs,
+		PluginPolicy: a.c.PluginPolicy,
 		HostServices: []common_catalog.HostServiceServer{
 			common_services.MetricsServiceHostServiceServer(metricsService),
 		},
@@ -87,6 +106,12 @@ func (a *Agent) Run(ctx context.Context) error {
 	}
 	defer cat.Close()
 
+	workloadCatalog, closeHelper, err := a.withPrivilegedHelper(cat)
+	if err != nil {
+		return err
+	}
+	defer closeHelper()
+
 	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
 
 	as, err := a.attest(ctx, cat, metrics)
@@ -99,7 +124,7 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	endpoints := a.newEndpoints(cat, metrics, manager)
+	endpoints := a.newEndpoints(workloadCatalog, metrics, manager)
 
 	if err := healthChecks.AddCheck("agent", a); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
@@ -195,6 +220,7 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemet
 		SVIDCachePath:         a.agentSVIDPath(),
 		Log:                   a.c.Log.WithField(telemetry.SubsystemName, telemetry.Attestor),
 		ServerAddress:         a.c.ServerAddress,
+		ServerResolver:        a.serverResolver(),
 		CreateNewAgentClient:  agent.NewAgentClient,
 		CreateNewBundleClient: bundle.NewBundleClient,
 	}
@@ -209,11 +235,19 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		Catalog:         cat,
 		TrustDomain:     a.c.TrustDomain,
 		ServerAddr:      a.c.ServerAddress,
+		ServerResolver:  a.serverResolver(),
 		Log:             a.c.Log.WithField(telemetry.SubsystemName, telemetry.Manager),
 		Metrics:         metrics,
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		SyncInterval:    a.c.SyncInterval,
+		HedgeDelay:      a.c.HedgeDelay,
+
+		BundleSyncInterval: a.c.BundleSyncInterval,
+		MaxSyncInterval:    a.c.MaxSyncInterval,
+		SelectorRedactor:   a.c.SelectorRedactor,
+		KeyReuse:           a.c.KeyReuse,
+		Clk:                a.c.Clock,
 	}
 
 	mgr := manager.New(config)
@@ -224,19 +258,55 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 	return mgr, nil
 }
 
+// withPrivilegedHelper returns a catalog that includes the workload attestors
+// run by the privileged helper, if configured, along with a function to close
+// the connection to the helper.
+func (a *Agent) withPrivilegedHelper(cat catalog.Catalog) (catalog.Catalog, func(), error) {
+	closeHelper := func() {}
+	if a.c.PrivilegedHelper != nil {
+		conn, err := helper.Dial(a.c.PrivilegedHelper.BindAddress)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to privileged helper: %w", err)
+		}
+		closeHelper = func() { conn.Close() }
+
+		a.c.Log.WithFields(logrus.Fields{
+			telemetry.Address:   a.c.PrivilegedHelper.BindAddress.String(),
+			telemetry.Attestors: a.c.PrivilegedHelper.WorkloadAttestors,
+		}).Info("Using privileged helper for workload attestation")
+		cat = helper.WithWorkloadAttestors(cat, helper.WorkloadAttestors(conn, a.c.PrivilegedHelper.WorkloadAttestors))
+	}
+
+	if len(cat.GetWorkloadAttestors()) == 0 {
+		closeHelper()
+		return nil, nil, errors.New("at least one workload attestor must be configured")
+	}
+	return cat, closeHelper, nil
+}
+
 func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager) endpoints.Server {
+	var listener net.Listener
+	if a.c.InMemoryWorkloadAPI != nil {
+		listener = a.c.InMemoryWorkloadAPI.listener
+	}
 	return endpoints.New(endpoints.Config{
 		BindAddr: a.c.BindAddress,
+		Listener: listener,
 		Attestor: workload_attestor.New(&workload_attestor.Config{
 			Catalog: cat,
 			Log:     a.c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAttestor),
 			Metrics: metrics,
+
+			SelectorRedactor: a.c.SelectorRedactor,
 		}),
 		Manager:           mgr,
 		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:           metrics,
 		DefaultSVIDName:   a.c.DefaultSVIDName,
 		DefaultBundleName: a.c.DefaultBundleName,
+		Limits:            a.c.WorkloadAPILimits,
+		Socket:            a.c.WorkloadAPISocket,
+		Audit:             a.c.WorkloadAPIAudit,
 	})
 }
 
@@ -255,6 +325,16 @@ func (a *Agent) newAdminEndpoints(mgr manager.Manager) (admin_api.Server, error)
 
 	return admin_api.New(config), nil
 }
+
+// serverResolver returns the resolver builder used to resolve the server
+// address, or nil if the default gRPC resolvers should be used.
+func (a *Agent) serverResolver() resolver.Builder {
+	if a.c.ServerResolver == nil {
+		return nil
+	}
+	return client.NewResolverBuilder(a.c.Log.WithField(telemetry.SubsystemName, telemetry.Resolver), *a.c.ServerResolver)
+}
+
 func (a *Agent) bundleCachePath() string {
 	return path.Join(a.c.DataDir, "bundle.der")
 }
@@ -265,6 +345,10 @@ func (a *Agent) agentSVIDPath() string {
 
 // Status is used as a top-level health check for the Agent.
 func (a *Agent) Status() (interface{}, error) {
+	if a.c.InMemoryWorkloadAPI != nil {
+		return a.inMemoryStatus()
+	}
+
 	client := api_workload.NewX509Client(&api_workload.X509ClientConfig{
 		Addr:        a.c.BindAddress,
 		FailOnError: true,
@@ -285,3 +369,62 @@ func (a *Agent) Status() (interface{}, error) {
 		Message: "successfully created a workload api client to fetch x509 svid",
 	}, nil
 }
+
+// inMemoryStatus checks that the in-memory workload api is served
+func (a *Agent) inMemoryStatus() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := a.c.InMemoryWorkloadAPI.Dial(ctx)
+	if err != nil {
+		return nil, errors.New("workload api is unavailable") //nolint: golint // error is (ab)used for CLI output
+	}
+	defer conn.Close()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "workload.spiffe.io", "true")
+	stream, err := workload_pb.NewSpiffeWorkloadAPIClient(conn).FetchX509SVID(ctx, &workload_pb.X509SVIDRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+		return nil, errors.New("workload api is unavailable") //nolint: golint // error is (ab)used for CLI output
+	}
+
+	return health.Details{
+		Message: "successfully created an in-memory workload api client to fetch x509 svid",
+	}, nil
+}
+
+// experimentalFeatures returns the names of the enabled experimental
+// features, as they appear in the configuration file.
+func (a *Agent) experimentalFeatures() []string {
+	var enabled []string
+	if a.c.SyncInterval != 0 {
+		enabled = append(enabled, "sync_interval")
+	}
+	if a.c.BundleSyncInterval != 0 {
+		enabled = append(enabled, "bundle_sync_interval")
+	}
+	if a.c.MaxSyncInterval != 0 {
+		enabled = append(enabled, "max_sync_interval")
+	}
+	if a.c.HedgeDelay > 0 {
+		enabled = append(enabled, "hedge_delay")
+	}
+	return enabled
+}
+
+func (a *Agent) emitLifecycleStarted(metrics telemetry.Metrics) {
+	buildInfo := telemetry.NewBuildInfo(a.experimentalFeatures()...)
+	telemetry.EmitBuildInfo(metrics, buildInfo)
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStarted)
+	a.c.Log.WithFields(buildInfo.Fields()).WithField(telemetry.Event, telemetry.LifecycleStarted).Info("Agent lifecycle event")
+}
+
+func (a *Agent) emitLifecycleStopped(metrics telemetry.Metrics) {
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStopped)
+	a.c.Log.WithFields(logrus.Fields{
+		telemetry.Event:       telemetry.LifecycleStopped,
+		telemetry.ElapsedTime: uptime.Uptime().String(),
+	}).Info("Agent lifecycle event")
+}
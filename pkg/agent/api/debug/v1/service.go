@@ -3,6 +3,7 @@ package debug
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
 	"sync"
 	"time"
 
@@ -21,6 +22,19 @@ import (
 
 const (
 	cacheExpiry = 5 * time.Second
+
+	// syncStalenessThreshold is the amount of time after the last successful
+	// sync with the server at which the server connectivity is considered
+	// unhealthy.
+	syncStalenessThreshold = 5 * time.Minute
+)
+
+// Names of the components reported by GetHealthStatus
+const (
+	componentAttestation        = "attestation"
+	componentServerConnectivity = "server_connectivity"
+	componentAgentSVID          = "agent_svid"
+	componentCache              = "cache"
 )
 
 // RegisterService registers debug service on provided server
@@ -92,19 +106,100 @@ func (s *Service) GetInfo(ctx context.Context, req *debug.GetInfoRequest) (*debu
 			})
 		}
 
+		rotationStatus := s.m.GetSVIDRotationStatus()
+
 		// Reset clock and set current response
 		s.getInfoResp.ts = s.clock.Now()
 		s.getInfoResp.resp = &debug.GetInfoResponse{
-			SvidChain:       svidChain,
-			Uptime:          int32(s.uptime().Seconds()),
-			SvidsCount:      int32(s.m.CountSVIDs()),
-			LastSyncSuccess: s.m.GetLastSync().UTC().Unix(),
+			SvidChain:              svidChain,
+			Uptime:                 int32(s.uptime().Seconds()),
+			SvidsCount:             int32(s.m.CountSVIDs()),
+			LastSyncSuccess:        s.m.GetLastSync().UTC().Unix(),
+			SvidRotationDeadline:   unixOrZero(rotationStatus.Deadline),
+			LastSvidRotation:       unixOrZero(rotationStatus.LastRotation),
+			LastSvidRotationReason: string(rotationStatus.LastRotationReason),
 		}
 	}
 
 	return s.getInfoResp.resp, nil
 }
 
+// GetHealthStatus gets the health status of the SPIRE Agent components
+func (s *Service) GetHealthStatus(ctx context.Context, req *debug.GetHealthStatusRequest) (*debug.GetHealthStatusResponse, error) {
+	now := s.clock.Now()
+	state := s.m.GetCurrentCredentials()
+
+	components := []*debug.GetHealthStatusResponse_Component{
+		attestationStatus(state.SVID),
+		serverConnectivityStatus(now, s.m.GetLastSync()),
+		agentSVIDStatus(now, state.SVID),
+		{
+			Name:    componentCache,
+			Healthy: true,
+			Details: fmt.Sprintf("%d SVIDs cached", s.m.CountSVIDs()),
+		},
+	}
+
+	healthy := true
+	for _, component := range components {
+		healthy = healthy && component.Healthy
+	}
+
+	return &debug.GetHealthStatusResponse{
+		Healthy:    healthy,
+		Components: components,
+	}, nil
+}
+
+func attestationStatus(svid []*x509.Certificate) *debug.GetHealthStatusResponse_Component {
+	component := &debug.GetHealthStatusResponse_Component{
+		Name: componentAttestation,
+	}
+	if len(svid) == 0 {
+		component.Details = "agent is not attested"
+		return component
+	}
+	id, err := x509svid.IDFromCert(svid[0])
+	if err != nil {
+		component.Details = fmt.Sprintf("agent SVID is invalid: %v", err)
+		return component
+	}
+	component.Healthy = true
+	component.Details = fmt.Sprintf("attested as %q", id)
+	return component
+}
+
+func serverConnectivityStatus(now, lastSync time.Time) *debug.GetHealthStatusResponse_Component {
+	component := &debug.GetHealthStatusResponse_Component{
+		Name: componentServerConnectivity,
+	}
+	if lastSync.IsZero() {
+		component.Details = "agent has not synced with the server"
+		return component
+	}
+	component.Healthy = now.Sub(lastSync) < syncStalenessThreshold
+	component.Details = fmt.Sprintf("last successful sync with the server was at %s", lastSync.UTC().Format(time.RFC3339))
+	return component
+}
+
+func agentSVIDStatus(now time.Time, svid []*x509.Certificate) *debug.GetHealthStatusResponse_Component {
+	component := &debug.GetHealthStatusResponse_Component{
+		Name: componentAgentSVID,
+	}
+	if len(svid) == 0 {
+		component.Details = "agent has no SVID"
+		return component
+	}
+	expiresAt := svid[0].NotAfter.UTC().Format(time.RFC3339)
+	if !now.Before(svid[0].NotAfter) {
+		component.Details = fmt.Sprintf("agent SVID expired at %s", expiresAt)
+		return component
+	}
+	component.Healthy = true
+	component.Details = fmt.Sprintf("agent SVID expires at %s", expiresAt)
+	return component
+}
+
 // spiffeIDFromCert gets types SPIFFE ID from certificate, it can be nil
 func spiffeIDFromCert(cert *x509.Certificate) *types.SPIFFEID {
 	id, err := x509svid.IDFromCert(cert)
@@ -132,3 +227,11 @@ func (s *Service) getCertificateChain(svid []*x509.Certificate) ([]*x509.Certifi
 
 	return certs[0], nil
 }
+
+// unixOrZero returns the unix time of t, or zero if t is not set
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
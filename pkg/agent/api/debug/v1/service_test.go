@@ -87,6 +87,8 @@ func TestGetInfo(t *testing.T) {
 	clk := clock.NewMock(t)
 	lastSync := clk.Now()
 	cachedLastSync := clk.Now().Add(time.Minute)
+	rotationDeadline := clk.Now().Add(time.Hour)
+	lastRotation := clk.Now().Add(-time.Hour)
 
 	for _, tt := range []struct {
 		name string
@@ -96,11 +98,12 @@ func TestGetInfo(t *testing.T) {
 		expectResp   *debugpb.GetInfoResponse
 		expectedLogs []spiretest.LogEntry
 		// Time to add to clock.Mock
-		addToClk  time.Duration
-		initCache bool
-		lastSync  time.Time
-		svidCount int
-		svidState svid.State
+		addToClk       time.Duration
+		initCache      bool
+		lastSync       time.Time
+		svidCount      int
+		svidState      svid.State
+		rotationStatus svid.RotationStatus
 	}{
 		{
 			name:      "svid without intermediate",
@@ -124,6 +127,25 @@ func TestGetInfo(t *testing.T) {
 				SvidChain:       svidWithIntermediateChain,
 			},
 		},
+		{
+			name:      "svid rotation status",
+			lastSync:  lastSync,
+			svidState: x509SVIDState,
+			svidCount: 123,
+			rotationStatus: svid.RotationStatus{
+				Deadline:           rotationDeadline,
+				LastRotation:       lastRotation,
+				LastRotationReason: svid.RotationReasonExpiryThreshold,
+			},
+			expectResp: &debugpb.GetInfoResponse{
+				LastSyncSuccess:        lastSync.UTC().Unix(),
+				SvidsCount:             123,
+				SvidChain:              x509SVIDChain,
+				SvidRotationDeadline:   rotationDeadline.Unix(),
+				LastSvidRotation:       lastRotation.Unix(),
+				LastSvidRotationReason: "expiry_threshold",
+			},
+		},
 		{
 			name: "get response from cache",
 			expectResp: &debugpb.GetInfoResponse{
@@ -191,6 +213,7 @@ func TestGetInfo(t *testing.T) {
 			test.m.svidCount = tt.svidCount
 			test.m.svidState = tt.svidState
 			test.m.lastSync = tt.lastSync
+			test.m.rotationStatus = tt.rotationStatus
 
 			resp, err := test.client.GetInfo(ctx, &debugpb.GetInfoRequest{})
 
@@ -208,6 +231,77 @@ func TestGetInfo(t *testing.T) {
 	}
 }
 
+func TestGetHealthStatus(t *testing.T) {
+	ca := testca.New(t, td)
+	x509SVID := ca.CreateX509SVID(td.NewID("/spire/agent/foo"))
+	expiresAt := x509SVID.Certificates[0].NotAfter
+
+	for _, tt := range []struct {
+		name       string
+		now        time.Time
+		lastSync   time.Time
+		svidState  svid.State
+		expectResp *debugpb.GetHealthStatusResponse
+	}{
+		{
+			name:      "healthy",
+			now:       expiresAt.Add(-time.Hour),
+			lastSync:  expiresAt.Add(-time.Hour - time.Minute),
+			svidState: svid.State{SVID: x509SVID.Certificates},
+			expectResp: &debugpb.GetHealthStatusResponse{
+				Healthy: true,
+				Components: []*debugpb.GetHealthStatusResponse_Component{
+					{Name: "attestation", Healthy: true, Details: `attested as "spiffe://example.org/spire/agent/foo"`},
+					{Name: "server_connectivity", Healthy: true, Details: "last successful sync with the server was at " + expiresAt.Add(-time.Hour-time.Minute).UTC().Format(time.RFC3339)},
+					{Name: "agent_svid", Healthy: true, Details: "agent SVID expires at " + expiresAt.UTC().Format(time.RFC3339)},
+					{Name: "cache", Healthy: true, Details: "3 SVIDs cached"},
+				},
+			},
+		},
+		{
+			name:      "stale sync and expired SVID",
+			now:       expiresAt,
+			lastSync:  expiresAt.Add(-time.Hour),
+			svidState: svid.State{SVID: x509SVID.Certificates},
+			expectResp: &debugpb.GetHealthStatusResponse{
+				Components: []*debugpb.GetHealthStatusResponse_Component{
+					{Name: "attestation", Healthy: true, Details: `attested as "spiffe://example.org/spire/agent/foo"`},
+					{Name: "server_connectivity", Details: "last successful sync with the server was at " + expiresAt.Add(-time.Hour).UTC().Format(time.RFC3339)},
+					{Name: "agent_svid", Details: "agent SVID expired at " + expiresAt.UTC().Format(time.RFC3339)},
+					{Name: "cache", Healthy: true, Details: "3 SVIDs cached"},
+				},
+			},
+		},
+		{
+			name: "not attested",
+			now:  expiresAt.Add(-time.Hour),
+			expectResp: &debugpb.GetHealthStatusResponse{
+				Components: []*debugpb.GetHealthStatusResponse_Component{
+					{Name: "attestation", Details: "agent is not attested"},
+					{Name: "server_connectivity", Details: "agent has not synced with the server"},
+					{Name: "agent_svid", Details: "agent has no SVID"},
+					{Name: "cache", Healthy: true, Details: "3 SVIDs cached"},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.clk.Set(tt.now)
+			test.m.svidCount = 3
+			test.m.svidState = tt.svidState
+			test.m.lastSync = tt.lastSync
+
+			resp, err := test.client.GetHealthStatus(ctx, &debugpb.GetHealthStatusRequest{})
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+}
+
 type serviceTest struct {
 	client debugpb.DebugClient
 	done   func()
@@ -264,10 +358,11 @@ func setupServiceTest(t *testing.T) *serviceTest {
 type fakeManager struct {
 	manager.Manager
 
-	bundle    *cache.Bundle
-	svidState svid.State
-	svidCount int
-	lastSync  time.Time
+	bundle         *cache.Bundle
+	svidState      svid.State
+	svidCount      int
+	lastSync       time.Time
+	rotationStatus svid.RotationStatus
 }
 
 func (m *fakeManager) GetCurrentCredentials() svid.State {
@@ -282,6 +377,10 @@ func (m *fakeManager) GetLastSync() time.Time {
 	return m.lastSync
 }
 
+func (m *fakeManager) GetSVIDRotationStatus() svid.RotationStatus {
+	return m.rotationStatus
+}
+
 func (m *fakeManager) GetBundle() *cache.Bundle {
 	return m.bundle
 }
@@ -17,12 +17,7 @@ import (
 	"google.golang.org/grpc"
 )
 
-func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byte, fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient) ([]*x509.Certificate, error) {
-	data, err := a.fetchAttestationData(fetchStream, nil)
-	if err != nil {
-		return nil, err
-	}
-
+func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byte, data *nodeattestor.FetchAttestationDataResponse, fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient) ([]*x509.Certificate, error) {
 	attestReq := &agent.AttestAgentRequest{
 		Step: &agent.AttestAgentRequest_Params_{
 			Params: &agent.AttestAgentRequest_Params{
@@ -39,7 +34,9 @@ func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byt
 		return nil, fmt.Errorf("could not create new agent client for attestation: %v", err)
 	}
 
-	if err := attestStream.Send(attestReq); err != nil {
+	// io.EOF means the server ended the stream; the status is returned by
+	// Recv, so that a server without the v1 API can be told apart.
+	if err := attestStream.Send(attestReq); err != nil && err != io.EOF {
 		return nil, fmt.Errorf("error sending attestation request to SPIRE server: %v", err)
 	}
 
@@ -49,7 +46,7 @@ func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byt
 		// the response.
 		attestResp, err = attestStream.Recv()
 		if err != nil {
-			return nil, fmt.Errorf("error getting attestation response from SPIRE server: %v", err)
+			return nil, fmt.Errorf("error getting attestation response from SPIRE server: %w", err)
 		}
 		if attestResp.GetChallenge() == nil {
 			break
@@ -71,13 +68,8 @@ func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byt
 		}
 	}
 
-	if fetchStream != nil {
-		if err := fetchStream.CloseSend(); err != nil {
-			return nil, fmt.Errorf("failed to close send on fetch stream: %v", err)
-		}
-		if _, err := fetchStream.Recv(); err != io.EOF {
-			a.c.Log.WithError(err).Warn("Received unexpected result on trailing recv")
-		}
+	if err := a.closeFetchStream(fetchStream); err != nil {
+		return nil, err
 	}
 	if err := attestStream.CloseSend(); err != nil {
 		return nil, fmt.Errorf("failed to close send on attest stream: %v", err)
@@ -95,6 +87,21 @@ func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byt
 	return svid, nil
 }
 
+// closeFetchStream closes the stream used to fetch attestation data from the
+// node attestor plugin, if any.
+func (a *attestor) closeFetchStream(fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient) error {
+	if fetchStream == nil {
+		return nil
+	}
+	if err := fetchStream.CloseSend(); err != nil {
+		return fmt.Errorf("failed to close send on fetch stream: %v", err)
+	}
+	if _, err := fetchStream.Recv(); err != io.EOF {
+		a.c.Log.WithError(err).Warn("Received unexpected result on trailing recv")
+	}
+	return nil
+}
+
 func (a *attestor) getBundle(ctx context.Context, conn *grpc.ClientConn) (*bundleutil.Bundle, error) {
 	updatedBundle, err := a.c.CreateNewBundleClient(conn).GetBundle(ctx, &bundlepb.GetBundleRequest{})
 	if err != nil {
@@ -0,0 +1,97 @@
+package attestor
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"google.golang.org/grpc"
+)
+
+// legacyAttest attests the node using the deprecated node API, for servers
+// that predate the v1 agent API. The node API returns the trust domain bundle
+// along with the SVID.
+func (a *attestor) legacyAttest(ctx context.Context, conn *grpc.ClientConn, csr []byte, data *nodeattestor.FetchAttestationDataResponse, fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient) ([]*x509.Certificate, *bundleutil.Bundle, error) {
+	attestStream, err := a.c.CreateNewNodeClient(conn).Attest(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create new node client for attestation: %v", err)
+	}
+
+	var attestResp *node.AttestResponse
+	for {
+		attestReq := &node.AttestRequest{
+			AttestationData: data.AttestationData,
+			Csr:             csr,
+			Response:        data.Response,
+		}
+		if err := attestStream.Send(attestReq); err != nil {
+			return nil, nil, fmt.Errorf("error sending attestation request to SPIRE server: %v", err)
+		}
+
+		attestResp, err = attestStream.Recv()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting attestation response from SPIRE server: %v", err)
+		}
+		if attestResp.Challenge == nil {
+			break
+		}
+
+		data, err = a.fetchAttestationData(fetchStream, attestResp.Challenge)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := a.closeFetchStream(fetchStream); err != nil {
+		return nil, nil, err
+	}
+	if err := attestStream.CloseSend(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close send on attest stream: %v", err)
+	}
+	if _, err := attestStream.Recv(); err != io.EOF {
+		a.c.Log.WithError(err).Warn("Received unexpected result on trailing recv")
+	}
+
+	svid, bundle, err := a.parseLegacyAttestResponse(attestResp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse attestation response: %v", err)
+	}
+	return svid, bundle, nil
+}
+
+func (a *attestor) parseLegacyAttestResponse(r *node.AttestResponse) ([]*x509.Certificate, *bundleutil.Bundle, error) {
+	if r.SvidUpdate == nil {
+		return nil, nil, errors.New("response missing svid update")
+	}
+	if len(r.SvidUpdate.Svids) != 1 {
+		return nil, nil, fmt.Errorf("expected 1 svid; got %d", len(r.SvidUpdate.Svids))
+	}
+
+	var svidMsg *node.X509SVID
+	for _, v := range r.SvidUpdate.Svids {
+		svidMsg = v
+	}
+	svid, err := x509.ParseCertificates(svidMsg.CertChain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid svid: %v", err)
+	}
+	if len(svid) == 0 {
+		return nil, nil, errors.New("empty svid cert chain")
+	}
+
+	bundleProto := r.SvidUpdate.Bundles[a.c.TrustDomain.String()]
+	if bundleProto == nil {
+		return nil, nil, errors.New("missing bundle")
+	}
+	bundle, err := bundleutil.BundleFromProto(bundleProto)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bundle: %v", err)
+	}
+
+	return svid, bundle, nil
+}
@@ -17,12 +17,15 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/common/api"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -30,6 +33,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/resolver"
 )
 
 const (
@@ -57,8 +61,10 @@ type Config struct {
 	SVIDCachePath         string
 	Log                   logrus.FieldLogger
 	ServerAddress         string
+	ServerResolver        resolver.Builder
 	CreateNewAgentClient  func(grpc.ClientConnInterface) agent.AgentClient
 	CreateNewBundleClient func(grpc.ClientConnInterface) bundle.BundleClient
+	CreateNewNodeClient   func(grpc.ClientConnInterface) node.NodeClient
 }
 
 type attestor struct {
@@ -69,7 +75,7 @@ type attestor struct {
 }
 
 func New(config *Config) Attestor {
-	// Defaults for CreateNewAgentClient and CreateNewBundleClient functions
+	// Defaults for CreateNewAgentClient, CreateNewBundleClient and CreateNewNodeClient functions
 	if config != nil {
 		if config.CreateNewAgentClient == nil {
 			config.CreateNewAgentClient = agent.NewAgentClient
@@ -77,6 +83,9 @@ func New(config *Config) Attestor {
 		if config.CreateNewBundleClient == nil {
 			config.CreateNewBundleClient = bundle.NewBundleClient
 		}
+		if config.CreateNewNodeClient == nil {
+			config.CreateNewNodeClient = node.NewNodeClient
+		}
 	}
 
 	return &attestor{c: config}
@@ -108,7 +117,10 @@ func (a *attestor) Attest(ctx context.Context) (res *AttestationResult, err erro
 		if err != nil {
 			return nil, err
 		}
-		log.WithField(telemetry.SPIFFEID, svid[0].URIs[0].String()).Info("Node attestation was successful")
+		log.WithFields(logrus.Fields{
+			telemetry.SPIFFEID: svid[0].URIs[0].String(),
+			telemetry.Attestor: a.attestorName(),
+		}).Info("Node attestation was successful")
 	case bundle == nil:
 		// This is a bizarre case where we have an SVID but were unable to
 		// load a bundle from the cache which suggests some tampering with the
@@ -128,6 +140,7 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 	if err != nil {
 		return nil, nil, fmt.Errorf("load private key: %v", err)
 	}
+	defer cryptoutil.ZeroBytes(fetchRes.PrivateKey)
 	svid := a.readSVIDFromDisk()
 
 	privateKeyExists := len(fetchRes.PrivateKey) > 0
@@ -142,7 +155,7 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 		}
 		return svid, key, nil
 	case privateKeyExists && svidExists && svidIsExpired:
-		a.c.Log.WithField("expiry", svid[0].NotAfter).Warn("Private key recovered, but SVID is expired. Generating new keypair")
+		a.c.Log.WithField(telemetry.Expiration, svid[0].NotAfter).Warn("Private key recovered, but SVID is expired. Generating new keypair")
 	case privateKeyExists && !svidExists:
 		a.c.Log.Warn("Private key recovered, but no SVID found. Generating new keypair")
 	case !privateKeyExists && svidExists:
@@ -155,6 +168,7 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 	if err != nil {
 		return nil, nil, fmt.Errorf("generate key pair: %s", err)
 	}
+	defer cryptoutil.ZeroBytes(generateRes.PrivateKey)
 	key, err := x509.ParseECPrivateKey(generateRes.PrivateKey)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
@@ -257,13 +271,11 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	attestorName = joinTokenType
+	attestorName = a.attestorName()
 	var fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient
 	if a.c.JoinToken == "" {
-		attestor := a.c.Catalog.GetNodeAttestor()
-		attestorName = attestor.Name()
 		var err error
-		fetchStream, err = attestor.FetchAttestationData(ctx)
+		fetchStream, err = a.c.Catalog.GetNodeAttestor().FetchAttestationData(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("opening stream for fetching attestation: %v", err)
 		}
@@ -280,7 +292,16 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 		return nil, nil, fmt.Errorf("failed to generate CSR for attestation: %v", err)
 	}
 
-	newSVID, err := a.getSVID(ctx, conn, csr, fetchStream)
+	data, err := a.fetchAttestationData(fetchStream, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get SVID: %v", err)
+	}
+
+	newSVID, err := a.getSVID(ctx, conn, csr, data, fetchStream)
+	if client.IsUnimplemented(err) {
+		a.c.Log.WithError(err).Warn("Server does not implement the v1 API; falling back to the deprecated node API for node attestation")
+		return a.legacyAttest(ctx, conn, csr, data, fetchStream)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get SVID: %v", err)
 	}
@@ -291,12 +312,22 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 	return newSVID, newBundle, nil
 }
 
+// attestorName returns the name of the attestor used for node attestation,
+// which is the join token when one is configured.
+func (a *attestor) attestorName() string {
+	if a.c.JoinToken != "" {
+		return joinTokenType
+	}
+	return a.c.Catalog.GetNodeAttestor().Name()
+}
+
 func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*grpc.ClientConn, error) {
 	if bundle != nil {
 		return client.DialServer(ctx, client.DialServerConfig{
 			Address:     a.c.ServerAddress,
 			TrustDomain: a.c.TrustDomain.Host,
 			GetBundle:   bundle.RootCAs,
+			Resolver:    a.c.ServerResolver,
 		})
 	}
 
@@ -336,9 +367,19 @@ func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*
 		},
 	}
 
-	return grpc.DialContext(ctx, a.c.ServerAddress,
+	// Report the agent time so that the server can reject the attestation
+	// of an agent with a skewed clock with a clear error.
+	clientTimeUnary, clientTimeStream := api.ClientTimeInterceptors(time.Now)
+	clientVersionUnary, clientVersionStream := api.ClientVersionInterceptors(client.ClientName)
+	opts := []grpc.DialOption{
 		grpc.WithBalancerName(roundrobin.Name), //nolint:staticcheck
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-	)
+		grpc.WithChainUnaryInterceptor(clientTimeUnary, clientVersionUnary),
+		grpc.WithChainStreamInterceptor(clientTimeStream, clientVersionStream),
+	}
+	if a.c.ServerResolver != nil {
+		opts = append(opts, grpc.WithResolvers(a.c.ServerResolver))
+	}
+	return grpc.DialContext(ctx, a.c.ServerAddress, opts...)
 }
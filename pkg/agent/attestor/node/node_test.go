@@ -37,6 +37,8 @@ import (
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -64,6 +66,8 @@ func TestAttestor(t *testing.T) {
 		CertChain: [][]byte{agentCert.Raw},
 	}
 
+	unimplementedErr := status.Error(codes.Unimplemented, "unknown service spire.api.server.agent.v1.Agent")
+
 	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{
 			{
@@ -314,6 +318,32 @@ func TestAttestor(t *testing.T) {
 			},
 			err: "error in Recv",
 		},
+		{
+			name:            "falls back to the legacy node API",
+			bootstrapBundle: caCert,
+			agentClient: &fakeAgentClient{
+				recvErr: unimplementedErr,
+			},
+			bundleClient: &fakeBundleClient{},
+		},
+		{
+			name:            "falls back to the legacy node API with join token",
+			bootstrapBundle: caCert,
+			agentClient: &fakeAgentClient{
+				recvErr:   unimplementedErr,
+				joinToken: "JOINTOKEN",
+			},
+			bundleClient: &fakeBundleClient{},
+		},
+		{
+			name:            "falls back to the legacy node API with challenge response",
+			bootstrapBundle: caCert,
+			agentClient: &fakeAgentClient{
+				recvErr:            unimplementedErr,
+				challengeResponses: []string{"FOO", "BAR", "BAZ"},
+			},
+			bundleClient: &fakeBundleClient{},
+		},
 		{
 			name:            "close send error",
 			bootstrapBundle: caCert,
@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -33,6 +34,9 @@ type Config struct {
 	Catalog catalog.Catalog
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
+
+	// SelectorRedactor redacts the selectors that are logged
+	SelectorRedactor *selector.Redactor
 }
 
 // Attest invokes all workload attestor plugins against the provided PID. If an error
@@ -74,24 +78,28 @@ func (wla *attestor) Attest(ctx context.Context, pid int32) []*common.Selector {
 	// hard-to-filter details if we're not careful (e.g. issue #1537). Only log
 	// if it is not the agent itself.
 	if int(pid) != os.Getpid() {
-		log.WithField(telemetry.Selectors, selectors).Debug("PID attested to have selectors")
+		log.WithField(telemetry.Selectors, wla.c.SelectorRedactor.RedactAll(selectors)).Debug("PID attested to have selectors")
 	}
 	return selectors
 }
 
 // invokeAttestor invokes attestation against the supplied plugin. Should be called from a goroutine.
-func (wla *attestor) invokeAttestor(ctx context.Context, a catalog.WorkloadAttestor, pid int32) (selectors []*common.Selector, err error) {
+func (wla *attestor) invokeAttestor(ctx context.Context, a catalog.WorkloadAttestor, pid int32) ([]*common.Selector, error) {
 	req := &workloadattestor.AttestRequest{
 		Pid: pid,
 	}
 
+	// The attestor call counter is given the error returned by the plugin
+	// (and not the wrapped error returned by this function) so that the
+	// status code reported by the plugin is preserved in the metrics.
 	counter := telemetry_workload.StartAttestorCall(wla.c.Metrics, a.Name())
-	defer counter.Done(&err)
-
 	resp, err := a.Attest(ctx, req)
+	counter.Done(&err)
 	if err != nil {
+		telemetry_workload.IncrAttestorFailureCounter(wla.c.Metrics, a.Name())
 		return nil, fmt.Errorf("workload attestor %q failed: %v", a.Name(), err)
 	}
 
+	telemetry_workload.AddAttestorSelectorsSample(wla.c.Metrics, a.Name(), float32(len(resp.Selectors)))
 	return resp.Selectors, nil
 }
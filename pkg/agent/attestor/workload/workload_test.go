@@ -112,6 +112,7 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 	expected := fakemetrics.New()
 	attestorCounter := telemetry_workload.StartAttestorCall(expected, "fake1")
 	attestorCounter.Done(nil)
+	telemetry_workload.AddAttestorSelectorsSample(expected, "fake1", float32(len(selectors)))
 	telemetry_workload.AddDiscoveredSelectorsSample(expected, float32(len(selectors)))
 	attestationCounter := telemetry_workload.StartAttestationCall(expected)
 	attestationCounter.Done(nil)
@@ -131,6 +132,7 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 	err := errors.New("some error")
 	attestorCounter = telemetry_workload.StartAttestorCall(expected, "fake1")
 	attestorCounter.Done(&err)
+	telemetry_workload.IncrAttestorFailureCounter(expected, "fake1")
 	telemetry_workload.AddDiscoveredSelectorsSample(expected, float32(0))
 	attestationCounter = telemetry_workload.StartAttestationCall(expected)
 	attestationCounter.Done(nil)
@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
@@ -35,6 +36,8 @@ type GlobalConfig = catalog.GlobalConfig
 type HCLPluginConfig = catalog.HCLPluginConfig
 type HCLPluginConfigMap = catalog.HCLPluginConfigMap
 
+type PluginPolicy = catalog.PluginPolicy
+
 func KnownPlugins() []catalog.PluginClient {
 	return []catalog.PluginClient{
 		keymanager.PluginClient,
@@ -82,7 +85,7 @@ type WorkloadAttestor struct {
 type Plugins struct {
 	KeyManager        KeyManager
 	NodeAttestor      NodeAttestor
-	WorkloadAttestors []WorkloadAttestor `catalog:"min=1"`
+	WorkloadAttestors []WorkloadAttestor
 }
 
 var _ Catalog = (*Plugins)(nil)
@@ -103,6 +106,7 @@ type Config struct {
 	Log          logrus.FieldLogger
 	GlobalConfig *GlobalConfig
 	PluginConfig HCLPluginConfigMap
+	PluginPolicy PluginPolicy
 	HostServices []catalog.HostServiceServer
 	Metrics      *telemetry.MetricsImpl
 }
@@ -127,6 +131,8 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		KnownServices: KnownServices(),
 		BuiltIns:      BuiltIns(),
 		HostServices:  config.HostServices,
+		Metrics:       config.Metrics,
+		PluginPolicy:  config.PluginPolicy,
 	}, p)
 	if err != nil {
 		return nil, err
@@ -139,3 +145,52 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		Closer:  closer,
 	}, nil
 }
+
+// WorkloadAttestorRepository holds the workload attestors run by the
+// privileged helper.
+type WorkloadAttestorRepository struct {
+	WorkloadAttestors []WorkloadAttestor
+	catalog.Closer
+}
+
+// LoadWorkloadAttestors loads the workload attestor plugins only. It is used
+// by the privileged helper, which runs the workload attestors that require
+// privileges on behalf of the agent.
+func LoadWorkloadAttestors(ctx context.Context, config Config) (*WorkloadAttestorRepository, error) {
+	pluginConfig, err := catalog.PluginConfigsFromHCL(config.PluginConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range pluginConfig {
+		if c.Type != workloadattestor.Type {
+			return nil, fmt.Errorf("plugin %q of type %q cannot be run by the privileged helper", c.Name, c.Type)
+		}
+	}
+
+	catalogConfig := catalog.Config{
+		Log:           config.Log,
+		GlobalConfig:  config.GlobalConfig,
+		PluginConfig:  pluginConfig,
+		KnownPlugins:  []catalog.PluginClient{workloadattestor.PluginClient},
+		KnownServices: KnownServices(),
+		BuiltIns:      BuiltIns(),
+		HostServices:  config.HostServices,
+		PluginPolicy:  config.PluginPolicy,
+	}
+	if config.Metrics != nil {
+		catalogConfig.Metrics = config.Metrics
+	}
+
+	p := new(struct {
+		WorkloadAttestors []WorkloadAttestor `catalog:"min=1"`
+	})
+	closer, err := catalog.Fill(ctx, catalogConfig, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkloadAttestorRepository{
+		WorkloadAttestors: p.WorkloadAttestors,
+		Closer:            closer,
+	}, nil
+}
@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultBreakerFailureThreshold is the default number of consecutive
+	// failures to reach the server that open the circuit breaker
+	DefaultBreakerFailureThreshold = 5
+
+	// DefaultBreakerProbeInterval is the default time the circuit breaker
+	// stays open before a call is let through to probe the server
+	DefaultBreakerProbeInterval = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned, without contacting the server, by the calls
+// made while the circuit breaker is open. The breaker logs when it opens and
+// when it closes again, so callers can log ErrCircuitOpen at debug level
+// rather than report every suspended call as an error.
+var ErrCircuitOpen = errors.New("calls to the server are suspended after consecutive failures to reach it")
+
+// BreakerState is the state of the circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through
+	BreakerClosed BreakerState = iota
+
+	// BreakerHalfOpen lets a single call through to probe the server, and
+	// rejects the rest until the probe completes
+	BreakerHalfOpen
+
+	// BreakerOpen rejects every call until the probe interval elapses
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig configures the circuit breaker around the server calls
+type BreakerConfig struct {
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+
+	// FailureThreshold is the number of consecutive failures to reach the
+	// server that open the breaker. Defaults to
+	// DefaultBreakerFailureThreshold.
+	FailureThreshold int
+
+	// ProbeInterval is how long the breaker stays open before a call is let
+	// through to probe the server. Defaults to DefaultBreakerProbeInterval.
+	ProbeInterval time.Duration
+}
+
+// WithCircuitBreaker wraps the client with a circuit breaker. Once the server
+// cannot be reached for FailureThreshold consecutive calls, the breaker opens
+// and calls fail with ErrCircuitOpen without contacting the server. After
+// ProbeInterval, a single call is let through: the breaker closes if it
+// reaches the server, and opens again otherwise.
+//
+// Only failures to reach the server (i.e. Unavailable and DeadlineExceeded
+// errors) count. Errors returned by the server show that it is up.
+func WithCircuitBreaker(client Client, c BreakerConfig) Client {
+	return newBreakerClient(client, c)
+}
+
+func newBreakerClient(client Client, c BreakerConfig) *breakerClient {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = DefaultBreakerProbeInterval
+	}
+	return &breakerClient{
+		Client: client,
+		c:      c,
+		log:    c.Log.WithField(telemetry.SubsystemName, telemetry.ServerCircuitBreaker),
+	}
+}
+
+type breakerClient struct {
+	Client
+	c   BreakerConfig
+	log logrus.FieldLogger
+
+	mtx      sync.Mutex
+	state    BreakerState
+	failures int
+	probeAt  time.Time
+}
+
+func (b *breakerClient) FetchUpdates(ctx context.Context) (*Update, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := b.Client.FetchUpdates(ctx)
+	b.done(ctx, err)
+	return resp, err
+}
+
+func (b *breakerClient) FetchBundles(ctx context.Context, federatedTrustDomains []string) (map[string]*common.Bundle, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := b.Client.FetchBundles(ctx, federatedTrustDomains)
+	b.done(ctx, err)
+	return resp, err
+}
+
+func (b *breakerClient) RenewSVID(ctx context.Context, csr []byte) (*AgentSVID, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := b.Client.RenewSVID(ctx, csr)
+	b.done(ctx, err)
+	return resp, err
+}
+
+func (b *breakerClient) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*node.X509SVID, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := b.Client.NewX509SVIDs(ctx, csrs)
+	b.done(ctx, err)
+	return resp, err
+}
+
+func (b *breakerClient) NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string) (*JWTSVID, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := b.Client.NewJWTSVID(ctx, jsr, entryID)
+	b.done(ctx, err)
+	return resp, err
+}
+
+// State returns the current state of the breaker
+func (b *breakerClient) State() BreakerState {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.state
+}
+
+// allow returns ErrCircuitOpen if the call must not be made
+func (b *breakerClient) allow() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.c.Clock.Now().Before(b.probeAt) {
+			break
+		}
+		b.setState(BreakerHalfOpen)
+		b.log.Debug("Probing the server")
+		return nil
+	case BreakerHalfOpen:
+		// A probe is in flight
+	default:
+		return nil
+	}
+
+	telemetry_agent.IncrServerCircuitBreakerRejectCounter(b.c.Metrics)
+	return ErrCircuitOpen
+}
+
+// done records the outcome of a call that was made
+func (b *breakerClient) done(ctx context.Context, err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The call was canceled by the caller (e.g. on shutdown), which says
+		// nothing about the server. A probe that did not complete is retried
+		// on the next call.
+		if b.state == BreakerHalfOpen {
+			b.setState(BreakerOpen)
+		}
+	case !isServerUnreachable(err):
+		if b.state != BreakerClosed {
+			b.log.Info("Server reachable again; resuming calls to the server")
+		}
+		b.failures = 0
+		b.setState(BreakerClosed)
+	default:
+		b.failures++
+		switch {
+		case b.state == BreakerHalfOpen:
+			b.log.WithError(err).Debug("Server still unreachable")
+			b.open()
+		case b.state == BreakerClosed && b.failures >= b.c.FailureThreshold:
+			b.log.WithError(err).WithFields(logrus.Fields{
+				telemetry.Count:         b.failures,
+				telemetry.RetryInterval: b.c.ProbeInterval,
+			}).Warn("Server unreachable; suspending calls to the server")
+			b.open()
+		}
+	}
+}
+
+// open opens the breaker until the probe interval elapses. The mutex must
+// be held.
+func (b *breakerClient) open() {
+	b.probeAt = b.c.Clock.Now().Add(b.c.ProbeInterval)
+	b.setState(BreakerOpen)
+}
+
+// setState updates the state of the breaker. The mutex must be held.
+func (b *breakerClient) setState(state BreakerState) {
+	b.state = state
+	telemetry_agent.SetServerCircuitBreakerStateGauge(b.c.Metrics, int(state))
+}
+
+// isServerUnreachable returns true if the error shows that the server could
+// not be reached
+func isServerUnreachable(err error) bool {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.GRPCStatus().Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
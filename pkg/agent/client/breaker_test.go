@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	b, fake, clk, metrics := setupBreakerTest(t)
+	ctx := context.Background()
+
+	// Errors returned by the server do not count, and reset the failures
+	fake.err = unavailableErr()
+	for i := 0; i < 2; i++ {
+		_, err := b.RenewSVID(ctx, nil)
+		require.Equal(t, codes.Unavailable, status.Code(errors.Unwrap(err)))
+	}
+	fake.err = status.Error(codes.PermissionDenied, "denied")
+	_, err := b.RenewSVID(ctx, nil)
+	require.Error(t, err)
+	assert.Equal(t, BreakerClosed, b.State())
+
+	// Three consecutive failures to reach the server open the breaker
+	fake.err = unavailableErr()
+	for i := 0; i < 3; i++ {
+		_, err := b.RenewSVID(ctx, nil)
+		require.Error(t, err)
+		require.NotEqual(t, ErrCircuitOpen, err)
+	}
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, 6, fake.calls)
+
+	// Calls are rejected without reaching the server until the probe
+	// interval elapses
+	_, err = b.FetchUpdates(ctx)
+	require.Equal(t, ErrCircuitOpen, err)
+	_, err = b.FetchBundles(ctx, nil)
+	require.Equal(t, ErrCircuitOpen, err)
+	_, err = b.NewX509SVIDs(ctx, nil)
+	require.Equal(t, ErrCircuitOpen, err)
+	_, err = b.NewJWTSVID(ctx, nil, "")
+	require.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, 6, fake.calls)
+	assert.Equal(t, float32(4), rejectCount(metrics))
+	assert.Equal(t, float32(BreakerOpen), stateGauge(metrics))
+
+	// A failed probe opens the breaker again
+	clk.Add(time.Minute)
+	_, err = b.RenewSVID(ctx, nil)
+	require.NotEqual(t, ErrCircuitOpen, err)
+	assert.Equal(t, 7, fake.calls)
+	assert.Equal(t, BreakerOpen, b.State())
+	_, err = b.RenewSVID(ctx, nil)
+	require.Equal(t, ErrCircuitOpen, err)
+
+	// A successful probe closes the breaker
+	clk.Add(time.Minute)
+	fake.err = nil
+	_, err = b.RenewSVID(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.Equal(t, float32(BreakerClosed), stateGauge(metrics))
+	_, err = b.FetchUpdates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 9, fake.calls)
+}
+
+func TestBreakerRejectsCallsWhileProbing(t *testing.T) {
+	b, fake, clk, _ := setupBreakerTest(t)
+	ctx := context.Background()
+
+	fake.err = unavailableErr()
+	for i := 0; i < 3; i++ {
+		_, _ = b.RenewSVID(ctx, nil)
+	}
+	require.Equal(t, BreakerOpen, b.State())
+
+	clk.Add(time.Minute)
+	require.NoError(t, b.allow())
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	require.Equal(t, ErrCircuitOpen, b.allow())
+}
+
+func TestBreakerIgnoresCanceledCalls(t *testing.T) {
+	b, fake, clk, _ := setupBreakerTest(t)
+
+	fake.err = unavailableErr()
+	for i := 0; i < 3; i++ {
+		_, _ = b.RenewSVID(context.Background(), nil)
+	}
+	require.Equal(t, BreakerOpen, b.State())
+
+	// A probe canceled by the caller does not close the breaker, and does
+	// not delay the next probe
+	clk.Add(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fake.err = status.Error(codes.Canceled, "canceled")
+	_, err := b.RenewSVID(ctx, nil)
+	require.NotEqual(t, ErrCircuitOpen, err)
+	assert.Equal(t, BreakerOpen, b.State())
+
+	fake.err = nil
+	_, err = b.RenewSVID(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, BreakerClosed, b.State())
+}
+
+func setupBreakerTest(t *testing.T) (*breakerClient, *fakeBreakerClient, *clock.Mock, *fakemetrics.FakeMetrics) {
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	metrics := fakemetrics.New()
+	fake := &fakeBreakerClient{}
+	b := newBreakerClient(fake, BreakerConfig{
+		Log:              log,
+		Metrics:          metrics,
+		Clock:            clk,
+		FailureThreshold: 3,
+		ProbeInterval:    time.Minute,
+	})
+	return b, fake, clk, metrics
+}
+
+func unavailableErr() error {
+	return fmt.Errorf("failed to renew agent: %w", status.Error(codes.Unavailable, "connection refused"))
+}
+
+func rejectCount(metrics *fakemetrics.FakeMetrics) float32 {
+	var count float32
+	for _, item := range metrics.AllMetrics() {
+		if item.Type == fakemetrics.IncrCounterType {
+			count += item.Val
+		}
+	}
+	return count
+}
+
+func stateGauge(metrics *fakemetrics.FakeMetrics) float32 {
+	var state float32
+	for _, item := range metrics.AllMetrics() {
+		if item.Type == fakemetrics.SetGaugeType {
+			state = item.Val
+		}
+	}
+	return state
+}
+
+type fakeBreakerClient struct {
+	Client
+	err   error
+	calls int
+}
+
+func (c *fakeBreakerClient) FetchUpdates(ctx context.Context) (*Update, error) {
+	c.calls++
+	return &Update{}, c.err
+}
+
+func (c *fakeBreakerClient) RenewSVID(ctx context.Context, csr []byte) (*AgentSVID, error) {
+	c.calls++
+	return &AgentSVID{}, c.err
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	agentpb "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
@@ -24,6 +25,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
 )
 
@@ -39,9 +41,22 @@ type JWTSVID struct {
 	ExpiresAt time.Time
 }
 
+// AgentSVID is an X509-SVID renewed for the agent
+type AgentSVID struct {
+	CertChain []byte
+	ExpiresAt time.Time
+
+	// RotateAfter is the time from which the server suggests rotating the
+	// SVID. Zero if the server did not provide a hint.
+	RotateAfter time.Time
+}
+
 type Client interface {
 	FetchUpdates(ctx context.Context) (*Update, error)
-	RenewSVID(ctx context.Context, csr []byte) (*node.X509SVID, error)
+	// FetchBundles fetches the trust domain bundle and the given federated
+	// bundles, keyed by trust domain ID.
+	FetchBundles(ctx context.Context, federatedTrustDomains []string) (map[string]*common.Bundle, error)
+	RenewSVID(ctx context.Context, csr []byte) (*AgentSVID, error)
 	NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*node.X509SVID, error)
 	NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string) (*JWTSVID, error)
 
@@ -60,18 +75,38 @@ type Config struct {
 
 	// RotMtx is used to prevent the creation of new connections during SVID rotations
 	RotMtx *sync.RWMutex
+
+	// Resolver is an optional resolver builder used to resolve Addr
+	Resolver resolver.Builder
+
+	// HedgeDelay, if positive, enables hedging of idempotent read RPCs. If
+	// such an RPC has not completed after HedgeDelay, a duplicate request is
+	// sent and the first successful response is used.
+	HedgeDelay time.Duration
+
+	// SelectorRedactor redacts the selectors that are logged
+	SelectorRedactor *selector.Redactor
+
+	// Metrics is used to report the server API in use. Optional.
+	Metrics telemetry.Metrics
 }
 
 type client struct {
 	c           *Config
+	metrics     telemetry.Metrics
 	connections *nodeConn
 	m           sync.Mutex
 
+	// legacyAPI is true while the server is known to only implement the
+	// legacy node API
+	legacyAPI bool
+
 	// Constructor used for testing purposes.
 	createNewEntryClient  func(grpc.ClientConnInterface) entrypb.EntryClient
 	createNewBundleClient func(grpc.ClientConnInterface) bundlepb.BundleClient
 	createNewSVIDClient   func(grpc.ClientConnInterface) svidpb.SVIDClient
 	createNewAgentClient  func(grpc.ClientConnInterface) agentpb.AgentClient
+	createNewNodeClient   func(grpc.ClientConnInterface) node.NodeClient
 
 	// Constructor used for testing purposes.
 	dialContext func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
@@ -83,12 +118,18 @@ func New(c *Config) Client {
 }
 
 func newClient(c *Config) *client {
+	metrics := c.Metrics
+	if metrics == nil {
+		metrics = telemetry.Blackhole{}
+	}
 	return &client{
 		c:                     c,
+		metrics:               metrics,
 		createNewEntryClient:  entrypb.NewEntryClient,
 		createNewBundleClient: bundlepb.NewBundleClient,
 		createNewSVIDClient:   svidpb.NewSVIDClient,
 		createNewAgentClient:  agentpb.NewAgentClient,
+		createNewNodeClient:   node.NewNodeClient,
 	}
 }
 
@@ -99,7 +140,15 @@ func (c *client) FetchUpdates(ctx context.Context) (*Update, error) {
 	c.c.RotMtx.RLock()
 	defer c.c.RotMtx.RUnlock()
 
+	if c.useLegacyAPI() {
+		return c.legacyFetchUpdates(ctx)
+	}
+
 	protoEntries, err := c.fetchEntries(ctx)
+	if IsUnimplemented(err) {
+		c.fallBackToLegacyAPI(err)
+		return c.legacyFetchUpdates(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -109,10 +158,14 @@ func (c *client) FetchUpdates(ctx context.Context) (*Update, error) {
 	for _, e := range protoEntries {
 		entry, err := slicedEntryFromProto(e)
 		if err != nil {
+			selectors := make([]*common.Selector, 0, len(e.Selectors))
+			for _, s := range e.Selectors {
+				selectors = append(selectors, &common.Selector{Type: s.Type, Value: s.Value})
+			}
 			c.c.Log.WithFields(logrus.Fields{
 				telemetry.RegistrationID: e.Id,
 				telemetry.SPIFFEID:       e.SpiffeId,
-				telemetry.Selectors:      e.Selectors,
+				telemetry.Selectors:      c.c.SelectorRedactor.RedactAll(selectors),
 				telemetry.Error:          err.Error(),
 			}).Warn("Received malformed entry from SPIRE server")
 			continue
@@ -130,31 +183,48 @@ func (c *client) FetchUpdates(ctx context.Context) (*Update, error) {
 		keys = append(keys, key)
 	}
 
-	protoBundles, err := c.fetchBundles(ctx, keys)
+	bundles, err := c.fetchCommonBundles(ctx, keys)
+	if IsUnimplemented(err) {
+		c.fallBackToLegacyAPI(err)
+		return c.legacyFetchUpdates(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	bundles := make(map[string]*common.Bundle)
-	for _, b := range protoBundles {
-		bundle, err := bundleutil.CommonBundleFromProto(b)
-		if err != nil {
-			c.c.Log.WithError(err).Warn("Received malformed bundle from SPIRE server")
-			continue
-		}
-		bundles[bundle.TrustDomainId] = bundle
-	}
-
 	return &Update{
 		Entries: regEntries,
 		Bundles: bundles,
 	}, nil
 }
 
-func (c *client) RenewSVID(ctx context.Context, csr []byte) (*node.X509SVID, error) {
+func (c *client) FetchBundles(ctx context.Context, federatedTrustDomains []string) (map[string]*common.Bundle, error) {
 	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
 
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	if c.useLegacyAPI() {
+		return c.legacyFetchBundles(ctx, federatedTrustDomains)
+	}
+
+	bundles, err := c.fetchCommonBundles(ctx, federatedTrustDomains)
+	if IsUnimplemented(err) {
+		c.fallBackToLegacyAPI(err)
+		return c.legacyFetchBundles(ctx, federatedTrustDomains)
+	}
+	return bundles, err
+}
+
+func (c *client) RenewSVID(ctx context.Context, csr []byte) (*AgentSVID, error) {
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	if c.useLegacyAPI() {
+		return c.legacyRenewSVID(ctx, csr)
+	}
+
 	agentClient, connection, err := c.newAgentClient(ctx)
 	if err != nil {
 		return nil, err
@@ -166,6 +236,11 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*node.X509SVID, err
 			Csr: csr,
 		},
 	})
+	if IsUnimplemented(err) {
+		c.release(connection)
+		c.fallBackToLegacyAPI(err)
+		return c.legacyRenewSVID(ctx, csr)
+	}
 	if err != nil {
 		c.release(connection)
 		c.c.Log.WithError(err).Error("Failed to renew agent")
@@ -176,10 +251,14 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*node.X509SVID, err
 	for _, cert := range resp.Svid.CertChain {
 		certChain = append(certChain, cert...)
 	}
-	return &node.X509SVID{
+	svid := &AgentSVID{
 		CertChain: certChain,
-		ExpiresAt: resp.Svid.ExpiresAt,
-	}, nil
+		ExpiresAt: time.Unix(resp.Svid.ExpiresAt, 0),
+	}
+	if resp.RotateAfter != 0 {
+		svid.RotateAfter = time.Unix(resp.RotateAfter, 0)
+	}
+	return svid, nil
 }
 
 func (c *client) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*node.X509SVID, error) {
@@ -189,6 +268,10 @@ func (c *client) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[
 	c.c.RotMtx.RLock()
 	defer c.c.RotMtx.RUnlock()
 
+	if c.useLegacyAPI() {
+		return c.legacyNewX509SVIDs(ctx, csrs)
+	}
+
 	svids := make(map[string]*node.X509SVID)
 	var params []*svidpb.NewX509SVIDParams
 	for entryID, csr := range csrs {
@@ -199,6 +282,10 @@ func (c *client) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[
 	}
 
 	protoSVIDs, err := c.fetchSVIDs(ctx, params)
+	if IsUnimplemented(err) {
+		c.fallBackToLegacyAPI(err)
+		return c.legacyNewX509SVIDs(ctx, csrs)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +317,10 @@ func (c *client) NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string)
 	c.c.RotMtx.RLock()
 	defer c.c.RotMtx.RUnlock()
 
+	if c.useLegacyAPI() {
+		return c.legacyNewJWTSVID(ctx, jsr)
+	}
+
 	svidClient, connection, err := c.newSVIDClient(ctx)
 	if err != nil {
 		return nil, err
@@ -240,6 +331,11 @@ func (c *client) NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string)
 		Audience: jsr.Audience,
 		EntryId:  entryID,
 	})
+	if IsUnimplemented(err) {
+		c.release(connection)
+		c.fallBackToLegacyAPI(err)
+		return c.legacyNewJWTSVID(ctx, jsr)
+	}
 	if err != nil {
 		c.release(connection)
 		c.c.Log.WithError(err).Error("Failed to fetch JWT SVID")
@@ -247,21 +343,26 @@ func (c *client) NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string)
 	}
 
 	svid := resp.Svid
-	switch {
-	case svid == nil:
+	if svid == nil {
 		return nil, errors.New("JWTSVID response missing SVID")
-	case svid.IssuedAt == 0:
+	}
+	return newJWTSVID(svid.Token, svid.IssuedAt, svid.ExpiresAt)
+}
+
+func newJWTSVID(token string, issuedAt, expiresAt int64) (*JWTSVID, error) {
+	switch {
+	case issuedAt == 0:
 		return nil, errors.New("JWTSVID missing issued at")
-	case svid.ExpiresAt == 0:
+	case expiresAt == 0:
 		return nil, errors.New("JWTSVID missing expires at")
-	case svid.IssuedAt > svid.ExpiresAt:
+	case issuedAt > expiresAt:
 		return nil, errors.New("JWTSVID issued after it has expired")
 	}
 
 	return &JWTSVID{
-		Token:     svid.Token,
-		IssuedAt:  time.Unix(svid.IssuedAt, 0).UTC(),
-		ExpiresAt: time.Unix(svid.ExpiresAt, 0).UTC(),
+		Token:     token,
+		IssuedAt:  time.Unix(issuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(expiresAt, 0).UTC(),
 	}, nil
 }
 
@@ -276,6 +377,9 @@ func (c *client) release(conn *nodeConn) {
 	if c.connections != nil && (conn == nil || conn == c.connections) {
 		c.connections.Release()
 		c.connections = nil
+		// The server may have been upgraded by the time the client
+		// reconnects
+		c.resetAPI()
 	}
 }
 
@@ -297,6 +401,7 @@ func (c *client) dial(ctx context.Context) (*grpc.ClientConn, error) {
 			}
 			return agentCert
 		},
+		Resolver:    c.c.Resolver,
 		dialContext: c.dialContext,
 	})
 }
@@ -308,14 +413,36 @@ func (c *client) fetchEntries(ctx context.Context) ([]*types.Entry, error) {
 	}
 	defer connection.Release()
 
-	resp, err := entryClient.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{})
+	resp, err := hedge(ctx, c.c.HedgeDelay, func(ctx context.Context) (interface{}, error) {
+		return entryClient.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{})
+	})
 	if err != nil {
 		c.release(connection)
 		c.c.Log.WithError(err).Error("Failed to fetch authorized entries")
 		return nil, fmt.Errorf("failed to fetch authorized entries: %w", err)
 	}
 
-	return resp.Entries, err
+	return resp.(*entrypb.GetAuthorizedEntriesResponse).Entries, err
+}
+
+// fetchCommonBundles fetches the trust domain bundle and the given federated
+// bundles, keyed by trust domain ID. Malformed bundles are skipped.
+func (c *client) fetchCommonBundles(ctx context.Context, federatedBundles []string) (map[string]*common.Bundle, error) {
+	protoBundles, err := c.fetchBundles(ctx, federatedBundles)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make(map[string]*common.Bundle)
+	for _, b := range protoBundles {
+		bundle, err := bundleutil.CommonBundleFromProto(b)
+		if err != nil {
+			c.c.Log.WithError(err).Warn("Received malformed bundle from SPIRE server")
+			continue
+		}
+		bundles[bundle.TrustDomainId] = bundle
+	}
+	return bundles, nil
 }
 
 func (c *client) fetchBundles(ctx context.Context, federatedBundles []string) ([]*types.Bundle, error) {
@@ -328,25 +455,29 @@ func (c *client) fetchBundles(ctx context.Context, federatedBundles []string) ([
 	var bundles []*types.Bundle
 
 	// Get bundle
-	bundle, err := bundleClient.GetBundle(ctx, &bundlepb.GetBundleRequest{})
+	bundle, err := hedge(ctx, c.c.HedgeDelay, func(ctx context.Context) (interface{}, error) {
+		return bundleClient.GetBundle(ctx, &bundlepb.GetBundleRequest{})
+	})
 	if err != nil {
 		c.release(connection)
 		c.c.Log.WithError(err).Error("Failed to fetch bundle")
 		return nil, fmt.Errorf("failed to fetch bundle: %w", err)
 	}
-	bundles = append(bundles, bundle)
+	bundles = append(bundles, bundle.(*types.Bundle))
 
 	for _, b := range federatedBundles {
 		federatedTD, err := spiffeid.TrustDomainFromString(b)
 		if err != nil {
 			return nil, err
 		}
-		bundle, err := bundleClient.GetFederatedBundle(ctx, &bundlepb.GetFederatedBundleRequest{
-			TrustDomain: federatedTD.String(),
+		bundle, err := hedge(ctx, c.c.HedgeDelay, func(ctx context.Context) (interface{}, error) {
+			return bundleClient.GetFederatedBundle(ctx, &bundlepb.GetFederatedBundleRequest{
+				TrustDomain: federatedTD.String(),
+			})
 		})
 		switch status.Code(err) {
 		case codes.OK:
-			bundles = append(bundles, bundle)
+			bundles = append(bundles, bundle.(*types.Bundle))
 		case codes.NotFound:
 			c.c.Log.WithError(err).WithField(telemetry.FederatedBundle, b).Warn("Federated bundle not found")
 		default:
@@ -451,3 +582,18 @@ func (c *client) newAgentClient(ctx context.Context) (agentpb.AgentClient, *node
 	c.connections.AddRef()
 	return c.createNewAgentClient(c.connections.conn), c.connections, nil
 }
+
+func (c *client) newNodeClient(ctx context.Context) (node.NodeClient, *nodeConn, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.connections == nil {
+		conn, err := c.dial(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.connections = newNodeConn(conn)
+	}
+	c.connections.AddRef()
+	return c.createNewNodeClient(c.connections.conn), c.connections, nil
+}
@@ -46,8 +46,9 @@ func TestFetchUpdates(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:   []string{"domain1.com"},
+			RevisionNumber:  1234,
+			X509SvidKeyType: "rsa-2048",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -139,12 +140,13 @@ func TestRenewSVID(t *testing.T) {
 	client, tc := createClient()
 
 	for _, tt := range []struct {
-		name       string
-		agentErr   error
-		err        string
-		expectSVID *node.X509SVID
-		csr        []byte
-		agentSVID  *types.X509SVID
+		name        string
+		agentErr    error
+		err         string
+		expectSVID  *AgentSVID
+		csr         []byte
+		agentSVID   *types.X509SVID
+		rotateAfter int64
 	}{
 		{
 			name: "success",
@@ -157,10 +159,28 @@ func TestRenewSVID(t *testing.T) {
 				CertChain: [][]byte{{1, 2, 3}},
 				ExpiresAt: 12345,
 			},
-			expectSVID: &node.X509SVID{
+			expectSVID: &AgentSVID{
 				CertChain: []byte{1, 2, 3},
+				ExpiresAt: time.Unix(12345, 0),
+			},
+		},
+		{
+			name: "success with rotation hint",
+			csr:  []byte{0, 1, 2},
+			agentSVID: &types.X509SVID{
+				Id: &types.SPIFFEID{
+					TrustDomain: "example.org",
+					Path:        "/agent1",
+				},
+				CertChain: [][]byte{{1, 2, 3}},
 				ExpiresAt: 12345,
 			},
+			rotateAfter: 6000,
+			expectSVID: &AgentSVID{
+				CertChain:   []byte{1, 2, 3},
+				ExpiresAt:   time.Unix(12345, 0),
+				RotateAfter: time.Unix(6000, 0),
+			},
 		},
 		{
 			name: "no csr",
@@ -186,6 +206,7 @@ func TestRenewSVID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tc.agentClient.err = tt.agentErr
 			tc.agentClient.svid = tt.agentSVID
+			tc.agentClient.rotateAfter = tt.rotateAfter
 
 			svid, err := client.RenewSVID(context.Background(), tt.csr)
 			if tt.err != "" {
@@ -218,8 +239,9 @@ func TestNewX509SVIDs(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:   []string{"domain1.com"},
+			RevisionNumber:  1234,
+			X509SvidKeyType: "rsa-2048",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -305,7 +327,8 @@ func newTestFetchX509SVIDResponse() *node.FetchX509SVIDResponse {
 					FederatesWith: []string{
 						"spiffe://domain1.com",
 					},
-					RevisionNumber: 1234,
+					RevisionNumber:  1234,
+					X509SvidKeyType: "rsa-2048",
 				},
 				// This entry should be ignored since it is missing an entry ID
 				{
@@ -369,8 +392,9 @@ func TestFetchReleaseWaitsForFetchUpdatesToFinish(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:   []string{"domain1.com"},
+			RevisionNumber:  1234,
+			X509SvidKeyType: "rsa-2048",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -878,8 +902,9 @@ func (c *fakeSVIDClient) NewJWTSVID(ctx context.Context, in *svidpb.NewJWTSVIDRe
 
 type fakeAgentClient struct {
 	agentpb.AgentClient
-	err  error
-	svid *types.X509SVID
+	err         error
+	svid        *types.X509SVID
+	rotateAfter int64
 }
 
 func (c *fakeAgentClient) RenewAgent(ctx context.Context, in *agentpb.RenewAgentRequest, opts ...grpc.CallOption) (*agentpb.RenewAgentResponse, error) {
@@ -892,7 +917,8 @@ func (c *fakeAgentClient) RenewAgent(ctx context.Context, in *agentpb.RenewAgent
 	}
 
 	return &agentpb.RenewAgentResponse{
-		Svid: c.svid,
+		Svid:        c.svid,
+		RotateAfter: c.rotateAfter,
 	}, nil
 }
 
@@ -13,15 +13,21 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/spire/pkg/common/api"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/resolver"
 )
 
 const (
 	_defaultDialTimeout = 30 * time.Second
+
+	// ClientName is the name the agent reports to the server, along with its
+	// version, with each call
+	ClientName = "spire-agent"
 )
 
 type DialServerConfig struct {
@@ -39,6 +45,10 @@ type DialServerConfig struct {
 	// certificate to present to the server during the TLS handshake.
 	GetAgentCertificate func() *tls.Certificate
 
+	// Resolver is an optional resolver builder used to resolve the server
+	// address. If nil, the resolvers registered with gRPC are used.
+	Resolver resolver.Builder
+
 	// dialContext is an optional constructor for the grpc client connection.
 	dialContext func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
 }
@@ -65,13 +75,23 @@ func DialServer(ctx context.Context, config DialServerConfig) (*grpc.ClientConn,
 	if config.dialContext == nil {
 		config.dialContext = grpc.DialContext
 	}
-	client, err := config.dialContext(ctx, config.Address,
+	// Report the agent time with each call so that the server can reject
+	// calls from an agent with a skewed clock with a clear error.
+	clientTimeUnary, clientTimeStream := api.ClientTimeInterceptors(time.Now)
+	clientVersionUnary, clientVersionStream := api.ClientVersionInterceptors(ClientName)
+	opts := []grpc.DialOption{
 		grpc.WithBalancerName(roundrobin.Name), //nolint:staticcheck
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithBlock(),
 		grpc.WithReturnConnectionError(),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-	)
+		grpc.WithChainUnaryInterceptor(clientTimeUnary, clientVersionUnary),
+		grpc.WithChainStreamInterceptor(clientTimeStream, clientVersionStream),
+	}
+	if config.Resolver != nil {
+		opts = append(opts, grpc.WithResolvers(config.Resolver))
+	}
+	client, err := config.dialContext(ctx, config.Address, opts...)
 	switch {
 	case err == nil:
 	case errors.Is(err, context.Canceled):
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// hedge invokes call and, if it has not completed after delay, invokes it a
+// second time concurrently. The first successful response is returned and the
+// outstanding call, if any, is canceled. An error is only returned once every
+// call that was made has failed. If delay is not positive, call is invoked
+// once.
+//
+// Only idempotent calls may be hedged. Since the server connection balances
+// requests across the resolved server addresses, the duplicate request is
+// normally served by a different server than the original one.
+func hedge(ctx context.Context, delay time.Duration, call func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if delay <= 0 {
+		return call(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		resp interface{}
+		err  error
+	}
+	results := make(chan result, 2)
+	attempt := func() {
+		resp, err := call(ctx)
+		results <- result{resp: resp, err: err}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	go attempt()
+	pending := 1
+	for {
+		select {
+		case <-timer.C:
+			go attempt()
+			pending++
+		case r := <-results:
+			pending--
+			if r.err == nil || pending == 0 {
+				return r.resp, r.err
+			}
+		}
+	}
+}
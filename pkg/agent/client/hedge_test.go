@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedgeDisabled(t *testing.T) {
+	var calls int32
+	resp, err := hedge(context.Background(), 0, func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "response", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgeFirstCallCompletesBeforeDelay(t *testing.T) {
+	var calls int32
+	resp, err := hedge(context.Background(), time.Hour, func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "response", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgeFirstCallFailsBeforeDelay(t *testing.T) {
+	var calls int32
+	_, err := hedge(context.Background(), time.Hour, func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("oh no")
+	})
+	require.EqualError(t, err, "oh no")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgeSlowCallIsHedged(t *testing.T) {
+	var calls int32
+	canceled := make(chan struct{})
+	resp, err := hedge(context.Background(), time.Millisecond, func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The first call is stuck until the hedged call wins
+			<-ctx.Done()
+			close(canceled)
+			return nil, ctx.Err()
+		}
+		return "hedged", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hedged", resp)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for the slow call to be canceled")
+	}
+}
+
+func TestHedgeFailedHedgeWaitsForSlowCall(t *testing.T) {
+	var calls int32
+	hedged := make(chan struct{})
+	resp, err := hedge(context.Background(), time.Millisecond, func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-hedged
+			return "slow", nil
+		}
+		defer close(hedged)
+		return nil, errors.New("oh no")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "slow", resp)
+}
+
+func TestHedgeAllCallsFail(t *testing.T) {
+	var calls int32
+	hedged := make(chan struct{})
+	_, err := hedge(context.Background(), time.Millisecond, func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-hedged
+			return nil, errors.New("slow failure")
+		}
+		defer close(hedged)
+		return nil, errors.New("hedged failure")
+	})
+	// Either failure may be observed last depending on scheduling
+	require.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
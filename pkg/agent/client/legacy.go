@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Servers that predate the v1 APIs only implement the legacy node API. When
+// a v1 RPC is not implemented by the server, the client falls back to the
+// legacy node API until it reconnects, so agents can be rolled out ahead of
+// the servers without coordinating configuration on every node.
+
+// IsUnimplemented returns true if the error is because the server does not
+// implement the RPC
+func IsUnimplemented(err error) bool {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &statusErr) && statusErr.GRPCStatus().Code() == codes.Unimplemented
+}
+
+// useLegacyAPI returns true if the server is known to only implement the
+// legacy node API
+func (c *client) useLegacyAPI() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.legacyAPI
+}
+
+// fallBackToLegacyAPI switches the client to the legacy node API, after the
+// server failed to serve a v1 RPC with the given error
+func (c *client) fallBackToLegacyAPI(err error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.legacyAPI {
+		return
+	}
+	c.legacyAPI = true
+	c.c.Log.WithError(err).Warn("Server does not implement the v1 API; falling back to the deprecated node API")
+	telemetry_agent.SetServerLegacyAPIGauge(c.metrics, true)
+}
+
+// resetAPI makes the client detect the API implemented by the server again.
+// The mutex must be held.
+func (c *client) resetAPI() {
+	if c.legacyAPI {
+		c.legacyAPI = false
+		telemetry_agent.SetServerLegacyAPIGauge(c.metrics, false)
+	}
+}
+
+func (c *client) legacyFetchUpdates(ctx context.Context) (*Update, error) {
+	update, err := c.legacyFetchX509SVID(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	regEntries := make(map[string]*common.RegistrationEntry)
+	for _, entry := range update.RegistrationEntries {
+		regEntries[entry.EntryId] = entry
+	}
+
+	return &Update{
+		Entries: regEntries,
+		Bundles: update.Bundles,
+	}, nil
+}
+
+func (c *client) legacyFetchBundles(ctx context.Context, federatedTrustDomains []string) (map[string]*common.Bundle, error) {
+	update, err := c.legacyFetchX509SVID(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The legacy node API returns the bundles of the trust domains the
+	// entries of the agent federate with, so only those requested are kept.
+	td, err := spiffeid.TrustDomainFromString(c.c.TrustDomain.Host)
+	if err != nil {
+		return nil, err
+	}
+	trustDomainID := td.IDString()
+	bundles := make(map[string]*common.Bundle)
+	if bundle, ok := update.Bundles[trustDomainID]; ok {
+		bundles[trustDomainID] = bundle
+	}
+	for _, b := range federatedTrustDomains {
+		federatedTD, err := spiffeid.TrustDomainFromString(b)
+		if err != nil {
+			return nil, err
+		}
+		bundle, ok := update.Bundles[federatedTD.IDString()]
+		if !ok {
+			c.c.Log.WithField(telemetry.FederatedBundle, b).Warn("Federated bundle not found")
+			continue
+		}
+		bundles[bundle.TrustDomainId] = bundle
+	}
+	return bundles, nil
+}
+
+func (c *client) legacyRenewSVID(ctx context.Context, csr []byte) (*AgentSVID, error) {
+	// The agent SVID is requested with its SPIFFE ID as key
+	chain, _, _ := c.c.KeysAndBundle()
+	if len(chain) == 0 || len(chain[0].URIs) == 0 {
+		return nil, errors.New("failed to renew agent: agent SVID has no SPIFFE ID")
+	}
+	agentID := chain[0].URIs[0].String()
+
+	update, err := c.legacyFetchX509SVID(ctx, map[string][]byte{agentID: csr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew agent: %w", err)
+	}
+
+	svid, ok := update.Svids[agentID]
+	if !ok {
+		return nil, errors.New("failed to renew agent: no SVID in the response")
+	}
+	return &AgentSVID{
+		CertChain: svid.CertChain,
+		ExpiresAt: time.Unix(svid.ExpiresAt, 0),
+	}, nil
+}
+
+func (c *client) legacyNewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*node.X509SVID, error) {
+	update, err := c.legacyFetchX509SVID(ctx, csrs)
+	if err != nil {
+		return nil, err
+	}
+
+	svids := make(map[string]*node.X509SVID)
+	for entryID := range csrs {
+		svid, ok := update.Svids[entryID]
+		if !ok {
+			c.c.Log.WithField(telemetry.RegistrationID, entryID).Debug("Entry not found")
+			continue
+		}
+		svids[entryID] = svid
+	}
+	return svids, nil
+}
+
+func (c *client) legacyNewJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error) {
+	nodeClient, connection, err := c.newNodeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Release()
+
+	resp, err := nodeClient.FetchJWTSVID(ctx, &node.FetchJWTSVIDRequest{
+		Jsr: jsr,
+	})
+	if err != nil {
+		c.release(connection)
+		c.c.Log.WithError(err).Error("Failed to fetch JWT SVID")
+		return nil, fmt.Errorf("failed to fetch JWT SVID: %w", err)
+	}
+
+	svid := resp.Svid
+	if svid == nil {
+		return nil, errors.New("JWTSVID response missing SVID")
+	}
+	return newJWTSVID(svid.Token, svid.IssuedAt, svid.ExpiresAt)
+}
+
+// legacyFetchX509SVID gets the entries and bundles of the agent, and signs
+// the given CSRs, keyed by entry ID, using the legacy node API
+func (c *client) legacyFetchX509SVID(ctx context.Context, csrs map[string][]byte) (*node.X509SVIDUpdate, error) {
+	nodeClient, connection, err := c.newNodeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Release()
+
+	update, err := func() (*node.X509SVIDUpdate, error) {
+		stream, err := nodeClient.FetchX509SVID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = stream.CloseSend()
+		}()
+
+		if err := stream.Send(&node.FetchX509SVIDRequest{Csrs: csrs}); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if resp.SvidUpdate == nil {
+			return nil, errors.New("response missing SVID update")
+		}
+		return resp.SvidUpdate, nil
+	}()
+	if err != nil {
+		c.release(connection)
+		c.c.Log.WithError(err).Error("Failed to fetch X509 SVID update")
+		return nil, fmt.Errorf("failed to fetch X509 SVID update: %w", err)
+	}
+	return update, nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLegacyAPIFallback(t *testing.T) {
+	client, tc := createClient()
+	metrics := fakemetrics.New()
+	client.metrics = metrics
+	client.c.KeysAndBundle = func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
+		agentID, _ := url.Parse("spiffe://example.org/spire/agent/test/host")
+		return []*x509.Certificate{{URIs: []*url.URL{agentID}}}, nil, nil
+	}
+
+	nodeClient := &fakeNodeClient{
+		update: &node.X509SVIDUpdate{
+			RegistrationEntries: []*common.RegistrationEntry{
+				{EntryId: "ENTRYID1", SpiffeId: "spiffe://example.org/id1", FederatesWith: []string{"spiffe://domain1.com"}},
+			},
+			Bundles: map[string]*common.Bundle{
+				"spiffe://example.org": {TrustDomainId: "spiffe://example.org"},
+				"spiffe://domain1.com": {TrustDomainId: "spiffe://domain1.com"},
+			},
+			Svids: map[string]*node.X509SVID{
+				"ENTRYID1": {CertChain: []byte{1}, ExpiresAt: 1},
+				"spiffe://example.org/spire/agent/test/host": {CertChain: []byte{2}, ExpiresAt: 2},
+			},
+		},
+		jwtSVID: &node.JWTSVID{Token: "token", IssuedAt: 1, ExpiresAt: 2},
+	}
+	client.createNewNodeClient = func(grpc.ClientConnInterface) node.NodeClient {
+		return nodeClient
+	}
+
+	// The server does not implement the v1 APIs
+	unimplemented := status.Error(codes.Unimplemented, "unknown service spire.api.server.entry.v1.Entry")
+	tc.entryClient.err = unimplemented
+	tc.svidClient.batchSVIDErr = errors.New("v1 API should not be used")
+
+	update, err := client.FetchUpdates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, nodeClient.update.RegistrationEntries[0], update.Entries["ENTRYID1"])
+	assert.Equal(t, nodeClient.update.Bundles, update.Bundles)
+	assert.True(t, client.useLegacyAPI())
+	assert.Equal(t, float32(1), legacyAPIGauge(metrics))
+
+	// Once detected, the legacy API is used for every call
+	bundles, err := client.FetchBundles(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*common.Bundle{
+		"spiffe://example.org": {TrustDomainId: "spiffe://example.org"},
+	}, bundles)
+
+	svids, err := client.NewX509SVIDs(context.Background(), map[string][]byte{"ENTRYID1": {1}, "ENTRYID2": {2}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*node.X509SVID{"ENTRYID1": {CertChain: []byte{1}, ExpiresAt: 1}}, svids)
+	assert.Equal(t, map[string][]byte{"ENTRYID1": {1}, "ENTRYID2": {2}}, nodeClient.csrs)
+
+	svid, err := client.RenewSVID(context.Background(), []byte{3})
+	require.NoError(t, err)
+	assert.Equal(t, &AgentSVID{CertChain: []byte{2}, ExpiresAt: time.Unix(2, 0)}, svid)
+	assert.Equal(t, map[string][]byte{"spiffe://example.org/spire/agent/test/host": {3}}, nodeClient.csrs)
+
+	jwtSVID, err := client.NewJWTSVID(context.Background(), &node.JSR{
+		SpiffeId: "spiffe://example.org/id1",
+		Audience: []string{"aud"},
+	}, "ENTRYID1")
+	require.NoError(t, err)
+	assert.Equal(t, "token", jwtSVID.Token)
+	assert.Equal(t, "spiffe://example.org/id1", nodeClient.jsr.SpiffeId)
+
+	// The API is detected again when the client reconnects, since the
+	// server may have been upgraded
+	client.Release()
+	assert.False(t, client.useLegacyAPI())
+	assert.Equal(t, float32(0), legacyAPIGauge(metrics))
+
+	tc.entryClient.err = nil
+	_, err = client.FetchUpdates(context.Background())
+	require.NoError(t, err)
+	assert.False(t, client.useLegacyAPI())
+}
+
+func legacyAPIGauge(metrics *fakemetrics.FakeMetrics) float32 {
+	var legacy float32
+	for _, item := range metrics.AllMetrics() {
+		if item.Type == fakemetrics.SetGaugeType {
+			legacy = item.Val
+		}
+	}
+	return legacy
+}
+
+type fakeNodeClient struct {
+	node.NodeClient
+
+	update  *node.X509SVIDUpdate
+	jwtSVID *node.JWTSVID
+	csrs    map[string][]byte
+	jsr     *node.JSR
+}
+
+func (c *fakeNodeClient) FetchX509SVID(ctx context.Context, opts ...grpc.CallOption) (node.Node_FetchX509SVIDClient, error) {
+	return &fakeFetchX509SVIDClient{c: c}, nil
+}
+
+func (c *fakeNodeClient) FetchJWTSVID(ctx context.Context, in *node.FetchJWTSVIDRequest, opts ...grpc.CallOption) (*node.FetchJWTSVIDResponse, error) {
+	c.jsr = in.Jsr
+	return &node.FetchJWTSVIDResponse{Svid: c.jwtSVID}, nil
+}
+
+type fakeFetchX509SVIDClient struct {
+	grpc.ClientStream
+	c *fakeNodeClient
+}
+
+func (s *fakeFetchX509SVIDClient) Send(req *node.FetchX509SVIDRequest) error {
+	s.c.csrs = req.Csrs
+	return nil
+}
+
+func (s *fakeFetchX509SVIDClient) Recv() (*node.FetchX509SVIDResponse, error) {
+	return &node.FetchX509SVIDResponse{SvidUpdate: s.c.update}, nil
+}
+
+func (s *fakeFetchX509SVIDClient) CloseSend() error {
+	return nil
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// ResolverScheme is the gRPC target scheme handled by the resolver
+	// returned by NewResolverBuilder.
+	ResolverScheme = "spire-server"
+
+	// DefaultMinResolveInterval is the default minimum amount of time between
+	// resolutions of the server address.
+	DefaultMinResolveInterval = 30 * time.Second
+
+	resolveTimeout = 10 * time.Second
+)
+
+// ResolverConfig controls how the SPIRE server address is resolved.
+type ResolverConfig struct {
+	// StaticAddrs, if set, pins the server to the given IP:port addresses.
+	// DNS is not consulted.
+	StaticAddrs []string
+
+	// DNSServer, if set, is the address (host:port) of the DNS server used to
+	// resolve the server address instead of the system resolver.
+	DNSServer string
+
+	// MinResolveInterval bounds how often the server address is re-resolved
+	// when connections to the server fail. Defaults to
+	// DefaultMinResolveInterval.
+	MinResolveInterval time.Duration
+}
+
+// NewResolverBuilder returns a gRPC resolver builder for the ResolverScheme
+// scheme. Unlike the default DNS resolver, addresses that were previously
+// resolved are kept when resolution fails, so that a flapping DNS does not
+// leave the agent without a server to connect to.
+func NewResolverBuilder(log logrus.FieldLogger, config ResolverConfig) resolver.Builder {
+	if config.MinResolveInterval <= 0 {
+		config.MinResolveInterval = DefaultMinResolveInterval
+	}
+
+	lookupHost := net.DefaultResolver.LookupHost
+	if config.DNSServer != "" {
+		dnsServer := config.DNSServer
+		r := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, dnsServer)
+			},
+		}
+		lookupHost = r.LookupHost
+	}
+
+	return &resolverBuilder{
+		log:        log,
+		config:     config,
+		clk:        clock.New(),
+		lookupHost: lookupHost,
+	}
+}
+
+type resolverBuilder struct {
+	log        logrus.FieldLogger
+	config     ResolverConfig
+	clk        clock.Clock
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+func (b *resolverBuilder) Scheme() string {
+	return ResolverScheme
+}
+
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	if len(b.config.StaticAddrs) > 0 {
+		var addrs []resolver.Address
+		for _, addr := range b.config.StaticAddrs {
+			addrs = append(addrs, resolver.Address{Addr: addr})
+		}
+		cc.UpdateState(resolver.State{Addresses: addrs})
+		return staticResolver{}, nil
+	}
+
+	host, port, err := net.SplitHostPort(target.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// IP literals do not need to be resolved
+	if net.ParseIP(host) != nil {
+		cc.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: target.Endpoint}}})
+		return staticResolver{}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &dnsResolver{
+		log:         b.log.WithField(telemetry.Address, target.Endpoint),
+		cc:          cc,
+		clk:         b.clk,
+		host:        host,
+		port:        port,
+		lookupHost:  b.lookupHost,
+		minInterval: b.config.MinResolveInterval,
+		ctx:         ctx,
+		cancel:      cancel,
+		rn:          make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+type staticResolver struct{}
+
+func (staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (staticResolver) Close() {}
+
+type dnsResolver struct {
+	log         logrus.FieldLogger
+	cc          resolver.ClientConn
+	clk         clock.Clock
+	host        string
+	port        string
+	lookupHost  func(ctx context.Context, host string) ([]string, error)
+	minInterval time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	rn     chan struct{}
+
+	// resolved is true once the address has been successfully resolved
+	resolved bool
+}
+
+func (r *dnsResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.rn <- struct{}{}:
+	default:
+	}
+}
+
+func (r *dnsResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *dnsResolver) watch() {
+	defer r.wg.Done()
+	for {
+		r.resolve()
+
+		// Wait at least the minimum interval before honoring the next
+		// resolution request to avoid hammering the DNS server while the
+		// server is unreachable.
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.clk.After(r.minInterval):
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.rn:
+		}
+	}
+}
+
+func (r *dnsResolver) resolve() {
+	ctx, cancel := context.WithTimeout(r.ctx, resolveTimeout)
+	defer cancel()
+
+	hosts, err := r.lookupHost(ctx, r.host)
+	if err == nil && len(hosts) == 0 {
+		err = &net.DNSError{Err: "no addresses found", Name: r.host}
+	}
+	if err != nil {
+		if r.ctx.Err() != nil {
+			return
+		}
+		if r.resolved {
+			r.log.WithError(err).Warn("Failed to resolve server address; keeping previously resolved addresses")
+			return
+		}
+		r.log.WithError(err).Error("Failed to resolve server address")
+		r.cc.ReportError(err)
+		return
+	}
+
+	addrs := make([]resolver.Address, 0, len(hosts))
+	for _, host := range hosts {
+		addrs = append(addrs, resolver.Address{Addr: net.JoinHostPort(host, r.port)})
+	}
+	r.resolved = true
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
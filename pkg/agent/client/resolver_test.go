@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+func TestResolverStaticAddrs(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	builder := NewResolverBuilder(log, ResolverConfig{
+		StaticAddrs: []string{"10.0.0.1:8081", "10.0.0.2:8081"},
+	})
+	assert.Equal(t, ResolverScheme, builder.Scheme())
+
+	cc := newFakeClientConn()
+	r, err := builder.Build(resolver.Target{Endpoint: "spire-server:8081"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Equal(t, []string{"10.0.0.1:8081", "10.0.0.2:8081"}, cc.waitForAddrs(t))
+}
+
+func TestResolverIPLiteral(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	builder := NewResolverBuilder(log, ResolverConfig{})
+
+	cc := newFakeClientConn()
+	r, err := builder.Build(resolver.Target{Endpoint: "10.0.0.1:8081"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Equal(t, []string{"10.0.0.1:8081"}, cc.waitForAddrs(t))
+}
+
+func TestResolverInvalidEndpoint(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	builder := NewResolverBuilder(log, ResolverConfig{})
+
+	_, err := builder.Build(resolver.Target{Endpoint: "spire-server"}, newFakeClientConn(), resolver.BuildOptions{})
+	require.Error(t, err)
+}
+
+func TestResolverDNS(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+
+	lookups := make(chan string, 10)
+	var mtx sync.Mutex
+	var hosts []string
+	var lookupErr error
+	setLookup := func(h []string, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		hosts = h
+		lookupErr = err
+	}
+
+	builder := NewResolverBuilder(log, ResolverConfig{MinResolveInterval: time.Minute}).(*resolverBuilder)
+	builder.clk = clk
+	builder.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		lookups <- host
+		return hosts, lookupErr
+	}
+
+	// The initial resolution fails and the error is reported
+	setLookup(nil, errors.New("oh no"))
+	cc := newFakeClientConn()
+	r, err := builder.Build(resolver.Target{Endpoint: "spire-server:8081"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, "spire-server", <-lookups)
+	assert.EqualError(t, cc.waitForErr(t), "oh no")
+
+	// Re-resolution is not attempted before the minimum interval elapses
+	setLookup([]string{"10.0.0.1", "10.0.0.2"}, nil)
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	clk.WaitForAfter(time.Minute, "waiting for the minimum resolve interval")
+	assert.Empty(t, lookups)
+	clk.Add(time.Minute)
+	assert.Equal(t, "spire-server", <-lookups)
+	assert.Equal(t, []string{"10.0.0.1:8081", "10.0.0.2:8081"}, cc.waitForAddrs(t))
+
+	// A failed re-resolution keeps the previously resolved addresses
+	setLookup(nil, errors.New("oh no"))
+	clk.WaitForAfter(time.Minute, "waiting for the minimum resolve interval")
+	clk.Add(time.Minute)
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	assert.Equal(t, "spire-server", <-lookups)
+	clk.WaitForAfter(time.Minute, "waiting for the minimum resolve interval")
+	cc.assertNoUpdates(t)
+}
+
+type fakeClientConn struct {
+	resolver.ClientConn
+
+	addrs chan []string
+	errs  chan error
+}
+
+func newFakeClientConn() *fakeClientConn {
+	return &fakeClientConn{
+		addrs: make(chan []string, 10),
+		errs:  make(chan error, 10),
+	}
+}
+
+func (cc *fakeClientConn) UpdateState(state resolver.State) {
+	var addrs []string
+	for _, addr := range state.Addresses {
+		addrs = append(addrs, addr.Addr)
+	}
+	cc.addrs <- addrs
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.errs <- err
+}
+
+func (cc *fakeClientConn) waitForAddrs(t *testing.T) []string {
+	select {
+	case addrs := <-cc.addrs:
+		return addrs
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for addresses")
+		return nil
+	}
+}
+
+func (cc *fakeClientConn) waitForErr(t *testing.T) error {
+	select {
+	case err := <-cc.errs:
+		return err
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for error")
+		return nil
+	}
+}
+
+func (cc *fakeClientConn) assertNoUpdates(t *testing.T) {
+	assert.Empty(t, cc.addrs)
+	assert.Empty(t, cc.errs)
+}
@@ -0,0 +1,55 @@
+package keyreuse
+
+import (
+	"time"
+)
+
+// Policy controls the reuse of a key pair across SVID renewals. Generating
+// a key pair is expensive on low-power devices, so a key pair can be kept
+// for a bounded number of renewals, and a bounded time, before a new one is
+// generated. The zero value disables key reuse.
+type Policy struct {
+	// MaxRenewals is the number of renewals a key pair is reused for before
+	// a new one is generated. Key reuse is disabled if zero.
+	MaxRenewals int
+
+	// MaxAge is the time from its generation after which a key pair is no
+	// longer reused. If zero, key pairs are only bounded by MaxRenewals.
+	MaxAge time.Duration
+}
+
+// State tracks the use of a key pair across SVID renewals.
+type State struct {
+	// GeneratedAt is the time the key pair was generated.
+	GeneratedAt time.Time
+
+	// Renewals is the number of renewals the key pair was reused for.
+	Renewals int
+}
+
+// New returns the state of a key pair generated at the given time.
+func New(now time.Time) State {
+	return State{GeneratedAt: now}
+}
+
+// Reused returns the state of the key pair after it is reused for another
+// renewal.
+func (s State) Reused() State {
+	s.Renewals++
+	return s
+}
+
+// Reuse returns true if a key pair in the given state can be reused for
+// another renewal at the given time.
+func (p Policy) Reuse(s State, now time.Time) bool {
+	switch {
+	case p.MaxRenewals <= 0:
+		return false
+	case s.Renewals >= p.MaxRenewals:
+		return false
+	case p.MaxAge > 0 && now.Sub(s.GeneratedAt) >= p.MaxAge:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,61 @@
+package keyreuse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReuse(t *testing.T) {
+	now := time.Now()
+	generated := New(now.Add(-time.Hour))
+
+	for _, tt := range []struct {
+		name   string
+		policy Policy
+		state  State
+		expect bool
+	}{
+		{
+			name:   "disabled",
+			state:  generated,
+			expect: false,
+		},
+		{
+			name:   "renewals left",
+			policy: Policy{MaxRenewals: 2},
+			state:  generated.Reused(),
+			expect: true,
+		},
+		{
+			name:   "no renewals left",
+			policy: Policy{MaxRenewals: 2},
+			state:  generated.Reused().Reused(),
+			expect: false,
+		},
+		{
+			name:   "younger than max age",
+			policy: Policy{MaxRenewals: 2, MaxAge: 2 * time.Hour},
+			state:  generated,
+			expect: true,
+		},
+		{
+			name:   "as old as max age",
+			policy: Policy{MaxRenewals: 2, MaxAge: time.Hour},
+			state:  generated,
+			expect: false,
+		},
+		{
+			name:   "unknown generation time",
+			policy: Policy{MaxRenewals: 2, MaxAge: time.Hour},
+			state:  State{},
+			expect: false,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.policy.Reuse(tt.state, now))
+		})
+	}
+}
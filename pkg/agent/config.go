@@ -6,9 +6,14 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -16,6 +21,11 @@ type Config struct {
 	// Address to bind the workload api to
 	BindAddress *net.UnixAddr
 
+	// InMemoryWorkloadAPI, if set, serves the workload api in memory to the
+	// process embedding the agent, instead of on BindAddress. It can only be
+	// used for a single run of the agent.
+	InMemoryWorkloadAPI *InMemoryWorkloadAPI
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -31,20 +41,53 @@ type Config struct {
 	// If true, the agent will bootstrap insecurely with the server
 	InsecureBootstrap bool
 
+	// If true, the agent locks its memory so that key material is never
+	// swapped to disk
+	LockMemory bool
+
 	// HealthChecks provides the configuration for health monitoring
 	HealthChecks health.Config
 
 	// Configurations for agent plugins
 	PluginConfigs catalog.HCLPluginConfigMap
 
+	// PluginPolicy restricts which plugins can be loaded
+	PluginPolicy catalog.PluginPolicy
+
 	Log logrus.FieldLogger
 
 	// Address of SPIRE server
 	ServerAddress string
 
+	// ServerResolver, if set, controls how ServerAddress is resolved. The
+	// address must then use the client.ResolverScheme scheme.
+	ServerResolver *client.ResolverConfig
+
 	// SyncInterval controls how often the agent sync synchronizer waits
 	SyncInterval time.Duration
 
+	// BundleSyncInterval controls how often bundles are synchronized, if
+	// different from SyncInterval
+	BundleSyncInterval time.Duration
+
+	// MaxSyncInterval, if greater than SyncInterval, is the maximum interval
+	// the agent sync synchronizer waits while registration entries do not
+	// change
+	MaxSyncInterval time.Duration
+
+	// HedgeDelay, if positive, is how long the agent waits on an idempotent
+	// read RPC to the server before sending a duplicate request
+	HedgeDelay time.Duration
+
+	// Clock is used by the manager to schedule synchronization and SVID
+	// rotation. Tests can set a mock clock to advance time deterministically.
+	// If unset, the wall clock is used.
+	Clock clock.Clock
+
+	// KeyReuse controls the reuse of the agent and workload SVID keys across
+	// SVID renewals
+	KeyReuse keyreuse.Policy
+
 	// Trust domain and associated CA bundle
 	TrustDomain url.URL
 	TrustBundle []*x509.Certificate
@@ -66,6 +109,35 @@ type Config struct {
 
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
+
+	// WorkloadAPILimits bounds the load workloads can put on the agent
+	// through the Workload API and SDS
+	WorkloadAPILimits endpoints.LimitsConfig
+
+	// WorkloadAPISocket controls the permissions of the Workload API socket
+	WorkloadAPISocket endpoints.SocketConfig
+
+	// WorkloadAPIAudit controls the Workload API audit log
+	WorkloadAPIAudit endpoints.AuditConfig
+
+	// SelectorRedactor redacts sensitive selector values in the logs
+	SelectorRedactor *selector.Redactor
+
+	// PrivilegedHelper, if set, configures the workload attestors that are
+	// run by the privileged helper process instead of the agent
+	PrivilegedHelper *PrivilegedHelperConfig
+}
+
+// PrivilegedHelperConfig configures the workload attestors run by the
+// privileged helper process, so the agent can run without the privileges
+// they require.
+type PrivilegedHelperConfig struct {
+	// BindAddress is the address of the socket the helper listens on
+	BindAddress *net.UnixAddr
+
+	// WorkloadAttestors are the names of the workload attestors run by the
+	// helper
+	WorkloadAttestors []string
 }
 
 func New(c *Config) *Agent {
@@ -0,0 +1,36 @@
+package endpoints
+
+import (
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// AuditConfig controls the Workload API audit log, which records each
+// authorization decision made by the Workload API.
+type AuditConfig struct {
+	// Enabled turns the audit log on
+	Enabled bool
+
+	// MaxEventsPerSecond bounds how many audit events are logged per
+	// second. If zero, workload.DefaultAuditMaxEventsPerSecond is used.
+	MaxEventsPerSecond int
+
+	// SelectorRedactor redacts the selectors of the callers
+	SelectorRedactor *selector.Redactor
+}
+
+func newAuditor(c AuditConfig, log logrus.FieldLogger, metrics telemetry.Metrics, clk clock.Clock) *workload.Auditor {
+	if !c.Enabled {
+		return nil
+	}
+	return workload.NewAuditor(workload.AuditorConfig{
+		Log:                log,
+		Metrics:            metrics,
+		MaxEventsPerSecond: c.MaxEventsPerSecond,
+		SelectorRedactor:   c.SelectorRedactor,
+		Clock:              clk,
+	})
+}
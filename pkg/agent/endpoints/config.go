@@ -20,6 +20,11 @@ import (
 type Config struct {
 	BindAddr *net.UnixAddr
 
+	// Listener, if set, is used to serve the APIs instead of a socket bound
+	// to BindAddr. The connections it accepts must be *peertracker.Conn
+	// (e.g. as accepted by a peertracker.PipeListener).
+	Listener net.Listener
+
 	Attestor attestor.Attestor
 
 	Manager manager.Manager
@@ -34,6 +39,15 @@ type Config struct {
 	// The Validation Context resource name to use for the default X.509 bundle with Envoy SDS
 	DefaultBundleName string
 
+	// Limits protects the agent from being overloaded by workloads
+	Limits LimitsConfig
+
+	// Socket controls the permissions of the Workload API socket
+	Socket SocketConfig
+
+	// Audit controls the Workload API audit log
+	Audit AuditConfig
+
 	// Hooks used by the unit tests to assert that the configuration provided
 	// to each handler is correct and return fake handlers.
 	newWorkloadAPIServer func(workload.Config) workload_pb.SpiffeWorkloadAPIServer
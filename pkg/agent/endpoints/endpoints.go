@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 
+	"github.com/andres-erbsen/clock"
 	discovery_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
 	"github.com/sirupsen/logrus"
@@ -27,16 +28,22 @@ type Server interface {
 
 type Endpoints struct {
 	addr              *net.UnixAddr
+	listener          net.Listener
+	socket            SocketConfig
 	log               logrus.FieldLogger
 	metrics           telemetry.Metrics
 	workloadAPIServer workload_pb.SpiffeWorkloadAPIServer
 	sdsv2Server       discovery_v2.SecretDiscoveryServiceServer
 	sdsv3Server       secret_v3.SecretDiscoveryServiceServer
 	healthServer      grpc_health_v1.HealthServer
+	streamLimit       grpc.StreamServerInterceptor
 }
 
 func New(c Config) *Endpoints {
-	attestor := peerTrackerAttestor{Attestor: c.Attestor}
+	attestor := peerTrackerAttestor{
+		Attestor: c.Attestor,
+		Limiter:  newAttestationLimiter(c.Limits, c.Metrics, clock.New()),
+	}
 
 	if c.newWorkloadAPIServer == nil {
 		c.newWorkloadAPIServer = func(c workload.Config) workload_pb.SpiffeWorkloadAPIServer {
@@ -62,6 +69,7 @@ func New(c Config) *Endpoints {
 	workloadAPIServer := c.newWorkloadAPIServer(workload.Config{
 		Manager:  c.Manager,
 		Attestor: attestor,
+		Auditor:  newAuditor(c.Audit, c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAPIAudit), c.Metrics, clock.New()),
 	})
 
 	sdsv2Server := c.newSDSv2Server(sdsv2.Config{
@@ -78,18 +86,25 @@ func New(c Config) *Endpoints {
 		DefaultBundleName: c.DefaultBundleName,
 	})
 
+	socketPath := c.BindAddr.String()
+	if c.Listener != nil {
+		socketPath = c.Listener.Addr().String()
+	}
 	healthServer := c.newHealthServer(healthv1.Config{
-		SocketPath: c.BindAddr.String(),
+		SocketPath: socketPath,
 	})
 
 	return &Endpoints{
 		addr:              c.BindAddr,
+		listener:          c.Listener,
+		socket:            c.Socket,
 		log:               c.Log,
 		metrics:           c.Metrics,
 		workloadAPIServer: workloadAPIServer,
 		sdsv2Server:       sdsv2Server,
 		sdsv3Server:       sdsv3Server,
 		healthServer:      healthServer,
+		streamLimit:       streamLimitInterceptor(c.Limits.MaxStreams, c.Metrics),
 	}
 }
 
@@ -98,6 +113,16 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 		Middleware(e.log, e.metrics),
 	)
 
+	// Panics are recovered inside of the middleware so that it observes the
+	// resulting error
+	recoveryUnary, recoveryStream := middleware.RecoveryInterceptors(e.log, e.metrics)
+	unaryInterceptor = middleware.ChainUnaryInterceptors(unaryInterceptor, recoveryUnary)
+	streamInterceptor = middleware.ChainStreamInterceptors(streamInterceptor, recoveryStream)
+
+	if e.streamLimit != nil {
+		streamInterceptor = middleware.ChainStreamInterceptors(e.streamLimit, streamInterceptor)
+	}
+
 	server := grpc.NewServer(
 		grpc.Creds(peertracker.NewCredentials()),
 		grpc.UnaryInterceptor(unaryInterceptor),
@@ -109,7 +134,7 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	secret_v3.RegisterSecretDiscoveryServiceServer(server, e.sdsv3Server)
 	grpc_health_v1.RegisterHealthServer(server, e.healthServer)
 
-	l, err := e.createUDSListener()
+	l, err := e.createListener()
 	if err != nil {
 		return err
 	}
@@ -132,6 +157,13 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	return err
 }
 
+func (e *Endpoints) createListener() (net.Listener, error) {
+	if e.listener != nil {
+		return e.listener, nil
+	}
+	return e.createUDSListener()
+}
+
 func (e *Endpoints) createUDSListener() (net.Listener, error) {
 	// Remove uds if already exists
 	os.Remove(e.addr.String())
@@ -145,8 +177,9 @@ func (e *Endpoints) createUDSListener() (net.Listener, error) {
 		return nil, fmt.Errorf("create UDS listener: %s", err)
 	}
 
-	if err := os.Chmod(e.addr.String(), os.ModePerm); err != nil {
-		return nil, fmt.Errorf("unable to change UDS permissions: %v", err)
+	if err := applySocketConfig(e.addr.String(), e.socket); err != nil {
+		l.Close()
+		return nil, err
 	}
 	return l, nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/endpoints/sdsv3"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
@@ -240,6 +241,59 @@ func TestEndpoints(t *testing.T) {
 	}
 }
 
+func TestEndpointsOverPipeListener(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	log, hook := test.NewNullLogger()
+	listener := peertracker.NewPipeListener()
+
+	endpoints := New(Config{
+		Listener: listener,
+		Log:      log,
+		Metrics:  fakemetrics.New(),
+		Attestor: FakeAttestor{},
+		Manager:  FakeManager{},
+		newWorkloadAPIServer: func(c workload.Config) workload_pb.SpiffeWorkloadAPIServer {
+			attestor, ok := c.Attestor.(peerTrackerAttestor)
+			require.True(t, ok, "attestor was not a peerTrackerAttestor wrapper")
+			return FakeWorkloadAPIServer{Attestor: attestor}
+		},
+		newHealthServer: func(c healthv1.Config) grpc_health_v1.HealthServer {
+			assert.Equal(t, "pipe", c.SocketPath)
+			return FakeHealthServer{}
+		},
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "passthrough:///pipe",
+		grpc.WithContextDialer(listener.DialContext),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The caller is attested as the current process
+	wlClient := workload_pb.NewSpiffeWorkloadAPIClient(conn)
+	_, err = wlClient.FetchJWTSVID(metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true")), &workload_pb.JWTSVIDRequest{})
+	require.NoError(t, err)
+
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{Level: logrus.InfoLevel, Message: "Starting Workload and SDS APIs"},
+		logEntryWithPID(logrus.InfoLevel, "Success",
+			"method", "FetchJWTSVID",
+			"service", "WorkloadAPI",
+		),
+	})
+}
+
 type FakeManager struct {
 	manager.Manager
 }
@@ -0,0 +1,123 @@
+package endpoints
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	workloadAPITelemetry "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// LimitsConfig holds the limits that keep the agent responsive when the
+// Workload API socket is under heavy load. Limits that are zero are not
+// enforced.
+type LimitsConfig struct {
+	// MaxConcurrentAttestations is the maximum number of workload
+	// attestations performed concurrently across the Workload API and SDS.
+	MaxConcurrentAttestations int
+
+	// AttestationQueueTimeout is how long a caller waits for an attestation
+	// slot before the call is shed. If zero, calls are shed as soon as the
+	// limit is reached.
+	AttestationQueueTimeout time.Duration
+
+	// MaxStreams is the maximum number of concurrently open Workload API and
+	// SDS streams.
+	MaxStreams int
+}
+
+// attestationLimiter bounds the number of concurrent workload attestations.
+// Callers that cannot get a slot right away wait up to the queue timeout and
+// are rejected with a RESOURCE_EXHAUSTED error afterwards, so that a burst of
+// short-lived processes cannot pile up unbounded attestation work.
+type attestationLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+	metrics      telemetry.Metrics
+	clk          clock.Clock
+}
+
+func newAttestationLimiter(config LimitsConfig, metrics telemetry.Metrics, clk clock.Clock) *attestationLimiter {
+	if config.MaxConcurrentAttestations <= 0 {
+		return nil
+	}
+	return &attestationLimiter{
+		slots:        make(chan struct{}, config.MaxConcurrentAttestations),
+		queueTimeout: config.AttestationQueueTimeout,
+		metrics:      metrics,
+		clk:          clk,
+	}
+}
+
+// acquire waits for an attestation slot. On success, the returned function
+// must be called to release the slot.
+func (l *attestationLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() { <-l.slots }
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if l.queueTimeout <= 0 {
+		return nil, l.shed()
+	}
+
+	workloadAPITelemetry.IncrAttestationQueuedCounter(l.metrics)
+	timer := l.clk.Timer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, l.shed()
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, "deadline exceeded while waiting for a workload attestation slot")
+		}
+		return nil, status.Error(codes.Canceled, "canceled while waiting for a workload attestation slot")
+	}
+}
+
+func (l *attestationLimiter) shed() error {
+	workloadAPITelemetry.IncrAttestationShedCounter(l.metrics)
+	return status.Errorf(codes.ResourceExhausted, "too many concurrent workload attestations (limit %d)", cap(l.slots))
+}
+
+// streamLimitInterceptor returns a stream interceptor that rejects new
+// Workload API and SDS streams with a RESOURCE_EXHAUSTED error once
+// maxStreams streams are open. Health checks are never limited. If
+// maxStreams is zero or less, nil is returned.
+func streamLimitInterceptor(maxStreams int, metrics telemetry.Metrics) grpc.StreamServerInterceptor {
+	if maxStreams <= 0 {
+		return nil
+	}
+
+	var open int32
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(srv, ss)
+		}
+
+		n := atomic.AddInt32(&open, 1)
+		defer func() {
+			workloadAPITelemetry.SetStreamTotalGauge(metrics, atomic.AddInt32(&open, -1))
+		}()
+		if n > int32(maxStreams) {
+			workloadAPITelemetry.IncrStreamShedCounter(metrics)
+			return status.Errorf(codes.ResourceExhausted, "too many concurrent streams (limit %d)", maxStreams)
+		}
+		workloadAPITelemetry.SetStreamTotalGauge(metrics, n)
+		return handler(srv, ss)
+	}
+}
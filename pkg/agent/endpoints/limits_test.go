@@ -0,0 +1,174 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	attestationQueuedMetric = fakemetrics.MetricItem{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "workload_attestation", "queued"}, Val: 1}
+	attestationShedMetric   = fakemetrics.MetricItem{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "workload_attestation", "shed"}, Val: 1}
+)
+
+func TestAttestationLimiterDisabled(t *testing.T) {
+	assert.Nil(t, newAttestationLimiter(LimitsConfig{}, fakemetrics.New(), clock.NewMock(t)))
+}
+
+func TestAttestationLimiterShedsWithoutQueueTimeout(t *testing.T) {
+	metrics := fakemetrics.New()
+	limiter := newAttestationLimiter(LimitsConfig{MaxConcurrentAttestations: 1}, metrics, clock.NewMock(t))
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	_, err = limiter.acquire(context.Background())
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "too many concurrent workload attestations (limit 1)")
+	assert.Equal(t, []fakemetrics.MetricItem{attestationShedMetric}, metrics.AllMetrics())
+
+	// Releasing the slot makes room for the next attestation
+	release()
+	release, err = limiter.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
+
+func TestAttestationLimiterQueues(t *testing.T) {
+	metrics := fakemetrics.New()
+	clk := clock.NewMock(t)
+	limiter := newAttestationLimiter(LimitsConfig{
+		MaxConcurrentAttestations: 1,
+		AttestationQueueTimeout:   time.Second,
+	}, metrics, clk)
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		release, err := limiter.acquire(context.Background())
+		if err == nil {
+			release()
+		}
+		errCh <- err
+	}()
+
+	clk.WaitForTimer(time.Minute, "timed out waiting for the queue timer")
+	release()
+	require.NoError(t, <-errCh)
+	assert.Equal(t, []fakemetrics.MetricItem{attestationQueuedMetric}, metrics.AllMetrics())
+}
+
+func TestAttestationLimiterQueueTimeout(t *testing.T) {
+	metrics := fakemetrics.New()
+	clk := clock.NewMock(t)
+	limiter := newAttestationLimiter(LimitsConfig{
+		MaxConcurrentAttestations: 1,
+		AttestationQueueTimeout:   time.Second,
+	}, metrics, clk)
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := limiter.acquire(context.Background())
+		errCh <- err
+	}()
+
+	clk.WaitForTimer(time.Minute, "timed out waiting for the queue timer")
+	clk.Add(time.Second)
+	spiretest.RequireGRPCStatus(t, <-errCh, codes.ResourceExhausted, "too many concurrent workload attestations (limit 1)")
+	assert.Equal(t, []fakemetrics.MetricItem{attestationQueuedMetric, attestationShedMetric}, metrics.AllMetrics())
+}
+
+func TestAttestationLimiterQueueCanceled(t *testing.T) {
+	clk := clock.NewMock(t)
+	limiter := newAttestationLimiter(LimitsConfig{
+		MaxConcurrentAttestations: 1,
+		AttestationQueueTimeout:   time.Second,
+	}, fakemetrics.New(), clk)
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := limiter.acquire(ctx)
+		errCh <- err
+	}()
+
+	clk.WaitForTimer(time.Minute, "timed out waiting for the queue timer")
+	cancel()
+	spiretest.RequireGRPCStatus(t, <-errCh, codes.Canceled, "canceled while waiting for a workload attestation slot")
+}
+
+func TestPeerTrackerAttestorWithLimiter(t *testing.T) {
+	limiter := newAttestationLimiter(LimitsConfig{MaxConcurrentAttestations: 1}, fakemetrics.New(), clock.NewMock(t))
+	attestor := peerTrackerAttestor{Attestor: FakeAttestor{}, Limiter: limiter}
+
+	// The slot is released once the attestation completes
+	for i := 0; i < 2; i++ {
+		_, err := attestor.Attest(WithFakeWatcher(true))
+		require.NoError(t, err)
+	}
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	_, err = attestor.Attest(WithFakeWatcher(true))
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "too many concurrent workload attestations (limit 1)")
+}
+
+func TestStreamLimitInterceptor(t *testing.T) {
+	assert.Nil(t, streamLimitInterceptor(0, fakemetrics.New()))
+
+	metrics := fakemetrics.New()
+	interceptor := streamLimitInterceptor(1, metrics)
+
+	workloadInfo := &grpc.StreamServerInfo{FullMethod: "/SpiffeWorkloadAPI/FetchX509SVID"}
+	healthInfo := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}
+
+	var handled int
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+
+	// Open a stream and, while it is open, try to open other streams
+	err := interceptor(nil, nil, workloadInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		err := interceptor(nil, nil, workloadInfo, handler)
+		spiretest.AssertGRPCStatus(t, err, codes.ResourceExhausted, "too many concurrent streams (limit 1)")
+
+		// Health checks are not limited
+		assert.NoError(t, interceptor(nil, nil, healthInfo, handler))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, handled)
+
+	// The stream has been closed so a new one can be opened
+	require.NoError(t, interceptor(nil, nil, workloadInfo, handler))
+	assert.Equal(t, 2, handled)
+
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "streams"}, Val: 1},
+		{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "streams", "shed"}, Val: 1},
+		{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "streams"}, Val: 1},
+		{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "streams"}, Val: 0},
+		{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "streams"}, Val: 1},
+		{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "streams"}, Val: 0},
+	}, metrics.AllMetrics())
+}
@@ -12,6 +12,9 @@ import (
 
 type peerTrackerAttestor struct {
 	Attestor attestor.Attestor
+
+	// Limiter, if set, bounds the number of concurrent attestations.
+	Limiter *attestationLimiter
 }
 
 func (a peerTrackerAttestor) Attest(ctx context.Context) ([]*common.Selector, error) {
@@ -20,6 +23,14 @@ func (a peerTrackerAttestor) Attest(ctx context.Context) ([]*common.Selector, er
 		return nil, status.Error(codes.Internal, "peer tracker watcher missing from context")
 	}
 
+	if a.Limiter != nil {
+		release, err := a.Limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	selectors := a.Attestor.Attest(ctx, watcher.PID())
 
 	// Ensure that the original caller is still alive so that we know we didn't
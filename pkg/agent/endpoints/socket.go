@@ -0,0 +1,98 @@
+package endpoints
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// defaultSocketMode is the mode of the Workload API socket when no mode is
+// configured. Workloads authenticate through attestation rather than through
+// file permissions, so by default any process can connect.
+const defaultSocketMode os.FileMode = os.ModePerm
+
+// SocketConfig controls the permissions applied to the Workload API socket
+// when it is created. On multi-tenant hosts it can be used to restrict which
+// local users are able to connect to the agent at all.
+type SocketConfig struct {
+	// Mode is the file mode of the socket. If zero, the socket is accessible
+	// by everyone (0777).
+	Mode os.FileMode
+
+	// Owner is the user, by name or numeric ID, that owns the socket. If
+	// empty, the owner is left unchanged.
+	Owner string
+
+	// Group is the group, by name or numeric ID, that owns the socket. If
+	// empty, the group is left unchanged.
+	Group string
+
+	// SELinuxContext is the SELinux security context the socket is labeled
+	// with. If empty, the label assigned by the system is kept. Only
+	// supported on Linux.
+	SELinuxContext string
+}
+
+// applySocketConfig sets the ownership, mode and SELinux label of the socket
+// at the given path. Ownership is changed before the mode so that the socket
+// is never accessible with the new mode by the previous owner.
+func applySocketConfig(path string, c SocketConfig) error {
+	if c.Owner != "" || c.Group != "" {
+		uid, gid := -1, -1
+		if c.Owner != "" {
+			var err error
+			uid, err = lookupUID(c.Owner)
+			if err != nil {
+				return err
+			}
+		}
+		if c.Group != "" {
+			var err error
+			gid, err = lookupGID(c.Group)
+			if err != nil {
+				return err
+			}
+		}
+		if err := os.Chown(path, uid, gid); err != nil {
+			return fmt.Errorf("unable to change UDS owner: %v", err)
+		}
+	}
+
+	mode := c.Mode
+	if mode == 0 {
+		mode = defaultSocketMode
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("unable to change UDS permissions: %v", err)
+	}
+
+	if c.SELinuxContext != "" {
+		if err := setSELinuxContext(path, c.SELinuxContext); err != nil {
+			return fmt.Errorf("unable to set UDS SELinux context: %v", err)
+		}
+	}
+	return nil
+}
+
+func lookupUID(owner string) (int, error) {
+	if uid, err := strconv.Atoi(owner); err == nil {
+		return uid, nil
+	}
+	u, err := user.Lookup(owner)
+	if err != nil {
+		return 0, fmt.Errorf("unable to look up UDS owner %q: %v", owner, err)
+	}
+	return strconv.Atoi(u.Uid)
+}
+
+func lookupGID(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, fmt.Errorf("unable to look up UDS group %q: %v", group, err)
+	}
+	return strconv.Atoi(g.Gid)
+}
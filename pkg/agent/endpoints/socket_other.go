@@ -0,0 +1,11 @@
+// +build !linux
+
+package endpoints
+
+import (
+	"errors"
+)
+
+func setSELinuxContext(path, context string) error {
+	return errors.New("SELinux contexts are only supported on Linux")
+}
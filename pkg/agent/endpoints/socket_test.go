@@ -0,0 +1,80 @@
+// +build !windows
+
+package endpoints
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySocketConfig(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+	uid := strconv.Itoa(os.Getuid())
+	gid := strconv.Itoa(os.Getgid())
+
+	for _, tt := range []struct {
+		name      string
+		config    SocketConfig
+		expectErr string
+		mode      os.FileMode
+	}{
+		{
+			name: "defaults",
+			mode: 0777,
+		},
+		{
+			name:   "mode",
+			config: SocketConfig{Mode: 0660},
+			mode:   0660,
+		},
+		{
+			name:   "owner and group by ID",
+			config: SocketConfig{Mode: 0600, Owner: uid, Group: gid},
+			mode:   0600,
+		},
+		{
+			name:   "owner by name",
+			config: SocketConfig{Owner: current.Username},
+			mode:   0777,
+		},
+		{
+			name:      "unknown owner",
+			config:    SocketConfig{Owner: "no-such-user-for-spire-tests"},
+			expectErr: `unable to look up UDS owner "no-such-user-for-spire-tests"`,
+		},
+		{
+			name:      "unknown group",
+			config:    SocketConfig{Group: "no-such-group-for-spire-tests"},
+			expectErr: `unable to look up UDS group "no-such-group-for-spire-tests"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(spiretest.TempDir(t), "agent.sock")
+			l, err := net.Listen("unix", path)
+			require.NoError(t, err)
+			defer l.Close()
+
+			err = applySocketConfig(path, tt.config)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.mode, info.Mode().Perm())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package workload
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	workloadAPITelemetry "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
+	"github.com/spiffe/spire/proto/spire/common"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultAuditMaxEventsPerSecond is the default rate limit of the
+	// Workload API audit log
+	DefaultAuditMaxEventsPerSecond = 100
+
+	auditAllowed = "allowed"
+	auditDenied  = "denied"
+)
+
+// AuditorConfig configures the Workload API audit log
+type AuditorConfig struct {
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+
+	// MaxEventsPerSecond bounds how many events are logged per second, so
+	// that a misbehaving workload cannot flood the logs. Events over the
+	// limit are dropped; the number of dropped events is reported with the
+	// next logged event and through telemetry. Defaults to
+	// DefaultAuditMaxEventsPerSecond.
+	MaxEventsPerSecond int
+
+	// SelectorRedactor redacts the selectors of the callers
+	SelectorRedactor *selector.Redactor
+
+	Clock clock.Clock
+}
+
+// Auditor logs each Workload API authorization decision: the selectors of
+// the caller, the registration entries it matched and the SVIDs it was
+// given, or why it was denied. This allows reconstructing which workload
+// held which identity at a given time. A nil Auditor logs nothing.
+type Auditor struct {
+	log      logrus.FieldLogger
+	metrics  telemetry.Metrics
+	clk      clock.Clock
+	limiter  *rate.Limiter
+	redactor *selector.Redactor
+
+	mu      sync.Mutex
+	dropped int
+}
+
+// NewAuditor returns a new Auditor
+func NewAuditor(c AuditorConfig) *Auditor {
+	if c.MaxEventsPerSecond <= 0 {
+		c.MaxEventsPerSecond = DefaultAuditMaxEventsPerSecond
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	return &Auditor{
+		log:      c.Log,
+		metrics:  c.Metrics,
+		clk:      c.Clock,
+		limiter:  rate.NewLimiter(rate.Limit(c.MaxEventsPerSecond), c.MaxEventsPerSecond),
+		redactor: c.SelectorRedactor,
+	}
+}
+
+// Allowed records that the caller was given the SVIDs of the identities
+func (a *Auditor) Allowed(ctx context.Context, method string, selectors []*common.Selector, identities []cache.Identity) {
+	if a == nil {
+		return
+	}
+
+	entryIDs := make([]string, 0, len(identities))
+	spiffeIDs := make([]string, 0, len(identities))
+	var serialNumbers []string
+	for _, identity := range identities {
+		entryIDs = append(entryIDs, identity.Entry.EntryId)
+		spiffeIDs = append(spiffeIDs, identity.Entry.SpiffeId)
+		if len(identity.SVID) > 0 {
+			serialNumbers = append(serialNumbers, identity.SVID[0].SerialNumber.String())
+		}
+	}
+
+	fields := logrus.Fields{
+		telemetry.RegistrationIDs: entryIDs,
+		telemetry.SPIFFEIDs:       spiffeIDs,
+	}
+	if serialNumbers != nil {
+		fields[telemetry.SerialNumbers] = serialNumbers
+	}
+	a.emit(ctx, method, auditAllowed, selectors, fields)
+}
+
+// Denied records that the caller was not given any SVID
+func (a *Auditor) Denied(ctx context.Context, method string, selectors []*common.Selector, reason string) {
+	if a == nil {
+		return
+	}
+	a.emit(ctx, method, auditDenied, selectors, logrus.Fields{
+		telemetry.Reason: reason,
+	})
+}
+
+func (a *Auditor) emit(ctx context.Context, method, decision string, selectors []*common.Selector, fields logrus.Fields) {
+	pid := rpccontext.CallerPID(ctx)
+	// The agent health check exercises the Workload API; it is not a
+	// workload and is not audited.
+	if pid == os.Getpid() {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.limiter.AllowN(a.clk.Now(), 1) {
+		a.dropped++
+		workloadAPITelemetry.AddAuditDroppedEventsCounter(a.metrics, 1)
+		return
+	}
+	if a.dropped > 0 {
+		fields[telemetry.DroppedEvents] = a.dropped
+		a.dropped = 0
+	}
+
+	fields[telemetry.Method] = method
+	fields[telemetry.Decision] = decision
+	fields[telemetry.PID] = pid
+	fields[telemetry.Selectors] = a.redactor.RedactAll(selectors)
+	a.log.WithFields(fields).Info("Workload API authorization decision")
+}
@@ -0,0 +1,160 @@
+package workload_test
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var auditSelectors = []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+
+func TestAuditorAllowed(t *testing.T) {
+	identity := cache.Identity{
+		Entry: &common.RegistrationEntry{
+			EntryId:  "ENTRYID",
+			SpiffeId: "spiffe://domain.test/workload",
+		},
+		SVID: []*x509.Certificate{{SerialNumber: big.NewInt(42)}},
+	}
+
+	auditor, logHook, _, _ := newTestAuditor(t, 10)
+	auditor.Allowed(auditContext(), "FetchX509SVID", auditSelectors, []cache.Identity{identity})
+
+	spiretest.AssertLogs(t, logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Workload API authorization decision",
+			Data: logrus.Fields{
+				telemetry.Method:          "FetchX509SVID",
+				telemetry.Decision:        "allowed",
+				telemetry.PID:             "12345",
+				telemetry.Selectors:       "[unix:uid:1000]",
+				telemetry.RegistrationIDs: "[ENTRYID]",
+				telemetry.SPIFFEIDs:       "[spiffe://domain.test/workload]",
+				telemetry.SerialNumbers:   "[42]",
+			},
+		},
+	})
+}
+
+func TestAuditorDenied(t *testing.T) {
+	auditor, logHook, _, _ := newTestAuditor(t, 10)
+	auditor.Denied(auditContext(), "FetchJWTSVID", auditSelectors, "no identity issued")
+
+	spiretest.AssertLogs(t, logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Workload API authorization decision",
+			Data: logrus.Fields{
+				telemetry.Method:    "FetchJWTSVID",
+				telemetry.Decision:  "denied",
+				telemetry.PID:       "12345",
+				telemetry.Selectors: "[unix:uid:1000]",
+				telemetry.Reason:    "no identity issued",
+			},
+		},
+	})
+}
+
+func TestAuditorRedactsSelectors(t *testing.T) {
+	redactor, err := selector.NewRedactor([]string{"unix:path:"}, nil)
+	require.NoError(t, err)
+
+	log, logHook := test.NewNullLogger()
+	auditor := workload.NewAuditor(workload.AuditorConfig{
+		Log:              log,
+		Metrics:          fakemetrics.New(),
+		SelectorRedactor: redactor,
+		Clock:            clock.NewMock(t),
+	})
+	auditor.Denied(auditContext(), "FetchJWTSVID", []*common.Selector{
+		{Type: "unix", Value: "uid:1000"},
+		{Type: "unix", Value: "path:/opt/secret"},
+	}, "no identity issued")
+
+	spiretest.AssertLogs(t, logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Workload API authorization decision",
+			Data: logrus.Fields{
+				telemetry.Method:    "FetchJWTSVID",
+				telemetry.Decision:  "denied",
+				telemetry.PID:       "12345",
+				telemetry.Selectors: "[unix:uid:1000 unix:path:[REDACTED]]",
+				telemetry.Reason:    "no identity issued",
+			},
+		},
+	})
+}
+
+func TestAuditorRateLimit(t *testing.T) {
+	auditor, logHook, metrics, clk := newTestAuditor(t, 1)
+	ctx := auditContext()
+
+	auditor.Denied(ctx, "FetchJWTSVID", auditSelectors, "first")
+	auditor.Denied(ctx, "FetchJWTSVID", auditSelectors, "dropped")
+	auditor.Denied(ctx, "FetchJWTSVID", auditSelectors, "dropped")
+	clk.Add(time.Second)
+	auditor.Denied(ctx, "FetchJWTSVID", auditSelectors, "second")
+
+	entries := logHook.AllEntries()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "first", entries[0].Data[telemetry.Reason])
+		assert.NotContains(t, entries[0].Data, telemetry.DroppedEvents)
+		assert.Equal(t, "second", entries[1].Data[telemetry.Reason])
+		assert.Equal(t, 2, entries[1].Data[telemetry.DroppedEvents])
+	}
+
+	droppedKey := []string{telemetry.WorkloadAPI, telemetry.Audit, telemetry.DroppedEvents}
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{Type: fakemetrics.IncrCounterType, Key: droppedKey, Val: 1},
+		{Type: fakemetrics.IncrCounterType, Key: droppedKey, Val: 1},
+	}, metrics.AllMetrics())
+}
+
+func TestAuditorSkipsAgentHealthCheck(t *testing.T) {
+	auditor, logHook, _, _ := newTestAuditor(t, 10)
+	ctx := rpccontext.WithCallerPID(context.Background(), os.Getpid())
+	auditor.Denied(ctx, "FetchX509SVID", auditSelectors, "no identity issued")
+	assert.Empty(t, logHook.AllEntries())
+}
+
+func TestNilAuditor(t *testing.T) {
+	var auditor *workload.Auditor
+	auditor.Allowed(auditContext(), "FetchX509SVID", auditSelectors, nil)
+	auditor.Denied(auditContext(), "FetchX509SVID", auditSelectors, "no identity issued")
+}
+
+func newTestAuditor(t *testing.T, maxEventsPerSecond int) (*workload.Auditor, *test.Hook, *fakemetrics.FakeMetrics, *clock.Mock) {
+	log, logHook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	clk := clock.NewMock(t)
+	auditor := workload.NewAuditor(workload.AuditorConfig{
+		Log:                log,
+		Metrics:            metrics,
+		MaxEventsPerSecond: maxEventsPerSecond,
+		Clock:              clk,
+	})
+	return auditor, logHook, metrics, clk
+}
+
+func auditContext() context.Context {
+	return rpccontext.WithCallerPID(context.Background(), 12345)
+}
@@ -21,11 +21,19 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// Method names used in audit events
+const (
+	fetchJWTBundlesMethod = "FetchJWTBundles"
+	fetchJWTSVIDMethod    = "FetchJWTSVID"
+	fetchX509SVIDMethod   = "FetchX509SVID"
+)
+
 type Manager interface {
 	SubscribeToCacheChanges(cache.Selectors) cache.Subscriber
 	MatchingIdentities([]*common.Selector) []cache.Identity
@@ -41,6 +49,9 @@ type Attestor interface {
 type Config struct {
 	Manager  Manager
 	Attestor Attestor
+
+	// Auditor, if set, records each authorization decision
+	Auditor *Auditor
 }
 
 type Handler struct {
@@ -65,6 +76,7 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 	selectors, err := h.c.Attestor.Attest(ctx)
 	if err != nil {
 		log.WithError(err).Error("Workload attestation failed")
+		h.c.Auditor.Denied(ctx, fetchJWTSVIDMethod, nil, "workload attestation failed")
 		return nil, err
 	}
 
@@ -72,16 +84,24 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 	identities := h.c.Manager.MatchingIdentities(selectors)
 	if len(identities) == 0 {
 		log.WithField(telemetry.Registered, false).Error("No identity issued")
+		h.c.Auditor.Denied(ctx, fetchJWTSVIDMethod, selectors, "no identity issued")
 		return nil, status.Errorf(codes.PermissionDenied, "no identity issued")
 	}
 
 	log = log.WithField(telemetry.Registered, true)
 
+	var issued []cache.Identity
 	for _, identity := range identities {
 		if req.SpiffeId != "" && identity.Entry.SpiffeId != req.SpiffeId {
 			continue
 		}
 		spiffeIDs = append(spiffeIDs, identity.Entry.SpiffeId)
+		issued = append(issued, cache.Identity{Entry: identity.Entry})
+	}
+	if len(spiffeIDs) == 0 {
+		log.WithField(telemetry.SPIFFEID, req.SpiffeId).Error("Workload is not entitled to the requested SPIFFE ID")
+		h.c.Auditor.Denied(ctx, fetchJWTSVIDMethod, selectors, "not entitled to the requested SPIFFE ID")
+		return nil, status.Errorf(codes.NotFound, "no identity issued for %q", req.SpiffeId)
 	}
 
 	resp = new(workload.JWTSVIDResponse)
@@ -103,6 +123,7 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 		loopLog.WithField(telemetry.TTL, ttl.Seconds()).Debug("Fetched JWT SVID")
 	}
 
+	h.c.Auditor.Allowed(ctx, fetchJWTSVIDMethod, selectors, issued)
 	return resp, nil
 }
 
@@ -114,6 +135,7 @@ func (h *Handler) FetchJWTBundles(req *workload.JWTBundlesRequest, stream worklo
 	selectors, err := h.c.Attestor.Attest(ctx)
 	if err != nil {
 		log.WithError(err).Error("Workload attestation failed")
+		h.c.Auditor.Denied(ctx, fetchJWTBundlesMethod, nil, "workload attestation failed")
 		return err
 	}
 
@@ -124,6 +146,9 @@ func (h *Handler) FetchJWTBundles(req *workload.JWTBundlesRequest, stream worklo
 		select {
 		case update := <-subscriber.Updates():
 			if err := sendJWTBundlesResponse(update, stream, log); err != nil {
+				if status.Code(err) == codes.PermissionDenied {
+					h.c.Auditor.Denied(ctx, fetchJWTBundlesMethod, selectors, "no identity issued")
+				}
 				return err
 			}
 		case <-ctx.Done():
@@ -187,25 +212,59 @@ func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.Spi
 	selectors, err := h.c.Attestor.Attest(ctx)
 	if err != nil {
 		log.WithError(err).Error("Workload attestation failed")
+		h.c.Auditor.Denied(ctx, fetchX509SVIDMethod, nil, "workload attestation failed")
 		return err
 	}
 
+	spiffeID, err := x509SVIDSPIFFEIDRequested(ctx)
+	if err != nil {
+		log.WithError(err).Error("Invalid SPIFFE ID requested")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	header := metadata.MD{}
+	var deltas *x509SVIDDeltas
+	if x509SVIDDeltasRequested(ctx) {
+		header.Set(X509SVIDDeltasKey, "true")
+		deltas = new(x509SVIDDeltas)
+	}
+	if spiffeID != "" {
+		header.Set(X509SVIDSPIFFEIDKey, spiffeID)
+	}
+	if header.Len() > 0 {
+		if err := stream.SendHeader(header); err != nil {
+			return err
+		}
+	}
+
 	subscriber := h.c.Manager.SubscribeToCacheChanges(selectors)
 	defer subscriber.Finish()
 
 	for {
 		select {
 		case update := <-subscriber.Updates():
-			if err := sendX509SVIDResponse(update, stream, log, quietLogging); err != nil {
+			if spiffeID != "" && len(update.Identities) > 0 {
+				update = selectIdentity(update, spiffeID)
+				if len(update.Identities) == 0 {
+					log.WithField(telemetry.SPIFFEID, spiffeID).Error("Workload is not entitled to the requested SPIFFE ID")
+					h.c.Auditor.Denied(ctx, fetchX509SVIDMethod, selectors, "not entitled to the requested SPIFFE ID")
+					return status.Errorf(codes.NotFound, "no identity issued for %q", spiffeID)
+				}
+			}
+			if err := sendX509SVIDResponse(update, stream, log, quietLogging, deltas); err != nil {
+				if status.Code(err) == codes.PermissionDenied {
+					h.c.Auditor.Denied(ctx, fetchX509SVIDMethod, selectors, "no identity issued")
+				}
 				return err
 			}
+			h.c.Auditor.Allowed(ctx, fetchX509SVIDMethod, selectors, update.Identities)
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
-func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer, log logrus.FieldLogger, quietLogging bool) (err error) {
+func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer, log logrus.FieldLogger, quietLogging bool, deltas *x509SVIDDeltas) (err error) {
 	if len(update.Identities) == 0 {
 		if !quietLogging {
 			log.WithField(telemetry.Registered, false).Error("No identity issued")
@@ -221,6 +280,14 @@ func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWo
 		return status.Errorf(codes.Unavailable, "could not serialize response: %v", err)
 	}
 
+	if deltas != nil {
+		resp = deltas.next(resp)
+		if resp == nil {
+			// Nothing changed since the previous response
+			return nil
+		}
+	}
+
 	if err := stream.Send(resp); err != nil {
 		log.WithError(err).Error("Failed to send X.509 SVID response")
 		return err
@@ -232,10 +299,17 @@ func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWo
 	// a response has already been sent so nothing is
 	// blocked on this logic
 	if !quietLogging {
-		for i, svid := range resp.Svids {
-			ttl := time.Until(update.Identities[i].SVID[0].NotAfter)
+		sent := make(map[string]bool, len(resp.Svids))
+		for _, svid := range resp.Svids {
+			sent[svid.SpiffeId] = len(svid.X509Svid) > 0
+		}
+		for _, identity := range update.Identities {
+			if !sent[identity.Entry.SpiffeId] {
+				continue
+			}
+			ttl := time.Until(identity.SVID[0].NotAfter)
 			log.WithFields(logrus.Fields{
-				telemetry.SPIFFEID: svid.SpiffeId,
+				telemetry.SPIFFEID: identity.Entry.SpiffeId,
 				telemetry.TTL:      ttl.Seconds(),
 			}).Debug("Fetched X.509 SVID")
 		}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
@@ -33,6 +36,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -177,6 +181,189 @@ func TestFetchX509SVID(t *testing.T) {
 	}
 }
 
+func TestFetchX509SVIDDeltas(t *testing.T) {
+	// Keys from the test CA are scarce, and the SVIDs only need to be
+	// distinguishable, so fake certificates sharing a key are used instead.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyData := pkcs8FromSigner(t, key)
+
+	identity := func(id, raw string) cache.Identity {
+		return cache.Identity{
+			Entry:      &common.RegistrationEntry{SpiffeId: td.NewID(id).String()},
+			PrivateKey: key,
+			SVID:       []*x509.Certificate{{Raw: []byte(raw), NotAfter: time.Now().Add(time.Hour)}},
+		}
+	}
+	svid := func(id, raw string) *workloadPB.X509SVID {
+		return &workloadPB.X509SVID{
+			SpiffeId:    td.NewID(id).String(),
+			X509Svid:    []byte(raw),
+			X509SvidKey: keyData,
+			Bundle:      []byte("root"),
+		}
+	}
+	update := func(federated string, identities ...cache.Identity) *cache.WorkloadUpdate {
+		return &cache.WorkloadUpdate{
+			Identities: identities,
+			Bundle:     bundleutil.BundleFromRootCA(td.IDString(), &x509.Certificate{Raw: []byte("root")}),
+			FederatedBundles: map[string]*bundleutil.Bundle{
+				td2.IDString(): bundleutil.BundleFromRootCA(td2.IDString(), &x509.Certificate{Raw: []byte(federated)}),
+			},
+		}
+	}
+
+	updates := []*cache.WorkloadUpdate{
+		update("fed1", identity("/a", "a1"), identity("/b", "b1"), identity("/c", "c1")),
+		update("fed1", identity("/a", "a1"), identity("/b", "b1"), identity("/c", "c1")),
+		update("fed2", identity("/a", "a1"), identity("/b", "b2")),
+	}
+	full := &workloadPB.X509SVIDResponse{
+		Svids:            []*workloadPB.X509SVID{svid("/a", "a1"), svid("/b", "b1"), svid("/c", "c1")},
+		FederatedBundles: map[string][]byte{td2.IDString(): []byte("fed1")},
+	}
+
+	t.Run("requested", func(t *testing.T) {
+		runTest(t, testParams{Updates: updates},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				ctx = metadata.AppendToOutgoingContext(ctx, workload.X509SVIDDeltasKey, "true")
+				stream, err := client.FetchX509SVID(ctx, &workloadPB.X509SVIDRequest{})
+				require.NoError(t, err)
+
+				header, err := stream.Header()
+				require.NoError(t, err)
+				require.Equal(t, []string{"true"}, header.Get(workload.X509SVIDDeltasKey))
+
+				resp, err := stream.Recv()
+				require.NoError(t, err)
+				spiretest.RequireProtoEqual(t, full, resp)
+
+				// The second update did not change anything so the next
+				// response is for the third update.
+				resp, err = stream.Recv()
+				require.NoError(t, err)
+				spiretest.RequireProtoEqual(t, &workloadPB.X509SVIDResponse{
+					Svids: []*workloadPB.X509SVID{
+						svid("/a", "a1"),
+						svid("/b", "b2"),
+						{SpiffeId: td.NewID("/c").String()},
+					},
+					FederatedBundles: map[string][]byte{td2.IDString(): []byte("fed2")},
+				}, resp)
+			})
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		runTest(t, testParams{Updates: updates},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				stream, err := client.FetchX509SVID(ctx, &workloadPB.X509SVIDRequest{})
+				require.NoError(t, err)
+
+				header, err := stream.Header()
+				require.NoError(t, err)
+				require.Empty(t, header.Get(workload.X509SVIDDeltasKey))
+
+				for i := 0; i < 2; i++ {
+					resp, err := stream.Recv()
+					require.NoError(t, err)
+					spiretest.RequireProtoEqual(t, full, resp)
+				}
+			})
+	})
+}
+
+func TestFetchX509SVIDSelectsSPIFFEID(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyData := pkcs8FromSigner(t, key)
+
+	identity := func(id, raw string) cache.Identity {
+		return cache.Identity{
+			Entry:      &common.RegistrationEntry{SpiffeId: td.NewID(id).String()},
+			PrivateKey: key,
+			SVID:       []*x509.Certificate{{Raw: []byte(raw), NotAfter: time.Now().Add(time.Hour)}},
+		}
+	}
+	update := func(identities ...cache.Identity) *cache.WorkloadUpdate {
+		return &cache.WorkloadUpdate{
+			Identities: identities,
+			Bundle:     bundleutil.BundleFromRootCA(td.IDString(), &x509.Certificate{Raw: []byte("root")}),
+		}
+	}
+
+	// The workload is no longer entitled to /b after the second update
+	updates := []*cache.WorkloadUpdate{
+		update(identity("/a", "a1"), identity("/b", "b1")),
+		update(identity("/a", "a1"), identity("/b", "b2")),
+		update(identity("/a", "a1")),
+	}
+
+	t.Run("entitled", func(t *testing.T) {
+		expectLogs := []spiretest.LogEntry{
+			{
+				Level:   logrus.ErrorLevel,
+				Message: "Workload is not entitled to the requested SPIFFE ID",
+				Data: logrus.Fields{
+					"service":   "WorkloadAPI",
+					"method":    "FetchX509SVID",
+					"spiffe_id": "spiffe://domain.test/b",
+				},
+			},
+		}
+		runTest(t, testParams{Updates: updates, ExpectLogs: expectLogs},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				ctx = metadata.AppendToOutgoingContext(ctx, workload.X509SVIDSPIFFEIDKey, td.NewID("/b").String())
+				stream, err := client.FetchX509SVID(ctx, &workloadPB.X509SVIDRequest{})
+				require.NoError(t, err)
+
+				header, err := stream.Header()
+				require.NoError(t, err)
+				require.Equal(t, []string{"spiffe://domain.test/b"}, header.Get(workload.X509SVIDSPIFFEIDKey))
+
+				for _, raw := range []string{"b1", "b2"} {
+					resp, err := stream.Recv()
+					require.NoError(t, err)
+					spiretest.RequireProtoEqual(t, &workloadPB.X509SVIDResponse{
+						Svids: []*workloadPB.X509SVID{
+							{
+								SpiffeId:    td.NewID("/b").String(),
+								X509Svid:    []byte(raw),
+								X509SvidKey: keyData,
+								Bundle:      []byte("root"),
+							},
+						},
+					}, resp)
+				}
+
+				_, err = stream.Recv()
+				spiretest.RequireGRPCStatus(t, err, codes.NotFound, `no identity issued for "spiffe://domain.test/b"`)
+			})
+	})
+
+	t.Run("invalid SPIFFE ID", func(t *testing.T) {
+		expectLogs := []spiretest.LogEntry{
+			{
+				Level:   logrus.ErrorLevel,
+				Message: "Invalid SPIFFE ID requested",
+				Data: logrus.Fields{
+					"service":       "WorkloadAPI",
+					"method":        "FetchX509SVID",
+					logrus.ErrorKey: `invalid "spire-x509-svid-spiffe-id" value: spiffeid: invalid scheme`,
+				},
+			},
+		}
+		runTest(t, testParams{Updates: updates, ExpectLogs: expectLogs},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				ctx = metadata.AppendToOutgoingContext(ctx, workload.X509SVIDSPIFFEIDKey, "domain.test/b")
+				stream, err := client.FetchX509SVID(ctx, &workloadPB.X509SVIDRequest{})
+				require.NoError(t, err)
+
+				_, err = stream.Recv()
+				spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, `invalid "spire-x509-svid-spiffe-id" value: spiffeid: invalid scheme`)
+			})
+	})
+}
+
 func TestFetchJWTSVID(t *testing.T) {
 	ca := testca.New(t, td)
 
@@ -288,6 +475,28 @@ func TestFetchJWTSVID(t *testing.T) {
 			expectCode:     codes.OK,
 			expectTokenIDs: []spiffeid.ID{x509SVID2.ID},
 		},
+		{
+			name: "not entitled to specific",
+			identities: []cache.Identity{
+				identityFromX509SVID(x509SVID1),
+			},
+			spiffeID:   x509SVID2.ID.String(),
+			audience:   []string{"AUDIENCE"},
+			expectCode: codes.NotFound,
+			expectMsg:  `no identity issued for "spiffe://domain.test/two"`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Workload is not entitled to the requested SPIFFE ID",
+					Data: logrus.Fields{
+						"service":    "WorkloadAPI",
+						"method":     "FetchJWTSVID",
+						"registered": "true",
+						"spiffe_id":  "spiffe://domain.test/two",
+					},
+				},
+			},
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
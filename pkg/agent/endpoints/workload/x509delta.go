@@ -0,0 +1,127 @@
+package workload
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// X509SVIDDeltasKey is the gRPC metadata key through which a FetchX509SVID
+// caller asks to receive deltas instead of the full set of SVIDs and bundles
+// with each message, by setting it to "true". The agent acknowledges it by
+// setting the same key in the response header; callers that do not receive
+// the acknowledgement (e.g. from older agents) receive full messages.
+//
+// With deltas, the first message holds the full set of SVIDs and bundles.
+// Each following message holds:
+//   - first, the SVIDs of the default (i.e. first) SPIFFE ID
+//   - the SVIDs of any other SPIFFE ID that changed since the previous message
+//   - an SVID with only the SPIFFE ID set for each SPIFFE ID that was removed
+//   - the federated bundles that changed, with an empty value for each
+//     federated bundle that was removed
+//
+// The SVIDs in a message replace all the SVIDs previously received for the
+// same SPIFFE ID. No message is sent if nothing changed.
+const X509SVIDDeltasKey = "spire-x509-svid-deltas"
+
+func x509SVIDDeltasRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(X509SVIDDeltasKey) {
+		if value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// x509SVIDDeltas tracks the SVIDs and bundles last sent to a caller that
+// receives deltas.
+type x509SVIDDeltas struct {
+	sent    bool
+	svids   map[string][]*workload.X509SVID
+	bundles map[string][]byte
+}
+
+// next returns the delta between what was last sent and the given full
+// response, or nil if nothing changed.
+func (d *x509SVIDDeltas) next(full *workload.X509SVIDResponse) *workload.X509SVIDResponse {
+	var order []string
+	svids := make(map[string][]*workload.X509SVID)
+	for _, svid := range full.Svids {
+		if _, ok := svids[svid.SpiffeId]; !ok {
+			order = append(order, svid.SpiffeId)
+		}
+		svids[svid.SpiffeId] = append(svids[svid.SpiffeId], svid)
+	}
+
+	if !d.sent {
+		d.sent = true
+		d.svids = svids
+		d.bundles = full.FederatedBundles
+		return full
+	}
+
+	delta := &workload.X509SVIDResponse{
+		FederatedBundles: make(map[string][]byte),
+	}
+	changed := false
+	for i, id := range order {
+		if !svidsEqual(d.svids[id], svids[id]) {
+			changed = true
+		} else if i > 0 {
+			continue
+		}
+		// The SVIDs of the default SPIFFE ID are always included first so
+		// that the first SVID of each message is the default SVID.
+		delta.Svids = append(delta.Svids, svids[id]...)
+	}
+	var removed []string
+	for id := range d.svids {
+		if _, ok := svids[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		changed = true
+		delta.Svids = append(delta.Svids, &workload.X509SVID{SpiffeId: id})
+	}
+	for id, bundle := range full.FederatedBundles {
+		if previous, ok := d.bundles[id]; !ok || !bytes.Equal(previous, bundle) {
+			changed = true
+			delta.FederatedBundles[id] = bundle
+		}
+	}
+	for id := range d.bundles {
+		if _, ok := full.FederatedBundles[id]; !ok {
+			changed = true
+			delta.FederatedBundles[id] = []byte{}
+		}
+	}
+
+	d.svids = svids
+	d.bundles = full.FederatedBundles
+	if !changed {
+		return nil
+	}
+	return delta
+}
+
+func svidsEqual(a, b []*workload.X509SVID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"google.golang.org/grpc/metadata"
+)
+
+// X509SVIDSPIFFEIDKey is the gRPC metadata key through which a FetchX509SVID
+// caller asks to receive only the SVIDs of the given SPIFFE ID, among those
+// the workload is entitled to. The agent acknowledges it by setting the same
+// key in the response header; callers that do not receive the
+// acknowledgement (e.g. from older agents) receive every SVID. If the
+// workload is not entitled to the SPIFFE ID, the stream ends with NotFound.
+const X509SVIDSPIFFEIDKey = "spire-x509-svid-spiffe-id"
+
+// x509SVIDSPIFFEIDRequested returns the SPIFFE ID a FetchX509SVID caller
+// asked for, if any.
+func x509SVIDSPIFFEIDRequested(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(X509SVIDSPIFFEIDKey)
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", fmt.Errorf("only one %q value is allowed", X509SVIDSPIFFEIDKey)
+	}
+
+	id, err := spiffeid.FromString(values[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid %q value: %w", X509SVIDSPIFFEIDKey, err)
+	}
+	return id.String(), nil
+}
+
+// selectIdentity returns the update with only the identities of the given
+// SPIFFE ID.
+func selectIdentity(update *cache.WorkloadUpdate, spiffeID string) *cache.WorkloadUpdate {
+	var identities []cache.Identity
+	for _, identity := range update.Identities {
+		if identity.Entry.SpiffeId == spiffeID {
+			identities = append(identities, identity)
+		}
+	}
+	return &cache.WorkloadUpdate{
+		Identities:       identities,
+		Bundle:           update.Bundle,
+		FederatedBundles: update.FederatedBundles,
+	}
+}
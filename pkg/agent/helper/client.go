@@ -0,0 +1,87 @@
+package helper
+
+import (
+	"context"
+	"net"
+
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	helper_pb "github.com/spiffe/spire/proto/spire/agent/helper"
+	"google.golang.org/grpc"
+)
+
+// Dial connects to the privileged helper listening on the given address. The
+// connection is established lazily, so the helper can be started after the
+// agent.
+func Dial(addr *net.UnixAddr) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr.String(),
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}))
+}
+
+// WorkloadAttestors returns the workload attestors with the given names,
+// which are run by the privileged helper.
+func WorkloadAttestors(conn grpc.ClientConnInterface, names []string) []catalog.WorkloadAttestor {
+	client := helper_pb.NewHelperClient(conn)
+
+	var attestors []catalog.WorkloadAttestor
+	for _, name := range names {
+		attestors = append(attestors, catalog.WorkloadAttestor{
+			PluginInfo:       pluginInfo{name: name},
+			WorkloadAttestor: workloadAttestor{name: name, client: client},
+		})
+	}
+	return attestors
+}
+
+// WithWorkloadAttestors returns a catalog that provides the given workload
+// attestors in addition to the workload attestors loaded in the catalog.
+func WithWorkloadAttestors(cat catalog.Catalog, attestors []catalog.WorkloadAttestor) catalog.Catalog {
+	return helperCatalog{
+		Catalog:   cat,
+		attestors: attestors,
+	}
+}
+
+type helperCatalog struct {
+	catalog.Catalog
+
+	attestors []catalog.WorkloadAttestor
+}
+
+func (c helperCatalog) GetWorkloadAttestors() []catalog.WorkloadAttestor {
+	attestors := append([]catalog.WorkloadAttestor{}, c.Catalog.GetWorkloadAttestors()...)
+	return append(attestors, c.attestors...)
+}
+
+type pluginInfo struct {
+	name string
+}
+
+func (info pluginInfo) Name() string {
+	return info.name
+}
+
+func (info pluginInfo) BuiltIn() bool {
+	return false
+}
+
+type workloadAttestor struct {
+	name   string
+	client helper_pb.HelperClient
+}
+
+func (a workloadAttestor) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	resp, err := a.client.Attest(ctx, &helper_pb.AttestRequest{
+		Attestor: a.name,
+		Pid:      req.Pid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workloadattestor.AttestResponse{
+		Selectors: resp.Selectors,
+	}, nil
+}
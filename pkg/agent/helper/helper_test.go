@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	helper_pb "github.com/spiffe/spire/proto/spire/agent/helper"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/fakes/fakeworkloadattestor"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestHelperAttestsOnBehalfOfAgent(t *testing.T) {
+	docker := fakeworkloadattestor.New()
+	docker.SetSelectors(1, []*common.Selector{{Type: "docker", Value: "image_id:foo"}})
+
+	addr := &net.UnixAddr{
+		Name: filepath.Join(spiretest.TempDir(t), "helper.sock"),
+		Net:  "unix",
+	}
+	log, _ := test.NewNullLogger()
+	server := New(Config{
+		BindAddr:  addr,
+		AgentUID:  os.Getuid(),
+		Attestors: map[string]workloadattestor.WorkloadAttestor{"docker": docker},
+		Log:       log,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe(ctx) }()
+	defer func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}()
+
+	// Wait for the helper to listen, which is when the socket is restricted
+	// to the agent
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(addr.Name)
+		return err == nil && info.Mode().Perm() == 0600
+	}, time.Minute, 10*time.Millisecond)
+
+	conn, err := Dial(addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	unix := fakeworkloadattestor.New()
+	unix.SetSelectors(1, []*common.Selector{{Type: "unix", Value: "uid:0"}})
+	cat := fakeagentcatalog.New()
+	cat.SetWorkloadAttestors(fakeagentcatalog.WorkloadAttestor("unix", unix))
+
+	// The attestors run by the helper are added to the ones run by the agent
+	attestors := WithWorkloadAttestors(cat, WorkloadAttestors(conn, []string{"docker", "k8s"})).GetWorkloadAttestors()
+	require.Len(t, attestors, 3)
+	assert.Equal(t, "unix", attestors[0].Name())
+	assert.Equal(t, "docker", attestors[1].Name())
+	assert.Equal(t, "k8s", attestors[2].Name())
+
+	resp, err := attestors[1].Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 1})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &workloadattestor.AttestResponse{
+		Selectors: []*common.Selector{{Type: "docker", Value: "image_id:foo"}},
+	}, resp)
+
+	_, err = attestors[2].Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 1})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, `workload attestor "k8s" is not run by the helper`)
+
+	_, err = attestors[1].Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 0})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "invalid PID 0")
+}
+
+func TestHelperRejectsOtherUsers(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	server := New(Config{
+		AgentUID:  1000,
+		Attestors: map[string]workloadattestor.WorkloadAttestor{"docker": fakeworkloadattestor.New()},
+		Log:       log,
+	})
+
+	callerCtx := func(uid uint32) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: peertracker.AuthInfo{Caller: peertracker.CallerInfo{UID: uid}},
+		})
+	}
+
+	_, err := server.Attest(callerCtx(1001), &helper_pb.AttestRequest{Attestor: "docker", Pid: 1})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "caller UID 1001 is not authorized")
+
+	_, err = server.Attest(context.Background(), &helper_pb.AttestRequest{Attestor: "docker", Pid: 1})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "peer tracker caller missing from context")
+
+	// The attestor is invoked for the agent
+	_, err = server.Attest(callerCtx(1000), &helper_pb.AttestRequest{Attestor: "docker", Pid: 1})
+	require.EqualError(t, err, "cannot attest pid 1")
+}
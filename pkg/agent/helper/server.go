@@ -0,0 +1,133 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	helper_pb "github.com/spiffe/spire/proto/spire/agent/helper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Config configures the privileged helper
+type Config struct {
+	// BindAddr is the address of the UDS the helper listens on
+	BindAddr *net.UnixAddr
+
+	// AgentUID is the UID the agent runs as. The socket is owned by this UID
+	// and only callers running as this UID (or root) are served.
+	AgentUID int
+
+	// Attestors are the workload attestors run by the helper, by name
+	Attestors map[string]workloadattestor.WorkloadAttestor
+
+	Log logrus.FieldLogger
+}
+
+// Server serves the workload attestors that require privileges to the agent,
+// so the agent itself can run without them.
+type Server struct {
+	helper_pb.UnsafeHelperServer
+
+	c Config
+}
+
+// New creates a new privileged helper server
+func New(c Config) *Server {
+	return &Server{c: c}
+}
+
+// ListenAndServe serves the helper API until the context is done
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	// Remove uds if already exists
+	os.Remove(s.c.BindAddr.String())
+
+	listenerFactory := &peertracker.ListenerFactory{
+		Log: s.c.Log,
+	}
+	l, err := listenerFactory.ListenUnix(s.c.BindAddr.Network(), s.c.BindAddr)
+	if err != nil {
+		return fmt.Errorf("create UDS listener: %w", err)
+	}
+	defer l.Close()
+
+	// Only the agent can connect to the socket
+	if err := os.Chown(s.c.BindAddr.String(), s.c.AgentUID, -1); err != nil {
+		return fmt.Errorf("failed to change owner of the helper socket: %w", err)
+	}
+	if err := os.Chmod(s.c.BindAddr.String(), 0600); err != nil {
+		return fmt.Errorf("failed to change permissions of the helper socket: %w", err)
+	}
+
+	server := grpc.NewServer(grpc.Creds(peertracker.NewCredentials()))
+	helper_pb.RegisterHelperServer(server, s)
+
+	s.c.Log.WithField(telemetry.Address, s.c.BindAddr.String()).Info("Starting privileged helper")
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		s.c.Log.Info("Stopping privileged helper")
+		server.Stop()
+		err = <-errChan
+		if err == grpc.ErrServerStopped {
+			err = nil
+		}
+	}
+	return err
+}
+
+// Attest attests the PID with the requested workload attestor
+func (s *Server) Attest(ctx context.Context, req *helper_pb.AttestRequest) (*helper_pb.AttestResponse, error) {
+	log := s.c.Log.WithFields(logrus.Fields{
+		telemetry.Attestor: req.Attestor,
+		telemetry.PID:      req.Pid,
+	})
+
+	if err := s.authorizeCaller(ctx); err != nil {
+		log.WithError(err).Warn("Rejecting request from unauthorized caller")
+		return nil, err
+	}
+
+	attestor, ok := s.c.Attestors[req.Attestor]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "workload attestor %q is not run by the helper", req.Attestor)
+	}
+	if req.Pid <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid PID %d", req.Pid)
+	}
+
+	resp, err := attestor.Attest(ctx, &workloadattestor.AttestRequest{
+		Pid: req.Pid,
+	})
+	if err != nil {
+		log.WithError(err).Debug("Workload attestor failed")
+		return nil, err
+	}
+
+	return &helper_pb.AttestResponse{
+		Selectors: resp.Selectors,
+	}, nil
+}
+
+// authorizeCaller checks that the caller is the agent. The socket permissions
+// already restrict access to the agent, but they might be relaxed by mistake.
+func (s *Server) authorizeCaller(ctx context.Context) error {
+	caller, ok := peertracker.CallerFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Internal, "peer tracker caller missing from context")
+	}
+	if caller.UID != 0 && int(caller.UID) != s.c.AgentUID {
+		return status.Errorf(codes.PermissionDenied, "caller UID %d is not authorized", caller.UID)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"context"
+	"net"
+
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"google.golang.org/grpc"
+)
+
+// InMemoryWorkloadAPI lets a Go process that embeds the agent reach the
+// Workload API (and SDS) without a socket on the filesystem. Set it on
+// Config.InMemoryWorkloadAPI before running the agent and dial the APIs with
+// Dial, or with DialContext as a gRPC context dialer.
+//
+// Callers are always attested as the process embedding the agent, so the
+// registration entries for these callers must use selectors that match that
+// process (e.g. unix:uid).
+type InMemoryWorkloadAPI struct {
+	listener *peertracker.PipeListener
+}
+
+// NewInMemoryWorkloadAPI creates a new in-memory Workload API endpoint
+func NewInMemoryWorkloadAPI() *InMemoryWorkloadAPI {
+	return &InMemoryWorkloadAPI{
+		listener: peertracker.NewPipeListener(),
+	}
+}
+
+// DialContext returns a new in-memory connection to the Workload API. The
+// address is ignored, which makes it suitable for grpc.WithContextDialer.
+func (w *InMemoryWorkloadAPI) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	return w.listener.DialContext(ctx, addr)
+}
+
+// Dial returns a gRPC client connection to the Workload API. Connections are
+// established lazily, so the agent does not need to be running yet.
+func (w *InMemoryWorkloadAPI) Dial(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(w.DialContext),
+	}, opts...)
+	return grpc.DialContext(ctx, "passthrough:///spire-agent", opts...)
+}
@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"context"
+	"sync"
+
 	"github.com/imkira/go-observer"
 )
 
@@ -37,9 +40,14 @@ func (c *BundleCache) SubscribeToBundleChanges() *BundleStream {
 	return NewBundleStream(c.bundles.Observe())
 }
 
-// Wraps an observer stream to provide a type safe interface
+// Wraps an observer stream to provide a type safe interface. It is safe for
+// concurrent use.
 type BundleStream struct {
+	mtx    sync.RWMutex
 	stream observer.Stream
+
+	// version is incremented every time the stream advances
+	version uint64
 }
 
 func NewBundleStream(stream observer.Stream) *BundleStream {
@@ -50,31 +58,69 @@ func NewBundleStream(stream observer.Stream) *BundleStream {
 
 // Value returns the current value for this stream.
 func (b *BundleStream) Value() map[string]*Bundle {
-	return b.stream.Value().(map[string]*Bundle)
+	value, _ := b.Snapshot()
+	return value
+}
+
+// Snapshot atomically returns the current value for this stream along with
+// its version. The version increases every time the stream advances, so
+// consumers can compare versions to detect updates that happened between
+// two reads.
+func (b *BundleStream) Snapshot() (map[string]*Bundle, uint64) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.stream.Value().(map[string]*Bundle), b.version
 }
 
 // Changes returns the channel that is closed when a new value is available.
 func (b *BundleStream) Changes() chan struct{} {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
 	return b.stream.Changes()
 }
 
 // Next advances this stream to the next state.
 // You should never call this unless Changes channel is closed.
 func (b *BundleStream) Next() map[string]*Bundle {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
 	value, _ := b.stream.Next().(map[string]*Bundle)
+	b.version++
 	return value
 }
 
 // HasNext checks whether there is a new value available.
 func (b *BundleStream) HasNext() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
 	return b.stream.HasNext()
 }
 
 // WaitNext waits for Changes to be closed, advances the stream and returns
 // the current value.
 func (b *BundleStream) WaitNext() map[string]*Bundle {
-	value, _ := b.stream.WaitNext().(map[string]*Bundle)
-	return value
+	<-b.Changes()
+	return b.advance()
+}
+
+// WaitForUpdateSince returns the current value and version of the stream as
+// soon as the version is greater than the given one, advancing the stream as
+// updates become available. It returns an error if the context is done
+// first.
+func (b *BundleStream) WaitForUpdateSince(ctx context.Context, version uint64) (map[string]*Bundle, uint64, error) {
+	for {
+		value, current := b.Snapshot()
+		if current > version {
+			return value, current, nil
+		}
+
+		select {
+		case <-b.Changes():
+			b.advance()
+		case <-ctx.Done():
+			return nil, current, ctx.Err()
+		}
+	}
 }
 
 // Clone creates a new independent stream from this one but sharing the same
@@ -82,11 +128,27 @@ func (b *BundleStream) WaitNext() map[string]*Bundle {
 // they may have different values depending on when they advance the stream
 // with Next.
 func (b *BundleStream) Clone() *BundleStream {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
 	return &BundleStream{
-		stream: b.stream.Clone(),
+		stream:  b.stream.Clone(),
+		version: b.version,
 	}
 }
 
+// advance advances the stream if a new value is available, which might
+// not be the case if another goroutine advanced it first, and returns the
+// current value.
+func (b *BundleStream) advance() map[string]*Bundle {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.stream.HasNext() {
+		b.stream.Next()
+		b.version++
+	}
+	return b.stream.Value().(map[string]*Bundle)
+}
+
 // copyBundleMap does a shallow copy of the bundle map.
 func copyBundleMap(bundles map[string]*Bundle) map[string]*Bundle {
 	if bundles == nil {
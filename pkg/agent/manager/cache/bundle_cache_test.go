@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleStreamSnapshot(t *testing.T) {
+	bundleV1 := bundleutil.New("spiffe://domain.test")
+	bundleV2 := bundleutil.New("spiffe://domain.test")
+	bundleV2.SetRefreshHint(time.Minute)
+
+	bc := NewBundleCache("spiffe://domain.test", bundleV1)
+	stream := bc.SubscribeToBundleChanges()
+
+	value, version := stream.Snapshot()
+	assert.Equal(t, map[string]*Bundle{"spiffe://domain.test": bundleV1}, value)
+	assert.Equal(t, uint64(0), version)
+
+	// The stream does not advance until Next is called
+	bc.Update(map[string]*Bundle{"spiffe://domain.test": bundleV2})
+	assert.True(t, stream.HasNext())
+	_, version = stream.Snapshot()
+	assert.Equal(t, uint64(0), version)
+
+	assert.Equal(t, map[string]*Bundle{"spiffe://domain.test": bundleV2}, stream.Next())
+	value, version = stream.Snapshot()
+	assert.Equal(t, map[string]*Bundle{"spiffe://domain.test": bundleV2}, value)
+	assert.Equal(t, uint64(1), version)
+
+	// Clones start at the same version
+	_, version = stream.Clone().Snapshot()
+	assert.Equal(t, uint64(1), version)
+}
+
+func TestBundleStreamWaitForUpdateSince(t *testing.T) {
+	bundleV1 := bundleutil.New("spiffe://domain.test")
+	bundleV2 := bundleutil.New("spiffe://domain.test")
+	bundleV2.SetRefreshHint(time.Minute)
+	bundleV3 := bundleutil.New("spiffe://domain.test")
+	bundleV3.SetRefreshHint(time.Hour)
+
+	bc := NewBundleCache("spiffe://domain.test", bundleV1)
+	stream := bc.SubscribeToBundleChanges()
+
+	// Times out when there are no updates
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, version, err := stream.WaitForUpdateSince(ctx, 0)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, uint64(0), version)
+
+	// Returns as soon as an update is available
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		value, version, err := stream.WaitForUpdateSince(context.Background(), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), version)
+		assert.Equal(t, map[string]*Bundle{"spiffe://domain.test": bundleV2}, value)
+	}()
+	bc.Update(map[string]*Bundle{"spiffe://domain.test": bundleV2})
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for the update")
+	}
+
+	// Returns immediately when the stream is already past the version,
+	// which lets consumers detect updates they missed
+	bc.Update(map[string]*Bundle{"spiffe://domain.test": bundleV3})
+	stream.Next()
+	value, version, err := stream.WaitForUpdateSince(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+	assert.Equal(t, map[string]*Bundle{"spiffe://domain.test": bundleV3}, value)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -52,6 +53,9 @@ type UpdateSVIDs struct {
 type X509SVID struct {
 	Chain      []*x509.Certificate
 	PrivateKey crypto.Signer
+
+	// KeyState tracks the reuse of the private key across renewals
+	KeyState keyreuse.State
 }
 
 // Cache caches each registration entry, signed X509-SVIDs for those entries,
@@ -63,6 +67,7 @@ type X509SVID struct {
 //   * has a new X509-SVID signed for it
 //   * federates with a federated bundle that is updated
 // 2) the trust bundle for the agent trust domain is updated
+// 3) a bundle previously sent to the subscriber is updated or removed
 //
 // When notified, the subscriber is given a WorkloadUpdate containing
 // related identities and trust bundles.
@@ -114,6 +119,13 @@ type Cache struct {
 
 	// bundles holds the trust bundles, keyed by trust domain id (i.e. "spiffe://domain.test")
 	bundles map[string]*bundleutil.Bundle
+
+	// bundleVersions holds the version of each bundle in bundles, keyed by
+	// trust domain id. Versions are assigned from lastBundleVersion, so a
+	// bundle that is removed and added back gets a version that no subscriber
+	// has seen.
+	bundleVersions    map[string]uint64
+	lastBundleVersion uint64
 }
 
 // StaleEntry holds stale entries with SVIDs expiration time
@@ -122,6 +134,8 @@ type StaleEntry struct {
 	Entry *common.RegistrationEntry
 	// SVIDs expiration time
 	ExpiresAt time.Time
+	// SVID is the current SVID of the entry, if any
+	SVID *X509SVID
 }
 
 func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics telemetry.Metrics) *Cache {
@@ -138,6 +152,10 @@ func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics t
 		bundles: map[string]*bundleutil.Bundle{
 			trustDomainID: bundle,
 		},
+		bundleVersions: map[string]uint64{
+			trustDomainID: 1,
+		},
+		lastBundleVersion: 1,
 	}
 }
 
@@ -216,6 +234,48 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.updateEntries(update, checkSVID)
+}
+
+// UpdateBundles updates the cache with the provided bundles, keyed by trust
+// domain id, and notifies impacted subscribers. Registration entries are left
+// untouched. Like in UpdateEntries, the provided bundles must be ALL the
+// bundles available to the agent.
+func (c *Cache) UpdateBundles(bundles map[string]*bundleutil.Bundle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make(map[string]*common.RegistrationEntry, len(c.records))
+	for id, record := range c.records {
+		entries[id] = record.entry
+	}
+	c.updateEntries(&UpdateEntries{
+		Bundles:             bundles,
+		RegistrationEntries: entries,
+	}, nil)
+}
+
+// FederatedTrustDomains returns the trust domains that the cached
+// registration entries federate with, sorted by trust domain id.
+func (c *Cache) FederatedTrustDomains() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	set := make(map[string]struct{})
+	for _, record := range c.records {
+		for _, id := range record.entry.FederatesWith {
+			set[id] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *Cache) updateEntries(update *UpdateEntries, checkSVID func(*common.RegistrationEntry, *common.RegistrationEntry, *X509SVID) bool) {
 	// Remove bundles that no longer exist. The bundle for the agent trust
 	// domain should NOT be removed even if not present (which should only be
 	// the case if there is a bug on the server) since it is necessary to
@@ -227,6 +287,7 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 			// bundle no longer exists.
 			c.log.WithField(telemetry.TrustDomainID, id).Debug("Bundle removed")
 			delete(c.bundles, id)
+			delete(c.bundleVersions, id)
 		}
 	}
 
@@ -244,6 +305,8 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 			}
 			bundleChanged[id] = true
 			c.bundles[id] = bundle
+			c.lastBundleVersion++
+			c.bundleVersions[id] = c.lastBundleVersion
 		}
 	}
 	trustDomainBundleChanged := bundleChanged[c.trustDomainID]
@@ -362,9 +425,21 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 		c.BundleCache.Update(c.bundles)
 	}
 
-	if trustDomainBundleChanged {
+	switch {
+	case trustDomainBundleChanged:
 		c.notifyAll()
-	} else {
+	case bundleRemoved || len(bundleChanged) > 0:
+		// Federated bundles changed. Besides the subscribers impacted by
+		// the entries, notify those that were sent a bundle that has since
+		// been updated or removed so they don't hold on to stale roots
+		// until their next SVID rotation.
+		subs, subsDone := c.getSubscribers(notifySet)
+		defer subsDone()
+		c.addSubscribersWithStaleBundles(subs)
+		for sub := range subs {
+			c.notify(sub)
+		}
+	default:
 		c.notifyBySelectors(notifySet)
 	}
 }
@@ -422,6 +497,7 @@ func (c *Cache) GetStaleEntries() []*StaleEntry {
 		staleEntries = append(staleEntries, &StaleEntry{
 			Entry:     cachedEntry.entry,
 			ExpiresAt: expiresAt,
+			SVID:      cachedEntry.svid,
 		})
 	}
 
@@ -554,9 +630,33 @@ func (c *Cache) notifyBySelectors(set selectorSet) {
 
 func (c *Cache) notify(sub *subscriber) {
 	update := c.buildWorkloadUpdate(sub.set)
+
+	// Track the version of each bundle sent to the subscriber so it can be
+	// notified if any of them change.
+	sub.bundleVersions = make(map[string]uint64, len(update.FederatedBundles)+1)
+	sub.bundleVersions[c.trustDomainID] = c.bundleVersions[c.trustDomainID]
+	for id := range update.FederatedBundles {
+		sub.bundleVersions[id] = c.bundleVersions[id]
+	}
+
 	sub.notify(update)
 }
 
+// addSubscribersWithStaleBundles adds to the set the subscribers that were
+// last sent a bundle that has since been updated or removed.
+func (c *Cache) addSubscribersWithStaleBundles(subs subscriberSet) {
+	all, allDone := c.allSubscribers()
+	defer allDone()
+	for sub := range all {
+		for id, version := range sub.bundleVersions {
+			if c.bundleVersions[id] != version {
+				subs[sub] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
 func (c *Cache) allSubscribers() (subscriberSet, func()) {
 	subs, subsDone := allocSubscriberSet()
 	for _, index := range c.selectors {
@@ -224,6 +224,134 @@ func TestSomeSubscribersNotifiedOnFederatedBundleChange(t *testing.T) {
 	assertNoWorkloadUpdate(t, subB)
 }
 
+func TestSubscribersNotifiedOnFederatedBundleRemoval(t *testing.T) {
+	cache := newTestCache()
+
+	// initialize the cache with an entry FOO that federates with
+	// otherdomain.test and has selector "A"
+	foo := makeRegistrationEntry("FOO", "A")
+	foo.FederatesWith = makeFederatesWith(otherBundleV1)
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:           bundleV1,
+		FederatedBundles: makeBundles(otherBundleV1),
+		Identities:       []Identity{{Entry: foo}},
+	})
+
+	subB := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer subB.Finish()
+	assertAnyWorkloadUpdate(t, subB)
+
+	// remove the federated bundle without touching the entry and make sure
+	// subA is notified that it is gone, but not subB.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subB)
+
+	// add the federated bundle back and make sure subA receives it again.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV2),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:           bundleV1,
+		FederatedBundles: makeBundles(otherBundleV2),
+		Identities:       []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subB)
+}
+
+func TestSubscribersNotifiedOnStaleFederatedBundle(t *testing.T) {
+	cache := newTestCache()
+
+	// initialize the cache with an entry FOO that federates with
+	// otherdomain.test and has selector "A"
+	foo := makeRegistrationEntry("FOO", "A")
+	foo.FederatesWith = makeFederatesWith(otherBundleV1)
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertAnyWorkloadUpdate(t, subA)
+
+	// drop FOO and rotate the federated bundle in the same update. subA is
+	// notified because of the dropped entry.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles: makeBundles(bundleV1, otherBundleV2),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle: bundleV1,
+	})
+
+	// rotating the federated bundle again does not notify subA, since it
+	// was not sent the federated bundle in the last update.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles: makeBundles(bundleV1, otherBundleV1),
+	}, nil)
+	assertNoWorkloadUpdate(t, subA)
+}
+
+func TestUpdateBundles(t *testing.T) {
+	cache := newTestCache()
+
+	// initialize the cache with an entry FOO that federates with
+	// otherdomain.test and has selector "A"
+	foo := makeRegistrationEntry("FOO", "A")
+	foo.FederatesWith = makeFederatesWith(otherBundleV1)
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+	require.Equal(t, []string{"spiffe://otherdomain.test"}, cache.FederatedTrustDomains())
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertAnyWorkloadUpdate(t, subA)
+
+	// update the federated bundle and make sure subA is notified and the
+	// entries are untouched.
+	cache.UpdateBundles(makeBundles(bundleV1, otherBundleV2))
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:           bundleV1,
+		FederatedBundles: makeBundles(otherBundleV2),
+		Identities:       []Identity{{Entry: foo}},
+	})
+	assert.Equal(t, makeBundles(bundleV1, otherBundleV2), cache.BundleCache.Bundles())
+
+	// updating with the same bundles does not notify subA.
+	cache.UpdateBundles(makeBundles(bundleV1, otherBundleV2))
+	assertNoWorkloadUpdate(t, subA)
+
+	// the trust domain bundle is kept even if not provided.
+	cache.UpdateBundles(makeBundles(otherBundleV2))
+	assertNoWorkloadUpdate(t, subA)
+	assert.Equal(t, makeBundles(bundleV1, otherBundleV2), cache.BundleCache.Bundles())
+}
+
 func TestSubscribersGetEntriesWithSelectorSubsets(t *testing.T) {
 	cache := newTestCache()
 
@@ -575,10 +703,11 @@ func TestGetStaleEntries(t *testing.T) {
 		return true
 	})
 
-	// Assert that the entry again returns as stale. This time the `ExpiresAt` field should be populated with the expiration of the SVID.
+	// Assert that the entry again returns as stale. This time the `ExpiresAt` and `SVID` fields should be populated with the current SVID.
 	expectedEntries = []*StaleEntry{{
 		Entry:     cache.records[foo.EntryId].entry,
 		ExpiresAt: expiredAt,
+		SVID:      svids[foo.EntryId],
 	}}
 	assert.Equal(t, expectedEntries, cache.GetStaleEntries())
 
@@ -16,6 +16,11 @@ type subscriber struct {
 	set     selectorSet
 	setFree func()
 
+	// bundleVersions holds the versions of the bundles in the last update
+	// sent to the subscriber, keyed by trust domain id. It is protected by
+	// the cache lock.
+	bundleVersions map[string]uint64
+
 	mu   sync.Mutex
 	c    chan *WorkloadUpdate
 	done bool
@@ -10,9 +10,12 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc/resolver"
 )
 
 // Config holds a cache manager configuration
@@ -26,10 +29,31 @@ type Config struct {
 	Log              logrus.FieldLogger
 	Metrics          telemetry.Metrics
 	ServerAddr       string
+	ServerResolver   resolver.Builder
 	SVIDCachePath    string
 	BundleCachePath  string
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
+	HedgeDelay       time.Duration
+
+	// BundleSyncInterval is how often bundles are synchronized. Bundles are
+	// also synchronized along with the registration entries. Defaults to
+	// SyncInterval.
+	BundleSyncInterval time.Duration
+
+	// MaxSyncInterval is the maximum interval between registration entry
+	// synchronizations. While entries do not change, the interval doubles
+	// from SyncInterval up to MaxSyncInterval, and goes back to SyncInterval
+	// as soon as they change. Defaults to SyncInterval, which disables the
+	// adaptive interval.
+	MaxSyncInterval time.Duration
+
+	// SelectorRedactor redacts the selectors that are logged
+	SelectorRedactor *selector.Redactor
+
+	// KeyReuse controls the reuse of the agent and workload SVID keys across
+	// SVID renewals
+	KeyReuse keyreuse.Policy
 
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
@@ -45,6 +69,14 @@ func newManager(c *Config) *manager {
 		c.SyncInterval = 5 * time.Second
 	}
 
+	if c.BundleSyncInterval == 0 {
+		c.BundleSyncInterval = c.SyncInterval
+	}
+
+	if c.MaxSyncInterval < c.SyncInterval {
+		c.MaxSyncInterval = c.SyncInterval
+	}
+
 	if c.RotationInterval == 0 {
 		c.RotationInterval = svid.DefaultRotatorInterval
 	}
@@ -56,16 +88,20 @@ func newManager(c *Config) *manager {
 	cache := cache.New(c.Log.WithField(telemetry.SubsystemName, telemetry.CacheManager), c.TrustDomain.String(), c.Bundle, c.Metrics)
 
 	rotCfg := &svid.RotatorConfig{
-		Catalog:      c.Catalog,
-		Log:          c.Log,
-		Metrics:      c.Metrics,
-		SVID:         c.SVID,
-		SVIDKey:      c.SVIDKey,
-		BundleStream: cache.SubscribeToBundleChanges(),
-		ServerAddr:   c.ServerAddr,
-		TrustDomain:  c.TrustDomain,
-		Interval:     c.RotationInterval,
-		Clk:          c.Clk,
+		Catalog:          c.Catalog,
+		Log:              c.Log,
+		Metrics:          c.Metrics,
+		SVID:             c.SVID,
+		SVIDKey:          c.SVIDKey,
+		BundleStream:     cache.SubscribeToBundleChanges(),
+		ServerAddr:       c.ServerAddr,
+		ServerResolver:   c.ServerResolver,
+		HedgeDelay:       c.HedgeDelay,
+		SelectorRedactor: c.SelectorRedactor,
+		TrustDomain:      c.TrustDomain,
+		Interval:         c.RotationInterval,
+		KeyReuse:         c.KeyReuse,
+		Clk:              c.Clk,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -17,6 +17,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -57,6 +58,10 @@ type Manager interface {
 	// GetCurrentCredentials returns the current SVID and key
 	GetCurrentCredentials() svid.State
 
+	// GetSVIDRotationStatus returns the rotation deadline of the current
+	// SVID and the details of the last rotation
+	GetSVIDRotationStatus() svid.RotationStatus
+
 	// SetRotationFinishedHook sets a hook that will be called when a rotation finished
 	SetRotationFinishedHook(func())
 
@@ -93,10 +98,23 @@ type manager struct {
 	svidCachePath   string
 	bundleCachePath string
 
+	// storedBundle is the trust domain bundle last persisted to the bundle
+	// cache path. It is only accessed by Initialize and the bundle observer,
+	// which never run concurrently.
+	storedBundle *bundleutil.Bundle
+
 	// backoff calculator for fetch interval, backing off if error is returned on
 	// fetch attempt
 	backoff backoff.BackOff
 
+	// syncMtx serializes the registration entry and bundle synchronizations
+	// so that an older response cannot overwrite a newer one in the cache.
+	syncMtx sync.Mutex
+
+	// entryCount is the number of registration entries received in the
+	// last synchronization. It is protected by syncMtx.
+	entryCount int
+
 	client client.Client
 
 	clk clock.Clock
@@ -127,11 +145,20 @@ func (m *manager) Initialize(ctx context.Context) error {
 func (m *manager) Run(ctx context.Context) error {
 	defer m.client.Release()
 
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		m.runSynchronizer,
 		m.runSVIDObserver,
 		m.runBundleObserver,
-		m.svid.Run)
+		m.svid.Run,
+	}
+	// Bundles are synchronized along with the registration entries. They
+	// only need to be synchronized on their own if their interval differs
+	// from the entry sync interval, or if the latter can lengthen.
+	if m.c.BundleSyncInterval != m.c.SyncInterval || m.c.MaxSyncInterval > m.c.SyncInterval {
+		tasks = append(tasks, m.runBundleSynchronizer)
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 
 	switch {
 	case err == nil || err == context.Canceled:
@@ -167,6 +194,10 @@ func (m *manager) GetCurrentCredentials() svid.State {
 	return m.svid.State()
 }
 
+func (m *manager) GetSVIDRotationStatus() svid.RotationStatus {
+	return m.svid.RotationStatus()
+}
+
 func (m *manager) SetRotationFinishedHook(f func()) {
 	m.svid.SetRotationFinishedHook(f)
 }
@@ -226,6 +257,7 @@ func (m *manager) getEntryID(spiffeID string) string {
 }
 
 func (m *manager) runSynchronizer(ctx context.Context) error {
+	syncInterval := m.c.SyncInterval
 	for {
 		select {
 		case <-m.clk.After(m.backoff.NextBackOff()):
@@ -233,16 +265,66 @@ func (m *manager) runSynchronizer(ctx context.Context) error {
 			return nil
 		}
 
-		err := m.synchronize(ctx)
+		entriesChanged, err := m.synchronizeEntries(ctx)
 		switch {
 		case err != nil && nodeutil.ShouldAgentReattest(err):
 			m.c.Log.WithError(err).Error("Synchronize failed")
 			return err
+		case errors.Is(err, client.ErrCircuitOpen):
+			// Cached entries keep being served until the server is back
+			m.c.Log.WithError(err).Debug("Synchronize failed")
 		case err != nil:
 			// Just log the error and wait for next synchronization
 			m.c.Log.WithError(err).Error("Synchronize failed")
 		default:
-			m.backoff.Reset()
+			next := m.nextSyncInterval(syncInterval, entriesChanged)
+			if next == syncInterval {
+				m.backoff.Reset()
+				continue
+			}
+			m.c.Log.WithField(telemetry.SyncInterval, next).Debug("Adjusting entry synchronization interval")
+			syncInterval = next
+			m.backoff = backoff.NewBackoff(m.clk, syncInterval)
+		}
+	}
+}
+
+// nextSyncInterval returns the interval until the next registration entry
+// synchronization. The interval doubles while entries do not change, up to
+// the configured maximum, and goes back to the configured interval as soon as
+// they change.
+func (m *manager) nextSyncInterval(current time.Duration, entriesChanged bool) time.Duration {
+	if entriesChanged {
+		return m.c.SyncInterval
+	}
+	next := 2 * current
+	if next > m.c.MaxSyncInterval {
+		next = m.c.MaxSyncInterval
+	}
+	return next
+}
+
+func (m *manager) runBundleSynchronizer(ctx context.Context) error {
+	bundleBackoff := backoff.NewBackoff(m.clk, m.c.BundleSyncInterval)
+	for {
+		select {
+		case <-m.clk.After(bundleBackoff.NextBackOff()):
+		case <-ctx.Done():
+			return nil
+		}
+
+		err := m.synchronizeBundles(ctx)
+		switch {
+		case err != nil && nodeutil.ShouldAgentReattest(err):
+			m.c.Log.WithError(err).Error("Bundle synchronization failed")
+			return err
+		case errors.Is(err, client.ErrCircuitOpen):
+			m.c.Log.WithError(err).Debug("Bundle synchronization failed")
+		case err != nil:
+			// Just log the error and wait for next synchronization
+			m.c.Log.WithError(err).Error("Bundle synchronization failed")
+		default:
+			bundleBackoff.Reset()
 		}
 	}
 }
@@ -308,15 +390,23 @@ func (m *manager) storeSVID(svidChain []*x509.Certificate) {
 	}
 }
 
+// storeBundle persists the trust domain bundle so it can be used to bootstrap
+// the agent on restart. The cached bundle takes precedence over the configured
+// trust bundle, so it must never be replaced with an empty bundle, which would
+// cause the agent to fail attestation after a restart.
 func (m *manager) storeBundle(bundle *bundleutil.Bundle) {
-	var rootCAs []*x509.Certificate
-	if bundle != nil {
-		rootCAs = bundle.RootCAs()
+	if bundle == nil || len(bundle.RootCAs()) == 0 {
+		m.c.Log.Warn("Not storing bundle: bundle has no root CAs")
+		return
 	}
-	err := StoreBundle(m.bundleCachePath, rootCAs)
-	if err != nil {
+	if m.storedBundle != nil && m.storedBundle.EqualTo(bundle) {
+		return
+	}
+	if err := StoreBundle(m.bundleCachePath, bundle.RootCAs()); err != nil {
 		m.c.Log.WithError(err).Error("Could not store bundle")
+		return
 	}
+	m.storedBundle = bundle
 }
 
 func (m *manager) storePrivateKey(ctx context.Context, key *ecdsa.PrivateKey) error {
@@ -325,6 +415,7 @@ func (m *manager) storePrivateKey(ctx context.Context, key *ecdsa.PrivateKey) er
 	if err != nil {
 		return err
 	}
+	defer cryptoutil.ZeroBytes(keyBytes)
 
 	if _, err = km.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: keyBytes}); err != nil {
 		return err
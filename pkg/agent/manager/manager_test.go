@@ -3,6 +3,8 @@ package manager
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -18,6 +20,7 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/disk"
@@ -25,6 +28,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	common_x509svid "github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	agentv1 "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
@@ -137,6 +141,40 @@ func TestStoreBundleOnStartup(t *testing.T) {
 	}
 }
 
+func TestStoreBundleKeepsCachedBundleWhenEmpty(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	ca, _ := createCA(t, clk)
+	rotatedCA, _ := createCA(t, clk)
+
+	c := &Config{
+		Log:             testLogger,
+		Metrics:         &telemetry.Blackhole{},
+		TrustDomain:     trustDomainURL,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Clk:             clk,
+	}
+	require.NoError(t, StoreBundle(c.BundleCachePath, []*x509.Certificate{ca}))
+
+	m := newManager(c)
+
+	// An empty bundle must not replace the cached bundle, otherwise the agent
+	// would be unable to bootstrap on restart.
+	m.storeBundle(nil)
+	m.storeBundle(bundleutil.New(trustDomain.IDString()))
+	bundle, err := ReadBundle(c.BundleCachePath)
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{ca}, bundle)
+
+	// A rotated bundle replaces the cached bundle.
+	m.storeBundle(bundleutil.BundleFromRootCAs(trustDomain.IDString(), []*x509.Certificate{ca, rotatedCA}))
+	bundle, err = ReadBundle(c.BundleCachePath)
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{ca, rotatedCA}, bundle)
+}
+
 func TestStoreSVIDOnStartup(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -738,6 +776,147 @@ func TestSynchronizationUpdatesRegistrationEntries(t *testing.T) {
 		regEntriesFromIdentities(m.cache.Identities()))
 }
 
+func TestSynchronizationReportsEntryChanges(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(h *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			switch count {
+			case 1, 2:
+				return makeGetAuthorizedEntriesResponse(t, "resp2"), nil
+			case 3:
+				return makeGetAuthorizedEntriesResponse(t, "resp3"), nil
+			default:
+				return nil, fmt.Errorf("unexpected getAuthorizedEntries call count: %d", count)
+			}
+		},
+		batchNewX509SVIDEntries: func(h *mockAPI, count int32) []*common.RegistrationEntry {
+			switch count {
+			case 1:
+				return makeBatchNewX509SVIDEntries("resp2")
+			case 2:
+				return makeBatchNewX509SVIDEntries("resp3")
+			default:
+				return nil
+			}
+		},
+		svidTTL: 200,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID(joinTokenID, 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	km := disk.New()
+	_, err := km.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`directory = %q`, dir),
+	})
+	require.NoError(t, err)
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	c := &Config{
+		ServerAddr:      api.addr,
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainURL,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          api.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	// the entries did not change since initialization
+	changed, err := m.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// the entries are updated
+	changed, err = m.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestNextSyncInterval(t *testing.T) {
+	m := &manager{c: &Config{
+		SyncInterval:    5 * time.Second,
+		MaxSyncInterval: 30 * time.Second,
+	}}
+
+	require.Equal(t, 10*time.Second, m.nextSyncInterval(5*time.Second, false))
+	require.Equal(t, 20*time.Second, m.nextSyncInterval(10*time.Second, false))
+	require.Equal(t, 30*time.Second, m.nextSyncInterval(20*time.Second, false))
+	require.Equal(t, 30*time.Second, m.nextSyncInterval(30*time.Second, false))
+	require.Equal(t, 5*time.Second, m.nextSyncInterval(30*time.Second, true))
+
+	// the interval does not change when the adaptive interval is disabled
+	m.c.MaxSyncInterval = m.c.SyncInterval
+	require.Equal(t, 5*time.Second, m.nextSyncInterval(5*time.Second, false))
+}
+
+func TestSynchronizeBundles(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(h *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			if count > 1 {
+				return nil, fmt.Errorf("unexpected getAuthorizedEntries call count: %d", count)
+			}
+			return makeGetAuthorizedEntriesResponse(t, "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(h *mockAPI, count int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp2")
+		},
+		svidTTL: 200,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID(joinTokenID, 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	km := disk.New()
+	_, err := km.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`directory = %q`, dir),
+	})
+	require.NoError(t, err)
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	c := &Config{
+		ServerAddr:         api.addr,
+		SVID:               baseSVID,
+		SVIDKey:            baseSVIDKey,
+		Log:                testLogger,
+		TrustDomain:        trustDomainURL,
+		SVIDCachePath:      path.Join(dir, "svid.der"),
+		BundleCachePath:    path.Join(dir, "bundle.der"),
+		Bundle:             api.bundle,
+		Metrics:            &telemetry.Blackhole{},
+		Clk:                clk,
+		Catalog:            cat,
+		BundleSyncInterval: time.Minute,
+	}
+
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.Len(t, m.GetBundle().RootCAs(), 1)
+
+	// rotate the server CA and synchronize the bundles only
+	ca, _ := createCA(t, clk)
+	api.bundle.AppendRootCA(ca)
+	require.NoError(t, m.synchronizeBundles(context.Background()))
+	require.Len(t, m.GetBundle().RootCAs(), 2)
+
+	// the entries were left untouched
+	compareRegistrationEntries(t,
+		regEntriesMap["resp2"],
+		regEntriesFromIdentities(m.cache.Identities()))
+}
+
 func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -982,6 +1161,101 @@ func TestFetchJWTSVID(t *testing.T) {
 	require.Nil(t, svid)
 }
 
+func TestNewCSRHonorsKeyType(t *testing.T) {
+	for _, tt := range []struct {
+		keyType     common_x509svid.KeyType
+		expectCurve elliptic.Curve
+		expectBits  int
+	}{
+		{keyType: "", expectCurve: elliptic.P256()},
+		{keyType: common_x509svid.KeyTypeECP384, expectCurve: elliptic.P384()},
+		{keyType: common_x509svid.KeyTypeRSA2048, expectBits: 2048},
+	} {
+		tt := tt
+		t.Run(string(tt.keyType), func(t *testing.T) {
+			key, csrBytes, err := newCSR("spiffe://example.org/workload", tt.keyType)
+			require.NoError(t, err)
+
+			csr, err := x509.ParseCertificateRequest(csrBytes)
+			require.NoError(t, err)
+			require.NoError(t, csr.CheckSignature())
+			assert.Equal(t, key.Public(), csr.PublicKey)
+
+			switch publicKey := csr.PublicKey.(type) {
+			case *ecdsa.PublicKey:
+				assert.Equal(t, tt.expectCurve, publicKey.Curve)
+			case *rsa.PublicKey:
+				assert.Equal(t, tt.expectBits, publicKey.N.BitLen())
+			default:
+				require.Fail(t, "unexpected public key type", "%T", publicKey)
+			}
+		})
+	}
+}
+
+func TestReuseKey(t *testing.T) {
+	now := time.Now()
+	ecKey, err := common_x509svid.GenerateKey(common_x509svid.KeyTypeECP256)
+	require.NoError(t, err)
+	current := &cache.X509SVID{
+		PrivateKey: ecKey,
+		KeyState:   keyreuse.New(now),
+	}
+
+	for _, tt := range []struct {
+		name        string
+		policy      keyreuse.Policy
+		keyType     common_x509svid.KeyType
+		currentSVID *cache.X509SVID
+		expectReuse bool
+	}{
+		{
+			name:        "disabled",
+			currentSVID: current,
+		},
+		{
+			name:   "no current SVID",
+			policy: keyreuse.Policy{MaxRenewals: 1},
+		},
+		{
+			name:        "default key type",
+			policy:      keyreuse.Policy{MaxRenewals: 1},
+			currentSVID: current,
+			expectReuse: true,
+		},
+		{
+			name:        "same key type",
+			policy:      keyreuse.Policy{MaxRenewals: 1},
+			keyType:     common_x509svid.KeyTypeECP256,
+			currentSVID: current,
+			expectReuse: true,
+		},
+		{
+			name:        "key type changed",
+			policy:      keyreuse.Policy{MaxRenewals: 1},
+			keyType:     common_x509svid.KeyTypeRSA2048,
+			currentSVID: current,
+		},
+		{
+			name:   "no renewals left",
+			policy: keyreuse.Policy{MaxRenewals: 1},
+			currentSVID: &cache.X509SVID{
+				PrivateKey: ecKey,
+				KeyState:   current.KeyState.Reused(),
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{c: &Config{KeyReuse: tt.policy}}
+			assert.Equal(t, tt.expectReuse, m.reuseKey(csrRequest{
+				KeyType:     tt.keyType,
+				CurrentSVID: tt.currentSVID,
+			}, now))
+		})
+	}
+}
+
 func makeGetAuthorizedEntriesResponse(t *testing.T, respKeys ...string) *entryv1.GetAuthorizedEntriesResponse {
 	var entries []*types.Entry
 	for _, respKey := range respKeys {
@@ -2,19 +2,19 @@ package manager
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto"
 	"crypto/x509"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 )
@@ -22,16 +22,34 @@ import (
 type csrRequest struct {
 	EntryID              string
 	SpiffeID             string
+	KeyType              x509svid.KeyType
 	CurrentSVIDExpiresAt time.Time
+	CurrentSVID          *cache.X509SVID
 }
 
 // synchronize hits the node api, checks for entries we haven't fetched yet, and fetches them.
-func (m *manager) synchronize(ctx context.Context) (err error) {
+func (m *manager) synchronize(ctx context.Context) error {
+	_, err := m.synchronizeEntries(ctx)
+	return err
+}
+
+// synchronizeEntries is like synchronize, but also reports whether any
+// registration entry was added, updated or removed.
+func (m *manager) synchronizeEntries(ctx context.Context) (entriesChanged bool, err error) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
 	update, err := m.fetchEntries(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
+	// A change in the number of entries means that entries were added or
+	// removed. Otherwise, entries were only removed if others were added,
+	// which is detected by the callback below.
+	entriesChanged = len(update.RegistrationEntries) != m.entryCount
+	m.entryCount = len(update.RegistrationEntries)
+
 	// update the cache and build a list of CSRs that need to be processed
 	// in this interval.
 	//
@@ -40,6 +58,10 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	var expiring int
 	var outdated int
 	m.cache.UpdateEntries(update, func(existingEntry, newEntry *common.RegistrationEntry, svid *cache.X509SVID) bool {
+		if existingEntry == nil || existingEntry.RevisionNumber != newEntry.RevisionNumber {
+			entriesChanged = true
+		}
+
 		switch {
 		case svid == nil:
 			// no SVID
@@ -87,13 +109,15 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 			csrs = append(csrs, csrRequest{
 				EntryID:              staleEntry.Entry.EntryId,
 				SpiffeID:             staleEntry.Entry.SpiffeId,
+				KeyType:              x509svid.KeyType(staleEntry.Entry.X509SvidKeyType),
 				CurrentSVIDExpiresAt: staleEntry.ExpiresAt,
+				CurrentSVID:          staleEntry.SVID,
 			})
 		}
 
 		update, err := m.fetchSVIDs(ctx, csrs)
 		if err != nil {
-			return err
+			return entriesChanged, err
 		}
 		// the values in `update` now belong to the cache. DO NOT MODIFY.
 		m.cache.UpdateSVIDs(update)
@@ -101,6 +125,31 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 
 	// Set last success sync
 	m.setLastSync()
+	return entriesChanged, nil
+}
+
+// synchronizeBundles fetches the trust domain bundle and the federated
+// bundles of the cached registration entries, and updates the cache with
+// them.
+func (m *manager) synchronizeBundles(ctx context.Context) (err error) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	counter := telemetry_agent.StartManagerFetchBundlesUpdatesCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	protoBundles, err := m.client.FetchBundles(ctx, m.cache.FederatedTrustDomains())
+	if err != nil {
+		return err
+	}
+
+	bundles, err := parseBundles(protoBundles)
+	if err != nil {
+		return err
+	}
+
+	// the values in `bundles` now belong to the cache. DO NOT MODIFY.
+	m.cache.UpdateBundles(bundles)
 	return nil
 }
 
@@ -111,25 +160,42 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 
 	csrsIn := make(map[string][]byte)
 
-	privateKeys := make(map[string]*ecdsa.PrivateKey, len(csrs))
+	now := m.c.Clk.Now()
+	privateKeys := make(map[string]crypto.Signer, len(csrs))
+	keyStates := make(map[string]keyreuse.State, len(csrs))
 	for _, csr := range csrs {
-		log := m.c.Log.WithField("spiffe_id", csr.SpiffeID)
+		log := m.c.Log.WithFields(logrus.Fields{
+			telemetry.RegistrationID: csr.EntryID,
+			telemetry.SPIFFEID:       csr.SpiffeID,
+		})
 		if !csr.CurrentSVIDExpiresAt.IsZero() {
-			log = log.WithField("expires_at", csr.CurrentSVIDExpiresAt.Format(time.RFC3339))
+			log = log.WithField(telemetry.Expiration, csr.CurrentSVIDExpiresAt.Format(time.RFC3339))
 		}
 
 		// Since entryIDs are unique, this shouldn't happen. Log just in case
 		if _, ok := privateKeys[csr.EntryID]; ok {
-			log.Warnf("Ignoring duplicate X509-SVID renewal for entry ID: %q", csr.EntryID)
+			log.Warn("Ignoring duplicate X509-SVID renewal")
 			continue
 		}
 
 		log.Info("Renewing X509-SVID")
-		privateKey, csrBytes, err := newCSR(csr.SpiffeID)
+		var privateKey crypto.Signer
+		var csrBytes []byte
+		var keyState keyreuse.State
+		if m.reuseKey(csr, now) {
+			privateKey = csr.CurrentSVID.PrivateKey
+			keyState = csr.CurrentSVID.KeyState.Reused()
+			log.WithField(telemetry.Renewals, keyState.Renewals).Debug("Reusing X509-SVID key")
+			csrBytes, err = util.MakeCSR(privateKey, csr.SpiffeID)
+		} else {
+			keyState = keyreuse.New(now)
+			privateKey, csrBytes, err = newCSR(csr.SpiffeID, csr.KeyType)
+		}
 		if err != nil {
 			return nil, err
 		}
 		privateKeys[csr.EntryID] = privateKey
+		keyStates[csr.EntryID] = keyState
 		csrsIn[csr.EntryID] = csrBytes
 	}
 
@@ -151,6 +217,7 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 		byEntryID[entryID] = &cache.X509SVID{
 			Chain:      chain,
 			PrivateKey: privateKey,
+			KeyState:   keyStates[entryID],
 		}
 	}
 
@@ -180,8 +247,22 @@ func (m *manager) fetchEntries(ctx context.Context) (_ *cache.UpdateEntries, err
 	}, nil
 }
 
-func newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err error) {
-	pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// reuseKey returns true if the key of the current SVID can be reused for the
+// requested SVID, i.e. the key reuse policy allows it and the key is still of
+// the requested type.
+func (m *manager) reuseKey(csr csrRequest, now time.Time) bool {
+	if csr.CurrentSVID == nil || !m.c.KeyReuse.Reuse(csr.CurrentSVID.KeyState, now) {
+		return false
+	}
+	keyType, ok := x509svid.KeyTypeOf(csr.CurrentSVID.PrivateKey)
+	if !ok {
+		return false
+	}
+	return keyType == csr.KeyType || (csr.KeyType == "" && keyType == x509svid.KeyTypeECP256)
+}
+
+func newCSR(spiffeID string, keyType x509svid.KeyType) (pk crypto.Signer, csr []byte, err error) {
+	pk, err = x509svid.GenerateKey(keyType)
 	if err != nil {
 		return
 	}
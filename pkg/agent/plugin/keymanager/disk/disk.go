@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/diskutil"
 
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
@@ -57,6 +58,8 @@ func (d *Plugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairReq
 		return nil, err
 	}
 
+	defer cryptoutil.ZeroECDSAPrivateKey(key)
+
 	privData, err := x509.MarshalECPrivateKey(key)
 	if err != nil {
 		return nil, err
@@ -102,12 +105,14 @@ func (d *Plugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyReq
 	if err != nil {
 		return nil, err
 	}
+	defer cryptoutil.ZeroBytes(data)
 
 	// Check key integrity first
 	key, err := x509.ParseECPrivateKey(data)
 	if err != nil {
 		return nil, err
 	}
+	defer cryptoutil.ZeroECDSAPrivateKey(key)
 
 	resp.PrivateKey, _ = x509.MarshalECPrivateKey(key)
 	return resp, nil
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 )
 
@@ -41,6 +42,8 @@ func (m *Plugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairReq
 	if err != nil {
 		return nil, err
 	}
+	defer cryptoutil.ZeroECDSAPrivateKey(key)
+
 	privateKey, err := x509.MarshalECPrivateKey(key)
 	if err != nil {
 		return nil, err
@@ -63,6 +66,9 @@ func (m *Plugin) StorePrivateKey(ctx context.Context, req *keymanager.StorePriva
 	if err != nil {
 		return nil, err
 	}
+
+	// Scrub the key being replaced so it does not linger in memory
+	cryptoutil.ZeroECDSAPrivateKey(m.key)
 	m.key = key
 
 	return &keymanager.StorePrivateKeyResponse{}, nil
@@ -39,6 +39,23 @@ func TestMemory_FetchPrivateKey(t *testing.T) {
 	assert.Equal(t, priv.PrivateKey, data.PrivateKey)
 }
 
+func TestMemory_StorePrivateKeyZeroesReplacedKey(t *testing.T) {
+	plugin := New()
+	first, e := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	require.NoError(t, e)
+	_, e = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: first.PrivateKey})
+	require.NoError(t, e)
+	replaced := plugin.key
+
+	second, e := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	require.NoError(t, e)
+	_, e = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: second.PrivateKey})
+	require.NoError(t, e)
+
+	assert.Zero(t, replaced.D.Sign())
+	assert.NotZero(t, plugin.key.D.Sign())
+}
+
 func TestMemory_Configure(t *testing.T) {
 	plugin := New()
 	data, e := plugin.Configure(ctx, &spi.ConfigureRequest{})
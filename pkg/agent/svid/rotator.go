@@ -4,16 +4,22 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/andres-erbsen/clock"
 	observer "github.com/imkira/go-observer"
+	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/util"
 )
@@ -22,6 +28,7 @@ type Rotator interface {
 	Run(ctx context.Context) error
 
 	State() State
+	RotationStatus() RotationStatus
 	Subscribe() observer.Stream
 	GetRotationMtx() *sync.RWMutex
 	SetRotationFinishedHook(func())
@@ -38,14 +45,23 @@ type rotator struct {
 	// rotation attempt
 	backoff backoff.BackOff
 
-	// Mutex used to protect access to c.BundleStream.
-	bsm *sync.RWMutex
-
 	// Mutex used to prevent rotations when a new connection is being created
 	rotMtx *sync.RWMutex
 
 	// Hook that will be called when the SVID rotation finishes
 	rotationFinishedHook func()
+
+	// Mutex used to protect access to the last rotation details
+	statusMtx          sync.RWMutex
+	lastRotation       time.Time
+	lastRotationReason RotationReason
+
+	// rotateAfter is the rotation time hinted by the server when the current
+	// SVID was issued. It is zero if the server did not provide a hint.
+	rotateAfter time.Time
+
+	// keyState tracks the reuse of the current key across rotations
+	keyState keyreuse.State
 }
 
 type State struct {
@@ -53,6 +69,29 @@ type State struct {
 	Key  *ecdsa.PrivateKey
 }
 
+// RotationReason describes what triggered an SVID rotation
+type RotationReason string
+
+const (
+	// RotationReasonExpiryThreshold is used when the SVID is rotated because
+	// it reached its rotation deadline (i.e. half of its lifetime elapsed, or
+	// the earlier time hinted by the server).
+	RotationReasonExpiryThreshold RotationReason = "expiry_threshold"
+)
+
+// RotationStatus describes the rotation decisions of the rotator
+type RotationStatus struct {
+	// Deadline is the time from which the current SVID is rotated
+	Deadline time.Time
+
+	// LastRotation is the time of the last successful rotation. It is zero
+	// if the SVID has not been rotated since the agent started.
+	LastRotation time.Time
+
+	// LastRotationReason is what triggered the last successful rotation
+	LastRotationReason RotationReason
+}
+
 // Run runs the rotator. It monitors the server SVID for expiration and rotates
 // as necessary. It also watches for changes to the trust bundle.
 func (r *rotator) Run(ctx context.Context) error {
@@ -70,6 +109,8 @@ func (r *rotator) runRotation(ctx context.Context) error {
 		case err != nil && nodeutil.ShouldAgentReattest(err):
 			r.c.Log.WithError(err).Error("Could not rotate agent SVID")
 			return err
+		case errors.Is(err, client.ErrCircuitOpen):
+			r.c.Log.WithError(err).Debug("Could not rotate agent SVID")
 		case err != nil:
 			// Just log the error and wait for next rotation
 			r.c.Log.WithError(err).Error("Could not rotate agent SVID")
@@ -85,15 +126,14 @@ func (r *rotator) runRotation(ctx context.Context) error {
 	}
 }
 
+// processBundleUpdates keeps the bundle stream current so the client
+// authenticates the server using the latest bundle.
 func (r *rotator) processBundleUpdates(ctx context.Context) error {
+	_, version := r.c.BundleStream.Snapshot()
 	for {
-		select {
-		case <-ctx.Done():
+		var err error
+		if _, version, err = r.c.BundleStream.WaitForUpdateSince(ctx, version); err != nil {
 			return nil
-		case <-r.c.BundleStream.Changes():
-			r.bsm.Lock()
-			r.c.BundleStream.Next()
-			r.bsm.Unlock()
 		}
 	}
 }
@@ -102,6 +142,17 @@ func (r *rotator) State() State {
 	return r.state.Value().(State)
 }
 
+func (r *rotator) RotationStatus() RotationStatus {
+	r.statusMtx.RLock()
+	defer r.statusMtx.RUnlock()
+
+	return RotationStatus{
+		Deadline:           r.deadline(r.State().SVID[0]),
+		LastRotation:       r.lastRotation,
+		LastRotationReason: r.lastRotationReason,
+	}
+}
+
 func (r *rotator) Subscribe() observer.Stream {
 	return r.state.Observe()
 }
@@ -116,22 +167,44 @@ func (r *rotator) SetRotationFinishedHook(f func()) {
 
 // rotateSVID asks SPIRE's server for a new agent's SVID.
 func (r *rotator) rotateSVID(ctx context.Context) (err error) {
-	if !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0]) {
+	now := r.clk.Now()
+	r.statusMtx.RLock()
+	deadline := r.deadline(r.state.Value().(State).SVID[0])
+	r.statusMtx.RUnlock()
+	telemetry_agent.SetAgentSVIDRotationDeadlineGauge(r.c.Metrics, float32(deadline.Sub(now).Seconds()))
+	if now.Before(deadline) {
 		return nil
 	}
+	reason := RotationReasonExpiryThreshold
 
 	counter := telemetry_agent.StartRotateAgentSVIDCall(r.c.Metrics)
+	counter.AddLabel(telemetry.Reason, string(reason))
 	defer counter.Done(&err)
 
 	// Get the mtx before starting the rotation
 	// In this way, the client do not create new connections until the new SVID is received
 	r.rotMtx.Lock()
 	defer r.rotMtx.Unlock()
-	r.c.Log.Debug("Rotating agent SVID")
-
-	key, err := r.newKey(ctx)
-	if err != nil {
-		return err
+	log := r.c.Log.WithFields(logrus.Fields{
+		telemetry.Reason:        reason,
+		telemetry.Deadline:      deadline,
+		telemetry.TrustDomainID: r.c.TrustDomain.String(),
+	})
+	if uris := r.state.Value().(State).SVID[0].URIs; len(uris) > 0 {
+		log = log.WithField(telemetry.SPIFFEID, uris[0].String())
+	}
+	log.Debug("Rotating agent SVID")
+
+	key := r.state.Value().(State).Key
+	keyState := r.keyState.Reused()
+	if r.c.KeyReuse.Reuse(r.keyState, now) {
+		log.WithField(telemetry.Renewals, keyState.Renewals).Debug("Reusing agent SVID key")
+	} else {
+		key, err = r.newKey(ctx)
+		if err != nil {
+			return err
+		}
+		keyState = keyreuse.New(now)
 	}
 
 	csr, err := util.MakeCSRWithoutURISAN(key)
@@ -154,13 +227,31 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 		Key:  key,
 	}
 
+	oldKey := r.state.Value().(State).Key
 	r.state.Update(s)
+	if len(certs[0].URIs) > 0 {
+		log.WithField(telemetry.SPIFFEID, certs[0].URIs[0].String()).Debug("Agent SVID rotated")
+	}
+
+	r.statusMtx.Lock()
+	r.lastRotation = r.clk.Now()
+	r.lastRotationReason = reason
+	r.rotateAfter = svid.RotateAfter.UTC()
+	r.statusMtx.Unlock()
+	r.keyState = keyState
 
 	// We must release the client because its underlaying connection is tied to an
 	// expired SVID, so next time the client is used, it will get a new connection with
 	// the most up-to-date SVID.
 	r.client.Release()
 
+	// New connections can't be created while the rotation mutex is held, and
+	// the connection tied to the old SVID has just been released, so nothing
+	// uses the old key anymore. Scrub it so it does not linger in memory.
+	if oldKey != key {
+		cryptoutil.ZeroECDSAPrivateKey(oldKey)
+	}
+
 	if r.rotationFinishedHook != nil {
 		r.rotationFinishedHook()
 	}
@@ -168,12 +259,25 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 	return nil
 }
 
+// deadline returns the time from which the given SVID is rotated. The hint
+// provided by the server is only honored when it falls within the first half
+// of the SVID lifetime, so a bad hint can never delay the rotation. The
+// status mutex must be held.
+func (r *rotator) deadline(svid *x509.Certificate) time.Time {
+	deadline := rotationutil.X509RotationDeadline(svid)
+	if r.rotateAfter.After(svid.NotBefore) && r.rotateAfter.Before(deadline) {
+		return r.rotateAfter
+	}
+	return deadline
+}
+
 func (r *rotator) newKey(ctx context.Context) (*ecdsa.PrivateKey, error) {
 	km := r.c.Catalog.GetKeyManager()
 	resp, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("generate key pair: %v", err)
 	}
+	defer cryptoutil.ZeroBytes(resp.PrivateKey)
 
 	return x509.ParseECPrivateKey(resp.PrivateKey)
 }
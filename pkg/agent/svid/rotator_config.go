@@ -13,8 +13,11 @@ import (
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc/resolver"
 )
 
 const DefaultRotatorInterval = 5 * time.Second
@@ -25,6 +28,12 @@ type RotatorConfig struct {
 	Metrics     telemetry.Metrics
 	TrustDomain url.URL
 	ServerAddr  string
+	// ServerResolver is an optional resolver builder for ServerAddr
+	ServerResolver resolver.Builder
+	// HedgeDelay, if positive, enables hedging of idempotent read RPCs
+	HedgeDelay time.Duration
+	// SelectorRedactor redacts the selectors that are logged
+	SelectorRedactor *selector.Redactor
 	// Initial SVID and key
 	SVID    []*x509.Certificate
 	SVIDKey *ecdsa.PrivateKey
@@ -34,6 +43,9 @@ type RotatorConfig struct {
 	// How long to wait between expiry checks
 	Interval time.Duration
 
+	// KeyReuse controls the reuse of the agent SVID key across rotations
+	KeyReuse keyreuse.Policy
+
 	// Clk is the clock that the rotator will use to create a ticker
 	Clk clock.Clock
 }
@@ -57,19 +69,20 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 	})
 
 	rotMtx := new(sync.RWMutex)
-	bsm := new(sync.RWMutex)
 
 	cfg := &client.Config{
-		TrustDomain: c.TrustDomain,
-		Log:         c.Log,
-		Addr:        c.ServerAddr,
-		RotMtx:      rotMtx,
+		TrustDomain:      c.TrustDomain,
+		Log:              c.Log,
+		Addr:             c.ServerAddr,
+		Resolver:         c.ServerResolver,
+		HedgeDelay:       c.HedgeDelay,
+		SelectorRedactor: c.SelectorRedactor,
+		Metrics:          c.Metrics,
+		RotMtx:           rotMtx,
 		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
 			s := state.Value().(State)
 
-			bsm.RLock()
 			bundles := c.BundleStream.Value()
-			bsm.RUnlock()
 
 			var rootCAs []*x509.Certificate
 			if bundle := bundles[c.TrustDomain.String()]; bundle != nil {
@@ -78,15 +91,28 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 			return s.SVID, s.Key, rootCAs
 		},
 	}
-	client := client.New(cfg)
+	// Calls to the server are suspended while it cannot be reached, so
+	// extended server maintenance does not flood the logs with failures.
+	client := client.WithCircuitBreaker(client.New(cfg), client.BreakerConfig{
+		Log:     c.Log,
+		Metrics: c.Metrics,
+		Clock:   c.Clk,
+	})
+
+	// The initial key was generated at the latest when the initial SVID was
+	// issued.
+	var keyState keyreuse.State
+	if len(c.SVID) > 0 {
+		keyState = keyreuse.New(c.SVID[0].NotBefore)
+	}
 
 	return &rotator{
-		c:       c,
-		client:  client,
-		state:   state,
-		clk:     c.Clk,
-		backoff: backoff.NewBackoff(c.Clk, c.Interval),
-		bsm:     bsm,
-		rotMtx:  rotMtx,
+		c:        c,
+		client:   client,
+		state:    state,
+		clk:      c.Clk,
+		backoff:  backoff.NewBackoff(c.Clk, c.Interval),
+		rotMtx:   rotMtx,
+		keyState: keyState,
 	}, client
 }
@@ -10,10 +10,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/keyreuse"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
-	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	mock_client "github.com/spiffe/spire/test/mock/agent/client"
@@ -47,7 +50,9 @@ func (s *RotatorTestSuite) SetupTest() {
 
 	b, err := util.LoadBundleFixture()
 	s.Require().NoError(err)
-	s.bundle = observer.NewProperty(b)
+	s.bundle = observer.NewProperty(map[string]*cache.Bundle{
+		"spiffe://example.org": bundleutil.BundleFromRootCAs("spiffe://example.org", b),
+	})
 
 	cat := fakeagentcatalog.New()
 	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
@@ -162,11 +167,12 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	// Cert that's expiring
 	temp.NotBefore = s.mockClock.Now().Add(-1 * time.Hour)
 	temp.NotAfter = s.mockClock.Now()
-	badCert, _, err := util.SelfSign(temp)
+	badCert, badKey, err := util.SelfSign(temp)
 	s.Require().NoError(err)
 
 	state := State{
 		SVID: []*x509.Certificate{badCert},
+		Key:  badKey,
 	}
 	s.r.state = observer.NewProperty(state)
 
@@ -179,6 +185,137 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	state = stream.Next().(State)
 	s.Require().Len(state.SVID, 1)
 	s.Assert().True(goodCert.Equal(state.SVID[0]))
+
+	// The key of the rotated SVID must have been scrubbed
+	s.Assert().Zero(badKey.D.Sign())
+	s.Assert().NotZero(state.Key.D.Sign())
+}
+
+func (s *RotatorTestSuite) TestRotateSVIDWithKeyReuse() {
+	s.r.c.KeyReuse = keyreuse.Policy{MaxRenewals: 1}
+	s.r.keyState = keyreuse.New(s.mockClock.Now())
+
+	// Cert that's valid for 1hr
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	// Cert that's expiring
+	temp.NotBefore = s.mockClock.Now().Add(-1 * time.Hour)
+	temp.NotAfter = s.mockClock.Now()
+	badCert, badKey, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{badCert},
+		Key:  badKey,
+	})
+
+	// The key is reused for the first rotation
+	s.expectSVIDRotation(goodCert)
+	s.Require().NoError(s.r.rotateSVID(context.Background()))
+	state := s.r.State()
+	s.Assert().Same(badKey, state.Key)
+	s.Assert().NotZero(badKey.D.Sign())
+
+	// A new key is generated once the key was reused for MaxRenewals
+	// rotations
+	s.r.state.Update(State{
+		SVID: []*x509.Certificate{badCert},
+		Key:  badKey,
+	})
+	s.expectSVIDRotation(goodCert)
+	s.Require().NoError(s.r.rotateSVID(context.Background()))
+	state = s.r.State()
+	s.Assert().NotSame(badKey, state.Key)
+	s.Assert().Zero(badKey.D.Sign())
+}
+
+func (s *RotatorTestSuite) TestRotationStatus() {
+	// Cert that's valid for 1hr
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	// Cert that's expiring
+	temp.NotBefore = s.mockClock.Now().Add(-1 * time.Hour)
+	temp.NotAfter = s.mockClock.Now()
+	badCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{badCert},
+	})
+
+	// Nothing has been rotated yet
+	status := s.r.RotationStatus()
+	s.Assert().Equal(badCert.NotAfter.Add(-30*time.Minute), status.Deadline)
+	s.Assert().True(status.LastRotation.IsZero())
+	s.Assert().Empty(status.LastRotationReason)
+
+	s.expectSVIDRotation(goodCert)
+	s.Require().NoError(s.r.rotateSVID(context.Background()))
+
+	status = s.r.RotationStatus()
+	s.Assert().Equal(rotationutil.X509RotationDeadline(goodCert), status.Deadline)
+	s.Assert().Equal(s.mockClock.Now(), status.LastRotation)
+	s.Assert().Equal(RotationReasonExpiryThreshold, status.LastRotationReason)
+}
+
+func (s *RotatorTestSuite) TestRotationHint() {
+	// Cert that's valid for 1hr
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	// Cert that's expiring
+	temp.NotBefore = s.mockClock.Now().Add(-1 * time.Hour)
+	temp.NotAfter = s.mockClock.Now()
+	badCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		name           string
+		rotateAfter    time.Time
+		expectDeadline time.Time
+	}{
+		{
+			name:           "no hint",
+			expectDeadline: rotationutil.X509RotationDeadline(goodCert),
+		},
+		{
+			name:           "hint before the half of the lifetime",
+			rotateAfter:    goodCert.NotBefore.Add(20 * time.Minute),
+			expectDeadline: goodCert.NotBefore.Add(20 * time.Minute),
+		},
+		{
+			name:           "hint after the half of the lifetime is ignored",
+			rotateAfter:    goodCert.NotBefore.Add(40 * time.Minute),
+			expectDeadline: rotationutil.X509RotationDeadline(goodCert),
+		},
+		{
+			name:           "hint before the SVID is valid is ignored",
+			rotateAfter:    goodCert.NotBefore.Add(-time.Minute),
+			expectDeadline: rotationutil.X509RotationDeadline(goodCert),
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			s.r.state = observer.NewProperty(State{
+				SVID: []*x509.Certificate{badCert},
+			})
+
+			svid := &client.AgentSVID{CertChain: goodCert.Raw, RotateAfter: tt.rotateAfter}
+			s.client.EXPECT().RenewSVID(gomock.Any(), gomock.Any()).Return(svid, nil)
+			s.client.EXPECT().Release().MaxTimes(2)
+			s.Require().NoError(s.r.rotateSVID(context.Background()))
+
+			s.Assert().Equal(tt.expectDeadline, s.r.RotationStatus().Deadline)
+		})
+	}
 }
 
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
@@ -186,7 +323,7 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
 	s.client.EXPECT().
 		RenewSVID(gomock.Any(), gomock.Any()).
-		Return(&node.X509SVID{
+		Return(&client.AgentSVID{
 			CertChain: cert.Raw,
 		}, nil)
 	s.client.EXPECT().Release().MaxTimes(2)
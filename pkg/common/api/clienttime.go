@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientTimeKey is the gRPC metadata key under which clients report their
+// current time, so that servers can detect callers with skewed clocks.
+const ClientTimeKey = "spire-client-time"
+
+// ClientTime returns the time reported by the caller, if any
+func ClientTime(ctx context.Context) (time.Time, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	values := md.Get(ClientTimeKey)
+	if len(values) == 0 {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s metadata: %w", ClientTimeKey, err)
+	}
+	return t, true, nil
+}
+
+// ClientTimeInterceptors returns client interceptors that report the time
+// given by now with each call
+func ClientTimeInterceptors(now func() time.Time) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	withClientTime := func(ctx context.Context) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, ClientTimeKey, now().UTC().Format(time.RFC3339Nano))
+	}
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withClientTime(ctx), method, req, reply, cc, opts...)
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withClientTime(ctx), desc, cc, method, opts...)
+	}
+	return unary, stream
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/spiffe/spire/pkg/common/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientVersionKey is the gRPC metadata key under which SPIRE components and
+// tools report their name and version (e.g. "spire-agent/1.0.0"), so that
+// servers can tell which versions are still calling them.
+const ClientVersionKey = "spire-client-version"
+
+// UnknownClientVersion is the client version reported in metrics for callers
+// that are not known SPIRE components or that report a malformed version.
+const UnknownClientVersion = "unknown"
+
+// knownClients are the client names reported in metrics. Other values are
+// only logged, since callers could otherwise inflate the metrics cardinality.
+var knownClients = map[string]bool{
+	"spire-agent":  true,
+	"spire-server": true,
+}
+
+var clientVersionRE = regexp.MustCompile(`^([a-z-]+)/v?(\d{1,4}\.\d{1,4}\.\d{1,4})`)
+
+// CallerMetadata holds the metadata reported by an API caller about itself
+type CallerMetadata struct {
+	// UserAgent is the gRPC user agent, which includes the client library
+	// and its version (e.g. "grpc-go/1.33.2").
+	UserAgent string
+
+	// ClientVersion is the name and version of the SPIRE component or tool
+	// making the call, if reported.
+	ClientVersion string
+}
+
+// MetricsClientVersion returns the client version to report in metrics. It is
+// reduced to the name and release of known SPIRE clients (e.g.
+// "spire-agent/1.0.0"), or UnknownClientVersion.
+func (m CallerMetadata) MetricsClientVersion() string {
+	match := clientVersionRE.FindStringSubmatch(m.ClientVersion)
+	if match == nil || !knownClients[match[1]] {
+		return UnknownClientVersion
+	}
+	return match[1] + "/" + match[2]
+}
+
+// CallerMetadataFromContext returns the metadata reported by the caller
+func CallerMetadataFromContext(ctx context.Context) CallerMetadata {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return CallerMetadata{}
+	}
+	return CallerMetadata{
+		UserAgent:     firstValue(md, "user-agent"),
+		ClientVersion: firstValue(md, ClientVersionKey),
+	}
+}
+
+// ClientVersionInterceptors returns client interceptors that report the given
+// client name, along with the version of this build, with each call
+func ClientVersionInterceptors(client string) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	clientVersion := client + "/" + version.Version()
+	withClientVersion := func(ctx context.Context) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, ClientVersionKey, clientVersion)
+	}
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withClientVersion(ctx), method, req, reply, cc, opts...)
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withClientVersion(ctx), desc, cc, method, opts...)
+	}
+	return unary, stream
+}
+
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
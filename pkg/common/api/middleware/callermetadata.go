@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// WithCallerMetadata returns middleware that captures the metadata reported
+// by the caller about itself (i.e. gRPC user agent and SPIRE client version)
+// into the handler context. The metadata is added to the per-rpc logger and
+// the client version to the per-call metrics. It must be chained after the
+// logging and metrics middleware.
+func WithCallerMetadata() Middleware {
+	return Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		md := api.CallerMetadataFromContext(ctx)
+		ctx = rpccontext.WithCallerMetadata(ctx, md)
+
+		fields := make(logrus.Fields)
+		if md.UserAgent != "" {
+			fields[telemetry.CallerUserAgent] = md.UserAgent
+		}
+		if md.ClientVersion != "" {
+			fields[telemetry.CallerClientVersion] = md.ClientVersion
+		}
+		if len(fields) > 0 {
+			ctx = rpccontext.WithLogger(ctx, rpccontext.Logger(ctx).WithFields(fields))
+		}
+
+		rpccontext.AddMetricsLabel(ctx, telemetry.CallerClientVersion, md.MetricsClientVersion())
+		return ctx, nil
+	})
+}
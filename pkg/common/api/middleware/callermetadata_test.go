@@ -0,0 +1,131 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithCallerMetadata(t *testing.T) {
+	for _, tt := range []struct {
+		name                 string
+		md                   metadata.MD
+		expectMetadata       api.CallerMetadata
+		expectLogFields      logrus.Fields
+		expectMetricsVersion string
+	}{
+		{
+			name:                 "no metadata",
+			expectLogFields:      logrus.Fields{},
+			expectMetricsVersion: "unknown",
+		},
+		{
+			name: "user agent only",
+			md:   metadata.Pairs("user-agent", "grpc-go/1.33.2"),
+			expectMetadata: api.CallerMetadata{
+				UserAgent: "grpc-go/1.33.2",
+			},
+			expectLogFields: logrus.Fields{
+				telemetry.CallerUserAgent: "grpc-go/1.33.2",
+			},
+			expectMetricsVersion: "unknown",
+		},
+		{
+			name: "known client",
+			md:   metadata.Pairs("user-agent", "grpc-go/1.33.2", api.ClientVersionKey, "spire-agent/1.0.0-dev-unk"),
+			expectMetadata: api.CallerMetadata{
+				UserAgent:     "grpc-go/1.33.2",
+				ClientVersion: "spire-agent/1.0.0-dev-unk",
+			},
+			expectLogFields: logrus.Fields{
+				telemetry.CallerUserAgent:     "grpc-go/1.33.2",
+				telemetry.CallerClientVersion: "spire-agent/1.0.0-dev-unk",
+			},
+			expectMetricsVersion: "spire_agent_1_0_0",
+		},
+		{
+			name: "known client with tagged version",
+			md:   metadata.Pairs(api.ClientVersionKey, "spire-server/v0.12.1"),
+			expectMetadata: api.CallerMetadata{
+				ClientVersion: "spire-server/v0.12.1",
+			},
+			expectLogFields: logrus.Fields{
+				telemetry.CallerClientVersion: "spire-server/v0.12.1",
+			},
+			expectMetricsVersion: "spire_server_0_12_1",
+		},
+		{
+			name: "unknown client",
+			md:   metadata.Pairs(api.ClientVersionKey, "my-tool/1.0.0"),
+			expectMetadata: api.CallerMetadata{
+				ClientVersion: "my-tool/1.0.0",
+			},
+			expectLogFields: logrus.Fields{
+				telemetry.CallerClientVersion: "my-tool/1.0.0",
+			},
+			expectMetricsVersion: "unknown",
+		},
+		{
+			name: "malformed version",
+			md:   metadata.Pairs(api.ClientVersionKey, "spire-agent/latest"),
+			expectMetadata: api.CallerMetadata{
+				ClientVersion: "spire-agent/latest",
+			},
+			expectLogFields: logrus.Fields{
+				telemetry.CallerClientVersion: "spire-agent/latest",
+			},
+			expectMetricsVersion: "unknown",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			metrics := fakemetrics.New()
+			m := middleware.Chain(
+				middleware.WithLogger(log),
+				middleware.WithMetrics(metrics),
+				middleware.WithCallerMetadata(),
+			)
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			ctx, err := m.Preprocess(ctx, fakeFullMethod)
+			require.NoError(t, err)
+
+			md, ok := rpccontext.CallerMetadata(ctx)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectMetadata, md)
+
+			rpccontext.Logger(ctx).Info("HELLO")
+			expectLogFields := logrus.Fields{
+				"service": "foo.v1.Foo",
+				"method":  "SomeMethod",
+			}
+			for k, v := range tt.expectLogFields {
+				expectLogFields[k] = v
+			}
+			spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+				{Level: logrus.InfoLevel, Message: "HELLO", Data: expectLogFields},
+			})
+
+			// Label values are sanitized by the telemetry package
+			m.Postprocess(ctx, fakeFullMethod, true, nil)
+			metricItems := metrics.AllMetrics()
+			require.NotEmpty(t, metricItems)
+			assert.Contains(t, metricItems[0].Labels, telemetry.Label{Name: telemetry.CallerClientVersion, Value: tt.expectMetricsVersion})
+		})
+	}
+}
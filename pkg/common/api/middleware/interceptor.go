@@ -34,6 +34,26 @@ func StreamInterceptor(middleware Middleware) grpc.StreamServerInterceptor {
 	}
 }
 
+// ChainUnaryInterceptors returns a unary interceptor that invokes the outer
+// interceptor followed by the inner one.
+func ChainUnaryInterceptors(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return outer(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return inner(ctx, req, info, handler)
+		})
+	}
+}
+
+// ChainStreamInterceptors returns a stream interceptor that invokes the outer
+// interceptor followed by the inner one.
+func ChainStreamInterceptors(outer, inner grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return outer(srv, ss, info, func(srv interface{}, ss grpc.ServerStream) error {
+			return inner(srv, ss, info, handler)
+		})
+	}
+}
+
 type serverStream struct {
 	grpc.ServerStream
 	ctx context.Context
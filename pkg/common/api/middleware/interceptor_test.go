@@ -39,6 +39,49 @@ func TestStreamInterceptor(t *testing.T) {
 	testStreamInterceptor(t, middleware.StreamInterceptor)
 }
 
+func TestChainUnaryInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	unary := middleware.ChainUnaryInterceptors(interceptor("outer"), interceptor("inner"))
+	resp, err := unary(context.Background(), "request", fakeUnaryServerInfo,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			assert.Equal(t, "request", req)
+			return "response", nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, []string{"outer", "inner", "handler"}, calls)
+}
+
+func TestChainStreamInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name)
+			return handler(srv, ss)
+		}
+	}
+
+	stream := middleware.ChainStreamInterceptors(interceptor("outer"), interceptor("inner"))
+	err := stream("server", fakeServerStream{}, fakeStreamServerInfo,
+		func(srv interface{}, stream grpc.ServerStream) error {
+			calls = append(calls, "handler")
+			assert.Equal(t, "server", srv)
+			return errFake
+		},
+	)
+	assert.Equal(t, errFake, err)
+	assert.Equal(t, []string{"outer", "inner", "handler"}, calls)
+}
+
 func testUnaryInterceptor(t *testing.T, makeInterceptor func(m middleware.Middleware) grpc.UnaryServerInterceptor) {
 	t.Run("success", func(t *testing.T) {
 		m := new(fakeMiddleware)
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptors returns interceptors that recover panics raised by RPC
+// handlers. The panic and its stack trace are logged, a counter is emitted,
+// and the RPC fails with an INTERNAL status instead of crashing the process.
+// The interceptors should be installed inside of the interceptors returned by
+// Interceptors so that the resulting error is observed by the middleware.
+func RecoveryInterceptors(log logrus.FieldLogger, metrics telemetry.Metrics) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return UnaryRecoveryInterceptor(log, metrics), StreamRecoveryInterceptor(log, metrics)
+}
+
+func UnaryRecoveryInterceptor(log logrus.FieldLogger, metrics telemetry.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, handlePanic(ctx, log, metrics, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecoveryInterceptor(log logrus.FieldLogger, metrics telemetry.Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(ss.Context(), log, metrics, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func handlePanic(ctx context.Context, log logrus.FieldLogger, metrics telemetry.Metrics, fullMethod string, r interface{}) error {
+	_, names := withNames(ctx, fullMethod)
+
+	log = log.WithFields(logrus.Fields{
+		"service":            names.Service,
+		"method":             names.Method,
+		telemetry.Panic:      r,
+		telemetry.StackTrace: string(debug.Stack()),
+	})
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		log = log.WithField(telemetry.Address, p.Addr.String())
+	}
+	log.Error("Recovered from panic in RPC handler")
+
+	metrics.IncrCounterWithLabels([]string{"rpc", telemetry.Panic}, 1, []telemetry.Label{
+		{Name: "service", Value: names.Service},
+		{Name: telemetry.Method, Value: names.Method},
+	})
+
+	return status.Error(codes.Internal, "internal error")
+}
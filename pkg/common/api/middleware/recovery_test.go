@@ -0,0 +1,111 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestUnaryRecoveryInterceptor(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	unary, _ := middleware.RecoveryInterceptors(log, metrics)
+
+	t.Run("handler succeeds", func(t *testing.T) {
+		resp, err := unary(context.Background(), "request", fakeUnaryServerInfo,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "response", nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "response", resp)
+		assert.Empty(t, hook.AllEntries())
+		assert.Empty(t, metrics.AllMetrics())
+	})
+
+	t.Run("handler panics", func(t *testing.T) {
+		resp, err := unary(context.Background(), "request", fakeUnaryServerInfo,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("ohno")
+			})
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "internal error")
+		assert.Nil(t, resp)
+		assertPanicRecovered(t, hook, metrics)
+	})
+}
+
+func TestStreamRecoveryInterceptor(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	_, stream := middleware.RecoveryInterceptors(log, metrics)
+
+	t.Run("handler succeeds", func(t *testing.T) {
+		err := stream("server", fakeServerStream{}, fakeStreamServerInfo,
+			func(srv interface{}, stream grpc.ServerStream) error {
+				return errFake
+			})
+		assert.Equal(t, errFake, err)
+		assert.Empty(t, hook.AllEntries())
+		assert.Empty(t, metrics.AllMetrics())
+	})
+
+	t.Run("handler panics", func(t *testing.T) {
+		err := stream("server", fakeServerStream{}, fakeStreamServerInfo,
+			func(srv interface{}, stream grpc.ServerStream) error {
+				panic("ohno")
+			})
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "internal error")
+		assertPanicRecovered(t, hook, metrics)
+	})
+}
+
+func TestRecoveryWithinMiddleware(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	m := new(fakeMiddleware)
+	recovery, _ := middleware.RecoveryInterceptors(log, fakemetrics.New())
+	unary := middleware.UnaryInterceptor(m)
+
+	_, err := unary(context.Background(), "request", fakeUnaryServerInfo,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return recovery(ctx, req, fakeUnaryServerInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("ohno")
+			})
+		})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "internal error")
+
+	// The middleware observes the failure like any other handler error
+	assert.True(t, m.lastPostprocess.handlerInvoked)
+	spiretest.AssertGRPCStatus(t, m.lastPostprocess.rpcErr, codes.Internal, "internal error")
+}
+
+func assertPanicRecovered(t *testing.T, hook *test.Hook, metrics *fakemetrics.FakeMetrics) {
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "Recovered from panic in RPC handler", entry.Message)
+	assert.Equal(t, "foo.v1.Foo", entry.Data["service"])
+	assert.Equal(t, "SomeMethod", entry.Data["method"])
+	assert.Equal(t, "ohno", entry.Data[telemetry.Panic])
+	assert.Contains(t, entry.Data[telemetry.StackTrace], "runtime/debug.Stack")
+
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type: fakemetrics.IncrCounterWithLabelsType,
+			Key:  []string{"rpc", telemetry.Panic},
+			Val:  1,
+			Labels: []telemetry.Label{
+				{Name: "service", Value: "foo_v1_Foo"},
+				{Name: telemetry.Method, Value: "SomeMethod"},
+			},
+		},
+	}, metrics.AllMetrics())
+}
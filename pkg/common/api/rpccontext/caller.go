@@ -0,0 +1,18 @@
+package rpccontext
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/api"
+)
+
+type callerMetadataKey struct{}
+
+func WithCallerMetadata(ctx context.Context, md api.CallerMetadata) context.Context {
+	return context.WithValue(ctx, callerMetadataKey{}, md)
+}
+
+func CallerMetadata(ctx context.Context) (api.CallerMetadata, bool) {
+	value, ok := ctx.Value(callerMetadataKey{}).(api.CallerMetadata)
+	return value, ok
+}
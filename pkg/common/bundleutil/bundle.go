@@ -1,6 +1,7 @@
 package bundleutil
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/x509"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/zeebo/errs"
 	"google.golang.org/protobuf/proto"
@@ -117,8 +119,19 @@ func (b *Bundle) TrustDomainID() string {
 	return b.b.TrustDomainId
 }
 
+// EqualTo returns true if both bundles have the same contents. The sequence
+// numbers are not compared since they are assigned by each datastore.
 func (b *Bundle) EqualTo(other *Bundle) bool {
-	return proto.Equal(b.b, other.b)
+	return proto.Equal(withoutSequenceNumber(b.b), withoutSequenceNumber(other.b))
+}
+
+func withoutSequenceNumber(b *common.Bundle) *common.Bundle {
+	return &common.Bundle{
+		TrustDomainId:  b.TrustDomainId,
+		RootCas:        b.RootCas,
+		JwtSigningKeys: b.JwtSigningKeys,
+		RefreshHint:    b.RefreshHint,
+	}
 }
 
 func (b *Bundle) RootCAs() []*x509.Certificate {
@@ -224,6 +237,9 @@ func JWTSigningKeysFromBundleProto(b *common.Bundle) (map[string]crypto.PublicKe
 	return out, nil
 }
 
+// MergeBundles returns a copy of a with the authorities of b that a does not
+// have yet, and whether any was added. A JWT signing key is already in a if
+// a has a key with the same key ID and public key.
 func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	c := cloneBundle(a)
 
@@ -233,7 +249,7 @@ func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	}
 	jwtSigningKeys := make(map[string]bool)
 	for _, jwtSigningKey := range a.JwtSigningKeys {
-		jwtSigningKeys[jwtSigningKey.String()] = true
+		jwtSigningKeys[jwtSigningKeyID(jwtSigningKey)] = true
 	}
 
 	var changed bool
@@ -244,7 +260,7 @@ func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 		}
 	}
 	for _, jwtSigningKey := range b.JwtSigningKeys {
-		if !jwtSigningKeys[jwtSigningKey.String()] {
+		if !jwtSigningKeys[jwtSigningKeyID(jwtSigningKey)] {
 			c.JwtSigningKeys = append(c.JwtSigningKeys, jwtSigningKey)
 			changed = true
 		}
@@ -252,6 +268,44 @@ func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	return c, changed
 }
 
+// CheckJWTKeyConflicts returns an error if a JWT signing key of b has the key
+// ID of a JWT signing key of a with a different public key.
+func CheckJWTKeyConflicts(a, b *common.Bundle) error {
+	existing := make(map[string][]byte, len(a.JwtSigningKeys))
+	for _, jwtSigningKey := range a.JwtSigningKeys {
+		existing[jwtSigningKey.Kid] = jwtSigningKey.PkixBytes
+	}
+	for _, jwtSigningKey := range b.JwtSigningKeys {
+		if pkixBytes, ok := existing[jwtSigningKey.Kid]; ok && !bytes.Equal(pkixBytes, jwtSigningKey.PkixBytes) {
+			return fmt.Errorf("key ID %q is already used by a different public key", jwtSigningKey.Kid)
+		}
+	}
+	return nil
+}
+
+func jwtSigningKeyID(jwtSigningKey *common.PublicKey) string {
+	return jwtSigningKey.Kid + "\x00" + string(jwtSigningKey.PkixBytes)
+}
+
+// CheckLimits returns an error if the bundle exceeds any of the limits. A
+// nil limits or a zero limit imposes no limit.
+func CheckLimits(b *common.Bundle, limits *datastore.BundleLimits) error {
+	switch {
+	case limits == nil:
+		return nil
+	case limits.MaxX509Authorities > 0 && len(b.RootCas) > int(limits.MaxX509Authorities):
+		return fmt.Errorf("bundle has %d X.509 authorities, exceeding the maximum of %d", len(b.RootCas), limits.MaxX509Authorities)
+	case limits.MaxJwtAuthorities > 0 && len(b.JwtSigningKeys) > int(limits.MaxJwtAuthorities):
+		return fmt.Errorf("bundle has %d JWT authorities, exceeding the maximum of %d", len(b.JwtSigningKeys), limits.MaxJwtAuthorities)
+	}
+	if limits.MaxBundleBytes > 0 {
+		if size := proto.Size(b); size > int(limits.MaxBundleBytes) {
+			return fmt.Errorf("bundle is %d bytes, exceeding the maximum of %d", size, limits.MaxBundleBytes)
+		}
+	}
+	return nil
+}
+
 // PruneBundle removes the bundle RootCAs and JWT keys that expired before a given time
 // It returns an error if prunning results in a bundle with no CAs or keys
 func PruneBundle(bundle *common.Bundle, expiration time.Time, log hclog.Logger) (*common.Bundle, bool, error) {
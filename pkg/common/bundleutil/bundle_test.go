@@ -13,6 +13,7 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/testca"
@@ -27,6 +28,57 @@ type bundleTest struct {
 	jwtKeyNotExpired *common.PublicKey
 }
 
+func TestCheckLimits(t *testing.T) {
+	bundle := &common.Bundle{
+		TrustDomainId:  "spiffe://example.org",
+		RootCas:        []*common.Certificate{{DerBytes: []byte("a")}, {DerBytes: []byte("b")}},
+		JwtSigningKeys: []*common.PublicKey{{Kid: "kid1"}, {Kid: "kid2"}},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		limits    *datastore.BundleLimits
+		expectErr string
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name:   "zero limits",
+			limits: &datastore.BundleLimits{},
+		},
+		{
+			name:   "within limits",
+			limits: &datastore.BundleLimits{MaxX509Authorities: 2, MaxJwtAuthorities: 2, MaxBundleBytes: 100},
+		},
+		{
+			name:      "too many X.509 authorities",
+			limits:    &datastore.BundleLimits{MaxX509Authorities: 1},
+			expectErr: "bundle has 2 X.509 authorities, exceeding the maximum of 1",
+		},
+		{
+			name:      "too many JWT authorities",
+			limits:    &datastore.BundleLimits{MaxJwtAuthorities: 1},
+			expectErr: "bundle has 2 JWT authorities, exceeding the maximum of 1",
+		},
+		{
+			name:      "too large",
+			limits:    &datastore.BundleLimits{MaxBundleBytes: 10},
+			expectErr: "bundle is 48 bytes, exceeding the maximum of 10",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckLimits(bundle, tt.limits)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestPruneBundle(t *testing.T) {
 	test := setupTest(t)
 
@@ -99,6 +99,7 @@ func Marshal(bundle *Bundle, opts ...MarshalOption) ([]byte, error) {
 	if !c.standardJWKS {
 		out = bundleDoc{
 			JSONWebKeySet: jwks,
+			Sequence:      bundle.b.SequenceNumber,
 			RefreshHint:   int(c.refreshHint / time.Second),
 		}
 	}
@@ -12,16 +12,23 @@ func TestMarshal(t *testing.T) {
 	rootCA := createCACertificate(t)
 
 	testCases := []struct {
-		name  string
-		empty bool
-		opts  []MarshalOption
-		out   string
+		name     string
+		empty    bool
+		sequence uint64
+		opts     []MarshalOption
+		out      string
 	}{
 		{
 			name:  "empty bundle",
 			empty: true,
 			out:   `{"keys":null, "spiffe_refresh_hint": 60}`,
 		},
+		{
+			name:     "with sequence number",
+			empty:    true,
+			sequence: 42,
+			out:      `{"keys":null, "spiffe_sequence": 42, "spiffe_refresh_hint": 60}`,
+		},
 		{
 			name:  "with refresh hint override",
 			empty: true,
@@ -130,6 +137,7 @@ func TestMarshal(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			bundle := New("spiffe://domain.test")
 			bundle.SetRefreshHint(time.Minute)
+			bundle.b.SequenceNumber = testCase.sequence
 			if !testCase.empty {
 				bundle.AppendRootCA(rootCA)
 				require.NoError(t, bundle.AppendJWTSigningKey("FOO", testKey.Public()))
@@ -11,6 +11,10 @@ const (
 	// MinimumRefreshHint is the smallest refresh hint the client allows.
 	// Anything smaller than the minimum will be reset to the minimum.
 	MinimumRefreshHint = time.Minute
+
+	// MaximumRefreshHint is the default largest refresh hint the server
+	// accepts and serves.
+	MaximumRefreshHint = 7 * 24 * time.Hour
 )
 
 // CalculateRefreshHint is used to calculate the refresh hint for a given
@@ -45,6 +49,18 @@ func CalculateRefreshHint(bundle *Bundle) time.Duration {
 	return safeRefreshHint(refreshHint)
 }
 
+// ClampRefreshHint returns the refresh hint bounded to the [min, max] range.
+func ClampRefreshHint(refreshHint, min, max time.Duration) time.Duration {
+	switch {
+	case refreshHint < min:
+		return min
+	case refreshHint > max:
+		return max
+	default:
+		return refreshHint
+	}
+}
+
 func safeRefreshHint(refreshHint time.Duration) time.Duration {
 	if refreshHint < MinimumRefreshHint {
 		return MinimumRefreshHint
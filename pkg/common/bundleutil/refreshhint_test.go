@@ -64,3 +64,9 @@ func TestCalculateRefreshHint(t *testing.T) {
 		})
 	}
 }
+
+func TestClampRefreshHint(t *testing.T) {
+	require.Equal(t, 5*time.Minute, ClampRefreshHint(time.Minute, 5*time.Minute, time.Hour))
+	require.Equal(t, 10*time.Minute, ClampRefreshHint(10*time.Minute, 5*time.Minute, time.Hour))
+	require.Equal(t, time.Hour, ClampRefreshHint(2*time.Hour, 5*time.Minute, time.Hour))
+}
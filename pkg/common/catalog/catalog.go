@@ -14,6 +14,10 @@ type GlobalConfig = spi.ConfigureRequest_GlobalConfig
 type Config struct {
 	Log logrus.FieldLogger
 
+	// PluginLog returns the logger of a plugin. Optional. If unset, the
+	// plugins log through Log.
+	PluginLog func(pluginType, pluginName string) logrus.FieldLogger
+
 	// GlobalConfig is passed to plugins during configuration.
 	GlobalConfig *GlobalConfig
 
@@ -31,6 +35,17 @@ type Config struct {
 
 	// BuiltIns is the set of builtin plugins available to the host.
 	BuiltIns []Plugin
+
+	// Metrics is used to emit telemetry about external plugin crashes and
+	// restarts. Optional.
+	Metrics telemetry.Metrics
+
+	// RestartPolicy controls the liveness checks and automatic restarts of
+	// external plugins.
+	RestartPolicy RestartPolicy
+
+	// PluginPolicy restricts which plugins can be loaded.
+	PluginPolicy PluginPolicy
 }
 
 // Catalog provides a method to obtain clients to loaded plugins and services.
@@ -159,7 +174,11 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 
 	for _, c := range config.PluginConfig {
 		// configure a logger for the plugin
-		pluginLog := config.Log.WithFields(logrus.Fields{
+		log := config.Log
+		if config.PluginLog != nil {
+			log = config.PluginLog(c.Type, c.Name)
+		}
+		pluginLog := log.WithFields(logrus.Fields{
 			telemetry.PluginName:    c.Name,
 			telemetry.PluginType:    c.Type,
 			telemetry.PluginBuiltIn: c.Path == "",
@@ -170,6 +189,11 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 			continue
 		}
 
+		if err := config.PluginPolicy.Check(c); err != nil {
+			pluginLog.WithError(err).Error("Refusing to load plugin")
+			return nil, err
+		}
+
 		var plugin *LoadedPlugin
 		if c.Path == "" {
 			builtin, ok := builtinsMap.Lookup(c.Name, c.Type)
@@ -177,7 +201,7 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 				return nil, errs.New("no such %s builtin %q", c.Type, c.Name)
 			}
 			plugin, err = LoadBuiltInPlugin(ctx, BuiltInPlugin{
-				Log:          config.Log,
+				Log:          log,
 				Plugin:       builtin,
 				HostServices: config.HostServices,
 			})
@@ -188,13 +212,15 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 			}
 
 			plugin, err = LoadExternalPlugin(ctx, ExternalPlugin{
-				Log:           config.Log,
+				Log:           log,
+				Metrics:       config.Metrics,
 				Name:          c.Name,
 				Path:          c.Path,
 				Checksum:      c.Checksum,
 				Plugin:        extPlugin,
 				KnownServices: config.KnownServices,
 				HostServices:  config.HostServices,
+				RestartPolicy: config.RestartPolicy,
 			})
 		}
 		if err != nil {
@@ -9,7 +9,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	logtest "github.com/sirupsen/logrus/hooks/test"
@@ -17,6 +19,8 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog/test"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/private/test/catalogtest"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -59,6 +63,7 @@ type CatalogSuite struct {
 	knownServices []catalog.ServiceClient
 	builtins      []catalog.Plugin
 	hostServices  []catalog.HostServiceServer
+	pluginPolicy  catalog.PluginPolicy
 }
 
 // SetupSuite builds the test plugin binary
@@ -93,6 +98,7 @@ func (s *CatalogSuite) SetupTest() {
 	}
 	s.builtins = nil
 	s.pluginConfig = nil
+	s.pluginPolicy = catalog.PluginPolicy{}
 }
 
 func (s *CatalogSuite) AfterTest(suiteName, testName string) {
@@ -138,6 +144,109 @@ func (s *CatalogSuite) TestOldPlugin() {
 	s.Require().Equal("plugin(OLD)", resp.Out)
 }
 
+func (s *CatalogSuite) TestExternalPluginRestart() {
+	require := s.Require()
+
+	metrics := fakemetrics.New()
+	plugin, v := s.loadCrashingPlugin(metrics, catalog.RestartPolicy{})
+	defer plugin.Close()
+
+	// The same client works again once the plugin has been restarted and
+	// reconfigured.
+	require.Eventually(func() bool {
+		resp, err := v.CallPlugin(context.Background(), &catalogtest.Request{
+			In: "hello-to-plugin",
+		})
+		return err == nil && resp.Out == "plugin(hostservice[plugin=testext](hello-to-plugin))"
+	}, 10*time.Second, 50*time.Millisecond)
+
+	s.assertHasLogEntry(testLogEntry{
+		Level:   logrus.WarnLevel,
+		Message: "External plugin restarted",
+		Data: logrus.Fields{
+			telemetry.Attempt:    1,
+			telemetry.PluginName: "testext",
+			telemetry.PluginType: "Plugin",
+		},
+	})
+
+	labels := []telemetry.Label{
+		{Name: telemetry.PluginName, Value: "testext"},
+		{Name: telemetry.PluginType, Value: "Plugin"},
+	}
+	s.Equal([]fakemetrics.MetricItem{
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{telemetry.PluginExternal, telemetry.Exited},
+			Val:    1,
+			Labels: labels,
+		},
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{telemetry.PluginExternal, telemetry.Restart},
+			Val:    1,
+			Labels: labels,
+		},
+	}, metrics.AllMetrics())
+}
+
+func (s *CatalogSuite) TestExternalPluginRestartDisabled() {
+	plugin, _ := s.loadCrashingPlugin(fakemetrics.New(), catalog.RestartPolicy{
+		MaxRestarts: -1,
+	})
+	defer plugin.Close()
+
+	s.Require().Eventually(func() bool {
+		for _, entry := range s.logHook.AllEntries() {
+			if entry.Message == "External plugin will not be restarted; automatic restarts are disabled" {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+// loadCrashingPlugin loads the external test plugin and makes it crash.
+func (s *CatalogSuite) loadCrashingPlugin(metrics telemetry.Metrics, policy catalog.RestartPolicy) (*catalog.LoadedPlugin, catalogtest.Plugin) {
+	require := s.Require()
+
+	policy.CheckInterval = 10 * time.Millisecond
+	policy.Backoff = 10 * time.Millisecond
+	plugin, err := catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:           s.log,
+		Metrics:       metrics,
+		Name:          "testext",
+		Path:          s.path,
+		Checksum:      s.checksum,
+		Plugin:        catalogtest.PluginPluginClient,
+		KnownServices: s.knownServices,
+		HostServices:  s.hostServices,
+		RestartPolicy: policy,
+	})
+	require.NoError(err)
+
+	err = plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: "CONFIG",
+	})
+	if err != nil {
+		plugin.Close()
+		require.NoError(err)
+	}
+
+	var v catalogtest.Plugin
+	if err := plugin.Fill(&v); err != nil {
+		plugin.Close()
+		require.NoError(err)
+	}
+
+	_, err = v.CallPlugin(context.Background(), &catalogtest.Request{
+		In: "EXIT",
+	})
+	require.Error(err)
+
+	return plugin, v
+}
+
 func (s *CatalogSuite) TestNoKnownPlugin() {
 	s.knownPlugins = nil
 	s.pluginConfig = s.extPluginConfig()
@@ -225,6 +334,42 @@ func (s *CatalogSuite) TestConfigureFailure() {
 	s.assertFillCatalogFails(`unable to configure plugin "testext": rpc error: code = InvalidArgument desc = BAD configuration`)
 }
 
+func (s *CatalogSuite) TestPluginPolicyDeny() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginPolicy.Deny = []string{"Plugin:testext"}
+
+	s.assertFillCatalogFails(`Plugin plugin "testext" is denied by the plugin policy`)
+}
+
+func (s *CatalogSuite) TestPluginPolicyAllow() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginPolicy.Allow = []string{"Plugin:other"}
+
+	s.assertFillCatalogFails(`Plugin plugin "testext" is not allowed by the plugin policy`)
+}
+
+func (s *CatalogSuite) TestPluginPolicyRequireChecksum() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Checksum = ""
+	s.pluginPolicy.RequireChecksum = true
+
+	s.assertFillCatalogFails(`Plugin plugin "testext" is external and the plugin policy requires a plugin_checksum`)
+}
+
+func (s *CatalogSuite) TestInvalidChecksumLength() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Checksum = "abcd"
+
+	s.assertFillCatalogFails(`checksum must be a hex encoded SHA-256 digest; got 2 bytes`)
+}
+
+func (s *CatalogSuite) TestChecksumMismatch() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Checksum = strings.Repeat("00", sha256.Size)
+
+	s.assertFillCatalogFails(`checksums did not match`)
+}
+
 func (s *CatalogSuite) TestDuplicateKnownPlugins() {
 	s.knownPlugins = []catalog.PluginClient{
 		catalogtest.PluginPluginClient,
@@ -715,6 +860,7 @@ func (s *CatalogSuite) fillCatalog(c interface{}) (catalog.Closer, error) {
 		KnownServices: s.knownServices,
 		BuiltIns:      s.builtins,
 		HostServices:  s.hostServices,
+		PluginPolicy:  s.pluginPolicy,
 	}, c)
 }
 
@@ -729,6 +875,7 @@ func (s *CatalogSuite) loadCatalog() catalog.Catalog {
 		KnownServices: s.knownServices,
 		HostServices:  s.hostServices,
 		BuiltIns:      s.builtins,
+		PluginPolicy:  s.pluginPolicy,
 	})
 	s.Require().NoError(err)
 	return cat
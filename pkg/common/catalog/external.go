@@ -80,6 +80,7 @@ func (d grpcBrokerDialer) DialHost() (*grpc.ClientConn, error) {
 
 type ExternalPlugin struct {
 	Log           logrus.FieldLogger
+	Metrics       telemetry.Metrics
 	Name          string
 	Path          string
 	Checksum      string
@@ -87,6 +88,7 @@ type ExternalPlugin struct {
 	Plugin        PluginClient
 	KnownServices []ServiceClient
 	HostServices  []HostServiceServer
+	RestartPolicy RestartPolicy
 }
 
 func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *LoadedPlugin, err error) {
@@ -96,43 +98,91 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		return nil, errs.Wrap(err)
 	}
 
-	cmd := pluginCmd(ext.Path)
-
-	var secureConfig *goplugin.SecureConfig
 	if ext.Checksum != "" {
-		secureConfig, err = buildSecureConfig(ext.Checksum)
-		if err != nil {
+		if _, err := buildSecureConfig(ext.Checksum); err != nil {
 			return nil, err
 		}
 	} else {
 		ext.Log.Warn("Plugin checksum not configured")
 	}
 
+	hcPlugin := &hcClientPlugin{
+		ext:  ext,
+		conn: new(pluginConn),
+	}
+
+	pluginClient, plugin, err := hcPlugin.start()
+	if err != nil {
+		return nil, err
+	}
+
+	supervisor := startPluginSupervisor(ext, hcPlugin, pluginClient, plugin)
+
+	// Closing the supervisor kills the plugin process, which also closes
+	// the gRPC client
+	plugin.closer = func() {
+		supervisor.Close()
+		hcPlugin.WaitUntilBrokerDone()
+	}
+
+	return plugin, nil
+}
+
+func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
+	sum, err := hex.DecodeString(checksum)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode checksum: %v", err)
+	}
+	if len(sum) != sha256.Size {
+		return nil, fmt.Errorf("checksum must be a hex encoded SHA-256 digest; got %d bytes", len(sum))
+	}
+
+	return &goplugin.SecureConfig{
+		Checksum: sum,
+		Hash:     sha256.New(),
+	}, nil
+}
+
+type hcClientPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	ext  ExternalPlugin
+	conn *pluginConn
+	wg   sync.WaitGroup
+}
+
+// start launches the plugin process and dispenses the plugin over its gRPC
+// connection. Clients of the dispensed plugin are bound to the plugin
+// connection and are therefore shared across restarts.
+func (p *hcClientPlugin) start() (_ *goplugin.Client, _ *LoadedPlugin, err error) {
+	var secureConfig *goplugin.SecureConfig
+	if p.ext.Checksum != "" {
+		secureConfig, err = buildSecureConfig(p.ext.Checksum)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	logger := log.NewHCLogAdapter(
-		ext.Log,
+		p.ext.Log,
 		telemetry.PluginExternal,
 	)
 
-	hcPlugin := &hcClientPlugin{
-		ext: ext,
-	}
-
 	// start the external plugin. ensure it is killed if there is an error.
 	pluginClient := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig: goplugin.HandshakeConfig{
 			ProtocolVersion:  1,
-			MagicCookieKey:   ext.Plugin.PluginType(),
-			MagicCookieValue: ext.Plugin.PluginType(),
+			MagicCookieKey:   p.ext.Plugin.PluginType(),
+			MagicCookieValue: p.ext.Plugin.PluginType(),
 		},
-		Cmd: cmd,
+		Cmd: pluginCmd(p.ext.Path),
 		// TODO: enable AutoMTLS if it is fixed to work with brokering.
 		// See https://github.com/hashicorp/go-plugin/issues/109
 		AutoMTLS:         false,
 		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
 		Plugins: map[string]goplugin.Plugin{
-			"external": hcPlugin,
+			"external": p,
 		},
-		Logger:       logger.Named(ext.Name),
+		Logger:       logger.Named(p.ext.Name),
 		SecureConfig: secureConfig,
 	})
 	defer func() {
@@ -144,46 +194,22 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 	// create the GRPC client and ensure it is closed on error
 	grpcClient, err := pluginClient.Client()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// the primary interface is dispensed via the plugin name
 	pluginRaw, err := grpcClient.Dispense("external")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	plugin, ok := pluginRaw.(*LoadedPlugin)
 	if !ok {
 		// shouldn't happen.
-		return nil, errs.New("expected %T, got %T", plugin, pluginRaw)
-	}
-
-	// Kill also closes the gRPC client
-	plugin.closer = func() {
-		pluginClient.Kill()
-		hcPlugin.WaitUntilBrokerDone()
+		return nil, nil, errs.New("expected %T, got %T", plugin, pluginRaw)
 	}
 
-	return plugin, nil
-}
-
-func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
-	sum, err := hex.DecodeString(checksum)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode checksum: %v", err)
-	}
-
-	return &goplugin.SecureConfig{
-		Checksum: sum,
-		Hash:     sha256.New(),
-	}, nil
-}
-
-type hcClientPlugin struct {
-	goplugin.NetRPCUnsupportedPlugin
-	ext ExternalPlugin
-	wg  sync.WaitGroup
+	return pluginClient, plugin, nil
 }
 
 var _ goplugin.GRPCPlugin = (*hcClientPlugin)(nil)
@@ -217,7 +243,10 @@ func (p *hcClientPlugin) GRPCClient(ctx context.Context, b *goplugin.GRPCBroker,
 		server.Stop()
 	}()
 
-	plugin, err := newCatalogPlugin(ctx, c, catalogPluginConfig{
+	// Route calls from the plugin clients to the new connection.
+	p.conn.set(c)
+
+	plugin, err := newCatalogPlugin(ctx, p.conn, catalogPluginConfig{
 		Log:           p.ext.Log,
 		Name:          p.ext.Name,
 		BuiltIn:       false,
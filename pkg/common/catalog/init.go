@@ -28,7 +28,7 @@ type catalogPluginConfig struct {
 	HostServices  []HostServiceServer
 }
 
-func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPluginConfig) (*LoadedPlugin, error) {
+func newCatalogPlugin(ctx context.Context, c grpc.ClientConnInterface, config catalogPluginConfig) (*LoadedPlugin, error) {
 	hostServiceTypes, err := makeHostServiceTypes(config.HostServices)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,11 @@ type LoadedPlugin struct {
 
 	closeOnce sync.Once
 	closer    func()
+
+	// configMtx guards configReq, the last configuration successfully
+	// applied to the plugin. It is replayed if the plugin is restarted.
+	configMtx sync.Mutex
+	configReq *spi.ConfigureRequest
 }
 
 func (p *LoadedPlugin) Name() string {
@@ -39,9 +44,25 @@ func (p *LoadedPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	if err != nil {
 		return errs.Wrap(err)
 	}
+
+	p.configMtx.Lock()
+	p.configReq = req
+	p.configMtx.Unlock()
 	return nil
 }
 
+// reconfigure replays the last successfully applied configuration, if any.
+func (p *LoadedPlugin) reconfigure(ctx context.Context) error {
+	p.configMtx.Lock()
+	req := p.configReq
+	p.configMtx.Unlock()
+
+	if req == nil {
+		return nil
+	}
+	return p.Configure(ctx, req)
+}
+
 func (p *LoadedPlugin) Fill(x interface{}) (err error) {
 	cf := newPluginFiller(p)
 	return cf.fill(x)
@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// PluginPolicy restricts which plugins can be loaded. Plugins are referenced
+// either by type (e.g. "NodeAttestor"), which matches every plugin of that
+// type, or by type and name separated by a colon (e.g.
+// "NodeAttestor:join_token").
+type PluginPolicy struct {
+	// Allow lists the plugins that can be loaded. If empty, every plugin that
+	// is not denied can be loaded.
+	Allow []string
+
+	// Deny lists the plugins that cannot be loaded. It takes precedence over
+	// Allow.
+	Deny []string
+
+	// RequireChecksum, if true, requires external plugins to be configured
+	// with a checksum, which is verified before the plugin is launched.
+	RequireChecksum bool
+}
+
+// HCLPluginPolicy is the HCL representation of a PluginPolicy
+type HCLPluginPolicy struct {
+	Allow           []string `hcl:"allow"`
+	Deny            []string `hcl:"deny"`
+	RequireChecksum bool     `hcl:"require_checksum"`
+	UnusedKeys      []string `hcl:",unusedKeys"`
+}
+
+// PluginPolicyFromHCL validates and converts the HCL representation of a
+// plugin policy. A nil policy results in an unrestricted policy.
+func PluginPolicyFromHCL(hclPolicy *HCLPluginPolicy) (PluginPolicy, error) {
+	if hclPolicy == nil {
+		return PluginPolicy{}, nil
+	}
+	for _, ref := range append(append([]string(nil), hclPolicy.Allow...), hclPolicy.Deny...) {
+		pluginType, name, hasName := splitPluginRef(ref)
+		if pluginType == "" || (hasName && name == "") {
+			return PluginPolicy{}, errs.New("invalid plugin reference %q in plugin policy; expected \"<type>\" or \"<type>:<name>\"", ref)
+		}
+	}
+	return PluginPolicy{
+		Allow:           hclPolicy.Allow,
+		Deny:            hclPolicy.Deny,
+		RequireChecksum: hclPolicy.RequireChecksum,
+	}, nil
+}
+
+// Check returns an error if the policy does not allow the plugin to be loaded
+func (p PluginPolicy) Check(c PluginConfig) error {
+	if matchesPluginRefs(p.Deny, c) {
+		return errs.New("%s plugin %q is denied by the plugin policy", c.Type, c.Name)
+	}
+	if len(p.Allow) > 0 && !matchesPluginRefs(p.Allow, c) {
+		return errs.New("%s plugin %q is not allowed by the plugin policy", c.Type, c.Name)
+	}
+	if p.RequireChecksum && c.Path != "" && c.Checksum == "" {
+		return errs.New("%s plugin %q is external and the plugin policy requires a plugin_checksum", c.Type, c.Name)
+	}
+	return nil
+}
+
+func matchesPluginRefs(refs []string, c PluginConfig) bool {
+	for _, ref := range refs {
+		pluginType, name, hasName := splitPluginRef(ref)
+		if pluginType == c.Type && (!hasName || name == c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPluginRef(ref string) (pluginType, name string, hasName bool) {
+	i := strings.IndexByte(ref, ':')
+	if i < 0 {
+		return ref, "", false
+	}
+	return ref[:i], ref[i+1:], true
+}
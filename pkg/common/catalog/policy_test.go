@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginPolicyFromHCL(t *testing.T) {
+	policy, err := PluginPolicyFromHCL(nil)
+	require.NoError(t, err)
+	require.Equal(t, PluginPolicy{}, policy)
+
+	policy, err = PluginPolicyFromHCL(&HCLPluginPolicy{
+		Allow:           []string{"NodeAttestor", "KeyManager:disk"},
+		Deny:            []string{"NodeAttestor:join_token"},
+		RequireChecksum: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PluginPolicy{
+		Allow:           []string{"NodeAttestor", "KeyManager:disk"},
+		Deny:            []string{"NodeAttestor:join_token"},
+		RequireChecksum: true,
+	}, policy)
+
+	for _, ref := range []string{"", ":disk", "KeyManager:"} {
+		_, err = PluginPolicyFromHCL(&HCLPluginPolicy{Deny: []string{ref}})
+		require.EqualError(t, err, `invalid plugin reference "`+ref+`" in plugin policy; expected "<type>" or "<type>:<name>"`)
+	}
+}
+
+func TestPluginPolicyCheck(t *testing.T) {
+	joinToken := PluginConfig{Type: "NodeAttestor", Name: "join_token"}
+	k8sPSAT := PluginConfig{Type: "NodeAttestor", Name: "k8s_psat"}
+	external := PluginConfig{Type: "KeyManager", Name: "hsm", Path: "/opt/hsm"}
+	externalWithChecksum := PluginConfig{Type: "KeyManager", Name: "hsm", Path: "/opt/hsm", Checksum: "CHECKSUM"}
+
+	for _, tt := range []struct {
+		name      string
+		policy    PluginPolicy
+		plugin    PluginConfig
+		expectErr string
+	}{
+		{
+			name:   "empty policy allows everything",
+			plugin: external,
+		},
+		{
+			name:      "denied by name",
+			policy:    PluginPolicy{Deny: []string{"NodeAttestor:join_token"}},
+			plugin:    joinToken,
+			expectErr: `NodeAttestor plugin "join_token" is denied by the plugin policy`,
+		},
+		{
+			name:   "not denied by name",
+			policy: PluginPolicy{Deny: []string{"NodeAttestor:join_token"}},
+			plugin: k8sPSAT,
+		},
+		{
+			name:      "denied by type",
+			policy:    PluginPolicy{Deny: []string{"NodeAttestor"}},
+			plugin:    k8sPSAT,
+			expectErr: `NodeAttestor plugin "k8s_psat" is denied by the plugin policy`,
+		},
+		{
+			name:      "deny takes precedence over allow",
+			policy:    PluginPolicy{Allow: []string{"NodeAttestor"}, Deny: []string{"NodeAttestor:join_token"}},
+			plugin:    joinToken,
+			expectErr: `NodeAttestor plugin "join_token" is denied by the plugin policy`,
+		},
+		{
+			name:   "allowed by type",
+			policy: PluginPolicy{Allow: []string{"NodeAttestor"}},
+			plugin: joinToken,
+		},
+		{
+			name:      "not allowed",
+			policy:    PluginPolicy{Allow: []string{"NodeAttestor:k8s_psat"}},
+			plugin:    joinToken,
+			expectErr: `NodeAttestor plugin "join_token" is not allowed by the plugin policy`,
+		},
+		{
+			name:      "checksum required for external plugins",
+			policy:    PluginPolicy{RequireChecksum: true},
+			plugin:    external,
+			expectErr: `KeyManager plugin "hsm" is external and the plugin policy requires a plugin_checksum`,
+		},
+		{
+			name:   "checksum provided",
+			policy: PluginPolicy{RequireChecksum: true},
+			plugin: externalWithChecksum,
+		},
+		{
+			name:   "checksum not required for built-in plugins",
+			policy: PluginPolicy{RequireChecksum: true},
+			plugin: joinToken,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.plugin)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package catalog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultLivenessCheckInterval = 5 * time.Second
+	defaultMaxRestarts           = 3
+	defaultRestartBackoff        = time.Second
+)
+
+// RestartPolicy controls the liveness checks and automatic restarts of
+// external plugin processes. The zero value uses sensible defaults.
+type RestartPolicy struct {
+	// CheckInterval is how often the plugin process is checked for
+	// liveness. Defaults to 5 seconds.
+	CheckInterval time.Duration
+
+	// MaxRestarts is the maximum number of times a crashed plugin is
+	// restarted over the lifetime of the catalog. Defaults to 3. A negative
+	// value disables automatic restarts.
+	MaxRestarts int
+
+	// Backoff is the delay before the first restart attempt. The delay is
+	// doubled for each subsequent attempt. Defaults to 1 second.
+	Backoff time.Duration
+}
+
+func (p RestartPolicy) withDefaults() RestartPolicy {
+	if p.CheckInterval <= 0 {
+		p.CheckInterval = defaultLivenessCheckInterval
+	}
+	if p.MaxRestarts == 0 {
+		p.MaxRestarts = defaultMaxRestarts
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = defaultRestartBackoff
+	}
+	return p
+}
+
+// pluginSupervisor watches an external plugin process and restarts it, up to
+// the limit imposed by the restart policy, when it exits unexpectedly.
+type pluginSupervisor struct {
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+	labels  []telemetry.Label
+	policy  RestartPolicy
+	hc      *hcClientPlugin
+	plugin  *LoadedPlugin
+
+	mu     sync.Mutex
+	client *goplugin.Client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func startPluginSupervisor(ext ExternalPlugin, hc *hcClientPlugin, client *goplugin.Client, plugin *LoadedPlugin) *pluginSupervisor {
+	metrics := ext.Metrics
+	if metrics == nil {
+		metrics = telemetry.Blackhole{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &pluginSupervisor{
+		log: ext.Log.WithFields(logrus.Fields{
+			telemetry.PluginName: ext.Name,
+			telemetry.PluginType: ext.Plugin.PluginType(),
+		}),
+		metrics: metrics,
+		labels: []telemetry.Label{
+			{Name: telemetry.PluginName, Value: ext.Name},
+			{Name: telemetry.PluginType, Value: ext.Plugin.PluginType()},
+		},
+		policy: ext.RestartPolicy.withDefaults(),
+		hc:     hc,
+		plugin: plugin,
+		client: client,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go s.run(ctx)
+	return s
+}
+
+// Close stops supervising the plugin and kills the plugin process.
+func (s *pluginSupervisor) Close() {
+	s.cancel()
+	<-s.done
+	s.currentClient().Kill()
+}
+
+func (s *pluginSupervisor) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.policy.CheckInterval)
+	defer ticker.Stop()
+
+	restarts := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		client := s.currentClient()
+		if !client.Exited() {
+			continue
+		}
+
+		s.log.Error("External plugin exited unexpectedly")
+		s.metrics.IncrCounterWithLabels([]string{telemetry.PluginExternal, telemetry.Exited}, 1, s.labels)
+		// Clean up whatever is left of the previous process and connection.
+		client.Kill()
+
+		if !s.restart(ctx, &restarts) {
+			return
+		}
+	}
+}
+
+// restart attempts to restart the plugin process until it succeeds, the
+// maximum number of restarts has been reached or the context is cancelled.
+// It returns true if the plugin was restarted.
+func (s *pluginSupervisor) restart(ctx context.Context, restarts *int) bool {
+	backoff := s.policy.Backoff
+	for *restarts < s.policy.MaxRestarts {
+		*restarts++
+		log := s.log.WithField(telemetry.Attempt, *restarts)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return false
+		}
+		backoff *= 2
+
+		client, _, err := s.hc.start()
+		if err == nil {
+			// The restarted process has lost its configuration, so replay the
+			// last configuration received by the plugin.
+			if err = s.plugin.reconfigure(ctx); err != nil {
+				client.Kill()
+			}
+		}
+		if err != nil {
+			log.WithError(err).Error("Failed to restart external plugin")
+			s.metrics.IncrCounterWithLabels([]string{telemetry.PluginExternal, telemetry.Restart, telemetry.Failures}, 1, s.labels)
+			continue
+		}
+
+		s.setClient(client)
+		log.Warn("External plugin restarted")
+		s.metrics.IncrCounterWithLabels([]string{telemetry.PluginExternal, telemetry.Restart}, 1, s.labels)
+		return true
+	}
+
+	if s.policy.MaxRestarts > 0 {
+		s.log.WithField(telemetry.Attempt, *restarts).Error("External plugin will not be restarted; maximum number of restarts reached")
+	} else {
+		s.log.Error("External plugin will not be restarted; automatic restarts are disabled")
+	}
+	return false
+}
+
+func (s *pluginSupervisor) currentClient() *goplugin.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+func (s *pluginSupervisor) setClient(client *goplugin.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client = client
+}
+
+// pluginConn is a gRPC client connection that forwards calls to the
+// connection of the current plugin process. Plugin and service clients are
+// bound to it so that they keep working after the plugin is restarted.
+type pluginConn struct {
+	mu sync.RWMutex
+	cc grpc.ClientConnInterface
+}
+
+var _ grpc.ClientConnInterface = (*pluginConn)(nil)
+
+func (c *pluginConn) set(cc grpc.ClientConnInterface) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cc = cc
+}
+
+func (c *pluginConn) get() grpc.ClientConnInterface {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cc
+}
+
+func (c *pluginConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	return c.get().Invoke(ctx, method, args, reply, opts...)
+}
+
+func (c *pluginConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.get().NewStream(ctx, desc, method, opts...)
+}
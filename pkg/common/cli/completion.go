@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CompletionCommand prints a script that, once evaluated by the shell,
+// enables command and flag completion for a CLI. Completions themselves are
+// served by the CLI when it is invoked by the shell with COMP_LINE set, so the
+// CLI must have autocompletion enabled.
+type CompletionCommand struct {
+	env        *Env
+	name       string
+	executable func() (string, error)
+}
+
+// NewCompletionCommand returns a completion command for the named binary.
+func NewCompletionCommand(name string) *CompletionCommand {
+	return newCompletionCommand(DefaultEnv, name, os.Executable)
+}
+
+func newCompletionCommand(env *Env, name string, executable func() (string, error)) *CompletionCommand {
+	return &CompletionCommand{
+		env:        env,
+		name:       name,
+		executable: executable,
+	}
+}
+
+func (c *CompletionCommand) Help() string {
+	return fmt.Sprintf(`Usage: %s completion <bash|zsh>
+
+  Prints a shell script enabling completion for %s. For example:
+
+    source <(%s completion bash)
+`, c.name, c.name, c.name)
+}
+
+func (c *CompletionCommand) Synopsis() string {
+	return "Prints a shell completion script"
+}
+
+func (c *CompletionCommand) Run(args []string) int {
+	if len(args) != 1 {
+		_ = c.env.ErrPrintln(c.Help())
+		return 1
+	}
+
+	script, err := c.script(args[0])
+	if err != nil {
+		_ = c.env.ErrPrintln("Error: " + err.Error())
+		return 1
+	}
+
+	if err := c.env.Printf("%s", script); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func (c *CompletionCommand) script(shell string) (string, error) {
+	path, err := c.executable()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine executable path: %w", err)
+	}
+	path = "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+
+	switch shell {
+	case "bash":
+		return fmt.Sprintf("complete -C %s %s\n", path, c.name), nil
+	case "zsh":
+		return fmt.Sprintf("autoload -U +X bashcompinit && bashcompinit\ncomplete -o nospace -C %s %s\n", path, c.name), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q: expected bash or zsh", shell)
+	}
+}
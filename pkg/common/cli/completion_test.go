@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		args       []string
+		executable func() (string, error)
+		expCode    int
+		expOut     string
+		expErr     string
+	}{
+		{
+			name:    "bash",
+			args:    []string{"bash"},
+			expCode: 0,
+			expOut:  "complete -C '/opt/spire/bin/spire-server' spire-server\n",
+		},
+		{
+			name:    "zsh",
+			args:    []string{"zsh"},
+			expCode: 0,
+			expOut:  "autoload -U +X bashcompinit && bashcompinit\ncomplete -o nospace -C '/opt/spire/bin/spire-server' spire-server\n",
+		},
+		{
+			name: "path is quoted",
+			args: []string{"bash"},
+			executable: func() (string, error) {
+				return "/opt/it's here/spire-server", nil
+			},
+			expCode: 0,
+			expOut:  "complete -C '/opt/it'\\''s here/spire-server' spire-server\n",
+		},
+		{
+			name:    "unsupported shell",
+			args:    []string{"fish"},
+			expCode: 1,
+			expErr:  "Error: unsupported shell \"fish\": expected bash or zsh\n",
+		},
+		{
+			name: "executable path unavailable",
+			args: []string{"bash"},
+			executable: func() (string, error) {
+				return "", errors.New("oh no")
+			},
+			expCode: 1,
+			expErr:  "Error: unable to determine executable path: oh no\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			executable := tt.executable
+			if executable == nil {
+				executable = func() (string, error) {
+					return "/opt/spire/bin/spire-server", nil
+				}
+			}
+
+			cmd := newCompletionCommand(&Env{Stdout: stdout, Stderr: stderr}, "spire-server", executable)
+			require.Equal(t, tt.expCode, cmd.Run(tt.args))
+			require.Equal(t, tt.expOut, stdout.String())
+			require.Equal(t, tt.expErr, stderr.String())
+		})
+	}
+}
+
+func TestCompletionCommandRequiresShell(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	cmd := newCompletionCommand(&Env{Stdout: new(bytes.Buffer), Stderr: stderr}, "spire-agent", nil)
+	require.Equal(t, 1, cmd.Run(nil))
+	require.Contains(t, stderr.String(), "Usage: spire-agent completion <bash|zsh>")
+}
@@ -0,0 +1,9 @@
+package cryptoutil
+
+import "syscall"
+
+// LockMemory locks all current and future pages of the process in memory so
+// that private key material is never written to swap.
+func LockMemory() error {
+	return syscall.Mlockall(syscall.MCL_CURRENT | syscall.MCL_FUTURE)
+}
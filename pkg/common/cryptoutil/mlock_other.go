@@ -0,0 +1,10 @@
+// +build !linux
+
+package cryptoutil
+
+import "errors"
+
+// LockMemory is not supported on this platform.
+func LockMemory() error {
+	return errors.New("memory locking is only supported on Linux")
+}
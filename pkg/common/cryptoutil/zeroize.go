@@ -0,0 +1,26 @@
+package cryptoutil
+
+import (
+	"crypto/ecdsa"
+)
+
+// ZeroBytes overwrites b with zeros. It is used to scrub buffers holding
+// private key material once they are no longer needed.
+func ZeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// ZeroECDSAPrivateKey overwrites the private scalar of key with zeros. The key
+// must not be used after it has been zeroed.
+func ZeroECDSAPrivateKey(key *ecdsa.PrivateKey) {
+	if key == nil || key.D == nil {
+		return
+	}
+	words := key.D.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	key.D.SetInt64(0)
+}
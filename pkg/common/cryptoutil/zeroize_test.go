@@ -0,0 +1,37 @@
+package cryptoutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroBytes(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	ZeroBytes(b)
+	require.Equal(t, []byte{0, 0, 0, 0}, b)
+
+	// Must not panic on empty buffers
+	ZeroBytes(nil)
+}
+
+func TestZeroECDSAPrivateKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	words := key.D.Bits()
+	ZeroECDSAPrivateKey(key)
+
+	require.Equal(t, 0, key.D.Cmp(big.NewInt(0)))
+	for _, word := range words {
+		require.Zero(t, word)
+	}
+
+	// Must not panic on nil keys
+	ZeroECDSAPrivateKey(nil)
+	ZeroECDSAPrivateKey(&ecdsa.PrivateKey{})
+}
@@ -266,6 +266,23 @@ func (s *TokenSuite) TestValidateExpiredToken() {
 	s.Require().Nil(claims)
 }
 
+func (s *TokenSuite) TestValidateTokenIssuedInTheFuture() {
+	issuedAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	token := s.signToken(jose.ES256, jose.JSONWebKey{Key: ec256Key, KeyID: "ec256Key"}, jwt.Claims{
+		Subject:  fakeSpiffeID,
+		Audience: fakeAudience,
+		IssuedAt: jwt.NewNumericDate(issuedAt),
+		Expiry:   jwt.NewNumericDate(issuedAt.Add(time.Hour)),
+	})
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience[0:1])
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "token is not valid yet (issued at "+issuedAt.UTC().Format(time.RFC3339)+", not before unset, current time ")
+	s.Require().Contains(err.Error(), "the clock of the token issuer or of this host may be skewed")
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+}
+
 func (s *TokenSuite) TestValidateNoSubject() {
 	token := s.signToken(jose.ES256, jose.JSONWebKey{Key: ec256Key, KeyID: "ec256Key"}, jwt.Claims{
 		Audience: []string{"audience"},
@@ -95,14 +95,20 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 
 	// Now that the signature over the claims has been verified, validate the
 	// standard claims.
+	now := time.Now()
 	if err := claims.Validate(jwt.Expected{
 		Audience: audience,
-		Time:     time.Now(),
+		Time:     now,
 	}); err != nil {
 		// Convert expected validation errors for pretty errors
 		switch err {
 		case jwt.ErrExpired:
 			err = errs.New("token has expired")
+		case jwt.ErrNotValidYet, jwt.ErrIssuedInTheFuture:
+			// Tokens are only valid this far in the future when the clocks of
+			// the issuer and of the validator are skewed.
+			err = errs.New("token is not valid yet (issued at %s, not before %s, current time %s); the clock of the token issuer or of this host may be skewed",
+				formatNumericDate(claims.IssuedAt), formatNumericDate(claims.NotBefore), now.UTC().Format(time.RFC3339))
 		case jwt.ErrInvalidAudience:
 			err = errs.New("expected audience in %q (audience=%q)", audience, claims.Audience)
 		default:
@@ -113,3 +119,10 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 
 	return spiffeID.String(), claimsMap, nil
 }
+
+func formatNumericDate(date *jwt.NumericDate) string {
+	if date == nil {
+		return "unset"
+	}
+	return date.Time().UTC().Format(time.RFC3339)
+}
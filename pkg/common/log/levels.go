@@ -0,0 +1,131 @@
+package log
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Levels gives each subsystem (e.g. "ca", or a plugin) its own logger, so
+// that the subsystems can log at different levels. The subsystem loggers
+// share the output, formatter and hooks of the base logger, and log at the
+// level of the base logger unless a level was set for the subsystem.
+type Levels struct {
+	base *logrus.Logger
+
+	mtx     sync.Mutex
+	loggers map[string]*logrus.Logger
+	levels  map[string]logrus.Level
+}
+
+// NewLevels returns the levels of the subsystems logging through the base
+// logger. The subsystem loggers write to the output of the base logger
+// concurrently with it, so the output must be safe for concurrent use, as
+// the files the loggers of this package write to are.
+func NewLevels(base *logrus.Logger) *Levels {
+	return &Levels{
+		base:    base,
+		loggers: make(map[string]*logrus.Logger),
+		levels:  make(map[string]logrus.Level),
+	}
+}
+
+// Logger returns the logger of the named subsystem.
+func (l *Levels) Logger(subsystem string) *logrus.Logger {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if logger, ok := l.loggers[subsystem]; ok {
+		return logger
+	}
+
+	logger := &logrus.Logger{
+		Out:          l.base.Out,
+		Formatter:    l.base.Formatter,
+		Hooks:        l.base.Hooks,
+		ReportCaller: l.base.ReportCaller,
+		ExitFunc:     l.base.ExitFunc,
+		Level:        l.levelOf(subsystem),
+	}
+	l.loggers[subsystem] = logger
+	return logger
+}
+
+// BaseLevel returns the level of the base logger.
+func (l *Levels) BaseLevel() logrus.Level {
+	return l.base.GetLevel()
+}
+
+// SetBaseLevel sets the level of the base logger, which is also the level
+// of the subsystems that have no level of their own.
+func (l *Levels) SetBaseLevel(level logrus.Level) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.base.SetLevel(level)
+	for subsystem, logger := range l.loggers {
+		if _, ok := l.levels[subsystem]; !ok {
+			logger.SetLevel(level)
+		}
+	}
+}
+
+// SetLevel sets the level of the named subsystem.
+func (l *Levels) SetLevel(subsystem string, level logrus.Level) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.levels[subsystem] = level
+	if logger, ok := l.loggers[subsystem]; ok {
+		logger.SetLevel(level)
+	}
+}
+
+// ResetLevel makes the named subsystem log at the level of the base logger
+// again.
+func (l *Levels) ResetLevel(subsystem string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	delete(l.levels, subsystem)
+	if logger, ok := l.loggers[subsystem]; ok {
+		logger.SetLevel(l.base.GetLevel())
+	}
+}
+
+// SubsystemLevels returns the level of every subsystem that has a logger or
+// a level of its own.
+func (l *Levels) SubsystemLevels() map[string]logrus.Level {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	levels := make(map[string]logrus.Level, len(l.loggers))
+	for subsystem := range l.loggers {
+		levels[subsystem] = l.levelOf(subsystem)
+	}
+	for subsystem, level := range l.levels {
+		levels[subsystem] = level
+	}
+	return levels
+}
+
+// Subsystems returns the sorted names of the subsystems that have a logger.
+func (l *Levels) Subsystems() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	subsystems := make([]string, 0, len(l.loggers))
+	for subsystem := range l.loggers {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	return subsystems
+}
+
+func (l *Levels) levelOf(subsystem string) logrus.Level {
+	if level, ok := l.levels[subsystem]; ok {
+		return level
+	}
+	return l.base.GetLevel()
+}
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels(t *testing.T) {
+	out := new(bytes.Buffer)
+	logger, err := NewLogger(WithLevel("info"), WithFormat(TextFormat))
+	require.NoError(t, err)
+	logger.SetOutput(out)
+
+	levels := NewLevels(logger.Logger)
+	levels.SetLevel("ca", logrus.DebugLevel)
+
+	ca := levels.Logger("ca")
+	endpoints := levels.Logger("endpoints")
+	require.Same(t, ca, levels.Logger("ca"))
+
+	// Subsystems log at their own level, or at the level of the base logger
+	ca.Debug("ca debug")
+	endpoints.Debug("endpoints debug")
+	endpoints.Info("endpoints info")
+	logger.Debug("base debug")
+	assert.Contains(t, out.String(), "ca debug")
+	assert.NotContains(t, out.String(), "endpoints debug")
+	assert.Contains(t, out.String(), "endpoints info")
+	assert.NotContains(t, out.String(), "base debug")
+
+	assert.Equal(t, []string{"ca", "endpoints"}, levels.Subsystems())
+	assert.Equal(t, map[string]logrus.Level{
+		"ca":        logrus.DebugLevel,
+		"endpoints": logrus.InfoLevel,
+	}, levels.SubsystemLevels())
+
+	// Changing the base level only affects the subsystems without a level
+	// of their own
+	levels.SetBaseLevel(logrus.WarnLevel)
+	assert.Equal(t, logrus.WarnLevel, levels.BaseLevel())
+	assert.Equal(t, logrus.WarnLevel, logger.GetLevel())
+	assert.Equal(t, logrus.DebugLevel, ca.GetLevel())
+	assert.Equal(t, logrus.WarnLevel, endpoints.GetLevel())
+
+	// Levels can be set before the subsystem logger is created
+	levels.SetLevel("datastore", logrus.ErrorLevel)
+	assert.Equal(t, logrus.ErrorLevel, levels.SubsystemLevels()["datastore"])
+	assert.Equal(t, logrus.ErrorLevel, levels.Logger("datastore").GetLevel())
+
+	// Resetting the level of a subsystem makes it follow the base level
+	levels.ResetLevel("ca")
+	assert.Equal(t, logrus.WarnLevel, ca.GetLevel())
+	levels.SetBaseLevel(logrus.InfoLevel)
+	assert.Equal(t, logrus.InfoLevel, ca.GetLevel())
+}
@@ -0,0 +1,110 @@
+package peertracker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+)
+
+// ErrListenerClosed is returned when accepting or dialing connections on a
+// closed PipeListener.
+var ErrListenerClosed = errors.New("listener closed")
+
+var _ net.Listener = &PipeListener{}
+
+// PipeListener is an in-memory net.Listener for callers that live in the
+// current process. Connections are established with DialContext instead of
+// a socket. Since the caller is always the current process, the accepted
+// connections carry the caller information of the current process, and the
+// caller is always alive.
+type PipeListener struct {
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPipeListener creates a new in-memory listener
+func NewPipeListener() *PipeListener {
+	return &PipeListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *PipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		pid := int32(os.Getpid())
+		return &Conn{
+			Conn: conn,
+			Info: AuthInfo{
+				Caller: CallerInfo{
+					Addr: conn.RemoteAddr(),
+					PID:  pid,
+					UID:  uint32(os.Getuid()),
+					GID:  uint32(os.Getgid()),
+				},
+				Watcher: selfWatcher{pid: pid},
+			},
+		}, nil
+	case <-l.closed:
+		return nil, ErrListenerClosed
+	}
+}
+
+func (l *PipeListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *PipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+// DialContext connects to the listener. The address is ignored, which makes
+// it suitable for use with grpc.WithContextDialer.
+func (l *PipeListener) DialContext(ctx context.Context, _ string) (net.Conn, error) {
+	serverConn, clientConn := net.Pipe()
+	select {
+	case l.conns <- serverConn:
+		return clientConn, nil
+	case <-l.closed:
+		serverConn.Close()
+		clientConn.Close()
+		return nil, ErrListenerClosed
+	case <-ctx.Done():
+		serverConn.Close()
+		clientConn.Close()
+		return nil, ctx.Err()
+	}
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string {
+	return "pipe"
+}
+
+func (pipeAddr) String() string {
+	return "pipe"
+}
+
+// selfWatcher watches the current process, which is alive for as long as
+// anyone can ask.
+type selfWatcher struct {
+	pid int32
+}
+
+func (w selfWatcher) Close() {}
+
+func (w selfWatcher) IsAlive() error {
+	return nil
+}
+
+func (w selfWatcher) PID() int32 {
+	return w.pid
+}
@@ -0,0 +1,71 @@
+package peertracker
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeListener(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	l := NewPipeListener()
+	require.Equal(t, "pipe", l.Addr().Network())
+
+	acceptCh := make(chan *Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(acceptCh)
+			return
+		}
+		acceptCh <- conn.(*Conn)
+	}()
+
+	clientConn, err := l.DialContext(ctx, "ignored")
+	require.NoError(t, err)
+	defer clientConn.Close()
+
+	serverConn := <-acceptCh
+	require.NotNil(t, serverConn)
+	defer serverConn.Close()
+
+	// The caller is the current process, which is alive
+	require.Equal(t, int32(os.Getpid()), serverConn.Info.Caller.PID)
+	require.Equal(t, uint32(os.Getuid()), serverConn.Info.Caller.UID)
+	require.Equal(t, uint32(os.Getgid()), serverConn.Info.Caller.GID)
+	require.Equal(t, int32(os.Getpid()), serverConn.Info.Watcher.PID())
+	require.NoError(t, serverConn.Info.Watcher.IsAlive())
+
+	// Data flows between both ends
+	go func() {
+		_, _ = clientConn.Write([]byte("hello"))
+	}()
+	buf := make([]byte, 5)
+	_, err = serverConn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf))
+
+	// Nothing can be accepted or dialed once closed
+	require.NoError(t, l.Close())
+	require.NoError(t, l.Close())
+	_, err = l.Accept()
+	require.Equal(t, ErrListenerClosed, err)
+	_, err = l.DialContext(ctx, "ignored")
+	require.Equal(t, ErrListenerClosed, err)
+}
+
+func TestPipeListenerDialHonorsContext(t *testing.T) {
+	l := NewPipeListener()
+	defer l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := l.DialContext(ctx, "ignored")
+	require.Equal(t, context.Canceled, err)
+}
@@ -26,16 +26,22 @@ func TestAllTrueMasks(t *testing.T) {
 	}, protoutil.AllTrueBundleMask)
 
 	assert.Equal(t, &types.EntryMask{
-		SpiffeId:       true,
-		ParentId:       true,
-		Selectors:      true,
-		Ttl:            true,
-		FederatesWith:  true,
-		Admin:          true,
-		Downstream:     true,
-		ExpiresAt:      true,
-		DnsNames:       true,
-		RevisionNumber: true,
+		SpiffeId:            true,
+		ParentId:            true,
+		Selectors:           true,
+		Ttl:                 true,
+		FederatesWith:       true,
+		Admin:               true,
+		Downstream:          true,
+		ExpiresAt:           true,
+		DnsNames:            true,
+		RevisionNumber:      true,
+		X509SvidPrimaryName: true,
+		X509SvidKeyType:     true,
+		CreatedBy:           true,
+		CreatedAt:           true,
+		UpdatedBy:           true,
+		UpdatedAt:           true,
 	}, protoutil.AllTrueEntryMask)
 
 	assert.Equal(t, &common.BundleMask{
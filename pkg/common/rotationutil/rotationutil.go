@@ -13,6 +13,13 @@ func ShouldRotateX509(now time.Time, cert *x509.Certificate) bool {
 	return shouldRotate(now, cert.NotBefore, cert.NotAfter)
 }
 
+// X509RotationDeadline returns the time from which ShouldRotateX509 reports
+// that the given X509 cert should be rotated.
+func X509RotationDeadline(cert *x509.Certificate) time.Time {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotAfter.Add(-lifetime / 2)
+}
+
 // X509Expired returns true if the given X509 cert has expired
 func X509Expired(now time.Time, cert *x509.Certificate) bool {
 	return now.After(cert.NotAfter)
@@ -31,6 +31,21 @@ func TestShouldRotateX509(t *testing.T) {
 	assert.True(t, ShouldRotateX509(mockClk.Now(), badCert))
 }
 
+func TestX509RotationDeadline(t *testing.T) {
+	mockClk := clock.NewMock(t)
+	temp, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	temp.NotBefore = mockClk.Now()
+	temp.NotAfter = mockClk.Now().Add(time.Hour)
+	cert, _, err := util.SelfSign(temp)
+	require.NoError(t, err)
+
+	deadline := X509RotationDeadline(cert)
+	assert.Equal(t, cert.NotBefore.Add(30*time.Minute), deadline)
+	assert.False(t, ShouldRotateX509(deadline.Add(-time.Second), cert))
+	assert.True(t, ShouldRotateX509(deadline, cert))
+}
+
 func TestX509Expired(t *testing.T) {
 	// Cert that's valid for 1hr
 	mockClk := clock.NewMock(t)
@@ -0,0 +1,75 @@
+package selector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Masked replaces the masked part of redacted selector values
+const Masked = "[REDACTED]"
+
+// Redactor redacts sensitive selector values before they are logged. Each
+// rule is a prefix of the "type:value" representation of the selectors it
+// applies to (e.g. "unix:path:" or "docker:label:secret"). The part of the
+// selector after the prefix is either masked, or replaced by a truncated
+// SHA-256 hash so that the same value can still be correlated across log
+// lines. Selectors are only redacted for display; matching always uses the
+// original values. A nil Redactor redacts nothing.
+type Redactor struct {
+	mask []string
+	hash []string
+}
+
+// NewRedactor returns a redactor that masks the selectors matching the mask
+// prefixes and hashes the selectors matching the hash prefixes. Masking
+// takes precedence when both match.
+func NewRedactor(mask, hash []string) (*Redactor, error) {
+	for _, prefix := range append(append([]string(nil), mask...), hash...) {
+		if !strings.Contains(prefix, Delimiter) || strings.HasPrefix(prefix, Delimiter) {
+			return nil, fmt.Errorf("redaction prefix %q must start with a selector type followed by %q", prefix, Delimiter)
+		}
+	}
+	return &Redactor{
+		mask: mask,
+		hash: hash,
+	}, nil
+}
+
+// Redact returns the "type:value" representation of the selector, redacted
+func (r *Redactor) Redact(s *common.Selector) string {
+	str := s.Type + Delimiter + s.Value
+	if r == nil {
+		return str
+	}
+	if prefix, ok := matchPrefix(r.mask, str); ok {
+		return prefix + Masked
+	}
+	if prefix, ok := matchPrefix(r.hash, str); ok {
+		sum := sha256.Sum256([]byte(str[len(prefix):]))
+		return prefix + "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return str
+}
+
+// RedactAll returns the redacted "type:value" representation of each
+// selector
+func (r *Redactor) RedactAll(selectors []*common.Selector) []string {
+	strs := make([]string, 0, len(selectors))
+	for _, s := range selectors {
+		strs = append(strs, r.Redact(s))
+	}
+	return strs
+}
+
+func matchPrefix(prefixes []string, str string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(str, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
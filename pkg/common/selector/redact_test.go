@@ -0,0 +1,56 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor(t *testing.T) {
+	r, err := NewRedactor([]string{"docker:label:secret", "unix:path:/usr/bin/"}, []string{"unix:path:"})
+	require.NoError(t, err)
+
+	selectors := []*common.Selector{
+		{Type: "unix", Value: "uid:1000"},
+		{Type: "unix", Value: "path:/usr/bin/app"},
+		{Type: "unix", Value: "path:/opt/app"},
+		{Type: "docker", Value: "label:secret_token:s3cr3t"},
+		{Type: "docker", Value: "label:app:web"},
+	}
+	assert.Equal(t, []string{
+		"unix:uid:1000",
+		"unix:path:/usr/bin/[REDACTED]",
+		"unix:path:sha256:b6aec2d3ff5cc88a",
+		"docker:label:secret[REDACTED]",
+		"docker:label:app:web",
+	}, r.RedactAll(selectors))
+
+	// Hashing is deterministic, so values can be correlated
+	hashed := r.Redact(&common.Selector{Type: "unix", Value: "path:/opt/app"})
+	assert.Regexp(t, `^unix:path:sha256:[0-9a-f]{16}$`, hashed)
+	assert.Equal(t, hashed, r.Redact(&common.Selector{Type: "unix", Value: "path:/opt/app"}))
+	assert.NotEqual(t, hashed, r.Redact(&common.Selector{Type: "unix", Value: "path:/opt/other"}))
+
+	// The selectors themselves are left untouched
+	assert.Equal(t, "path:/usr/bin/app", selectors[1].Value)
+}
+
+func TestRedactorHash(t *testing.T) {
+	r, err := NewRedactor(nil, []string{"unix:path:"})
+	require.NoError(t, err)
+	assert.Equal(t, "unix:path:sha256:c088972b8fb0ed47", r.Redact(&common.Selector{Type: "unix", Value: "path:/usr/bin/app"}))
+}
+
+func TestNilRedactor(t *testing.T) {
+	var r *Redactor
+	assert.Equal(t, []string{"unix:uid:1000"}, r.RedactAll([]*common.Selector{{Type: "unix", Value: "uid:1000"}}))
+}
+
+func TestNewRedactorValidatesPrefixes(t *testing.T) {
+	_, err := NewRedactor([]string{"unix"}, nil)
+	require.EqualError(t, err, `redaction prefix "unix" must start with a selector type followed by ":"`)
+	_, err = NewRedactor(nil, []string{":path"})
+	require.EqualError(t, err, `redaction prefix ":path" must start with a selector type followed by ":"`)
+}
@@ -0,0 +1,23 @@
+package agent
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Counters (literal increments, not call counters)
+
+// IncrServerCircuitBreakerRejectCounter indicates that a call to the server
+// was rejected because the circuit breaker is open
+func IncrServerCircuitBreakerRejectCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.ServerCircuitBreaker, telemetry.Reject}, 1)
+}
+
+// End Counters
+
+// Gauge (remember previous value set)
+
+// SetServerCircuitBreakerStateGauge sets the state of the circuit breaker
+// around the calls to the server (0 closed, 1 half-open, 2 open)
+func SetServerCircuitBreakerStateGauge(m telemetry.Metrics, state int) {
+	m.SetGauge([]string{telemetry.ServerCircuitBreaker, telemetry.State}, float32(state))
+}
+
+// End Gauge
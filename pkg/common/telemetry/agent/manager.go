@@ -14,6 +14,13 @@ func StartManagerFetchEntriesUpdatesCall(m telemetry.Metrics) *telemetry.CallCou
 	return telemetry.StartCall(m, telemetry.Manager, telemetry.Sync, telemetry.FetchEntriesUpdates)
 }
 
+// StartManagerFetchBundlesUpdatesCall returns metric for when agent's
+// synchronization manager fetching latest bundles information
+// from server
+func StartManagerFetchBundlesUpdatesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Manager, telemetry.Sync, telemetry.FetchBundlesUpdates)
+}
+
 // StartManagerFetchSVIDsUpdatesCall returns metric for when agent's
 // synchronization manager fetching latest SVIDs information
 // from server
@@ -14,3 +14,14 @@ func StartRotateAgentSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 }
 
 // End Call Counters
+
+// Gauge (remember previous value set)
+
+// SetAgentSVIDRotationDeadlineGauge sets the number of seconds left until
+// the Agent's SVID is due for rotation. The value is negative when the
+// rotation is overdue.
+func SetAgentSVIDRotationDeadlineGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.AgentSVID, telemetry.Rotate, telemetry.Deadline}, val)
+}
+
+// End Gauge
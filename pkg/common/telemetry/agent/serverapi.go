@@ -0,0 +1,18 @@
+package agent
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Gauge (remember previous value set)
+
+// SetServerLegacyAPIGauge sets whether the agent is using the deprecated
+// node API (1) because the server does not implement the v1 APIs, or the v1
+// APIs (0)
+func SetServerLegacyAPIGauge(m telemetry.Metrics, legacy bool) {
+	var val float32
+	if legacy {
+		val = 1
+	}
+	m.SetGauge([]string{telemetry.ServerAPI, telemetry.Legacy}, val)
+}
+
+// End Gauge
@@ -32,11 +32,48 @@ func IncrConnectionCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.Connection}, 1)
 }
 
+// IncrAttestorFailureCounter indicate a failure of a specific workload
+// attestor during an agent Workload Attest call (running total count)
+func IncrAttestorFailureCounter(m telemetry.Metrics, aType string) {
+	m.IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.WorkloadAttestor, telemetry.Failures}, 1, []telemetry.Label{
+		{Name: telemetry.Attestor, Value: aType},
+	})
+}
+
 // SetConnectionTotalGauge sets the number of active Workload API connections
 func SetConnectionTotalGauge(m telemetry.Metrics, connections int32) {
 	m.SetGauge([]string{telemetry.WorkloadAPI, telemetry.Connections}, float32(connections))
 }
 
+// IncrAttestationQueuedCounter indicates a workload attestation had to wait
+// for the concurrent attestation limit (running total count)
+func IncrAttestationQueuedCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.WorkloadAttestation, telemetry.Queued}, 1)
+}
+
+// IncrAttestationShedCounter indicates a workload attestation was rejected
+// because the concurrent attestation limit was reached (running total count)
+func IncrAttestationShedCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.WorkloadAttestation, telemetry.Shed}, 1)
+}
+
+// IncrStreamShedCounter indicates a stream was rejected because the
+// concurrent stream limit was reached (running total count)
+func IncrStreamShedCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.Streams, telemetry.Shed}, 1)
+}
+
+// SetStreamTotalGauge sets the number of open Workload API and SDS streams
+func SetStreamTotalGauge(m telemetry.Metrics, streams int32) {
+	m.SetGauge([]string{telemetry.WorkloadAPI, telemetry.Streams}, float32(streams))
+}
+
+// AddAuditDroppedEventsCounter counts Workload API audit events that were
+// dropped because of the audit log rate limit
+func AddAuditDroppedEventsCounter(m telemetry.Metrics, count int) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.Audit, telemetry.DroppedEvents}, float32(count))
+}
+
 // End Counters
 
 // Add Samples (metric on count of some object, entries, event...)
@@ -47,4 +84,12 @@ func AddDiscoveredSelectorsSample(m telemetry.Metrics, count float32) {
 	m.AddSample([]string{telemetry.WorkloadAPI, telemetry.DiscoveredSelectors}, count)
 }
 
+// AddAttestorSelectorsSample count of selectors discovered by a specific
+// workload attestor during an agent Workload Attest call
+func AddAttestorSelectorsSample(m telemetry.Metrics, aType string, count float32) {
+	m.AddSampleWithLabels([]string{telemetry.WorkloadAPI, telemetry.WorkloadAttestor, telemetry.DiscoveredSelectors}, count, []telemetry.Label{
+		{Name: telemetry.Attestor, Value: aType},
+	})
+}
+
 // End Add Samples
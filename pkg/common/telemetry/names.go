@@ -22,6 +22,14 @@ const (
 	// to add clarity
 	Attest = "attest"
 
+	// Batch functionality related to applying a batch of operations on some
+	// entities; should be used with other tags to add clarity
+	Batch = "batch"
+
+	// Check functionality related to checking some entity; should be used
+	// with other tags to add clarity
+	Check = "check"
+
 	// Create functionality related to creating some entity; should be used with other tags
 	// to add clarity
 	Create = "create"
@@ -58,10 +66,18 @@ const (
 	// (server)
 	GetPublicKeys = "get_public_keys"
 
+	// Legacy functionality related to a deprecated version of some
+	// interface; should be used with other tags to add clarity
+	Legacy = "legacy"
+
 	// List functionality related to listing some objects; should be used
 	// with other tags to add clarity
 	List = "list"
 
+	// Poll functionality related to periodically polling some entity for
+	// changes; should be used with other tags to add clarity
+	Poll = "poll"
+
 	// Prepare functionality related to preparation of some entity; should be used with other tags
 	// to add clarity
 	Prepare = "prepare"
@@ -75,9 +91,22 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// Reconcile functionality related to reconciling some entity(ies) with
+	// an external source of truth; should be used with other tags to add
+	// clarity
+	Reconcile = "reconcile"
+
+	// Reject functionality related to rejecting some element (such as a call
+	// that is not attempted); should be used with other tags to add clarity
+	Reject = "reject"
+
 	// Reload functionality related to reloading of a cache
 	Reload = "reload"
 
+	// Restart functionality related to restarting some process (such as an
+	// external plugin); should be used with other tags to add clarity
+	Restart = "restart"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -106,6 +135,10 @@ const (
 	// with other tags to add clarity
 	Update = "update"
 
+	// Use functionality related to consuming some single-use entity; should
+	// be used with other tags to add clarity
+	Use = "use"
+
 	// Mint functionality related to minting identities
 	Mint = "mint"
 )
@@ -113,25 +146,47 @@ const (
 // Attribute metric tags or labels that are typically an attribute of a
 // larger entity or logic path
 const (
+	// Added tags some entity as added; should be used with other tags to add
+	// clarity
+	Added = "added"
+
 	// Address tags some network address
 	Address = "address"
 
 	// Agent SPIFFE ID
 	AgentID = "agent_id"
 
+	// AgentCaller tags whether the caller of an API is an agent
+	AgentCaller = "agent_caller"
+
 	// Attempt tags some count of attempts
 	Attempt = "attempt"
 
 	// Audience tags some audience for a token
 	Audience = "audience"
 
+	// AuthorityType tags the type of a bundle authority (x509 or jwt)
+	AuthorityType = "authority_type"
+
+	// CallerClientVersion tags the name and version of the SPIRE component or
+	// tool calling an API (e.g. spire-agent/1.0.0)
+	CallerClientVersion = "caller_client_version"
+
 	// CallerID tags an API caller; should be used with other tags
 	// to add clarity
 	CallerID = "caller_id"
 
+	// CallerUserAgent tags the gRPC user agent of an API caller, which
+	// includes the version of the client library
+	CallerUserAgent = "caller_user_agent"
+
 	// CGroupPath tags a linux CGroup path, most likely for use in attestation
 	CGroupPath = "cgroup_path"
 
+	// ClockSkew tags the difference between the clock of a caller and the
+	// local clock
+	ClockSkew = "clock_skew"
+
 	// Connection functionality related to some connection; should be used with other tags
 	// to add clarity
 	Connection = "connection"
@@ -152,19 +207,43 @@ const (
 	// DatabaseType labels a database type (MySQL, postgres...)
 	DatabaseType = "db_type"
 
+	// Deadline tags a deadline for some action; should be used with other
+	// tags to add clarity
+	Deadline = "deadline"
+
+	// Decision tags the outcome of an authorization decision (e.g. allowed
+	// or denied)
+	Decision = "decision"
+
+	// Description tags a human readable description of some entity, such as
+	// a datastore integrity issue
+	Description = "description"
+
 	// DiscoveredSelectors tags selectors for some registration
 	DiscoveredSelectors = "discovered_selectors"
 
 	// DNS name is a name which is resolvable with DNS
 	DNSName = "dns_name"
 
+	// DroppedEvents tags a count of events that were dropped, e.g. because
+	// of rate limiting
+	DroppedEvents = "dropped_events"
+
 	// ElapsedTime tags some duration of time.
 	ElapsedTime = "elapsed_time"
 
+	// Endpoint tags the name of some endpoint (such as a datastore read
+	// replica)
+	Endpoint = "endpoint"
+
 	// Error tag for some error that occurred. Limited usage, such as logging errors at
 	// non-error level.
 	Error = "error"
 
+	// Exited tags a process (such as an external plugin) that exited
+	// unexpectedly
+	Exited = "exited"
+
 	// Expect tags an expected value, as opposed to the one received. Message should clarify
 	// what kind of value was expected, and a different field should show the received value
 	Expect = "expect"
@@ -172,10 +251,22 @@ const (
 	// Expiration tags an expiration time for some entity
 	Expiration = "expiration"
 
+	// Expiring tags some entity as about to expire; should be used with
+	// other tags to add clarity
+	Expiring = "expiring"
+
 	// ExpiryCheckDuration tags duration for an expiry check; should be used with other tags
 	// to add clarity
 	ExpiryCheckDuration = "expiry_check_duration"
 
+	// Experimental tags the comma-separated list of enabled experimental
+	// features
+	Experimental = "experimental"
+
+	// Failures tags some count of failures; should be used with other tags
+	// to add clarity
+	Failures = "failures"
+
 	// FederatedAdded labels some count of federated bundles that have been added to an entity
 	FederatedAdded = "fed_add"
 
@@ -185,6 +276,9 @@ const (
 	// Generation represents an objection generation (i.e. version)
 	Generation = "generation"
 
+	// GoVersion tags the version of Go used to build the binary
+	GoVersion = "go_version"
+
 	// IDType tags some type of ID (eg. registration ID, SPIFFE ID...)
 	IDType = "id_type"
 
@@ -201,6 +295,12 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// Kind tags the kind of some entity, such as a datastore integrity issue
+	Kind = "kind"
+
+	// LogLevel tags a log level
+	LogLevel = "log_level"
+
 	// NewSerialNumber tags a certificate new serial number
 	NewSerialNumber = "new_serial_num"
 
@@ -210,6 +310,12 @@ const (
 	// Nonce tags some nonce for communication
 	Nonce = "nonce"
 
+	// Operation tags the name of an operation, such as a datastore call
+	Operation = "operation"
+
+	// Panic tags the value recovered from a panic
+	Panic = "panic"
+
 	// ParentID tags parent ID for an entry
 	ParentID = "parent_id"
 
@@ -245,12 +351,25 @@ const (
 	// Pruned flagging something has been pruned
 	Pruned = "pruned"
 
+	// Queued flags something that had to wait for capacity before being
+	// processed
+	Queued = "queued"
+
 	// Reason is the reason for something
 	Reason = "reason"
 
+	// RefreshHint tags a bundle refresh hint, in seconds
+	RefreshHint = "refresh_hint"
+
+	// Region tags some region (such as the region of a datastore endpoint)
+	Region = "region"
+
 	// RegistrationID tags some registration entry ID
 	RegistrationID = "entry_id"
 
+	// RegistrationIDs tags a list of registration entry IDs
+	RegistrationIDs = "entry_ids"
+
 	// Registered flags whether some entity is registered or not; should be
 	// either true or false
 	Registered = "registered"
@@ -258,12 +377,27 @@ const (
 	// RegistrationEntry tags a registration entry
 	RegistrationEntry = "registration_entry"
 
+	// Removed tags some entity as removed; should be used with other tags to
+	// add clarity
+	Removed = "removed"
+
+	// Renewals tags the number of renewals a key was reused for
+	Renewals = "renewals"
+
 	// ResourceNames tags some group of resources by name
 	ResourceNames = "resource_names"
 
 	// RetryInterval tags some interval for retry logic
 	RetryInterval = "retry_interval"
 
+	// Revoked tags some count of revoked entities; should be used with other
+	// tags to add clarity
+	Revoked = "revoked"
+
+	// Scheduled tags some count of entities scheduled for later processing;
+	// should be used with other tags to add clarity
+	Scheduled = "scheduled"
+
 	// Schema tags database schema version
 	Schema = "schema"
 
@@ -286,25 +420,61 @@ const (
 	// SelfSigned tags whether or not some entity is self-signed
 	SelfSigned = "self_signed"
 
+	// Shed flags something that has been rejected to protect the process
+	// from overload
+	Shed = "shed"
+
 	// SendJWTBundleLatency tags latency for sending JWT bundle
 	SendJWTBundleLatency = "send_jwt_bundle_latency"
 
 	// SerialNumber tags a certificate serial number
 	SerialNumber = "serial_num"
 
+	// SerialNumbers tags a list of certificate serial numbers
+	SerialNumbers = "serial_nums"
+
+	// Served tags something served to a caller, such as a bundle
+	Served = "served"
+
+	// Size tags the size, in bytes, of some serialized entity
+	Size = "size"
+
 	// Slot X509 CA Slot ID
 	Slot = "slot"
 
 	// SPIFFEID tags a SPIFFE ID
 	SPIFFEID = "spiffe_id"
 
+	// SPIFFEIDs tags a list of SPIFFE IDs
+	SPIFFEIDs = "spiffe_ids"
+
+	// StackTrace tags the stack trace of a goroutine, such as one that panicked
+	StackTrace = "stack_trace"
+
+	// State tags the state of some element (such as a circuit breaker)
+	State = "state"
+
 	// Status tags status of call (OK, or some error), or status of some process
 	Status = "status"
 
+	// Streams functionality related to some group of streams; should be used
+	// with other tags to add clarity
+	Streams = "streams"
+
 	// Subject tags some subject (likely a SPIFFE ID, and likely for a token); should be used
 	// with other tags to add clarity
 	Subject = "subject"
 
+	// SubjectKeyID tags the subject key ID of a certificate
+	SubjectKeyID = "subject_key_id"
+
+	// Subsystem tags the name of a server subsystem or plugin whose log
+	// level is being adjusted
+	Subsystem = "subsystem"
+
+	// SyncInterval tags the interval between synchronizations
+	SyncInterval = "sync_interval"
+
 	// SVIDResponseLatency tags latency for SVID response
 	SVIDResponseLatency = "svid_response_latency"
 
@@ -324,6 +494,10 @@ const (
 	// TrustDomainID tags some trust domain ID
 	TrustDomainID = "trust_domain_id"
 
+	// Unavailable tags some entity as temporarily unavailable; should be
+	// used with other tags to add clarity
+	Unavailable = "unavailable"
+
 	// Unknown tags some unknown caller, entity, or status
 	Unknown = "unknown"
 
@@ -354,13 +528,28 @@ const (
 	// AgentSVID tag a node (agent) SVID
 	AgentSVID = "agent_svid"
 
+	// Age tags the time elapsed since some entity was last refreshed
+	Age = "age"
+
 	// Attestor tags an attestor plugin/type (eg. gcp, aws...)
 	Attestor = "attestor"
 
+	// Attestors tags a list of attestor plugins
+	Attestors = "attestors"
+
+	// Audit functionality related to audit logging
+	Audit = "audit"
+
+	// Authority tags a bundle authority (X.509 root CA or JWT signing key)
+	Authority = "authority"
+
 	// Bundle functionality related to a bundle; should be used with other tags
 	// to add clarity
 	Bundle = "bundle"
 
+	// BundleAudit functionality related to auditing bundle changes
+	BundleAudit = "bundle_audit"
+
 	// BundleManager functionality related to a Bundle manager
 	BundleManager = "bundle_manager"
 
@@ -386,6 +575,9 @@ const (
 	// Datastore functionality related to datastore plugin
 	Datastore = "datastore"
 
+	// EC2Inventory functionality related to the AWS EC2 inventory reconciler
+	EC2Inventory = "ec2_inventory"
+
 	// Endpoints functionality related to agent/server endpoints
 	Endpoints = "endpoints"
 
@@ -406,6 +598,10 @@ const (
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// Integrity functionality related to the integrity of the datastore;
+	// should be used with other tags to add clarity
+	Integrity = "integrity"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -439,6 +635,13 @@ const (
 	// to add clarity
 	Notifier = "notifier"
 
+	// ReadReplica functionality related to the read replicas of the datastore;
+	// should be used with other tags to add clarity
+	ReadReplica = "read_replica"
+
+	// Resolver functionality related to resolving the server address
+	Resolver = "resolver"
+
 	// ServerCA functionality related to a server CA; should be used with other tags
 	// to add clarity
 	ServerCA = "server_ca"
@@ -462,6 +665,10 @@ const (
 	// Telemetry tags a telemetry module
 	Telemetry = "telemetry"
 
+	// UpstreamRoots functionality related to the X.509 roots of an upstream
+	// authority; should be used with other tags to add clarity
+	UpstreamRoots = "upstream_roots"
+
 	// X509CA functionality related to an x509 CA; should be used with other tags
 	// to add clarity
 	X509CA = "x509_ca"
@@ -514,6 +721,10 @@ const (
 	// FetchBundle functionality related to fetching a CA bundle
 	FetchBundle = "fetch_bundle"
 
+	// FetchBundlesUpdates functionality related to fetching bundles updates; should be used
+	// with other tags to add clarity
+	FetchBundlesUpdates = "fetch_bundles_updates"
+
 	// FetchEntriesUpdates functionality related to fetching entries updates; should be used
 	// with other tags to add clarity
 	FetchEntriesUpdates = "fetch_entries_updates"
@@ -590,6 +801,13 @@ const (
 	// to add clarity
 	SDSAPI = "sds_api"
 
+	// ServerAPI functionality related to the server APIs used by the agent
+	ServerAPI = "server_api"
+
+	// ServerCircuitBreaker functionality related to the circuit breaker around
+	// the calls from the agent to the server
+	ServerCircuitBreaker = "server_circuit_breaker"
+
 	// ServerKeyManager attached to all operations related to the server KeyManager interface
 	ServerKeyManager = "server_key_manager"
 
@@ -614,4 +832,8 @@ const (
 	// WorkloadAPI flagging usage of workload API; should be used with other tags
 	// to add clarity
 	WorkloadAPI = "workload_api"
+
+	// WorkloadAPIAudit functionality related to auditing Workload API
+	// authorization decisions
+	WorkloadAPIAudit = "workload_api_audit"
 )
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Counters (literal increments, not call counters)
+
+// IncrBundleAuthorityAddedCounter indicates an authority was added to the
+// bundle of the given trust domain
+func IncrBundleAuthorityAddedCounter(m telemetry.Metrics, trustDomain, authorityType string) {
+	m.IncrCounterWithLabels([]string{telemetry.Bundle, telemetry.Authority, telemetry.Added}, 1, []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+		{Name: telemetry.AuthorityType, Value: authorityType},
+	})
+}
+
+// IncrBundleAuthorityRemovedCounter indicates an authority was removed from
+// the bundle of the given trust domain
+func IncrBundleAuthorityRemovedCounter(m telemetry.Metrics, trustDomain, authorityType string) {
+	m.IncrCounterWithLabels([]string{telemetry.Bundle, telemetry.Authority, telemetry.Removed}, 1, []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+		{Name: telemetry.AuthorityType, Value: authorityType},
+	})
+}
+
+// End Counters
+
+// Gauge (remember previous value set)
+
+// SetServedBundleAuthoritiesGauge set gauge for the number of authorities of
+// the given type in the bundle of the given trust domain, as last served by
+// the bundle API
+func SetServedBundleAuthoritiesGauge(m telemetry.Metrics, trustDomain, authorityType string, count int) {
+	m.SetGaugeWithLabels([]string{telemetry.Bundle, telemetry.Served, telemetry.Authority, telemetry.Count}, float32(count), []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+		{Name: telemetry.AuthorityType, Value: authorityType},
+	})
+}
+
+// End Gauge
+
+// Samples
+
+// AddServedBundleSizeSample records the size, in bytes, of the serialized
+// bundle of the given trust domain served by the given bundle API method,
+// and whether it was served to an agent
+func AddServedBundleSizeSample(m telemetry.Metrics, trustDomain, method string, agentCaller bool, size int) {
+	m.AddSampleWithLabels([]string{telemetry.Bundle, telemetry.Served, telemetry.Size}, float32(size), []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+		{Name: telemetry.Method, Value: method},
+		{Name: telemetry.AgentCaller, Value: strconv.FormatBool(agentCaller)},
+	})
+}
+
+// End Samples
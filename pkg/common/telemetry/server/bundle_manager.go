@@ -19,3 +19,36 @@ func IncrBundleManagerUpdateFederatedBundleCounter(m telemetry.Metrics, trustDom
 }
 
 // End Counters
+
+// Gauge (remember previous value set)
+
+// SetFederatedBundleAgeGauge set gauge for the time, in seconds, since the
+// federated bundle of a specific TrustDomain was last refreshed
+func SetFederatedBundleAgeGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.Age},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// SetFederatedBundleTTLGauge set gauge for the time, in seconds, until the
+// last X.509 authority in the federated bundle of a specific TrustDomain
+// expires
+func SetFederatedBundleTTLGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.BundleManager, telemetry.FederatedBundle, telemetry.TTL},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// SetBundleCountGauge set gauge for the number of bundles stored in the
+// datastore, including the bundle of the server trust domain
+func SetBundleCountGauge(m telemetry.Metrics, val int32) {
+	m.SetGauge([]string{telemetry.BundleManager, telemetry.Bundle, telemetry.Count}, float32(val))
+}
+
+// End Gauge
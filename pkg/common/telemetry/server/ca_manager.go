@@ -13,6 +13,12 @@ func StartCAManagerPruneBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.CA, telemetry.Manager, telemetry.Bundle, telemetry.Prune)
 }
 
+// StartCAManagerPollUpstreamBundleCall returns metric for
+// server CA manager polling the upstream authority for bundle updates
+func StartCAManagerPollUpstreamBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.CA, telemetry.Manager, telemetry.UpstreamRoots, telemetry.Poll)
+}
+
 // StartServerCAManagerPrepareJWTKeyCall return metric for
 // Server CA Manager preparing a JWT Key
 func StartServerCAManagerPrepareJWTKeyCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -40,6 +46,17 @@ func SetX509CARotateGauge(m telemetry.Metrics, trustDomain string, val float32)
 		})
 }
 
+// SetUpstreamRootsTTLGauge set gauge for the time left until
+// the last upstream root of a specific TrustDomain expires
+func SetUpstreamRootsTTLGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.CA, telemetry.Manager, telemetry.UpstreamRoots, telemetry.TTL},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
 // End Gauge
 
 // Counters (literal increments, not call counters)
@@ -56,12 +73,24 @@ func IncrActivateX509CAManagerCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.X509CA, telemetry.Activate}, 1)
 }
 
+// IncrCAManagerKeyManagerUnavailableCounter indicate the CA manager
+// failed to prepare or load a CA because the key manager was unavailable
+func IncrCAManagerKeyManagerUnavailableCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.ServerKeyManager, telemetry.Unavailable}, 1)
+}
+
 // IncrManagerPrunedBundleCounter indicate manager
 // having pruned a bundle
 func IncrManagerPrunedBundleCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.Bundle, telemetry.Pruned}, 1)
 }
 
+// IncrUpstreamRootsExpiringCounter indicate manager
+// found all upstream roots about to expire
+func IncrUpstreamRootsExpiringCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.UpstreamRoots, telemetry.Expiring}, 1)
+}
+
 // IncrServerCASignJWTSVIDCounter indicate Server CA
 // signed a JWT SVID.
 func IncrServerCASignJWTSVIDCounter(m telemetry.Metrics) {
@@ -80,4 +109,10 @@ func IncrServerCASignX509Counter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.ServerCA, telemetry.Sign, telemetry.X509SVID}, 1)
 }
 
+// IncrServerCASignUnavailableCounter indicate Server CA
+// failed to sign because the key manager was unavailable.
+func IncrServerCASignUnavailableCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.ServerCA, telemetry.Sign, telemetry.Unavailable}, 1)
+}
+
 // End Counters
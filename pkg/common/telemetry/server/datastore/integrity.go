@@ -0,0 +1,16 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartCheckIntegrityCall return metric
+// for server's datastore, on checking the datastore integrity.
+func StartCheckIntegrityCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Integrity, telemetry.Check)
+}
+
+// End Call Counters
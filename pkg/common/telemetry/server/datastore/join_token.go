@@ -25,10 +25,22 @@ func StartFetchJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Fetch)
 }
 
+// StartListJoinTokenCall return metric
+// for server's datastore, on listing join tokens.
+func StartListJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.List)
+}
+
 // StartPruneJoinTokenCall return metric
 // for server's datastore, on pruning join tokens.
 func StartPruneJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Prune)
 }
 
+// StartUseJoinTokenCall return metric
+// for server's datastore, on marking a join token as used.
+func StartUseJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Use)
+}
+
 // End Call Counters
@@ -7,6 +7,12 @@ import (
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
 
+// StartBatchRegistrationCall return metric
+// for server's datastore, on applying a batch of registration operations.
+func StartBatchRegistrationCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntry, telemetry.Batch)
+}
+
 // StartCountRegistrationCall return metric
 // for server's datastore, on counting registrations.
 func StartCountRegistrationCall(m telemetry.Metrics) *telemetry.CallCounter {
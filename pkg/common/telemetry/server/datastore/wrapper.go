@@ -2,14 +2,19 @@ package datastore
 
 import (
 	"context"
+	"time"
 
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/status"
 )
 
 // WithMetrics wraps a datastore interface and provides per-call metrics. The
 // metrics produced include a call counter and elapsed time measurement with
-// labels for the status code.
+// labels for the status code. In addition, the elapsed time of every call is
+// measured under a single key labeled by operation, and failed calls are
+// counted under a single error key, so slow or failing datastore operations
+// can be compared against each other.
 func WithMetrics(ds datastore.DataStore, metrics telemetry.Metrics) datastore.DataStore {
 	return metricsWrapper{ds: ds, m: metrics}
 }
@@ -19,176 +24,246 @@ type metricsWrapper struct {
 	m  telemetry.Metrics
 }
 
+// observeOperation emits the operation labeled latency and error metrics for
+// a datastore call that started at the given time.
+func (w metricsWrapper) observeOperation(operation string, start time.Time, errp *error) {
+	labels := []telemetry.Label{
+		{Name: telemetry.Operation, Value: operation},
+		{Name: telemetry.Status, Value: status.Code(*errp).String()},
+	}
+	w.m.MeasureSinceWithLabels([]string{telemetry.Datastore, telemetry.Operation, telemetry.ElapsedTime}, start, labels)
+	if *errp != nil {
+		w.m.IncrCounterWithLabels([]string{telemetry.Datastore, telemetry.Operation, telemetry.Error}, 1, labels)
+	}
+}
+
 func (w metricsWrapper) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (_ *datastore.AppendBundleResponse, err error) {
 	callCounter := StartAppendBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("AppendBundle", time.Now(), &err)
 	return w.ds.AppendBundle(ctx, req)
 }
 
+func (w metricsWrapper) CheckIntegrity(ctx context.Context, req *datastore.CheckIntegrityRequest) (_ *datastore.CheckIntegrityResponse, err error) {
+	callCounter := StartCheckIntegrityCall(w.m)
+	defer callCounter.Done(&err)
+	defer w.observeOperation("CheckIntegrity", time.Now(), &err)
+	return w.ds.CheckIntegrity(ctx, req)
+}
+
 func (w metricsWrapper) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (_ *datastore.CreateAttestedNodeResponse, err error) {
 	callCounter := StartCreateNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CreateAttestedNode", time.Now(), &err)
 	return w.ds.CreateAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (_ *datastore.CreateBundleResponse, err error) {
 	callCounter := StartCreateBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CreateBundle", time.Now(), &err)
 	return w.ds.CreateBundle(ctx, req)
 }
 
 func (w metricsWrapper) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (_ *datastore.CreateJoinTokenResponse, err error) {
 	callCounter := StartCreateJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CreateJoinToken", time.Now(), &err)
 	return w.ds.CreateJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (_ *datastore.CreateRegistrationEntryResponse, err error) {
 	callCounter := StartCreateRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CreateRegistrationEntry", time.Now(), &err)
 	return w.ds.CreateRegistrationEntry(ctx, req)
 }
 
 func (w metricsWrapper) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (_ *datastore.DeleteAttestedNodeResponse, err error) {
 	callCounter := StartDeleteNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("DeleteAttestedNode", time.Now(), &err)
 	return w.ds.DeleteAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (_ *datastore.DeleteBundleResponse, err error) {
 	callCounter := StartDeleteBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("DeleteBundle", time.Now(), &err)
 	return w.ds.DeleteBundle(ctx, req)
 }
 
 func (w metricsWrapper) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (_ *datastore.DeleteJoinTokenResponse, err error) {
 	callCounter := StartDeleteJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("DeleteJoinToken", time.Now(), &err)
 	return w.ds.DeleteJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (_ *datastore.DeleteRegistrationEntryResponse, err error) {
 	callCounter := StartDeleteRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("DeleteRegistrationEntry", time.Now(), &err)
 	return w.ds.DeleteRegistrationEntry(ctx, req)
 }
 
 func (w metricsWrapper) FetchAttestedNode(ctx context.Context, req *datastore.FetchAttestedNodeRequest) (_ *datastore.FetchAttestedNodeResponse, err error) {
 	callCounter := StartFetchNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("FetchAttestedNode", time.Now(), &err)
 	return w.ds.FetchAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (_ *datastore.FetchBundleResponse, err error) {
 	callCounter := StartFetchBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("FetchBundle", time.Now(), &err)
 	return w.ds.FetchBundle(ctx, req)
 }
 
 func (w metricsWrapper) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (_ *datastore.FetchJoinTokenResponse, err error) {
 	callCounter := StartFetchJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("FetchJoinToken", time.Now(), &err)
 	return w.ds.FetchJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (_ *datastore.FetchRegistrationEntryResponse, err error) {
 	callCounter := StartFetchRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("FetchRegistrationEntry", time.Now(), &err)
 	return w.ds.FetchRegistrationEntry(ctx, req)
 }
 
 func (w metricsWrapper) GetNodeSelectors(ctx context.Context, req *datastore.GetNodeSelectorsRequest) (_ *datastore.GetNodeSelectorsResponse, err error) {
 	callCounter := StartGetNodeSelectorsCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("GetNodeSelectors", time.Now(), &err)
 	return w.ds.GetNodeSelectors(ctx, req)
 }
 
 func (w metricsWrapper) ListAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (_ *datastore.ListAttestedNodesResponse, err error) {
 	callCounter := StartListNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("ListAttestedNodes", time.Now(), &err)
 	return w.ds.ListAttestedNodes(ctx, req)
 }
 
 func (w metricsWrapper) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (_ *datastore.ListBundlesResponse, err error) {
 	callCounter := StartListBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("ListBundles", time.Now(), &err)
 	return w.ds.ListBundles(ctx, req)
 }
 
+func (w metricsWrapper) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (_ *datastore.ListJoinTokensResponse, err error) {
+	callCounter := StartListJoinTokenCall(w.m)
+	defer callCounter.Done(&err)
+	defer w.observeOperation("ListJoinTokens", time.Now(), &err)
+	return w.ds.ListJoinTokens(ctx, req)
+}
+
 func (w metricsWrapper) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (_ *datastore.ListNodeSelectorsResponse, err error) {
 	callCounter := StartListNodeSelectorsCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("ListNodeSelectors", time.Now(), &err)
 	return w.ds.ListNodeSelectors(ctx, req)
 }
 
 func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (_ *datastore.ListRegistrationEntriesResponse, err error) {
 	callCounter := StartListRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("ListRegistrationEntries", time.Now(), &err)
 	return w.ds.ListRegistrationEntries(ctx, req)
 }
 
+func (w metricsWrapper) BatchRegistrationEntries(ctx context.Context, req *datastore.BatchRegistrationEntriesRequest) (_ *datastore.BatchRegistrationEntriesResponse, err error) {
+	callCounter := StartBatchRegistrationCall(w.m)
+	defer callCounter.Done(&err)
+	defer w.observeOperation("BatchRegistrationEntries", time.Now(), &err)
+	return w.ds.BatchRegistrationEntries(ctx, req)
+}
+
 func (w metricsWrapper) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (_ *datastore.CountAttestedNodesResponse, err error) {
 	callCounter := StartCountNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CountAttestedNodes", time.Now(), &err)
 	return w.ds.CountAttestedNodes(ctx, req)
 }
 
 func (w metricsWrapper) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (_ *datastore.CountBundlesResponse, err error) {
 	callCounter := StartCountBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CountBundles", time.Now(), &err)
 	return w.ds.CountBundles(ctx, req)
 }
 
 func (w metricsWrapper) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (_ *datastore.CountRegistrationEntriesResponse, err error) {
 	callCounter := StartCountRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("CountRegistrationEntries", time.Now(), &err)
 	return w.ds.CountRegistrationEntries(ctx, req)
 }
 
 func (w metricsWrapper) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (_ *datastore.PruneBundleResponse, err error) {
 	callCounter := StartPruneBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("PruneBundle", time.Now(), &err)
 	return w.ds.PruneBundle(ctx, req)
 }
 
 func (w metricsWrapper) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (_ *datastore.PruneJoinTokensResponse, err error) {
 	callCounter := StartPruneJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("PruneJoinTokens", time.Now(), &err)
 	return w.ds.PruneJoinTokens(ctx, req)
 }
 
 func (w metricsWrapper) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (_ *datastore.PruneRegistrationEntriesResponse, err error) {
 	callCounter := StartPruneRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("PruneRegistrationEntries", time.Now(), &err)
 	return w.ds.PruneRegistrationEntries(ctx, req)
 }
 
 func (w metricsWrapper) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (_ *datastore.SetBundleResponse, err error) {
 	callCounter := StartSetBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("SetBundle", time.Now(), &err)
 	return w.ds.SetBundle(ctx, req)
 }
 
 func (w metricsWrapper) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (_ *datastore.SetNodeSelectorsResponse, err error) {
 	callCounter := StartSetNodeSelectorsCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("SetNodeSelectors", time.Now(), &err)
 	return w.ds.SetNodeSelectors(ctx, req)
 }
 
 func (w metricsWrapper) UpdateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) (_ *datastore.UpdateAttestedNodeResponse, err error) {
 	callCounter := StartUpdateNodeCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("UpdateAttestedNode", time.Now(), &err)
 	return w.ds.UpdateAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (_ *datastore.UpdateBundleResponse, err error) {
 	callCounter := StartUpdateBundleCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("UpdateBundle", time.Now(), &err)
 	return w.ds.UpdateBundle(ctx, req)
 }
 
 func (w metricsWrapper) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (_ *datastore.UpdateRegistrationEntryResponse, err error) {
 	callCounter := StartUpdateRegistrationCall(w.m)
 	defer callCounter.Done(&err)
+	defer w.observeOperation("UpdateRegistrationEntry", time.Now(), &err)
 	return w.ds.UpdateRegistrationEntry(ctx, req)
 }
+
+func (w metricsWrapper) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (_ *datastore.UseJoinTokenResponse, err error) {
+	callCounter := StartUseJoinTokenCall(w.m)
+	defer callCounter.Done(&err)
+	defer w.observeOperation("UseJoinToken", time.Now(), &err)
+	return w.ds.UseJoinToken(ctx, req)
+}
@@ -37,6 +37,14 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.append",
 			methodName: "AppendBundle",
 		},
+		{
+			key:        "datastore.registration_entry.batch",
+			methodName: "BatchRegistrationEntries",
+		},
+		{
+			key:        "datastore.integrity.check",
+			methodName: "CheckIntegrity",
+		},
 		{
 			key:        "datastore.node.count",
 			methodName: "CountAttestedNodes",
@@ -109,6 +117,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.list",
 			methodName: "ListBundles",
 		},
+		{
+			key:        "datastore.join_token.list",
+			methodName: "ListJoinTokens",
+		},
 		{
 			key:        "datastore.node.selectors.list",
 			methodName: "ListNodeSelectors",
@@ -149,6 +161,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry.update",
 			methodName: "UpdateRegistrationEntry",
 		},
+		{
+			key:        "datastore.join_token.use",
+			methodName: "UseJoinToken",
+		},
 	} {
 		tt := tt
 		methodType, ok := wt.MethodByName(tt.methodName)
@@ -175,7 +191,26 @@ func TestWithMetrics(t *testing.T) {
 
 		expectedMetrics := func(code codes.Code) []fakemetrics.MetricItem {
 			key := strings.Split(tt.key, ".")
-			return []fakemetrics.MetricItem{
+			operationLabels := []telemetry.Label{
+				{Name: "operation", Value: tt.methodName},
+				{Name: "status", Value: code.String()},
+			}
+			metrics := []fakemetrics.MetricItem{
+				{
+					Type:   fakemetrics.MeasureSinceWithLabelsType,
+					Key:    []string{"datastore", "operation", "elapsed_time"},
+					Labels: operationLabels,
+				},
+			}
+			if code != codes.OK {
+				metrics = append(metrics, fakemetrics.MetricItem{
+					Type:   fakemetrics.IncrCounterWithLabelsType,
+					Key:    []string{"datastore", "operation", "error"},
+					Labels: operationLabels,
+					Val:    1,
+				})
+			}
+			return append(metrics, []fakemetrics.MetricItem{
 				{
 					Type: fakemetrics.IncrCounterWithLabelsType,
 					Key:  key,
@@ -191,7 +226,7 @@ func TestWithMetrics(t *testing.T) {
 						{Name: "status", Value: code.String()},
 					},
 				},
-			}
+			}...)
 		}
 
 		t.Run(tt.key+"(success)", func(t *testing.T) {
@@ -224,6 +259,14 @@ func (ds *fakeDataStore) AppendBundle(context.Context, *datastore.AppendBundleRe
 	return &datastore.AppendBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) BatchRegistrationEntries(context.Context, *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	return &datastore.BatchRegistrationEntriesResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) CheckIntegrity(context.Context, *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	return &datastore.CheckIntegrityResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CountAttestedNodes(context.Context, *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
 	return &datastore.CountAttestedNodesResponse{}, ds.err
 }
@@ -296,6 +339,10 @@ func (ds *fakeDataStore) ListBundles(context.Context, *datastore.ListBundlesRequ
 	return &datastore.ListBundlesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListJoinTokens(context.Context, *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	return &datastore.ListJoinTokensResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListNodeSelectors(context.Context, *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
 	return &datastore.ListNodeSelectorsResponse{}, ds.err
 }
@@ -335,3 +382,7 @@ func (ds *fakeDataStore) UpdateBundle(context.Context, *datastore.UpdateBundleRe
 func (ds *fakeDataStore) UpdateRegistrationEntry(context.Context, *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 	return &datastore.UpdateRegistrationEntryResponse{}, ds.err
 }
+
+func (ds *fakeDataStore) UseJoinToken(context.Context, *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	return &datastore.UseJoinTokenResponse{}, ds.err
+}
@@ -11,4 +11,25 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerCheckIntegrityCall returns metric for
+// server registration manager datastore integrity checks
+func StartRegistrationManagerCheckIntegrityCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Integrity, telemetry.Check)
+}
+
 // End Call Counters
+
+// Gauge (remember previous value set)
+
+// SetIntegrityIssuesGauge set gauge for the number of datastore integrity
+// issues of a specific kind found by the last check
+func SetIntegrityIssuesGauge(m telemetry.Metrics, kind string, val int) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.RegistrationEntry, telemetry.Manager, telemetry.Integrity, telemetry.Count},
+		float32(val),
+		[]telemetry.Label{
+			{Name: telemetry.Kind, Value: kind},
+		})
+}
+
+// End Gauge
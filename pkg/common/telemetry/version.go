@@ -1,11 +1,74 @@
 package telemetry
 
 import (
+	"runtime"
+	"sort"
+	"strings"
+
 	"github.com/spiffe/spire/pkg/common/version"
 )
 
+// Lifecycle events of the server and agent processes
+const (
+	LifecycleStarted = "started"
+	LifecycleStopped = "stopped"
+)
+
+// BuildInfo describes the running binary and the experimental features it
+// has enabled.
+type BuildInfo struct {
+	Version      string
+	GoVersion    string
+	Experimental []string
+}
+
+// NewBuildInfo returns the build information of the running binary with the
+// given experimental features enabled.
+func NewBuildInfo(experimental ...string) BuildInfo {
+	experimental = append([]string(nil), experimental...)
+	sort.Strings(experimental)
+	return BuildInfo{
+		Version:      version.Version(),
+		GoVersion:    runtime.Version(),
+		Experimental: experimental,
+	}
+}
+
+// Fields returns the build information as structured log fields.
+func (b BuildInfo) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		VersionInfo:  b.Version,
+		GoVersion:    b.GoVersion,
+		Experimental: strings.Join(b.Experimental, ","),
+	}
+}
+
 func EmitVersion(m Metrics) {
 	m.SetGaugeWithLabels([]string{"started"}, 1, []Label{
 		{Name: "version", Value: version.Version()},
 	})
 }
+
+// EmitBuildInfo sets the build_info gauge, labeled with the version of the
+// binary and the version of Go used to build it, and a build_info
+// experimental gauge for each enabled experimental feature, so that fleet
+// dashboards can track version rollouts and configuration drift.
+func EmitBuildInfo(m Metrics, b BuildInfo) {
+	m.SetGaugeWithLabels([]string{"build_info"}, 1, []Label{
+		{Name: "version", Value: b.Version},
+		{Name: GoVersion, Value: b.GoVersion},
+	})
+	for _, experimental := range b.Experimental {
+		m.SetGaugeWithLabels([]string{"build_info", Experimental}, 1, []Label{
+			{Name: Experimental, Value: experimental},
+		})
+	}
+}
+
+// EmitLifecycleEvent counts a lifecycle event (see LifecycleStarted and
+// LifecycleStopped) of the running process.
+func EmitLifecycleEvent(m Metrics, event string) {
+	m.IncrCounterWithLabels([]string{"lifecycle"}, 1, []Label{
+		{Name: Event, Value: event},
+	})
+}
@@ -0,0 +1,77 @@
+package telemetry_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	info := telemetry.NewBuildInfo("b", "a")
+	assert.Equal(t, telemetry.BuildInfo{
+		Version:      version.Version(),
+		GoVersion:    runtime.Version(),
+		Experimental: []string{"a", "b"},
+	}, info)
+	assert.Equal(t, map[string]interface{}{
+		telemetry.VersionInfo:  version.Version(),
+		telemetry.GoVersion:    runtime.Version(),
+		telemetry.Experimental: "a,b",
+	}, info.Fields())
+}
+
+func TestEmitBuildInfo(t *testing.T) {
+	metrics := fakemetrics.New()
+	telemetry.EmitBuildInfo(metrics, telemetry.BuildInfo{
+		Version:      "1",
+		GoVersion:    "go1",
+		Experimental: []string{"a", "b"},
+	})
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type: fakemetrics.SetGaugeWithLabelsType,
+			Key:  []string{"build_info"},
+			Val:  1,
+			Labels: []telemetry.Label{
+				{Name: "version", Value: "1"},
+				{Name: telemetry.GoVersion, Value: "go1"},
+			},
+		},
+		{
+			Type:   fakemetrics.SetGaugeWithLabelsType,
+			Key:    []string{"build_info", "experimental"},
+			Val:    1,
+			Labels: []telemetry.Label{{Name: telemetry.Experimental, Value: "a"}},
+		},
+		{
+			Type:   fakemetrics.SetGaugeWithLabelsType,
+			Key:    []string{"build_info", "experimental"},
+			Val:    1,
+			Labels: []telemetry.Label{{Name: telemetry.Experimental, Value: "b"}},
+		},
+	}, metrics.AllMetrics())
+}
+
+func TestEmitLifecycleEvent(t *testing.T) {
+	metrics := fakemetrics.New()
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStarted)
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStopped)
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{"lifecycle"},
+			Val:    1,
+			Labels: []telemetry.Label{{Name: telemetry.Event, Value: telemetry.LifecycleStarted}},
+		},
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{"lifecycle"},
+			Val:    1,
+			Labels: []telemetry.Label{{Name: telemetry.Event, Value: telemetry.LifecycleStopped}},
+		},
+	}, metrics.AllMetrics())
+}
@@ -15,13 +15,14 @@ func MakeCSR(privateKey interface{}, spiffeID string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The signature algorithm is not provided so that the crypto/x509
+	// package picks one appropriate for the key type.
 	return makeCSR(privateKey, &x509.CertificateRequest{
 		Subject: pkix.Name{
 			Country:      []string{"US"},
 			Organization: []string{"SPIRE"},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{uri},
+		URIs: []*url.URL{uri},
 	})
 }
 
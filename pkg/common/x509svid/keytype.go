@@ -0,0 +1,79 @@
+package x509svid
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+// KeyType is the type of key generated for an X509-SVID. Some stacks are
+// only able to interoperate with RSA keys.
+type KeyType string
+
+const (
+	// KeyTypeECP256 is an ECDSA key on the P-256 curve. This is the
+	// default key type.
+	KeyTypeECP256 KeyType = "ec-p256"
+
+	// KeyTypeECP384 is an ECDSA key on the P-384 curve.
+	KeyTypeECP384 KeyType = "ec-p384"
+
+	// KeyTypeRSA2048 is a 2048-bit RSA key.
+	KeyTypeRSA2048 KeyType = "rsa-2048"
+
+	// KeyTypeRSA3072 is a 3072-bit RSA key.
+	KeyTypeRSA3072 KeyType = "rsa-3072"
+)
+
+// ParseKeyType parses the key type. An empty string is returned as is,
+// meaning that the default should be used.
+func ParseKeyType(s string) (KeyType, error) {
+	switch KeyType(s) {
+	case "", KeyTypeECP256, KeyTypeECP384, KeyTypeRSA2048, KeyTypeRSA3072:
+		return KeyType(s), nil
+	default:
+		return "", fmt.Errorf("invalid X509-SVID key type %q: expected %q, %q, %q or %q", s, KeyTypeECP256, KeyTypeECP384, KeyTypeRSA2048, KeyTypeRSA3072)
+	}
+}
+
+// GenerateKey generates a private key of the given type. An empty key type
+// generates a key of the default type.
+func GenerateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case "", KeyTypeECP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeECP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyTypeRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyTypeRSA3072:
+		return rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return nil, fmt.Errorf("invalid X509-SVID key type %q", keyType)
+	}
+}
+
+// KeyTypeOf returns the type of the given private key. It returns false if
+// the key is not of any of the supported types.
+func KeyTypeOf(key crypto.Signer) (KeyType, bool) {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return KeyTypeECP256, true
+		case elliptic.P384():
+			return KeyTypeECP384, true
+		}
+	case *rsa.PrivateKey:
+		switch key.N.BitLen() {
+		case 2048:
+			return KeyTypeRSA2048, true
+		case 3072:
+			return KeyTypeRSA3072, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,69 @@
+package x509svid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyType(t *testing.T) {
+	for _, s := range []string{"", "ec-p256", "ec-p384", "rsa-2048", "rsa-3072"} {
+		keyType, err := ParseKeyType(s)
+		require.NoError(t, err)
+		assert.Equal(t, KeyType(s), keyType)
+	}
+
+	_, err := ParseKeyType("rsa-1024")
+	require.EqualError(t, err, `invalid X509-SVID key type "rsa-1024": expected "ec-p256", "ec-p384", "rsa-2048" or "rsa-3072"`)
+}
+
+func TestGenerateKey(t *testing.T) {
+	for _, tt := range []struct {
+		keyType     KeyType
+		expectCurve elliptic.Curve
+		expectBits  int
+	}{
+		{keyType: "", expectCurve: elliptic.P256()},
+		{keyType: KeyTypeECP256, expectCurve: elliptic.P256()},
+		{keyType: KeyTypeECP384, expectCurve: elliptic.P384()},
+		{keyType: KeyTypeRSA2048, expectBits: 2048},
+		{keyType: KeyTypeRSA3072, expectBits: 3072},
+	} {
+		tt := tt
+		t.Run(string(tt.keyType), func(t *testing.T) {
+			key, err := GenerateKey(tt.keyType)
+			require.NoError(t, err)
+			switch key := key.(type) {
+			case *ecdsa.PrivateKey:
+				assert.Equal(t, tt.expectCurve, key.Curve)
+			case *rsa.PrivateKey:
+				assert.Equal(t, tt.expectBits, key.N.BitLen())
+			default:
+				require.Fail(t, "unexpected key type", "%T", key)
+			}
+
+			keyType, ok := KeyTypeOf(key)
+			require.True(t, ok)
+			if tt.keyType == "" {
+				assert.Equal(t, KeyTypeECP256, keyType)
+			} else {
+				assert.Equal(t, tt.keyType, keyType)
+			}
+		})
+	}
+
+	_, err := GenerateKey("rsa-1024")
+	require.EqualError(t, err, `invalid X509-SVID key type "rsa-1024"`)
+}
+
+func TestKeyTypeOf(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	_, ok := KeyTypeOf(key)
+	assert.False(t, ok)
+}
@@ -0,0 +1,90 @@
+package x509svid
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+)
+
+// PrimaryName determines which name is presented first in an X509-SVID, i.e.
+// which name is used as the subject common name and listed first in the
+// subject alternative name extension. Some TLS stacks use the common name or
+// the first SAN for hostname verification.
+type PrimaryName string
+
+const (
+	// PrimaryNameDNSName presents the first DNS name first. This is the
+	// default behavior.
+	PrimaryNameDNSName PrimaryName = "dns_name"
+
+	// PrimaryNameSPIFFEID presents the SPIFFE ID first.
+	PrimaryNameSPIFFEID PrimaryName = "spiffe_id"
+)
+
+var oidExtensionSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// ParsePrimaryName parses the primary name. An empty string is returned as
+// is, meaning that the default should be used.
+func ParsePrimaryName(s string) (PrimaryName, error) {
+	switch PrimaryName(s) {
+	case "", PrimaryNameDNSName, PrimaryNameSPIFFEID:
+		return PrimaryName(s), nil
+	default:
+		return "", fmt.Errorf("invalid X509-SVID primary name %q: expected %q or %q", s, PrimaryNameDNSName, PrimaryNameSPIFFEID)
+	}
+}
+
+// ApplyPrimaryName sets the subject common name and the subject alternative
+// names of the template so that the given primary name is presented first.
+// The template is expected to already have the SPIFFE ID as its only URI SAN.
+func ApplyPrimaryName(template *x509.Certificate, primaryName PrimaryName, dnsNames []string) error {
+	switch primaryName {
+	case "", PrimaryNameDNSName:
+		// The x509 package encodes DNS names before URIs, so there is
+		// nothing to do other than setting the common name.
+		if len(dnsNames) > 0 {
+			template.Subject.CommonName = dnsNames[0]
+			template.DNSNames = dnsNames
+		}
+		return nil
+	case PrimaryNameSPIFFEID:
+		if len(template.URIs) != 1 {
+			return fmt.Errorf("expected exactly one URI SAN; got %d", len(template.URIs))
+		}
+		template.Subject.CommonName = template.URIs[0].String()
+		if len(dnsNames) == 0 {
+			return nil
+		}
+		template.DNSNames = dnsNames
+
+		// The x509 package always encodes DNS names before URIs, so the
+		// extension is marshaled by hand to list the SPIFFE ID first.
+		ext, err := marshalSANs(template.URIs[0].String(), dnsNames)
+		if err != nil {
+			return err
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, ext)
+		return nil
+	default:
+		return fmt.Errorf("invalid X509-SVID primary name %q", primaryName)
+	}
+}
+
+func marshalSANs(uri string, dnsNames []string) (pkix.Extension, error) {
+	const (
+		nameTypeDNS = 2
+		nameTypeURI = 6
+	)
+
+	rawValues := []asn1.RawValue{{Tag: nameTypeURI, Class: asn1.ClassContextSpecific, Bytes: []byte(uri)}}
+	for _, dnsName := range dnsNames {
+		rawValues = append(rawValues, asn1.RawValue{Tag: nameTypeDNS, Class: asn1.ClassContextSpecific, Bytes: []byte(dnsName)})
+	}
+
+	value, err := asn1.Marshal(rawValues)
+	if err != nil {
+		return pkix.Extension{}, fmt.Errorf("unable to marshal subject alternative names: %v", err)
+	}
+	return pkix.Extension{Id: oidExtensionSubjectAltName, Value: value}, nil
+}
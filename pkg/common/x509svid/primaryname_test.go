@@ -0,0 +1,96 @@
+package x509svid
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrimaryName(t *testing.T) {
+	for _, s := range []string{"", "dns_name", "spiffe_id"} {
+		primaryName, err := ParsePrimaryName(s)
+		require.NoError(t, err)
+		assert.Equal(t, PrimaryName(s), primaryName)
+	}
+
+	_, err := ParsePrimaryName("common_name")
+	require.EqualError(t, err, `invalid X509-SVID primary name "common_name": expected "dns_name" or "spiffe_id"`)
+}
+
+func TestApplyPrimaryName(t *testing.T) {
+	id := &url.URL{Scheme: "spiffe", Host: "example.org", Path: "/workload"}
+
+	for _, tt := range []struct {
+		name             string
+		primaryName      PrimaryName
+		dnsNames         []string
+		expectCommonName string
+		expectSANs       []string
+		expectErr        string
+	}{
+		{
+			name: "default without DNS names",
+		},
+		{
+			name:             "default with DNS names",
+			dnsNames:         []string{"host1", "host2"},
+			expectCommonName: "host1",
+		},
+		{
+			name:             "DNS name",
+			primaryName:      PrimaryNameDNSName,
+			dnsNames:         []string{"host1", "host2"},
+			expectCommonName: "host1",
+		},
+		{
+			name:             "SPIFFE ID without DNS names",
+			primaryName:      PrimaryNameSPIFFEID,
+			expectCommonName: "spiffe://example.org/workload",
+		},
+		{
+			name:             "SPIFFE ID with DNS names",
+			primaryName:      PrimaryNameSPIFFEID,
+			dnsNames:         []string{"host1", "host2"},
+			expectCommonName: "spiffe://example.org/workload",
+			expectSANs:       []string{"spiffe://example.org/workload", "host1", "host2"},
+		},
+		{
+			name:        "invalid",
+			primaryName: "common_name",
+			expectErr:   `invalid X509-SVID primary name "common_name"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template := &x509.Certificate{URIs: []*url.URL{id}}
+			err := ApplyPrimaryName(template, tt.primaryName, tt.dnsNames)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCommonName, template.Subject.CommonName)
+			assert.Equal(t, tt.dnsNames, template.DNSNames)
+
+			if tt.expectSANs == nil {
+				assert.Empty(t, template.ExtraExtensions)
+				return
+			}
+			require.Len(t, template.ExtraExtensions, 1)
+			assert.Equal(t, oidExtensionSubjectAltName, template.ExtraExtensions[0].Id)
+
+			var rawValues []asn1.RawValue
+			_, err = asn1.Unmarshal(template.ExtraExtensions[0].Value, &rawValues)
+			require.NoError(t, err)
+			var sans []string
+			for _, rawValue := range rawValues {
+				sans = append(sans, string(rawValue.Bytes))
+			}
+			assert.Equal(t, tt.expectSANs, sans)
+		})
+	}
+}
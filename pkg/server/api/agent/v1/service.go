@@ -2,22 +2,30 @@ package agent
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"path"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/gofrs/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -31,6 +39,18 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// maxRevokeAttempts is the number of times the revocation of the entries
+	// of a banned agent is attempted when the entries are concurrently
+	// modified.
+	maxRevokeAttempts = 3
+
+	// listNodesPageSize is the page size used to list the agents that node
+	// alias entries may map to.
+	listNodesPageSize = 1000
 )
 
 // RegisterService registers the agent service on the gRPC server/
@@ -45,6 +65,10 @@ type Config struct {
 	DataStore   datastore.DataStore
 	ServerCA    ca.ServerCA
 	TrustDomain spiffeid.TrustDomain
+
+	// BundleTracker, if set, forgets the bundle sync status of deleted
+	// agents.
+	BundleTracker *propagation.Tracker
 }
 
 // New creates a new agent service
@@ -55,6 +79,7 @@ func New(config Config) *Service {
 		ds:  config.DataStore,
 		ca:  config.ServerCA,
 		td:  config.TrustDomain,
+		bt:  config.BundleTracker,
 	}
 }
 
@@ -67,6 +92,7 @@ type Service struct {
 	ds  datastore.DataStore
 	ca  ca.ServerCA
 	td  spiffeid.TrustDomain
+	bt  *propagation.Tracker
 }
 
 func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest) (*agent.ListAgentsResponse, error) {
@@ -82,6 +108,8 @@ func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest)
 		filter := req.Filter
 		listReq.ByAttestationType = filter.ByAttestationType
 		listReq.ByBanned = filter.ByBanned
+		listReq.ByExpiresBefore = filter.ByExpiresBefore
+		listReq.ByExpiresAtOrAfter = filter.ByExpiresAtOrAfter
 
 		if filter.BySelectorMatch != nil {
 			selectors, err := api.SelectorsFromProto(filter.BySelectorMatch.Selectors)
@@ -149,9 +177,14 @@ func (s *Service) GetAgent(ctx context.Context, req *agent.GetAgentRequest) (*ty
 		return nil, api.MakeErr(log, codes.NotFound, "agent not found", err)
 	}
 
-	selectors, err := s.getSelectorsFromAgentID(ctx, resp.Node.SpiffeId)
-	if err != nil {
-		return nil, api.MakeErr(log, codes.Internal, "failed to get selectors from agent", err)
+	// Selectors are stored apart from the node, so only fetch them when
+	// they are part of the response.
+	var selectors []*types.Selector
+	if req.OutputMask == nil || req.OutputMask.Selectors {
+		selectors, err = s.getSelectorsFromAgentID(ctx, resp.Node.SpiffeId)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to get selectors from agent", err)
+		}
 	}
 
 	agent, err := api.AttestedNodeToProto(resp.Node, selectors)
@@ -178,6 +211,9 @@ func (s *Service) DeleteAgent(ctx context.Context, req *agent.DeleteAgentRequest
 	})
 	switch status.Code(err) {
 	case codes.OK:
+		if s.bt != nil {
+			s.bt.Forget(id.String())
+		}
 		log.Info("Agent deleted")
 		return &emptypb.Empty{}, nil
 	case codes.NotFound:
@@ -187,7 +223,7 @@ func (s *Service) DeleteAgent(ctx context.Context, req *agent.DeleteAgentRequest
 	}
 }
 
-func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*emptypb.Empty, error) {
+func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*agent.BanAgentResponse, error) {
 	log := rpccontext.Logger(ctx)
 
 	id, err := api.TrustDomainAgentIDFromProto(s.td, req.Id)
@@ -197,6 +233,13 @@ func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*em
 
 	log = log.WithField(telemetry.SPIFFEID, id.String())
 
+	switch {
+	case req.EntryDeletionGracePeriod < 0:
+		return nil, api.MakeErr(log, codes.InvalidArgument, "entry deletion grace period cannot be negative", nil)
+	case req.EntryDeletionGracePeriod > 0 && !req.RevokeEntries:
+		return nil, api.MakeErr(log, codes.InvalidArgument, "entry deletion grace period requires revoking entries", nil)
+	}
+
 	// The agent "Banned" state is pointed out by setting its
 	// serial numbers (current and new) to empty strings.
 	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
@@ -210,12 +253,248 @@ func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*em
 	switch status.Code(err) {
 	case codes.OK:
 		log.Info("Agent banned")
-		return &emptypb.Empty{}, nil
 	case codes.NotFound:
 		return nil, api.MakeErr(log, codes.NotFound, "agent not found", err)
 	default:
 		return nil, api.MakeErr(log, codes.Internal, "failed to ban agent", err)
 	}
+
+	if !req.RevokeEntries {
+		return &agent.BanAgentResponse{}, nil
+	}
+
+	// The agent is banned at this point, so a failed revocation is reported
+	// in the response rather than as an error. It can be retried by banning
+	// the agent again.
+	gracePeriod := time.Duration(req.EntryDeletionGracePeriod) * time.Second
+	resp, err := s.revokeAgentEntries(ctx, id, gracePeriod)
+	if err != nil {
+		code := codes.Internal
+		if status.Code(err) == codes.Aborted {
+			code = codes.Aborted
+		}
+		return &agent.BanAgentResponse{
+			RevokeEntriesStatus: api.MakeStatus(log, code, "agent banned but failed to revoke agent entries", err),
+		}, nil
+	}
+
+	log.WithFields(logrus.Fields{
+		telemetry.Revoked:   len(resp.RevokedEntryIds),
+		telemetry.Scheduled: len(resp.ScheduledEntryIds),
+	}).Info("Agent entries revoked")
+	resp.RevokeEntriesStatus = api.OK()
+	return resp, nil
+}
+
+// revokeAgentEntries revokes the entries of the given agent, starting over
+// if any of the entries is concurrently modified. It gives up with Aborted
+// after maxRevokeAttempts attempts.
+func (s *Service) revokeAgentEntries(ctx context.Context, agentID spiffeid.ID, gracePeriod time.Duration) (*agent.BanAgentResponse, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := s.tryRevokeAgentEntries(ctx, agentID, gracePeriod)
+		if status.Code(err) != codes.Aborted || attempt == maxRevokeAttempts {
+			return resp, err
+		}
+	}
+}
+
+// tryRevokeAgentEntries deletes the node alias entries that only map to the
+// given agent and expires the entries parented by the agent, or by the
+// deleted node aliases, once the grace period elapses. Expired entries are
+// deleted by the registration manager the next time it prunes entries. All
+// changes are applied in a single datastore transaction, which fails with
+// Aborted if any of the entries changed since it was listed.
+func (s *Service) tryRevokeAgentEntries(ctx context.Context, agentID spiffeid.ID, gracePeriod time.Duration) (*agent.BanAgentResponse, error) {
+	aliases, err := s.exclusiveNodeAliases(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make(map[string]bool)
+	for _, alias := range aliases {
+		revoked[alias.EntryId] = true
+	}
+
+	parentIDs := []string{agentID.String()}
+	for _, alias := range aliases {
+		// Entries parented by the node alias are still authorized for other
+		// agents as long as another node alias with the same ID remains.
+		shared, err := s.hasRemainingNodeAlias(ctx, alias.SpiffeId, revoked)
+		if err != nil {
+			return nil, err
+		}
+		if !shared {
+			parentIDs = append(parentIDs, alias.SpiffeId)
+		}
+	}
+
+	expiresAt := s.clk.Now().Add(gracePeriod).Unix()
+	var operations []*datastore.RegistrationEntryOperation
+	for _, alias := range aliases {
+		operations = append(operations, &datastore.RegistrationEntryOperation{
+			Delete: &datastore.DeleteRegistrationEntryRequest{
+				EntryId: alias.EntryId,
+			},
+			IfRevisionNumber: wrapperspb.Int64(alias.RevisionNumber),
+		})
+	}
+
+	resp := &agent.BanAgentResponse{}
+	scheduled := make(map[string]bool)
+	for _, parentID := range parentIDs {
+		listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			ByParentId: wrapperspb.String(parentID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range listResp.Entries {
+			if revoked[entry.EntryId] || scheduled[entry.EntryId] {
+				continue
+			}
+			scheduled[entry.EntryId] = true
+			resp.ScheduledEntryIds = append(resp.ScheduledEntryIds, entry.EntryId)
+
+			// Never extend the lifetime of an entry that already expires
+			// before the grace period elapses.
+			if entry.EntryExpiry != 0 && entry.EntryExpiry <= expiresAt {
+				continue
+			}
+			entry.EntryExpiry = expiresAt
+			operations = append(operations, &datastore.RegistrationEntryOperation{
+				Update: &datastore.UpdateRegistrationEntryRequest{
+					Entry: entry,
+					Mask:  &common.RegistrationEntryMask{EntryExpiry: true},
+				},
+				IfRevisionNumber: wrapperspb.Int64(entry.RevisionNumber),
+			})
+		}
+	}
+
+	if len(operations) > 0 {
+		if _, err := s.ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+			Operations: operations,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, alias := range aliases {
+		resp.RevokedEntryIds = append(resp.RevokedEntryIds, alias.EntryId)
+	}
+	if len(resp.ScheduledEntryIds) > 0 {
+		resp.ScheduledDeletionAt = expiresAt
+	}
+	return resp, nil
+}
+
+// exclusiveNodeAliases returns the node alias entries that map to the given
+// agent and to no other agent that is neither banned nor expired. Agents are
+// listed a page at a time, and only until every node alias is known to map
+// to another agent.
+func (s *Service) exclusiveNodeAliases(ctx context.Context, agentID spiffeid.ID) ([]*common.RegistrationEntry, error) {
+	selectorsResp, err := s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: agentID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if selectorsResp.Selectors == nil || len(selectorsResp.Selectors.Selectors) == 0 {
+		return nil, nil
+	}
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySelectors: &datastore.BySelectors{
+			Selectors: selectorsResp.Selectors.Selectors,
+			Match:     datastore.BySelectors_MATCH_SUBSET,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serverID := idutil.ServerID(s.td).String()
+	var candidates []*common.RegistrationEntry
+	for _, entry := range listResp.Entries {
+		if entry.ParentId == serverID {
+			candidates = append(candidates, entry)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	nodesReq := &datastore.ListAttestedNodesRequest{
+		ByBanned:           wrapperspb.Bool(false),
+		ByExpiresAtOrAfter: wrapperspb.Int64(s.clk.Now().Unix()),
+		FetchSelectors:     true,
+		Pagination: &datastore.Pagination{
+			PageSize: listNodesPageSize,
+		},
+	}
+	for len(candidates) > 0 {
+		nodesResp, err := s.ds.ListAttestedNodes(ctx, nodesReq)
+		if err != nil {
+			return nil, err
+		}
+
+		var exclusive []*common.RegistrationEntry
+		for _, entry := range candidates {
+			if !mapsToOtherNode(entry, agentID.String(), nodesResp.Nodes) {
+				exclusive = append(exclusive, entry)
+			}
+		}
+		candidates = exclusive
+
+		if len(nodesResp.Nodes) == 0 || nodesResp.Pagination == nil || nodesResp.Pagination.Token == "" {
+			break
+		}
+		nodesReq.Pagination.Token = nodesResp.Pagination.Token
+	}
+	return candidates, nil
+}
+
+// mapsToOtherNode returns true if the node alias entry maps to any of the
+// given nodes other than the agent.
+func mapsToOtherNode(entry *common.RegistrationEntry, agentID string, nodes []*common.AttestedNode) bool {
+	for _, node := range nodes {
+		if node.SpiffeId != agentID && selectorsSubset(entry.Selectors, node.Selectors) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorsSubset returns true if every selector in sub is also in whole.
+func selectorsSubset(sub, whole []*common.Selector) bool {
+	set := make(map[string]bool, len(whole))
+	for _, selector := range whole {
+		set[selector.Type+":"+selector.Value] = true
+	}
+	for _, selector := range sub {
+		if !set[selector.Type+":"+selector.Value] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasRemainingNodeAlias returns true if a node alias entry with the given ID
+// exists that is not part of the revoked set.
+func (s *Service) hasRemainingNodeAlias(ctx context.Context, aliasID string, revoked map[string]bool) (bool, error) {
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		ByParentId: wrapperspb.String(idutil.ServerID(s.td).String()),
+		BySpiffeId: wrapperspb.String(aliasID),
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range listResp.Entries {
+		if !revoked[entry.EntryId] {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (s *Service) AttestAgent(stream agent.Agent_AttestAgentServer) error {
@@ -377,70 +656,247 @@ func (s *Service) RenewAgent(ctx context.Context, req *agent.RenewAgentRequest)
 			ExpiresAt: agentSVID[0].NotAfter.Unix(),
 			CertChain: x509util.RawCertsFromCertificates(agentSVID),
 		},
+		RotateAfter: rotationHint(callerID, agentSVID[0]).Unix(),
 	}, nil
 }
 
+// rotationHint returns when the agent should renew the given SVID. Agents
+// otherwise renew when half of the SVID lifetime has elapsed, so agents that
+// renewed at the same time (e.g. after a CA rotation) keep renewing together.
+// The hint spreads renewals over the sixth of the lifetime preceding that
+// point, using an offset derived from the agent ID so that it stays stable
+// for each agent.
+func rotationHint(agentID spiffeid.ID, svid *x509.Certificate) time.Time {
+	lifetime := svid.NotAfter.Sub(svid.NotBefore)
+	window := lifetime / 6
+
+	sum := sha256.Sum256([]byte(agentID.String()))
+	fraction := float64(binary.BigEndian.Uint64(sum[:8])) / math.MaxUint64
+	offset := time.Duration(fraction * float64(window))
+
+	return svid.NotBefore.Add(lifetime/2 - offset)
+}
+
 func (s *Service) CreateJoinToken(ctx context.Context, req *agent.CreateJoinTokenRequest) (*types.JoinToken, error) {
 	log := rpccontext.Logger(ctx)
 
 	if req.Ttl < 1 {
 		return nil, api.MakeErr(log, codes.InvalidArgument, "ttl is required, you must provide one", nil)
 	}
+	if req.AgentId == nil && (len(req.AgentSelectors) > 0 || req.AgentTtl != 0) {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "agent selectors and TTL require an agent ID", nil)
+	}
+	if req.AgentTtl < 0 {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "agent TTL cannot be negative", nil)
+	}
+
+	agentSelectors, err := api.SelectorsFromProto(req.AgentSelectors)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent selectors", err)
+	}
+
+	// Generate a token if one wasn't specified
+	customToken := req.Token != ""
+	if !customToken {
+		u, err := uuid.NewV4()
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to generate token UUID", err)
+		}
+		req.Token = u.String()
+	}
 
 	// If provided, check that the AgentID is valid BEFORE creating the join token so we can fail early
 	var agentID spiffeid.ID
-	var err error
 	if req.AgentId != nil {
-		agentID, err = api.TrustDomainWorkloadIDFromProto(s.td, req.AgentId)
+		agentID, err = s.joinTokenAgentID(req.AgentId, req.Token)
 		if err != nil {
 			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
 		}
-		log.WithField(telemetry.SPIFFEID, agentID.String())
+		log = log.WithField(telemetry.SPIFFEID, agentID.String())
 	}
 
-	// Generate a token if one wasn't specified
-	if req.Token == "" {
-		u, err := uuid.NewV4()
+	// A custom token may have been created by a previous call, in which case
+	// it is reused so that the call can be safely retried.
+	var joinToken *datastore.JoinToken
+	if customToken {
+		resp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+			Token: req.Token,
+		})
 		if err != nil {
-			return nil, api.MakeErr(log, codes.Internal, "failed to generate token UUID", err)
+			return nil, api.MakeErr(log, codes.Internal, "failed to fetch token", err)
+		}
+		joinToken = resp.JoinToken
+		switch {
+		case joinToken == nil:
+		case joinToken.UsedAt != 0:
+			return nil, api.MakeErr(log, codes.FailedPrecondition, "token has already been used", nil)
+		case time.Unix(joinToken.Expiry, 0).Before(s.clk.Now()):
+			return nil, api.MakeErr(log, codes.FailedPrecondition, "token has expired", nil)
+		case joinToken.Ttl != 0 && joinToken.Ttl != req.Ttl:
+			return nil, api.MakeErr(log, codes.AlreadyExists, fmt.Sprintf("token already exists with a TTL of %d seconds", joinToken.Ttl), nil)
 		}
-		req.Token = u.String()
 	}
 
-	expiry := time.Now().Unix() + int64(req.Ttl)
-
-	result, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
-		JoinToken: &datastore.JoinToken{
-			Token:  req.Token,
-			Expiry: expiry,
-		},
-	})
-	if err != nil {
-		return nil, api.MakeErr(log, codes.Internal, "failed to create token", err)
+	if joinToken == nil {
+		result, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: &datastore.JoinToken{
+				Token:  req.Token,
+				Expiry: s.clk.Now().Unix() + int64(req.Ttl),
+				Ttl:    req.Ttl,
+			},
+		})
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to create token", err)
+		}
+		joinToken = result.JoinToken
 	}
 
 	if req.AgentId != nil {
-		err := s.createJoinTokenRegistrationEntry(ctx, req.Token, agentID.String())
+		err := s.createJoinTokenRegistrationEntry(ctx, req.Token, agentID.String(), agentSelectors, req.AgentTtl)
 		if err != nil {
 			return nil, api.MakeErr(log, codes.Internal, "failed to create join token registration entry", err)
 		}
 	}
 
-	return &types.JoinToken{Value: result.JoinToken.Token, ExpiresAt: expiry}, nil
+	return &types.JoinToken{Value: joinToken.Token, ExpiresAt: joinToken.Expiry}, nil
+}
+
+func (s *Service) ListJoinTokens(ctx context.Context, req *agent.ListJoinTokensRequest) (*agent.ListJoinTokensResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListJoinTokensRequest{}
+
+	// Parse proto filter into datastore request
+	if req.Filter != nil {
+		filter := req.Filter
+		if filter.ByExpired != nil {
+			now := &wrapperspb.Int64Value{Value: s.clk.Now().Unix()}
+			if filter.ByExpired.Value {
+				listReq.ByExpiresBefore = now
+			} else {
+				listReq.ByExpiresAtOrAfter = now
+			}
+		}
+		listReq.ByUsed = filter.ByUsed
+	}
+
+	// Set pagination parameters
+	if req.PageSize > 0 {
+		listReq.Pagination = &datastore.Pagination{
+			PageSize: req.PageSize,
+			Token:    req.PageToken,
+		}
+	}
+
+	dsResp, err := s.ds.ListJoinTokens(ctx, listReq)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list join tokens", err)
+	}
+
+	resp := &agent.ListJoinTokensResponse{}
+
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+
+	for _, joinToken := range dsResp.JoinTokens {
+		info := &agent.JoinTokenInfo{
+			Id:        joinTokenID(joinToken.Token),
+			ExpiresAt: joinToken.Expiry,
+			UsedAt:    joinToken.UsedAt,
+		}
+		if req.IncludeValues {
+			info.Value = joinToken.Token
+		}
+		resp.JoinTokens = append(resp.JoinTokens, info)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) PruneJoinTokens(ctx context.Context, req *agent.PruneJoinTokensRequest) (*emptypb.Empty, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.ExpiresBefore < 0 {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "expires before cannot be negative", nil)
+	}
+
+	expiresBefore := req.ExpiresBefore
+	if expiresBefore == 0 {
+		expiresBefore = s.clk.Now().Unix()
+	}
+
+	if _, err := s.ds.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{
+		ExpiresBefore: expiresBefore,
+	}); err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to prune join tokens", err)
+	}
+
+	log.WithField(telemetry.Expiration, expiresBefore).Info("Join tokens pruned")
+	return &emptypb.Empty{}, nil
+}
+
+// joinTokenID returns an identifier for the given token that can be shown
+// without disclosing the token itself.
+func joinTokenID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// joinTokenAgentID renders the agent ID path template with the given token
+// and validates the resulting ID.
+func (s *Service) joinTokenAgentID(id *types.SPIFFEID, token string) (spiffeid.ID, error) {
+	tmpl, err := template.New("agent ID").Option("missingkey=error").Parse(id.Path)
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+
+	var agentPath strings.Builder
+	if err := tmpl.Execute(&agentPath, struct{ Token string }{Token: token}); err != nil {
+		return spiffeid.ID{}, err
+	}
+
+	return api.TrustDomainWorkloadIDFromProto(s.td, &types.SPIFFEID{
+		TrustDomain: id.TrustDomain,
+		Path:        agentPath.String(),
+	})
 }
 
-func (s *Service) createJoinTokenRegistrationEntry(ctx context.Context, token string, agentID string) error {
+// createJoinTokenRegistrationEntry creates the registration entry that maps
+// the join token agent ID to the given agent ID, unless an identical entry
+// already exists.
+func (s *Service) createJoinTokenRegistrationEntry(ctx context.Context, token string, agentID string, selectors []*common.Selector, ttl int32) error {
 	parentID := s.td.NewID(path.Join("spire", "agent", "join_token", token))
+	selectors = append([]*common.Selector{
+		{Type: "spiffe_id", Value: parentID.String()},
+	}, selectors...)
+
+	existing, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		ByParentId: &wrapperspb.StringValue{Value: parentID.String()},
+		BySpiffeId: &wrapperspb.StringValue{Value: agentID},
+		BySelectors: &datastore.BySelectors{
+			Selectors: selectors,
+			Match:     datastore.BySelectors_MATCH_EXACT,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range existing.Entries {
+		if entry.Ttl == ttl {
+			return nil
+		}
+	}
+
 	req := &datastore.CreateRegistrationEntryRequest{
 		Entry: &common.RegistrationEntry{
-			ParentId: parentID.String(),
-			SpiffeId: agentID,
-			Selectors: []*common.Selector{
-				{Type: "spiffe_id", Value: parentID.String()},
-			},
+			ParentId:  parentID.String(),
+			SpiffeId:  agentID,
+			Selectors: selectors,
+			Ttl:       ttl,
 		},
 	}
-	_, err := s.ds.CreateRegistrationEntry(ctx, req)
+	_, err = s.ds.CreateRegistrationEntry(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -497,16 +953,17 @@ func (s *Service) attestJoinToken(ctx context.Context, token string) (*nodeattes
 	switch {
 	case err != nil:
 		return nil, api.MakeErr(log, codes.Internal, "failed to fetch join token", err)
-	case resp.JoinToken == nil:
+	case resp.JoinToken == nil, resp.JoinToken.UsedAt != 0:
 		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to attest: join token does not exist or has already been used", nil)
 	}
 
-	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: token,
+	_, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token:  token,
+		UsedAt: s.clk.Now().Unix(),
 	})
 	switch {
 	case err != nil:
-		return nil, api.MakeErr(log, codes.Internal, "failed to delete join token", err)
+		return nil, api.MakeErr(log, codes.Internal, "failed to mark join token as used", err)
 	case time.Unix(resp.JoinToken.Expiry, 0).Before(s.clk.Now()):
 		return nil, api.MakeErr(log, codes.InvalidArgument, "join token expired", nil)
 	}
@@ -3,7 +3,9 @@ package agent_test
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -19,6 +21,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/agent/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
@@ -123,6 +126,7 @@ func TestListAgents(t *testing.T) {
 
 	notAfter := time.Now().Add(-time.Minute).Unix()
 	newNoAfter := time.Now().Add(time.Minute).Unix()
+	node3NotAfter := time.Now().Add(time.Hour).Unix()
 	node1ID := spiffeid.Must("example.org", "node1")
 	node1 := &common.AttestedNode{
 		SpiffeId:            node1ID.String(),
@@ -176,7 +180,7 @@ func TestListAgents(t *testing.T) {
 		SpiffeId:            node3ID.String(),
 		AttestationDataType: "t3",
 		CertSerialNumber:    "",
-		CertNotAfter:        notAfter,
+		CertNotAfter:        node3NotAfter,
 		NewCertNotAfter:     newNoAfter,
 		NewCertSerialNumber: "",
 	}
@@ -239,7 +243,7 @@ func TestListAgents(t *testing.T) {
 						Id:                   api.ProtoFromID(node3ID),
 						AttestationType:      "t3",
 						Banned:               true,
-						X509SvidExpiresAt:    notAfter,
+						X509SvidExpiresAt:    node3NotAfter,
 						X509SvidSerialNumber: "",
 					},
 				},
@@ -301,6 +305,51 @@ func TestListAgents(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "by expires before",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{},
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					ByExpiresBefore: &wrapperspb.Int64Value{Value: node3NotAfter},
+				},
+			},
+			expectResp: &agentpb.ListAgentsResponse{
+				Agents: []*types.Agent{
+					{Id: api.ProtoFromID(node1ID)},
+					{Id: api.ProtoFromID(node2ID)},
+				},
+			},
+		},
+		{
+			name: "by expires at or after",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{},
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					ByExpiresAtOrAfter: &wrapperspb.Int64Value{Value: node3NotAfter},
+				},
+			},
+			expectResp: &agentpb.ListAgentsResponse{
+				Agents: []*types.Agent{
+					{Id: api.ProtoFromID(node3ID)},
+				},
+			},
+		},
+		{
+			name: "by expiry window",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{},
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					ByExpiresAtOrAfter: &wrapperspb.Int64Value{Value: notAfter},
+					ByExpiresBefore:    &wrapperspb.Int64Value{Value: notAfter + 1},
+				},
+			},
+			expectResp: &agentpb.ListAgentsResponse{
+				Agents: []*types.Agent{
+					{Id: api.ProtoFromID(node1ID)},
+					{Id: api.ProtoFromID(node2ID)},
+				},
+			},
+		},
 		{
 			name: "by selectors",
 			req: &agentpb.ListAgentsRequest{
@@ -602,6 +651,149 @@ func TestBanAgent(t *testing.T) {
 	}
 }
 
+func TestBanAgentRevokeEntries(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	ctx := context.Background()
+
+	serverID := "spiffe://example.org/spire/server"
+	createNode := func(id string, notAfter time.Time, selectors ...*common.Selector) {
+		_, err := test.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            id,
+				AttestationDataType: "attestation-type",
+				CertSerialNumber:    "1234",
+				CertNotAfter:        notAfter.Unix(),
+			},
+		})
+		require.NoError(t, err)
+		_, err = test.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  id,
+				Selectors: selectors,
+			},
+		})
+		require.NoError(t, err)
+	}
+	createEntry := func(parentID, spiffeID string, expiry int64, selectors ...*common.Selector) *common.RegistrationEntry {
+		resp, err := test.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: &common.RegistrationEntry{
+				ParentId:    parentID,
+				SpiffeId:    spiffeID,
+				EntryExpiry: expiry,
+				Selectors:   selectors,
+			},
+		})
+		require.NoError(t, err)
+		return resp.Entry
+	}
+
+	exclusiveSelector := &common.Selector{Type: "node", Value: "exclusive"}
+	sharedSelector := &common.Selector{Type: "node", Value: "shared"}
+	workloadSelector := &common.Selector{Type: "unix", Value: "uid:1000"}
+
+	createNode(agent1, test.clk.Now().Add(time.Hour), exclusiveSelector, sharedSelector)
+	createNode(agent2, test.clk.Now().Add(time.Hour), sharedSelector)
+	// Expired agents cannot use the node aliases they map to
+	createNode("spiffe://example.org/spire/agent/expired", test.clk.Now().Add(-time.Second), exclusiveSelector)
+
+	exclusiveAlias := createEntry(serverID, "spiffe://example.org/exclusive", 0, exclusiveSelector)
+	sharedAlias := createEntry(serverID, "spiffe://example.org/shared", 0, sharedSelector)
+	agentChild := createEntry(agent1, "spiffe://example.org/agent-child", 0, workloadSelector)
+	aliasChild := createEntry(exclusiveAlias.SpiffeId, "spiffe://example.org/alias-child", 0, workloadSelector)
+	expiringChild := createEntry(agent1, "spiffe://example.org/expiring-child", test.clk.Now().Unix(), workloadSelector)
+	sharedChild := createEntry(sharedAlias.SpiffeId, "spiffe://example.org/shared-child", 0, workloadSelector)
+
+	t.Run("grace period without revoking entries", func(t *testing.T) {
+		_, err := test.client.BanAgent(ctx, &agentpb.BanAgentRequest{
+			Id:                       &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"},
+			EntryDeletionGracePeriod: 60,
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "entry deletion grace period requires revoking entries")
+	})
+
+	t.Run("negative grace period", func(t *testing.T) {
+		_, err := test.client.BanAgent(ctx, &agentpb.BanAgentRequest{
+			Id:                       &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"},
+			RevokeEntries:            true,
+			EntryDeletionGracePeriod: -1,
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "entry deletion grace period cannot be negative")
+	})
+
+	t.Run("revocation fails", func(t *testing.T) {
+		test.logHook.Reset()
+		test.ds.AppendNextError(nil)
+		test.ds.AppendNextError(errors.New("oh no"))
+		resp, err := test.client.BanAgent(ctx, &agentpb.BanAgentRequest{
+			Id:            &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"},
+			RevokeEntries: true,
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, &agentpb.BanAgentResponse{
+			RevokeEntriesStatus: &types.Status{
+				Code:    int32(codes.Internal),
+				Message: "agent banned but failed to revoke agent entries: oh no",
+			},
+		}, resp)
+
+		fetchResp, err := test.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: agent1})
+		require.NoError(t, err)
+		require.Empty(t, fetchResp.Node.CertSerialNumber)
+
+		entryResp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: exclusiveAlias.EntryId})
+		require.NoError(t, err)
+		require.NotNil(t, entryResp.Entry)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		test.logHook.Reset()
+		resp, err := test.client.BanAgent(ctx, &agentpb.BanAgentRequest{
+			Id:                       &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"},
+			RevokeEntries:            true,
+			EntryDeletionGracePeriod: 60,
+		})
+		require.NoError(t, err)
+
+		expiresAt := test.clk.Now().Add(time.Minute).Unix()
+		require.Equal(t, []string{exclusiveAlias.EntryId}, resp.RevokedEntryIds)
+		require.ElementsMatch(t, []string{agentChild.EntryId, expiringChild.EntryId, aliasChild.EntryId}, resp.ScheduledEntryIds)
+		require.Equal(t, expiresAt, resp.ScheduledDeletionAt)
+		spiretest.RequireProtoEqual(t, api.OK(), resp.RevokeEntriesStatus)
+
+		spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+			{
+				Level:   logrus.InfoLevel,
+				Message: "Agent banned",
+				Data: logrus.Fields{
+					telemetry.SPIFFEID: agent1,
+				},
+			},
+			{
+				Level:   logrus.InfoLevel,
+				Message: "Agent entries revoked",
+				Data: logrus.Fields{
+					telemetry.SPIFFEID:  agent1,
+					telemetry.Revoked:   "1",
+					telemetry.Scheduled: "3",
+				},
+			},
+		})
+
+		fetchEntry := func(id string) *common.RegistrationEntry {
+			resp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: id})
+			require.NoError(t, err)
+			return resp.Entry
+		}
+		require.Nil(t, fetchEntry(exclusiveAlias.EntryId))
+		require.Equal(t, int64(0), fetchEntry(sharedAlias.EntryId).EntryExpiry)
+		require.Equal(t, expiresAt, fetchEntry(agentChild.EntryId).EntryExpiry)
+		require.Equal(t, expiresAt, fetchEntry(aliasChild.EntryId).EntryExpiry)
+		require.Equal(t, expiringChild.EntryExpiry, fetchEntry(expiringChild.EntryId).EntryExpiry)
+		require.Equal(t, int64(0), fetchEntry(sharedChild.EntryId).EntryExpiry)
+	})
+}
+
 func TestDeleteAgent(t *testing.T) {
 	node1 := &common.AttestedNode{
 		SpiffeId: "spiffe://example.org/spire/agent/node1",
@@ -748,6 +940,9 @@ func TestDeleteAgent(t *testing.T) {
 			require.NoError(t, err)
 			test.ds.SetNextError(tt.dsError)
 
+			bundle := &common.Bundle{TrustDomainId: td.IDString()}
+			test.bundleTracker.Record(node1.SpiffeId, bundle)
+
 			resp, err := test.client.DeleteAgent(ctx, tt.req)
 
 			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
@@ -762,11 +957,15 @@ func TestDeleteAgent(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, node.Node)
 
+				// Verify bundle sync status was kept
+				require.Equal(t, 1, test.bundleTracker.SyncedAgents(propagation.Digest(bundle)))
+
 				return
 			}
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
+			require.Equal(t, 0, test.bundleTracker.SyncedAgents(propagation.Digest(bundle)))
 
 			id := spiffeid.Must(tt.req.Id.TrustDomain, tt.req.Id.Path)
 
@@ -781,13 +980,14 @@ func TestDeleteAgent(t *testing.T) {
 
 func TestGetAgent(t *testing.T) {
 	for _, tt := range []struct {
-		name    string
-		req     *agentpb.GetAgentRequest
-		agent   *types.Agent
-		code    codes.Code
-		err     string
-		logs    []spiretest.LogEntry
-		dsError error
+		name     string
+		req      *agentpb.GetAgentRequest
+		agent    *types.Agent
+		code     codes.Code
+		err      string
+		logs     []spiretest.LogEntry
+		dsError  error
+		dsErrors []error
 	}{
 		{
 			name:  "success agent-1",
@@ -822,6 +1022,34 @@ func TestGetAgent(t *testing.T) {
 				Id: expectedAgents[agent1].Id,
 			},
 		},
+		{
+			name: "selectors are not fetched when masked out",
+			req: &agentpb.GetAgentRequest{Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"},
+				OutputMask: &types.AgentMask{AttestationType: true}},
+			// The selectors lookup would fail if it were done
+			dsErrors: []error{errors.New("selectors error")},
+			agent: &types.Agent{
+				Id:              expectedAgents[agent1].Id,
+				AttestationType: expectedAgents[agent1].AttestationType,
+			},
+		},
+		{
+			name:     "selectors datastore error",
+			req:      &agentpb.GetAgentRequest{Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent-1"}},
+			dsErrors: []error{errors.New("selectors error")},
+			logs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to get selectors from agent",
+					Data: logrus.Fields{
+						logrus.ErrorKey:    "failed to get node selectors: selectors error",
+						telemetry.SPIFFEID: "spiffe://example.org/spire/agent/agent-1",
+					},
+				},
+			},
+			err:  "failed to get selectors from agent: failed to get node selectors: selectors error",
+			code: codes.Internal,
+		},
 		{
 			name: "no SPIFFE ID",
 			req:  &agentpb.GetAgentRequest{},
@@ -890,6 +1118,9 @@ func TestGetAgent(t *testing.T) {
 			test := setupServiceTest(t)
 			test.createTestNodes(ctx, t)
 			test.ds.SetNextError(tt.dsError)
+			for _, err := range tt.dsErrors {
+				test.ds.AppendNextError(err)
+			}
 			agent, err := test.client.GetAgent(context.Background(), tt.req)
 			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.logs)
 			if tt.err != "" {
@@ -1149,6 +1380,13 @@ func TestRenewAgent(t *testing.T) {
 			require.Equal(t, expiredAt, x509Svid.NotAfter)
 			require.Equal(t, []*url.URL{agentID.URL()}, x509Svid.URIs)
 
+			// Validate the rotation hint, which falls within the sixth of the
+			// lifetime that precedes its half
+			lifetime := x509Svid.NotAfter.Sub(x509Svid.NotBefore)
+			halfLife := x509Svid.NotBefore.Add(lifetime / 2)
+			require.LessOrEqual(t, resp.RotateAfter, halfLife.Unix())
+			require.GreaterOrEqual(t, resp.RotateAfter, halfLife.Add(-lifetime/6).Unix())
+
 			// Validate attested node in datastore
 			updatedNode, err := test.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
 				SpiffeId: agentID.String(),
@@ -1197,6 +1435,44 @@ func TestCreateJoinToken(t *testing.T) {
 			err:  "ttl is required, you must provide one",
 			code: codes.InvalidArgument,
 		},
+		{
+			name: "Fail Agent Selectors Without Agent ID",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:            1000,
+				AgentSelectors: []*types.Selector{{Type: "a", Value: "1"}},
+			},
+			err:  "agent selectors and TTL require an agent ID",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Negative Agent Ttl",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:      1000,
+				AgentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/valid"},
+				AgentTtl: -1,
+			},
+			err:  "agent TTL cannot be negative",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Invalid Agent Selectors",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:            1000,
+				AgentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/valid"},
+				AgentSelectors: []*types.Selector{{Type: "a"}},
+			},
+			err:  "invalid agent selectors: missing selector value",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Invalid Agent ID Template",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:     1000,
+				AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host/{{ .Token "},
+			},
+			err:  "invalid agent ID: template: agent ID:1: unclosed action",
+			code: codes.InvalidArgument,
+		},
 		{
 			name: "Fail Datastore Error",
 			err:  "failed to create token: datatore broken",
@@ -1249,6 +1525,273 @@ func TestCreateJoinTokenWithAgentId(t *testing.T) {
 	require.Equal(t, "spiffe://example.org/spire/agent/join_token/"+token.Value, listEntries.Entries[0].Selectors[0].Value)
 }
 
+func TestCreateJoinTokenWithAgentEntryTemplate(t *testing.T) {
+	test := setupServiceTest(t)
+
+	req := &agentpb.CreateJoinTokenRequest{
+		Ttl:     1000,
+		Token:   "bootstrap",
+		AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host/{{ .Token }}"},
+		AgentSelectors: []*types.Selector{
+			{Type: "type", Value: "value"},
+		},
+		AgentTtl: 60,
+	}
+
+	token, err := test.client.CreateJoinToken(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "bootstrap", token.Value)
+	require.Equal(t, test.clk.Now().Unix()+1000, token.ExpiresAt)
+
+	// Creating the same token again succeeds without creating anything new
+	again, err := test.client.CreateJoinToken(context.Background(), req)
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, token, again)
+
+	listEntries, err := test.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listEntries.Entries, 1)
+	entry := listEntries.Entries[0]
+	require.Equal(t, "spiffe://example.org/host/bootstrap", entry.SpiffeId)
+	require.Equal(t, "spiffe://example.org/spire/agent/join_token/bootstrap", entry.ParentId)
+	require.Equal(t, int32(60), entry.Ttl)
+	spiretest.AssertProtoListEqual(t, []*common.Selector{
+		{Type: "spiffe_id", Value: "spiffe://example.org/spire/agent/join_token/bootstrap"},
+		{Type: "type", Value: "value"},
+	}, entry.Selectors)
+}
+
+func TestCreateJoinTokenAlreadyUsed(t *testing.T) {
+	test := setupServiceTest(t)
+	ctx := context.Background()
+
+	req := &agentpb.CreateJoinTokenRequest{Ttl: 1000, Token: "token"}
+	_, err := test.client.CreateJoinToken(ctx, req)
+	require.NoError(t, err)
+	_, err = test.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "token", UsedAt: test.clk.Now().Unix()})
+	require.NoError(t, err)
+
+	// A used token cannot be handed out again
+	_, err = test.client.CreateJoinToken(ctx, req)
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "token has already been used")
+}
+
+func TestCreateJoinTokenExpired(t *testing.T) {
+	test := setupServiceTest(t)
+	ctx := context.Background()
+
+	req := &agentpb.CreateJoinTokenRequest{Ttl: 60, Token: "token"}
+	_, err := test.client.CreateJoinToken(ctx, req)
+	require.NoError(t, err)
+
+	// An expired token is not handed out again
+	test.clk.Add(61 * time.Second)
+	_, err = test.client.CreateJoinToken(ctx, req)
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "token has expired")
+}
+
+func TestCreateJoinTokenTTLMismatch(t *testing.T) {
+	test := setupServiceTest(t)
+	ctx := context.Background()
+
+	_, err := test.client.CreateJoinToken(ctx, &agentpb.CreateJoinTokenRequest{Ttl: 60, Token: "token"})
+	require.NoError(t, err)
+
+	// Retrying with a different TTL fails instead of silently returning the
+	// token with the original expiration
+	_, err = test.client.CreateJoinToken(ctx, &agentpb.CreateJoinTokenRequest{Ttl: 3600, Token: "token"})
+	spiretest.RequireGRPCStatus(t, err, codes.AlreadyExists, "token already exists with a TTL of 60 seconds")
+}
+
+func TestListJoinTokens(t *testing.T) {
+	test := setupServiceTest(t)
+	ctx := context.Background()
+	now := test.clk.Now().Unix()
+
+	for _, joinToken := range []*datastore.JoinToken{
+		{Token: "expired", Expiry: now - 1},
+		{Token: "valid1", Expiry: now},
+		{Token: "valid2", Expiry: now + 60},
+	} {
+		_, err := test.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: joinToken})
+		require.NoError(t, err)
+	}
+
+	_, err := test.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "valid2", UsedAt: now})
+	require.NoError(t, err)
+
+	expired := &agentpb.JoinTokenInfo{Id: joinTokenID("expired"), ExpiresAt: now - 1}
+	valid1 := &agentpb.JoinTokenInfo{Id: joinTokenID("valid1"), ExpiresAt: now}
+	valid2 := &agentpb.JoinTokenInfo{Id: joinTokenID("valid2"), ExpiresAt: now + 60, UsedAt: now}
+
+	for _, tt := range []struct {
+		name         string
+		request      *agentpb.ListJoinTokensRequest
+		expectTokens []*agentpb.JoinTokenInfo
+		err          string
+		code         codes.Code
+		dsError      error
+	}{
+		{
+			name:         "no filter",
+			request:      &agentpb.ListJoinTokensRequest{},
+			expectTokens: []*agentpb.JoinTokenInfo{expired, valid1, valid2},
+		},
+		{
+			name:    "include values",
+			request: &agentpb.ListJoinTokensRequest{IncludeValues: true},
+			expectTokens: []*agentpb.JoinTokenInfo{
+				{Id: expired.Id, Value: "expired", ExpiresAt: now - 1},
+				{Id: valid1.Id, Value: "valid1", ExpiresAt: now},
+				{Id: valid2.Id, Value: "valid2", ExpiresAt: now + 60, UsedAt: now},
+			},
+		},
+		{
+			name: "by expired",
+			request: &agentpb.ListJoinTokensRequest{
+				Filter: &agentpb.ListJoinTokensRequest_Filter{
+					ByExpired: &wrapperspb.BoolValue{Value: true},
+				},
+			},
+			expectTokens: []*agentpb.JoinTokenInfo{expired},
+		},
+		{
+			name: "by not expired",
+			request: &agentpb.ListJoinTokensRequest{
+				Filter: &agentpb.ListJoinTokensRequest_Filter{
+					ByExpired: &wrapperspb.BoolValue{Value: false},
+				},
+			},
+			expectTokens: []*agentpb.JoinTokenInfo{valid1, valid2},
+		},
+		{
+			name: "by used",
+			request: &agentpb.ListJoinTokensRequest{
+				Filter: &agentpb.ListJoinTokensRequest_Filter{
+					ByUsed: &wrapperspb.BoolValue{Value: true},
+				},
+			},
+			expectTokens: []*agentpb.JoinTokenInfo{valid2},
+		},
+		{
+			name: "by not used and not expired",
+			request: &agentpb.ListJoinTokensRequest{
+				Filter: &agentpb.ListJoinTokensRequest_Filter{
+					ByExpired: &wrapperspb.BoolValue{Value: false},
+					ByUsed:    &wrapperspb.BoolValue{Value: false},
+				},
+			},
+			expectTokens: []*agentpb.JoinTokenInfo{valid1},
+		},
+		{
+			name:    "ds fails",
+			request: &agentpb.ListJoinTokensRequest{},
+			err:     "failed to list join tokens: some error",
+			code:    codes.Internal,
+			dsError: errors.New("some error"),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.ds.SetNextError(tt.dsError)
+
+			resp, err := test.client.ListJoinTokens(ctx, tt.request)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoListEqual(t, tt.expectTokens, resp.JoinTokens)
+		})
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		var tokens []*agentpb.JoinTokenInfo
+		req := &agentpb.ListJoinTokensRequest{PageSize: 2}
+		for i := 0; ; i++ {
+			require.Less(t, i, 10, "pagination did not terminate")
+			resp, err := test.client.ListJoinTokens(ctx, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(resp.JoinTokens), 2)
+			tokens = append(tokens, resp.JoinTokens...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		spiretest.AssertProtoListEqual(t, []*agentpb.JoinTokenInfo{expired, valid1, valid2}, tokens)
+	})
+}
+
+func TestPruneJoinTokens(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		expiresBefore int64
+		expectTokens  []string
+		err           string
+		code          codes.Code
+		dsError       error
+	}{
+		{
+			name:         "defaults to now",
+			expectTokens: []string{"valid"},
+		},
+		{
+			name:          "explicit time",
+			expiresBefore: 1,
+			expectTokens:  []string{"expired", "valid"},
+		},
+		{
+			name:          "negative time",
+			expiresBefore: -1,
+			expectTokens:  []string{"expired", "valid"},
+			err:           "expires before cannot be negative",
+			code:          codes.InvalidArgument,
+		},
+		{
+			name:         "ds fails",
+			expectTokens: []string{"expired", "valid"},
+			err:          "failed to prune join tokens: some error",
+			code:         codes.Internal,
+			dsError:      errors.New("some error"),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			ctx := context.Background()
+			now := test.clk.Now().Unix()
+
+			for _, joinToken := range []*datastore.JoinToken{
+				{Token: "expired", Expiry: now - 1},
+				{Token: "valid", Expiry: now},
+			} {
+				_, err := test.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: joinToken})
+				require.NoError(t, err)
+			}
+
+			test.ds.SetNextError(tt.dsError)
+			_, err := test.client.PruneJoinTokens(ctx, &agentpb.PruneJoinTokensRequest{
+				ExpiresBefore: tt.expiresBefore,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			resp, err := test.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+			require.NoError(t, err)
+			var tokens []string
+			for _, joinToken := range resp.JoinTokens {
+				tokens = append(tokens, joinToken.Token)
+			}
+			require.Equal(t, tt.expectTokens, tokens)
+		})
+	}
+}
+
 func TestAttestAgent(t *testing.T) {
 	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testkey.MustEC256())
 	require.NoError(t, err)
@@ -1580,10 +2123,10 @@ func TestAttestAgent(t *testing.T) {
 		},
 
 		{
-			name:       "ds: fails to delete join token",
+			name:       "ds: fails to mark join token as used",
 			request:    getAttestAgentRequest("join_token", []byte("test_token"), testCsr),
 			expectCode: codes.Internal,
-			expectMsg:  "failed to delete join token",
+			expectMsg:  "failed to mark join token as used",
 			dsError: []error{
 				nil,
 				errors.New("some error"),
@@ -1591,7 +2134,7 @@ func TestAttestAgent(t *testing.T) {
 			expectLogs: []spiretest.LogEntry{
 				{
 					Level:   logrus.ErrorLevel,
-					Message: "Failed to delete join token",
+					Message: "Failed to mark join token as used",
 					Data: logrus.Fields{
 						telemetry.NodeAttestorType: "join_token",
 						logrus.ErrorKey:            "some error",
@@ -1769,15 +2312,17 @@ func TestAttestAgent(t *testing.T) {
 }
 
 type serviceTest struct {
-	client       agentpb.AgentClient
-	done         func()
-	ds           *fakedatastore.DataStore
-	ca           *fakeserverca.CA
-	cat          *fakeservercatalog.Catalog
-	logHook      *test.Hook
-	rateLimiter  *fakeRateLimiter
-	withCallerID bool
-	pluginCloser func()
+	client        agentpb.AgentClient
+	done          func()
+	ds            *fakedatastore.DataStore
+	ca            *fakeserverca.CA
+	cat           *fakeservercatalog.Catalog
+	logHook       *test.Hook
+	rateLimiter   *fakeRateLimiter
+	withCallerID  bool
+	pluginCloser  func()
+	bundleTracker *propagation.Tracker
+	clk           *clock.Mock
 }
 
 func (s *serviceTest) Cleanup() {
@@ -1791,13 +2336,16 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	ca := fakeserverca.New(t, td, &fakeserverca.Options{})
 	ds := fakedatastore.New(t)
 	cat := fakeservercatalog.New()
+	bundleTracker := propagation.NewTracker()
+	clk := clock.NewMock(t)
 
 	service := agent.New(agent.Config{
-		ServerCA:    ca,
-		DataStore:   ds,
-		TrustDomain: td,
-		Clock:       clock.NewMock(t),
-		Catalog:     cat,
+		ServerCA:      ca,
+		DataStore:     ds,
+		TrustDomain:   td,
+		Clock:         clk,
+		Catalog:       cat,
+		BundleTracker: bundleTracker,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -1809,11 +2357,13 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	rateLimiter := &fakeRateLimiter{}
 
 	test := &serviceTest{
-		ca:          ca,
-		ds:          ds,
-		cat:         cat,
-		logHook:     logHook,
-		rateLimiter: rateLimiter,
+		ca:            ca,
+		ds:            ds,
+		cat:           cat,
+		logHook:       logHook,
+		rateLimiter:   rateLimiter,
+		bundleTracker: bundleTracker,
+		clk:           clk,
 	}
 
 	contextFn := func(ctx context.Context) context.Context {
@@ -1931,6 +2481,12 @@ func (s *serviceTest) setupNodes(ctx context.Context, t *testing.T) {
 	require.NoError(t, err)
 }
 
+// joinTokenID mirrors the identifier the service derives from a token value
+func joinTokenID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
 func (s *serviceTest) setupJoinTokens(ctx context.Context, t *testing.T) {
 	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
 		JoinToken: &datastore.JoinToken{
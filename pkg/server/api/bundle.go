@@ -23,7 +23,7 @@ func BundleToProto(b *common.Bundle) (*types.Bundle, error) {
 	return &types.Bundle{
 		TrustDomain:     td.String(),
 		RefreshHint:     b.RefreshHint,
-		SequenceNumber:  0,
+		SequenceNumber:  b.SequenceNumber,
 		X509Authorities: CertificatesToProto(b.RootCas),
 		JwtAuthorities:  PublicKeysToProto(b.JwtSigningKeys),
 	}, nil
@@ -58,17 +58,17 @@ func ProtoToBundle(b *types.Bundle) (*common.Bundle, error) {
 
 	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
 	if err != nil {
-		return nil, err
+		return nil, FieldViolation("trust_domain", err)
 	}
 
 	rootCas, err := ParseX509Authorities(b.X509Authorities)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse X.509 authority: %v", err)
+		return nil, fmt.Errorf("unable to parse X.509 authority: %w", err)
 	}
 
 	jwtSigningKeys, err := ParseJWTAuthorities(b.JwtAuthorities)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse JWT authority: %v", err)
+		return nil, fmt.Errorf("unable to parse JWT authority: %w", err)
 	}
 
 	commonBundle := &common.Bundle{
@@ -95,9 +95,9 @@ func ProtoToBundleMask(mask *types.BundleMask) *common.BundleMask {
 
 func ParseX509Authorities(certs []*types.X509Certificate) ([]*common.Certificate, error) {
 	var rootCAs []*common.Certificate
-	for _, rootCA := range certs {
+	for i, rootCA := range certs {
 		if _, err := x509.ParseCertificates(rootCA.Asn1); err != nil {
-			return nil, err
+			return nil, FieldViolation(fmt.Sprintf("x509_authorities[%d].asn1", i), err)
 		}
 
 		rootCAs = append(rootCAs, &common.Certificate{
@@ -110,13 +110,13 @@ func ParseX509Authorities(certs []*types.X509Certificate) ([]*common.Certificate
 
 func ParseJWTAuthorities(keys []*types.JWTKey) ([]*common.PublicKey, error) {
 	var jwtKeys []*common.PublicKey
-	for _, key := range keys {
+	for i, key := range keys {
 		if _, err := x509.ParsePKIXPublicKey(key.PublicKey); err != nil {
-			return nil, err
+			return nil, FieldViolation(fmt.Sprintf("jwt_authorities[%d].public_key", i), err)
 		}
 
 		if key.KeyId == "" {
-			return nil, errors.New("missing key ID")
+			return nil, FieldViolation(fmt.Sprintf("jwt_authorities[%d].key_id", i), errors.New("missing key ID"))
 		}
 
 		jwtKeys = append(jwtKeys, &common.PublicKey{
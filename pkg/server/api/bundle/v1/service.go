@@ -1,14 +1,26 @@
 package bundle
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/audit"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
@@ -17,8 +29,19 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// defaultWatchInterval is the default interval at which WatchBundle checks
+// the bundle for changes.
+const defaultWatchInterval = 5 * time.Second
+
+// minConcurrentBundleConversions is the number of bundles from which
+// ListFederatedBundles converts bundles concurrently. Each worker is given at
+// least this many bundles to convert.
+const minConcurrentBundleConversions = 64
+
 // RegisterService registers the bundle service on the gRPC server.
 func RegisterService(s *grpc.Server, service *Service) {
 	bundle.RegisterBundleServer(s, service)
@@ -39,14 +62,70 @@ type Config struct {
 	DataStore         datastore.DataStore
 	TrustDomain       spiffeid.TrustDomain
 	UpstreamPublisher UpstreamPublisher
+
+	// MaxX509Authorities, if greater than zero, is the maximum number of
+	// X.509 authorities a bundle can hold.
+	MaxX509Authorities int
+
+	// MaxJWTAuthorities, if greater than zero, is the maximum number of JWT
+	// authorities a bundle can hold.
+	MaxJWTAuthorities int
+
+	// MaxBundleBytes, if greater than zero, is the maximum size in bytes of
+	// a serialized bundle.
+	MaxBundleBytes int
+
+	// MinRefreshHint and MaxRefreshHint bound the refresh hint that can be
+	// set on a bundle. If zero, bundleutil.MinimumRefreshHint and
+	// bundleutil.MaximumRefreshHint are used, respectively.
+	MinRefreshHint time.Duration
+	MaxRefreshHint time.Duration
+
+	// BundleTracker, if set, records the bundle served to agents so the
+	// propagation of bundle changes can be reported.
+	BundleTracker *propagation.Tracker
+
+	// WatchInterval is the interval at which WatchBundle checks the bundle
+	// for changes. If zero, defaultWatchInterval is used.
+	WatchInterval time.Duration
+
+	// Clock drives the WatchBundle checks. If unset, the wall clock is used.
+	Clock clock.Clock
+
+	// Metrics is used to emit the size of the bundles served. Optional.
+	Metrics telemetry.Metrics
 }
 
 // New creates a new bundle service
 func New(config Config) *Service {
+	if config.MinRefreshHint == 0 {
+		config.MinRefreshHint = bundleutil.MinimumRefreshHint
+	}
+	if config.MaxRefreshHint == 0 {
+		config.MaxRefreshHint = bundleutil.MaximumRefreshHint
+	}
+	if config.WatchInterval == 0 {
+		config.WatchInterval = defaultWatchInterval
+	}
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	if config.Metrics == nil {
+		config.Metrics = telemetry.Blackhole{}
+	}
 	return &Service{
-		ds: config.DataStore,
-		td: config.TrustDomain,
-		up: config.UpstreamPublisher,
+		ds:                 config.DataStore,
+		td:                 config.TrustDomain,
+		up:                 config.UpstreamPublisher,
+		maxX509Authorities: config.MaxX509Authorities,
+		maxJWTAuthorities:  config.MaxJWTAuthorities,
+		maxBundleBytes:     config.MaxBundleBytes,
+		minRefreshHint:     config.MinRefreshHint,
+		maxRefreshHint:     config.MaxRefreshHint,
+		bt:                 config.BundleTracker,
+		watchInterval:      config.WatchInterval,
+		clk:                config.Clock,
+		metrics:            config.Metrics,
 	}
 }
 
@@ -57,11 +136,28 @@ type Service struct {
 	ds datastore.DataStore
 	td spiffeid.TrustDomain
 	up UpstreamPublisher
+
+	maxX509Authorities int
+	maxJWTAuthorities  int
+	maxBundleBytes     int
+	minRefreshHint     time.Duration
+	maxRefreshHint     time.Duration
+
+	bt *propagation.Tracker
+
+	watchInterval time.Duration
+	clk           clock.Clock
+
+	metrics telemetry.Metrics
 }
 
 func (s *Service) GetBundle(ctx context.Context, req *bundle.GetBundleRequest) (*types.Bundle, error) {
 	log := rpccontext.Logger(ctx)
 
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		return nil, api.MakeErr(log, status.Code(err), "rejecting request due to bundle rate limiting", err)
+	}
+
 	dsResp, err := s.ds.FetchBundle(dscache.WithCache(ctx), &datastore.FetchBundleRequest{
 		TrustDomainId: s.td.IDString(),
 	})
@@ -78,19 +174,120 @@ func (s *Service) GetBundle(ctx context.Context, req *bundle.GetBundleRequest) (
 		return nil, api.MakeErr(log, codes.Internal, "failed to convert bundle", err)
 	}
 
+	s.recordAgentSync(ctx, dsResp.Bundle)
+
 	applyBundleMask(bundle, req.OutputMask)
+	s.emitServedBundleMetrics(ctx, "GetBundle", dsResp.Bundle, bundle)
 	return bundle, nil
 }
 
+func (s *Service) WatchBundle(req *bundle.WatchBundleRequest, stream bundle.Bundle_WatchBundleServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		return api.MakeErr(log, status.Code(err), "rejecting request due to bundle rate limiting", err)
+	}
+
+	ticker := s.clk.Ticker(s.watchInterval)
+	defer ticker.Stop()
+
+	var current *common.Bundle
+	for {
+		// The datastore cache is used so that watchers, however many, do
+		// not fetch the bundle from the datastore more often than the cache
+		// expires.
+		dsResp, err := s.ds.FetchBundle(dscache.WithCache(ctx), &datastore.FetchBundleRequest{
+			TrustDomainId: s.td.IDString(),
+		})
+		switch {
+		case err != nil:
+			return api.MakeErr(log, codes.Internal, "failed to fetch bundle", err)
+		case dsResp.Bundle == nil:
+			return api.MakeErr(log, codes.NotFound, "bundle not found", nil)
+		}
+
+		if !proto.Equal(current, dsResp.Bundle) {
+			current = dsResp.Bundle
+
+			b, err := api.BundleToProto(current)
+			if err != nil {
+				return api.MakeErr(log, codes.Internal, "failed to convert bundle", err)
+			}
+			applyBundleMask(b, req.OutputMask)
+
+			if err := stream.Send(b); err != nil {
+				return api.MakeErr(log, codes.Internal, "failed to send bundle over stream", err)
+			}
+			s.recordAgentSync(ctx, current)
+			s.emitServedBundleMetrics(ctx, "WatchBundle", current, b)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// recordAgentSync records the bundle served to the caller when the caller
+// is an agent of the trust domain.
+func (s *Service) recordAgentSync(ctx context.Context, b *common.Bundle) {
+	if s.bt == nil {
+		return
+	}
+	callerID, ok := s.agentCallerID(ctx)
+	if !ok {
+		return
+	}
+	s.bt.Record(callerID.String(), b)
+}
+
+// agentCallerID returns the SPIFFE ID of the caller when the caller is an
+// agent of the trust domain.
+func (s *Service) agentCallerID(ctx context.Context) (spiffeid.ID, bool) {
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok || !callerID.MemberOf(s.td) || !idutil.IsAgentPath(callerID.Path()) {
+		return spiffeid.ID{}, false
+	}
+	return callerID, true
+}
+
+// emitServedBundleMetrics emits the number of authorities in a served
+// bundle and the size of the bundle as sent to the caller, so that bundles
+// growing large enough to slow down every handshake can be spotted.
+func (s *Service) emitServedBundleMetrics(ctx context.Context, method string, b *common.Bundle, served *types.Bundle) {
+	_, agentCaller := s.agentCallerID(ctx)
+	telemetry_server.SetServedBundleAuthoritiesGauge(s.metrics, b.TrustDomainId, audit.AuthorityTypeX509, len(b.RootCas))
+	telemetry_server.SetServedBundleAuthoritiesGauge(s.metrics, b.TrustDomainId, audit.AuthorityTypeJWT, len(b.JwtSigningKeys))
+	telemetry_server.AddServedBundleSizeSample(s.metrics, b.TrustDomainId, method, agentCaller, proto.Size(served))
+}
+
 func (s *Service) AppendBundle(ctx context.Context, req *bundle.AppendBundleRequest) (*types.Bundle, error) {
 	log := rpccontext.Logger(ctx)
 
-	if len(req.JwtAuthorities) == 0 && len(req.X509Authorities) == 0 {
+	setRefreshHint := false
+	if req.InputMask != nil {
+		if req.InputMask.X509Authorities || req.InputMask.JwtAuthorities || req.InputMask.SequenceNumber {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "only the refresh hint can be set through the input mask", nil)
+		}
+		setRefreshHint = req.InputMask.RefreshHint
+	}
+
+	hasAuthorities := len(req.JwtAuthorities) > 0 || len(req.X509Authorities) > 0
+	if !hasAuthorities && !setRefreshHint {
 		return nil, api.MakeErr(log, codes.InvalidArgument, "no authorities to append", nil)
 	}
 
 	log = log.WithField(telemetry.TrustDomainID, s.td.String())
 
+	if setRefreshHint {
+		if err := s.checkRefreshHint(req.RefreshHint); err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid refresh hint", err)
+		}
+	}
+
 	jwtAuth, err := api.ParseJWTAuthorities(req.JwtAuthorities)
 	if err != nil {
 		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to convert JWT authority", err)
@@ -101,18 +298,63 @@ func (s *Service) AppendBundle(ctx context.Context, req *bundle.AppendBundleRequ
 		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to convert X.509 authority", err)
 	}
 
-	resp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
-		Bundle: &common.Bundle{
-			TrustDomainId:  s.td.IDString(),
-			JwtSigningKeys: jwtAuth,
-			RootCas:        x509Auth,
-		},
-	})
-	if err != nil {
-		return nil, api.MakeErr(log, codes.Internal, "failed to append bundle", err)
+	appended := &common.Bundle{
+		TrustDomainId:  s.td.IDString(),
+		JwtSigningKeys: jwtAuth,
+		RootCas:        x509Auth,
 	}
 
-	bundle, err := api.BundleToProto(resp.Bundle)
+	if err := dedupAuthorities(appended); err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "conflicting authorities", err)
+	}
+
+	var dsBundle *common.Bundle
+	if hasAuthorities {
+		// The bundle limits and the conflicts with existing JWT authorities
+		// are checked by the datastore in the same write as the append, so
+		// that concurrent appends cannot get around them. The refresh hint
+		// is set in the same write.
+		appendReq := &datastore.AppendBundleRequest{
+			Bundle: appended,
+			Limits: s.bundleLimits(),
+		}
+		if setRefreshHint {
+			appendReq.RefreshHint = wrapperspb.Int64(req.RefreshHint)
+		}
+		resp, err := s.ds.AppendBundle(ctx, appendReq)
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.InvalidArgument:
+			return nil, api.MakeErr(log, codes.InvalidArgument, "bundle exceeds configured limits", err)
+		case codes.FailedPrecondition:
+			return nil, api.MakeErr(log, codes.InvalidArgument, "conflicting authorities", api.FieldViolation("jwt_authorities", errors.New(status.Convert(err).Message())))
+		default:
+			return nil, api.MakeErr(log, codes.Internal, "failed to append bundle", err)
+		}
+		dsBundle = resp.Bundle
+	} else {
+		resp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+			Bundle: &common.Bundle{
+				TrustDomainId: s.td.IDString(),
+				RefreshHint:   req.RefreshHint,
+			},
+			InputMask: &common.BundleMask{RefreshHint: true},
+		})
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.NotFound:
+			return nil, api.MakeErr(log, codes.NotFound, "bundle not found", err)
+		default:
+			return nil, api.MakeErr(log, codes.Internal, "failed to set bundle refresh hint", err)
+		}
+		dsBundle = resp.Bundle
+	}
+
+	if setRefreshHint {
+		log.WithField(telemetry.RefreshHint, req.RefreshHint).Info("Bundle refresh hint set")
+	}
+
+	bundle, err := api.BundleToProto(dsBundle)
 	if err != nil {
 		return nil, api.MakeErr(log, codes.Internal, "failed to convert bundle", err)
 	}
@@ -150,6 +392,10 @@ func (s *Service) PublishJWTAuthority(ctx context.Context, req *bundle.PublishJW
 func (s *Service) ListFederatedBundles(ctx context.Context, req *bundle.ListFederatedBundlesRequest) (*bundle.ListFederatedBundlesResponse, error) {
 	log := rpccontext.Logger(ctx)
 
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		return nil, api.MakeErr(log, status.Code(err), "rejecting request due to bundle rate limiting", err)
+	}
+
 	listReq := &datastore.ListBundlesRequest{}
 
 	// Set pagination parameters
@@ -171,6 +417,7 @@ func (s *Service) ListFederatedBundles(ctx context.Context, req *bundle.ListFede
 		resp.NextPageToken = dsResp.Pagination.Token
 	}
 
+	federatedBundles := make([]*common.Bundle, 0, len(dsResp.Bundles))
 	for _, dsBundle := range dsResp.Bundles {
 		log = log.WithField(telemetry.TrustDomainID, dsBundle.TrustDomainId)
 		td, err := spiffeid.TrustDomainFromString(dsBundle.TrustDomainId)
@@ -182,21 +429,85 @@ func (s *Service) ListFederatedBundles(ctx context.Context, req *bundle.ListFede
 		if s.td.Compare(td) == 0 {
 			continue
 		}
+		federatedBundles = append(federatedBundles, dsBundle)
+	}
 
-		b, err := api.BundleToProto(dsBundle)
+	bundles, errs := convertBundles(federatedBundles, req.OutputMask)
+	for i, err := range errs {
 		if err != nil {
+			log = log.WithField(telemetry.TrustDomainID, federatedBundles[i].TrustDomainId)
 			return nil, api.MakeErr(log, codes.Internal, "failed to convert bundle", err)
 		}
-		applyBundleMask(b, req.OutputMask)
-		resp.Bundles = append(resp.Bundles, b)
+	}
+	if len(bundles) > 0 {
+		resp.Bundles = bundles
 	}
 
 	return resp, nil
 }
 
+func (s *Service) CountBundles(ctx context.Context, req *bundle.CountBundlesRequest) (*bundle.CountBundlesResponse, error) {
+	dsResp, err := s.ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	if err != nil {
+		log := rpccontext.Logger(ctx)
+		return nil, api.MakeErr(log, codes.Internal, "failed to count bundles", err)
+	}
+
+	return &bundle.CountBundlesResponse{Count: dsResp.Bundles}, nil
+}
+
+// convertBundles converts the bundles to their API representation and
+// applies the output mask. Conversion dominates the cost of listing large
+// numbers of bundles, so it is spread across a pool of workers, bounded by
+// GOMAXPROCS and by the number of bundles. The returned bundles and errors
+// are in the same order as the input.
+func convertBundles(dsBundles []*common.Bundle, outputMask *types.BundleMask) ([]*types.Bundle, []error) {
+	bundles := make([]*types.Bundle, len(dsBundles))
+	errs := make([]error, len(dsBundles))
+
+	next := int64(-1)
+	convert := func() {
+		for {
+			i := int(atomic.AddInt64(&next, 1))
+			if i >= len(dsBundles) {
+				return
+			}
+			bundles[i], errs[i] = api.BundleToProto(dsBundles[i])
+			if errs[i] == nil {
+				applyBundleMask(bundles[i], outputMask)
+			}
+		}
+	}
+
+	// Small lists are not worth the scheduling overhead
+	workers := len(dsBundles) / minConcurrentBundleConversions
+	if maxWorkers := runtime.GOMAXPROCS(0); workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers < 2 {
+		convert()
+		return bundles, errs
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			convert()
+		}()
+	}
+	wg.Wait()
+	return bundles, errs
+}
+
 func (s *Service) GetFederatedBundle(ctx context.Context, req *bundle.GetFederatedBundleRequest) (*types.Bundle, error) {
 	log := rpccontext.Logger(ctx).WithField(telemetry.TrustDomainID, req.TrustDomain)
 
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		return nil, api.MakeErr(log, status.Code(err), "rejecting request due to bundle rate limiting", err)
+	}
+
 	td, err := spiffeid.TrustDomainFromString(req.TrustDomain)
 	if err != nil {
 		return nil, api.MakeErr(log, codes.InvalidArgument, "trust domain argument is not valid", err)
@@ -223,6 +534,7 @@ func (s *Service) GetFederatedBundle(ctx context.Context, req *bundle.GetFederat
 	}
 
 	applyBundleMask(b, req.OutputMask)
+	s.emitServedBundleMetrics(ctx, "GetFederatedBundle", dsResp.Bundle, b)
 
 	return b, nil
 }
@@ -243,6 +555,7 @@ func (s *Service) createFederatedBundle(ctx context.Context, b *types.Bundle, ou
 
 	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
 	if err != nil {
+		err = api.FieldViolation("trust_domain", err)
 		return &bundle.BatchCreateFederatedBundleResponse_Result{
 			Status: api.MakeStatus(log, codes.InvalidArgument, "trust domain argument is not valid", err),
 		}
@@ -261,6 +574,24 @@ func (s *Service) createFederatedBundle(ctx context.Context, b *types.Bundle, ou
 		}
 	}
 
+	if err := dedupAuthorities(dsBundle); err != nil {
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "conflicting authorities", err),
+		}
+	}
+
+	if err := s.checkRefreshHint(dsBundle.RefreshHint); err != nil {
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "invalid refresh hint", err),
+		}
+	}
+
+	if err := s.checkBundleLimits(dsBundle); err != nil {
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "bundle exceeds configured limits", err),
+		}
+	}
+
 	resp, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
 		Bundle: dsBundle,
 	})
@@ -297,6 +628,7 @@ func (s *Service) setFederatedBundle(ctx context.Context, b *types.Bundle, outpu
 
 	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
 	if err != nil {
+		err = api.FieldViolation("trust_domain", err)
 		return &bundle.BatchSetFederatedBundleResponse_Result{
 			Status: api.MakeStatus(log, codes.InvalidArgument, "trust domain argument is not valid", err),
 		}
@@ -314,11 +646,35 @@ func (s *Service) setFederatedBundle(ctx context.Context, b *types.Bundle, outpu
 			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert bundle", err),
 		}
 	}
+
+	if err := dedupAuthorities(dsBundle); err != nil {
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "conflicting authorities", err),
+		}
+	}
+
+	if err := s.checkRefreshHint(dsBundle.RefreshHint); err != nil {
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "invalid refresh hint", err),
+		}
+	}
+
+	if err := s.checkBundleLimits(dsBundle); err != nil {
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "bundle exceeds configured limits", err),
+		}
+	}
 	resp, err := s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
 		Bundle: dsBundle,
+		Limits: s.bundleLimits(),
 	})
-
-	if err != nil {
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "bundle exceeds configured limits", err),
+		}
+	default:
 		return &bundle.BatchSetFederatedBundleResponse_Result{
 			Status: api.MakeStatus(log, codes.Internal, "failed to set bundle", err),
 		}
@@ -355,6 +711,7 @@ func (s *Service) updateFederatedBundle(ctx context.Context, b *types.Bundle, in
 
 	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
 	if err != nil {
+		err = api.FieldViolation("trust_domain", err)
 		return &bundle.BatchUpdateFederatedBundleResponse_Result{
 			Status: api.MakeStatus(log, codes.InvalidArgument, "trust domain argument is not valid", err),
 		}
@@ -372,13 +729,35 @@ func (s *Service) updateFederatedBundle(ctx context.Context, b *types.Bundle, in
 			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert bundle", err),
 		}
 	}
+
+	if err := dedupAuthorities(dsBundle); err != nil {
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "conflicting authorities", err),
+		}
+	}
+
+	if inputMask == nil || inputMask.RefreshHint {
+		if err := s.checkRefreshHint(dsBundle.RefreshHint); err != nil {
+			return &bundle.BatchUpdateFederatedBundleResponse_Result{
+				Status: api.MakeStatus(log, codes.InvalidArgument, "invalid refresh hint", err),
+			}
+		}
+	}
+
+	// The bundle limits are enforced by the datastore on the updated bundle,
+	// which keeps the fields not in the input mask.
 	resp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
 		Bundle:    dsBundle,
 		InputMask: api.ProtoToBundleMask(inputMask),
+		Limits:    s.bundleLimits(),
 	})
 
 	switch status.Code(err) {
 	case codes.OK:
+	case codes.InvalidArgument:
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "bundle exceeds configured limits", err),
+		}
 	case codes.NotFound:
 		return &bundle.BatchUpdateFederatedBundleResponse_Result{
 			Status: api.MakeStatus(log, codes.NotFound, "bundle not found", err),
@@ -477,6 +856,82 @@ func (s *Service) deleteFederatedBundle(ctx context.Context, log logrus.FieldLog
 	}
 }
 
+// bundleLimits returns the configured limits for the datastore to enforce on
+// the bundles it stores, or nil if no limit is configured.
+func (s *Service) bundleLimits() *datastore.BundleLimits {
+	if s.maxX509Authorities <= 0 && s.maxJWTAuthorities <= 0 && s.maxBundleBytes <= 0 {
+		return nil
+	}
+	return &datastore.BundleLimits{
+		MaxX509Authorities: int32(s.maxX509Authorities),
+		MaxJwtAuthorities:  int32(s.maxJWTAuthorities),
+		MaxBundleBytes:     int32(s.maxBundleBytes),
+	}
+}
+
+// checkBundleLimits returns an error if the bundle exceeds any of the
+// configured limits.
+func (s *Service) checkBundleLimits(b *common.Bundle) error {
+	if s.maxX509Authorities > 0 && len(b.RootCas) > s.maxX509Authorities {
+		return api.FieldViolation("x509_authorities", fmt.Errorf("bundle has %d X.509 authorities, exceeding the maximum of %d", len(b.RootCas), s.maxX509Authorities))
+	}
+	if s.maxJWTAuthorities > 0 && len(b.JwtSigningKeys) > s.maxJWTAuthorities {
+		return api.FieldViolation("jwt_authorities", fmt.Errorf("bundle has %d JWT authorities, exceeding the maximum of %d", len(b.JwtSigningKeys), s.maxJWTAuthorities))
+	}
+	if s.maxBundleBytes > 0 {
+		if size := proto.Size(b); size > s.maxBundleBytes {
+			return api.FieldViolation("bundle", fmt.Errorf("bundle is %d bytes, exceeding the maximum of %d", size, s.maxBundleBytes))
+		}
+	}
+	return nil
+}
+
+// dedupAuthorities removes duplicated authorities from the bundle, keeping
+// the first occurrence. X.509 authorities are duplicated if they have the same
+// DER bytes. JWT authorities are duplicated if they have the same key ID and
+// public key. JWT authorities with the same key ID but different public keys
+// are conflicting and result in an error.
+func dedupAuthorities(b *common.Bundle) error {
+	rootCAs := b.RootCas[:0]
+	seenRootCAs := make(map[string]bool, len(b.RootCas))
+	for _, rootCA := range b.RootCas {
+		if !seenRootCAs[string(rootCA.DerBytes)] {
+			seenRootCAs[string(rootCA.DerBytes)] = true
+			rootCAs = append(rootCAs, rootCA)
+		}
+	}
+	b.RootCas = rootCAs
+
+	jwtSigningKeys := b.JwtSigningKeys[:0]
+	seenJWTSigningKeys := make(map[string][]byte, len(b.JwtSigningKeys))
+	for _, jwtSigningKey := range b.JwtSigningKeys {
+		pkixBytes, ok := seenJWTSigningKeys[jwtSigningKey.Kid]
+		switch {
+		case !ok:
+			seenJWTSigningKeys[jwtSigningKey.Kid] = jwtSigningKey.PkixBytes
+			jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
+		case !bytes.Equal(pkixBytes, jwtSigningKey.PkixBytes):
+			return api.FieldViolation("jwt_authorities", fmt.Errorf("key ID %q is used by more than one public key", jwtSigningKey.Kid))
+		}
+	}
+	b.JwtSigningKeys = jwtSigningKeys
+	return nil
+}
+
+// checkRefreshHint returns an error if the refresh hint, in seconds, is
+// outside of the configured bounds. A zero refresh hint is always allowed,
+// since it lets the refresh hint be calculated from the bundle contents.
+func (s *Service) checkRefreshHint(refreshHint int64) error {
+	if refreshHint == 0 {
+		return nil
+	}
+	d := time.Duration(refreshHint) * time.Second
+	if refreshHint < 0 || d < s.minRefreshHint || d > s.maxRefreshHint {
+		return api.FieldViolation("refresh_hint", fmt.Errorf("refresh hint of %ds is outside of the allowed range of [%s, %s]", refreshHint, s.minRefreshHint, s.maxRefreshHint))
+	}
+	return nil
+}
+
 func parseDeleteMode(mode bundle.BatchDeleteFederatedBundleRequest_Mode) (datastore.DeleteBundleRequest_Mode, error) {
 	switch mode {
 	case bundle.BatchDeleteFederatedBundleRequest_RESTRICT:
@@ -19,17 +19,21 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	bundlepb "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/testca"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -258,6 +262,211 @@ func TestGetBundle(t *testing.T) {
 	}
 }
 
+func TestGetBundleRecordsAgentSync(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		callerID     spiffeid.ID
+		expectSynced int
+	}{
+		{
+			name:         "agent",
+			callerID:     spiffeid.Must("example.org", "spire", "agent", "foo"),
+			expectSynced: 1,
+		},
+		{
+			name:     "workload",
+			callerID: spiffeid.Must("example.org", "workload"),
+		},
+		{
+			name:     "foreign agent",
+			callerID: spiffeid.Must("another-example.org", "spire", "agent", "foo"),
+		},
+		{
+			name: "no caller ID",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := propagation.NewTracker()
+			test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+				c.BundleTracker = tracker
+			})
+			defer test.Cleanup()
+			test.callerID = tt.callerID
+
+			b := makeValidCommonBundle(t, serverTrustDomain)
+			test.setBundle(t, b)
+
+			_, err := test.client.GetBundle(context.Background(), &bundlepb.GetBundleRequest{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectSynced, tracker.SyncedAgents(propagation.Digest(b)))
+		})
+	}
+}
+
+func TestWatchBundle(t *testing.T) {
+	clk := clock.NewMock(t)
+	test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+		c.WatchInterval = time.Minute
+		c.Clock = clk
+	})
+	defer test.Cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	outputMask := &types.BundleMask{X509Authorities: true, RefreshHint: true}
+
+	b := makeValidCommonBundle(t, serverTrustDomain)
+	test.setBundle(t, b)
+
+	stream, err := test.client.WatchBundle(ctx, &bundlepb.WatchBundleRequest{
+		OutputMask: outputMask,
+	})
+	require.NoError(t, err)
+
+	// The current bundle is sent when the stream is opened
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assertCommonBundleWithMask(t, b, resp, outputMask)
+
+	// The bundle is sent again when it changes, on the next check
+	clk.WaitForTicker(time.Minute, "timed out waiting for the watch ticker")
+	b.RefreshHint = 120
+	test.setBundle(t, b)
+	clk.Add(time.Minute)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assertCommonBundleWithMask(t, b, resp, outputMask)
+	require.Equal(t, int64(120), resp.RefreshHint)
+}
+
+func TestWatchBundleNotFound(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	stream, err := test.client.WatchBundle(context.Background(), &bundlepb.WatchBundleRequest{})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "bundle not found")
+	require.Equal(t, "Bundle not found", test.logHook.LastEntry().Message)
+}
+
+func TestServedBundleMetrics(t *testing.T) {
+	agentID := spiffeid.Must("example.org", "spire", "agent", "foo")
+
+	for _, tt := range []struct {
+		name        string
+		callerID    spiffeid.ID
+		trustDomain spiffeid.TrustDomain
+		method      string
+		serve       func(t *testing.T, client bundlepb.BundleClient) *types.Bundle
+		// Metric labels are sanitized
+		expectTrustDomain string
+		expectAgent       string
+	}{
+		{
+			name:        "GetBundle to an agent",
+			callerID:    agentID,
+			trustDomain: serverTrustDomain,
+			method:      "GetBundle",
+			serve: func(t *testing.T, client bundlepb.BundleClient) *types.Bundle {
+				b, err := client.GetBundle(ctx, &bundlepb.GetBundleRequest{})
+				require.NoError(t, err)
+				return b
+			},
+			expectTrustDomain: "spiffe_example_org",
+			expectAgent:       "true",
+		},
+		{
+			name:        "GetFederatedBundle",
+			trustDomain: federatedTrustDomain,
+			method:      "GetFederatedBundle",
+			serve: func(t *testing.T, client bundlepb.BundleClient) *types.Bundle {
+				b, err := client.GetFederatedBundle(ctx, &bundlepb.GetFederatedBundleRequest{
+					TrustDomain: federatedTrustDomain.String(),
+				})
+				require.NoError(t, err)
+				return b
+			},
+			expectTrustDomain: "spiffe_another_example_org",
+			expectAgent:       "false",
+		},
+		{
+			name:        "WatchBundle to an agent",
+			callerID:    agentID,
+			trustDomain: serverTrustDomain,
+			method:      "WatchBundle",
+			serve: func(t *testing.T, client bundlepb.BundleClient) *types.Bundle {
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				stream, err := client.WatchBundle(ctx, &bundlepb.WatchBundleRequest{
+					OutputMask: &types.BundleMask{X509Authorities: true},
+				})
+				require.NoError(t, err)
+				b, err := stream.Recv()
+				require.NoError(t, err)
+				return b
+			},
+			expectTrustDomain: "spiffe_example_org",
+			expectAgent:       "true",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := fakemetrics.New()
+			test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+				c.Metrics = metrics
+			})
+			defer test.Cleanup()
+			test.callerID = tt.callerID
+
+			b := makeValidCommonBundle(t, tt.trustDomain)
+			test.setBundle(t, b)
+
+			served := tt.serve(t, test.client)
+
+			trustDomainLabel := telemetry.Label{Name: telemetry.TrustDomainID, Value: tt.expectTrustDomain}
+			expected := []fakemetrics.MetricItem{
+				{
+					Type: fakemetrics.SetGaugeWithLabelsType,
+					Key:  []string{telemetry.Bundle, telemetry.Served, telemetry.Authority, telemetry.Count},
+					Val:  float32(len(b.RootCas)),
+					Labels: []telemetry.Label{
+						trustDomainLabel,
+						{Name: telemetry.AuthorityType, Value: "x509"},
+					},
+				},
+				{
+					Type: fakemetrics.SetGaugeWithLabelsType,
+					Key:  []string{telemetry.Bundle, telemetry.Served, telemetry.Authority, telemetry.Count},
+					Val:  float32(len(b.JwtSigningKeys)),
+					Labels: []telemetry.Label{
+						trustDomainLabel,
+						{Name: telemetry.AuthorityType, Value: "jwt"},
+					},
+				},
+				{
+					Type: fakemetrics.AddSampleWithLabelsType,
+					Key:  []string{telemetry.Bundle, telemetry.Served, telemetry.Size},
+					Val:  float32(proto.Size(served)),
+					Labels: []telemetry.Label{
+						trustDomainLabel,
+						{Name: telemetry.Method, Value: tt.method},
+						{Name: telemetry.AgentCaller, Value: tt.expectAgent},
+					},
+				},
+			}
+			require.Eventually(t, func() bool {
+				return len(metrics.AllMetrics()) == len(expected)
+			}, time.Second, 10*time.Millisecond)
+			require.Equal(t, expected, metrics.AllMetrics())
+		})
+	}
+}
+
 func TestAppendBundle(t *testing.T) {
 	ca := testca.New(t, serverTrustDomain)
 	rootCA := ca.X509Authorities()[0]
@@ -289,6 +498,9 @@ func TestAppendBundle(t *testing.T) {
 	x509Cert := &types.X509Certificate{
 		Asn1: rootCA.Raw,
 	}
+	otherPKIXBytes, err := x509.MarshalPKIXPublicKey(rootCA.PublicKey)
+	require.NoError(t, err)
+
 	_, expectedX509Err := x509.ParseCertificates([]byte("malformed"))
 	require.Error(t, expectedX509Err)
 
@@ -309,6 +521,8 @@ func TestAppendBundle(t *testing.T) {
 		invalidEntry    bool
 		noBundle        bool
 		outputMask      *types.BundleMask
+		inputMask       *types.BundleMask
+		refreshHint     int64
 	}{
 		{
 			name:            "no output mask defined",
@@ -317,7 +531,7 @@ func TestAppendBundle(t *testing.T) {
 			expectBundle: &types.Bundle{
 				TrustDomain:     defaultBundle.TrustDomain,
 				RefreshHint:     defaultBundle.RefreshHint,
-				SequenceNumber:  defaultBundle.SequenceNumber,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
 				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
 				JwtAuthorities:  append(defaultBundle.JwtAuthorities, jwtKey2),
 			},
@@ -340,7 +554,7 @@ func TestAppendBundle(t *testing.T) {
 			expectBundle: &types.Bundle{
 				TrustDomain:     defaultBundle.TrustDomain,
 				RefreshHint:     defaultBundle.RefreshHint,
-				SequenceNumber:  defaultBundle.SequenceNumber,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
 				JwtAuthorities:  defaultBundle.JwtAuthorities,
 				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
 			},
@@ -351,7 +565,7 @@ func TestAppendBundle(t *testing.T) {
 			expectBundle: &types.Bundle{
 				TrustDomain:     defaultBundle.TrustDomain,
 				RefreshHint:     defaultBundle.RefreshHint,
-				SequenceNumber:  defaultBundle.SequenceNumber,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
 				JwtAuthorities:  append(defaultBundle.JwtAuthorities, jwtKey2),
 				X509Authorities: defaultBundle.X509Authorities,
 			},
@@ -368,6 +582,92 @@ func TestAppendBundle(t *testing.T) {
 				SequenceNumber:  false,
 			},
 		},
+		{
+			name:        "set only refresh hint",
+			inputMask:   &types.BundleMask{RefreshHint: true},
+			refreshHint: 3600,
+			expectBundle: &types.Bundle{
+				TrustDomain:     defaultBundle.TrustDomain,
+				RefreshHint:     3600,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
+				JwtAuthorities:  defaultBundle.JwtAuthorities,
+				X509Authorities: defaultBundle.X509Authorities,
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Bundle refresh hint set",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: serverTrustDomain.String(),
+						telemetry.RefreshHint:   "3600",
+					},
+				},
+			},
+		},
+		{
+			name:            "append authorities and set refresh hint",
+			x509Authorities: []*types.X509Certificate{x509Cert},
+			inputMask:       &types.BundleMask{RefreshHint: true},
+			refreshHint:     3600,
+			expectBundle: &types.Bundle{
+				TrustDomain:     defaultBundle.TrustDomain,
+				RefreshHint:     3600,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
+				JwtAuthorities:  defaultBundle.JwtAuthorities,
+				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Bundle refresh hint set",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: serverTrustDomain.String(),
+						telemetry.RefreshHint:   "3600",
+					},
+				},
+			},
+		},
+		{
+			name:            "refresh hint is ignored unless selected by the input mask",
+			x509Authorities: []*types.X509Certificate{x509Cert},
+			refreshHint:     3600,
+			expectBundle: &types.Bundle{
+				TrustDomain:     defaultBundle.TrustDomain,
+				RefreshHint:     defaultBundle.RefreshHint,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
+				JwtAuthorities:  defaultBundle.JwtAuthorities,
+				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
+			},
+		},
+		{
+			name:        "refresh hint out of bounds",
+			inputMask:   &types.BundleMask{RefreshHint: true},
+			refreshHint: 30,
+			code:        codes.InvalidArgument,
+			err:         "invalid refresh hint: refresh hint of 30s is outside of the allowed range of [1m0s, 168h0m0s]",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid refresh hint",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: serverTrustDomain.String(),
+						logrus.ErrorKey:         "refresh hint of 30s is outside of the allowed range of [1m0s, 168h0m0s]",
+					},
+				},
+			},
+		},
+		{
+			name:      "input mask selects unsupported fields",
+			inputMask: &types.BundleMask{X509Authorities: true},
+			code:      codes.InvalidArgument,
+			err:       "only the refresh hint can be set through the input mask",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: only the refresh hint can be set through the input mask",
+				},
+			},
+		},
 		{
 			name: "no authorities",
 			code: codes.InvalidArgument,
@@ -459,6 +759,71 @@ func TestAppendBundle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "duplicated authorities are dropped",
+			x509Authorities: []*types.X509Certificate{x509Cert, x509Cert},
+			jwtAuthorities: []*types.JWTKey{
+				jwtKey2,
+				jwtKey2,
+				{
+					PublicKey: pkixBytes,
+					KeyId:     "key-id-1",
+					ExpiresAt: expiresAt,
+				},
+			},
+			expectBundle: &types.Bundle{
+				TrustDomain:     defaultBundle.TrustDomain,
+				RefreshHint:     defaultBundle.RefreshHint,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
+				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
+				JwtAuthorities:  append(defaultBundle.JwtAuthorities, jwtKey2),
+			},
+		},
+		{
+			name: "JWT authority conflicts with an existing one",
+			jwtAuthorities: []*types.JWTKey{
+				{
+					PublicKey: otherPKIXBytes,
+					KeyId:     "key-id-1",
+					ExpiresAt: expiresAt,
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  `conflicting authorities: datastore-sql: key ID "key-id-1" is already used by a different public key`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: conflicting authorities",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: serverTrustDomain.String(),
+						logrus.ErrorKey:         `datastore-sql: key ID "key-id-1" is already used by a different public key`,
+					},
+				},
+			},
+		},
+		{
+			name: "JWT authorities conflict with each other",
+			jwtAuthorities: []*types.JWTKey{
+				jwtKey2,
+				{
+					PublicKey: otherPKIXBytes,
+					KeyId:     "key-id-2",
+					ExpiresAt: expiresAt,
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  `conflicting authorities: key ID "key-id-2" is used by more than one public key`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: conflicting authorities",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: serverTrustDomain.String(),
+						logrus.ErrorKey:         `key ID "key-id-2" is used by more than one public key`,
+					},
+				},
+			},
+		},
 		{
 			name:            "if bundle not found, a new bundle is created",
 			x509Authorities: []*types.X509Certificate{x509Cert},
@@ -494,6 +859,8 @@ func TestAppendBundle(t *testing.T) {
 				X509Authorities: tt.x509Authorities,
 				JwtAuthorities:  tt.jwtAuthorities,
 				OutputMask:      tt.outputMask,
+				InputMask:       tt.inputMask,
+				RefreshHint:     tt.refreshHint,
 			})
 
 			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
@@ -524,7 +891,6 @@ func TestBatchDeleteFederatedBundle(t *testing.T) {
 		td3.IDString(),
 	}
 	newEntry := &common.RegistrationEntry{
-		EntryId:  "entry1",
 		ParentId: "spiffe://example.org/foo",
 		SpiffeId: "spiffe://example.org/bar",
 		Ttl:      60,
@@ -1072,6 +1438,186 @@ func TestListFederatedBundles(t *testing.T) {
 	}
 }
 
+func TestBundleReadRateLimiting(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.rateLimiter.err = status.Error(codes.ResourceExhausted, "rate exceeded")
+
+	for _, tt := range []struct {
+		name      string
+		call      func() error
+		logFields logrus.Fields
+	}{
+		{
+			name: "GetBundle",
+			call: func() error {
+				_, err := test.client.GetBundle(ctx, &bundlepb.GetBundleRequest{})
+				return err
+			},
+		},
+		{
+			name: "GetFederatedBundle",
+			call: func() error {
+				_, err := test.client.GetFederatedBundle(ctx, &bundlepb.GetFederatedBundleRequest{TrustDomain: "another-example.org"})
+				return err
+			},
+			logFields: logrus.Fields{
+				telemetry.TrustDomainID: "another-example.org",
+			},
+		},
+		{
+			name: "ListFederatedBundles",
+			call: func() error {
+				_, err := test.client.ListFederatedBundles(ctx, &bundlepb.ListFederatedBundlesRequest{})
+				return err
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+
+			err := tt.call()
+			spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rejecting request due to bundle rate limiting: rate exceeded")
+
+			logFields := logrus.Fields{
+				logrus.ErrorKey: "rpc error: code = ResourceExhausted desc = rate exceeded",
+			}
+			for k, v := range tt.logFields {
+				logFields[k] = v
+			}
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Rejecting request due to bundle rate limiting",
+					Data:    logFields,
+				},
+			})
+		})
+	}
+}
+
+func TestListFederatedBundlesManyBundles(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	// Enough bundles for the conversion to be done concurrently
+	var expectBundles []*common.Bundle
+	for i := 0; i < 200; i++ {
+		expectBundles = append(expectBundles, createBundle(t, test, fmt.Sprintf("spiffe://td%03d.org", i)))
+	}
+
+	outputMask := &types.BundleMask{X509Authorities: true}
+	resp, err := test.client.ListFederatedBundles(context.Background(), &bundlepb.ListFederatedBundlesRequest{
+		OutputMask: outputMask,
+	})
+	require.NoError(t, err)
+
+	// Bundles are returned in datastore order
+	require.Len(t, resp.Bundles, len(expectBundles))
+	for i, actualBundle := range resp.Bundles {
+		assertCommonBundleWithMask(t, expectBundles[i], actualBundle, outputMask)
+	}
+}
+
+func TestCountBundles(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	_ = createBundle(t, test, serverTrustDomain.IDString())
+	_ = createBundle(t, test, "spiffe://td1.org")
+	_ = createBundle(t, test, "spiffe://td2.org")
+
+	for _, tt := range []struct {
+		name        string
+		dsError     error
+		code        codes.Code
+		err         string
+		expectCount int32
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:        "success",
+			expectCount: 3,
+		},
+		{
+			name:    "datastore failure",
+			dsError: errors.New("oh no"),
+			code:    codes.Internal,
+			err:     "failed to count bundles: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to count bundles",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsError)
+
+			resp, err := test.client.CountBundles(context.Background(), &bundlepb.CountBundlesRequest{})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectCount, resp.Count)
+		})
+	}
+}
+
+func BenchmarkListFederatedBundles(b *testing.B) {
+	ctx := context.Background()
+	ds := fakedatastore.New(b)
+	for i := 0; i < 1000; i++ {
+		td := fmt.Sprintf("spiffe://td%04d.org", i)
+		_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: &common.Bundle{
+				TrustDomainId: td,
+				RefreshHint:   60,
+				RootCas:       []*common.Certificate{{DerBytes: []byte("cert-bytes-" + td)}},
+				JwtSigningKeys: []*common.PublicKey{
+					{
+						Kid:       "key-id-" + td,
+						NotAfter:  time.Now().Add(time.Minute).Unix(),
+						PkixBytes: []byte("key-bytes-" + td),
+					},
+				},
+			},
+		})
+		require.NoError(b, err)
+	}
+
+	service := bundle.New(bundle.Config{
+		DataStore:   ds,
+		TrustDomain: serverTrustDomain,
+	})
+	log, _ := test.NewNullLogger()
+	ctx = rpccontext.WithLogger(ctx, log)
+	ctx = rpccontext.WithRateLimiter(ctx, &fakeRateLimiter{count: 1})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := service.ListFederatedBundles(ctx, &bundlepb.ListFederatedBundlesRequest{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(resp.Bundles) != 1000 {
+			b.Fatalf("unexpected number of bundles: %d", len(resp.Bundles))
+		}
+	}
+}
+
 func createBundle(t *testing.T, test *serviceTest, td string) *common.Bundle {
 	b := &common.Bundle{
 		TrustDomainId: td,
@@ -1183,7 +1729,7 @@ func TestBatchCreateFederatedBundle(t *testing.T) {
 				}(),
 			},
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`)},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`), "trust_domain", `spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`)},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1278,7 +1824,7 @@ func TestBatchCreateFederatedBundle(t *testing.T) {
 				},
 			},
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, `failed to convert bundle: unable to parse X.509 authority: %v`, expectedX509Err)},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `failed to convert bundle: unable to parse X.509 authority: %v`, expectedX509Err), "x509_authorities[0].asn1", expectedX509Err.Error())},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1319,6 +1865,8 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 	_, expectedX509Err := x509.ParseCertificates([]byte("malformed"))
 	require.Error(t, expectedX509Err)
 
+	jwtKey, conflictingJWTKey := makeConflictingJWTKeys(t)
+
 	for _, tt := range []struct {
 		name              string
 		bundlesToUpdate   []*types.Bundle
@@ -1338,7 +1886,7 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
 				{
 					Status: api.OK(),
-					Bundle: makeValidBundle(t, federatedTrustDomain),
+					Bundle: withSequenceNumber(makeValidBundle(t, federatedTrustDomain), 1),
 				},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
@@ -1363,7 +1911,7 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
 				{
 					Status: api.OK(),
-					Bundle: makeValidBundle(t, federatedTrustDomain),
+					Bundle: withSequenceNumber(makeValidBundle(t, federatedTrustDomain), 1),
 				},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
@@ -1416,7 +1964,7 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 				}(),
 			},
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`)},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`), "trust_domain", `spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`)},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1500,7 +2048,7 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 				},
 			},
 			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, fmt.Sprintf("failed to convert bundle: unable to parse X.509 authority: %v", expectedX509Err))},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, fmt.Sprintf("failed to convert bundle: unable to parse X.509 authority: %v", expectedX509Err)), "x509_authorities[0].asn1", expectedX509Err.Error())},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1513,6 +2061,61 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:              "Duplicated authorities are dropped",
+			preExistentBundle: &common.Bundle{TrustDomainId: federatedTrustDomain.IDString()},
+			bundlesToUpdate: []*types.Bundle{
+				func() *types.Bundle {
+					b := makeValidBundle(t, federatedTrustDomain)
+					b.X509Authorities = append(b.X509Authorities, b.X509Authorities...)
+					b.JwtAuthorities = []*types.JWTKey{jwtKey, jwtKey}
+					return b
+				}(),
+			},
+			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
+				{
+					Status: api.OK(),
+					Bundle: func() *types.Bundle {
+						b := makeValidBundle(t, federatedTrustDomain)
+						b.JwtAuthorities = []*types.JWTKey{jwtKey}
+						b.SequenceNumber = 1
+						return b
+					}(),
+				},
+			},
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federated bundle updated",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "another-example.org",
+					},
+				},
+			},
+		},
+		{
+			name: "Update fails if JWT authorities conflict",
+			bundlesToUpdate: []*types.Bundle{
+				func() *types.Bundle {
+					b := makeValidBundle(t, federatedTrustDomain)
+					b.JwtAuthorities = []*types.JWTKey{jwtKey, conflictingJWTKey}
+					return b
+				}(),
+			},
+			expectedResults: []*bundlepb.BatchCreateFederatedBundleResponse_Result{
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `conflicting authorities: key ID "key-id" is used by more than one public key`), "jwt_authorities", `key ID "key-id" is used by more than one public key`)},
+			},
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: conflicting authorities",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "another-example.org",
+						logrus.ErrorKey:         `key ID "key-id" is used by more than one public key`,
+					},
+				},
+			},
+		},
 		{
 			name:              "Multiple updates",
 			preExistentBundle: &common.Bundle{TrustDomainId: federatedTrustDomain.IDString()},
@@ -1523,7 +2126,7 @@ func TestBatchUpdateFederatedBundle(t *testing.T) {
 				},
 				{
 					Status: api.OK(),
-					Bundle: makeValidBundle(t, federatedTrustDomain),
+					Bundle: withSequenceNumber(makeValidBundle(t, federatedTrustDomain), 1),
 				},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
@@ -1597,6 +2200,9 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 	// Change the refresh hint
 	updatedBundle.RefreshHint = 120
 
+	tooFrequentBundle := makeValidBundle(t, federatedTrustDomain)
+	tooFrequentBundle.RefreshHint = 10
+
 	for _, tt := range []struct {
 		name            string
 		bundlesToSet    []*types.Bundle
@@ -1650,6 +2256,23 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "Fails if the refresh hint is out of bounds",
+			bundlesToSet: []*types.Bundle{tooFrequentBundle},
+			expectedResults: []*bundlepb.BatchSetFederatedBundleResponse_Result{
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, "invalid refresh hint: refresh hint of 10s is outside of the allowed range of [1m0s, 168h0m0s]"), "refresh_hint", "refresh hint of 10s is outside of the allowed range of [1m0s, 168h0m0s]")},
+			},
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid refresh hint",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "another-example.org",
+						logrus.ErrorKey:         "refresh hint of 10s is outside of the allowed range of [1m0s, 168h0m0s]",
+					},
+				},
+			},
+		},
 		{
 			name:         "Succeeds with nil mask",
 			bundlesToSet: []*types.Bundle{makeValidBundle(t, federatedTrustDomain)},
@@ -1683,7 +2306,7 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 				},
 				{
 					Status: api.OK(),
-					Bundle: updatedBundle,
+					Bundle: withSequenceNumber(updatedBundle, 1),
 				},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
@@ -1713,7 +2336,7 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 				}(),
 			},
 			expectedResults: []*bundlepb.BatchSetFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: trust domain is empty`)},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: trust domain is empty`), "trust_domain", "spiffeid: trust domain is empty")},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1779,7 +2402,7 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 				},
 			},
 			expectedResults: []*bundlepb.BatchSetFederatedBundleResponse_Result{
-				{Status: api.CreateStatus(codes.InvalidArgument, `failed to convert bundle: unable to parse X.509 authority: %v`, expectedX509Err)},
+				{Status: withFieldViolation(api.CreateStatus(codes.InvalidArgument, `failed to convert bundle: unable to parse X.509 authority: %v`, expectedX509Err), "x509_authorities[0].asn1", expectedX509Err.Error())},
 			},
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
@@ -1818,6 +2441,123 @@ func TestBatchSetFederatedBundle(t *testing.T) {
 	}
 }
 
+func TestBundleLimits(t *testing.T) {
+	ca := testca.New(t, serverTrustDomain)
+	otherCA := testca.New(t, serverTrustDomain)
+
+	serverBundle := &common.Bundle{
+		TrustDomainId: serverTrustDomain.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: ca.X509Authorities()[0].Raw}},
+	}
+
+	t.Run("append within limits", func(t *testing.T) {
+		test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+			c.MaxX509Authorities = 1
+		})
+		defer test.Cleanup()
+		test.setBundle(t, serverBundle)
+
+		// Appending an authority already in the bundle does not grow it
+		resp, err := test.client.AppendBundle(ctx, &bundlepb.AppendBundleRequest{
+			X509Authorities: []*types.X509Certificate{{Asn1: ca.X509Authorities()[0].Raw}},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.X509Authorities, 1)
+	})
+
+	t.Run("append exceeds X.509 authorities", func(t *testing.T) {
+		test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+			c.MaxX509Authorities = 1
+		})
+		defer test.Cleanup()
+		test.setBundle(t, serverBundle)
+
+		_, err := test.client.AppendBundle(ctx, &bundlepb.AppendBundleRequest{
+			X509Authorities: []*types.X509Certificate{{Asn1: otherCA.X509Authorities()[0].Raw}},
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "bundle exceeds configured limits: datastore-sql: bundle has 2 X.509 authorities, exceeding the maximum of 1")
+	})
+
+	t.Run("set exceeds JWT authorities", func(t *testing.T) {
+		test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+			c.MaxJWTAuthorities = 1
+		})
+		defer test.Cleanup()
+
+		pkixBytes, err := base64.StdEncoding.DecodeString("MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEYSlUVLqTD8DEnA4F1EWMTf5RXc5lnCxw+5WKJwngEL3rPc9i4Tgzz9riR3I/NiSlkgRO1WsxBusqpC284j9dXA==")
+		require.NoError(t, err)
+
+		b := makeValidBundle(t, federatedTrustDomain)
+		b.JwtAuthorities = []*types.JWTKey{
+			{PublicKey: pkixBytes, KeyId: "key-id"},
+			{PublicKey: pkixBytes, KeyId: "other-key-id"},
+		}
+
+		resp, err := test.client.BatchSetFederatedBundle(ctx, &bundlepb.BatchSetFederatedBundleRequest{
+			Bundle: []*types.Bundle{b},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		spiretest.RequireProtoEqual(t, withFieldViolation(api.CreateStatus(codes.InvalidArgument, "bundle exceeds configured limits: bundle has 2 JWT authorities, exceeding the maximum of 1"), "jwt_authorities", "bundle has 2 JWT authorities, exceeding the maximum of 1"), resp.Results[0].Status)
+	})
+
+	t.Run("create exceeds bundle bytes", func(t *testing.T) {
+		test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+			c.MaxBundleBytes = 64
+		})
+		defer test.Cleanup()
+
+		b := makeValidBundle(t, federatedTrustDomain)
+		dsBundle, err := api.ProtoToBundle(b)
+		require.NoError(t, err)
+		violation := fmt.Sprintf("bundle is %d bytes, exceeding the maximum of 64", proto.Size(dsBundle))
+
+		resp, err := test.client.BatchCreateFederatedBundle(ctx, &bundlepb.BatchCreateFederatedBundleRequest{
+			Bundle: []*types.Bundle{b},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		spiretest.RequireProtoEqual(t, withFieldViolation(api.CreateStatus(codes.InvalidArgument, "bundle exceeds configured limits: "+violation), "bundle", violation), resp.Results[0].Status)
+	})
+
+	t.Run("update checks the stored bundle", func(t *testing.T) {
+		stored, err := api.ProtoToBundle(makeValidBundle(t, federatedTrustDomain))
+		require.NoError(t, err)
+		updated := proto.Clone(stored).(*common.Bundle)
+		updated.RootCas = append(updated.RootCas, &common.Certificate{DerBytes: otherCA.X509Authorities()[0].Raw})
+
+		test := setupServiceTestWithConfig(t, func(c *bundle.Config) {
+			c.MaxBundleBytes = proto.Size(updated) - 1
+		})
+		defer test.Cleanup()
+		test.setBundle(t, stored)
+
+		// The update only carries the X.509 authorities, which are within
+		// the limit on their own, but the stored bundle keeps its JWT
+		// authorities.
+		b := &types.Bundle{TrustDomain: federatedTrustDomain.String()}
+		for _, rootCA := range updated.RootCas {
+			b.X509Authorities = append(b.X509Authorities, &types.X509Certificate{Asn1: rootCA.DerBytes})
+		}
+		resp, err := test.client.BatchUpdateFederatedBundle(ctx, &bundlepb.BatchUpdateFederatedBundleRequest{
+			Bundle:    []*types.Bundle{b},
+			InputMask: &types.BundleMask{X509Authorities: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		require.Equal(t, int32(codes.InvalidArgument), resp.Results[0].Status.Code)
+		require.Regexp(t, fmt.Sprintf("^bundle exceeds configured limits: datastore-sql: bundle is [0-9]+ bytes, exceeding the maximum of %d$", proto.Size(updated)-1), resp.Results[0].Status.Message)
+	})
+}
+
+func withFieldViolation(st *types.Status, field, description string) *types.Status {
+	st.FieldViolations = append(st.FieldViolations, &types.FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+	return st
+}
+
 func assertCommonBundleWithMask(t *testing.T, expected *common.Bundle, actual *types.Bundle, m *types.BundleMask) {
 	exp, err := api.BundleToProto(expected)
 	require.NoError(t, err)
@@ -1870,6 +2610,7 @@ type serviceTest struct {
 	isAdmin     bool
 	isAgent     bool
 	isLocal     bool
+	callerID    spiffeid.ID
 }
 
 func (c *serviceTest) Cleanup() {
@@ -1877,14 +2618,20 @@ func (c *serviceTest) Cleanup() {
 }
 
 func setupServiceTest(t *testing.T) *serviceTest {
+	return setupServiceTestWithConfig(t, func(*bundle.Config) {})
+}
+
+func setupServiceTestWithConfig(t *testing.T, configure func(*bundle.Config)) *serviceTest {
 	ds := fakedatastore.New(t)
 	up := new(fakeUpstreamPublisher)
-	rateLimiter := new(fakeRateLimiter)
-	service := bundle.New(bundle.Config{
+	rateLimiter := &fakeRateLimiter{count: 1}
+	config := bundle.Config{
 		DataStore:         ds,
 		TrustDomain:       serverTrustDomain,
 		UpstreamPublisher: up,
-	})
+	}
+	configure(&config)
+	service := bundle.New(config)
 
 	log, logHook := test.NewNullLogger()
 	log.Level = logrus.DebugLevel
@@ -1901,6 +2648,9 @@ func setupServiceTest(t *testing.T) *serviceTest {
 
 	contextFn := func(ctx context.Context) context.Context {
 		ctx = rpccontext.WithLogger(ctx, log)
+		if !test.callerID.IsZero() {
+			ctx = rpccontext.WithCallerID(ctx, test.callerID)
+		}
 		if test.isAdmin {
 			ctx = rpccontext.WithCallerAdminEntries(ctx, []*types.Entry{{Admin: true}})
 		}
@@ -1925,6 +2675,28 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	return test
 }
 
+// makeConflictingJWTKeys returns two JWT keys with the same key ID but
+// different public keys.
+func makeConflictingJWTKeys(t *testing.T) (*types.JWTKey, *types.JWTKey) {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	pkixBytes1, err := x509.MarshalPKIXPublicKey(testca.New(t, federatedTrustDomain).X509Authorities()[0].PublicKey)
+	require.NoError(t, err)
+	pkixBytes2, err := base64.StdEncoding.DecodeString("MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEYSlUVLqTD8DEnA4F1EWMTf5RXc5lnCxw+5WKJwngEL3rPc9i4Tgzz9riR3I/NiSlkgRO1WsxBusqpC284j9dXA==")
+	require.NoError(t, err)
+
+	return &types.JWTKey{PublicKey: pkixBytes1, KeyId: "key-id", ExpiresAt: expiresAt},
+		&types.JWTKey{PublicKey: pkixBytes2, KeyId: "key-id", ExpiresAt: expiresAt}
+}
+
+// withSequenceNumber returns a copy of the bundle with the sequence number
+// assigned by the datastore after the given number of changes.
+func withSequenceNumber(b *types.Bundle, sequenceNumber uint64) *types.Bundle {
+	b = proto.Clone(b).(*types.Bundle)
+	b.SequenceNumber = sequenceNumber
+	return b
+}
+
 func makeValidBundle(t *testing.T, td spiffeid.TrustDomain) *types.Bundle {
 	b, err := spiffebundle.Parse(td, bundleBytes)
 	require.NoError(t, err)
@@ -26,9 +26,10 @@ func TestBundleToProto(t *testing.T) {
 		{
 			name: "success",
 			bundle: &common.Bundle{
-				TrustDomainId: td.IDString(),
-				RefreshHint:   10,
-				RootCas:       []*common.Certificate{{DerBytes: []byte("cert-bytes")}},
+				TrustDomainId:  td.IDString(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
+				RootCas:        []*common.Certificate{{DerBytes: []byte("cert-bytes")}},
 				JwtSigningKeys: []*common.PublicKey{
 					{
 						Kid:       "key-id-1",
@@ -38,8 +39,9 @@ func TestBundleToProto(t *testing.T) {
 				},
 			},
 			expectBundle: &types.Bundle{
-				TrustDomain: td.String(),
-				RefreshHint: 10,
+				TrustDomain:    td.String(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
 				X509Authorities: []*types.X509Certificate{
 					{
 						Asn1: []byte("cert-bytes"),
@@ -10,11 +10,15 @@ import (
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"google.golang.org/grpc"
@@ -37,6 +41,14 @@ type Config struct {
 	SVIDObserver svid.Observer
 	TrustDomain  spiffeid.TrustDomain
 	Uptime       func() time.Duration
+
+	// BundleTracker, if set, is used to report how many agents have synced
+	// the current trust domain bundle with this server since it started.
+	BundleTracker *propagation.Tracker
+
+	// LogLevels, if set, is used to report and adjust the log levels of the
+	// server subsystems.
+	LogLevels *log.Levels
 }
 
 // New creates a new debug service
@@ -47,6 +59,8 @@ func New(config Config) *Service {
 		so:     config.SVIDObserver,
 		td:     config.TrustDomain,
 		uptime: config.Uptime,
+		bt:     config.BundleTracker,
+		levels: config.LogLevels,
 	}
 }
 
@@ -59,6 +73,8 @@ type Service struct {
 	so     svid.Observer
 	td     spiffeid.TrustDomain
 	uptime func() time.Duration
+	bt     *propagation.Tracker
+	levels *log.Levels
 
 	getInfoResp getInfoResp
 }
@@ -93,7 +109,12 @@ func (s *Service) GetInfo(ctx context.Context, req *debug.GetInfoRequest) (*debu
 			return nil, api.MakeErr(log, codes.Internal, "failed to count bundles", err)
 		}
 
-		svidChain, err := s.getCertificateChain(ctx, log)
+		bundle, err := s.getTrustDomainBundle(ctx, log)
+		if err != nil {
+			return nil, err
+		}
+
+		svidChain, err := s.getCertificateChain(log, bundle)
 		if err != nil {
 			return nil, err
 		}
@@ -107,29 +128,96 @@ func (s *Service) GetInfo(ctx context.Context, req *debug.GetInfoRequest) (*debu
 			SvidChain:             svidChain,
 			Uptime:                int32(s.uptime().Seconds()),
 		}
+
+		if s.bt != nil {
+			digest := propagation.Digest(bundle)
+			s.getInfoResp.resp.BundleDigest = digest
+			s.getInfoResp.resp.InstanceBundleSyncedAgentsCount = int32(s.bt.SyncedAgents(digest))
+			s.getInfoResp.resp.InstanceSyncedAgentsCount = int32(s.bt.Agents())
+		}
 	}
 
 	return s.getInfoResp.resp, nil
 }
 
-func (s *Service) getCertificateChain(ctx context.Context, log logrus.FieldLogger) ([]*debug.GetInfoResponse_Cert, error) {
-	trustDomainID := s.td.IDString()
+// GetLogLevels gets the log levels of the server and its subsystems
+func (s *Service) GetLogLevels(ctx context.Context, req *debug.GetLogLevelsRequest) (*debug.GetLogLevelsResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if s.levels == nil {
+		return nil, api.MakeErr(log, codes.Unimplemented, "log levels are not adjustable", nil)
+	}
 
-	// Extract trustdomains bundle and append federated bundles
-	bundle, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
-		TrustDomainId: trustDomainID,
+	level, subsystemLevels := s.logLevels()
+	return &debug.GetLogLevelsResponse{
+		Level:           level,
+		SubsystemLevels: subsystemLevels,
+	}, nil
+}
+
+// SetLogLevel sets the log level of the server or one of its subsystems
+func (s *Service) SetLogLevel(ctx context.Context, req *debug.SetLogLevelRequest) (*debug.SetLogLevelResponse, error) {
+	log := rpccontext.Logger(ctx).WithFields(logrus.Fields{
+		telemetry.Subsystem: req.Subsystem,
+		telemetry.LogLevel:  req.Level,
+	})
+
+	if s.levels == nil {
+		return nil, api.MakeErr(log, codes.Unimplemented, "log levels are not adjustable", nil)
+	}
+
+	switch {
+	case req.Level == "" && req.Subsystem == "":
+		return nil, api.MakeErr(log, codes.InvalidArgument, "level is required to set the server log level", nil)
+	case req.Level == "":
+		s.levels.ResetLevel(req.Subsystem)
+	default:
+		level, err := logrus.ParseLevel(req.Level)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid log level", err)
+		}
+		if req.Subsystem == "" {
+			s.levels.SetBaseLevel(level)
+		} else {
+			s.levels.SetLevel(req.Subsystem, level)
+		}
+	}
+	log.Info("Log level changed")
+
+	level, subsystemLevels := s.logLevels()
+	return &debug.SetLogLevelResponse{
+		Level:           level,
+		SubsystemLevels: subsystemLevels,
+	}, nil
+}
+
+func (s *Service) logLevels() (string, map[string]string) {
+	subsystemLevels := make(map[string]string)
+	for subsystem, level := range s.levels.SubsystemLevels() {
+		subsystemLevels[subsystem] = level.String()
+	}
+	return s.levels.BaseLevel().String(), subsystemLevels
+}
+
+func (s *Service) getTrustDomainBundle(ctx context.Context, log logrus.FieldLogger) (*common.Bundle, error) {
+	resp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: s.td.IDString(),
 	})
 	if err != nil {
 		return nil, api.MakeErr(log, codes.Internal, "failed to fetch trust domain bundle", err)
 	}
 
-	if bundle.Bundle == nil {
+	if resp.Bundle == nil {
 		return nil, api.MakeErr(log, codes.NotFound, "trust domain bundle not found", nil)
 	}
 
+	return resp.Bundle, nil
+}
+
+func (s *Service) getCertificateChain(log logrus.FieldLogger, bundle *common.Bundle) ([]*debug.GetInfoResponse_Cert, error) {
 	// Create bundle source using rootCAs
 	var rootCAs []*x509.Certificate
-	for _, b := range bundle.Bundle.RootCas {
+	for _, b := range bundle.RootCas {
 		cert, err := x509.ParseCertificate(b.DerBytes)
 		if err != nil {
 			return nil, api.MakeErr(log, codes.Internal, "failed to parse bundle", err)
@@ -13,10 +13,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api/debug/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
 	debugpb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
@@ -427,6 +429,148 @@ func TestGetInfo(t *testing.T) {
 	}
 }
 
+func TestGetInfoBundlePropagation(t *testing.T) {
+	ctx := context.Background()
+	tracker := propagation.NewTracker()
+	test := setupServiceTestWithTracker(t, tracker)
+	defer test.Cleanup()
+
+	ca := testca.New(t, td)
+	x509SVID := ca.CreateX509SVID(td.NewID("/spire/server"))
+	test.so.state = svid.State{SVID: x509SVID.Certificates}
+
+	bundle := &common.Bundle{
+		TrustDomainId: td.IDString(),
+		RootCas: []*common.Certificate{
+			{DerBytes: x509util.DERFromCertificates(ca.X509Authorities())},
+		},
+	}
+	_, err := test.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+	for _, id := range []string{"spiffe://example.org/spire/agent/a", "spiffe://example.org/spire/agent/b"} {
+		_, err := test.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{SpiffeId: id},
+		})
+		require.NoError(t, err)
+	}
+
+	// One agent synced the current bundle, the other one an older bundle
+	tracker.Record("spiffe://example.org/spire/agent/a", bundle)
+	tracker.Record("spiffe://example.org/spire/agent/b", &common.Bundle{
+		TrustDomainId: td.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: []byte("old")}},
+	})
+
+	resp, err := test.client.GetInfo(ctx, &debugpb.GetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.AgentsCount)
+	require.Equal(t, propagation.Digest(bundle), resp.BundleDigest)
+	require.Equal(t, int32(1), resp.InstanceBundleSyncedAgentsCount)
+	require.Equal(t, int32(2), resp.InstanceSyncedAgentsCount)
+}
+
+func TestLogLevels(t *testing.T) {
+	t.Run("not adjustable", func(t *testing.T) {
+		test := setupServiceTest(t)
+		defer test.Cleanup()
+
+		_, err := test.client.GetLogLevels(ctx, &debugpb.GetLogLevelsRequest{})
+		spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "log levels are not adjustable")
+		_, err = test.client.SetLogLevel(ctx, &debugpb.SetLogLevelRequest{Level: "debug"})
+		spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "log levels are not adjustable")
+	})
+
+	base := logrus.New()
+	base.SetLevel(logrus.InfoLevel)
+	levels := log.NewLevels(base)
+	levels.Logger("ca")
+	levels.SetLevel("DataStore:sql", logrus.DebugLevel)
+
+	test := setupServiceTestWithConfig(t, func(config *debug.Config) {
+		config.LogLevels = levels
+	})
+	defer test.Cleanup()
+
+	getResp, err := test.client.GetLogLevels(ctx, &debugpb.GetLogLevelsRequest{})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &debugpb.GetLogLevelsResponse{
+		Level: "info",
+		SubsystemLevels: map[string]string{
+			"ca":            "info",
+			"DataStore:sql": "debug",
+		},
+	}, getResp)
+
+	for _, tt := range []struct {
+		name       string
+		req        *debugpb.SetLogLevelRequest
+		expectCode codes.Code
+		expectMsg  string
+		expectResp *debugpb.SetLogLevelResponse
+	}{
+		{
+			name: "set subsystem level",
+			req:  &debugpb.SetLogLevelRequest{Subsystem: "ca", Level: "warn"},
+			expectResp: &debugpb.SetLogLevelResponse{
+				Level: "info",
+				SubsystemLevels: map[string]string{
+					"ca":            "warning",
+					"DataStore:sql": "debug",
+				},
+			},
+		},
+		{
+			name: "set server level",
+			req:  &debugpb.SetLogLevelRequest{Level: "error"},
+			expectResp: &debugpb.SetLogLevelResponse{
+				Level: "error",
+				SubsystemLevels: map[string]string{
+					"ca":            "warning",
+					"DataStore:sql": "debug",
+				},
+			},
+		},
+		{
+			name: "reset subsystem level",
+			req:  &debugpb.SetLogLevelRequest{Subsystem: "ca"},
+			expectResp: &debugpb.SetLogLevelResponse{
+				Level: "error",
+				SubsystemLevels: map[string]string{
+					"ca":            "error",
+					"DataStore:sql": "debug",
+				},
+			},
+		},
+		{
+			name:       "invalid level",
+			req:        &debugpb.SetLogLevelRequest{Subsystem: "ca", Level: "loud"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid log level: not a valid logrus Level: "loud"`,
+		},
+		{
+			name:       "missing server level",
+			req:        &debugpb.SetLogLevelRequest{},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "level is required to set the server log level",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.SetLogLevel(ctx, tt.req)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+
+	require.Equal(t, logrus.ErrorLevel, levels.Logger("ca").GetLevel())
+	require.Equal(t, logrus.DebugLevel, levels.Logger("DataStore:sql").GetLevel())
+}
+
 type serviceTest struct {
 	client debugpb.DebugClient
 	done   func()
@@ -443,6 +587,16 @@ func (s *serviceTest) Cleanup() {
 }
 
 func setupServiceTest(t *testing.T) *serviceTest {
+	return setupServiceTestWithTracker(t, nil)
+}
+
+func setupServiceTestWithTracker(t *testing.T, tracker *propagation.Tracker) *serviceTest {
+	return setupServiceTestWithConfig(t, func(config *debug.Config) {
+		config.BundleTracker = tracker
+	})
+}
+
+func setupServiceTestWithConfig(t *testing.T, configure func(*debug.Config)) *serviceTest {
 	clk := clock.NewMock()
 	ds := fakedatastore.New(t)
 	log, logHook := test.NewNullLogger()
@@ -453,13 +607,15 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	}
 	observer := &fakeObserver{}
 
-	service := debug.New(debug.Config{
+	config := debug.Config{
 		Clock:        clk,
 		DataStore:    ds,
 		SVIDObserver: observer,
 		TrustDomain:  td,
 		Uptime:       fakeUptime.uptime,
-	})
+	}
+	configure(&config)
+	service := debug.New(config)
 
 	test := &serviceTest{
 		clk:     clk,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
@@ -53,17 +54,23 @@ func RegistrationEntryToProto(e *common.RegistrationEntry) (*types.Entry, error)
 	}
 
 	return &types.Entry{
-		Id:             e.EntryId,
-		SpiffeId:       ProtoFromID(spiffeID),
-		ParentId:       ProtoFromID(parentID),
-		Selectors:      ProtoFromSelectors(e.Selectors),
-		Ttl:            e.Ttl,
-		FederatesWith:  federatesWith,
-		Admin:          e.Admin,
-		Downstream:     e.Downstream,
-		ExpiresAt:      e.EntryExpiry,
-		DnsNames:       append([]string(nil), e.DnsNames...),
-		RevisionNumber: e.RevisionNumber,
+		Id:                  e.EntryId,
+		SpiffeId:            ProtoFromID(spiffeID),
+		ParentId:            ProtoFromID(parentID),
+		Selectors:           ProtoFromSelectors(e.Selectors),
+		Ttl:                 e.Ttl,
+		FederatesWith:       federatesWith,
+		Admin:               e.Admin,
+		Downstream:          e.Downstream,
+		ExpiresAt:           e.EntryExpiry,
+		DnsNames:            append([]string(nil), e.DnsNames...),
+		RevisionNumber:      e.RevisionNumber,
+		X509SvidPrimaryName: e.X509SvidPrimaryName,
+		X509SvidKeyType:     e.X509SvidKeyType,
+		CreatedBy:           e.CreatedBy,
+		CreatedAt:           e.CreatedAt,
+		UpdatedBy:           e.UpdatedBy,
+		UpdatedAt:           e.UpdatedAt,
 	}, nil
 }
 
@@ -164,17 +171,37 @@ func ProtoToRegistrationEntryWithMask(td spiffeid.TrustDomain, e *types.Entry, m
 		revisionNumber = e.RevisionNumber
 	}
 
+	var x509SVIDPrimaryName string
+	if mask.X509SvidPrimaryName {
+		primaryName, err := x509svid.ParsePrimaryName(e.X509SvidPrimaryName)
+		if err != nil {
+			return nil, err
+		}
+		x509SVIDPrimaryName = string(primaryName)
+	}
+
+	var x509SVIDKeyType string
+	if mask.X509SvidKeyType {
+		keyType, err := x509svid.ParseKeyType(e.X509SvidKeyType)
+		if err != nil {
+			return nil, err
+		}
+		x509SVIDKeyType = string(keyType)
+	}
+
 	return &common.RegistrationEntry{
-		EntryId:        e.Id,
-		ParentId:       parentIDString,
-		SpiffeId:       spiffeIDString,
-		Admin:          admin,
-		DnsNames:       dnsNames,
-		Downstream:     downstream,
-		EntryExpiry:    expiresAt,
-		FederatesWith:  federatesWith,
-		Selectors:      selectors,
-		Ttl:            ttl,
-		RevisionNumber: revisionNumber,
+		EntryId:             e.Id,
+		ParentId:            parentIDString,
+		SpiffeId:            spiffeIDString,
+		Admin:               admin,
+		DnsNames:            dnsNames,
+		Downstream:          downstream,
+		EntryExpiry:         expiresAt,
+		FederatesWith:       federatesWith,
+		Selectors:           selectors,
+		Ttl:                 ttl,
+		RevisionNumber:      revisionNumber,
+		X509SvidPrimaryName: x509SVIDPrimaryName,
+		X509SvidKeyType:     x509SVIDKeyType,
 	}, nil
 }
@@ -0,0 +1,201 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchCreateEntryAtomic creates the entries in a single datastore
+// transaction. Any entry that cannot be created fails the whole RPC, and
+// none of the entries is created.
+func (s *Service) batchCreateEntryAtomic(ctx context.Context, req *entry.BatchCreateEntryRequest) (*entry.BatchCreateEntryResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	callerID := callerIDString(ctx)
+	now := s.clk.Now().Unix()
+	usage := newQuotaUsage(s.quotas)
+
+	var cEntries []*common.RegistrationEntry
+	var ops []*datastore.RegistrationEntryOperation
+	for i, e := range req.Entries {
+		cEntry, err := api.ProtoToRegistrationEntry(s.td, e)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, fmt.Sprintf("failed to convert entry %d", i), err)
+		}
+
+		entryLog := log.WithField(telemetry.SPIFFEID, cEntry.SpiffeId)
+		if cEntry.EntryId != "" {
+			entryLog = entryLog.WithField(telemetry.RegistrationID, cEntry.EntryId)
+			if err := validateEntryID(cEntry.EntryId); err != nil {
+				return nil, api.MakeErr(entryLog, codes.InvalidArgument, "invalid entry ID", err)
+			}
+		}
+
+		cEntry.CreatedBy = callerID
+		cEntry.CreatedAt = now
+		cEntry.UpdatedBy = callerID
+		cEntry.UpdatedAt = now
+
+		for j, other := range cEntries {
+			if isSimilarEntry(cEntry, other) {
+				return nil, api.MakeErr(entryLog, codes.InvalidArgument, fmt.Sprintf("entry %d is similar to entry %d", i, j), nil)
+			}
+		}
+
+		existingEntry, err := s.getExistingEntry(ctx, cEntry)
+		if err != nil {
+			return nil, api.MakeErr(entryLog, codes.Internal, "failed to list entries", err)
+		}
+		if existingEntry != nil {
+			return nil, api.MakeErr(entryLog, codes.AlreadyExists, "similar entry already exists", nil)
+		}
+
+		quota, err := usage.check(ctx, s.ds, cEntry.SpiffeId)
+		if err != nil {
+			return nil, api.MakeErr(entryLog, codes.Internal, "failed to count entries for quota", err)
+		}
+		if quota != nil {
+			return nil, api.MakeErr(entryLog, codes.ResourceExhausted, "entry quota exceeded", quota.exceededError())
+		}
+		usage.add(cEntry.SpiffeId)
+
+		cEntries = append(cEntries, cEntry)
+		ops = append(ops, &datastore.RegistrationEntryOperation{
+			Create: &datastore.CreateRegistrationEntryRequest{Entry: cEntry},
+		})
+	}
+
+	entries, err := s.batchRegistrationEntries(ctx, ops, req.OutputMask)
+	if err != nil {
+		return nil, api.MakeErr(log, batchErrorCode(err), "failed to create entries", err)
+	}
+
+	resp := &entry.BatchCreateEntryResponse{}
+	for _, tEntry := range entries {
+		resp.Results = append(resp.Results, &entry.BatchCreateEntryResponse_Result{
+			Status: api.OK(),
+			Entry:  tEntry,
+		})
+	}
+	return resp, nil
+}
+
+// batchUpdateEntryAtomic updates the entries in a single datastore
+// transaction. Any entry that cannot be updated fails the whole RPC, and
+// none of the entries is updated.
+func (s *Service) batchUpdateEntryAtomic(ctx context.Context, req *entry.BatchUpdateEntryRequest) (*entry.BatchUpdateEntryResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	callerID := callerIDString(ctx)
+	now := s.clk.Now().Unix()
+
+	var ops []*datastore.RegistrationEntryOperation
+	for i, e := range req.Entries {
+		convEntry, err := api.ProtoToRegistrationEntryWithMask(s.td, e, req.InputMask)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, fmt.Sprintf("failed to convert entry %d", i), err)
+		}
+		convEntry.UpdatedBy = callerID
+		convEntry.UpdatedAt = now
+
+		ops = append(ops, &datastore.RegistrationEntryOperation{
+			Update: &datastore.UpdateRegistrationEntryRequest{
+				Entry: convEntry,
+				Mask:  registrationEntryMask(req.InputMask),
+			},
+		})
+	}
+
+	entries, err := s.batchRegistrationEntries(ctx, ops, req.OutputMask)
+	if err != nil {
+		return nil, api.MakeErr(log, batchErrorCode(err), "failed to update entries", err)
+	}
+
+	resp := &entry.BatchUpdateEntryResponse{}
+	for _, tEntry := range entries {
+		resp.Results = append(resp.Results, &entry.BatchUpdateEntryResponse_Result{
+			Status: api.OK(),
+			Entry:  tEntry,
+		})
+	}
+	return resp, nil
+}
+
+// batchDeleteEntryAtomic deletes the entries in a single datastore
+// transaction. Any entry that cannot be deleted fails the whole RPC, and
+// none of the entries is deleted.
+func (s *Service) batchDeleteEntryAtomic(ctx context.Context, req *entry.BatchDeleteEntryRequest) (*entry.BatchDeleteEntryResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	var ops []*datastore.RegistrationEntryOperation
+	for i, id := range req.Ids {
+		if id == "" {
+			return nil, api.MakeErr(log, codes.InvalidArgument, fmt.Sprintf("missing ID of entry %d", i), nil)
+		}
+		ops = append(ops, &datastore.RegistrationEntryOperation{
+			Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: id},
+		})
+	}
+
+	if _, err := s.batchRegistrationEntries(ctx, ops, nil); err != nil {
+		return nil, api.MakeErr(log, batchErrorCode(err), "failed to delete entries", err)
+	}
+
+	resp := &entry.BatchDeleteEntryResponse{}
+	for _, id := range req.Ids {
+		resp.Results = append(resp.Results, &entry.BatchDeleteEntryResponse_Result{
+			Status: api.OK(),
+			Id:     id,
+		})
+	}
+	return resp, nil
+}
+
+// batchRegistrationEntries applies the operations in a single datastore
+// transaction and returns the resulting entries with the output mask
+// applied.
+func (s *Service) batchRegistrationEntries(ctx context.Context, ops []*datastore.RegistrationEntryOperation, outputMask *types.EntryMask) ([]*types.Entry, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	resp, err := s.ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: ops,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*types.Entry, 0, len(resp.Entries))
+	for _, regEntry := range resp.Entries {
+		tEntry, err := api.RegistrationEntryToProto(regEntry)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert entry: %v", err)
+		}
+		applyMask(tEntry, outputMask)
+		entries = append(entries, tEntry)
+	}
+	return entries, nil
+}
+
+// batchErrorCode returns the code of the error of an atomic batch. Errors
+// caused by the content of the batch are passed through, so callers can tell
+// them apart from server failures.
+func batchErrorCode(err error) codes.Code {
+	switch code := status.Code(err); code {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Aborted:
+		return code
+	default:
+		return codes.Internal
+	}
+}
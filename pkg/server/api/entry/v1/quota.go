@@ -0,0 +1,122 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+const quotaPageSize = 1000
+
+// Quota limits the number of registration entries whose SPIFFE ID path
+// starts with PathPrefix.
+type Quota struct {
+	// PathPrefix is matched against the path of the SPIFFE ID of the entries
+	// (e.g. "/team-a/").
+	PathPrefix string
+
+	// MaxEntries is the maximum number of entries under the prefix.
+	MaxEntries int
+}
+
+// quotaUsage tracks the number of entries under each quota path prefix while
+// a batch of entries is created. Entries are counted on first use, so
+// batches that do not create entries under a quota do not pay for it.
+type quotaUsage struct {
+	quotas []Quota
+	counts map[string]int
+}
+
+func newQuotaUsage(quotas []Quota) *quotaUsage {
+	return &quotaUsage{
+		quotas: quotas,
+	}
+}
+
+// check returns the quota that would be exceeded by creating an entry with
+// the given SPIFFE ID, if any.
+func (u *quotaUsage) check(ctx context.Context, ds datastore.DataStore, spiffeID string) (*Quota, error) {
+	quotas := u.matchingQuotas(spiffeID)
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+	if err := u.load(ctx, ds); err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		if u.counts[quota.PathPrefix] >= quota.MaxEntries {
+			return quota, nil
+		}
+	}
+	return nil, nil
+}
+
+// add accounts for an entry created with the given SPIFFE ID
+func (u *quotaUsage) add(spiffeID string) {
+	if u.counts == nil {
+		return
+	}
+	for _, quota := range u.matchingQuotas(spiffeID) {
+		u.counts[quota.PathPrefix]++
+	}
+}
+
+func (u *quotaUsage) matchingQuotas(spiffeID string) []*Quota {
+	path := spiffeIDPath(spiffeID)
+	var quotas []*Quota
+	for i := range u.quotas {
+		if strings.HasPrefix(path, u.quotas[i].PathPrefix) {
+			quotas = append(quotas, &u.quotas[i])
+		}
+	}
+	return quotas
+}
+
+func (u *quotaUsage) load(ctx context.Context, ds datastore.DataStore) error {
+	if u.counts != nil {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	req := &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: quotaPageSize,
+		},
+	}
+	for {
+		resp, err := ds.ListRegistrationEntries(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			path := spiffeIDPath(entry.SpiffeId)
+			for _, quota := range u.quotas {
+				if strings.HasPrefix(path, quota.PathPrefix) {
+					counts[quota.PathPrefix]++
+				}
+			}
+		}
+		if len(resp.Entries) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		req.Pagination.Token = resp.Pagination.Token
+	}
+
+	u.counts = counts
+	return nil
+}
+
+func (q *Quota) exceededError() error {
+	return fmt.Errorf("quota of %d entries under path prefix %q reached", q.MaxEntries, q.PathPrefix)
+}
+
+func spiffeIDPath(spiffeID string) string {
+	id, err := spiffeid.FromString(spiffeID)
+	if err != nil {
+		return ""
+	}
+	return id.Path()
+}
@@ -3,9 +3,13 @@ package entry
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/andres-erbsen/clock"
+	"github.com/gofrs/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
@@ -29,14 +33,21 @@ type Config struct {
 	TrustDomain  spiffeid.TrustDomain
 	EntryFetcher api.AuthorizedEntryFetcher
 	DataStore    datastore.DataStore
+	Clock        clock.Clock
+
+	// Quotas limit the number of entries that can be created under SPIFFE ID
+	// path prefixes.
+	Quotas []Quota
 }
 
 // New creates a new entry service
 func New(config Config) *Service {
 	return &Service{
-		td: config.TrustDomain,
-		ds: config.DataStore,
-		ef: config.EntryFetcher,
+		td:     config.TrustDomain,
+		ds:     config.DataStore,
+		ef:     config.EntryFetcher,
+		clk:    config.Clock,
+		quotas: config.Quotas,
 	}
 }
 
@@ -44,9 +55,11 @@ func New(config Config) *Service {
 type Service struct {
 	entry.UnsafeEntryServer
 
-	td spiffeid.TrustDomain
-	ds datastore.DataStore
-	ef api.AuthorizedEntryFetcher
+	td     spiffeid.TrustDomain
+	ds     datastore.DataStore
+	ef     api.AuthorizedEntryFetcher
+	clk    clock.Clock
+	quotas []Quota
 }
 
 func (s *Service) ListEntries(ctx context.Context, req *entry.ListEntriesRequest) (*entry.ListEntriesResponse, error) {
@@ -113,6 +126,33 @@ func (s *Service) ListEntries(ctx context.Context, req *entry.ListEntriesRequest
 				TrustDomains: trustDomains,
 			}
 		}
+
+		if req.Filter.ByMinTtl != nil {
+			if req.Filter.ByMinTtl.Value < 0 {
+				return nil, api.MakeErr(log, codes.InvalidArgument, "malformed TTL filter", errors.New("minimum TTL cannot be negative"))
+			}
+			listReq.ByMinTtl = &wrapperspb.Int32Value{
+				Value: req.Filter.ByMinTtl.Value,
+			}
+		}
+
+		if req.Filter.ByMaxTtl != nil {
+			if req.Filter.ByMaxTtl.Value < 0 {
+				return nil, api.MakeErr(log, codes.InvalidArgument, "malformed TTL filter", errors.New("maximum TTL cannot be negative"))
+			}
+			if req.Filter.ByMinTtl != nil && req.Filter.ByMinTtl.Value > req.Filter.ByMaxTtl.Value {
+				return nil, api.MakeErr(log, codes.InvalidArgument, "malformed TTL filter", errors.New("minimum TTL cannot be greater than maximum TTL"))
+			}
+			listReq.ByMaxTtl = &wrapperspb.Int32Value{
+				Value: req.Filter.ByMaxTtl.Value,
+			}
+		}
+
+		if req.Filter.ByExpiresBefore != nil {
+			listReq.ByExpiresBefore = &wrapperspb.Int64Value{
+				Value: req.Filter.ByExpiresBefore.Value,
+			}
+		}
 	}
 
 	dsResp, err := s.ds.ListRegistrationEntries(ctx, listReq)
@@ -166,9 +206,14 @@ func (s *Service) GetEntry(ctx context.Context, req *entry.GetEntryRequest) (*ty
 }
 
 func (s *Service) BatchCreateEntry(ctx context.Context, req *entry.BatchCreateEntryRequest) (*entry.BatchCreateEntryResponse, error) {
+	if req.Atomic {
+		return s.batchCreateEntryAtomic(ctx, req)
+	}
+
 	var results []*entry.BatchCreateEntryResponse_Result
+	usage := newQuotaUsage(s.quotas)
 	for _, eachEntry := range req.Entries {
-		results = append(results, s.createEntry(ctx, eachEntry, req.OutputMask))
+		results = append(results, s.createEntry(ctx, eachEntry, req.OutputMask, usage))
 	}
 
 	return &entry.BatchCreateEntryResponse{
@@ -176,7 +221,7 @@ func (s *Service) BatchCreateEntry(ctx context.Context, req *entry.BatchCreateEn
 	}, nil
 }
 
-func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask) *entry.BatchCreateEntryResponse_Result {
+func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask, usage *quotaUsage) *entry.BatchCreateEntryResponse_Result {
 	log := rpccontext.Logger(ctx)
 
 	cEntry, err := api.ProtoToRegistrationEntry(s.td, e)
@@ -188,6 +233,28 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 
 	log = log.WithField(telemetry.SPIFFEID, cEntry.SpiffeId)
 
+	callerID := callerIDString(ctx)
+	now := s.clk.Now().Unix()
+	cEntry.CreatedBy = callerID
+	cEntry.CreatedAt = now
+	cEntry.UpdatedBy = callerID
+	cEntry.UpdatedAt = now
+
+	if cEntry.EntryId != "" {
+		log = log.WithField(telemetry.RegistrationID, cEntry.EntryId)
+		if err := validateEntryID(cEntry.EntryId); err != nil {
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.MakeStatus(log, codes.InvalidArgument, "invalid entry ID", err),
+			}
+		}
+
+		// A caller retrying a create (e.g. after a timeout) gets back the
+		// entry created by the previous attempt.
+		if result := s.getExistingEntryByID(ctx, log, cEntry, outputMask); result != nil {
+			return result
+		}
+	}
+
 	existingEntry, err := s.getExistingEntry(ctx, cEntry)
 	if err != nil {
 		return &entry.BatchCreateEntryResponse_Result{
@@ -199,16 +266,35 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 	regEntry := existingEntry
 
 	if existingEntry == nil {
+		quota, err := usage.check(ctx, s.ds, cEntry.SpiffeId)
+		if err != nil {
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.MakeStatus(log, codes.Internal, "failed to count entries for quota", err),
+			}
+		}
+		if quota != nil {
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.MakeStatus(log, codes.ResourceExhausted, "entry quota exceeded", quota.exceededError()),
+			}
+		}
+
 		// Create entry
 		resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
 			Entry: cEntry,
 		})
 		if err != nil {
+			// The entry ID may have been taken by a concurrent create
+			if cEntry.EntryId != "" && status.Code(err) == codes.AlreadyExists {
+				if result := s.getExistingEntryByID(ctx, log, cEntry, outputMask); result != nil {
+					return result
+				}
+			}
 			return &entry.BatchCreateEntryResponse_Result{
 				Status: api.MakeStatus(log, codes.Internal, "failed to create entry", err),
 			}
 		}
 		regEntry = resp.Entry
+		usage.add(regEntry.SpiffeId)
 	} else {
 		resultStatus = api.CreateStatus(codes.AlreadyExists, "similar entry already exists")
 	}
@@ -229,6 +315,10 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 }
 
 func (s *Service) BatchUpdateEntry(ctx context.Context, req *entry.BatchUpdateEntryRequest) (*entry.BatchUpdateEntryResponse, error) {
+	if req.Atomic {
+		return s.batchUpdateEntryAtomic(ctx, req)
+	}
+
 	var results []*entry.BatchUpdateEntryResponse_Result
 
 	for _, eachEntry := range req.Entries {
@@ -242,6 +332,10 @@ func (s *Service) BatchUpdateEntry(ctx context.Context, req *entry.BatchUpdateEn
 }
 
 func (s *Service) BatchDeleteEntry(ctx context.Context, req *entry.BatchDeleteEntryRequest) (*entry.BatchDeleteEntryResponse, error) {
+	if req.Atomic {
+		return s.batchDeleteEntryAtomic(ctx, req)
+	}
+
 	var results []*entry.BatchDeleteEntryResponse_Result
 	for _, id := range req.Ids {
 		results = append(results, s.deleteEntry(ctx, id))
@@ -364,6 +458,60 @@ func applyMask(e *types.Entry, mask *types.EntryMask) {
 	if !mask.RevisionNumber {
 		e.RevisionNumber = 0
 	}
+
+	if !mask.X509SvidPrimaryName {
+		e.X509SvidPrimaryName = ""
+	}
+
+	if !mask.X509SvidKeyType {
+		e.X509SvidKeyType = ""
+	}
+
+	if !mask.CreatedBy {
+		e.CreatedBy = ""
+	}
+
+	if !mask.CreatedAt {
+		e.CreatedAt = 0
+	}
+
+	if !mask.UpdatedBy {
+		e.UpdatedBy = ""
+	}
+
+	if !mask.UpdatedAt {
+		e.UpdatedAt = 0
+	}
+}
+
+// callerIDString returns the SPIFFE ID of the caller, or an empty string
+// when the caller is not identified by one (e.g. local UDS callers).
+func callerIDString(ctx context.Context) string {
+	if callerID, ok := rpccontext.CallerID(ctx); ok {
+		return callerID.String()
+	}
+	return ""
+}
+
+// validateEntryID validates a caller-supplied entry ID, which must be a UUID
+// in its canonical form so that IDs compare equal regardless of the datastore.
+func validateEntryID(id string) error {
+	u, err := uuid.FromString(id)
+	if err != nil {
+		return err
+	}
+	if u.String() != id {
+		return fmt.Errorf("entry ID %q is not in canonical form (expected %q)", id, u.String())
+	}
+	return nil
+}
+
+// isSimilarEntry returns true if both entries have the same SPIFFE ID,
+// parent ID and selectors
+func isSimilarEntry(a, b *common.RegistrationEntry) bool {
+	return a.SpiffeId == b.SpiffeId &&
+		a.ParentId == b.ParentId &&
+		selector.NewSetFromRaw(a.Selectors).Equal(selector.NewSetFromRaw(b.Selectors))
 }
 
 func (s *Service) getExistingEntry(ctx context.Context, e *common.RegistrationEntry) (*common.RegistrationEntry, error) {
@@ -390,6 +538,42 @@ func (s *Service) getExistingEntry(ctx context.Context, e *common.RegistrationEn
 	return nil, nil
 }
 
+// getExistingEntryByID returns the result for creating an entry with a
+// caller-supplied ID that is already in use, or nil if there is no entry
+// with that ID. If the existing entry is similar (i.e. same SPIFFE ID, parent
+// ID and selectors) it is returned; otherwise the ID is in use by a different
+// entry, which is not revealed.
+func (s *Service) getExistingEntryByID(ctx context.Context, log logrus.FieldLogger, e *common.RegistrationEntry, outputMask *types.EntryMask) *entry.BatchCreateEntryResponse_Result {
+	resp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: e.EntryId,
+	})
+	switch {
+	case err != nil:
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+		}
+	case resp.Entry == nil:
+		return nil
+	case !isSimilarEntry(resp.Entry, e):
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.AlreadyExists, "entry ID is already in use by a different entry"),
+		}
+	}
+
+	tEntry, err := api.RegistrationEntryToProto(resp.Entry)
+	if err != nil {
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to convert entry", err),
+		}
+	}
+	applyMask(tEntry, outputMask)
+
+	return &entry.BatchCreateEntryResponse_Result{
+		Status: api.CreateStatus(codes.AlreadyExists, "entry already exists"),
+		Entry:  tEntry,
+	}
+}
+
 func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *types.EntryMask, outputMask *types.EntryMask) *entry.BatchUpdateEntryResponse_Result {
 	log := rpccontext.Logger(ctx)
 	log = log.WithField(telemetry.RegistrationID, e.Id)
@@ -400,26 +584,13 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert entry", err),
 		}
 	}
+	convEntry.UpdatedBy = callerIDString(ctx)
+	convEntry.UpdatedAt = s.clk.Now().Unix()
 
-	var resp *datastore.UpdateRegistrationEntryResponse
-	if inputMask != nil {
-		resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
-			Entry: convEntry,
-			Mask: &common.RegistrationEntryMask{
-				SpiffeId:      inputMask.SpiffeId,
-				ParentId:      inputMask.ParentId,
-				Ttl:           inputMask.Ttl,
-				FederatesWith: inputMask.FederatesWith,
-				Admin:         inputMask.Admin,
-				Downstream:    inputMask.Downstream,
-				EntryExpiry:   inputMask.ExpiresAt,
-				DnsNames:      inputMask.DnsNames,
-				Selectors:     inputMask.Selectors,
-			}})
-	} else {
-		resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: convEntry})
-	}
-
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: convEntry,
+		Mask:  registrationEntryMask(inputMask),
+	})
 	if err != nil {
 		return &entry.BatchUpdateEntryResponse_Result{
 			Status: api.MakeStatus(log, codes.Internal, "failed to update entry", err),
@@ -440,3 +611,24 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 		Entry:  tEntry,
 	}
 }
+
+// registrationEntryMask converts the input mask of an entry update to the
+// datastore mask. A nil mask updates every field.
+func registrationEntryMask(inputMask *types.EntryMask) *common.RegistrationEntryMask {
+	if inputMask == nil {
+		return nil
+	}
+	return &common.RegistrationEntryMask{
+		SpiffeId:            inputMask.SpiffeId,
+		ParentId:            inputMask.ParentId,
+		Ttl:                 inputMask.Ttl,
+		FederatesWith:       inputMask.FederatesWith,
+		Admin:               inputMask.Admin,
+		Downstream:          inputMask.Downstream,
+		EntryExpiry:         inputMask.ExpiresAt,
+		DnsNames:            inputMask.DnsNames,
+		Selectors:           inputMask.Selectors,
+		X509SvidPrimaryName: inputMask.X509SvidPrimaryName,
+		X509SvidKeyType:     inputMask.X509SvidKeyType,
+	}
+}
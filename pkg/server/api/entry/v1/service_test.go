@@ -18,6 +18,7 @@ import (
 	entrypb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
@@ -35,6 +37,7 @@ var (
 	secondFederatedTd = spiffeid.RequireTrustDomainFromString("domain2.org")
 	notFederatedTd    = spiffeid.RequireTrustDomainFromString("domain3.org")
 	agentID           = spiffeid.RequireFromString("spiffe://example.org/agent")
+	now               = time.Unix(1600000000, 0)
 )
 
 func TestListEntries(t *testing.T) {
@@ -61,12 +64,15 @@ func TestListEntries(t *testing.T) {
 			federatedTd.IDString(),
 		},
 	}
+	secondChildExpiry := time.Now().Add(time.Hour).Unix()
 	secondChildRegEntry := &common.RegistrationEntry{
 		ParentId: parentID.String(),
 		SpiffeId: secondChildID.String(),
 		Selectors: []*common.Selector{
 			{Type: "unix", Value: "uid:1000"},
 		},
+		Ttl:         60,
+		EntryExpiry: secondChildExpiry,
 		FederatesWith: []string{
 			federatedTd.IDString(),
 			secondFederatedTd.IDString(),
@@ -132,6 +138,8 @@ func TestListEntries(t *testing.T) {
 			federatedTd.String(),
 			secondFederatedTd.String(),
 		},
+		Ttl:       60,
+		ExpiresAt: secondChildExpiry,
 	}
 
 	for _, tt := range []struct {
@@ -352,6 +360,49 @@ func TestListEntries(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "filter by federates with any match",
+			expectedEntries: []*types.Entry{expectedSecondChild},
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByFederatesWith: &types.FederatesWithMatch{
+						TrustDomains: []string{
+							secondFederatedTd.String(),
+							notFederatedTd.String(),
+						},
+						Match: types.FederatesWithMatch_MATCH_ANY,
+					},
+				},
+			},
+		},
+		{
+			name:            "filter by min TTL",
+			expectedEntries: []*types.Entry{expectedSecondChild},
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByMinTtl: &wrapperspb.Int32Value{Value: 60},
+				},
+			},
+		},
+		{
+			name:            "filter by max TTL",
+			logMsg:          "Failed to convert entry: ",
+			expectedEntries: []*types.Entry{expectedChild},
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByMaxTtl: &wrapperspb.Int32Value{Value: 59},
+				},
+			},
+		},
+		{
+			name:            "filter by expires before",
+			expectedEntries: []*types.Entry{expectedSecondChild},
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByExpiresBefore: &wrapperspb.Int64Value{Value: secondChildExpiry + 1},
+				},
+			},
+		},
 		{
 			name:                  "page",
 			expectedEntries:       []*types.Entry{expectedChild},
@@ -402,6 +453,40 @@ func TestListEntries(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "bad TTL filter (negative minimum)",
+			err:    "malformed TTL filter: minimum TTL cannot be negative",
+			code:   codes.InvalidArgument,
+			logMsg: "Invalid argument: malformed TTL filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByMinTtl: &wrapperspb.Int32Value{Value: -1},
+				},
+			},
+		},
+		{
+			name:   "bad TTL filter (negative maximum)",
+			err:    "malformed TTL filter: maximum TTL cannot be negative",
+			code:   codes.InvalidArgument,
+			logMsg: "Invalid argument: malformed TTL filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByMaxTtl: &wrapperspb.Int32Value{Value: -1},
+				},
+			},
+		},
+		{
+			name:   "bad TTL filter (minimum greater than maximum)",
+			err:    "malformed TTL filter: minimum TTL cannot be greater than maximum TTL",
+			code:   codes.InvalidArgument,
+			logMsg: "Invalid argument: malformed TTL filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByMinTtl: &wrapperspb.Int32Value{Value: 60},
+					ByMaxTtl: &wrapperspb.Int32Value{Value: 30},
+				},
+			},
+		},
 		{
 			name:   "bad selectors filter (bad selector)",
 			err:    "malformed selectors filter: missing selector type",
@@ -652,6 +737,8 @@ func TestBatchCreateEntry(t *testing.T) {
 	expiresAt := time.Now().Unix()
 
 	useDefaultEntryID := "DEFAULT_ENTRY_ID"
+	entry1ID := "0e4e3ac8-3a5c-4b8b-9d4c-3f2a5f1c7a01"
+	entry2ID := "0e4e3ac8-3a5c-4b8b-9d4c-3f2a5f1c7a02"
 
 	defaultEntry := &common.RegistrationEntry{
 		ParentId: entryParentID.String(),
@@ -670,7 +757,7 @@ func TestBatchCreateEntry(t *testing.T) {
 
 	// Create a test entry
 	testEntry := &types.Entry{
-		Id:       "entry1",
+		Id:       entry1ID,
 		ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "host"},
 		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "workload"},
 		Selectors: []*types.Selector{
@@ -686,7 +773,7 @@ func TestBatchCreateEntry(t *testing.T) {
 	}
 	// Registration entry for test entry
 	testDSEntry := &common.RegistrationEntry{
-		EntryId:  "entry1",
+		EntryId:  entry1ID,
 		ParentId: "spiffe://example.org/host",
 		SpiffeId: "spiffe://example.org/workload",
 		Selectors: []*common.Selector{
@@ -699,6 +786,8 @@ func TestBatchCreateEntry(t *testing.T) {
 		EntryExpiry:   expiresAt,
 		FederatesWith: []string{"spiffe://domain1.org"},
 		Ttl:           60,
+		CreatedAt:     now.Unix(),
+		UpdatedAt:     now.Unix(),
 	}
 
 	for _, tt := range []struct {
@@ -713,6 +802,7 @@ func TestBatchCreateEntry(t *testing.T) {
 		dsError         error
 		dsResults       map[string]*common.RegistrationEntry
 		expectDsEntries map[string]*common.RegistrationEntry
+		withCallerID    bool
 	}{
 		{
 			name: "multiple entries",
@@ -729,7 +819,7 @@ func TestBatchCreateEntry(t *testing.T) {
 				{
 					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
 					Entry: &types.Entry{
-						Id:       "entry1",
+						Id:       entry1ID,
 						ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
 						SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
 					},
@@ -743,7 +833,7 @@ func TestBatchCreateEntry(t *testing.T) {
 				{
 					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
 					Entry: &types.Entry{
-						Id:       "entry2",
+						Id:       entry2ID,
 						ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
 						SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload2"},
 					},
@@ -767,7 +857,7 @@ func TestBatchCreateEntry(t *testing.T) {
 					Selectors: []*types.Selector{{Type: "type", Value: "value"}},
 					DnsNames:  []string{""},
 				}, {
-					Id: "entry2",
+					Id: entry2ID,
 					ParentId: &types.SPIFFEID{
 						TrustDomain: "example.org",
 						Path:        "agent",
@@ -780,8 +870,8 @@ func TestBatchCreateEntry(t *testing.T) {
 				},
 			},
 			expectDsEntries: map[string]*common.RegistrationEntry{
-				"entry1": testDSEntry,
-				"entry2": {EntryId: "entry2", ParentId: "spiffe://example.org/agent", SpiffeId: "spiffe://example.org/workload2", Selectors: []*common.Selector{{Type: "type", Value: "value"}}},
+				entry1ID: testDSEntry,
+				entry2ID: {EntryId: entry2ID, ParentId: "spiffe://example.org/agent", SpiffeId: "spiffe://example.org/workload2", Selectors: []*common.Selector{{Type: "type", Value: "value"}}, CreatedAt: now.Unix(), UpdatedAt: now.Unix()},
 			},
 		},
 		{
@@ -790,7 +880,7 @@ func TestBatchCreateEntry(t *testing.T) {
 				{
 					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
 					Entry: &types.Entry{
-						Id:       "entry1",
+						Id:       entry1ID,
 						ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
 						SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
 						Selectors: []*types.Selector{
@@ -803,11 +893,44 @@ func TestBatchCreateEntry(t *testing.T) {
 						ExpiresAt:     expiresAt,
 						FederatesWith: []string{"domain1.org"},
 						Ttl:           60,
+						CreatedAt:     now.Unix(),
+						UpdatedAt:     now.Unix(),
 					},
 				},
 			},
 			reqEntries:      []*types.Entry{testEntry},
-			expectDsEntries: map[string]*common.RegistrationEntry{"entry1": testDSEntry},
+			expectDsEntries: map[string]*common.RegistrationEntry{entry1ID: testDSEntry},
+		},
+		{
+			name: "records caller as creator",
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+					Entry: &types.Entry{
+						Id:        entry1ID,
+						CreatedBy: agentID.String(),
+						CreatedAt: now.Unix(),
+						UpdatedBy: agentID.String(),
+						UpdatedAt: now.Unix(),
+					},
+				},
+			},
+			outputMask: &types.EntryMask{
+				CreatedBy: true,
+				CreatedAt: true,
+				UpdatedBy: true,
+				UpdatedAt: true,
+			},
+			reqEntries: []*types.Entry{testEntry},
+			expectDsEntries: map[string]*common.RegistrationEntry{
+				entry1ID: func() *common.RegistrationEntry {
+					e := proto.Clone(testDSEntry).(*common.RegistrationEntry)
+					e.CreatedBy = agentID.String()
+					e.UpdatedBy = agentID.String()
+					return e
+				}(),
+			},
+			withCallerID: true,
 		},
 		{
 			name: "output mask all false",
@@ -815,13 +938,13 @@ func TestBatchCreateEntry(t *testing.T) {
 				{
 					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
 					Entry: &types.Entry{
-						Id: "entry1",
+						Id: entry1ID,
 					},
 				},
 			},
 			outputMask:      &types.EntryMask{},
 			reqEntries:      []*types.Entry{testEntry},
-			expectDsEntries: map[string]*common.RegistrationEntry{"entry1": testDSEntry},
+			expectDsEntries: map[string]*common.RegistrationEntry{entry1ID: testDSEntry},
 		},
 		{
 			name:          "no entries to add",
@@ -834,7 +957,7 @@ func TestBatchCreateEntry(t *testing.T) {
 				{
 					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
 					Entry: &types.Entry{
-						Id:       "entry1",
+						Id:       entry1ID,
 						ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
 						SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
 					},
@@ -846,7 +969,7 @@ func TestBatchCreateEntry(t *testing.T) {
 			},
 			reqEntries: []*types.Entry{
 				{
-					Id:       "entry1",
+					Id:       entry1ID,
 					ParentId: api.ProtoFromID(entryParentID),
 					SpiffeId: api.ProtoFromID(entrySpiffeID),
 					Ttl:      60,
@@ -856,14 +979,16 @@ func TestBatchCreateEntry(t *testing.T) {
 				},
 			},
 			expectDsEntries: map[string]*common.RegistrationEntry{
-				"entry1": {
-					EntryId:  "entry1",
+				entry1ID: {
+					EntryId:  entry1ID,
 					ParentId: "spiffe://example.org/foo",
 					SpiffeId: "spiffe://example.org/bar",
 					Ttl:      60,
 					Selectors: []*common.Selector{
 						{Type: "type", Value: "value1"},
 					},
+					CreatedAt: now.Unix(),
+					UpdatedAt: now.Unix(),
 				},
 			},
 		},
@@ -899,6 +1024,87 @@ func TestBatchCreateEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid entry ID",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid entry ID",
+					Data: logrus.Fields{
+						logrus.ErrorKey:          "uuid: incorrect UUID length: not-a-uuid",
+						telemetry.RegistrationID: "not-a-uuid",
+						telemetry.SPIFFEID:       "spiffe://example.org/workload",
+					},
+				},
+			},
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.InvalidArgument),
+						Message: "invalid entry ID: uuid: incorrect UUID length: not-a-uuid",
+					},
+				},
+			},
+			reqEntries: []*types.Entry{
+				{
+					Id:        "not-a-uuid",
+					ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+					Selectors: []*types.Selector{{Type: "type", Value: "value"}},
+				},
+			},
+		},
+		{
+			name: "returns existing entry with the same ID",
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.AlreadyExists),
+						Message: "entry already exists",
+					},
+					Entry: &types.Entry{
+						Id:       useDefaultEntryID,
+						ParentId: api.ProtoFromID(entryParentID),
+						SpiffeId: api.ProtoFromID(entrySpiffeID),
+					},
+				},
+			},
+			outputMask: &types.EntryMask{
+				ParentId: true,
+				SpiffeId: true,
+			},
+			reqEntries: []*types.Entry{
+				{
+					Id:       useDefaultEntryID,
+					ParentId: api.ProtoFromID(entryParentID),
+					SpiffeId: api.ProtoFromID(entrySpiffeID),
+					Ttl:      20,
+					Selectors: []*types.Selector{
+						{Type: "unix", Value: "uid:1000"},
+						{Type: "unix", Value: "gid:1000"},
+					},
+				},
+			},
+		},
+		{
+			name: "entry ID in use by a different entry",
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.AlreadyExists),
+						Message: "entry ID is already in use by a different entry",
+					},
+				},
+			},
+			reqEntries: []*types.Entry{
+				{
+					Id:        useDefaultEntryID,
+					ParentId:  api.ProtoFromID(entryParentID),
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+			},
+		},
 		{
 			name: "invalid entry",
 			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
@@ -931,8 +1137,9 @@ func TestBatchCreateEntry(t *testing.T) {
 					Level:   logrus.ErrorLevel,
 					Message: "Failed to create entry",
 					Data: logrus.Fields{
-						logrus.ErrorKey:    "creating error",
-						telemetry.SPIFFEID: "spiffe://example.org/workload",
+						logrus.ErrorKey:          "creating error",
+						telemetry.RegistrationID: entry1ID,
+						telemetry.SPIFFEID:       "spiffe://example.org/workload",
 					},
 				},
 			},
@@ -946,9 +1153,9 @@ func TestBatchCreateEntry(t *testing.T) {
 			},
 
 			reqEntries:      []*types.Entry{testEntry},
-			expectDsEntries: map[string]*common.RegistrationEntry{"entry1": testDSEntry},
+			expectDsEntries: map[string]*common.RegistrationEntry{entry1ID: testDSEntry},
 			dsError:         errors.New("creating error"),
-			dsResults:       map[string]*common.RegistrationEntry{"entry1": nil},
+			dsResults:       map[string]*common.RegistrationEntry{entry1ID: nil},
 		},
 		{
 			name: "ds returns malformed entry",
@@ -957,8 +1164,9 @@ func TestBatchCreateEntry(t *testing.T) {
 					Level:   logrus.ErrorLevel,
 					Message: "Failed to convert entry",
 					Data: logrus.Fields{
-						logrus.ErrorKey:    "invalid SPIFFE ID: spiffeid: invalid scheme",
-						telemetry.SPIFFEID: "spiffe://example.org/workload",
+						logrus.ErrorKey:          "invalid SPIFFE ID: spiffeid: invalid scheme",
+						telemetry.RegistrationID: entry1ID,
+						telemetry.SPIFFEID:       "spiffe://example.org/workload",
 					},
 				},
 			},
@@ -972,8 +1180,8 @@ func TestBatchCreateEntry(t *testing.T) {
 			},
 
 			reqEntries:      []*types.Entry{testEntry},
-			expectDsEntries: map[string]*common.RegistrationEntry{"entry1": testDSEntry},
-			dsResults: map[string]*common.RegistrationEntry{"entry1": {
+			expectDsEntries: map[string]*common.RegistrationEntry{entry1ID: testDSEntry},
+			dsResults: map[string]*common.RegistrationEntry{entry1ID: {
 				ParentId: "spiffe://example.org/path",
 				SpiffeId: "invalid id",
 			}},
@@ -984,12 +1192,19 @@ func TestBatchCreateEntry(t *testing.T) {
 			ds := newFakeDS(t)
 
 			test := setupServiceTest(t, ds)
+			test.withCallerID = tt.withCallerID
 			defer test.Cleanup()
 
 			// Create federated bundles, that we use on "FederatesWith"
 			createFederatedBundles(t, ds)
 			defaultEntryID := createTestEntries(t, ds, defaultEntry)[defaultEntry.SpiffeId].EntryId
 
+			for _, e := range tt.reqEntries {
+				if e.Id == useDefaultEntryID {
+					e.Id = defaultEntryID
+				}
+			}
+
 			// Setup fake
 			ds.customCreate = true
 			ds.t = t
@@ -1407,6 +1622,7 @@ type serviceTest struct {
 	ef           *entryFetcher
 	done         func()
 	ds           datastore.DataStore
+	clk          *clock.Mock
 	logHook      *test.Hook
 	withCallerID bool
 }
@@ -1416,11 +1632,19 @@ func (s *serviceTest) Cleanup() {
 }
 
 func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
+	return setupServiceTestWithQuotas(t, ds, nil)
+}
+
+func setupServiceTestWithQuotas(t *testing.T, ds datastore.DataStore, quotas []entry.Quota) *serviceTest {
 	ef := &entryFetcher{}
+	clk := clock.NewMock(t)
+	clk.Set(now)
 	service := entry.New(entry.Config{
 		TrustDomain:  td,
 		DataStore:    ds,
 		EntryFetcher: ef,
+		Clock:        clk,
+		Quotas:       quotas,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -1430,6 +1654,7 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 
 	test := &serviceTest{
 		ds:      ds,
+		clk:     clk,
 		logHook: logHook,
 		ef:      ef,
 	}
@@ -2044,6 +2269,8 @@ func TestBatchUpdateEntry(t *testing.T) {
 						DnsNames:       []string{"dns3", "dns4"},
 						Downstream:     false,
 						RevisionNumber: 1,
+						CreatedAt:      now.Unix(),
+						UpdatedAt:      now.Unix(),
 					},
 				},
 			},
@@ -2079,6 +2306,8 @@ func TestBatchUpdateEntry(t *testing.T) {
 						DnsNames:       []string{"dns1", "dns2"},
 						Downstream:     true,
 						RevisionNumber: 1,
+						CreatedAt:      now.Unix(),
+						UpdatedAt:      now.Unix(),
 					},
 				},
 			},
@@ -2202,12 +2431,322 @@ func TestBatchUpdateEntry(t *testing.T) {
 				require.NoError(t, err)
 				firstEntry, err := api.RegistrationEntryToProto(listEntries.Entries[0])
 				require.NoError(t, err)
-				spiretest.AssertProtoEqual(t, firstEntry, tt.expectDsEntries(listEntries.Entries[0].EntryId)[0])
+				// Entries are created and updated at the same (mocked) time
+				expectEntry := tt.expectDsEntries(listEntries.Entries[0].EntryId)[0]
+				expectEntry.CreatedAt = now.Unix()
+				expectEntry.UpdatedAt = now.Unix()
+				spiretest.AssertProtoEqual(t, firstEntry, expectEntry)
 			}
 		})
 	}
 }
 
+func TestBatchUpdateEntryRecordsProvenance(t *testing.T) {
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+
+	createResp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				ParentId:  api.ProtoFromID(td.NewID("foo")),
+				SpiffeId:  api.ProtoFromID(td.NewID("bar")),
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, createResp.Results, 1)
+	require.Equal(t, api.OK(), createResp.Results[0].Status)
+	createdAt := test.clk.Now().Unix()
+
+	// Update the entry later on, as an identified caller
+	test.clk.Add(time.Minute)
+	test.withCallerID = true
+	updateResp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:  createResp.Results[0].Entry.Id,
+				Ttl: 500,
+			},
+		},
+		InputMask: &types.EntryMask{Ttl: true},
+		OutputMask: &types.EntryMask{
+			CreatedBy: true,
+			CreatedAt: true,
+			UpdatedBy: true,
+			UpdatedAt: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, updateResp.Results, 1)
+
+	spiretest.AssertProtoEqual(t, &entrypb.BatchUpdateEntryResponse_Result{
+		Status: api.OK(),
+		Entry: &types.Entry{
+			Id:        createResp.Results[0].Entry.Id,
+			CreatedAt: createdAt,
+			UpdatedBy: agentID.String(),
+			UpdatedAt: test.clk.Now().Unix(),
+		},
+	}, updateResp.Results[0])
+}
+
+func TestBatchCreateEntryQuotas(t *testing.T) {
+	ds := fakedatastore.New(t)
+	test := setupServiceTestWithQuotas(t, ds, []entry.Quota{
+		{PathPrefix: "/team-a/", MaxEntries: 3},
+		{PathPrefix: "/team-a/db/", MaxEntries: 1},
+	})
+	defer test.Cleanup()
+
+	createTestEntries(t, ds, &common.RegistrationEntry{
+		ParentId:  td.NewID("agent").String(),
+		SpiffeId:  td.NewID("team-a/web").String(),
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	newEntry := func(path string) *types.Entry {
+		return &types.Entry{
+			ParentId:  api.ProtoFromID(td.NewID("agent")),
+			SpiffeId:  api.ProtoFromID(td.NewID(path)),
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+		}
+	}
+
+	resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+		Entries: []*types.Entry{
+			// Counts towards both quotas
+			newEntry("team-a/db/primary"),
+			// Exceeds the nested quota
+			newEntry("team-a/db/replica"),
+			// Reaches the top-level quota, counting the existing entry
+			newEntry("team-a/api"),
+			// Exceeds the top-level quota
+			newEntry("team-a/worker"),
+			// Not subject to any quota
+			newEntry("team-b/web"),
+			newEntry("team-ab/web"),
+		},
+		OutputMask: &types.EntryMask{},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Results, 6)
+	for i, expectStatus := range []*types.Status{
+		api.OK(),
+		{
+			Code:    int32(codes.ResourceExhausted),
+			Message: `entry quota exceeded: quota of 1 entries under path prefix "/team-a/db/" reached`,
+		},
+		api.OK(),
+		{
+			Code:    int32(codes.ResourceExhausted),
+			Message: `entry quota exceeded: quota of 3 entries under path prefix "/team-a/" reached`,
+		},
+		api.OK(),
+		api.OK(),
+	} {
+		spiretest.AssertProtoEqual(t, expectStatus, resp.Results[i].Status)
+	}
+
+	// The quota is enforced across requests
+	resp, err = test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+		Entries: []*types.Entry{newEntry("team-a/worker")},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, int32(codes.ResourceExhausted), resp.Results[0].Status.Code)
+}
+
+func TestBatchEntryAtomic(t *testing.T) {
+	newEntry := func(path string) *types.Entry {
+		return &types.Entry{
+			ParentId:  api.ProtoFromID(td.NewID("agent")),
+			SpiffeId:  api.ProtoFromID(td.NewID(path)),
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		}
+	}
+	existingID := "7d3a8d2c-4f5e-4b7a-9c1d-2e3f4a5b6c7d"
+	existingEntry := &common.RegistrationEntry{
+		EntryId:   existingID,
+		ParentId:  td.NewID("agent").String(),
+		SpiffeId:  td.NewID("existing").String(),
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+
+	listSPIFFEIDs := func(t *testing.T, ds datastore.DataStore) []string {
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+		require.NoError(t, err)
+		var ids []string
+		for _, e := range resp.Entries {
+			ids = append(ids, e.SpiffeId)
+		}
+		return ids
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ds := fakedatastore.New(t)
+		test := setupServiceTest(t, ds)
+		defer test.Cleanup()
+
+		resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+			Entries:    []*types.Entry{newEntry("a"), newEntry("b")},
+			OutputMask: &types.EntryMask{SpiffeId: true},
+			Atomic:     true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		for i, path := range []string{"/a", "/b"} {
+			spiretest.AssertProtoEqual(t, api.OK(), resp.Results[i].Status)
+			assert.Equal(t, path, resp.Results[i].Entry.SpiffeId.Path)
+			assert.NotEmpty(t, resp.Results[i].Entry.Id)
+		}
+		assert.ElementsMatch(t, []string{"spiffe://example.org/a", "spiffe://example.org/b"}, listSPIFFEIDs(t, ds))
+	})
+
+	for _, tt := range []struct {
+		name       string
+		entries    []*types.Entry
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:       "create invalid entry",
+			entries:    []*types.Entry{newEntry("a"), {ParentId: api.ProtoFromID(td.NewID("agent"))}},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "failed to convert entry 1",
+		},
+		{
+			name:       "create similar entries",
+			entries:    []*types.Entry{newEntry("a"), newEntry("a")},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "entry 1 is similar to entry 0",
+		},
+		{
+			name:       "create similar to existing entry",
+			entries:    []*types.Entry{newEntry("a"), newEntry("existing")},
+			expectCode: codes.AlreadyExists,
+			expectMsg:  "similar entry already exists",
+		},
+		{
+			name: "create with ID in use",
+			entries: []*types.Entry{newEntry("a"), func() *types.Entry {
+				e := newEntry("b")
+				e.Id = existingID
+				return e
+			}()},
+			expectCode: codes.AlreadyExists,
+			expectMsg:  "failed to create entries",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := fakedatastore.New(t)
+			createTestEntries(t, ds, existingEntry)
+			test := setupServiceTest(t, ds)
+			defer test.Cleanup()
+
+			resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+				Entries: tt.entries,
+				Atomic:  true,
+			})
+			spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+			require.Nil(t, resp)
+
+			// None of the entries was created
+			assert.Equal(t, []string{"spiffe://example.org/existing"}, listSPIFFEIDs(t, ds))
+		})
+	}
+
+	t.Run("create exceeding quota", func(t *testing.T) {
+		ds := fakedatastore.New(t)
+		test := setupServiceTestWithQuotas(t, ds, []entry.Quota{{PathPrefix: "/", MaxEntries: 1}})
+		defer test.Cleanup()
+
+		_, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+			Entries: []*types.Entry{newEntry("a"), newEntry("b")},
+			Atomic:  true,
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, `entry quota exceeded: quota of 1 entries under path prefix "/" reached`)
+		assert.Empty(t, listSPIFFEIDs(t, ds))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ds := fakedatastore.New(t)
+		entries := createTestEntries(t, ds, existingEntry, &common.RegistrationEntry{
+			ParentId:  td.NewID("agent").String(),
+			SpiffeId:  td.NewID("other").String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		})
+		test := setupServiceTest(t, ds)
+		defer test.Cleanup()
+
+		ids := []string{
+			entries["spiffe://example.org/existing"].EntryId,
+			entries["spiffe://example.org/other"].EntryId,
+		}
+
+		// An unknown entry fails the whole batch
+		_, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+			Entries:   []*types.Entry{{Id: ids[0], Ttl: 100}, {Id: "unknown", Ttl: 100}},
+			InputMask: &types.EntryMask{Ttl: true},
+			Atomic:    true,
+		})
+		spiretest.RequireGRPCStatusContains(t, err, codes.NotFound, "failed to update entries")
+		fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: ids[0]})
+		require.NoError(t, err)
+		assert.Zero(t, fetchResp.Entry.Ttl)
+
+		resp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+			Entries:    []*types.Entry{{Id: ids[0], Ttl: 100}, {Id: ids[1], Ttl: 200}},
+			InputMask:  &types.EntryMask{Ttl: true},
+			OutputMask: &types.EntryMask{Ttl: true},
+			Atomic:     true,
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, &entrypb.BatchUpdateEntryResponse{
+			Results: []*entrypb.BatchUpdateEntryResponse_Result{
+				{Status: api.OK(), Entry: &types.Entry{Id: ids[0], Ttl: 100}},
+				{Status: api.OK(), Entry: &types.Entry{Id: ids[1], Ttl: 200}},
+			},
+		}, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ds := fakedatastore.New(t)
+		createTestEntries(t, ds, existingEntry)
+		test := setupServiceTest(t, ds)
+		defer test.Cleanup()
+
+		// An unknown entry fails the whole batch
+		_, err := test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+			Ids:    []string{existingID, "unknown"},
+			Atomic: true,
+		})
+		spiretest.RequireGRPCStatusContains(t, err, codes.NotFound, "failed to delete entries")
+		assert.Equal(t, []string{"spiffe://example.org/existing"}, listSPIFFEIDs(t, ds))
+
+		_, err = test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+			Ids:    []string{existingID, ""},
+			Atomic: true,
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing ID of entry 1")
+
+		resp, err := test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+			Ids:    []string{existingID},
+			Atomic: true,
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, &entrypb.BatchDeleteEntryResponse{
+			Results: []*entrypb.BatchDeleteEntryResponse_Result{
+				{Status: api.OK(), Id: existingID},
+			},
+		}, resp)
+		assert.Empty(t, listSPIFFEIDs(t, ds))
+	})
+}
+
 type fakeDS struct {
 	*fakedatastore.DataStore
 
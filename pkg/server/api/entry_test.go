@@ -40,11 +40,16 @@ func TestRegistrationEntryToProto(t *testing.T) {
 					// domain name either way.
 					"domain2.com",
 				},
-				Admin:          true,
-				EntryExpiry:    expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:           true,
+				EntryExpiry:     expiresAt,
+				DnsNames:        []string{"dns1", "dns2"},
+				Downstream:      true,
+				RevisionNumber:  99,
+				X509SvidKeyType: "rsa-2048",
+				CreatedBy:       "spiffe://example.org/admin",
+				CreatedAt:       expiresAt,
+				UpdatedBy:       "spiffe://example.org/admin",
+				UpdatedAt:       expiresAt,
 			},
 			expectEntry: &types.Entry{
 				Id:       "entry1",
@@ -59,11 +64,16 @@ func TestRegistrationEntryToProto(t *testing.T) {
 					"domain1.com",
 					"domain2.com",
 				},
-				Admin:          true,
-				ExpiresAt:      expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:           true,
+				ExpiresAt:       expiresAt,
+				DnsNames:        []string{"dns1", "dns2"},
+				Downstream:      true,
+				RevisionNumber:  99,
+				X509SvidKeyType: "rsa-2048",
+				CreatedBy:       "spiffe://example.org/admin",
+				CreatedAt:       expiresAt,
+				UpdatedBy:       "spiffe://example.org/admin",
+				UpdatedAt:       expiresAt,
 			},
 		},
 		{
@@ -132,11 +142,12 @@ func TestProtoToRegistrationEntryWithMask(t *testing.T) {
 					// either way.
 					"spiffe://domain2.com",
 				},
-				Admin:          true,
-				ExpiresAt:      expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:           true,
+				ExpiresAt:       expiresAt,
+				DnsNames:        []string{"dns1", "dns2"},
+				Downstream:      true,
+				RevisionNumber:  99,
+				X509SvidKeyType: "rsa-2048",
 			},
 			expectEntry: &common.RegistrationEntry{
 				EntryId:  "entry1",
@@ -151,11 +162,12 @@ func TestProtoToRegistrationEntryWithMask(t *testing.T) {
 					"spiffe://domain1.com",
 					"spiffe://domain2.com",
 				},
-				Admin:          true,
-				EntryExpiry:    expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:           true,
+				EntryExpiry:     expiresAt,
+				DnsNames:        []string{"dns1", "dns2"},
+				Downstream:      true,
+				RevisionNumber:  99,
+				X509SvidKeyType: "rsa-2048",
 			},
 			mask: protoutil.AllTrueEntryMask,
 		},
@@ -345,6 +357,16 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 			},
 			err: "selector list is empty",
 		},
+		{
+			name: "invalid X509-SVID key type",
+			entry: &types.Entry{
+				ParentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:       []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				X509SvidKeyType: "rsa-1024",
+			},
+			err: `invalid X509-SVID key type "rsa-1024"`,
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,50 @@
+package info
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/proto/spire/api/server/info/v1"
+	"google.golang.org/grpc"
+)
+
+// RegisterService registers the info service on the provided server
+func RegisterService(s *grpc.Server, service *Service) {
+	info.RegisterInfoServer(s, service)
+}
+
+// Config is the info service configuration
+type Config struct {
+	// ExperimentalFeatures lists the experimental features enabled on the
+	// server.
+	ExperimentalFeatures []string
+
+	// APIVersions lists the API versions served by the server, as protobuf
+	// package names.
+	APIVersions []string
+}
+
+// New creates a new info service
+func New(config Config) *Service {
+	return &Service{
+		experimentalFeatures: config.ExperimentalFeatures,
+		apiVersions:          config.APIVersions,
+	}
+}
+
+// Service implements the v1 info server
+type Service struct {
+	info.UnsafeInfoServer
+
+	experimentalFeatures []string
+	apiVersions          []string
+}
+
+// GetBuildInfo returns the server version and capabilities
+func (s *Service) GetBuildInfo(ctx context.Context, req *info.GetBuildInfoRequest) (*info.GetBuildInfoResponse, error) {
+	return &info.GetBuildInfoResponse{
+		Version:              version.Version(),
+		ExperimentalFeatures: s.experimentalFeatures,
+		ApiVersions:          s.apiVersions,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package info_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/pkg/server/api/info/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	infopb "github.com/spiffe/spire/proto/spire/api/server/info/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGetBuildInfo(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		config     info.Config
+		expectResp *infopb.GetBuildInfoResponse
+	}{
+		{
+			name: "no experimental features",
+			config: info.Config{
+				APIVersions: []string{"spire.api.server.agent.v1", "spire.api.server.info.v1"},
+			},
+			expectResp: &infopb.GetBuildInfoResponse{
+				Version:     version.Version(),
+				ApiVersions: []string{"spire.api.server.agent.v1", "spire.api.server.info.v1"},
+			},
+		},
+		{
+			name: "experimental features",
+			config: info.Config{
+				ExperimentalFeatures: []string{"api_gateway", "ec2_inventory"},
+				APIVersions:          []string{"spire.api.server.agent.v1", "spire.api.server.info.v1"},
+			},
+			expectResp: &infopb.GetBuildInfoResponse{
+				Version:              version.Version(),
+				ExperimentalFeatures: []string{"api_gateway", "ec2_inventory"},
+				ApiVersions:          []string{"spire.api.server.agent.v1", "spire.api.server.info.v1"},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client, done := setupServiceTest(t, tt.config)
+			defer done()
+
+			resp, err := client.GetBuildInfo(context.Background(), &infopb.GetBuildInfoRequest{})
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+}
+
+func setupServiceTest(t *testing.T, config info.Config) (infopb.InfoClient, func()) {
+	log, _ := test.NewNullLogger()
+	service := info.New(config)
+
+	registerFn := func(s *grpc.Server) {
+		info.RegisterService(s, service)
+	}
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	return infopb.NewInfoClient(conn), done
+}
@@ -35,6 +35,10 @@ func WithMetrics(metrics telemetry.Metrics) Middleware {
 	return middleware.WithMetrics(metrics)
 }
 
+func WithCallerMetadata() Middleware {
+	return middleware.WithCallerMetadata()
+}
+
 func Interceptors(m Middleware) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
 	return middleware.Interceptors(m)
 }
@@ -46,3 +50,15 @@ func UnaryInterceptor(m Middleware) grpc.UnaryServerInterceptor {
 func StreamInterceptor(m Middleware) grpc.StreamServerInterceptor {
 	return middleware.StreamInterceptor(m)
 }
+
+func ChainUnaryInterceptors(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return middleware.ChainUnaryInterceptors(outer, inner)
+}
+
+func ChainStreamInterceptors(outer, inner grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return middleware.ChainStreamInterceptors(outer, inner)
+}
+
+func RecoveryInterceptors(log logrus.FieldLogger, metrics telemetry.Metrics) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return middleware.RecoveryInterceptors(log, metrics)
+}
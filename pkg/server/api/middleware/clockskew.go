@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithClockSkewTolerance returns a middleware that rejects requests from
+// callers whose clock differs from the server clock by more than the given
+// tolerance. Skewed clocks otherwise surface as confusing certificate and
+// token validation failures; rejecting the request early with a
+// FAILED_PRECONDITION error describing the skew makes the cause obvious.
+//
+// Callers report their time through the api.ClientTimeKey metadata. Callers
+// that don't (e.g. older agents or the CLI) are not checked. A tolerance less
+// than or equal to zero disables the check.
+func WithClockSkewTolerance(tolerance time.Duration, clk clock.Clock) middleware.Middleware {
+	return middleware.Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		if tolerance <= 0 {
+			return ctx, nil
+		}
+
+		clientTime, ok, err := api.ClientTime(ctx)
+		switch {
+		case err != nil:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case !ok:
+			return ctx, nil
+		}
+
+		skew := clientTime.Sub(clk.Now())
+		direction := "ahead of"
+		if skew < 0 {
+			skew = -skew
+			direction = "behind"
+		}
+		if skew <= tolerance {
+			return ctx, nil
+		}
+
+		skew = skew.Truncate(time.Second)
+		rpccontext.Logger(ctx).WithField(telemetry.ClockSkew, skew).Warnf("Rejecting request from caller with a clock %s the server clock", direction)
+		return nil, status.Errorf(codes.FailedPrecondition, "caller clock is %s %s the server clock, which exceeds the tolerance of %s; check that the caller clock is synchronized", skew, direction, tolerance)
+	})
+}
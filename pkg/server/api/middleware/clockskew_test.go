@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/api"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithClockSkewTolerance(t *testing.T) {
+	clk := clock.NewMock(t)
+
+	for _, tt := range []struct {
+		name       string
+		tolerance  time.Duration
+		clientTime string
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:      "no client time",
+			tolerance: time.Minute,
+		},
+		{
+			name:       "within tolerance",
+			tolerance:  time.Minute,
+			clientTime: clk.Now().Add(-time.Minute).Format(time.RFC3339Nano),
+		},
+		{
+			name:       "ahead of server",
+			tolerance:  time.Minute,
+			clientTime: clk.Now().Add(time.Hour + time.Millisecond).Format(time.RFC3339Nano),
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "caller clock is 1h0m0s ahead of the server clock, which exceeds the tolerance of 1m0s; check that the caller clock is synchronized",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Rejecting request from caller with a clock ahead of the server clock",
+					Data:    logrus.Fields{telemetry.ClockSkew: "1h0m0s"},
+				},
+			},
+		},
+		{
+			name:       "behind server",
+			tolerance:  time.Minute,
+			clientTime: clk.Now().Add(-2 * time.Minute).Format(time.RFC3339Nano),
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "caller clock is 2m0s behind the server clock, which exceeds the tolerance of 1m0s; check that the caller clock is synchronized",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Rejecting request from caller with a clock behind the server clock",
+					Data:    logrus.Fields{telemetry.ClockSkew: "2m0s"},
+				},
+			},
+		},
+		{
+			name:       "malformed client time",
+			tolerance:  time.Minute,
+			clientTime: "yesterday",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid spire-client-time metadata: parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			name:       "disabled",
+			clientTime: clk.Now().Add(time.Hour).Format(time.RFC3339Nano),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			ctx := rpccontext.WithLogger(context.Background(), log)
+			if tt.clientTime != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(api.ClientTimeKey, tt.clientTime))
+			}
+
+			_, err := WithClockSkewTolerance(tt.tolerance, clk).Preprocess(ctx, "/fake.Service/Method")
+			if tt.expectCode == codes.OK {
+				require.NoError(t, err)
+			} else {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			}
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+)
+
+// WithDataStoreTimeout returns a middleware that sets the default timeout
+// applied to datastore calls made by the handlers. Each datastore call is
+// bounded by the timeout and by the RPC deadline, if any, minus the given
+// safety margin, so that handlers have time to report the failure before the
+// RPC deadline expires. A timeout less than or equal to zero disables the
+// default timeout.
+//
+// The timeout is only applied to datastore calls made through a datastore
+// that derives its call contexts with rpccontext.DataStoreContext.
+func WithDataStoreTimeout(timeout, margin time.Duration) middleware.Middleware {
+	return middleware.Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		if timeout <= 0 {
+			return ctx, nil
+		}
+		return rpccontext.WithDataStoreTimeout(ctx, timeout, margin), nil
+	})
+}
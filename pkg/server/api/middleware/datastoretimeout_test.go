@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDataStoreTimeout(t *testing.T) {
+	m := WithDataStoreTimeout(time.Minute, time.Second)
+
+	t.Run("without RPC deadline", func(t *testing.T) {
+		ctx, err := m.Preprocess(context.Background(), "/fake.Service/Method")
+		require.NoError(t, err)
+
+		dsCtx, cancel := rpccontext.DataStoreContext(ctx)
+		defer cancel()
+		deadline, ok := dsCtx.Deadline()
+		require.True(t, ok, "datastore context has no deadline")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	})
+
+	t.Run("RPC deadline sooner than timeout", func(t *testing.T) {
+		rpcCtx, rpcCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer rpcCancel()
+		rpcDeadline, _ := rpcCtx.Deadline()
+
+		ctx, err := m.Preprocess(rpcCtx, "/fake.Service/Method")
+		require.NoError(t, err)
+
+		dsCtx, cancel := rpccontext.DataStoreContext(ctx)
+		defer cancel()
+		deadline, ok := dsCtx.Deadline()
+		require.True(t, ok, "datastore context has no deadline")
+		assert.WithinDuration(t, rpcDeadline.Add(-time.Second), deadline, 500*time.Millisecond)
+	})
+
+	t.Run("RPC deadline within margin", func(t *testing.T) {
+		rpcCtx, rpcCancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer rpcCancel()
+		rpcDeadline, _ := rpcCtx.Deadline()
+
+		ctx, err := m.Preprocess(rpcCtx, "/fake.Service/Method")
+		require.NoError(t, err)
+
+		dsCtx, cancel := rpccontext.DataStoreContext(ctx)
+		defer cancel()
+		deadline, ok := dsCtx.Deadline()
+		require.True(t, ok, "datastore context has no deadline")
+		assert.Equal(t, rpcDeadline, deadline)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx, err := WithDataStoreTimeout(0, time.Second).Preprocess(context.Background(), "/fake.Service/Method")
+		require.NoError(t, err)
+
+		dsCtx, cancel := rpccontext.DataStoreContext(ctx)
+		defer cancel()
+		_, ok := dsCtx.Deadline()
+		assert.False(t, ok, "datastore context should not have a deadline")
+	})
+}
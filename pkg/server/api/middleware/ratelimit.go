@@ -17,8 +17,8 @@ import (
 )
 
 const (
-	// gcInterval is the interval at which per-ip limiters are garbage
-	// collected.
+	// gcInterval is the interval at which per-ip and per-caller limiters are
+	// garbage collected.
 	gcInterval = time.Minute
 )
 
@@ -68,6 +68,15 @@ func PerIPLimit(limit int) api.RateLimiter {
 	return newPerIPLimiter(limit)
 }
 
+// PerCallerLimit returns a rate limiter that imposes a per-caller limit on
+// calls to a method. Callers are identified by their SPIFFE ID, if
+// authenticated, or otherwise by their IP address. Local (i.e. UDS) callers
+// are not limited. It can be shared across methods to enforce per-caller
+// limits for a group of methods.
+func PerCallerLimit(limit int) api.RateLimiter {
+	return newPerCallerLimiter(limit)
+}
+
 // WithRateLimits returns a middleware that performs rate limiting for the
 // group of methods descripted by the rateLimits map. It provides the
 // configured rate limiter to the method handlers via the request context. If
@@ -114,6 +123,51 @@ func (lim *perCallLimiter) RateLimit(ctx context.Context, count int) error {
 }
 
 type perIPLimiter struct {
+	limiters *keyedLimiters
+}
+
+func newPerIPLimiter(limit int) *perIPLimiter {
+	return &perIPLimiter{limiters: newKeyedLimiters(limit)}
+}
+
+func (lim *perIPLimiter) RateLimit(ctx context.Context, count int) error {
+	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+	if !ok {
+		// Calls not via TCP/IP aren't limited
+		return nil
+	}
+	limiter := lim.limiters.getLimiter(tcpAddr.IP.String())
+	return waitN(ctx, limiter, count)
+}
+
+type perCallerLimiter struct {
+	limiters *keyedLimiters
+}
+
+func newPerCallerLimiter(limit int) *perCallerLimiter {
+	return &perCallerLimiter{limiters: newKeyedLimiters(limit)}
+}
+
+func (lim *perCallerLimiter) RateLimit(ctx context.Context, count int) error {
+	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+	if !ok {
+		// Calls not via TCP/IP aren't limited
+		return nil
+	}
+
+	// The SPIFFE ID is preferred since callers behind NATs may share an IP
+	// address.
+	key := tcpAddr.IP.String()
+	if id, ok := rpccontext.CallerID(ctx); ok {
+		key = id.String()
+	}
+	limiter := lim.limiters.getLimiter(key)
+	return waitN(ctx, limiter, count)
+}
+
+// keyedLimiters holds a limiter per key (e.g. IP address), garbage collecting
+// the limiters that have not been used for a GC interval.
+type keyedLimiters struct {
 	limit int
 
 	mtx sync.RWMutex
@@ -129,50 +183,40 @@ type perIPLimiter struct {
 	lastGC time.Time
 }
 
-func newPerIPLimiter(limit int) *perIPLimiter {
-	return &perIPLimiter{limit: limit,
+func newKeyedLimiters(limit int) *keyedLimiters {
+	return &keyedLimiters{limit: limit,
 		current: make(map[string]rawRateLimiter),
 		lastGC:  clk.Now(),
 	}
 }
 
-func (lim *perIPLimiter) RateLimit(ctx context.Context, count int) error {
-	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
-	if !ok {
-		// Calls not via TCP/IP aren't limited
-		return nil
-	}
-	limiter := lim.getLimiter(tcpAddr.IP.String())
-	return waitN(ctx, limiter, count)
-}
-
-func (lim *perIPLimiter) getLimiter(ip string) rawRateLimiter {
+func (lim *keyedLimiters) getLimiter(key string) rawRateLimiter {
 	lim.mtx.RLock()
-	limiter, ok := lim.current[ip]
+	limiter, ok := lim.current[key]
 	if ok {
 		lim.mtx.RUnlock()
 		return limiter
 	}
 	lim.mtx.RUnlock()
 
-	// A limiter does not exist for that address.
+	// A limiter does not exist for that key.
 	lim.mtx.Lock()
 	defer lim.mtx.Unlock()
 
-	// Check the "current" entries in case another goroutine raced on this IP.
-	if limiter, ok = lim.current[ip]; ok {
+	// Check the "current" entries in case another goroutine raced on this key.
+	if limiter, ok = lim.current[key]; ok {
 		return limiter
 	}
 
 	// Then check the "previous" entries to see if a limiter exists for this
-	// IP as of the last GC. If so, move it to current and return it.
-	if limiter, ok = lim.previous[ip]; ok {
-		lim.current[ip] = limiter
-		delete(lim.previous, ip)
+	// key as of the last GC. If so, move it to current and return it.
+	if limiter, ok = lim.previous[key]; ok {
+		lim.current[key] = limiter
+		delete(lim.previous, key)
 		return limiter
 	}
 
-	// There is no limiter for this IP. Before we create one, we should see
+	// There is no limiter for this key. Before we create one, we should see
 	// if we need to do GC.
 	now := clk.Now()
 	if now.Sub(lim.lastGC) >= gcInterval {
@@ -182,7 +226,7 @@ func (lim *perIPLimiter) getLimiter(ip string) rawRateLimiter {
 	}
 
 	limiter = newRawRateLimiter(rate.Limit(lim.limit), lim.limit)
-	lim.current[ip] = limiter
+	lim.current[key] = limiter
 	return limiter
 }
 
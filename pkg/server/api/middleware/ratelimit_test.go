@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
@@ -95,6 +96,37 @@ func TestPerIPLimit(t *testing.T) {
 	}, limiters.WaitNEvents)
 }
 
+func TestPerCallerLimit(t *testing.T) {
+	limiters := NewFakeLimiters()
+
+	m := PerCallerLimit(10)
+
+	// Does not rate limit non-TCP/IP callers
+	err := m.RateLimit(unixCallerContext(), 11)
+	require.NoError(t, err)
+
+	// Exceeding burst size for the agent
+	agentID := spiffeid.Must("example.org", "spire", "agent", "test", "1")
+	err = m.RateLimit(rpccontext.WithCallerID(tcpCallerContext("1.1.1.1"), agentID), 11)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (11) exceeds burst size (10)")
+
+	// Authenticated callers are limited by SPIFFE ID, even when the address
+	// changes or is shared with another caller
+	require.NoError(t, m.RateLimit(rpccontext.WithCallerID(tcpCallerContext("1.1.1.1"), agentID), 1))
+	require.NoError(t, m.RateLimit(rpccontext.WithCallerID(tcpCallerContext("2.2.2.2"), agentID), 2))
+
+	// Unauthenticated callers are limited by IP address
+	require.NoError(t, m.RateLimit(tcpCallerContext("1.1.1.1"), 3))
+
+	// There should be two rate limiters; the agent, and 1.1.1.1
+	assert.Equal(t, 2, limiters.Count)
+	assert.Equal(t, []WaitNEvent{
+		{ID: 1, Count: 1},
+		{ID: 1, Count: 2},
+		{ID: 2, Count: 3},
+	}, limiters.WaitNEvents)
+}
+
 func TestPerIPLimitGC(t *testing.T) {
 	mockClk, restoreClk := setupClock(t)
 	defer restoreClk()
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// WithStreamLimits returns a middleware that limits the number of concurrently
+// open streams per caller for the methods described by the streamLimits map.
+// Methods that are not present in the map are not limited. Callers are
+// identified by the SPIFFE ID in their X509-SVID, if one is presented, or
+// otherwise by their IP address. Local (i.e. UDS) callers are not limited.
+//
+// When a caller has reached the limit for a method, new streams for that
+// method are rejected with a RESOURCE_EXHAUSTED error until one of the
+// open streams is closed.
+//
+// Unlike the other middleware in this package, WithStreamLimits does not
+// depend on the caller information injected by the Authorization middleware
+// and can therefore be used to limit streams for the deprecated APIs.
+//
+// WithStreamLimits owns the passed streamLimits map and assumes it will not
+// be mutated after the method is called.
+func WithStreamLimits(streamLimits map[string]int) middleware.Middleware {
+	return &streamLimitsMiddleware{
+		limits: streamLimits,
+		open:   make(map[streamKey]int),
+	}
+}
+
+type streamKey struct {
+	method string
+	caller string
+}
+
+type streamLimitsMiddleware struct {
+	limits map[string]int
+
+	mtx  sync.Mutex
+	open map[streamKey]int
+}
+
+type streamKeyKey struct{}
+
+func (m *streamLimitsMiddleware) Preprocess(ctx context.Context, fullMethod string) (context.Context, error) {
+	limit, ok := m.limits[fullMethod]
+	if !ok || limit <= 0 {
+		return ctx, nil
+	}
+
+	caller, ok := streamCallerFromContext(ctx)
+	if !ok {
+		// Callers that cannot be identified (i.e. local callers) aren't
+		// limited.
+		return ctx, nil
+	}
+
+	key := streamKey{method: fullMethod, caller: caller}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.open[key] >= limit {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent streams for %q (limit %d)", fullMethod, limit)
+	}
+	m.open[key]++
+
+	return context.WithValue(ctx, streamKeyKey{}, key), nil
+}
+
+func (m *streamLimitsMiddleware) Postprocess(ctx context.Context, fullMethod string, handlerInvoked bool, rpcErr error) {
+	key, ok := ctx.Value(streamKeyKey{}).(streamKey)
+	if !ok {
+		return
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.open[key] <= 1 {
+		delete(m.open, key)
+		return
+	}
+	m.open[key]--
+}
+
+// streamCallerFromContext returns an identifier for the caller. The SPIFFE ID
+// of the presented X509-SVID is preferred since agents behind NATs may share
+// an IP address.
+func streamCallerFromContext(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	tcpAddr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		return "", false
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 && len(certs[0].URIs) == 1 {
+			return certs[0].URIs[0].String(), true
+		}
+	}
+
+	return tcpAddr.IP.String(), true
+}
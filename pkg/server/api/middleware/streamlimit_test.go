@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestStreamLimits(t *testing.T) {
+	m := WithStreamLimits(map[string]int{
+		"/fake.Service/Limited": 2,
+	})
+
+	agentA := streamPeerContext("1.1.1.1", "/spire/agent/a")
+	agentB := streamPeerContext("1.1.1.1", "/spire/agent/b")
+	anonymous := streamPeerContext("2.2.2.2", "")
+	local := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix", Name: "/not/a/real/path.sock"},
+	})
+
+	// Open streams up to the limit for agent A
+	a1, err := m.Preprocess(agentA, "/fake.Service/Limited")
+	require.NoError(t, err)
+	a2, err := m.Preprocess(agentA, "/fake.Service/Limited")
+	require.NoError(t, err)
+
+	// The next stream for agent A is rejected
+	_, err = m.Preprocess(agentA, "/fake.Service/Limited")
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, `too many concurrent streams for "/fake.Service/Limited" (limit 2)`)
+
+	// Agent B shares the IP address with agent A but is limited separately
+	_, err = m.Preprocess(agentB, "/fake.Service/Limited")
+	require.NoError(t, err)
+
+	// Callers without an X509-SVID are limited by IP address
+	_, err = m.Preprocess(anonymous, "/fake.Service/Limited")
+	require.NoError(t, err)
+	_, err = m.Preprocess(anonymous, "/fake.Service/Limited")
+	require.NoError(t, err)
+	_, err = m.Preprocess(anonymous, "/fake.Service/Limited")
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, `too many concurrent streams for "/fake.Service/Limited" (limit 2)`)
+
+	// Local callers and methods without a limit are not limited
+	for i := 0; i < 3; i++ {
+		_, err = m.Preprocess(local, "/fake.Service/Limited")
+		require.NoError(t, err)
+		_, err = m.Preprocess(agentA, "/fake.Service/Unlimited")
+		require.NoError(t, err)
+	}
+
+	// Closing a stream allows agent A to open another one
+	m.Postprocess(a1, "/fake.Service/Limited", true, nil)
+	_, err = m.Preprocess(agentA, "/fake.Service/Limited")
+	require.NoError(t, err)
+	_, err = m.Preprocess(agentA, "/fake.Service/Limited")
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, `too many concurrent streams for "/fake.Service/Limited" (limit 2)`)
+
+	// Streams that failed downstream preprocessing are also released
+	m.Postprocess(a2, "/fake.Service/Limited", false, nil)
+	_, err = m.Preprocess(agentA, "/fake.Service/Limited")
+	require.NoError(t, err)
+}
+
+func TestStreamLimitsReleasesAllStreams(t *testing.T) {
+	m := WithStreamLimits(map[string]int{
+		"/fake.Service/Limited": 1,
+	}).(*streamLimitsMiddleware)
+
+	ctx, err := m.Preprocess(streamPeerContext("1.1.1.1", ""), "/fake.Service/Limited")
+	require.NoError(t, err)
+	assert.Len(t, m.open, 1)
+
+	m.Postprocess(ctx, "/fake.Service/Limited", true, nil)
+	assert.Empty(t, m.open)
+}
+
+func streamPeerContext(ip, agentPath string) context.Context {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip)},
+	}
+	if agentPath != "" {
+		p.AuthInfo = credentials.TLSInfo{
+			State: tls.ConnectionState{
+				HandshakeComplete: true,
+				PeerCertificates: []*x509.Certificate{
+					{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: agentPath}}},
+				},
+			},
+		}
+	}
+	return peer.NewContext(context.Background(), p)
+}
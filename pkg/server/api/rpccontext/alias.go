@@ -28,6 +28,14 @@ func AddMetricsLabel(ctx context.Context, name, value string) {
 	CallCounter(ctx).AddLabel(name, value)
 }
 
+func WithCallerMetadata(ctx context.Context, md api.CallerMetadata) context.Context {
+	return rpccontext.WithCallerMetadata(ctx, md)
+}
+
+func CallerMetadata(ctx context.Context) (api.CallerMetadata, bool) {
+	return rpccontext.CallerMetadata(ctx)
+}
+
 func WithNames(ctx context.Context, names api.Names) context.Context {
 	return rpccontext.WithNames(ctx, names)
 }
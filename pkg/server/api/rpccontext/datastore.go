@@ -0,0 +1,49 @@
+package rpccontext
+
+import (
+	"context"
+	"time"
+)
+
+type dataStoreTimeoutKey struct{}
+
+type dataStoreTimeout struct {
+	timeout time.Duration
+	margin  time.Duration
+}
+
+// WithDataStoreTimeout returns a context that carries the default timeout
+// applied to datastore calls made while handling the RPC. The margin is
+// subtracted from the RPC deadline, if any, so that datastore calls time out
+// early enough for the handler to report the failure to the caller.
+func WithDataStoreTimeout(ctx context.Context, timeout, margin time.Duration) context.Context {
+	return context.WithValue(ctx, dataStoreTimeoutKey{}, dataStoreTimeout{
+		timeout: timeout,
+		margin:  margin,
+	})
+}
+
+// DataStoreContext returns a context to be used for a single datastore call.
+// The deadline of the returned context is the earliest of the default
+// datastore timeout and the RPC deadline minus the safety margin. If the
+// RPC deadline is too close to apply the margin, the RPC deadline is used
+// as is. If no datastore timeout has been set on the context, the context is
+// returned without an additional deadline.
+func DataStoreContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	value, ok := ctx.Value(dataStoreTimeoutKey{}).(dataStoreTimeout)
+	if !ok || value.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	timeout := value.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline) - value.margin
+		if remaining <= 0 {
+			return context.WithCancel(ctx)
+		}
+		if remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -1,15 +1,42 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// ErrorDomain is the domain set on the ErrorInfo details attached to errors
+// returned by the APIs.
+const ErrorDomain = "spire.spiffe.io"
+
+// RetryDelay is the delay suggested to callers, via RetryInfo details, before
+// retrying a request that failed because of a timeout.
+const RetryDelay = time.Second
+
+// errorInfoFields are the log fields that are copied into the metadata of the
+// ErrorInfo details attached to errors. Only fields that identify the objects
+// involved in the request are included; fields describing the caller (e.g.
+// address) are intentionally omitted.
+var errorInfoFields = []string{
+	telemetry.AgentID,
+	telemetry.ParentID,
+	telemetry.RegistrationID,
+	telemetry.SPIFFEID,
+	telemetry.TrustDomainID,
+}
+
 // CreateStatus creates a proto Status
 func CreateStatus(code codes.Code, format string, a ...interface{}) *types.Status {
 	return &types.Status{
@@ -31,12 +58,74 @@ func MakeStatus(log logrus.FieldLogger, code codes.Code, msg string, err error)
 		return OK()
 	}
 
-	return CreateStatus(code, status.Convert(e).Message())
+	st := status.Convert(e)
+	protoStatus := CreateStatus(st.Code(), st.Message())
+	for _, violation := range FieldViolations(e) {
+		protoStatus.FieldViolations = append(protoStatus.FieldViolations, &types.FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return protoStatus
+}
+
+// FieldViolationError is an error caused by an invalid field of the request.
+// Errors that wrap a FieldViolationError are returned with BadRequest
+// details (and, in batch results, with field violations in the Status)
+// identifying the offending field.
+type FieldViolationError struct {
+	// Field is the path to the offending field, e.g. "x509_authorities[2]".
+	Field string
+
+	// Err is the reason the field is invalid.
+	Err error
+}
+
+// FieldViolation returns a FieldViolationError for the given field. The
+// error message is the message of err, so wrapping an error does not change
+// the messages returned to callers.
+func FieldViolation(field string, err error) error {
+	return &FieldViolationError{Field: field, Err: err}
+}
+
+func (e *FieldViolationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldViolationError) Unwrap() error {
+	return e.Err
+}
+
+// FieldViolations returns the field violations in the BadRequest details
+// attached to an error returned by the APIs, if any.
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.FieldViolations
+		}
+	}
+	return nil
 }
 
 // MakeErr logs and returns an error composed of: msg, err and code.
-// Errors are treated differently according to its gRPC code.
+// Errors are treated differently according to its gRPC code. Errors caused by
+// an exceeded deadline (e.g. a datastore call that timed out) are returned
+// as Unavailable, with RetryInfo details, so that callers know the request
+// can be retried.
 func MakeErr(log logrus.FieldLogger, code codes.Code, msg string, err error) error {
+	if code != codes.OK && isDeadlineExceeded(err) {
+		code = codes.Unavailable
+	}
+	if code == codes.Internal {
+		// Dependencies that are temporarily unavailable (e.g. the server CA
+		// while the KeyManager is down) report it through the error code,
+		// which is passed through so callers know to retry later.
+		switch status.Code(err) {
+		case codes.Unavailable, codes.ResourceExhausted:
+			code = status.Code(err)
+		}
+	}
+
 	errMsg := msg
 	switch code {
 	case codes.OK:
@@ -53,12 +142,12 @@ func MakeErr(log logrus.FieldLogger, code codes.Code, msg string, err error) err
 		}
 
 		log.Errorf("Invalid argument: %s", msg)
-		return status.Error(code, errMsg)
+		return makeErrWithInfo(log, code, msg, errMsg, err)
 
 	case codes.NotFound:
 		// Do not log nor return the inner error for NotFound errors
 		log.Error(capitalize(msg))
-		return status.Error(code, errMsg)
+		return makeErrWithInfo(log, code, msg, errMsg, err)
 
 	default:
 		if err != nil {
@@ -66,8 +155,113 @@ func MakeErr(log logrus.FieldLogger, code codes.Code, msg string, err error) err
 			errMsg = concatErr(msg, err)
 		}
 		log.Error(capitalize(msg))
-		return status.Error(code, errMsg)
+		return makeErrWithInfo(log, code, msg, errMsg, err)
+	}
+}
+
+// ErrorInfo returns the ErrorInfo details attached to an error returned by
+// the APIs, if any.
+func ErrorInfo(err error) (*errdetails.ErrorInfo, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info, true
+		}
+	}
+	return nil, false
+}
+
+// makeErrWithInfo returns an error with the given code and message, with
+// ErrorInfo details (and BadRequest details, if err is caused by an invalid
+// field) attached. The reason is derived from the (static) error
+// message so that clients can branch on it without parsing the message,
+// which may include details from inner errors.
+func makeErrWithInfo(log logrus.FieldLogger, code codes.Code, msg, errMsg string, err error) error {
+	st := status.New(code, errMsg)
+	info := &errdetails.ErrorInfo{
+		Reason:   ErrorReason(msg),
+		Domain:   ErrorDomain,
+		Metadata: errorInfoMetadata(log),
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	var violation *FieldViolationError
+	if errors.As(err, &violation) {
+		badRequest := &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: violation.Field, Description: violation.Err.Error()},
+			},
+		}
+		if detailed, err := st.WithDetails(badRequest); err == nil {
+			st = detailed
+		}
+	}
+	if code == codes.Unavailable {
+		retryInfo := &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(RetryDelay),
+		}
+		if detailed, err := st.WithDetails(retryInfo); err == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// RetryInfo returns the RetryInfo details attached to an error returned by
+// the APIs, if any.
+func RetryInfo(err error) (*errdetails.RetryInfo, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info, true
+		}
+	}
+	return nil, false
+}
+
+func isDeadlineExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+}
+
+// ErrorReason converts an error message into an UPPER_SNAKE_CASE reason,
+// e.g. "failed to fetch bundle" becomes "FAILED_TO_FETCH_BUNDLE".
+func ErrorReason(msg string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range msg {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			underscore = b.Len() > 0
+			continue
+		}
+		if underscore {
+			b.WriteByte('_')
+			underscore = false
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+func errorInfoMetadata(log logrus.FieldLogger) map[string]string {
+	entry, ok := log.(*logrus.Entry)
+	if !ok {
+		return nil
+	}
+
+	var metadata map[string]string
+	for _, field := range errorInfoFields {
+		value, ok := entry.Data[field]
+		if !ok {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[field] = fmt.Sprint(value)
 	}
+	return metadata
 }
 
 // Concat message with provided error and avoid "status.Code"
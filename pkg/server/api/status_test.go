@@ -1,17 +1,22 @@
 package api_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestOK(t *testing.T) {
@@ -142,8 +147,144 @@ func TestMakeErr(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			log, hook := test.NewNullLogger()
 			err := api.MakeErr(log, tt.code, tt.msg, tt.err)
-			require.Equal(t, err, tt.expErr)
 			spiretest.AssertLogs(t, hook.AllEntries(), tt.expLog)
+			if tt.expErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			expSt := status.Convert(tt.expErr)
+			spiretest.RequireGRPCStatus(t, err, expSt.Code(), expSt.Message())
+
+			info, ok := api.ErrorInfo(err)
+			require.True(t, ok, "error info details are missing")
+			spiretest.AssertProtoEqual(t, &errdetails.ErrorInfo{
+				Reason: api.ErrorReason(tt.msg),
+				Domain: api.ErrorDomain,
+			}, info)
+		})
+	}
+}
+
+func TestMakeErrErrorInfoMetadata(t *testing.T) {
+	l, _ := test.NewNullLogger()
+	log := l.WithFields(logrus.Fields{
+		telemetry.TrustDomainID: "spiffe://example.org",
+		telemetry.SPIFFEID:      "spiffe://example.org/workload",
+		"caller-addr":           "1.1.1.1",
+	})
+
+	err := api.MakeErr(log, codes.Internal, "failed to fetch bundle", errors.New("oh no"))
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to fetch bundle: oh no")
+
+	info, ok := api.ErrorInfo(err)
+	require.True(t, ok, "error info details are missing")
+	spiretest.AssertProtoEqual(t, &errdetails.ErrorInfo{
+		Reason: "FAILED_TO_FETCH_BUNDLE",
+		Domain: api.ErrorDomain,
+		Metadata: map[string]string{
+			telemetry.TrustDomainID: "spiffe://example.org",
+			telemetry.SPIFFEID:      "spiffe://example.org/workload",
+		},
+	}, info)
+}
+
+func TestMakeErrDeadlineExceeded(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "context error",
+			err:  fmt.Errorf("datastore-sql: %w", context.DeadlineExceeded),
+		},
+		{
+			name: "status error",
+			err:  status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := test.NewNullLogger()
+			err := api.MakeErr(l, codes.Internal, "failed to fetch entry", tt.err)
+			require.Equal(t, codes.Unavailable, status.Code(err))
+
+			info, ok := api.ErrorInfo(err)
+			require.True(t, ok, "error info details are missing")
+			require.Equal(t, "FAILED_TO_FETCH_ENTRY", info.Reason)
+
+			retryInfo, ok := api.RetryInfo(err)
+			require.True(t, ok, "retry info details are missing")
+			spiretest.AssertProtoEqual(t, &errdetails.RetryInfo{
+				RetryDelay: durationpb.New(api.RetryDelay),
+			}, retryInfo)
+
+			sts := api.MakeStatus(l, codes.Internal, "failed to fetch entry", tt.err)
+			require.Equal(t, int32(codes.Unavailable), sts.Code)
 		})
 	}
 }
+
+func TestRetryInfoMissing(t *testing.T) {
+	l, _ := test.NewNullLogger()
+	_, ok := api.RetryInfo(api.MakeErr(l, codes.Internal, "failed to fetch entry", errors.New("oh no")))
+	require.False(t, ok)
+}
+
+func TestErrorReason(t *testing.T) {
+	require.Equal(t, "FAILED_TO_FETCH_BUNDLE", api.ErrorReason("failed to fetch bundle"))
+	require.Equal(t, "INVALID_X_509_AUTHORITY", api.ErrorReason("invalid X.509 authority"))
+	require.Equal(t, "TRUST_DOMAIN_ARGUMENT_IS_NOT_VALID", api.ErrorReason("  trust domain argument is not valid: "))
+	require.Equal(t, "", api.ErrorReason(""))
+}
+
+func TestErrorInfoMissing(t *testing.T) {
+	_, ok := api.ErrorInfo(status.Error(codes.Internal, "oh no"))
+	require.False(t, ok)
+}
+
+func TestMakeStatusFieldViolation(t *testing.T) {
+	l, _ := test.NewNullLogger()
+	err := fmt.Errorf("unable to parse X.509 authority: %w", api.FieldViolation("x509_authorities[1].asn1", errors.New("malformed")))
+	sts := api.MakeStatus(l, codes.InvalidArgument, "failed to convert bundle", err)
+
+	spiretest.RequireProtoEqual(t, &types.Status{
+		Code:    int32(codes.InvalidArgument),
+		Message: "failed to convert bundle: unable to parse X.509 authority: malformed",
+		FieldViolations: []*types.FieldViolation{
+			{Field: "x509_authorities[1].asn1", Description: "malformed"},
+		},
+	}, sts)
+}
+
+func TestMakeErrFieldViolation(t *testing.T) {
+	l, _ := test.NewNullLogger()
+	err := api.MakeErr(l, codes.InvalidArgument, "invalid refresh hint", api.FieldViolation("refresh_hint", errors.New("out of range")))
+
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = invalid refresh hint: out of range")
+	violations := api.FieldViolations(err)
+	require.Len(t, violations, 1)
+	spiretest.RequireProtoEqual(t, &errdetails.BadRequest_FieldViolation{
+		Field:       "refresh_hint",
+		Description: "out of range",
+	}, violations[0])
+}
+
+func TestFieldViolationsMissing(t *testing.T) {
+	l, _ := test.NewNullLogger()
+	require.Empty(t, api.FieldViolations(api.MakeErr(l, codes.Internal, "failed to fetch bundle", errors.New("oh no"))))
+}
+
+func TestMakeErrUnavailableDependency(t *testing.T) {
+	l, _ := test.NewNullLogger()
+
+	err := api.MakeErr(l, codes.Internal, "failed to sign X509-SVID", status.Error(codes.Unavailable, "key manager is unavailable"))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, ok := api.RetryInfo(err)
+	require.True(t, ok)
+
+	err = api.MakeErr(l, codes.Internal, "failed to sign X509-SVID", status.Error(codes.ResourceExhausted, "no sessions left"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	err = api.MakeErr(l, codes.InvalidArgument, "invalid CSR", status.Error(codes.Unavailable, "oh no"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
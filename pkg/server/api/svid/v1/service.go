@@ -9,6 +9,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
@@ -217,10 +218,11 @@ func (s *Service) newX509SVID(ctx context.Context, param *svid.NewX509SVIDParams
 	log = log.WithField(telemetry.SPIFFEID, spiffeID.String())
 
 	x509Svid, err := s.ca.SignX509SVID(ctx, ca.X509SVIDParams{
-		SpiffeID:  spiffeID,
-		PublicKey: csr.PublicKey,
-		DNSList:   entry.DnsNames,
-		TTL:       time.Duration(entry.Ttl) * time.Second,
+		SpiffeID:    spiffeID,
+		PublicKey:   csr.PublicKey,
+		DNSList:     entry.DnsNames,
+		PrimaryName: x509svid.PrimaryName(entry.X509SvidPrimaryName),
+		TTL:         time.Duration(entry.Ttl) * time.Second,
 	})
 	if err != nil {
 		return &svid.BatchNewX509SVIDResponse_Result{
@@ -0,0 +1,149 @@
+// Package audit turns bundle writes into auditable events. Every change to
+// the authorities of a bundle, whether it comes from the APIs, the CA
+// manager or the federated bundle refresher, is logged and counted.
+package audit
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// AuthorityTypeX509 is the type of X.509 root CA authorities
+	AuthorityTypeX509 = "x509"
+
+	// AuthorityTypeJWT is the type of JWT signing key authorities
+	AuthorityTypeJWT = "jwt"
+)
+
+// Authority describes a bundle authority
+type Authority struct {
+	// Type is the authority type, either AuthorityTypeX509 or
+	// AuthorityTypeJWT
+	Type string
+
+	// ID identifies the authority. It is the hex encoded subject key ID of
+	// X.509 authorities and the key ID of JWT authorities.
+	ID string
+
+	// ExpiresAt is when the authority expires. It is zero for JWT
+	// authorities that do not expire.
+	ExpiresAt time.Time
+
+	// key uniquely identifies the authority content
+	key string
+}
+
+// Diff holds the authorities added to and removed from a bundle
+type Diff struct {
+	Added   []Authority
+	Removed []Authority
+}
+
+// Empty returns true if no authorities were added or removed
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Compare returns the authorities added and removed between two versions of
+// a bundle. Either bundle may be nil, for example when a bundle is created
+// or deleted.
+func Compare(oldBundle, newBundle *common.Bundle) Diff {
+	oldAuthorities := authorities(oldBundle)
+	newAuthorities := authorities(newBundle)
+
+	var diff Diff
+	for _, authority := range newAuthorities {
+		if !contains(oldAuthorities, authority) {
+			diff.Added = append(diff.Added, authority)
+		}
+	}
+	for _, authority := range oldAuthorities {
+		if !contains(newAuthorities, authority) {
+			diff.Removed = append(diff.Removed, authority)
+		}
+	}
+	return diff
+}
+
+// Report logs each change in the diff and emits telemetry for it
+func Report(log logrus.FieldLogger, metrics telemetry.Metrics, trustDomainID string, diff Diff) {
+	for _, authority := range diff.Added {
+		authorityLog(log, trustDomainID, authority).Info("Bundle authority added")
+		telemetry_server.IncrBundleAuthorityAddedCounter(metrics, trustDomainID, authority.Type)
+	}
+	for _, authority := range diff.Removed {
+		authorityLog(log, trustDomainID, authority).Info("Bundle authority removed")
+		telemetry_server.IncrBundleAuthorityRemovedCounter(metrics, trustDomainID, authority.Type)
+	}
+}
+
+func authorityLog(log logrus.FieldLogger, trustDomainID string, authority Authority) logrus.FieldLogger {
+	fields := logrus.Fields{
+		telemetry.TrustDomainID: trustDomainID,
+		telemetry.AuthorityType: authority.Type,
+	}
+	switch authority.Type {
+	case AuthorityTypeX509:
+		fields[telemetry.SubjectKeyID] = authority.ID
+	case AuthorityTypeJWT:
+		fields[telemetry.Kid] = authority.ID
+	}
+	if !authority.ExpiresAt.IsZero() {
+		fields[telemetry.Expiration] = authority.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return log.WithFields(fields)
+}
+
+func authorities(bundle *common.Bundle) []Authority {
+	if bundle == nil {
+		return nil
+	}
+
+	var authorities []Authority
+	for _, rootCA := range bundle.RootCas {
+		authority := Authority{
+			Type: AuthorityTypeX509,
+			key:  string(rootCA.DerBytes),
+		}
+		if cert, err := x509.ParseCertificate(rootCA.DerBytes); err == nil {
+			authority.ID = hex.EncodeToString(cert.SubjectKeyId)
+			authority.ExpiresAt = cert.NotAfter
+		}
+		if authority.ID == "" {
+			// Fall back to a digest of the certificate so the authority can
+			// still be told apart in the logs.
+			sum := sha256.Sum256(rootCA.DerBytes)
+			authority.ID = hex.EncodeToString(sum[:])
+		}
+		authorities = append(authorities, authority)
+	}
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		authority := Authority{
+			Type: AuthorityTypeJWT,
+			ID:   jwtSigningKey.Kid,
+			key:  jwtSigningKey.Kid + "/" + string(jwtSigningKey.PkixBytes),
+		}
+		if jwtSigningKey.NotAfter != 0 {
+			authority.ExpiresAt = time.Unix(jwtSigningKey.NotAfter, 0).UTC()
+		}
+		authorities = append(authorities, authority)
+	}
+	return authorities
+}
+
+func contains(authorities []Authority, authority Authority) bool {
+	for _, candidate := range authorities {
+		if candidate.Type == authority.Type && candidate.key == authority.key {
+			return true
+		}
+	}
+	return false
+}
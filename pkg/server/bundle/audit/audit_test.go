@@ -0,0 +1,202 @@
+package audit
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+)
+
+const trustDomainID = "spiffe://example.org"
+
+var (
+	expiresAt = time.Unix(1700000000, 0).UTC()
+
+	rootA     = &common.Certificate{}
+	rootB     = &common.Certificate{}
+	rootLater = &common.Certificate{}
+
+	jwtKeyA     = &common.PublicKey{Kid: "KID-A", PkixBytes: []byte("A"), NotAfter: expiresAt.Unix()}
+	jwtKeyB     = &common.PublicKey{Kid: "KID-B", PkixBytes: []byte("B")}
+	jwtKeyLater = &common.PublicKey{Kid: "KID-C", PkixBytes: []byte("C"), NotAfter: expiresAt.Add(time.Hour).Unix()}
+)
+
+func init() {
+	rootA.DerBytes = createRoot([]byte{0x0a}, expiresAt).Raw
+	rootB.DerBytes = createRoot([]byte{0x0b}, expiresAt).Raw
+	rootLater.DerBytes = createRoot([]byte{0x0c}, expiresAt.Add(time.Hour)).Raw
+}
+
+func TestCompare(t *testing.T) {
+	authorityA := Authority{Type: AuthorityTypeX509, ID: "0a", ExpiresAt: expiresAt, key: string(rootA.DerBytes)}
+	authorityB := Authority{Type: AuthorityTypeX509, ID: "0b", ExpiresAt: expiresAt, key: string(rootB.DerBytes)}
+	jwtAuthorityA := Authority{Type: AuthorityTypeJWT, ID: "KID-A", ExpiresAt: expiresAt, key: "KID-A/A"}
+	jwtAuthorityB := Authority{Type: AuthorityTypeJWT, ID: "KID-B", key: "KID-B/B"}
+
+	for _, tt := range []struct {
+		name       string
+		oldBundle  *common.Bundle
+		newBundle  *common.Bundle
+		expectDiff Diff
+	}{
+		{
+			name: "no bundles",
+		},
+		{
+			name:      "unchanged",
+			oldBundle: bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyA}),
+			newBundle: bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyA}),
+		},
+		{
+			name:      "created",
+			newBundle: bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyB}),
+			expectDiff: Diff{
+				Added: []Authority{authorityA, jwtAuthorityB},
+			},
+		},
+		{
+			name:      "deleted",
+			oldBundle: bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyB}),
+			expectDiff: Diff{
+				Removed: []Authority{authorityA, jwtAuthorityB},
+			},
+		},
+		{
+			name:      "rotated",
+			oldBundle: bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyA}),
+			newBundle: bundle([]*common.Certificate{rootA, rootB}, []*common.PublicKey{jwtKeyB}),
+			expectDiff: Diff{
+				Added:   []Authority{authorityB, jwtAuthorityB},
+				Removed: []Authority{jwtAuthorityA},
+			},
+		},
+		{
+			name:      "JWT key replaced under the same key ID",
+			oldBundle: bundle(nil, []*common.PublicKey{jwtKeyA}),
+			newBundle: bundle(nil, []*common.PublicKey{{Kid: "KID-A", PkixBytes: []byte("other")}}),
+			expectDiff: Diff{
+				Added:   []Authority{{Type: AuthorityTypeJWT, ID: "KID-A", key: "KID-A/other"}},
+				Removed: []Authority{jwtAuthorityA},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Compare(tt.oldBundle, tt.newBundle)
+			assert.Equal(t, tt.expectDiff, diff)
+			assert.Equal(t, len(tt.expectDiff.Added)+len(tt.expectDiff.Removed) == 0, diff.Empty())
+		})
+	}
+}
+
+func TestCompareMalformedCertificate(t *testing.T) {
+	diff := Compare(nil, bundle([]*common.Certificate{{DerBytes: []byte("malformed")}}, nil))
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, AuthorityTypeX509, diff.Added[0].Type)
+	sum := sha256.Sum256([]byte("malformed"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), diff.Added[0].ID)
+	assert.True(t, diff.Added[0].ExpiresAt.IsZero())
+}
+
+func TestReport(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+
+	Report(log, metrics, trustDomainID, Compare(
+		bundle(nil, []*common.PublicKey{jwtKeyA}),
+		bundle([]*common.Certificate{rootA}, []*common.PublicKey{jwtKeyB}),
+	))
+
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Bundle authority added",
+			Data: logrus.Fields{
+				"trust_domain_id": trustDomainID,
+				"authority_type":  "x509",
+				"subject_key_id":  "0a",
+				"expiration":      expiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Bundle authority added",
+			Data: logrus.Fields{
+				"trust_domain_id": trustDomainID,
+				"authority_type":  "jwt",
+				"kid":             "KID-B",
+			},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Bundle authority removed",
+			Data: logrus.Fields{
+				"trust_domain_id": trustDomainID,
+				"authority_type":  "jwt",
+				"kid":             "KID-A",
+				"expiration":      expiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	assert.Equal(t, []fakemetrics.MetricItem{
+		authorityMetric("added", "x509"),
+		authorityMetric("added", "jwt"),
+		authorityMetric("removed", "jwt"),
+	}, metrics.AllMetrics())
+}
+
+func bundle(rootCAs []*common.Certificate, jwtSigningKeys []*common.PublicKey) *common.Bundle {
+	return &common.Bundle{
+		TrustDomainId:  trustDomainID,
+		RootCas:        rootCAs,
+		JwtSigningKeys: jwtSigningKeys,
+	}
+}
+
+func authorityMetric(change, authorityType string) fakemetrics.MetricItem {
+	return fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterWithLabelsType,
+		Key:  []string{"bundle", "authority", change},
+		Val:  1,
+		// Metric labels are sanitized
+		Labels: []metrics.Label{
+			{Name: "trust_domain_id", Value: "spiffe_example_org"},
+			{Name: "authority_type", Value: authorityType},
+		},
+	}
+}
+
+func createRoot(subjectKeyID []byte, notAfter time.Time) *x509.Certificate {
+	key := testkey.MustEC256()
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ROOT"},
+		SubjectKeyId:          subjectKeyID,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		NotBefore:             notAfter.Add(-time.Hour),
+		NotAfter:              notAfter,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// WithAuditing wraps a datastore so that every bundle write reports the
+// authorities it adds or removes. The bundle is fetched before each write to
+// compute the diff; concurrent writes to the same bundle may therefore be
+// reported against an intermediate version.
+func WithAuditing(ds datastore.DataStore, log logrus.FieldLogger, metrics telemetry.Metrics) datastore.DataStore {
+	return auditWrapper{
+		DataStore: ds,
+		log:       log,
+		metrics:   metrics,
+	}
+}
+
+type auditWrapper struct {
+	datastore.DataStore
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+}
+
+func (w auditWrapper) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	oldBundle, ok := w.fetchBundle(ctx, req.Bundle.GetTrustDomainId())
+	resp, err := w.DataStore.AppendBundle(ctx, req)
+	if err == nil && ok {
+		w.report(req.Bundle.GetTrustDomainId(), oldBundle, resp.Bundle)
+	}
+	return resp, err
+}
+
+func (w auditWrapper) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	resp, err := w.DataStore.CreateBundle(ctx, req)
+	if err == nil {
+		w.report(req.Bundle.GetTrustDomainId(), nil, resp.Bundle)
+	}
+	return resp, err
+}
+
+func (w auditWrapper) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	resp, err := w.DataStore.DeleteBundle(ctx, req)
+	if err == nil {
+		w.report(req.TrustDomainId, resp.Bundle, nil)
+	}
+	return resp, err
+}
+
+func (w auditWrapper) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	oldBundle, ok := w.fetchBundle(ctx, req.TrustDomainId)
+	resp, err := w.DataStore.PruneBundle(ctx, req)
+	if err == nil && ok && resp.BundleChanged {
+		if newBundle, ok := w.fetchBundle(ctx, req.TrustDomainId); ok {
+			w.report(req.TrustDomainId, oldBundle, newBundle)
+		}
+	}
+	return resp, err
+}
+
+func (w auditWrapper) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	oldBundle, ok := w.fetchBundle(ctx, req.Bundle.GetTrustDomainId())
+	resp, err := w.DataStore.SetBundle(ctx, req)
+	if err == nil && ok {
+		w.report(req.Bundle.GetTrustDomainId(), oldBundle, resp.Bundle)
+	}
+	return resp, err
+}
+
+func (w auditWrapper) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	oldBundle, ok := w.fetchBundle(ctx, req.Bundle.GetTrustDomainId())
+	resp, err := w.DataStore.UpdateBundle(ctx, req)
+	if err == nil && ok {
+		w.report(req.Bundle.GetTrustDomainId(), oldBundle, resp.Bundle)
+	}
+	return resp, err
+}
+
+// fetchBundle returns the current bundle for the trust domain, which is nil
+// if the bundle does not exist. Failing to fetch the bundle does not fail the
+// write but the write goes unreported, since the diff cannot be computed.
+func (w auditWrapper) fetchBundle(ctx context.Context, trustDomainID string) (*common.Bundle, bool) {
+	resp, err := w.DataStore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		w.log.WithError(err).WithField(telemetry.TrustDomainID, trustDomainID).Warn("Unable to fetch bundle for auditing")
+		return nil, false
+	}
+	return resp.Bundle, true
+}
+
+func (w auditWrapper) report(trustDomainID string, oldBundle, newBundle *common.Bundle) {
+	Report(w.log, w.metrics, trustDomainID, Compare(oldBundle, newBundle))
+}
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAuditing(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New(t)
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	auditedDS := WithAuditing(ds, log, metrics)
+
+	requireReported := func(t *testing.T, expected ...fakemetrics.MetricItem) {
+		assert.Equal(t, expected, metrics.AllMetrics())
+		assert.Len(t, hook.AllEntries(), len(expected))
+		metrics.Reset()
+		hook.Reset()
+	}
+
+	_, err := auditedDS.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootA}, nil),
+	})
+	require.NoError(t, err)
+	requireReported(t, authorityMetric("added", "x509"))
+
+	_, err = auditedDS.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootB}, []*common.PublicKey{jwtKeyA}),
+	})
+	require.NoError(t, err)
+	requireReported(t, authorityMetric("added", "x509"), authorityMetric("added", "jwt"))
+
+	// Appending authorities that are already in the bundle is not reported
+	_, err = auditedDS.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootA}, nil),
+	})
+	require.NoError(t, err)
+	requireReported(t)
+
+	_, err = auditedDS.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootB}, []*common.PublicKey{jwtKeyA, jwtKeyB}),
+	})
+	require.NoError(t, err)
+	requireReported(t, authorityMetric("added", "jwt"), authorityMetric("removed", "x509"))
+
+	_, err = auditedDS.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootB, rootLater}, []*common.PublicKey{jwtKeyB, jwtKeyLater}),
+	})
+	require.NoError(t, err)
+	requireReported(t, authorityMetric("added", "x509"), authorityMetric("added", "jwt"), authorityMetric("removed", "jwt"))
+
+	// rootB expires at expiresAt and jwtKeyB has no expiration, so both
+	// are pruned while rootLater and jwtKeyLater are kept
+	pruneResp, err := auditedDS.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: trustDomainID,
+		ExpiresBefore: expiresAt.Unix(),
+	})
+	require.NoError(t, err)
+	require.True(t, pruneResp.BundleChanged)
+	requireReported(t, authorityMetric("removed", "x509"), authorityMetric("removed", "jwt"))
+
+	// Pruning again does not change the bundle
+	pruneResp, err = auditedDS.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: trustDomainID,
+		ExpiresBefore: expiresAt.Unix(),
+	})
+	require.NoError(t, err)
+	require.False(t, pruneResp.BundleChanged)
+	requireReported(t)
+
+	_, err = auditedDS.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	require.NoError(t, err)
+	requireReported(t, authorityMetric("removed", "x509"), authorityMetric("removed", "jwt"))
+}
+
+func TestWithAuditingFetchFailure(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New(t)
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	auditedDS := WithAuditing(ds, log, metrics)
+
+	// The write succeeds but goes unreported since the diff cannot be
+	// computed
+	ds.SetNextError(errors.New("oh no"))
+	_, err := auditedDS.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootA}, nil),
+	})
+	require.NoError(t, err)
+
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Unable to fetch bundle for auditing",
+			Data: logrus.Fields{
+				"trust_domain_id": trustDomainID,
+				logrus.ErrorKey:   "oh no",
+			},
+		},
+	})
+	assert.Empty(t, metrics.AllMetrics())
+}
+
+func TestWithAuditingWriteFailure(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New(t)
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	auditedDS := WithAuditing(ds, log, metrics)
+
+	ds.AppendNextError(nil)
+	ds.AppendNextError(errors.New("oh no"))
+	_, err := auditedDS.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundle([]*common.Certificate{rootA}, nil),
+	})
+	require.EqualError(t, err, "oh no")
+	assert.Empty(t, hook.AllEntries())
+	assert.Empty(t, metrics.AllMetrics())
+}
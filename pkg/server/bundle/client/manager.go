@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
@@ -20,6 +21,20 @@ const (
 	// bundle. It is important to try more than once within a refresh hint
 	// period so we can be resilient to temporary downtime or failures.
 	attemptsPerRefreshHint = 4
+
+	// DefaultStaleRefreshHints is the default number of refresh hint periods
+	// after which a federated bundle that has not been refreshed is
+	// considered stale.
+	DefaultStaleRefreshHints = 3
+
+	// DefaultExpiryWarningThreshold is the default remaining lifetime of the
+	// last X.509 authority in a federated bundle under which a warning is
+	// logged.
+	DefaultExpiryWarningThreshold = 6 * time.Hour
+
+	// stalenessCheckInterval is how often federated bundles are checked for
+	// staleness and expiration, and how often the bundles are counted.
+	stalenessCheckInterval = time.Minute
 )
 
 type TrustDomainConfig struct {
@@ -42,6 +57,16 @@ type ManagerConfig struct {
 	Clock        clock.Clock
 	TrustDomains map[spiffeid.TrustDomain]TrustDomainConfig
 
+	// StaleRefreshHints is the number of refresh hint periods after which a
+	// federated bundle that has not been refreshed is considered stale.
+	// Defaults to DefaultStaleRefreshHints.
+	StaleRefreshHints int
+
+	// ExpiryWarningThreshold is the remaining lifetime of the last X.509
+	// authority in a federated bundle under which a warning is logged.
+	// Defaults to DefaultExpiryWarningThreshold.
+	ExpiryWarningThreshold time.Duration
+
 	// newBundleUpdater is a test hook to inject updater behavior
 	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
 }
@@ -49,8 +74,26 @@ type ManagerConfig struct {
 type Manager struct {
 	log      logrus.FieldLogger
 	metrics  telemetry.Metrics
+	ds       datastore.DataStore
 	clock    clock.Clock
 	updaters map[spiffeid.TrustDomain]BundleUpdater
+
+	staleRefreshHints      int
+	expiryWarningThreshold time.Duration
+
+	statusMtx sync.Mutex
+	status    map[spiffeid.TrustDomain]*bundleStatus
+}
+
+// bundleStatus tracks the freshness of a federated bundle.
+type bundleStatus struct {
+	// bundle is the most recent bundle known for the trust domain
+	bundle *bundleutil.Bundle
+
+	// lastRefresh is the last time the bundle was refreshed from the
+	// bundle endpoint, or when the manager started running if it has not
+	// been refreshed yet.
+	lastRefresh time.Time
 }
 
 func NewManager(config ManagerConfig) *Manager {
@@ -60,6 +103,12 @@ func NewManager(config ManagerConfig) *Manager {
 	if config.newBundleUpdater == nil {
 		config.newBundleUpdater = NewBundleUpdater
 	}
+	if config.StaleRefreshHints <= 0 {
+		config.StaleRefreshHints = DefaultStaleRefreshHints
+	}
+	if config.ExpiryWarningThreshold <= 0 {
+		config.ExpiryWarningThreshold = DefaultExpiryWarningThreshold
+	}
 
 	updaters := make(map[spiffeid.TrustDomain]BundleUpdater)
 	for trustDomain, trustDomainConfig := range config.TrustDomains {
@@ -71,15 +120,26 @@ func NewManager(config ManagerConfig) *Manager {
 	}
 
 	return &Manager{
-		log:      config.Log,
-		metrics:  config.Metrics,
-		clock:    config.Clock,
-		updaters: updaters,
+		log:                    config.Log,
+		metrics:                config.Metrics,
+		ds:                     config.DataStore,
+		clock:                  config.Clock,
+		updaters:               updaters,
+		staleRefreshHints:      config.StaleRefreshHints,
+		expiryWarningThreshold: config.ExpiryWarningThreshold,
+		status:                 make(map[spiffeid.TrustDomain]*bundleStatus),
 	}
 }
 
 func (m *Manager) Run(ctx context.Context) error {
-	var tasks []func(context.Context) error
+	now := m.clock.Now()
+	m.statusMtx.Lock()
+	for trustDomain := range m.updaters {
+		m.status[trustDomain] = &bundleStatus{lastRefresh: now}
+	}
+	m.statusMtx.Unlock()
+
+	tasks := []func(context.Context) error{m.runStalenessChecker}
 	for trustDomain, updater := range m.updaters {
 		// alias the loop variables that are used by the closure
 		trustDomain := trustDomain
@@ -106,8 +166,10 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 		case endpointBundle != nil:
 			telemetry_server.IncrBundleManagerUpdateFederatedBundleCounter(m.metrics, trustDomain.String())
 			log.Info("Bundle refreshed")
+			m.setBundleStatus(trustDomain, endpointBundle, true)
 			nextRefresh = calculateNextUpdate(endpointBundle)
 		case localBundle != nil:
+			m.setBundleStatus(trustDomain, localBundle, false)
 			nextRefresh = calculateNextUpdate(localBundle)
 		default:
 			// We have no bundle to use to calculate the refresh hint. Since
@@ -133,6 +195,96 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 	}
 }
 
+func (m *Manager) setBundleStatus(trustDomain spiffeid.TrustDomain, bundle *bundleutil.Bundle, refreshed bool) {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	status, ok := m.status[trustDomain]
+	if !ok {
+		status = &bundleStatus{lastRefresh: m.clock.Now()}
+		m.status[trustDomain] = status
+	}
+	status.bundle = bundle
+	if refreshed {
+		status.lastRefresh = m.clock.Now()
+	}
+}
+
+// runStalenessChecker periodically checks that the federated bundles are
+// being refreshed and that they have X.509 authorities that are not about to
+// expire, so broken federation relationships are noticed before workloads
+// start failing to authenticate each other.
+func (m *Manager) runStalenessChecker(ctx context.Context) error {
+	ticker := m.clock.Ticker(stalenessCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.checkStaleness()
+			m.countBundles(ctx)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *Manager) checkStaleness() {
+	now := m.clock.Now()
+
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	for trustDomain, status := range m.status {
+		if status.bundle == nil {
+			// There is no bundle to check yet. Failures to obtain it are
+			// logged by the updater.
+			continue
+		}
+
+		log := m.log.WithField(telemetry.TrustDomainID, trustDomain.IDString())
+
+		age := now.Sub(status.lastRefresh)
+		telemetry_server.SetFederatedBundleAgeGauge(m.metrics, trustDomain.String(), float32(age.Seconds()))
+		refreshHint := bundleutil.CalculateRefreshHint(status.bundle)
+		if maxAge := time.Duration(m.staleRefreshHints) * refreshHint; age > maxAge {
+			log.WithFields(logrus.Fields{
+				telemetry.Age:         age.String(),
+				telemetry.RefreshHint: refreshHint.String(),
+			}).Warn("Federated bundle is stale; it has not been refreshed from the bundle endpoint for several refresh hint periods")
+		}
+
+		var lastExpiration time.Time
+		for _, rootCA := range status.bundle.RootCAs() {
+			if rootCA.NotAfter.After(lastExpiration) {
+				lastExpiration = rootCA.NotAfter
+			}
+		}
+		if lastExpiration.IsZero() {
+			continue
+		}
+		ttl := lastExpiration.Sub(now)
+		telemetry_server.SetFederatedBundleTTLGauge(m.metrics, trustDomain.String(), float32(ttl.Seconds()))
+		switch {
+		case ttl <= 0:
+			log.WithField(telemetry.Expiration, lastExpiration.UTC().Format(time.RFC3339)).Warn("All X.509 authorities in federated bundle have expired")
+		case ttl < m.expiryWarningThreshold:
+			log.WithField(telemetry.Expiration, lastExpiration.UTC().Format(time.RFC3339)).Warn("Last X.509 authority in federated bundle is about to expire")
+		}
+	}
+}
+
+// countBundles reports the number of bundles in the datastore, so federation
+// growth can be tracked without listing the bundles.
+func (m *Manager) countBundles(ctx context.Context) {
+	resp, err := m.ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	if err != nil {
+		// Failures while shutting down are not worth reporting
+		if ctx.Err() == nil {
+			m.log.WithError(err).Warn("Failed to count bundles")
+		}
+		return
+	}
+	telemetry_server.SetBundleCountGauge(m.metrics, resp.Bundles)
+}
+
 func calculateNextUpdate(b *bundleutil.Bundle) time.Duration {
 	return bundleutil.CalculateRefreshHint(b) / attemptsPerRefreshHint
 }
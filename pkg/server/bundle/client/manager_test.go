@@ -2,17 +2,25 @@ package client
 
 import (
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/errs"
 	"gotest.tools/assert"
@@ -154,3 +162,151 @@ func (u *fakeBundleUpdater) UpdateBundle(context.Context) (*bundleutil.Bundle, *
 	u.updateCount++
 	return u.localBundle, u.endpointBundle, errors.New("UNUSED")
 }
+
+func TestManagerStalenessChecks(t *testing.T) {
+	clk := clock.NewMock(t)
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	trustDomain := spiffeid.RequireTrustDomainFromString("domain.test")
+
+	caCert, _ := spiretest.SelfSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		NotBefore:    clk.Now(),
+		NotAfter:     clk.Now().Add(12 * time.Hour),
+		IsCA:         true,
+		Subject:      pkix.Name{CommonName: "CA"},
+	})
+	bundle := bundleutil.BundleFromRootCA(trustDomain.IDString(), caCert)
+	bundle.SetRefreshHint(time.Hour)
+
+	manager := NewManager(ManagerConfig{
+		Log:     log,
+		Metrics: metrics,
+		Clock:   clk,
+	})
+
+	expectGauges := func(age, ttl time.Duration) {
+		labels := []telemetry.Label{{Name: telemetry.TrustDomainID, Value: "domain_test"}}
+		assert.DeepEqual(t, []fakemetrics.MetricItem{
+			{
+				Type:   fakemetrics.SetGaugeWithLabelsType,
+				Key:    []string{"bundle_manager", "federated_bundle", "age"},
+				Val:    float32(age.Seconds()),
+				Labels: labels,
+			},
+			{
+				Type:   fakemetrics.SetGaugeWithLabelsType,
+				Key:    []string{"bundle_manager", "federated_bundle", "ttl"},
+				Val:    float32(ttl.Seconds()),
+				Labels: labels,
+			},
+		}, metrics.AllMetrics())
+	}
+
+	// Nothing is checked until a bundle is known
+	manager.checkStaleness()
+	assert.Equal(t, 0, len(metrics.AllMetrics()))
+
+	// The bundle was just refreshed and is not about to expire
+	manager.setBundleStatus(trustDomain, bundle, true)
+	manager.checkStaleness()
+	expectGauges(0, 12*time.Hour)
+	spiretest.AssertLogs(t, hook.AllEntries(), nil)
+
+	// The bundle has not been refreshed for more than three refresh hints
+	metrics.Reset()
+	clk.Add(3*time.Hour + time.Minute)
+	manager.setBundleStatus(trustDomain, bundle, false)
+	manager.checkStaleness()
+	expectGauges(3*time.Hour+time.Minute, 9*time.Hour-time.Minute)
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Federated bundle is stale; it has not been refreshed from the bundle endpoint for several refresh hint periods",
+			Data: logrus.Fields{
+				telemetry.TrustDomainID: "spiffe://domain.test",
+				telemetry.Age:           "3h1m0s",
+				telemetry.RefreshHint:   "1h0m0s",
+			},
+		},
+	})
+
+	// The bundle is refreshed but the last authority is about to expire
+	hook.Reset()
+	metrics.Reset()
+	clk.Add(6 * time.Hour)
+	manager.setBundleStatus(trustDomain, bundle, true)
+	manager.checkStaleness()
+	expectGauges(0, 3*time.Hour-time.Minute)
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Last X.509 authority in federated bundle is about to expire",
+			Data: logrus.Fields{
+				telemetry.TrustDomainID: "spiffe://domain.test",
+				telemetry.Expiration:    caCert.NotAfter.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+
+	// All authorities have expired
+	hook.Reset()
+	metrics.Reset()
+	clk.Add(3 * time.Hour)
+	manager.setBundleStatus(trustDomain, bundle, true)
+	manager.checkStaleness()
+	expectGauges(0, -time.Minute)
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "All X.509 authorities in federated bundle have expired",
+			Data: logrus.Fields{
+				telemetry.TrustDomainID: "spiffe://domain.test",
+				telemetry.Expiration:    caCert.NotAfter.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+}
+
+func TestManagerCountsBundles(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	ds := fakedatastore.New(t)
+
+	manager := NewManager(ManagerConfig{
+		Log:       log,
+		Metrics:   metrics,
+		DataStore: ds,
+	})
+
+	for _, td := range []string{"spiffe://domain.test", "spiffe://other.test"} {
+		_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+			Bundle: &common.Bundle{TrustDomainId: td},
+		})
+		require.NoError(t, err)
+	}
+
+	manager.countBundles(context.Background())
+	assert.DeepEqual(t, []fakemetrics.MetricItem{
+		{
+			Type: fakemetrics.SetGaugeType,
+			Key:  []string{"bundle_manager", "bundle", "count"},
+			Val:  2,
+		},
+	}, metrics.AllMetrics())
+
+	// The gauge is left as is when the bundles cannot be counted
+	metrics.Reset()
+	ds.SetNextError(errors.New("oh no"))
+	manager.countBundles(context.Background())
+	assert.Equal(t, 0, len(metrics.AllMetrics()))
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Failed to count bundles",
+			Data: logrus.Fields{
+				logrus.ErrorKey: "oh no",
+			},
+		},
+	})
+}
@@ -129,6 +129,8 @@ func TestBundleUpdater(t *testing.T) {
 			require.NotNil(t, resp)
 			if testCase.storedBundle != nil {
 				require.NotNil(t, resp.Bundle)
+				// The sequence number is assigned by the datastore
+				resp.Bundle.SequenceNumber = 0
 				spiretest.RequireProtoEqual(t, testCase.storedBundle.Proto(), resp.Bundle)
 			} else {
 				require.Nil(t, resp.Bundle)
@@ -0,0 +1,96 @@
+// Package propagation keeps track of the trust domain bundle that was last
+// served to each agent, so operators can tell how far a bundle change (e.g.
+// a prepared CA during a forced rotation) has propagated.
+package propagation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sync"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Tracker records the digest of the bundle last served to each agent. The
+// records are kept in memory and only cover agents that synced with this
+// server since it started.
+type Tracker struct {
+	mu     sync.RWMutex
+	agents map[string]string
+}
+
+// NewTracker returns a new, empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		agents: make(map[string]string),
+	}
+}
+
+// Record records that the agent with the given SPIFFE ID has been served
+// the given bundle.
+func (t *Tracker) Record(agentID string, bundle *common.Bundle) {
+	if bundle == nil {
+		return
+	}
+	digest := Digest(bundle)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.agents[agentID] = digest
+}
+
+// Forget removes any record for the agent with the given SPIFFE ID.
+func (t *Tracker) Forget(agentID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.agents, agentID)
+}
+
+// Agents returns the number of agents with a record, regardless of the bundle
+// they were last served.
+func (t *Tracker) Agents() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.agents)
+}
+
+// SyncedAgents returns the number of agents that were last served a bundle
+// with the given digest.
+func (t *Tracker) SyncedAgents(digest string) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	count := 0
+	for _, agentDigest := range t.agents {
+		if agentDigest == digest {
+			count++
+		}
+	}
+	return count
+}
+
+// Digest returns a hex encoded SHA-256 digest over the X.509 and JWT
+// authorities of the bundle. Two bundles with the same authorities, in the
+// same order, have the same digest.
+func Digest(bundle *common.Bundle) string {
+	h := sha256.New()
+	for _, rootCA := range bundle.RootCas {
+		writeField(h, rootCA.DerBytes)
+	}
+	for _, jwtKey := range bundle.JwtSigningKeys {
+		writeField(h, []byte(jwtKey.Kid))
+		writeField(h, jwtKey.PkixBytes)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeField writes the length prefixed field so that field boundaries are
+// part of the digest.
+func writeField(h hash.Hash, b []byte) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(b)))
+	_, _ = h.Write(size[:])
+	_, _ = h.Write(b)
+}
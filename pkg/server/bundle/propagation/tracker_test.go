@@ -0,0 +1,61 @@
+package propagation
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bundleA = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("A")}},
+	}
+	bundleAB = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("A")}, {DerBytes: []byte("B")}},
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "KID", PkixBytes: []byte("KEY")},
+		},
+	}
+)
+
+func TestDigest(t *testing.T) {
+	assert.Equal(t, Digest(bundleA), Digest(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("A")}},
+		RefreshHint:   60,
+	}), "refresh hint should not affect the digest")
+	assert.NotEqual(t, Digest(bundleA), Digest(bundleAB))
+
+	// Field boundaries are part of the digest
+	assert.NotEqual(t,
+		Digest(&common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("AB")}}}),
+		Digest(&common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("A")}, {DerBytes: []byte("B")}}}))
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	require.Equal(t, 0, tracker.SyncedAgents(Digest(bundleA)))
+	require.Equal(t, 0, tracker.Agents())
+
+	tracker.Record("spiffe://example.org/spire/agent/1", bundleA)
+	tracker.Record("spiffe://example.org/spire/agent/2", bundleA)
+	tracker.Record("spiffe://example.org/spire/agent/3", nil)
+	assert.Equal(t, 2, tracker.SyncedAgents(Digest(bundleA)))
+	assert.Equal(t, 2, tracker.Agents())
+	assert.Equal(t, 0, tracker.SyncedAgents(Digest(bundleAB)))
+
+	// Agents move to the new bundle as they sync
+	tracker.Record("spiffe://example.org/spire/agent/1", bundleAB)
+	assert.Equal(t, 1, tracker.SyncedAgents(Digest(bundleA)))
+	assert.Equal(t, 1, tracker.SyncedAgents(Digest(bundleAB)))
+
+	assert.Equal(t, 2, tracker.Agents())
+
+	tracker.Forget("spiffe://example.org/spire/agent/1")
+	assert.Equal(t, 0, tracker.SyncedAgents(Digest(bundleAB)))
+	assert.Equal(t, 1, tracker.Agents())
+}
@@ -6,7 +6,9 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,9 +18,12 @@ import (
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -51,9 +56,13 @@ type X509SVIDParams struct {
 	TTL time.Duration
 
 	// DNSList is used to add DNS SAN's to the X509 SVID. The first entry
-	// is also added as the CN.
+	// is also added as the CN, unless the primary name is the SPIFFE ID.
 	DNSList []string
 
+	// PrimaryName determines which name is presented first in the SVID. If
+	// empty, the CA default is used.
+	PrimaryName x509svid.PrimaryName
+
 	// Subject of the SVID. Default subject is used if it is empty.
 	Subject pkix.Name
 }
@@ -108,6 +117,20 @@ type JWTKey struct {
 	NotAfter time.Time
 }
 
+// TTLPolicy caps the TTL of the SVIDs issued for SPIFFE IDs whose path
+// starts with PathPrefix, regardless of the TTL requested for the SVID.
+type TTLPolicy struct {
+	// PathPrefix is matched against the path of the SPIFFE ID of the SVID
+	// (e.g. "/admin/").
+	PathPrefix string
+
+	// X509SVIDMaxTTL is the maximum TTL of X509-SVIDs. Zero means no cap.
+	X509SVIDMaxTTL time.Duration
+
+	// JWTSVIDMaxTTL is the maximum TTL of JWT-SVIDs. Zero means no cap.
+	JWTSVIDMaxTTL time.Duration
+}
+
 type Config struct {
 	Log         logrus.FieldLogger
 	Metrics     telemetry.Metrics
@@ -117,6 +140,13 @@ type Config struct {
 	JWTIssuer   string
 	Clock       clock.Clock
 	CASubject   pkix.Name
+
+	// X509SVIDPrimaryName is the default primary name of X509 SVIDs.
+	X509SVIDPrimaryName x509svid.PrimaryName
+
+	// TTLPolicies cap the TTL of SVIDs by SPIFFE ID path prefix. When more
+	// than one policy matches a SPIFFE ID, the smallest cap applies.
+	TTLPolicies []TTLPolicy
 }
 
 type CA struct {
@@ -182,6 +212,9 @@ func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.
 	if params.TTL <= 0 {
 		params.TTL = ca.c.X509SVIDTTL
 	}
+	params.TTL = ca.capTTL(params.SpiffeID, params.TTL, func(p TTLPolicy) time.Duration {
+		return p.X509SVIDMaxTTL
+	})
 
 	notBefore, notAfter := ca.capLifetime(params.TTL, x509CA.Certificate.NotAfter)
 	serialNumber, err := x509util.NewSerialNumber()
@@ -203,16 +236,19 @@ func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.
 	// added if the subject and issuer match name match (however unlikely).
 	template.AuthorityKeyId = x509CA.Certificate.SubjectKeyId
 
-	// for non-CA certificates, add DNS names to certificate. the first DNS
+	// for non-CA certificates, add DNS names to certificate. the primary
 	// name is also added as the common name.
-	if len(params.DNSList) > 0 {
-		template.Subject.CommonName = params.DNSList[0]
-		template.DNSNames = params.DNSList
+	primaryName := params.PrimaryName
+	if primaryName == "" {
+		primaryName = ca.c.X509SVIDPrimaryName
+	}
+	if err := x509svid.ApplyPrimaryName(template, primaryName, params.DNSList); err != nil {
+		return nil, err
 	}
 
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
-		return nil, errs.New("unable to create X509 SVID: %v", err)
+		return nil, ca.signingErr("unable to create X509 SVID", err)
 	}
 
 	spiffeID := cert.URIs[0].String()
@@ -260,7 +296,7 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
-		return nil, errs.New("unable to create X509 CA SVID: %v", err)
+		return nil, ca.signingErr("unable to create X509 CA SVID", err)
 	}
 
 	spiffeID := cert.URIs[0].String()
@@ -289,11 +325,14 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 	if ttl <= 0 {
 		ttl = ca.c.JWTSVIDTTL
 	}
+	ttl = ca.capTTL(params.SpiffeID, ttl, func(p TTLPolicy) time.Duration {
+		return p.JWTSVIDMaxTTL
+	})
 	_, expiresAt := ca.capLifetime(ttl, jwtKey.NotAfter)
 
 	token, err := ca.jwtSigner.SignToken(params.SpiffeID.String(), params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid)
 	if err != nil {
-		return "", errs.New("unable to sign JWT SVID: %v", err)
+		return "", ca.signingErr("unable to sign JWT SVID", err)
 	}
 
 	telemetry_server.IncrServerCASignJWTSVIDCounter(ca.c.Metrics)
@@ -306,6 +345,20 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 	return token, nil
 }
 
+// capTTL caps the TTL using the maximum TTL, as returned by maxTTL, of each
+// TTL policy matching the SPIFFE ID.
+func (ca *CA) capTTL(id spiffeid.ID, ttl time.Duration, maxTTL func(TTLPolicy) time.Duration) time.Duration {
+	for _, policy := range ca.c.TTLPolicies {
+		if !strings.HasPrefix(id.Path(), policy.PathPrefix) {
+			continue
+		}
+		if limit := maxTTL(policy); limit > 0 && ttl > limit {
+			ttl = limit
+		}
+	}
+	return ttl
+}
+
 func (ca *CA) capLifetime(ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
 	now := ca.c.Clock.Now()
 	notBefore = now.Add(-backdate)
@@ -323,8 +376,44 @@ func makeSVIDCertChain(x509CA *X509CA, cert *x509.Certificate) []*x509.Certifica
 func createCertificate(template, parent *x509.Certificate, pub, priv interface{}) (*x509.Certificate, error) {
 	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
 	if err != nil {
-		return nil, errs.New("unable to create X509 SVID: %v", err)
+		return nil, fmt.Errorf("unable to create X509 SVID: %w", err)
 	}
 
 	return x509.ParseCertificate(certDER)
 }
+
+// signingErr returns the error for a failed signing operation. If the
+// signing failed because the key manager is temporarily unavailable (e.g.
+// during an HSM outage), the error carries an Unavailable or
+// ResourceExhausted code so that callers can tell the outage apart from
+// other failures and retry later.
+func (ca *CA) signingErr(msg string, err error) error {
+	code, ok := keyManagerUnavailable(err)
+	if !ok {
+		return errs.New("%s: %v", msg, err)
+	}
+	telemetry_server.IncrServerCASignUnavailableCounter(ca.c.Metrics)
+	return status.Errorf(code, "%s: key manager is unavailable: %v", msg, err)
+}
+
+// keyManagerUnavailable returns whether err was caused by the key manager
+// being temporarily unable to serve requests and, if so, the code callers
+// should see: ResourceExhausted if the key manager ran out of resources
+// (e.g. HSM sessions) and Unavailable otherwise.
+func keyManagerUnavailable(err error) (codes.Code, bool) {
+	var st interface{ GRPCStatus() *status.Status }
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.Unavailable, true
+	case !errors.As(err, &st):
+		return codes.OK, false
+	}
+	switch code := st.GRPCStatus().Code(); code {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return code, true
+	case codes.DeadlineExceeded:
+		return codes.Unavailable, true
+	default:
+		return codes.OK, false
+	}
+}
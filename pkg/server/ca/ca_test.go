@@ -2,9 +2,12 @@ package ca
 
 import (
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
+	"io"
 	"math/big"
 	"testing"
 	"time"
@@ -14,10 +17,13 @@ import (
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -157,6 +163,37 @@ func (s *CATestSuite) TestSignX509SVIDMultipleDNS() {
 	s.Require().Equal("somehost1", svid[0].Subject.CommonName)
 }
 
+func (s *CATestSuite) TestSignX509SVIDPrimaryNameSPIFFEID() {
+	params := s.createX509SVIDParams()
+	params.DNSList = []string{"somehost1", "somehost2"}
+	params.PrimaryName = x509svid.PrimaryNameSPIFFEID
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(params.DNSList, svid[0].DNSNames)
+	s.Require().Equal("spiffe://example.org/workload", svid[0].Subject.CommonName)
+	s.Require().Len(svid[0].URIs, 1)
+	s.Require().Equal("spiffe://example.org/workload", svid[0].URIs[0].String())
+}
+
+func (s *CATestSuite) TestSignX509SVIDPrimaryNameFromConfig() {
+	s.ca.c.X509SVIDPrimaryName = x509svid.PrimaryNameSPIFFEID
+
+	params := s.createX509SVIDParams()
+	params.DNSList = []string{"somehost1"}
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal("spiffe://example.org/workload", svid[0].Subject.CommonName)
+
+	// The per-SVID primary name overrides the configured default
+	params.PrimaryName = x509svid.PrimaryNameDNSName
+	svid, err = s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal("somehost1", svid[0].Subject.CommonName)
+}
+
 func (s *CATestSuite) TestSignX509SVIDWithSubject() {
 	subject := pkix.Name{
 		Organization: []string{"ORG"},
@@ -240,6 +277,33 @@ func (s *CATestSuite) TestSignX509SVIDCapsTTLToCATTL() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), svid[0].NotAfter)
 }
 
+func (s *CATestSuite) TestSignX509SVIDCapsTTLToPolicy() {
+	s.ca.c.TTLPolicies = []TTLPolicy{
+		{PathPrefix: "/work", X509SVIDMaxTTL: 30 * time.Second},
+		{PathPrefix: "/workload", X509SVIDMaxTTL: 20 * time.Second, JWTSVIDMaxTTL: time.Second},
+		{PathPrefix: "/admin/", X509SVIDMaxTTL: time.Second},
+	}
+
+	// The smallest cap of the matching policies applies, even to the
+	// default TTL
+	svid, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(s.clock.Now().Add(20*time.Second), svid[0].NotAfter)
+}
+
+func (s *CATestSuite) TestSignX509SVIDIgnoresNonMatchingTTLPolicy() {
+	s.ca.c.TTLPolicies = []TTLPolicy{
+		{PathPrefix: "/admin/", X509SVIDMaxTTL: time.Second},
+		{PathPrefix: "/workload", JWTSVIDMaxTTL: time.Second},
+	}
+
+	svid, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
+}
+
 func (s *CATestSuite) TestSignX509SVIDValidatesTrustDomain() {
 	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParamsInDomain(trustDomainFoo))
 	s.Require().EqualError(err, `"spiffe://foo.com/workload" is not a member of trust domain "example.org"`)
@@ -288,6 +352,18 @@ func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), expiresAt)
 }
 
+func (s *CATestSuite) TestSignJWTSVIDCapsTTLToPolicy() {
+	s.ca.c.TTLPolicies = []TTLPolicy{
+		{PathPrefix: "/workload", X509SVIDMaxTTL: time.Second, JWTSVIDMaxTTL: 30 * time.Second},
+	}
+
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams(trustDomainExample, time.Minute))
+	s.Require().NoError(err)
+	_, expiresAt, err := jwtsvid.GetTokenExpiry(token)
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(30*time.Second), expiresAt)
+}
+
 func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	// spiffe id for wrong trust domain
 	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams(trustDomainFoo, 0))
@@ -344,6 +420,45 @@ func (s *CATestSuite) TestSignCAX509SVIDValidatesTrustDomain() {
 	s.Require().EqualError(err, `"spiffe://foo.com" is not a member of trust domain "example.org"`)
 }
 
+func (s *CATestSuite) TestSignX509SVIDKeyManagerUnavailable() {
+	s.ca.SetX509CA(&X509CA{
+		Signer:      failingSigner{err: status.Error(codes.Unavailable, "hsm is down")},
+		Certificate: s.caCert,
+	})
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().Equal(codes.Unavailable, status.Code(err))
+	s.Require().Contains(status.Convert(err).Message(), "unable to create X509 SVID: key manager is unavailable")
+}
+
+func (s *CATestSuite) TestSignX509CASVIDKeyManagerDeadlineExceeded() {
+	s.ca.SetX509CA(&X509CA{
+		Signer:      failingSigner{err: status.Error(codes.DeadlineExceeded, "timed out")},
+		Certificate: s.caCert,
+	})
+	_, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams(trustDomainExample))
+	s.Require().Equal(codes.Unavailable, status.Code(err))
+}
+
+func (s *CATestSuite) TestSignJWTSVIDKeyManagerResourceExhausted() {
+	s.ca.SetJWTKey(&JWTKey{
+		Signer:   failingSigner{err: status.Error(codes.ResourceExhausted, "no sessions left")},
+		Kid:      "KID",
+		NotAfter: s.clock.Now().Add(10 * time.Minute),
+	})
+	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams(trustDomainExample, 0))
+	s.Require().Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (s *CATestSuite) TestSignX509SVIDSignerFailure() {
+	s.ca.SetX509CA(&X509CA{
+		Signer:      failingSigner{err: errors.New("oh no")},
+		Certificate: s.caCert,
+	})
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().EqualError(err, "unable to create X509 SVID: unable to create X509 SVID: oh no")
+	s.Require().Equal(codes.Unknown, status.Code(err))
+}
+
 func (s *CATestSuite) setX509CA(selfSigned bool) {
 	var upstreamChain []*x509.Certificate
 	if !selfSigned {
@@ -413,3 +528,15 @@ func (s *CATestSuite) createCACertificate(cn string, parent *x509.Certificate) *
 	s.Require().NoError(err)
 	return cert
 }
+
+type failingSigner struct {
+	err error
+}
+
+func (s failingSigner) Public() crypto.PublicKey {
+	return testSigner.Public()
+}
+
+func (s failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, s.err
+}
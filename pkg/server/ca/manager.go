@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -39,6 +41,17 @@ const (
 	pruneInterval   = 6 * time.Hour
 	safetyThreshold = 24 * time.Hour
 
+	// DefaultUpstreamBundlePollInterval is how often the UpstreamAuthority is
+	// polled for X.509 root updates when it is not streaming them.
+	DefaultUpstreamBundlePollInterval = 6 * time.Hour
+
+	// DefaultKeyManagerRetryTimeout is how long initialization is retried
+	// while the KeyManager is unavailable before giving up.
+	DefaultKeyManagerRetryTimeout = 2 * time.Minute
+
+	keyManagerRetryInitialBackoff = time.Second
+	keyManagerRetryMaxBackoff     = 30 * time.Second
+
 	thirtyDays              = 30 * 24 * time.Hour
 	preparationThresholdCap = thirtyDays
 
@@ -46,6 +59,15 @@ const (
 	activationThresholdCap = sevenDays
 
 	publishJWKTimeout = 5 * time.Second
+
+	// upstreamRootsExpiryThreshold is how long before the last upstream root
+	// expires that the manager starts warning about it.
+	upstreamRootsExpiryThreshold = thirtyDays
+
+	// prePublicationExpiryMargin is how long before the current X509 CA
+	// expires that the next X509 CA is activated even if it has not been
+	// published for the whole pre-publication window.
+	prePublicationExpiryMargin = time.Minute
 )
 
 type ManagedCA interface {
@@ -65,6 +87,23 @@ type ManagerConfig struct {
 	Log           logrus.FieldLogger
 	Metrics       telemetry.Metrics
 	Clock         clock.Clock
+
+	// UpstreamBundlePollInterval is how often the UpstreamAuthority is polled
+	// for X.509 root updates when it is not streaming them. Defaults to
+	// DefaultUpstreamBundlePollInterval.
+	UpstreamBundlePollInterval time.Duration
+
+	// KeyManagerRetryTimeout is how long initialization is retried, with
+	// exponential backoff, while the KeyManager is temporarily unavailable
+	// (e.g. during an HSM outage). Defaults to DefaultKeyManagerRetryTimeout.
+	KeyManagerRetryTimeout time.Duration
+
+	// CAPrePublicationWindow is the minimum time a prepared X509 CA is
+	// published in the trust bundle before it is activated, so relying
+	// parties have its root before the first SVID chains to it. The next
+	// X509 CA is prepared early enough for the window to elapse by the
+	// activation threshold. Zero keeps the default preparation schedule.
+	CAPrePublicationWindow time.Duration
 }
 
 type Manager struct {
@@ -91,6 +130,12 @@ func NewManager(c ManagerConfig) *Manager {
 	if c.Clock == nil {
 		c.Clock = clock.New()
 	}
+	if c.UpstreamBundlePollInterval <= 0 {
+		c.UpstreamBundlePollInterval = DefaultUpstreamBundlePollInterval
+	}
+	if c.KeyManagerRetryTimeout <= 0 {
+		c.KeyManagerRetryTimeout = DefaultKeyManagerRetryTimeout
+	}
 	if c.X509CAKeyType == 0 {
 		c.X509CAKeyType = keymanager.KeyType_EC_P256
 	}
@@ -119,14 +164,40 @@ func NewManager(c ManagerConfig) *Manager {
 	return m
 }
 
+// Initialize loads the CAs from the journal and prepares any missing ones.
+// The server cannot serve without a CA to sign its own SVID, so if the
+// KeyManager is temporarily unavailable, initialization is retried with
+// exponential backoff for up to KeyManagerRetryTimeout before failing.
 func (m *Manager) Initialize(ctx context.Context) error {
-	if err := m.loadJournal(ctx); err != nil {
-		return err
+	deadline := m.c.Clock.Now().Add(m.c.KeyManagerRetryTimeout)
+	backoff := keyManagerRetryInitialBackoff
+	for {
+		err := m.initialize(ctx)
+		if _, unavailable := keyManagerUnavailable(err); !unavailable {
+			return err
+		}
+		telemetry_server.IncrCAManagerKeyManagerUnavailableCounter(m.c.Metrics)
+		if !m.c.Clock.Now().Add(backoff).Before(deadline) {
+			return fmt.Errorf("key manager unavailable for %s: %w", m.c.KeyManagerRetryTimeout, err)
+		}
+		m.c.Log.WithError(err).WithField(telemetry.RetryInterval, backoff).Warn("Key manager is unavailable; retrying CA initialization")
+		select {
+		case <-m.c.Clock.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+		if backoff > keyManagerRetryMaxBackoff {
+			backoff = keyManagerRetryMaxBackoff
+		}
 	}
-	if err := m.rotate(ctx); err != nil {
+}
+
+func (m *Manager) initialize(ctx context.Context) error {
+	if err := m.loadJournal(ctx); err != nil {
 		return err
 	}
-	return nil
+	return m.rotate(ctx)
 }
 
 func (m *Manager) Run(ctx context.Context) error {
@@ -139,7 +210,7 @@ func (m *Manager) Run(ctx context.Context) error {
 	if err := m.notifyBundleLoaded(ctx); err != nil {
 		return err
 	}
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
 			return m.rotateEvery(ctx, rotateInterval)
 		},
@@ -152,7 +223,13 @@ func (m *Manager) Run(ctx context.Context) error {
 			m.notifyOnBundleUpdate(ctx)
 			return nil
 		},
-	)
+	}
+	if m.upstreamClient != nil {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return m.pollUpstreamBundleEvery(ctx, m.c.UpstreamBundlePollInterval)
+		})
+	}
+	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
@@ -177,20 +254,32 @@ func (m *Manager) rotateEvery(ctx context.Context, interval time.Duration) error
 	}
 }
 
+// rotate prepares and activates CAs as needed. Failures are logged, and the
+// current CAs, if any, are kept in use, so that the server keeps serving
+// while the KeyManager or UpstreamAuthority is unavailable.
 func (m *Manager) rotate(ctx context.Context) error {
 	x509CAErr := m.rotateX509CA(ctx)
 	if x509CAErr != nil {
-		m.c.Log.WithError(x509CAErr).Error("Unable to rotate X509 CA")
+		m.logRotateErr(x509CAErr, "Unable to rotate X509 CA")
 	}
 
 	jwtKeyErr := m.rotateJWTKey(ctx)
 	if jwtKeyErr != nil {
-		m.c.Log.WithError(jwtKeyErr).Error("Unable to rotate JWT key")
+		m.logRotateErr(jwtKeyErr, "Unable to rotate JWT key")
 	}
 
 	return errs.Combine(x509CAErr, jwtKeyErr)
 }
 
+func (m *Manager) logRotateErr(err error, msg string) {
+	if _, unavailable := keyManagerUnavailable(err); unavailable {
+		telemetry_server.IncrCAManagerKeyManagerUnavailableCounter(m.c.Metrics)
+		m.c.Log.WithError(err).Error(msg + "; key manager is unavailable")
+		return
+	}
+	m.c.Log.WithError(err).Error(msg)
+}
+
 func (m *Manager) rotateX509CA(ctx context.Context) error {
 	now := m.c.Clock.Now()
 
@@ -204,13 +293,13 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 
 	// if there is no next keypair set and the current is within the
 	// preparation threshold, generate one.
-	if m.nextX509CA.IsEmpty() && m.currentX509CA.ShouldPrepareNext(now) {
+	if m.nextX509CA.IsEmpty() && m.shouldPrepareNextX509CA(now) {
 		if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
 			return err
 		}
 	}
 
-	if m.currentX509CA.ShouldActivateNext(now) {
+	if m.shouldActivateNextX509CA(now) {
 		m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
 		m.nextX509CA.Reset()
 		m.activateX509CA()
@@ -219,6 +308,47 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 	return nil
 }
 
+// shouldPrepareNextX509CA returns true if the next X509 CA must be prepared,
+// either because the current one is within the preparation threshold, or so
+// that the next one is published for the whole pre-publication window by
+// the activation threshold.
+func (m *Manager) shouldPrepareNextX509CA(now time.Time) bool {
+	if m.currentX509CA.ShouldPrepareNext(now) {
+		return true
+	}
+	if m.c.CAPrePublicationWindow <= 0 || m.currentX509CA.x509CA == nil {
+		return false
+	}
+	activationThreshold := KeyActivationThreshold(m.currentX509CA.issuedAt, m.currentX509CA.x509CA.Certificate.NotAfter)
+	return now.After(activationThreshold.Add(-m.c.CAPrePublicationWindow))
+}
+
+// shouldActivateNextX509CA returns true if the next X509 CA must be
+// activated. Activation is deferred until the next X509 CA has been
+// published for the pre-publication window, unless the current X509 CA is
+// about to expire.
+func (m *Manager) shouldActivateNextX509CA(now time.Time) bool {
+	if !m.currentX509CA.ShouldActivateNext(now) {
+		return false
+	}
+	if m.nextX509CA.IsEmpty() {
+		return true
+	}
+	publishedUntil := m.nextX509CA.issuedAt.Add(m.c.CAPrePublicationWindow)
+	if !now.Before(publishedUntil) {
+		return true
+	}
+	if now.Before(m.currentX509CA.x509CA.Certificate.NotAfter.Add(-prePublicationExpiryMargin)) {
+		return false
+	}
+	m.c.Log.WithFields(logrus.Fields{
+		telemetry.Slot:       m.nextX509CA.id,
+		telemetry.IssuedAt:   timeField(m.nextX509CA.issuedAt),
+		telemetry.Expiration: timeField(m.currentX509CA.x509CA.Certificate.NotAfter),
+	}).Warn("Activating X509 CA before the pre-publication window elapsed since the current X509 CA is about to expire")
+	return true
+}
+
 func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err error) {
 	counter := telemetry_server.StartServerCAManagerPrepareX509CACall(m.c.Metrics)
 	defer counter.Done(&err)
@@ -459,6 +589,92 @@ func (m *Manager) pruneBundle(ctx context.Context) (err error) {
 	return nil
 }
 
+func (m *Manager) pollUpstreamBundleEvery(ctx context.Context, interval time.Duration) error {
+	ticker := m.c.Clock.Ticker(interval)
+	defer ticker.Stop()
+
+	m.checkUpstreamRootsExpiry(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.pollUpstreamBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not poll the upstream authority for X.509 root updates")
+			}
+			m.checkUpstreamRootsExpiry(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// pollUpstreamBundle fetches the X.509 roots from the UpstreamAuthority
+// plugin when the plugin is not streaming root updates, either because it
+// does not support streaming or because the stream ended. The plugin only
+// returns roots alongside a freshly minted CA, so the CA is minted for an
+// ephemeral key and discarded. The roots are appended to the bundle by the
+// upstream client, which also resubscribes to updates if the plugin supports
+// streaming them.
+func (m *Manager) pollUpstreamBundle(ctx context.Context) (err error) {
+	if m.upstreamClient.IsMintX509CAStreamActive() {
+		return nil
+	}
+
+	counter := telemetry_server.StartCAManagerPollUpstreamBundleCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("unable to generate ephemeral key: %w", err)
+	}
+	csr, err := GenerateServerCACSR(key, m.c.TrustDomain, m.c.CASubject)
+	if err != nil {
+		return err
+	}
+	if _, err := m.upstreamClient.MintX509CA(ctx, csr, m.c.CATTL); err != nil {
+		return fmt.Errorf("unable to fetch X.509 roots: %w", err)
+	}
+	return nil
+}
+
+// checkUpstreamRootsExpiry reports how long until the last X.509 root in the
+// bundle expires and warns when it is within upstreamRootsExpiryThreshold,
+// which indicates the upstream PKI has not rotated its root in time.
+func (m *Manager) checkUpstreamRootsExpiry(ctx context.Context) {
+	bundle, err := m.fetchOptionalBundle(ctx)
+	if err != nil {
+		m.c.Log.WithError(err).Error("Could not fetch bundle to check upstream root expiration")
+		return
+	}
+	if bundle == nil {
+		return
+	}
+
+	var notAfter time.Time
+	for _, rootCA := range bundle.RootCas {
+		cert, err := x509.ParseCertificate(rootCA.DerBytes)
+		if err != nil {
+			m.c.Log.WithError(err).Error("Could not parse bundle root CA to check upstream root expiration")
+			return
+		}
+		if cert.NotAfter.After(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return
+	}
+
+	ttl := notAfter.Sub(m.c.Clock.Now())
+	telemetry_server.SetUpstreamRootsTTLGauge(m.c.Metrics, m.c.TrustDomain.String(), float32(ttl.Seconds()))
+	if ttl < upstreamRootsExpiryThreshold {
+		telemetry_server.IncrUpstreamRootsExpiringCounter(m.c.Metrics)
+		m.c.Log.WithFields(logrus.Fields{
+			telemetry.TrustDomainID: m.c.TrustDomain.IDString(),
+			telemetry.Expiration:    timeField(notAfter),
+		}).Warn("All upstream X.509 roots are about to expire; the upstream authority may not have rotated its root")
+	}
+}
+
 func (m *Manager) appendBundle(ctx context.Context, caChain []*x509.Certificate, jwtSigningKeys []*common.PublicKey) (*datastore.AppendBundleResponse, error) {
 	var rootCAs []*common.Certificate
 	for _, caCert := range caChain {
@@ -36,6 +36,8 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeupstreamauthority"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -133,6 +135,46 @@ func (s *ManagerSuite) TestPersistenceFailsIfJournalLost() {
 	s.requireJWTKeyNotEqual(jwtKey, s.currentJWTKey())
 }
 
+func (s *ManagerSuite) TestInitializeRetriesWhileKeyManagerUnavailable() {
+	km := &unavailableKeyManager{KeyManager: s.km, unavailable: true}
+	s.cat.SetKeyManager(km)
+	s.cat.SetUpstreamAuthority(nil)
+	s.m = NewManager(s.selfSignedConfig())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.m.Initialize(context.Background())
+	}()
+
+	s.clock.WaitForAfter(time.Minute, "waiting for initialization to be retried")
+	km.SetUnavailable(false)
+	s.clock.Add(time.Second)
+
+	s.Require().NoError(<-errCh)
+	s.Require().NotNil(s.currentX509CA())
+	s.Require().NotNil(s.currentJWTKey())
+}
+
+func (s *ManagerSuite) TestInitializeFailsIfKeyManagerStaysUnavailable() {
+	s.cat.SetKeyManager(&unavailableKeyManager{KeyManager: s.km, unavailable: true})
+	s.cat.SetUpstreamAuthority(nil)
+	c := s.selfSignedConfig()
+	c.KeyManagerRetryTimeout = 3 * time.Second
+	s.m = NewManager(c)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.m.Initialize(context.Background())
+	}()
+
+	s.clock.WaitForAfter(time.Minute, "waiting for initialization to be retried")
+	s.clock.Add(time.Second)
+
+	err := <-errCh
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "key manager unavailable for 3s")
+}
+
 func (s *ManagerSuite) TestSelfSigning() {
 	s.initSelfSignedManager()
 
@@ -225,6 +267,74 @@ func (s *ManagerSuite) TestUpstreamAuthorityWithPublishJWTKeyImplemented() {
 	)
 }
 
+func (s *ManagerSuite) TestPollUpstreamBundle() {
+	upstreamAuthority, fakeUA := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain:                testTrustDomain,
+		DisableMintX509CAStreaming: true,
+	})
+	s.initUpstreamSignedManager(upstreamAuthority)
+
+	firstRoot := fakeUA.X509Root()
+	s.requireBundleRootCAs(firstRoot)
+
+	// The plugin does not stream root updates, so a new upstream root is
+	// not observed until the upstream authority is polled.
+	s.Require().Eventually(func() bool {
+		return !s.m.upstreamClient.IsMintX509CAStreamActive()
+	}, time.Minute, 10*time.Millisecond)
+	fakeUA.RotateX509CA()
+	s.requireBundleRootCAs(firstRoot)
+
+	metrics := fakemetrics.New()
+	s.m.c.Metrics = metrics
+	s.Require().NoError(s.m.pollUpstreamBundle(ctx))
+	s.requireBundleRootCAs(firstRoot, fakeUA.X509Root())
+	s.Require().NotEmpty(metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestPollUpstreamBundleSkippedWhileStreaming() {
+	upstreamAuthority, _ := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamSignedManager(upstreamAuthority)
+	s.Require().True(s.m.upstreamClient.IsMintX509CAStreamActive())
+
+	metrics := fakemetrics.New()
+	s.m.c.Metrics = metrics
+	s.Require().NoError(s.m.pollUpstreamBundle(ctx))
+	s.Require().Empty(metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestCheckUpstreamRootsExpiry() {
+	upstreamAuthority, fakeUA := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamSignedManager(upstreamAuthority)
+	notAfter := fakeUA.X509Root().NotAfter
+
+	// The root is far from expiring; only the gauge is set.
+	s.clock.Set(notAfter.Add(-2 * upstreamRootsExpiryThreshold))
+	metrics := fakemetrics.New()
+	s.m.c.Metrics = metrics
+	s.m.checkUpstreamRootsExpiry(ctx)
+
+	expected := fakemetrics.New()
+	telemetry_server.SetUpstreamRootsTTLGauge(expected, testTrustDomain.String(), float32((2 * upstreamRootsExpiryThreshold).Seconds()))
+	s.Require().Equal(expected.AllMetrics(), metrics.AllMetrics())
+	s.Require().Equal(0, s.countLogEntries(logrus.WarnLevel, "All upstream X.509 roots are about to expire; the upstream authority may not have rotated its root"))
+
+	// The root is about to expire; the manager warns about it.
+	s.clock.Set(notAfter.Add(-time.Hour))
+	metrics.Reset()
+	s.m.checkUpstreamRootsExpiry(ctx)
+
+	expected = fakemetrics.New()
+	telemetry_server.SetUpstreamRootsTTLGauge(expected, testTrustDomain.String(), float32(time.Hour.Seconds()))
+	telemetry_server.IncrUpstreamRootsExpiringCounter(expected)
+	s.Require().Equal(expected.AllMetrics(), metrics.AllMetrics())
+	s.Require().Equal(1, s.countLogEntries(logrus.WarnLevel, "All upstream X.509 roots are about to expire; the upstream authority may not have rotated its root"))
+}
+
 func (s *ManagerSuite) TestX509CARotation() {
 	notifier, notifyCh := fakenotifier.NotifyWaiter()
 	s.setNotifier(notifier)
@@ -298,6 +408,58 @@ func (s *ManagerSuite) TestX509CARotation() {
 	s.Nil(s.nextX509CA())
 }
 
+func (s *ManagerSuite) TestX509CAPrePublicationWindow() {
+	c := s.selfSignedConfig()
+	c.CAPrePublicationWindow = 40 * time.Minute
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+
+	// CA TTL is an hour so the next X509CA is prepared after ten minutes, so
+	// that it is published for forty minutes by the activation mark.
+	initTime := s.clock.Now()
+	first := s.currentX509CA()
+
+	s.setTimeAndRotateX509CA(initTime.Add(10 * time.Minute))
+	s.Nil(s.nextX509CA(), "second X509CA should not be prepared yet")
+
+	s.addTimeAndRotateX509CA(time.Second)
+	second := s.nextX509CA()
+	s.Require().NotNil(second, "second X509CA should have been prepared")
+	s.requireBundleRootCAs(first.Certificate, second.Certificate)
+
+	s.setTimeAndRotateX509CA(initTime.Add(activateAfter))
+	s.requireX509CAEqual(first, s.currentX509CA())
+
+	s.addTimeAndRotateX509CA(time.Second)
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Nil(s.nextX509CA())
+}
+
+func (s *ManagerSuite) TestX509CAPrePublicationWindowDefersActivation() {
+	c := s.selfSignedConfig()
+	c.CAPrePublicationWindow = 40 * time.Minute
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+
+	// The next X509CA is only prepared after the activation mark (e.g. the
+	// server was down), so its activation is deferred.
+	initTime := s.clock.Now()
+	first := s.currentX509CA()
+	s.setTimeAndRotateX509CA(initTime.Add(activateAfter + time.Second))
+	s.requireX509CAEqual(first, s.currentX509CA())
+	second := s.nextX509CA()
+	s.Require().NotNil(second, "second X509CA should have been prepared")
+
+	s.setTimeAndRotateX509CA(first.Certificate.NotAfter.Add(-2 * time.Minute))
+	s.requireX509CAEqual(first, s.currentX509CA())
+	s.requireX509CAEqual(second, s.nextX509CA())
+
+	// It is activated anyway when the current X509CA is about to expire
+	s.setTimeAndRotateX509CA(first.Certificate.NotAfter.Add(-prePublicationExpiryMargin))
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Nil(s.nextX509CA())
+}
+
 func (s *ManagerSuite) TestX509CARotationMetric() {
 	s.initSelfSignedManager()
 
@@ -935,3 +1097,39 @@ func (s *fakeCA) SetJWTKey(jwtKey *JWTKey) {
 	defer s.mu.Unlock()
 	s.jwtKey = jwtKey
 }
+
+type unavailableKeyManager struct {
+	keymanager.KeyManager
+
+	mu          sync.Mutex
+	unavailable bool
+}
+
+func (km *unavailableKeyManager) SetUnavailable(unavailable bool) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.unavailable = unavailable
+}
+
+func (km *unavailableKeyManager) err() error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if km.unavailable {
+		return status.Error(codes.Unavailable, "hsm is down")
+	}
+	return nil
+}
+
+func (km *unavailableKeyManager) GenerateKey(ctx context.Context, req *keymanager.GenerateKeyRequest) (*keymanager.GenerateKeyResponse, error) {
+	if err := km.err(); err != nil {
+		return nil, err
+	}
+	return km.KeyManager.GenerateKey(ctx, req)
+}
+
+func (km *unavailableKeyManager) GetPublicKey(ctx context.Context, req *keymanager.GetPublicKeyRequest) (*keymanager.GetPublicKeyResponse, error) {
+	if err := km.err(); err != nil {
+		return nil, err
+	}
+	return km.KeyManager.GetPublicKey(ctx, req)
+}
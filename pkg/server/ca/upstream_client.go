@@ -111,6 +111,14 @@ func (u *UpstreamClient) WaitUntilMintX509CAStreamDone(ctx context.Context) erro
 	return u.mintX509CAStream.WaitUntilStopped(ctx)
 }
 
+// IsMintX509CAStreamActive returns true if the MintX509CA stream is still
+// open and receiving X.509 root updates from the UpstreamAuthority plugin.
+func (u *UpstreamClient) IsMintX509CAStreamActive() bool {
+	u.mintX509CAMtx.Lock()
+	defer u.mintX509CAMtx.Unlock()
+	return u.mintX509CAStream.Active()
+}
+
 // PublishJWTKey publishes the JWT key to the UpstreamAuthority. It maintains
 // an open stream to the UpstreamAuthority plugin to receive and append JWT key
 // updates to the bundle. The stream remains open until another call to
@@ -312,13 +320,17 @@ type streamState struct {
 	wg       sync.WaitGroup
 	stopOnce *sync.Once
 	stopped  chan struct{}
+	done     chan struct{}
 }
 
 func newStreamState() *streamState {
+	done := make(chan struct{})
+	close(done)
 	return &streamState{
 		cancel:   func() {},
 		stopOnce: new(sync.Once),
 		stopped:  make(chan struct{}),
+		done:     done,
 	}
 }
 
@@ -331,15 +343,28 @@ func (s *streamState) Start(fn func(context.Context)) {
 
 	s.stopOnce = new(sync.Once)
 	s.stopped = make(chan struct{})
+	done := make(chan struct{})
+	s.done = done
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
+		defer close(done)
 		fn(ctx)
 	}()
 }
 
+// Active returns true if the stream function is still running.
+func (s *streamState) Active() bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
 func (s *streamState) WaitUntilStopped(ctx context.Context) error {
 	select {
 	case <-s.stopped:
@@ -43,7 +43,7 @@ func TestFetchBundleCache(t *testing.T) {
 	// bundle is there
 	resp, err = cache.FetchBundle(ctxWithCache, req)
 	require.NoError(t, err)
-	spiretest.RequireProtoEqual(t, bundle1, resp.Bundle)
+	requireBundleEqual(t, bundle1, resp.Bundle)
 
 	// Change bundle
 	_, err = ds.SetBundle(context.Background(), &datastore.SetBundleRequest{
@@ -54,13 +54,13 @@ func TestFetchBundleCache(t *testing.T) {
 	// Assert bundle contents unchanged since cache is still valid
 	resp, err = cache.FetchBundle(ctxWithCache, req)
 	require.NoError(t, err)
-	spiretest.RequireProtoEqual(t, bundle1, resp.Bundle)
+	requireBundleEqual(t, bundle1, resp.Bundle)
 
 	// If caches expires by time, FetchBundle must fetch a fresh bundle
 	clock.Add(datastoreCacheExpiry)
 	resp, err = cache.FetchBundle(ctxWithCache, req)
 	require.NoError(t, err)
-	spiretest.RequireProtoEqual(t, bundle2, resp.Bundle)
+	requireBundleEqual(t, bundle2, resp.Bundle)
 
 	// Change bundle
 	_, err = ds.SetBundle(context.Background(), &datastore.SetBundleRequest{
@@ -71,11 +71,11 @@ func TestFetchBundleCache(t *testing.T) {
 	// If a context without cache is used, FetchBundle must fetch a fresh bundle
 	resp, err = cache.FetchBundle(ctxWithoutCache, req)
 	require.NoError(t, err)
-	spiretest.RequireProtoEqual(t, bundle1, resp.Bundle)
+	requireBundleEqual(t, bundle1, resp.Bundle)
 
 	resp, err = cache.FetchBundle(ctxWithCache, req)
 	require.NoError(t, err)
-	spiretest.RequireProtoEqual(t, bundle1, resp.Bundle)
+	requireBundleEqual(t, bundle1, resp.Bundle)
 }
 
 func TestBundleInvalidations(t *testing.T) {
@@ -204,9 +204,7 @@ func TestBundleInvalidations(t *testing.T) {
 			if tt.dsFailure {
 				resp, err := cache.FetchBundle(ctxWithCache, req)
 				require.NoError(t, err)
-				spiretest.RequireProtoEqual(t, &datastore.FetchBundleResponse{
-					Bundle: bundle1,
-				}, resp)
+				requireBundleEqual(t, bundle1, resp.Bundle)
 				return
 			}
 
@@ -215,11 +213,20 @@ func TestBundleInvalidations(t *testing.T) {
 			// bundle (bundle2)
 			resp, err := cache.FetchBundle(ctxWithCache, req)
 			require.NoError(t, err)
-			spiretest.RequireProtoEqual(t, bundle2, resp.Bundle)
+			requireBundleEqual(t, bundle2, resp.Bundle)
 		})
 	}
 }
 
+// requireBundleEqual requires the bundles to be equal, regardless of the
+// sequence number assigned by the datastore.
+func requireBundleEqual(t *testing.T, expected, actual *common.Bundle) {
+	require.NotNil(t, actual)
+	actual = proto.Clone(actual).(*common.Bundle)
+	actual.SequenceNumber = 0
+	spiretest.RequireProtoEqual(t, expected, actual)
+}
+
 // getBundles returns two different bundles with the same trust domain.
 func getBundles(t *testing.T, td string) (*common.Bundle, *common.Bundle) {
 	roots, keys := getRoots(t, td), getKeys(t)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/andres-erbsen/clock"
+	hclog "github.com/hashicorp/go-hclog"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_log "github.com/spiffe/spire/pkg/common/log"
@@ -13,8 +14,10 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	datastore_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/datastore"
 	keymanager_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/keymanager"
+	"github.com/spiffe/spire/pkg/server/bundle/audit"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	ds_dynamodb "github.com/spiffe/spire/pkg/server/plugin/datastore/dynamodb"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
@@ -49,6 +52,7 @@ var (
 	builtIns = []catalog.Plugin{
 		// DataStores
 		ds_sql.BuiltIn(),
+		ds_dynamodb.BuiltIn(),
 		// NodeAttestors
 		na_aws_iid.BuiltIn(),
 		na_gcp_iit.BuiltIn(),
@@ -90,6 +94,8 @@ type GlobalConfig = catalog.GlobalConfig
 type HCLPluginConfig = catalog.HCLPluginConfig
 type HCLPluginConfigMap = catalog.HCLPluginConfigMap
 
+type PluginPolicy = catalog.PluginPolicy
+
 func KnownPlugins() []catalog.PluginClient {
 	return []catalog.PluginClient{
 		nodeattestor.PluginClient,
@@ -166,11 +172,24 @@ type Config struct {
 	Log          logrus.FieldLogger
 	GlobalConfig *GlobalConfig
 	PluginConfig HCLPluginConfigMap
+	PluginPolicy PluginPolicy
+
+	// PluginLog returns the logger of a plugin. Optional. If unset, the
+	// plugins log through Log.
+	PluginLog func(pluginType, pluginName string) logrus.FieldLogger
 
 	Metrics          telemetry.Metrics
 	IdentityProvider hostservices.IdentityProviderServer
 	AgentStore       hostservices.AgentStoreServer
 	MetricsService   common_services.MetricsServiceServer
+
+	// Clock is used by the datastore cache. If unset, the wall clock is
+	// used.
+	Clock clock.Clock
+
+	// DataStore, if set, is used instead of loading the DataStore plugin
+	// from PluginConfig.
+	DataStore datastore.DataStore
 }
 
 type Repository struct {
@@ -179,13 +198,24 @@ type Repository struct {
 }
 
 func Load(ctx context.Context, config Config) (*Repository, error) {
-	// Strip out the Datastore plugin configuration and load the SQL plugin
-	// directly. This allows us to bypass gRPC and get rid of response limits.
+	// Strip out the Datastore plugin configuration and load the built-in
+	// plugin directly. This allows us to bypass gRPC and get rid of response
+	// limits.
 	dataStoreConfig := config.PluginConfig[datastore.Type]
 	delete(config.PluginConfig, datastore.Type)
-	ds, err := loadSQLDataStore(ctx, config.Log, dataStoreConfig)
-	if err != nil {
-		return nil, err
+	ds := config.DataStore
+	if ds == nil {
+		dataStoreLog := config.Log
+		if config.PluginLog != nil && len(dataStoreConfig) == 1 {
+			for name := range dataStoreConfig {
+				dataStoreLog = config.PluginLog(datastore.Type, name)
+			}
+		}
+		var err error
+		ds, err = loadDataStore(ctx, dataStoreLog, config.Metrics, dataStoreConfig, config.PluginPolicy)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	pluginConfigs, err := catalog.PluginConfigsFromHCL(config.PluginConfig)
@@ -196,6 +226,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	p := new(Plugins)
 	closer, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
+		PluginLog:     config.PluginLog,
 		GlobalConfig:  config.GlobalConfig,
 		PluginConfig:  pluginConfigs,
 		KnownPlugins:  KnownPlugins(),
@@ -206,13 +237,20 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 			hostservices.AgentStoreHostServiceServer(config.AgentStore),
 			common_services.MetricsServiceHostServiceServer(config.MetricsService),
 		},
+		Metrics:      config.Metrics,
+		PluginPolicy: config.PluginPolicy,
 	}, p)
 	if err != nil {
 		return nil, err
 	}
 
 	p.DataStore.DataStore = datastore_telemetry.WithMetrics(ds, config.Metrics)
-	p.DataStore.DataStore = dscache.New(p.DataStore.DataStore, clock.New())
+	p.DataStore.DataStore = audit.WithAuditing(p.DataStore.DataStore, config.Log.WithField(telemetry.SubsystemName, telemetry.BundleAudit), config.Metrics)
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.New()
+	}
+	p.DataStore.DataStore = dscache.New(p.DataStore.DataStore, clk)
 	p.KeyManager = keymanager_telemetry.WithMetrics(p.KeyManager, config.Metrics)
 
 	return &Repository{
@@ -221,7 +259,27 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}, nil
 }
 
-func loadSQLDataStore(ctx context.Context, log logrus.FieldLogger, datastoreConfig map[string]catalog.HCLPluginConfig) (*ds_sql.Plugin, error) {
+// LoadDataStore loads only the DataStore plugin from the given plugin
+// configuration. It is used by tooling that operates directly on the
+// datastore while the server is not running.
+func LoadDataStore(ctx context.Context, log logrus.FieldLogger, pluginConfig HCLPluginConfigMap) (datastore.DataStore, error) {
+	return loadDataStore(ctx, log, telemetry.Blackhole{}, pluginConfig[datastore.Type], PluginPolicy{})
+}
+
+// builtInDataStore is implemented by the built-in DataStore plugins
+type builtInDataStore interface {
+	datastore.DataStore
+	SetLogger(hclog.Logger)
+	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+}
+
+// metricsDataStore is implemented by the built-in DataStore plugins that
+// emit their own metrics
+type metricsDataStore interface {
+	SetMetrics(telemetry.Metrics)
+}
+
+func loadDataStore(ctx context.Context, log logrus.FieldLogger, metrics telemetry.Metrics, datastoreConfig map[string]catalog.HCLPluginConfig, policy PluginPolicy) (datastore.DataStore, error) {
 	switch {
 	case len(datastoreConfig) == 0:
 		return nil, errors.New("expecting a DataStore plugin")
@@ -229,25 +287,41 @@ func loadSQLDataStore(ctx context.Context, log logrus.FieldLogger, datastoreConf
 		return nil, errors.New("only one DataStore plugin is allowed")
 	}
 
-	sqlHCLConfig, ok := datastoreConfig[ds_sql.PluginName]
-	if !ok {
-		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q plugin is supported", ds_sql.PluginName)
+	var name string
+	var hclConfig catalog.HCLPluginConfig
+	for n, c := range datastoreConfig {
+		name, hclConfig = n, c
 	}
 
-	sqlConfig, err := catalog.PluginConfigFromHCL(datastore.Type, ds_sql.PluginName, sqlHCLConfig)
+	var ds builtInDataStore
+	switch name {
+	case ds_sql.PluginName:
+		ds = ds_sql.New()
+	case ds_dynamodb.PluginName:
+		ds = ds_dynamodb.New()
+	default:
+		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q and %q plugins are supported", ds_sql.PluginName, ds_dynamodb.PluginName)
+	}
+
+	pluginConfig, err := catalog.PluginConfigFromHCL(datastore.Type, name, hclConfig)
 	if err != nil {
 		return nil, err
 	}
+	if err := policy.Check(pluginConfig); err != nil {
+		return nil, err
+	}
 
 	// Is the plugin external?
-	if sqlConfig.Path != "" {
-		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q plugin is supported", ds_sql.PluginName)
+	if pluginConfig.Path != "" {
+		return nil, fmt.Errorf("pluggability for the DataStore is deprecated; only the built-in %q and %q plugins are supported", ds_sql.PluginName, ds_dynamodb.PluginName)
 	}
 
-	ds := ds_sql.New()
-	ds.SetLogger(common_log.NewHCLogAdapter(log, telemetry.PluginBuiltIn).Named(sqlConfig.Name))
+	ds.SetLogger(common_log.NewHCLogAdapter(log, telemetry.PluginBuiltIn).Named(pluginConfig.Name))
+	if mds, ok := ds.(metricsDataStore); ok {
+		mds.SetMetrics(metrics)
+	}
 	if _, err := ds.Configure(ctx, &spi.ConfigureRequest{
-		Configuration: sqlConfig.Data,
+		Configuration: pluginConfig.Data,
 	}); err != nil {
 		return nil, err
 	}
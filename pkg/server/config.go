@@ -3,16 +3,23 @@ package server
 import (
 	"crypto/x509/pkix"
 	"net"
+	"os"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509svid"
+	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 )
 
@@ -20,14 +27,29 @@ type Config struct {
 	// Configurations for server plugins
 	PluginConfigs common.HCLPluginConfigMap
 
+	// PluginPolicy restricts which plugins can be loaded
+	PluginPolicy common.PluginPolicy
+
 	Log logrus.FieldLogger
 
+	// LogLevels holds the loggers of the subsystems and plugins, so their
+	// levels can be configured, and adjusted at runtime, independently of
+	// the level of Log. If nil, everything logs through Log.
+	LogLevels *log.Levels
+
 	// Address of SPIRE server
 	BindAddress *net.TCPAddr
 
 	// Address of the UDS SPIRE server
 	BindUDSAddress *net.UnixAddr
 
+	// Address of the UDS SPIRE server dedicated to the admin APIs. If nil,
+	// the admin APIs are served on BindUDSAddress.
+	AdminBindUDSAddress *net.UnixAddr
+
+	// File mode of the admin UDS
+	AdminUDSMode os.FileMode
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -51,10 +73,22 @@ type Config struct {
 	// SVIDTTL is default time-to-live for SVIDs
 	SVIDTTL time.Duration
 
+	// SVIDTTLPolicies cap the time-to-live of SVIDs by SPIFFE ID path
+	// prefix, regardless of the TTL of the registration entry.
+	SVIDTTLPolicies []ca.TTLPolicy
+
 	// CATTL is the time-to-live for the server CA. This only applies to
 	// self-signed CA certificates, otherwise it is up to the upstream CA.
 	CATTL time.Duration
 
+	// CAPrePublicationWindow is the minimum time a prepared CA is published
+	// in the trust bundle before it is activated.
+	CAPrePublicationWindow time.Duration
+
+	// UpstreamBundlePollInterval is how often the UpstreamAuthority is polled
+	// for X.509 root updates when it does not stream them.
+	UpstreamBundlePollInterval time.Duration
+
 	// JWTIssuer is used as the issuer claim in JWT-SVIDs minted by the server.
 	// If unset, the JWT-SVID will not have an issuer claim.
 	JWTIssuer string
@@ -62,9 +96,23 @@ type Config struct {
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
 
+	// X509SVIDPrimaryName is the name presented first in X509-SVIDs when
+	// not overridden by the registration entry.
+	X509SVIDPrimaryName x509svid.PrimaryName
+
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
 
+	// Clock is shared by the server subsystems that run on a schedule (CA
+	// rotation, bundle and entry pruning, SVID rotation, federated bundle
+	// refresh). Tests can set a mock clock to advance time deterministically.
+	// If unset, the wall clock is used.
+	Clock clock.Clock
+
+	// DataStore, if set, is used instead of the DataStore plugin configured
+	// in PluginConfigs. Tests can set a fake datastore.
+	DataStore datastore.DataStore
+
 	// HealthChecks provides the configuration for health monitoring
 	HealthChecks health.Config
 
@@ -77,11 +125,74 @@ type Config struct {
 
 	// RateLimit holds rate limiting configurations.
 	RateLimit endpoints.RateLimitConfig
+
+	// BundleLimits holds the limits enforced on bundles set through the
+	// bundle API.
+	BundleLimits endpoints.BundleLimitsConfig
+
+	// EntryQuotas limit the number of registration entries that can be
+	// created under SPIFFE ID path prefixes.
+	EntryQuotas []entryv1.Quota
+
+	// DataStoreTimeout is the default timeout for datastore calls made while
+	// handling API requests. If unset, the endpoints default is used.
+	DataStoreTimeout time.Duration
+
+	// ClockSkewTolerance is the maximum difference allowed between the clock
+	// of an agent and the server clock. If unset, clock skew is not checked.
+	ClockSkewTolerance time.Duration
 }
 
 type ExperimentalConfig struct {
 	// Skip agent id validation in node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// EC2Inventory, if set, enables the reconciler that maintains node alias
+	// entries for groups of AWS EC2 instances.
+	EC2Inventory *EC2InventoryConfig
+
+	// APIGatewayAddress, if set, enables the HTTP/JSON gateway for the v1
+	// APIs on the given address.
+	APIGatewayAddress *net.TCPAddr
+}
+
+// Enabled returns the names of the enabled experimental features, as they
+// appear in the configuration file.
+func (c ExperimentalConfig) Enabled() []string {
+	var enabled []string
+	if c.AllowAgentlessNodeAttestors {
+		enabled = append(enabled, "allow_agentless_node_attestors")
+	}
+	if c.EC2Inventory != nil {
+		enabled = append(enabled, "ec2_inventory")
+	}
+	if c.APIGatewayAddress != nil {
+		enabled = append(enabled, "api_gateway")
+	}
+	return enabled
+}
+
+type EC2InventoryConfig struct {
+	// Regions are the AWS regions to poll for instances.
+	Regions []string
+
+	// AccessKeyID and SecretAccessKey are the AWS credentials. If unset,
+	// the default AWS credential chain is used.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// GroupTagKey is the key of the tag used to group instances.
+	GroupTagKey string
+
+	// FilterTags restricts the instances considered to those with all of
+	// the given tags.
+	FilterTags map[string]string
+
+	// PathPrefix is the path prefix of the managed node alias SPIFFE IDs.
+	PathPrefix string
+
+	// PollInterval is how often the inventory is reconciled.
+	PollInterval time.Duration
 }
 
 type FederationConfig struct {
@@ -90,9 +201,19 @@ type FederationConfig struct {
 	// FederatesWith holds the federation configuration for trust domains this
 	// server federates with.
 	FederatesWith map[spiffeid.TrustDomain]bundle_client.TrustDomainConfig
+	// StaleBundleRefreshHints is the number of refresh hint periods after
+	// which a federated bundle that has not been refreshed is reported as
+	// stale.
+	StaleBundleRefreshHints int
+	// BundleExpiryWarningThreshold is the remaining lifetime of the last
+	// X.509 authority in a federated bundle under which a warning is logged.
+	BundleExpiryWarningThreshold time.Duration
 }
 
 func New(config Config) *Server {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
 	return &Server{
 		config: config,
 	}
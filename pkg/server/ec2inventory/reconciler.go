@@ -0,0 +1,364 @@
+// Package ec2inventory provides a reconciler that maintains node alias
+// registration entries for groups of AWS EC2 instances. Instances are grouped
+// by the value of a configurable tag (by default, the auto scaling group name)
+// and each group is given a node alias entry whose selector matches the tag
+// selector produced by the aws_iid node attestor.
+package ec2inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	caws "github.com/spiffe/spire/pkg/common/plugin/aws"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// DefaultGroupTagKey is the tag used to group instances when one is not
+	// configured. It is set by AWS on instances launched by an auto scaling
+	// group.
+	DefaultGroupTagKey = "aws:autoscaling:groupName"
+
+	// DefaultPathPrefix is the path prefix of the node alias SPIFFE IDs
+	// managed by the reconciler when one is not configured.
+	DefaultPathPrefix = "/aws/ec2"
+
+	// DefaultPollInterval is how often the EC2 inventory is polled when an
+	// interval is not configured.
+	DefaultPollInterval = 5 * time.Minute
+
+	serverPath = "/spire/server"
+
+	listEntriesPageSize = 500
+)
+
+// EC2Client is the subset of the EC2 API used by the reconciler.
+type EC2Client interface {
+	DescribeInstancesPagesWithContext(ctx aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error
+}
+
+// Config is the configuration for the reconciler.
+type Config struct {
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+
+	// TrustDomain is the trust domain of the server.
+	TrustDomain spiffeid.TrustDomain
+
+	// EntryClient is used to list, create and delete node alias entries.
+	EntryClient entryv1.EntryClient
+
+	// EC2Clients are the EC2 clients, keyed by region, used to list
+	// instances.
+	EC2Clients map[string]EC2Client
+
+	// GroupTagKey is the key of the tag used to group instances. Each
+	// distinct value of the tag results in a node alias entry.
+	GroupTagKey string
+
+	// FilterTags, if set, restricts the instances considered to those that
+	// have all of the given tags.
+	FilterTags map[string]string
+
+	// PathPrefix is the path prefix for the node alias SPIFFE IDs. Entries
+	// parented to the server whose SPIFFE ID falls under this prefix are
+	// owned by the reconciler and will be deleted if no longer needed.
+	PathPrefix string
+
+	// PollInterval is how often the inventory is reconciled.
+	PollInterval time.Duration
+}
+
+// Reconciler reconciles node alias entries with the EC2 inventory.
+type Reconciler struct {
+	c Config
+}
+
+// New creates a new reconciler.
+func New(c Config) (*Reconciler, error) {
+	if c.EntryClient == nil {
+		return nil, errors.New("entry client is required")
+	}
+	if len(c.EC2Clients) == 0 {
+		return nil, errors.New("at least one region is required")
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.GroupTagKey == "" {
+		c.GroupTagKey = DefaultGroupTagKey
+	}
+	if c.PathPrefix == "" {
+		c.PathPrefix = DefaultPathPrefix
+	}
+	if !strings.HasPrefix(c.PathPrefix, "/") {
+		return nil, fmt.Errorf("path prefix %q must start with a slash", c.PathPrefix)
+	}
+	c.PathPrefix = path.Clean(c.PathPrefix)
+	if c.PathPrefix == "/" || idutil.IsReservedPath(c.PathPrefix) {
+		return nil, fmt.Errorf("path prefix %q is reserved", c.PathPrefix)
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+
+	return &Reconciler{
+		c: c,
+	}, nil
+}
+
+// Run reconciles the inventory every poll interval until the context is
+// canceled.
+func (r *Reconciler) Run(ctx context.Context) error {
+	ticker := r.c.Clock.Ticker(r.c.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Log an error on failure unless we're shutting down
+		if err := r.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			r.c.Log.WithError(err).Error("Failed to reconcile EC2 inventory")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Reconcile performs a single reconciliation pass. Node alias entries are
+// created for instance groups that do not have one and deleted for groups
+// that no longer have instances.
+func (r *Reconciler) Reconcile(ctx context.Context) (err error) {
+	counter := telemetry.StartCall(r.c.Metrics, telemetry.RegistrationEntry, telemetry.EC2Inventory, telemetry.Reconcile)
+	defer counter.Done(&err)
+
+	groups, err := r.listGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := r.listManagedEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	var toCreate []*types.Entry
+	for group := range groups {
+		entry := r.makeEntry(group)
+		if _, ok := existing[entry.SpiffeId.Path]; ok {
+			delete(existing, entry.SpiffeId.Path)
+			continue
+		}
+		toCreate = append(toCreate, entry)
+	}
+
+	var toDelete []string
+	for _, entry := range existing {
+		toDelete = append(toDelete, entry.Id)
+	}
+
+	// Sort for deterministic ordering of requests and logs
+	sort.Slice(toCreate, func(i, j int) bool {
+		return toCreate[i].SpiffeId.Path < toCreate[j].SpiffeId.Path
+	})
+	sort.Strings(toDelete)
+
+	if err := r.createEntries(ctx, toCreate); err != nil {
+		return err
+	}
+	if err := r.deleteEntries(ctx, toDelete); err != nil {
+		return err
+	}
+
+	r.c.Log.WithFields(logrus.Fields{
+		"groups":  len(groups),
+		"created": len(toCreate),
+		"deleted": len(toDelete),
+	}).Debug("Reconciled EC2 inventory")
+	return nil
+}
+
+func (r *Reconciler) listGroups(ctx context.Context) (map[string]struct{}, error) {
+	filters := []*ec2.Filter{
+		{
+			Name:   aws.String("instance-state-name"),
+			Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+		},
+		{
+			Name:   aws.String("tag-key"),
+			Values: aws.StringSlice([]string{r.c.GroupTagKey}),
+		},
+	}
+	for key, value := range r.c.FilterTags {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: aws.StringSlice([]string{value}),
+		})
+	}
+	// Sort the filters for deterministic requests
+	sort.Slice(filters, func(i, j int) bool {
+		return aws.StringValue(filters[i].Name) < aws.StringValue(filters[j].Name)
+	})
+
+	groups := make(map[string]struct{})
+	for region, client := range r.c.EC2Clients {
+		err := client.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{
+			Filters: filters,
+		}, func(output *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range output.Reservations {
+				for _, instance := range reservation.Instances {
+					for _, tag := range instance.Tags {
+						if aws.StringValue(tag.Key) == r.c.GroupTagKey && aws.StringValue(tag.Value) != "" {
+							groups[aws.StringValue(tag.Value)] = struct{}{}
+						}
+					}
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances in region %q: %w", region, err)
+		}
+	}
+	return groups, nil
+}
+
+func (r *Reconciler) listManagedEntries(ctx context.Context) (map[string]*types.Entry, error) {
+	entries := make(map[string]*types.Entry)
+	pageToken := ""
+	for {
+		resp, err := r.c.EntryClient.ListEntries(ctx, &entryv1.ListEntriesRequest{
+			Filter: &entryv1.ListEntriesRequest_Filter{
+				ByParentId: &types.SPIFFEID{
+					TrustDomain: r.c.TrustDomain.String(),
+					Path:        serverPath,
+				},
+			},
+			PageSize:  listEntriesPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list entries: %w", err)
+		}
+		for _, entry := range resp.Entries {
+			if entry.SpiffeId != nil && strings.HasPrefix(entry.SpiffeId.Path, r.c.PathPrefix+"/") {
+				entries[entry.SpiffeId.Path] = entry
+			}
+		}
+		if resp.NextPageToken == "" {
+			return entries, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (r *Reconciler) createEntries(ctx context.Context, entries []*types.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	resp, err := r.c.EntryClient.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{
+		Entries: entries,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create entries: %w", err)
+	}
+
+	for i, result := range resp.Results {
+		log := r.c.Log.WithField(telemetry.SPIFFEID, spiffeIDString(entries[i].SpiffeId))
+		if code := codes.Code(result.Status.GetCode()); code != codes.OK {
+			log.WithField(telemetry.Error, result.Status.GetMessage()).Error("Failed to create node alias entry")
+			continue
+		}
+		log.WithField(telemetry.RegistrationID, result.Entry.GetId()).Info("Created node alias entry")
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteEntries(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	resp, err := r.c.EntryClient.BatchDeleteEntry(ctx, &entryv1.BatchDeleteEntryRequest{
+		Ids: ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete entries: %w", err)
+	}
+
+	for _, result := range resp.Results {
+		log := r.c.Log.WithField(telemetry.RegistrationID, result.Id)
+		if code := codes.Code(result.Status.GetCode()); code != codes.OK {
+			log.WithField(telemetry.Error, result.Status.GetMessage()).Error("Failed to delete node alias entry")
+			continue
+		}
+		log.Info("Deleted node alias entry")
+	}
+	return nil
+}
+
+func (r *Reconciler) makeEntry(group string) *types.Entry {
+	return &types.Entry{
+		SpiffeId: &types.SPIFFEID{
+			TrustDomain: r.c.TrustDomain.String(),
+			Path:        path.Join(r.c.PathPrefix, escapePathSegment(group)),
+		},
+		ParentId: &types.SPIFFEID{
+			TrustDomain: r.c.TrustDomain.String(),
+			Path:        serverPath,
+		},
+		Selectors: []*types.Selector{
+			{
+				Type:  caws.PluginName,
+				Value: fmt.Sprintf("tag:%s:%s", r.c.GroupTagKey, group),
+			},
+		},
+	}
+}
+
+// escapePathSegment makes the group name safe to use as a single SPIFFE ID
+// path segment. Bytes outside of the characters allowed in a segment are
+// written as an underscore followed by two lowercase hex digits. Underscores
+// are escaped as well, so distinct group names always map to distinct
+// segments. The dots of the "." and ".." names, which are not allowed as
+// segments, are escaped too.
+func escapePathSegment(s string) string {
+	escapeDots := s == "." || s == ".."
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			b.WriteByte(c)
+		case c == '.' && !escapeDots:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
+
+func spiffeIDString(id *types.SPIFFEID) string {
+	return "spiffe://" + id.TrustDomain + id.Path
+}
@@ -0,0 +1,261 @@
+package ec2inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var td = spiffeid.RequireTrustDomainFromString("example.org")
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		config    Config
+		expectErr string
+	}{
+		{
+			name:      "missing entry client",
+			config:    Config{EC2Clients: map[string]EC2Client{"us-east-1": &fakeEC2{}}},
+			expectErr: "entry client is required",
+		},
+		{
+			name:      "missing regions",
+			config:    Config{EntryClient: &fakeEntryClient{}},
+			expectErr: "at least one region is required",
+		},
+		{
+			name: "relative path prefix",
+			config: Config{
+				EntryClient: &fakeEntryClient{},
+				EC2Clients:  map[string]EC2Client{"us-east-1": &fakeEC2{}},
+				PathPrefix:  "aws",
+			},
+			expectErr: `path prefix "aws" must start with a slash`,
+		},
+		{
+			name: "reserved path prefix",
+			config: Config{
+				EntryClient: &fakeEntryClient{},
+				EC2Clients:  map[string]EC2Client{"us-east-1": &fakeEC2{}},
+				PathPrefix:  "/spire/agent",
+			},
+			expectErr: `path prefix "/spire/agent" is reserved`,
+		},
+		{
+			name: "defaults",
+			config: Config{
+				EntryClient: &fakeEntryClient{},
+				EC2Clients:  map[string]EC2Client{"us-east-1": &fakeEC2{}},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.config)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, DefaultGroupTagKey, r.c.GroupTagKey)
+			assert.Equal(t, DefaultPathPrefix, r.c.PathPrefix)
+			assert.Equal(t, DefaultPollInterval, r.c.PollInterval)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ec2East := &fakeEC2{
+		instances: []*ec2.Instance{
+			instanceWithTags("aws:autoscaling:groupName", "web"),
+			instanceWithTags("aws:autoscaling:groupName", "web"),
+			instanceWithTags("aws:autoscaling:groupName", "db/primary"),
+		},
+	}
+	ec2West := &fakeEC2{
+		instances: []*ec2.Instance{
+			instanceWithTags("aws:autoscaling:groupName", "batch"),
+		},
+	}
+	entryClient := &fakeEntryClient{
+		entries: []*types.Entry{
+			aliasEntry("1", "/aws/ec2/web"),
+			aliasEntry("2", "/aws/ec2/stale"),
+			// Not managed by the reconciler
+			aliasEntry("3", "/other/stale"),
+		},
+	}
+
+	log, _ := test.NewNullLogger()
+	r, err := New(Config{
+		Log:         log,
+		Metrics:     fakemetrics.New(),
+		TrustDomain: td,
+		EntryClient: entryClient,
+		EC2Clients: map[string]EC2Client{
+			"us-east-1": ec2East,
+			"us-west-1": ec2West,
+		},
+		FilterTags: map[string]string{"env": "prod"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Reconcile(context.Background()))
+
+	// Assert instances were filtered by state, the group tag and the filter
+	// tags
+	expectFilters := []*ec2.Filter{
+		{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"pending", "running"})},
+		{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{"aws:autoscaling:groupName"})},
+		{Name: aws.String("tag:env"), Values: aws.StringSlice([]string{"prod"})},
+	}
+	assert.Equal(t, expectFilters, ec2East.input.Filters)
+	assert.Equal(t, expectFilters, ec2West.input.Filters)
+
+	// Assert entries were listed by the server parent ID
+	spiretest.AssertProtoEqual(t, &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"}, entryClient.listFilter.ByParentId)
+
+	// Assert missing groups were created and stale ones deleted
+	spiretest.AssertProtoListEqual(t, []*types.Entry{
+		{
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/aws/ec2/batch"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"},
+			Selectors: []*types.Selector{{Type: "aws_iid", Value: "tag:aws:autoscaling:groupName:batch"}},
+		},
+		{
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/aws/ec2/db_2fprimary"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"},
+			Selectors: []*types.Selector{{Type: "aws_iid", Value: "tag:aws:autoscaling:groupName:db/primary"}},
+		},
+	}, entryClient.created)
+	assert.Equal(t, []string{"2"}, entryClient.deleted)
+}
+
+func TestReconcileFailsOnEC2Error(t *testing.T) {
+	entryClient := &fakeEntryClient{}
+
+	log, _ := test.NewNullLogger()
+	r, err := New(Config{
+		Log:         log,
+		Metrics:     fakemetrics.New(),
+		TrustDomain: td,
+		EntryClient: entryClient,
+		EC2Clients: map[string]EC2Client{
+			"us-east-1": &fakeEC2{err: errors.New("ohno")},
+		},
+	})
+	require.NoError(t, err)
+
+	err = r.Reconcile(context.Background())
+	require.EqualError(t, err, `failed to describe instances in region "us-east-1": ohno`)
+
+	// Nothing should be deleted when the inventory cannot be listed
+	assert.Empty(t, entryClient.created)
+	assert.Empty(t, entryClient.deleted)
+}
+
+func TestEscapePathSegment(t *testing.T) {
+	for _, tt := range []struct {
+		group  string
+		expect string
+	}{
+		{group: "web-1.prod", expect: "web-1.prod"},
+		{group: "db/primary", expect: "db_2fprimary"},
+		{group: "db_primary", expect: "db_5fprimary"},
+		{group: "my group", expect: "my_20group"},
+		{group: "caf\u00e9", expect: "caf_c3_a9"},
+		{group: ".", expect: "_2e"},
+		{group: "..", expect: "_2e_2e"},
+		{group: "...", expect: "..."},
+	} {
+		assert.Equal(t, tt.expect, escapePathSegment(tt.group), tt.group)
+	}
+}
+
+func instanceWithTags(kvs ...string) *ec2.Instance {
+	instance := &ec2.Instance{}
+	for i := 0; i < len(kvs); i += 2 {
+		instance.Tags = append(instance.Tags, &ec2.Tag{
+			Key:   aws.String(kvs[i]),
+			Value: aws.String(kvs[i+1]),
+		})
+	}
+	return instance
+}
+
+func aliasEntry(id, path string) *types.Entry {
+	return &types.Entry{
+		Id:       id,
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: path},
+		ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"},
+	}
+}
+
+type fakeEC2 struct {
+	instances []*ec2.Instance
+	err       error
+	input     *ec2.DescribeInstancesInput
+}
+
+func (c *fakeEC2) DescribeInstancesPagesWithContext(ctx aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error {
+	c.input = input
+	if c.err != nil {
+		return c.err
+	}
+	fn(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: c.instances}},
+	}, true)
+	return nil
+}
+
+type fakeEntryClient struct {
+	entryv1.EntryClient
+
+	entries    []*types.Entry
+	listFilter *entryv1.ListEntriesRequest_Filter
+	created    []*types.Entry
+	deleted    []string
+}
+
+func (c *fakeEntryClient) ListEntries(ctx context.Context, req *entryv1.ListEntriesRequest, opts ...grpc.CallOption) (*entryv1.ListEntriesResponse, error) {
+	c.listFilter = req.Filter
+	return &entryv1.ListEntriesResponse{Entries: c.entries}, nil
+}
+
+func (c *fakeEntryClient) BatchCreateEntry(ctx context.Context, req *entryv1.BatchCreateEntryRequest, opts ...grpc.CallOption) (*entryv1.BatchCreateEntryResponse, error) {
+	resp := &entryv1.BatchCreateEntryResponse{}
+	for _, entry := range req.Entries {
+		c.created = append(c.created, entry)
+		resp.Results = append(resp.Results, &entryv1.BatchCreateEntryResponse_Result{
+			Status: &types.Status{},
+			Entry:  entry,
+		})
+	}
+	return resp, nil
+}
+
+func (c *fakeEntryClient) BatchDeleteEntry(ctx context.Context, req *entryv1.BatchDeleteEntryRequest, opts ...grpc.CallOption) (*entryv1.BatchDeleteEntryResponse, error) {
+	resp := &entryv1.BatchDeleteEntryResponse{}
+	for _, id := range req.Ids {
+		c.deleted = append(c.deleted, id)
+		resp.Results = append(resp.Results, &entryv1.BatchDeleteEntryResponse_Result{
+			Status: &types.Status{},
+			Id:     id,
+		})
+	}
+	return resp, nil
+}
@@ -1,6 +1,10 @@
 package bundle
 
-import "net"
+import (
+	"net"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
 
 type EndpointConfig struct {
 	// Address is the address on which to serve the federation bundle endpoint.
@@ -9,4 +13,12 @@ type EndpointConfig struct {
 	// ACME is the ACME configuration for the bundle endpoint.
 	// If unset, the bundle endpoint will use SPIFFE auth.
 	ACME *ACMEConfig
+
+	// MirroredTrustDomains are the federated trust domains whose bundles are
+	// also served by the bundle endpoint, under FederatedPathPrefix.
+	MirroredTrustDomains []spiffeid.TrustDomain
+
+	// Signer, if set, signs the bundle documents served under
+	// SignedPathPrefix.
+	Signer *Signer
 }
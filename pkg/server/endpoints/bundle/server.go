@@ -4,14 +4,25 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/zeebo/errs"
 )
 
+// FederatedPathPrefix is the path prefix under which the bundles of mirrored
+// trust domains are served, e.g. /federated/example.org.
+const FederatedPathPrefix = "/federated/"
+
+// Getter returns a bundle. Getters of mirrored bundles return a nil bundle
+// if the bundle is not (yet) available.
 type Getter interface {
 	GetBundle(ctx context.Context) (*bundleutil.Bundle, error)
 }
@@ -32,6 +43,20 @@ type ServerConfig struct {
 	Getter     Getter
 	ServerAuth ServerAuth
 
+	// MirroredBundles holds the getters of the federated bundles that are
+	// served, in addition to the local bundle, under FederatedPathPrefix.
+	MirroredBundles map[spiffeid.TrustDomain]Getter
+
+	// MinRefreshHint and MaxRefreshHint bound the refresh hint served with
+	// the bundle. If zero, bundleutil.MinimumRefreshHint and
+	// bundleutil.MaximumRefreshHint are used, respectively.
+	MinRefreshHint time.Duration
+	MaxRefreshHint time.Duration
+
+	// Signer, if set, signs the bundle documents served under
+	// SignedPathPrefix.
+	Signer *Signer
+
 	// test hooks
 	listen func(network, address string) (net.Listener, error)
 }
@@ -44,6 +69,12 @@ func NewServer(config ServerConfig) *Server {
 	if config.listen == nil {
 		config.listen = net.Listen
 	}
+	if config.MinRefreshHint == 0 {
+		config.MinRefreshHint = bundleutil.MinimumRefreshHint
+	}
+	if config.MaxRefreshHint == 0 {
+		config.MaxRefreshHint = bundleutil.MaximumRefreshHint
+	}
 	return &Server{
 		c: config,
 	}
@@ -85,11 +116,32 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	if req.URL.Path != "/" {
+
+	path := req.URL.Path
+	signed := false
+	if path == SignedPathPrefix || strings.HasPrefix(path, SignedPathPrefix+"/") {
+		if s.c.Signer == nil {
+			http.NotFound(w, req)
+			return
+		}
+		signed = true
+		path = strings.TrimPrefix(path, SignedPathPrefix)
+		if path == "" {
+			path = "/"
+		}
+	}
+
+	switch {
+	case path == "/":
+		s.serveLocalBundle(w, req, signed)
+	case strings.HasPrefix(path, FederatedPathPrefix):
+		s.serveMirroredBundle(w, req, strings.TrimPrefix(path, FederatedPathPrefix), signed)
+	default:
 		http.NotFound(w, req)
-		return
 	}
+}
 
+func (s *Server) serveLocalBundle(w http.ResponseWriter, req *http.Request, signed bool) {
 	b, err := s.c.Getter.GetBundle(req.Context())
 	if err != nil {
 		s.c.Log.WithError(err).Error("Unable to retrieve local bundle")
@@ -97,7 +149,40 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	refreshHint := bundleutil.CalculateRefreshHint(b)
+	s.writeBundle(w, b, s.c.Log, "local", signed)
+}
+
+func (s *Server) serveMirroredBundle(w http.ResponseWriter, req *http.Request, name string, signed bool) {
+	// TrustDomainFromString also accepts SPIFFE IDs, so require the name to
+	// be the trust domain name itself
+	td, err := spiffeid.TrustDomainFromString(name)
+	if err != nil || td.String() != name {
+		http.NotFound(w, req)
+		return
+	}
+	getter, ok := s.c.MirroredBundles[td]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	log := s.c.Log.WithField(telemetry.TrustDomainID, td.IDString())
+	b, err := getter.GetBundle(req.Context())
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Unable to retrieve federated bundle")
+		http.Error(w, "500 unable to retrieve federated bundle", http.StatusInternalServerError)
+		return
+	case b == nil:
+		http.NotFound(w, req)
+		return
+	}
+
+	s.writeBundle(w, b, log, "federated", signed)
+}
+
+func (s *Server) writeBundle(w http.ResponseWriter, b *bundleutil.Bundle, log logrus.FieldLogger, kind string, signed bool) {
+	refreshHint := bundleutil.ClampRefreshHint(bundleutil.CalculateRefreshHint(b), s.c.MinRefreshHint, s.c.MaxRefreshHint)
 
 	// TODO: bundle sequence number?
 	opts := []bundleutil.MarshalOption{
@@ -106,8 +191,20 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 
 	jsonBytes, err := bundleutil.Marshal(b, opts...)
 	if err != nil {
-		s.c.Log.WithError(err).Error("Unable to marshal local bundle")
-		http.Error(w, "500 unable to marshal local bundle", http.StatusInternalServerError)
+		log.WithError(err).Errorf("Unable to marshal %s bundle", kind)
+		http.Error(w, fmt.Sprintf("500 unable to marshal %s bundle", kind), http.StatusInternalServerError)
+		return
+	}
+
+	if signed {
+		jwsBytes, err := s.c.Signer.Sign(jsonBytes)
+		if err != nil {
+			log.WithError(err).Errorf("Unable to sign %s bundle", kind)
+			http.Error(w, fmt.Sprintf("500 unable to sign %s bundle", kind), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", SignedBundleContentType)
+		_, _ = w.Write(jwsBytes)
 		return
 	}
 
@@ -3,16 +3,22 @@ package bundle
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,8 +31,10 @@ import (
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
 )
 
 const (
@@ -152,6 +160,240 @@ func TestServer(t *testing.T) {
 	}
 }
 
+func TestServerRefreshHintBounds(t *testing.T) {
+	bundle := bundleutil.New("spiffe://domain.test")
+	bundle.SetRefreshHint(time.Hour)
+
+	for _, tt := range []struct {
+		name              string
+		minRefreshHint    time.Duration
+		maxRefreshHint    time.Duration
+		expectRefreshHint int
+	}{
+		{
+			name:              "default bounds",
+			expectRefreshHint: 3600,
+		},
+		{
+			name:              "below minimum",
+			minRefreshHint:    2 * time.Hour,
+			maxRefreshHint:    3 * time.Hour,
+			expectRefreshHint: 7200,
+		},
+		{
+			name:              "above maximum",
+			minRefreshHint:    time.Minute,
+			maxRefreshHint:    30 * time.Minute,
+			expectRefreshHint: 1800,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			server := NewServer(ServerConfig{
+				Log:            log,
+				Getter:         testGetter(bundle),
+				MinRefreshHint: tt.minRefreshHint,
+				MaxRefreshHint: tt.maxRefreshHint,
+			})
+
+			rec := httptest.NewRecorder()
+			server.serveHTTP(rec, httptest.NewRequest("GET", "/", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var doc struct {
+				RefreshHint int `json:"spiffe_refresh_hint"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+			require.Equal(t, tt.expectRefreshHint, doc.RefreshHint)
+		})
+	}
+}
+
+func TestServerMirroredBundles(t *testing.T) {
+	federatedBundle := bundleutil.New("spiffe://federated.test")
+	federatedBundle.SetRefreshHint(time.Hour)
+
+	pendingTD := spiffeid.RequireTrustDomainFromString("pending.test")
+	failingTD := spiffeid.RequireTrustDomainFromString("failing.test")
+
+	log, hook := test.NewNullLogger()
+	server := NewServer(ServerConfig{
+		Log:    log,
+		Getter: testGetter(bundleutil.New("spiffe://domain.test")),
+		MirroredBundles: map[spiffeid.TrustDomain]Getter{
+			spiffeid.RequireTrustDomainFromString("federated.test"): testGetter(federatedBundle),
+			pendingTD: GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
+				return nil, nil
+			}),
+			failingTD: testGetter(nil),
+		},
+	})
+
+	for _, tt := range []struct {
+		name   string
+		path   string
+		status int
+		body   string
+	}{
+		{
+			name:   "success",
+			path:   "/federated/federated.test",
+			status: http.StatusOK,
+			body:   `{"keys":null,"spiffe_refresh_hint":3600}`,
+		},
+		{
+			name:   "trust domain not mirrored",
+			path:   "/federated/other.test",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+		{
+			name:   "invalid trust domain",
+			path:   "/federated/spiffe://federated.test/foo",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+		{
+			name:   "bundle not yet available",
+			path:   "/federated/pending.test",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+		{
+			name:   "fail to retrieve bundle",
+			path:   "/federated/failing.test",
+			status: http.StatusInternalServerError,
+			body:   "500 unable to retrieve federated bundle\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			hook.Reset()
+
+			rec := httptest.NewRecorder()
+			server.serveHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+			require.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusOK {
+				require.JSONEq(t, tt.body, rec.Body.String())
+				return
+			}
+			require.Equal(t, tt.body, rec.Body.String())
+		})
+	}
+
+	if entry := hook.LastEntry(); assert.NotNil(t, entry) {
+		assert.Equal(t, "Unable to retrieve federated bundle", entry.Message)
+		assert.Equal(t, failingTD.IDString(), entry.Data["trust_domain_id"])
+	}
+}
+
+func TestServerSignedBundles(t *testing.T) {
+	key := testkey.NewEC256(t)
+	signer, err := NewSigner(key)
+	require.NoError(t, err)
+
+	localBundle := bundleutil.New("spiffe://domain.test")
+	localBundle.SetRefreshHint(time.Hour)
+	federatedBundle := bundleutil.New("spiffe://federated.test")
+	federatedBundle.SetRefreshHint(time.Hour)
+
+	newServer := func(signer *Signer) *Server {
+		log, _ := test.NewNullLogger()
+		return NewServer(ServerConfig{
+			Log:    log,
+			Getter: testGetter(localBundle),
+			MirroredBundles: map[spiffeid.TrustDomain]Getter{
+				spiffeid.RequireTrustDomainFromString("federated.test"): testGetter(federatedBundle),
+			},
+			Signer: signer,
+		})
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		path   string
+		status int
+		body   string
+	}{
+		{
+			name:   "local bundle",
+			path:   "/signed",
+			status: http.StatusOK,
+		},
+		{
+			name:   "local bundle with trailing slash",
+			path:   "/signed/",
+			status: http.StatusOK,
+		},
+		{
+			name:   "mirrored bundle",
+			path:   "/signed/federated/federated.test",
+			status: http.StatusOK,
+		},
+		{
+			name:   "trust domain not mirrored",
+			path:   "/signed/federated/other.test",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+		{
+			name:   "invalid path",
+			path:   "/signed/foo",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(signer)
+			rec := httptest.NewRecorder()
+			server.serveHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+			require.Equal(t, tt.status, rec.Code)
+			if tt.status != http.StatusOK {
+				require.Equal(t, tt.body, rec.Body.String())
+				return
+			}
+			require.Equal(t, SignedBundleContentType, rec.Header().Get("Content-Type"))
+
+			jws, err := jose.ParseSigned(rec.Body.String())
+			require.NoError(t, err)
+			require.Len(t, jws.Signatures, 1)
+			header := jws.Signatures[0].Header
+			assert.Equal(t, string(jose.ES256), header.Algorithm)
+			assert.Equal(t, base64.RawURLEncoding.EncodeToString(thumbprint), header.KeyID)
+			assert.Equal(t, "jwk-set+json", header.ExtraHeaders[jose.HeaderContentType])
+
+			// The payload is the same document served unsigned
+			payload, err := jws.Verify(key.Public())
+			require.NoError(t, err)
+			unsignedPath := strings.TrimPrefix(tt.path, SignedPathPrefix)
+			if unsignedPath == "" {
+				unsignedPath = "/"
+			}
+			unsigned := httptest.NewRecorder()
+			server.serveHTTP(unsigned, httptest.NewRequest("GET", unsignedPath, nil))
+			require.Equal(t, unsigned.Body.String(), string(payload))
+		})
+	}
+
+	t.Run("not served without a signer", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newServer(nil).serveHTTP(rec, httptest.NewRequest("GET", "/signed", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestNewSignerRejectsUnsupportedKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	_, err = NewSigner(key)
+	require.EqualError(t, err, `unsupported bundle signing key curve "P-224"`)
+}
+
 func TestACMEAuth(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -0,0 +1,91 @@
+package bundle
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// SignedPathPrefix is the path prefix under which signed bundle documents
+// are served, e.g. /signed for the local bundle or
+// /signed/federated/example.org for a mirrored bundle.
+const SignedPathPrefix = "/signed"
+
+// SignedBundleContentType is the content type of the signed bundle
+// documents, which are JWS (compact serialization) over the bundle document.
+const SignedBundleContentType = "application/jose"
+
+// signedBundlePayloadType is the content type of the JWS payload
+const signedBundlePayloadType = "jwk-set+json"
+
+// Signer signs bundle documents so that consumers that cannot authenticate
+// the bundle endpoint (e.g. air-gapped ones that receive the documents
+// out-of-band) can verify their integrity with the public key of the signer.
+type Signer struct {
+	signer jose.Signer
+}
+
+// NewSigner returns a signer using the given key. ECDSA (P-256, P-384 and
+// P-521), RSA and Ed25519 keys are supported. The key ID header of the
+// signatures is the RFC 7638 thumbprint of the public key.
+func NewSigner(key crypto.Signer) (*Signer, error) {
+	alg, err := signatureAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute the key thumbprint: %w", err)
+	}
+
+	options := new(jose.SignerOptions).
+		WithHeader(jose.HeaderKey("kid"), base64.RawURLEncoding.EncodeToString(thumbprint)).
+		WithContentType(signedBundlePayloadType)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, options)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create bundle signer: %w", err)
+	}
+	return &Signer{signer: signer}, nil
+}
+
+// Sign returns the JWS, in compact serialization, over the bundle document
+func (s *Signer) Sign(doc []byte) ([]byte, error) {
+	jws, err := s.signer.Sign(doc)
+	if err != nil {
+		return nil, err
+	}
+	compact, err := jws.CompactSerialize()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(compact), nil
+}
+
+func signatureAlgorithm(key crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch publicKey := key.Public().(type) {
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		default:
+			return "", fmt.Errorf("unsupported bundle signing key curve %q", publicKey.Curve.Params().Name)
+		}
+	case *rsa.PublicKey:
+		return jose.RS256, nil
+	case ed25519.PublicKey:
+		return jose.EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported bundle signing key type %T", publicKey)
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"crypto/x509"
 	"errors"
 	"net"
+	"os"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	agentv1 "github.com/spiffe/spire/pkg/server/api/agent/v1"
@@ -18,7 +20,9 @@ import (
 	debugv1 "github.com/spiffe/spire/pkg/server/api/debug/v1"
 	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
 	healthv1 "github.com/spiffe/spire/pkg/server/api/health/v1"
+	infov1 "github.com/spiffe/spire/pkg/server/api/info/v1"
 	svidv1 "github.com/spiffe/spire/pkg/server/api/svid/v1"
+	"github.com/spiffe/spire/pkg/server/bundle/propagation"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/catalog"
@@ -38,6 +42,17 @@ type Config struct {
 	// UDSAddr is the address to bind the UDS listener to.
 	UDSAddr *net.UnixAddr
 
+	// AdminUDSAddr, if set, is the address to bind a dedicated UDS listener
+	// for the admin APIs to. These APIs are then no longer served on UDSAddr.
+	AdminUDSAddr *net.UnixAddr
+
+	// AdminUDSMode is the file mode of the admin UDS. Defaults to 0700.
+	AdminUDSMode os.FileMode
+
+	// APIGatewayAddr, if set, is the address to bind the HTTP/JSON gateway
+	// for the agent, bundle and entry APIs to.
+	APIGatewayAddr *net.TCPAddr
+
 	// The svid rotator used to obtain the latest server credentials
 	SVIDObserver svid.Observer
 
@@ -53,6 +68,10 @@ type Config struct {
 	// Allow agentless spiffeIds when doing node attestation
 	AllowAgentlessNodeAttestors bool
 
+	// ExperimentalFeatures lists the experimental features enabled on the
+	// server. It is reported by the info API.
+	ExperimentalFeatures []string
+
 	// Bundle endpoint configuration
 	BundleEndpoint bundle.EndpointConfig
 
@@ -62,9 +81,28 @@ type Config struct {
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
+	// LogLevels, if set, is used by the debug API to report and adjust the
+	// log levels of the server subsystems.
+	LogLevels *log.Levels
+
 	// RateLimit holds rate limiting configurations.
 	RateLimit RateLimitConfig
 
+	// BundleLimits holds the limits enforced on bundles.
+	BundleLimits BundleLimitsConfig
+
+	// EntryQuotas limit the number of registration entries that can be
+	// created under SPIFFE ID path prefixes.
+	EntryQuotas []entryv1.Quota
+
+	// DataStoreTimeout is the default timeout for datastore calls made by
+	// the API handlers. If unset, defaultDataStoreTimeout is used.
+	DataStoreTimeout time.Duration
+
+	// ClockSkewTolerance is the maximum difference allowed between the clock
+	// of a caller and the server clock. If unset, clock skew is not checked.
+	ClockSkewTolerance time.Duration
+
 	Uptime func() time.Duration
 
 	Clock clock.Clock
@@ -116,6 +154,30 @@ func (c *Config) maybeMakeBundleEndpointServer() Server {
 	}
 
 	ds := c.Catalog.GetDataStore()
+
+	var mirroredBundles map[spiffeid.TrustDomain]bundle.Getter
+	if len(c.BundleEndpoint.MirroredTrustDomains) > 0 {
+		mirroredBundles = make(map[spiffeid.TrustDomain]bundle.Getter)
+		for _, td := range c.BundleEndpoint.MirroredTrustDomains {
+			td := td
+			mirroredBundles[td] = bundle.GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
+				resp, err := ds.FetchBundle(dscache.WithCache(ctx), &datastore.FetchBundleRequest{
+					TrustDomainId: td.IDString(),
+				})
+				if err != nil {
+					return nil, err
+				}
+				if resp.Bundle == nil {
+					// The bundle has not been fetched from the federated
+					// trust domain yet.
+					return nil, nil
+				}
+				return bundleutil.BundleFromProto(resp.Bundle)
+			})
+		}
+		c.Log.WithField("trust_domains", c.BundleEndpoint.MirroredTrustDomains).Info("Mirroring federated bundles on bundle endpoint")
+	}
+
 	return bundle.NewServer(bundle.ServerConfig{
 		Log:     c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
 		Address: c.BundleEndpoint.Address.String(),
@@ -131,43 +193,65 @@ func (c *Config) maybeMakeBundleEndpointServer() Server {
 			}
 			return bundleutil.BundleFromProto(resp.Bundle)
 		}),
-		ServerAuth: serverAuth,
+		ServerAuth:      serverAuth,
+		MirroredBundles: mirroredBundles,
+		MinRefreshHint:  c.BundleLimits.MinRefreshHint,
+		MaxRefreshHint:  c.BundleLimits.MaxRefreshHint,
+		Signer:          c.BundleEndpoint.Signer,
 	})
 }
 
 func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServers {
-	ds := c.Catalog.GetDataStore()
+	ds := withDataStoreDeadlines(c.Catalog.GetDataStore())
 	upstreamPublisher := UpstreamPublisher(c.Manager)
+	bundleTracker := propagation.NewTracker()
 
 	return APIServers{
 		AgentServer: agentv1.New(agentv1.Config{
-			DataStore:   ds,
-			ServerCA:    c.ServerCA,
-			TrustDomain: c.TrustDomain,
-			Catalog:     c.Catalog,
-			Clock:       c.Clock,
+			DataStore:     ds,
+			ServerCA:      c.ServerCA,
+			TrustDomain:   c.TrustDomain,
+			Catalog:       c.Catalog,
+			Clock:         c.Clock,
+			BundleTracker: bundleTracker,
 		}),
 		BundleServer: bundlev1.New(bundlev1.Config{
-			TrustDomain:       c.TrustDomain,
-			DataStore:         ds,
-			UpstreamPublisher: upstreamPublisher,
+			TrustDomain:        c.TrustDomain,
+			DataStore:          ds,
+			UpstreamPublisher:  upstreamPublisher,
+			MaxX509Authorities: c.BundleLimits.MaxX509Authorities,
+			MaxJWTAuthorities:  c.BundleLimits.MaxJWTAuthorities,
+			MaxBundleBytes:     c.BundleLimits.MaxBundleBytes,
+			MinRefreshHint:     c.BundleLimits.MinRefreshHint,
+			MaxRefreshHint:     c.BundleLimits.MaxRefreshHint,
+			BundleTracker:      bundleTracker,
+			Clock:              c.Clock,
+			Metrics:            c.Metrics,
 		}),
 		DebugServer: debugv1.New(debugv1.Config{
-			TrustDomain:  c.TrustDomain,
-			Clock:        c.Clock,
-			DataStore:    ds,
-			SVIDObserver: c.SVIDObserver,
-			Uptime:       c.Uptime,
+			TrustDomain:   c.TrustDomain,
+			Clock:         c.Clock,
+			DataStore:     ds,
+			SVIDObserver:  c.SVIDObserver,
+			Uptime:        c.Uptime,
+			BundleTracker: bundleTracker,
+			LogLevels:     c.LogLevels,
 		}),
 		EntryServer: entryv1.New(entryv1.Config{
 			TrustDomain:  c.TrustDomain,
 			DataStore:    ds,
 			EntryFetcher: entryFetcher,
+			Clock:        c.Clock,
+			Quotas:       c.EntryQuotas,
 		}),
 		HealthServer: healthv1.New(healthv1.Config{
 			TrustDomain: c.TrustDomain,
 			DataStore:   ds,
 		}),
+		InfoServer: infov1.New(infov1.Config{
+			ExperimentalFeatures: c.ExperimentalFeatures,
+			APIVersions:          apiVersions,
+		}),
 		SVIDServer: svidv1.New(svidv1.Config{
 			TrustDomain:  c.TrustDomain,
 			EntryFetcher: entryFetcher,
@@ -0,0 +1,217 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// deadlineDataStore wraps a datastore so that each call is bounded by the
+// datastore timeout set on the RPC context by the WithDataStoreTimeout
+// middleware.
+type deadlineDataStore struct {
+	ds datastore.DataStore
+}
+
+func withDataStoreDeadlines(ds datastore.DataStore) datastore.DataStore {
+	return deadlineDataStore{ds: ds}
+}
+
+func (w deadlineDataStore) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.AppendBundle(ctx, req)
+}
+
+func (w deadlineDataStore) BatchRegistrationEntries(ctx context.Context, req *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.BatchRegistrationEntries(ctx, req)
+}
+
+func (w deadlineDataStore) CheckIntegrity(ctx context.Context, req *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CheckIntegrity(ctx, req)
+}
+
+func (w deadlineDataStore) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CountAttestedNodes(ctx, req)
+}
+
+func (w deadlineDataStore) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (*datastore.CountBundlesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CountBundles(ctx, req)
+}
+
+func (w deadlineDataStore) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CountRegistrationEntries(ctx, req)
+}
+
+func (w deadlineDataStore) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CreateAttestedNode(ctx, req)
+}
+
+func (w deadlineDataStore) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CreateBundle(ctx, req)
+}
+
+func (w deadlineDataStore) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CreateJoinToken(ctx, req)
+}
+
+func (w deadlineDataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.CreateRegistrationEntry(ctx, req)
+}
+
+func (w deadlineDataStore) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.DeleteAttestedNode(ctx, req)
+}
+
+func (w deadlineDataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.DeleteBundle(ctx, req)
+}
+
+func (w deadlineDataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.DeleteJoinToken(ctx, req)
+}
+
+func (w deadlineDataStore) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.DeleteRegistrationEntry(ctx, req)
+}
+
+func (w deadlineDataStore) FetchAttestedNode(ctx context.Context, req *datastore.FetchAttestedNodeRequest) (*datastore.FetchAttestedNodeResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.FetchAttestedNode(ctx, req)
+}
+
+func (w deadlineDataStore) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.FetchBundle(ctx, req)
+}
+
+func (w deadlineDataStore) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.FetchJoinToken(ctx, req)
+}
+
+func (w deadlineDataStore) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (*datastore.FetchRegistrationEntryResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.FetchRegistrationEntry(ctx, req)
+}
+
+func (w deadlineDataStore) GetNodeSelectors(ctx context.Context, req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.GetNodeSelectors(ctx, req)
+}
+
+func (w deadlineDataStore) ListAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.ListAttestedNodes(ctx, req)
+}
+
+func (w deadlineDataStore) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.ListBundles(ctx, req)
+}
+
+func (w deadlineDataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.ListJoinTokens(ctx, req)
+}
+
+func (w deadlineDataStore) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.ListNodeSelectors(ctx, req)
+}
+
+func (w deadlineDataStore) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.ListRegistrationEntries(ctx, req)
+}
+
+func (w deadlineDataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.PruneBundle(ctx, req)
+}
+
+func (w deadlineDataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.PruneJoinTokens(ctx, req)
+}
+
+func (w deadlineDataStore) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.PruneRegistrationEntries(ctx, req)
+}
+
+func (w deadlineDataStore) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.SetBundle(ctx, req)
+}
+
+func (w deadlineDataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.SetNodeSelectors(ctx, req)
+}
+
+func (w deadlineDataStore) UpdateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.UpdateAttestedNode(ctx, req)
+}
+
+func (w deadlineDataStore) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.UpdateBundle(ctx, req)
+}
+
+func (w deadlineDataStore) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.UpdateRegistrationEntry(ctx, req)
+}
+
+func (w deadlineDataStore) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	ctx, cancel := rpccontext.DataStoreContext(ctx)
+	defer cancel()
+	return w.ds.UseJoinToken(ctx, req)
+}
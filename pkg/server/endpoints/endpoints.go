@@ -34,6 +34,7 @@ import (
 	bundlev1_pb "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	debugv1_pb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	entryv1_pb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	infov1_pb "github.com/spiffe/spire/proto/spire/api/server/info/v1"
 	svidv1_pb "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
 )
 
@@ -42,6 +43,38 @@ import (
 // route to the server in the case of a change in DNS membership.
 const defaultMaxConnectionAge = 3 * time.Minute
 
+const (
+	// defaultDataStoreTimeout is the default timeout for datastore calls made
+	// by the API handlers.
+	defaultDataStoreTimeout = 30 * time.Second
+
+	// dataStoreTimeoutMargin is subtracted from the RPC deadline when
+	// bounding datastore calls so that handlers have time to return an
+	// error before the caller gives up.
+	dataStoreTimeoutMargin = 250 * time.Millisecond
+
+	// udsMode restricts access to the UDS to processes running as the same
+	// user or group as the server.
+	udsMode os.FileMode = 0770
+
+	// defaultAdminUDSMode restricts access to the admin UDS to processes
+	// running as the same user as the server.
+	defaultAdminUDSMode os.FileMode = 0700
+)
+
+// apiVersions lists the API versions served on the TCP endpoint, as protobuf
+// package names. It is reported by the info API so clients can tell which
+// APIs are available before calling them.
+var apiVersions = []string{
+	"spire.api.node",
+	"spire.api.registration",
+	"spire.api.server.agent.v1",
+	"spire.api.server.bundle.v1",
+	"spire.api.server.entry.v1",
+	"spire.api.server.info.v1",
+	"spire.api.server.svid.v1",
+}
+
 // Server manages gRPC and HTTP endpoint lifecycle
 type Server interface {
 	// ListenAndServe starts all endpoint servers and blocks until the context
@@ -56,6 +89,9 @@ type Endpoints struct {
 
 	TCPAddr                      *net.TCPAddr
 	UDSAddr                      *net.UnixAddr
+	AdminUDSAddr                 *net.UnixAddr
+	AdminUDSMode                 os.FileMode
+	APIGatewayAddr               *net.TCPAddr
 	SVIDObserver                 svid.Observer
 	TrustDomain                  spiffeid.TrustDomain
 	DataStore                    datastore.DataStore
@@ -64,7 +100,10 @@ type Endpoints struct {
 	Log                          logrus.FieldLogger
 	Metrics                      telemetry.Metrics
 	RateLimit                    RateLimitConfig
+	DataStoreTimeout             time.Duration
+	ClockSkewTolerance           time.Duration
 	EntryFetcherCacheRebuildTask func(context.Context) error
+	Clock                        clock.Clock
 }
 
 type OldAPIServers struct {
@@ -78,6 +117,7 @@ type APIServers struct {
 	DebugServer  debugv1_pb.DebugServer
 	EntryServer  entryv1_pb.EntryServer
 	HealthServer grpc_health_v1.HealthServer
+	InfoServer   infov1_pb.InfoServer
 	SVIDServer   svidv1_pb.SVIDServer
 }
 
@@ -85,6 +125,40 @@ type APIServers struct {
 type RateLimitConfig struct {
 	// Attestation, if true, rate limits attestation
 	Attestation bool
+
+	// StreamsPerCaller, if greater than zero, limits the number of
+	// concurrently open agent streams (attestation and SVID sync) per caller.
+	StreamsPerCaller int
+
+	// Bundle, if greater than zero, limits the number of bundle reads
+	// (GetBundle, GetFederatedBundle and ListFederatedBundles) per second per
+	// caller.
+	Bundle int
+}
+
+// BundleLimitsConfig holds the limits enforced on bundles set through the
+// bundle API. Limits that are zero are not enforced, except for the refresh
+// hint bounds, which fall back to sane defaults.
+type BundleLimitsConfig struct {
+	// MaxX509Authorities is the maximum number of X.509 authorities in a
+	// bundle.
+	MaxX509Authorities int
+
+	// MaxJWTAuthorities is the maximum number of JWT authorities in a bundle.
+	MaxJWTAuthorities int
+
+	// MaxBundleBytes is the maximum size in bytes of a serialized bundle.
+	MaxBundleBytes int
+
+	// MinRefreshHint is the smallest refresh hint that can be set on a
+	// bundle and that is served by the bundle endpoint. If zero,
+	// bundleutil.MinimumRefreshHint is used.
+	MinRefreshHint time.Duration
+
+	// MaxRefreshHint is the largest refresh hint that can be set on a bundle
+	// and that is served by the bundle endpoint. If zero,
+	// bundleutil.MaximumRefreshHint is used.
+	MaxRefreshHint time.Duration
 }
 
 // New creates new endpoints struct
@@ -105,10 +179,23 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		return nil, err
 	}
 
+	dataStoreTimeout := c.DataStoreTimeout
+	if dataStoreTimeout == 0 {
+		dataStoreTimeout = defaultDataStoreTimeout
+	}
+
+	adminUDSMode := c.AdminUDSMode
+	if adminUDSMode == 0 {
+		adminUDSMode = defaultAdminUDSMode
+	}
+
 	return &Endpoints{
 		OldAPIServers:                oldAPIServers,
 		TCPAddr:                      c.TCPAddr,
 		UDSAddr:                      c.UDSAddr,
+		AdminUDSAddr:                 c.AdminUDSAddr,
+		AdminUDSMode:                 adminUDSMode,
+		APIGatewayAddr:               c.APIGatewayAddr,
 		SVIDObserver:                 c.SVIDObserver,
 		TrustDomain:                  c.TrustDomain,
 		DataStore:                    c.Catalog.GetDataStore(),
@@ -117,7 +204,10 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		Log:                          c.Log,
 		Metrics:                      c.Metrics,
 		RateLimit:                    c.RateLimit,
+		DataStoreTimeout:             dataStoreTimeout,
+		ClockSkewTolerance:           c.ClockSkewTolerance,
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
+		Clock:                        c.Clock,
 	}, nil
 }
 
@@ -133,6 +223,13 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	tcpServer := e.createTCPServer(ctx, unaryInterceptor, streamInterceptor)
 	udsServer := e.createUDSServer(unaryInterceptor, streamInterceptor)
 
+	// The new APIs meant for operators are served on the admin UDS server
+	// when one is configured, and on the UDS server otherwise.
+	adminServer := udsServer
+	if e.AdminUDSAddr != nil {
+		adminServer = e.createUDSServer(unaryInterceptor, streamInterceptor)
+	}
+
 	// Old APIs
 	node_pb.RegisterNodeServer(tcpServer, e.OldAPIServers.NodeServer)
 	registration_pb.RegisterRegistrationServer(tcpServer, e.OldAPIServers.RegistrationServer)
@@ -140,28 +237,48 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 
 	// New APIs
 	agentv1_pb.RegisterAgentServer(tcpServer, e.APIServers.AgentServer)
-	agentv1_pb.RegisterAgentServer(udsServer, e.APIServers.AgentServer)
+	agentv1_pb.RegisterAgentServer(adminServer, e.APIServers.AgentServer)
 	bundlev1_pb.RegisterBundleServer(tcpServer, e.APIServers.BundleServer)
-	bundlev1_pb.RegisterBundleServer(udsServer, e.APIServers.BundleServer)
+	bundlev1_pb.RegisterBundleServer(adminServer, e.APIServers.BundleServer)
 	entryv1_pb.RegisterEntryServer(tcpServer, e.APIServers.EntryServer)
-	entryv1_pb.RegisterEntryServer(udsServer, e.APIServers.EntryServer)
+	entryv1_pb.RegisterEntryServer(adminServer, e.APIServers.EntryServer)
 	svidv1_pb.RegisterSVIDServer(tcpServer, e.APIServers.SVIDServer)
-	svidv1_pb.RegisterSVIDServer(udsServer, e.APIServers.SVIDServer)
+	svidv1_pb.RegisterSVIDServer(adminServer, e.APIServers.SVIDServer)
+	infov1_pb.RegisterInfoServer(tcpServer, e.APIServers.InfoServer)
+	infov1_pb.RegisterInfoServer(adminServer, e.APIServers.InfoServer)
 
-	// Register Health and Debug only on UDS server
+	// Register Health and Debug only on UDS servers
 	grpc_health_v1.RegisterHealthServer(udsServer, e.APIServers.HealthServer)
-	debugv1_pb.RegisterDebugServer(udsServer, e.APIServers.DebugServer)
+	debugv1_pb.RegisterDebugServer(adminServer, e.APIServers.DebugServer)
 
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
 			return e.runTCPServer(ctx, tcpServer)
 		},
 		func(ctx context.Context) error {
-			return e.runUDSServer(ctx, udsServer)
+			return e.runUDSServer(ctx, udsServer, e.UDSAddr, udsMode, "UDS")
 		},
 		e.EntryFetcherCacheRebuildTask,
 	}
 
+	if e.AdminUDSAddr != nil {
+		grpc_health_v1.RegisterHealthServer(adminServer, e.APIServers.HealthServer)
+		tasks = append(tasks, func(ctx context.Context) error {
+			return e.runUDSServer(ctx, adminServer, e.AdminUDSAddr, e.AdminUDSMode, "admin UDS")
+		})
+	}
+
+	if e.APIGatewayAddr != nil {
+		gw := newGateway(e.Log, unaryInterceptor)
+		agentv1_pb.RegisterAgentServer(gw, e.APIServers.AgentServer)
+		bundlev1_pb.RegisterBundleServer(gw, e.APIServers.BundleServer)
+		entryv1_pb.RegisterEntryServer(gw, e.APIServers.EntryServer)
+		infov1_pb.RegisterInfoServer(gw, e.APIServers.InfoServer)
+		tasks = append(tasks, func(ctx context.Context) error {
+			return e.runGatewayServer(ctx, gw)
+		})
+	}
+
 	if e.BundleEndpointServer != nil {
 		tasks = append(tasks, e.BundleEndpointServer.ListenAndServe)
 	}
@@ -221,35 +338,35 @@ func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error
 	}
 }
 
-// runUDSServer  will start the server and block until it exits or we are dying.
-func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error {
-	os.Remove(e.UDSAddr.String())
-	l, err := net.ListenUnix(e.UDSAddr.Network(), e.UDSAddr)
+// runUDSServer will start the server on the given address and block until it
+// exits or we are dying. The name is used to identify the server in logs.
+func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server, addr *net.UnixAddr, mode os.FileMode, name string) error {
+	os.Remove(addr.String())
+	l, err := net.ListenUnix(addr.Network(), addr)
 	if err != nil {
 		return err
 	}
 	defer l.Close()
 
-	// Restrict access to the UDS to processes running as the same user or
-	// group as the server.
-	if err := os.Chmod(e.UDSAddr.String(), 0770); err != nil {
+	// Restrict access to the UDS according to the given file mode.
+	if err := os.Chmod(addr.String(), mode); err != nil {
 		return err
 	}
 
 	// Skip use of tomb here so we don't pollute a clean shutdown with errors
-	e.Log.WithField(telemetry.Address, l.Addr().String()).Info("Starting UDS server")
+	e.Log.WithField(telemetry.Address, l.Addr().String()).Infof("Starting %s server", name)
 	errChan := make(chan error)
 	go func() { errChan <- server.Serve(l) }()
 
 	select {
 	case err := <-errChan:
-		e.Log.WithError(err).Error("UDS server stopped prematurely")
+		e.Log.WithError(err).Errorf("%s server stopped prematurely", name)
 		return err
 	case <-ctx.Done():
-		e.Log.Info("Stopping UDS server")
+		e.Log.Infof("Stopping %s server", name)
 		server.Stop()
 		<-errChan
-		e.Log.Info("UDS server has stopped")
+		e.Log.Infof("%s server has stopped", name)
 		return nil
 	}
 }
@@ -327,7 +444,19 @@ func (e *Endpoints) makeInterceptors() (grpc.UnaryServerInterceptor, grpc.Stream
 
 	oldUnary, oldStream := wrapWithDeprecationLogging(log, auth.UnaryAuthorizeCall, auth.StreamAuthorizeCall)
 
-	newUnary, newStream := middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, clock.New(), e.RateLimit))
+	newUnary, newStream := middleware.Interceptors(middleware.Chain(
+		Middleware(log, e.Metrics, e.DataStore, e.Clock, e.RateLimit),
+		middleware.WithDataStoreTimeout(e.DataStoreTimeout, dataStoreTimeoutMargin),
+		middleware.WithClockSkewTolerance(e.ClockSkewTolerance, e.Clock),
+	))
+
+	streamLimiter := middleware.StreamInterceptor(middleware.WithStreamLimits(StreamLimits(e.RateLimit)))
+
+	// Panics are recovered inside of the other interceptors so that they
+	// observe the resulting error
+	recoveryUnary, recoveryStream := middleware.RecoveryInterceptors(log, e.Metrics)
 
-	return unaryInterceptorMux(oldUnary, newUnary), streamInterceptorMux(oldStream, newStream)
+	unary := middleware.ChainUnaryInterceptors(unaryInterceptorMux(oldUnary, newUnary), recoveryUnary)
+	stream := middleware.ChainStreamInterceptors(streamLimiter, middleware.ChainStreamInterceptors(streamInterceptorMux(oldStream, newStream), recoveryStream))
+	return unary, stream
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"net"
+	"os"
 	"path/filepath"
 	"reflect"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	bundlev1 "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	debugv1 "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	infov1 "github.com/spiffe/spire/proto/spire/api/server/info/v1"
 	svidv1 "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
@@ -113,6 +115,8 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, cat.GetDataStore(), endpoints.DataStore)
 	assert.Equal(t, log, endpoints.Log)
 	assert.Equal(t, metrics, endpoints.Metrics)
+	assert.Equal(t, defaultDataStoreTimeout, endpoints.DataStoreTimeout)
+	assert.Equal(t, clk, endpoints.Clock)
 }
 
 func TestNewErrorCreatingAuthorizedEntryFetcher(t *testing.T) {
@@ -208,6 +212,7 @@ func TestListenAndServe(t *testing.T) {
 			DebugServer:  &debugv1.UnimplementedDebugServer{},
 			EntryServer:  &entryv1.UnimplementedEntryServer{},
 			HealthServer: &grpc_health_v1.UnimplementedHealthServer{},
+			InfoServer:   &infov1.UnimplementedInfoServer{},
 			SVIDServer:   &svidv1.UnimplementedSVIDServer{},
 		},
 		BundleEndpointServer:         bundleEndpointServer,
@@ -215,6 +220,7 @@ func TestListenAndServe(t *testing.T) {
 		Metrics:                      metrics,
 		RateLimit:                    rateLimit,
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
+		Clock:                        clk,
 	}
 
 	// Prime the datastore with the:
@@ -288,6 +294,9 @@ func TestListenAndServe(t *testing.T) {
 	t.Run("Health", func(t *testing.T) {
 		testHealthAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
 	})
+	t.Run("Info", func(t *testing.T) {
+		testInfoAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
+	})
 	t.Run("Bundle", func(t *testing.T) {
 		testBundleAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
 	})
@@ -312,6 +321,150 @@ func TestListenAndServe(t *testing.T) {
 	}
 }
 
+func TestListenAndServeWithAdminUDS(t *testing.T) {
+	ca := testca.New(t, testTD)
+	serverSVID := ca.CreateX509SVID(serverID)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	dir := spiretest.TempDir(t)
+	udsPath := filepath.Join(dir, "socket")
+	adminUDSPath := filepath.Join(dir, "admin-socket")
+
+	ds := fakedatastore.New(t)
+	log, _ := test.NewNullLogger()
+
+	endpoints := Endpoints{
+		TCPAddr:      listener.Addr().(*net.TCPAddr),
+		UDSAddr:      &net.UnixAddr{Name: udsPath, Net: "unix"},
+		AdminUDSAddr: &net.UnixAddr{Name: adminUDSPath, Net: "unix"},
+		AdminUDSMode: 0750,
+		SVIDObserver: newSVIDObserver(serverSVID),
+		TrustDomain:  testTD,
+		DataStore:    ds,
+		OldAPIServers: OldAPIServers{
+			RegistrationServer: newRegistrationServer(),
+			NodeServer:         newNodeServer(),
+		},
+		APIServers: APIServers{
+			AgentServer:  &agentv1.UnimplementedAgentServer{},
+			BundleServer: &bundlev1.UnimplementedBundleServer{},
+			DebugServer:  &debugv1.UnimplementedDebugServer{},
+			EntryServer:  &entryv1.UnimplementedEntryServer{},
+			HealthServer: &grpc_health_v1.UnimplementedHealthServer{},
+			InfoServer:   &infov1.UnimplementedInfoServer{},
+			SVIDServer:   &svidv1.UnimplementedSVIDServer{},
+		},
+		Log:                          log,
+		Metrics:                      fakemetrics.New(),
+		RateLimit:                    rateLimit,
+		EntryFetcherCacheRebuildTask: func(ctx context.Context) error { <-ctx.Done(); return nil },
+		Clock:                        clock.NewMock(t),
+	}
+	prepareDataStore(t, ds, ca, ca.CreateX509SVID(agentID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+
+	dialUDS := func(path string) *grpc.ClientConn {
+		conn, err := grpc.DialContext(ctx, "unix://"+path, grpc.WithBlock(), grpc.WithInsecure())
+		require.NoError(t, err)
+		return conn
+	}
+	udsConn := dialUDS(udsPath)
+	defer udsConn.Close()
+	adminConn := dialUDS(adminUDSPath)
+	defer adminConn.Close()
+
+	// The admin APIs are only served on the admin UDS
+	for _, tt := range []struct {
+		service string
+		method  string
+		call    func(conn *grpc.ClientConn) error
+	}{
+		{
+			service: "spire.api.server.agent.v1.Agent",
+			method:  "ListAgents",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := agentv1.NewAgentClient(conn).ListAgents(ctx, &agentv1.ListAgentsRequest{})
+				return err
+			},
+		},
+		{
+			service: "spire.api.server.bundle.v1.Bundle",
+			method:  "GetBundle",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := bundlev1.NewBundleClient(conn).GetBundle(ctx, &bundlev1.GetBundleRequest{})
+				return err
+			},
+		},
+		{
+			service: "spire.api.server.debug.v1.Debug",
+			method:  "GetInfo",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := debugv1.NewDebugClient(conn).GetInfo(ctx, &debugv1.GetInfoRequest{})
+				return err
+			},
+		},
+		{
+			service: "spire.api.server.entry.v1.Entry",
+			method:  "ListEntries",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := entryv1.NewEntryClient(conn).ListEntries(ctx, &entryv1.ListEntriesRequest{})
+				return err
+			},
+		},
+		{
+			service: "spire.api.server.info.v1.Info",
+			method:  "GetBuildInfo",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := infov1.NewInfoClient(conn).GetBuildInfo(ctx, &infov1.GetBuildInfoRequest{})
+				return err
+			},
+		},
+		{
+			service: "spire.api.server.svid.v1.SVID",
+			method:  "MintX509SVID",
+			call: func(conn *grpc.ClientConn) error {
+				_, err := svidv1.NewSVIDClient(conn).MintX509SVID(ctx, &svidv1.MintX509SVIDRequest{})
+				return err
+			},
+		},
+	} {
+		spiretest.RequireGRPCStatus(t, tt.call(adminConn), codes.Unimplemented, "method "+tt.method+" not implemented")
+		spiretest.RequireGRPCStatus(t, tt.call(udsConn), codes.Unimplemented, "unknown service "+tt.service)
+	}
+
+	// Health is served on both
+	for _, conn := range []*grpc.ClientConn{udsConn, adminConn} {
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "method Check not implemented")
+	}
+
+	// Each socket has its own file mode
+	info, err := os.Stat(udsPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0770), info.Mode().Perm())
+	info, err = os.Stat(adminUDSPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0750), info.Mode().Perm())
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for ListenAndServe to stop")
+	}
+}
+
 func prepareDataStore(t *testing.T, ds datastore.DataStore, ca *testca.CA, agentSVID *x509svid.SVID) {
 	// Prepare the bundle
 	_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
@@ -422,6 +575,8 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": true,
+			"ListJoinTokens":  true,
+			"PruneJoinTokens": true,
 		})
 	})
 
@@ -434,6 +589,8 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": false,
+			"ListJoinTokens":  false,
+			"PruneJoinTokens": false,
 		})
 	})
 
@@ -446,6 +603,8 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 			"AttestAgent":     true,
 			"RenewAgent":      true,
 			"CreateJoinToken": false,
+			"ListJoinTokens":  false,
+			"PruneJoinTokens": false,
 		})
 	})
 
@@ -458,6 +617,8 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": true,
+			"ListJoinTokens":  true,
+			"PruneJoinTokens": true,
 		})
 	})
 
@@ -470,6 +631,8 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": false,
+			"ListJoinTokens":  false,
+			"PruneJoinTokens": false,
 		})
 	})
 }
@@ -511,34 +674,76 @@ func testHealthAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 	})
 }
 
+func testInfoAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, infov1.NewInfoClient(udsConn), map[string]bool{
+			"GetBuildInfo": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, infov1.NewInfoClient(noauthConn), map[string]bool{
+			"GetBuildInfo": true,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, infov1.NewInfoClient(agentConn), map[string]bool{
+			"GetBuildInfo": true,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, infov1.NewInfoClient(adminConn), map[string]bool{
+			"GetBuildInfo": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, infov1.NewInfoClient(downstreamConn), map[string]bool{
+			"GetBuildInfo": true,
+		})
+	})
+}
+
 func testDebugAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(udsConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":      true,
+			"GetLogLevels": true,
+			"SetLogLevel":  true,
 		})
 	})
 
 	t.Run("NoAuth", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(noauthConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":      true,
+			"GetLogLevels": true,
+			"SetLogLevel":  true,
 		})
 	})
 
 	t.Run("Agent", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(agentConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":      true,
+			"GetLogLevels": true,
+			"SetLogLevel":  true,
 		})
 	})
 
 	t.Run("Admin", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(adminConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":      true,
+			"GetLogLevels": true,
+			"SetLogLevel":  true,
 		})
 	})
 
 	t.Run("Downstream", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(downstreamConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":      true,
+			"GetLogLevels": true,
+			"SetLogLevel":  true,
 		})
 	})
 }
@@ -550,11 +755,13 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 			"AppendBundle":               true,
 			"PublishJWTAuthority":        false,
 			"ListFederatedBundles":       true,
+			"CountBundles":               true,
 			"GetFederatedBundle":         true,
 			"BatchCreateFederatedBundle": true,
 			"BatchUpdateFederatedBundle": true,
 			"BatchSetFederatedBundle":    true,
 			"BatchDeleteFederatedBundle": true,
+			"WatchBundle":                true,
 		})
 	})
 
@@ -564,11 +771,13 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 			"AppendBundle":               false,
 			"PublishJWTAuthority":        false,
 			"ListFederatedBundles":       false,
+			"CountBundles":               false,
 			"GetFederatedBundle":         false,
 			"BatchCreateFederatedBundle": false,
 			"BatchUpdateFederatedBundle": false,
 			"BatchSetFederatedBundle":    false,
 			"BatchDeleteFederatedBundle": false,
+			"WatchBundle":                true,
 		})
 	})
 
@@ -578,11 +787,13 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 			"AppendBundle":               false,
 			"PublishJWTAuthority":        false,
 			"ListFederatedBundles":       false,
+			"CountBundles":               false,
 			"GetFederatedBundle":         true,
 			"BatchCreateFederatedBundle": false,
 			"BatchUpdateFederatedBundle": false,
 			"BatchSetFederatedBundle":    false,
 			"BatchDeleteFederatedBundle": false,
+			"WatchBundle":                true,
 		})
 	})
 
@@ -592,11 +803,13 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 			"AppendBundle":               true,
 			"PublishJWTAuthority":        false,
 			"ListFederatedBundles":       true,
+			"CountBundles":               true,
 			"GetFederatedBundle":         true,
 			"BatchCreateFederatedBundle": true,
 			"BatchUpdateFederatedBundle": true,
 			"BatchSetFederatedBundle":    true,
 			"BatchDeleteFederatedBundle": true,
+			"WatchBundle":                true,
 		})
 	})
 
@@ -606,11 +819,13 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 			"AppendBundle":               false,
 			"PublishJWTAuthority":        true,
 			"ListFederatedBundles":       false,
+			"CountBundles":               false,
 			"GetFederatedBundle":         false,
 			"BatchCreateFederatedBundle": false,
 			"BatchUpdateFederatedBundle": false,
 			"BatchSetFederatedBundle":    false,
 			"BatchDeleteFederatedBundle": false,
+			"WatchBundle":                true,
 		})
 	})
 }
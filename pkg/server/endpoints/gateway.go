@@ -0,0 +1,238 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// gatewayMaxRequestBytes is the maximum size of a JSON request body
+	// accepted by the API gateway.
+	gatewayMaxRequestBytes = 4 << 20
+
+	// statusClientClosedRequest is the non-standard HTTP status used to
+	// report RPCs canceled by the caller.
+	statusClientClosedRequest = 499
+)
+
+// gateway is an HTTP/JSON facade in front of the unary methods of the gRPC
+// APIs. Requests are POSTed as JSON to the full gRPC method path (e.g.
+// /spire.api.server.bundle.v1.Bundle/GetBundle) and are dispatched through
+// the same interceptor used by the gRPC servers, so they are subject to the
+// same authentication and authorization. Streaming methods are not exposed.
+type gateway struct {
+	log         logrus.FieldLogger
+	interceptor grpc.UnaryServerInterceptor
+	methods     map[string]gatewayMethod
+}
+
+type gatewayMethod struct {
+	impl interface{}
+	desc grpc.MethodDesc
+}
+
+// gatewayError is the JSON body returned when an RPC fails.
+type gatewayError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+func newGateway(log logrus.FieldLogger, interceptor grpc.UnaryServerInterceptor) *gateway {
+	return &gateway{
+		log:         log,
+		interceptor: interceptor,
+		methods:     make(map[string]gatewayMethod),
+	}
+}
+
+// RegisterService implements grpc.ServiceRegistrar so that the generated
+// Register*Server functions can be used to expose services on the gateway.
+func (g *gateway) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	for _, method := range desc.Methods {
+		g.methods["/"+desc.ServiceName+"/"+method.MethodName] = gatewayMethod{
+			impl: impl,
+			desc: method,
+		}
+	}
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := g.methods[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, gatewayMaxRequestBytes))
+	if err != nil {
+		g.writeError(w, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err))
+		return
+	}
+
+	ctx, err := gatewayContext(r)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	resp, err := method.desc.Handler(method.impl, ctx, func(in interface{}) error {
+		return decodeGatewayRequest(body, in)
+	}, g.interceptor)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		g.writeError(w, status.Errorf(codes.Internal, "unexpected response type %T", resp))
+		return
+	}
+	out, err := protojson.Marshal(msg)
+	if err != nil {
+		g.writeError(w, status.Errorf(codes.Internal, "failed to marshal response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(out); err != nil {
+		g.log.WithError(err).Debug("Failed to write API gateway response")
+	}
+}
+
+func (g *gateway) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	if err := json.NewEncoder(w).Encode(gatewayError{
+		Code:    st.Code(),
+		Message: st.Message(),
+	}); err != nil {
+		g.log.WithError(err).Debug("Failed to write API gateway response")
+	}
+}
+
+// gatewayContext returns a context carrying the peer information of the
+// HTTP caller, as the gRPC transport would, so that the caller is
+// authenticated by the middleware from its TLS client certificate.
+func gatewayContext(r *http.Request) (context.Context, error) {
+	addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid remote address %q: %v", r.RemoteAddr, err)
+	}
+	p := &peer.Peer{Addr: addr}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(r.Context(), p), nil
+}
+
+func decodeGatewayRequest(body []byte, in interface{}) error {
+	msg, ok := in.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected request type %T", in)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unmarshal request: %v", err)
+	}
+	return nil
+}
+
+// httpStatusFromCode maps a gRPC status code to the closest HTTP status.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return statusClientClosedRequest
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// runGatewayServer will start the API gateway and block until it exits or we
+// are dying.
+func (e *Endpoints) runGatewayServer(ctx context.Context, gw *gateway) error {
+	l, err := net.Listen(e.APIGatewayAddr.Network(), e.APIGatewayAddr.String())
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	server := &http.Server{
+		Handler: gw,
+	}
+
+	e.Log.WithField(telemetry.Address, l.Addr().String()).Info("Starting API gateway")
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(tls.NewListener(l, e.getGatewayTLSConfig(ctx))) }()
+
+	select {
+	case err = <-errChan:
+		e.Log.WithError(err).Error("API gateway stopped prematurely")
+		return err
+	case <-ctx.Done():
+		e.Log.Info("Stopping API gateway")
+		server.Close()
+		<-errChan
+		e.Log.Info("API gateway has stopped")
+		return nil
+	}
+}
+
+// getGatewayTLSConfig returns the TLS configuration of the API gateway. It
+// is the same as the one of the gRPC server, except that HTTP/1.1 clients
+// are also accepted.
+func (e *Endpoints) getGatewayTLSConfig(ctx context.Context) *tls.Config {
+	getTLSConfig := e.getTLSConfig(ctx)
+	return &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			config, err := getTLSConfig(hello)
+			if err != nil {
+				return nil, err
+			}
+			config.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+			return config, nil
+		},
+	}
+}
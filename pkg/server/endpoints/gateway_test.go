@@ -0,0 +1,156 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	bundlev1 "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestGateway(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		authErr      error
+		expectStatus int
+		expectBody   string
+		expectMethod string
+	}{
+		{
+			name:         "success",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/GetBundle",
+			body:         `{"outputMask": {"refreshHint": true}}`,
+			expectStatus: http.StatusOK,
+			expectBody:   `{"trustDomain":"domain.test","refreshHint":"60"}`,
+			expectMethod: "/spire.api.server.bundle.v1.Bundle/GetBundle",
+		},
+		{
+			name:         "empty body",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/GetBundle",
+			expectStatus: http.StatusOK,
+			expectBody:   `{"trustDomain":"domain.test"}`,
+			expectMethod: "/spire.api.server.bundle.v1.Bundle/GetBundle",
+		},
+		{
+			name:         "unknown method",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/Unknown",
+			expectStatus: http.StatusNotFound,
+			expectBody:   "404 page not found\n",
+		},
+		{
+			name:         "wrong HTTP method",
+			method:       http.MethodGet,
+			path:         "/spire.api.server.bundle.v1.Bundle/GetBundle",
+			expectStatus: http.StatusMethodNotAllowed,
+			expectBody:   "Method Not Allowed\n",
+		},
+		{
+			name:         "malformed request",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/GetBundle",
+			body:         `{"outputMask": 1}`,
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `"code":3`,
+			// Like gRPC, the request is decoded before the interceptor runs
+		},
+		{
+			name:         "unauthorized",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/GetBundle",
+			authErr:      status.Error(codes.PermissionDenied, "authorization denied"),
+			expectStatus: http.StatusForbidden,
+			expectBody:   `{"code":7,"message":"authorization denied"}` + "\n",
+			expectMethod: "/spire.api.server.bundle.v1.Bundle/GetBundle",
+		},
+		{
+			name:         "unimplemented",
+			method:       http.MethodPost,
+			path:         "/spire.api.server.bundle.v1.Bundle/ListFederatedBundles",
+			expectStatus: http.StatusNotImplemented,
+			expectBody:   `"code":12`,
+			expectMethod: "/spire.api.server.bundle.v1.Bundle/ListFederatedBundles",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var calledMethod string
+			var calledPeer *peer.Peer
+			interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				calledMethod = info.FullMethod
+				calledPeer, _ = peer.FromContext(ctx)
+				if tt.authErr != nil {
+					return nil, tt.authErr
+				}
+				return handler(ctx, req)
+			}
+
+			log, _ := test.NewNullLogger()
+			gw := newGateway(log, interceptor)
+			bundlev1.RegisterBundleServer(gw, fakeGatewayBundleServer{})
+
+			req := httptest.NewRequest(tt.method, "https://localhost"+tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			gw.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			if tt.expectStatus == http.StatusOK {
+				// protojson output is not stable, so compare the JSON values
+				assert.JSONEq(t, tt.expectBody, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), tt.expectBody)
+			}
+			assert.Equal(t, tt.expectMethod, calledMethod)
+			if tt.expectMethod != "" {
+				require.NotNil(t, calledPeer)
+				assert.Equal(t, "tcp", calledPeer.Addr.Network())
+				assert.Equal(t, req.RemoteAddr, calledPeer.Addr.String())
+				assert.IsType(t, credentials.TLSInfo{}, calledPeer.AuthInfo)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	assert.Equal(t, http.StatusOK, httpStatusFromCode(codes.OK))
+	assert.Equal(t, statusClientClosedRequest, httpStatusFromCode(codes.Canceled))
+	assert.Equal(t, http.StatusBadRequest, httpStatusFromCode(codes.InvalidArgument))
+	assert.Equal(t, http.StatusBadRequest, httpStatusFromCode(codes.FailedPrecondition))
+	assert.Equal(t, http.StatusGatewayTimeout, httpStatusFromCode(codes.DeadlineExceeded))
+	assert.Equal(t, http.StatusNotFound, httpStatusFromCode(codes.NotFound))
+	assert.Equal(t, http.StatusConflict, httpStatusFromCode(codes.AlreadyExists))
+	assert.Equal(t, http.StatusForbidden, httpStatusFromCode(codes.PermissionDenied))
+	assert.Equal(t, http.StatusUnauthorized, httpStatusFromCode(codes.Unauthenticated))
+	assert.Equal(t, http.StatusTooManyRequests, httpStatusFromCode(codes.ResourceExhausted))
+	assert.Equal(t, http.StatusNotImplemented, httpStatusFromCode(codes.Unimplemented))
+	assert.Equal(t, http.StatusServiceUnavailable, httpStatusFromCode(codes.Unavailable))
+	assert.Equal(t, http.StatusInternalServerError, httpStatusFromCode(codes.Internal))
+}
+
+type fakeGatewayBundleServer struct {
+	bundlev1.UnimplementedBundleServer
+}
+
+func (fakeGatewayBundleServer) GetBundle(ctx context.Context, req *bundlev1.GetBundleRequest) (*types.Bundle, error) {
+	bundle := &types.Bundle{TrustDomain: "domain.test"}
+	if req.OutputMask != nil && req.OutputMask.RefreshHint {
+		bundle.RefreshHint = 60
+	}
+	return bundle, nil
+}
@@ -34,6 +34,7 @@ func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.
 	return middleware.Chain(
 		middleware.WithLogger(log),
 		middleware.WithMetrics(metrics),
+		middleware.WithCallerMetadata(),
 		middleware.WithAuthorization(Authorization(log, ds, clk)),
 		middleware.WithRateLimits(RateLimits(rlConf)),
 	)
@@ -59,15 +60,20 @@ func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clo
 		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                     agent,
 		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":            downstream,
 		"/spire.api.server.bundle.v1.Bundle/GetBundle":                  any,
+		"/spire.api.server.bundle.v1.Bundle/WatchBundle":                any,
 		"/spire.api.server.bundle.v1.Bundle/AppendBundle":               localOrAdmin,
 		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":        downstream,
 		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":       localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/CountBundles":               localOrAdmin,
 		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":         localOrAdminOrAgent,
 		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle": localOrAdmin,
 		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle": localOrAdmin,
 		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":    localOrAdmin,
 		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle": localOrAdmin,
 		"/spire.api.server.debug.v1.Debug/GetInfo":                      local,
+		"/spire.api.server.debug.v1.Debug/GetLogLevels":                 local,
+		"/spire.api.server.debug.v1.Debug/SetLogLevel":                  local,
+		"/spire.api.server.info.v1.Info/GetBuildInfo":                   any,
 		"/spire.api.server.entry.v1.Entry/ListEntries":                  localOrAdmin,
 		"/spire.api.server.entry.v1.Entry/GetEntry":                     localOrAdmin,
 		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":             localOrAdmin,
@@ -81,6 +87,8 @@ func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clo
 		"/spire.api.server.agent.v1.Agent/AttestAgent":                  any,
 		"/spire.api.server.agent.v1.Agent/RenewAgent":                   agent,
 		"/spire.api.server.agent.v1.Agent/CreateJoinToken":              localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/ListJoinTokens":               localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/PruneJoinTokens":              localOrAdmin,
 		"/grpc.health.v1.Health/Check":                                  local,
 		"/grpc.health.v1.Health/Watch":                                  local,
 	}
@@ -200,6 +208,10 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 	csrLimit := middleware.PerIPLimit(node_pb.CSRLimit)
 	jsrLimit := middleware.PerIPLimit(node_pb.JSRLimit)
 	pushJWTKeyLimit := middleware.PerIPLimit(node_pb.PushJWTKeyLimit)
+	bundleLimit := middleware.DisabledLimit()
+	if config.Bundle > 0 {
+		bundleLimit = middleware.PerCallerLimit(config.Bundle)
+	}
 
 	return map[string]api.RateLimiter{
 		"/spire.api.server.svid.v1.SVID/MintX509SVID":                   noLimit,
@@ -207,16 +219,21 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":               csrLimit,
 		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                     jsrLimit,
 		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":            csrLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                  noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                  bundleLimit,
+		"/spire.api.server.bundle.v1.Bundle/WatchBundle":                bundleLimit,
 		"/spire.api.server.bundle.v1.Bundle/AppendBundle":               noLimit,
 		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":        pushJWTKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":       noLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":         noLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":       bundleLimit,
+		"/spire.api.server.bundle.v1.Bundle/CountBundles":               noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":         bundleLimit,
 		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle": noLimit,
 		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle": noLimit,
 		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":    noLimit,
 		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle": noLimit,
 		"/spire.api.server.debug.v1.Debug/GetInfo":                      noLimit,
+		"/spire.api.server.debug.v1.Debug/GetLogLevels":                 noLimit,
+		"/spire.api.server.debug.v1.Debug/SetLogLevel":                  noLimit,
+		"/spire.api.server.info.v1.Info/GetBuildInfo":                   noLimit,
 		"/spire.api.server.entry.v1.Entry/ListEntries":                  noLimit,
 		"/spire.api.server.entry.v1.Entry/GetEntry":                     noLimit,
 		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":             noLimit,
@@ -230,11 +247,29 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 		"/spire.api.server.agent.v1.Agent/AttestAgent":                  attestLimit,
 		"/spire.api.server.agent.v1.Agent/RenewAgent":                   csrLimit,
 		"/spire.api.server.agent.v1.Agent/CreateJoinToken":              noLimit,
+		"/spire.api.server.agent.v1.Agent/ListJoinTokens":               noLimit,
+		"/spire.api.server.agent.v1.Agent/PruneJoinTokens":              noLimit,
 		"/grpc.health.v1.Health/Check":                                  noLimit,
 		"/grpc.health.v1.Health/Watch":                                  noLimit,
 	}
 }
 
+// StreamLimits returns the per-caller concurrent stream limits for the
+// streaming methods used by agents, and for the bundle watch. Both the
+// deprecated and the new APIs are covered since agents may use either.
+func StreamLimits(config RateLimitConfig) map[string]int {
+	if config.StreamsPerCaller <= 0 {
+		return nil
+	}
+
+	return map[string]int{
+		"/spire.api.node.Node/Attest":                    config.StreamsPerCaller,
+		"/spire.api.node.Node/FetchX509SVID":             config.StreamsPerCaller,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":   config.StreamsPerCaller,
+		"/spire.api.server.bundle.v1.Bundle/WatchBundle": config.StreamsPerCaller,
+	}
+}
+
 func unaryInterceptorMux(oldInterceptor, newInterceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if !isOldAPI(info.FullMethod) {
@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -27,6 +28,27 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+func TestBundleRateLimits(t *testing.T) {
+	bundleReads := []string{
+		"/spire.api.server.bundle.v1.Bundle/GetBundle",
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle",
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles",
+	}
+
+	// Bundle reads are not limited by default
+	limits := RateLimits(RateLimitConfig{})
+	for _, method := range bundleReads {
+		assert.Equal(t, middleware.DisabledLimit(), limits[method], method)
+	}
+
+	// When configured, the limit is per caller and shared by the bundle reads
+	limits = RateLimits(RateLimitConfig{Bundle: 10})
+	for _, method := range bundleReads {
+		assert.IsType(t, middleware.PerCallerLimit(10), limits[method], method)
+		assert.Same(t, limits[bundleReads[0]], limits[method], method)
+	}
+}
+
 type testEntries struct {
 	nodeAliasEntries []*types.Entry
 	workloadEntries  []*types.Entry
@@ -22,6 +22,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
@@ -774,9 +775,13 @@ func (h *Handler) attestToken(ctx context.Context, attestationData *common.Attes
 	if t.Token == "" {
 		return nil, errors.New("invalid join token")
 	}
+	if t.UsedAt != 0 {
+		return nil, errors.New("join token does not exist or has already been used")
+	}
 
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
+	_, err = ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token:  tokenValue,
+		UsedAt: h.c.Clock.Now().Unix(),
 	})
 	if err != nil {
 		return nil, err
@@ -993,10 +998,11 @@ func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries
 	}
 
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
-		SpiffeID:  csr.SpiffeID,
-		PublicKey: csr.PublicKey,
-		TTL:       time.Duration(entry.Ttl) * time.Second,
-		DNSList:   entry.DnsNames,
+		SpiffeID:    csr.SpiffeID,
+		PublicKey:   csr.PublicKey,
+		TTL:         time.Duration(entry.Ttl) * time.Second,
+		DNSList:     entry.DnsNames,
+		PrimaryName: x509svid.PrimaryName(entry.X509SvidPrimaryName),
 	})
 	if err != nil {
 		return nil, err
@@ -498,8 +498,8 @@ func (s *HandlerSuite) TestAttestWithExpiredJoinToken() {
 		Csr:             s.makeCSR(joinTokenID),
 	}, codes.Unknown, "failed to attest: join token expired")
 
-	// join token should be removed from the datastore even if attestation failed
-	s.Nil(s.fetchJoinToken("TOKEN"))
+	// join token should be marked as used even if attestation failed
+	s.Equal(s.clock.Now().Unix(), s.fetchJoinToken("TOKEN").UsedAt)
 
 	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
 }
@@ -511,8 +511,8 @@ func (s *HandlerSuite) TestAttestWithValidJoinToken() {
 		Csr:             s.makeCSR(joinTokenID),
 	}, joinTokenID)
 
-	// join token should be removed for successful attestation
-	s.Nil(s.fetchJoinToken("TOKEN"))
+	// join token should be marked as used for successful attestation
+	s.Equal(s.clock.Now().Unix(), s.fetchJoinToken("TOKEN").UsedAt)
 
 	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
 }
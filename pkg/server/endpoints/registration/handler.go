@@ -799,6 +799,9 @@ func (h *Handler) createRegistrationEntry(ctx context.Context, requestedEntry *c
 	if err != nil {
 		return nil, false, status.Error(codes.InvalidArgument, err.Error())
 	}
+	// Entry IDs are assigned by the datastore; only the Entry API accepts
+	// caller-supplied IDs.
+	requestedEntry.EntryId = ""
 
 	ds := h.getDataStore()
 
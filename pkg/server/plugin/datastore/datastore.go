@@ -14,10 +14,15 @@ import (
 
 type AppendBundleRequest = datastore.AppendBundleRequest                           //nolint: golint
 type AppendBundleResponse = datastore.AppendBundleResponse                         //nolint: golint
+type BatchRegistrationEntriesRequest = datastore.BatchRegistrationEntriesRequest   //nolint: golint
+type BatchRegistrationEntriesResponse = datastore.BatchRegistrationEntriesResponse //nolint: golint
+type BundleLimits = datastore.BundleLimits                                         //nolint: golint
 type ByFederatesWith = datastore.ByFederatesWith                                   //nolint: golint
 type ByFederatesWith_MatchBehavior = datastore.ByFederatesWith_MatchBehavior       //nolint: golint
 type BySelectors = datastore.BySelectors                                           //nolint: golint
 type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior               //nolint: golint
+type CheckIntegrityRequest = datastore.CheckIntegrityRequest                       //nolint: golint
+type CheckIntegrityResponse = datastore.CheckIntegrityResponse                     //nolint: golint
 type CountAttestedNodesRequest = datastore.CountAttestedNodesRequest               //nolint: golint
 type CountAttestedNodesResponse = datastore.CountAttestedNodesResponse             //nolint: golint
 type CountBundlesRequest = datastore.CountBundlesRequest                           //nolint: golint
@@ -53,11 +58,15 @@ type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest
 type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse     //nolint: golint
 type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                   //nolint: golint
 type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                 //nolint: golint
+type IntegrityIssue = datastore.IntegrityIssue                                     //nolint: golint
+type IntegrityIssue_Kind = datastore.IntegrityIssue_Kind                           //nolint: golint
 type JoinToken = datastore.JoinToken                                               //nolint: golint
 type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                 //nolint: golint
 type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse               //nolint: golint
 type ListBundlesRequest = datastore.ListBundlesRequest                             //nolint: golint
 type ListBundlesResponse = datastore.ListBundlesResponse                           //nolint: golint
+type ListJoinTokensRequest = datastore.ListJoinTokensRequest                       //nolint: golint
+type ListJoinTokensResponse = datastore.ListJoinTokensResponse                     //nolint: golint
 type ListNodeSelectorsRequest = datastore.ListNodeSelectorsRequest                 //nolint: golint
 type ListNodeSelectorsResponse = datastore.ListNodeSelectorsResponse               //nolint: golint
 type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest     //nolint: golint
@@ -70,6 +79,7 @@ type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest
 type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                   //nolint: golint
 type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest   //nolint: golint
 type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse //nolint: golint
+type RegistrationEntryOperation = datastore.RegistrationEntryOperation             //nolint: golint
 type SetBundleRequest = datastore.SetBundleRequest                                 //nolint: golint
 type SetBundleResponse = datastore.SetBundleResponse                               //nolint: golint
 type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                   //nolint: golint
@@ -82,21 +92,30 @@ type UpdateBundleRequest = datastore.UpdateBundleRequest
 type UpdateBundleResponse = datastore.UpdateBundleResponse                         //nolint: golint
 type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest     //nolint: golint
 type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse   //nolint: golint
+type UseJoinTokenRequest = datastore.UseJoinTokenRequest                           //nolint: golint
+type UseJoinTokenResponse = datastore.UseJoinTokenResponse                         //nolint: golint
 
 const (
-	Type                           = "DataStore"
-	ByFederatesWith_MATCH_EXACT    = datastore.ByFederatesWith_MATCH_EXACT    //nolint: golint
-	ByFederatesWith_MATCH_SUBSET   = datastore.ByFederatesWith_MATCH_SUBSET   //nolint: golint
-	BySelectors_MATCH_EXACT        = datastore.BySelectors_MATCH_EXACT        //nolint: golint
-	BySelectors_MATCH_SUBSET       = datastore.BySelectors_MATCH_SUBSET       //nolint: golint
-	DeleteBundleRequest_DELETE     = datastore.DeleteBundleRequest_DELETE     //nolint: golint
-	DeleteBundleRequest_DISSOCIATE = datastore.DeleteBundleRequest_DISSOCIATE //nolint: golint
-	DeleteBundleRequest_RESTRICT   = datastore.DeleteBundleRequest_RESTRICT   //nolint: golint
+	Type                               = "DataStore"
+	ByFederatesWith_MATCH_ANY          = datastore.ByFederatesWith_MATCH_ANY          //nolint: golint
+	ByFederatesWith_MATCH_EXACT        = datastore.ByFederatesWith_MATCH_EXACT        //nolint: golint
+	ByFederatesWith_MATCH_SUBSET       = datastore.ByFederatesWith_MATCH_SUBSET       //nolint: golint
+	BySelectors_MATCH_EXACT            = datastore.BySelectors_MATCH_EXACT            //nolint: golint
+	BySelectors_MATCH_SUBSET           = datastore.BySelectors_MATCH_SUBSET           //nolint: golint
+	DeleteBundleRequest_DELETE         = datastore.DeleteBundleRequest_DELETE         //nolint: golint
+	DeleteBundleRequest_DISSOCIATE     = datastore.DeleteBundleRequest_DISSOCIATE     //nolint: golint
+	DeleteBundleRequest_RESTRICT       = datastore.DeleteBundleRequest_RESTRICT       //nolint: golint
+	IntegrityIssue_DANGLING_FEDERATION = datastore.IntegrityIssue_DANGLING_FEDERATION //nolint: golint
+	IntegrityIssue_NODE_WITHOUT_SERIAL = datastore.IntegrityIssue_NODE_WITHOUT_SERIAL //nolint: golint
+	IntegrityIssue_ORPHANED_DNS_NAME   = datastore.IntegrityIssue_ORPHANED_DNS_NAME   //nolint: golint
+	IntegrityIssue_ORPHANED_SELECTOR   = datastore.IntegrityIssue_ORPHANED_SELECTOR   //nolint: golint
 )
 
 // DataStore is the client interface for the service type DataStore interface.
 type DataStore interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	BatchRegistrationEntries(context.Context, *BatchRegistrationEntriesRequest) (*BatchRegistrationEntriesResponse, error)
+	CheckIntegrity(context.Context, *CheckIntegrityRequest) (*CheckIntegrityResponse, error)
 	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
 	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
 	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
@@ -115,6 +134,7 @@ type DataStore interface {
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
@@ -125,11 +145,14 @@ type DataStore interface {
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
 type Plugin interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	BatchRegistrationEntries(context.Context, *BatchRegistrationEntriesRequest) (*BatchRegistrationEntriesResponse, error)
+	CheckIntegrity(context.Context, *CheckIntegrityRequest) (*CheckIntegrityResponse, error)
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
 	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
 	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
@@ -150,6 +173,7 @@ type Plugin interface {
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
@@ -160,6 +184,7 @@ type Plugin interface {
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // PluginServer returns a catalog PluginServer implementation for the DataStore plugin.
@@ -211,6 +236,14 @@ func (a pluginClientAdapter) AppendBundle(ctx context.Context, in *AppendBundleR
 	return a.client.AppendBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) BatchRegistrationEntries(ctx context.Context, in *BatchRegistrationEntriesRequest) (*BatchRegistrationEntriesResponse, error) {
+	return a.client.BatchRegistrationEntries(ctx, in)
+}
+
+func (a pluginClientAdapter) CheckIntegrity(ctx context.Context, in *CheckIntegrityRequest) (*CheckIntegrityResponse, error) {
+	return a.client.CheckIntegrity(ctx, in)
+}
+
 func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return a.client.Configure(ctx, in)
 }
@@ -291,6 +324,10 @@ func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesReq
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return a.client.ListJoinTokens(ctx, in)
+}
+
 func (a pluginClientAdapter) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
 	return a.client.ListNodeSelectors(ctx, in)
 }
@@ -330,3 +367,7 @@ func (a pluginClientAdapter) UpdateBundle(ctx context.Context, in *UpdateBundleR
 func (a pluginClientAdapter) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return a.client.UpdateRegistrationEntry(ctx, in)
 }
+
+func (a pluginClientAdapter) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return a.client.UseJoinToken(ctx, in)
+}
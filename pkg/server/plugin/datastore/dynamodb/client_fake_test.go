@@ -0,0 +1,223 @@
+package dynamodb
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueryPageSize is deliberately small so that the plugin has to follow
+// LastEvaluatedKey across query pages.
+const fakeQueryPageSize = 2
+
+type item = map[string]*dynamodb.AttributeValue
+
+// clientFake is an in-memory implementation of the subset of the DynamoDB
+// API used by the plugin. It only understands the expressions the plugin
+// issues.
+type clientFake struct {
+	t         *testing.T
+	tableName string
+
+	mu    sync.Mutex
+	items map[string]item
+
+	// conflicts is the number of upcoming writes conditioned on the record
+	// version that fail as if the record had been concurrently modified.
+	conflicts int
+}
+
+func newClientFake(t *testing.T, tableName string) *clientFake {
+	return &clientFake{
+		t:         t,
+		tableName: tableName,
+		items:     make(map[string]item),
+	}
+}
+
+func (f *clientFake) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput, opts ...request.Option) (*dynamodb.GetItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requireTable(input.TableName)
+	return &dynamodb.GetItemOutput{
+		Item: copyItem(f.items[f.keyOf(input.Key)]),
+	}, nil
+}
+
+func (f *clientFake) PutItemWithContext(ctx aws.Context, input *dynamodb.PutItemInput, opts ...request.Option) (*dynamodb.PutItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requireTable(input.TableName)
+	key := f.keyOf(input.Item)
+	if err := f.checkCondition(input.ConditionExpression, input.ExpressionAttributeValues, key); err != nil {
+		return nil, err
+	}
+	f.items[key] = copyItem(input.Item)
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *clientFake) DeleteItemWithContext(ctx aws.Context, input *dynamodb.DeleteItemInput, opts ...request.Option) (*dynamodb.DeleteItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requireTable(input.TableName)
+	key := f.keyOf(input.Key)
+	if err := f.checkCondition(input.ConditionExpression, input.ExpressionAttributeValues, key); err != nil {
+		return nil, err
+	}
+
+	out := &dynamodb.DeleteItemOutput{}
+	if aws.StringValue(input.ReturnValues) == dynamodb.ReturnValueAllOld {
+		out.Attributes = copyItem(f.items[key])
+	}
+	delete(f.items, key)
+	return out, nil
+}
+
+func (f *clientFake) QueryWithContext(ctx aws.Context, input *dynamodb.QueryInput, opts ...request.Option) (*dynamodb.QueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requireTable(input.TableName)
+	require.Equal(f.t, kindIndexName, aws.StringValue(input.IndexName))
+	require.Equal(f.t, keyConditionKind, aws.StringValue(input.KeyConditionExpression))
+	kind := aws.StringValue(input.ExpressionAttributeValues[":kind"].S)
+
+	var after string
+	if input.ExclusiveStartKey != nil {
+		require.Equal(f.t, kind, aws.StringValue(input.ExclusiveStartKey[attrKind].S))
+		after = f.keyOf(input.ExclusiveStartKey)
+	}
+
+	var keys []string
+	for key, item := range f.items {
+		if aws.StringValue(item[attrKind].S) == kind && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	out := &dynamodb.QueryOutput{}
+	for _, key := range keys {
+		if len(out.Items) == fakeQueryPageSize {
+			last := out.Items[len(out.Items)-1]
+			out.LastEvaluatedKey = item{
+				attrPK:   last[attrPK],
+				attrKind: last[attrKind],
+			}
+			break
+		}
+		out.Items = append(out.Items, copyItem(f.items[key]))
+	}
+	return out, nil
+}
+
+func (f *clientFake) TransactWriteItemsWithContext(ctx aws.Context, input *dynamodb.TransactWriteItemsInput, opts ...request.Option) (*dynamodb.TransactWriteItemsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Check every condition before applying any of the writes
+	seen := make(map[string]bool)
+	for i, transactItem := range input.TransactItems {
+		var key string
+		var condition *string
+		var values item
+		switch {
+		case transactItem.Put != nil:
+			f.requireTable(transactItem.Put.TableName)
+			key = f.keyOf(transactItem.Put.Item)
+			condition = transactItem.Put.ConditionExpression
+			values = transactItem.Put.ExpressionAttributeValues
+		case transactItem.Delete != nil:
+			f.requireTable(transactItem.Delete.TableName)
+			key = f.keyOf(transactItem.Delete.Key)
+			condition = transactItem.Delete.ConditionExpression
+			values = transactItem.Delete.ExpressionAttributeValues
+		default:
+			require.FailNow(f.t, "unsupported transaction item")
+		}
+		require.False(f.t, seen[key], "transaction writes %q more than once", key)
+		seen[key] = true
+		if err := f.checkCondition(condition, values, key); err != nil {
+			// Like DynamoDB, report a reason for every write, in order
+			reasons := make([]*dynamodb.CancellationReason, len(input.TransactItems))
+			for j := range reasons {
+				reasons[j] = &dynamodb.CancellationReason{Code: aws.String("None")}
+			}
+			reasons[i].Code = aws.String(reasonConditionalCheckFailed)
+			return nil, &dynamodb.TransactionCanceledException{
+				Message_:            aws.String("Transaction cancelled"),
+				CancellationReasons: reasons,
+			}
+		}
+	}
+
+	for _, transactItem := range input.TransactItems {
+		if transactItem.Put != nil {
+			f.items[f.keyOf(transactItem.Put.Item)] = copyItem(transactItem.Put.Item)
+		} else {
+			delete(f.items, f.keyOf(transactItem.Delete.Key))
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func (f *clientFake) requireTable(tableName *string) {
+	require.Equal(f.t, f.tableName, aws.StringValue(tableName))
+}
+
+func (f *clientFake) keyOf(item item) string {
+	pk := item[attrPK]
+	require.NotNil(f.t, pk, "item is missing the partition key")
+	return aws.StringValue(pk.S)
+}
+
+func (f *clientFake) checkCondition(condition *string, values item, key string) error {
+	existing, exists := f.items[key]
+	switch aws.StringValue(condition) {
+	case "":
+		return nil
+	case conditionExists:
+		if exists {
+			return nil
+		}
+	case conditionNotExists:
+		if !exists {
+			return nil
+		}
+	case conditionVersion:
+		if f.conflicts > 0 {
+			f.conflicts--
+			break
+		}
+		if exists && aws.StringValue(existing[attrVersion].N) == aws.StringValue(values[":version"].N) {
+			return nil
+		}
+	default:
+		require.FailNow(f.t, "unsupported condition expression", aws.StringValue(condition))
+	}
+	return awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "The conditional request failed", nil)
+}
+
+func copyItem(in item) item {
+	if in == nil {
+		return nil
+	}
+	out := make(item, len(in))
+	for name, value := range in {
+		v := *value
+		if value.B != nil {
+			v.B = append([]byte(nil), value.B...)
+		}
+		out[name] = &v
+	}
+	return out
+}
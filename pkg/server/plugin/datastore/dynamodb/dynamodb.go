@@ -0,0 +1,1698 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	pluginInfo = spi.GetPluginInfoResponse{
+		Description: "",
+		DateCreated: "",
+		Version:     "",
+		Author:      "",
+		Company:     "",
+	}
+
+	dynamoError = errs.Class("datastore-dynamodb")
+)
+
+const (
+	PluginName = "dynamodb"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(PluginName,
+		datastore.PluginServer(p),
+	)
+}
+
+type configuration struct {
+	TableName string `hcl:"table_name" json:"table_name"`
+	Region    string `hcl:"region" json:"region"`
+	Endpoint  string `hcl:"endpoint" json:"endpoint"`
+}
+
+// Plugin is a DataStore plugin implemented via an AWS DynamoDB table
+type Plugin struct {
+	datastore.UnsafeDataStoreServer
+
+	mu    sync.RWMutex
+	table *table
+	log   hclog.Logger
+
+	hooks struct {
+		newClient func(config *configuration) (Client, error)
+	}
+}
+
+// New creates a new dynamodb plugin struct. Configure must be called
+// in order to set up the DynamoDB client.
+func New() *Plugin {
+	return newPlugin(newClient)
+}
+
+func newPlugin(newClient func(config *configuration) (Client, error)) *Plugin {
+	p := &Plugin{}
+	p.hooks.newClient = newClient
+	return p
+}
+
+func (ds *Plugin) SetLogger(logger hclog.Logger) {
+	ds.log = logger
+}
+
+// Configure parses HCL config payload into config struct, and creates the
+// DynamoDB client based on the result
+func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := &configuration{}
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, err
+	}
+
+	if config.TableName == "" {
+		return nil, dynamoError.New("table_name must be set")
+	}
+
+	client, err := ds.hooks.newClient(config)
+	if err != nil {
+		return nil, dynamoError.New("unable to create DynamoDB client: %v", err)
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.table = &table{
+		client: client,
+		name:   config.TableName,
+	}
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &pluginInfo, nil
+}
+
+func (ds *Plugin) getTable() (*table, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	if ds.table == nil {
+		return nil, status.Error(codes.FailedPrecondition, "datastore-dynamodb: not configured")
+	}
+	return ds.table, nil
+}
+
+// CreateBundle stores the given bundle
+func (ds *Plugin) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return createBundle(ctx, t, req)
+}
+
+// UpdateBundle updates an existing bundle with the given CAs. Overwrites any
+// existing certificates.
+func (ds *Plugin) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return updateBundle(ctx, t, req)
+}
+
+// SetBundle sets bundle contents. If no bundle exists for the trust domain, it is created.
+func (ds *Plugin) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return setBundle(ctx, t, req)
+}
+
+// AppendBundle append bundle contents to the existing bundle (by trust domain). If no existing one is present, create it.
+func (ds *Plugin) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return appendBundle(ctx, t, req)
+}
+
+// DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
+func (ds *Plugin) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return deleteBundle(ctx, t, req)
+}
+
+// FetchBundle returns the bundle matching the specified Trust Domain.
+func (ds *Plugin) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return fetchBundle(ctx, t, req)
+}
+
+// CountBundles can be used to count all existing bundles.
+func (ds *Plugin) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (*datastore.CountBundlesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	count, err := t.count(ctx, kindBundle)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.CountBundlesResponse{Bundles: count}, nil
+}
+
+// ListBundles can be used to fetch all existing bundles.
+func (ds *Plugin) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return listBundles(ctx, t, req)
+}
+
+// PruneBundle removes expired certs and keys from a bundle
+func (ds *Plugin) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return pruneBundle(ctx, t, req, ds.log)
+}
+
+// CreateAttestedNode stores the given attested node
+func (ds *Plugin) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return createAttestedNode(ctx, t, req)
+}
+
+// FetchAttestedNode fetches an existing attested node by SPIFFE ID
+func (ds *Plugin) FetchAttestedNode(ctx context.Context, req *datastore.FetchAttestedNodeRequest) (*datastore.FetchAttestedNodeResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	node := new(common.AttestedNode)
+	_, ok, err := t.get(ctx, kindNode, req.SpiffeId, node)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchAttestedNodeResponse{}, nil
+	}
+	return &datastore.FetchAttestedNodeResponse{Node: node}, nil
+}
+
+// CountAttestedNodes counts all attested nodes
+func (ds *Plugin) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	count, err := t.count(ctx, kindNode)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.CountAttestedNodesResponse{Nodes: count}, nil
+}
+
+// ListAttestedNodes lists all attested nodes (pagination available)
+func (ds *Plugin) ListAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return listAttestedNodes(ctx, t, req)
+}
+
+// UpdateAttestedNode updates the given node's cert serial and expiration.
+func (ds *Plugin) UpdateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return updateAttestedNode(ctx, t, req)
+}
+
+// DeleteAttestedNode deletes the given attested node
+func (ds *Plugin) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	node := new(common.AttestedNode)
+	ok, err := t.delete(ctx, kindNode, req.SpiffeId, node)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.NotFound, "datastore-dynamodb: attested node %q not found", req.SpiffeId)
+	}
+	return &datastore.DeleteAttestedNodeResponse{Node: node}, nil
+}
+
+// SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
+func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	if req.Selectors == nil {
+		return nil, dynamoError.New("invalid request: missing selectors")
+	}
+
+	// Nodes without selectors have no record so they are not listed
+	if len(req.Selectors.Selectors) == 0 {
+		if _, err := t.delete(ctx, kindNodeSelectors, req.Selectors.SpiffeId, nil); err != nil {
+			return nil, err
+		}
+		return &datastore.SetNodeSelectorsResponse{}, nil
+	}
+
+	err = retryConflicts(ctx, func() error {
+		version, ok, err := t.get(ctx, kindNodeSelectors, req.Selectors.SpiffeId, new(datastore.NodeSelectors))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return createRecord(ctx, t, kindNodeSelectors, req.Selectors.SpiffeId, req.Selectors)
+		}
+		return updateRecord(ctx, t, kindNodeSelectors, req.Selectors.SpiffeId, version, req.Selectors)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.SetNodeSelectorsResponse{}, nil
+}
+
+// GetNodeSelectors gets node (agent) selectors by SPIFFE ID
+func (ds *Plugin) GetNodeSelectors(ctx context.Context, req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	selectors, err := getNodeSelectors(ctx, t, req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.GetNodeSelectorsResponse{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  req.SpiffeId,
+			Selectors: selectors,
+		},
+	}, nil
+}
+
+// ListNodeSelectors gets node (agent) selectors by SPIFFE ID
+func (ds *Plugin) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return listNodeSelectors(ctx, t, req)
+}
+
+// CreateRegistrationEntry stores the given registration entry
+func (ds *Plugin) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return createRegistrationEntry(ctx, t, req)
+}
+
+// FetchRegistrationEntry fetches an existing registration by entry ID
+func (ds *Plugin) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (*datastore.FetchRegistrationEntryResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	entry := new(common.RegistrationEntry)
+	_, ok, err := t.get(ctx, kindEntry, req.EntryId, entry)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchRegistrationEntryResponse{}, nil
+	}
+	return &datastore.FetchRegistrationEntryResponse{Entry: entry}, nil
+}
+
+// CountRegistrationEntries counts all registrations (pagination available)
+func (ds *Plugin) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	count, err := t.count(ctx, kindEntry)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.CountRegistrationEntriesResponse{Entries: count}, nil
+}
+
+// ListRegistrationEntries lists all registrations (pagination available)
+func (ds *Plugin) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return listRegistrationEntries(ctx, t, req)
+}
+
+// UpdateRegistrationEntry updates an existing registration entry
+func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return updateRegistrationEntry(ctx, t, req)
+}
+
+// DeleteRegistrationEntry deletes the given registration
+func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	entry := new(common.RegistrationEntry)
+	ok, err := t.delete(ctx, kindEntry, req.EntryId, entry)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.NotFound, "datastore-dynamodb: registration entry %q not found", req.EntryId)
+	}
+	return &datastore.DeleteRegistrationEntryResponse{Entry: entry}, nil
+}
+
+// PruneRegistrationEntries takes a registration entry message, and deletes all entries which have expired
+// before the date in the message
+func (ds *Plugin) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	err = prune(ctx, t, kindEntry, new(common.RegistrationEntry), func(msg proto.Message) bool {
+		entry := msg.(*common.RegistrationEntry)
+		return entry.EntryExpiry != 0 && entry.EntryExpiry < req.ExpiresBefore
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.PruneRegistrationEntriesResponse{}, nil
+}
+
+// BatchRegistrationEntries applies the given registration entry operations in
+// a single transaction. Either all of the operations are applied or none.
+func (ds *Plugin) BatchRegistrationEntries(ctx context.Context, req *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return batchRegistrationEntries(ctx, t, req)
+}
+
+// CreateJoinToken takes a Token message and stores it
+func (ds *Plugin) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	if req.JoinToken == nil || req.JoinToken.Token == "" || req.JoinToken.Expiry == 0 {
+		return nil, dynamoError.New("token and expiry are required")
+	}
+
+	ok, err := t.create(ctx, kindJoinToken, req.JoinToken.Token, req.JoinToken)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Error(codes.AlreadyExists, "datastore-dynamodb: join token already exists")
+	}
+	return &datastore.CreateJoinTokenResponse{JoinToken: req.JoinToken}, nil
+}
+
+// FetchJoinToken takes a Token message and returns one, populating the fields
+// we have knowledge of
+func (ds *Plugin) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	token := new(datastore.JoinToken)
+	_, ok, err := t.get(ctx, kindJoinToken, req.Token, token)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchJoinTokenResponse{}, nil
+	}
+	return &datastore.FetchJoinTokenResponse{JoinToken: token}, nil
+}
+
+// DeleteJoinToken deletes the matching join token
+func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	token := new(datastore.JoinToken)
+	ok, err := t.delete(ctx, kindJoinToken, req.Token, token)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Error(codes.NotFound, "datastore-dynamodb: join token not found")
+	}
+	return &datastore.DeleteJoinTokenResponse{JoinToken: token}, nil
+}
+
+// UseJoinToken marks the matching join token as used. It fails if the token
+// does not exist or has already been used.
+func (ds *Plugin) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	if req.UsedAt == 0 {
+		return nil, dynamoError.New("used at is required")
+	}
+
+	// A conflict means the token was concurrently used or deleted, after
+	// which the next attempt fails, so a single retry is ever needed.
+	token := new(datastore.JoinToken)
+	err = retryConflicts(ctx, func() error {
+		version, ok, err := t.get(ctx, kindJoinToken, req.Token, token)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return status.Error(codes.NotFound, "datastore-dynamodb: join token not found")
+		case token.UsedAt != 0:
+			return status.Error(codes.FailedPrecondition, "datastore-dynamodb: join token has already been used")
+		}
+
+		token.UsedAt = req.UsedAt
+		return updateRecord(ctx, t, kindJoinToken, req.Token, version, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.UseJoinTokenResponse{JoinToken: token}, nil
+}
+
+// ListJoinTokens lists join tokens (optionally filtered and paginated)
+func (ds *Plugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	resp := new(datastore.ListJoinTokensResponse)
+	pagination, err := t.list(ctx, kindJoinToken, req.Pagination, func(data []byte) (bool, error) {
+		token := new(datastore.JoinToken)
+		if err := proto.Unmarshal(data, token); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+		if req.ByExpiresBefore != nil && token.Expiry >= req.ByExpiresBefore.Value {
+			return false, nil
+		}
+		if req.ByExpiresAtOrAfter != nil && token.Expiry < req.ByExpiresAtOrAfter.Value {
+			return false, nil
+		}
+		if req.ByUsed != nil && (token.UsedAt != 0) != req.ByUsed.Value {
+			return false, nil
+		}
+		resp.JoinTokens = append(resp.JoinTokens, token)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Pagination = pagination
+	return resp, nil
+}
+
+// PruneJoinTokens takes a Token message, and deletes all tokens which have expired
+// before the date in the message
+func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	err = prune(ctx, t, kindJoinToken, new(datastore.JoinToken), func(msg proto.Message) bool {
+		return msg.(*datastore.JoinToken).Expiry < req.ExpiresBefore
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.PruneJoinTokensResponse{}, nil
+}
+
+// CheckIntegrity looks for records left inconsistent by interrupted writes
+// and, if asked to, repairs them
+func (ds *Plugin) CheckIntegrity(ctx context.Context, req *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	t, err := ds.getTable()
+	if err != nil {
+		return nil, err
+	}
+	return checkIntegrity(ctx, t, req)
+}
+
+func createBundle(ctx context.Context, t *table, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	id, err := bundleID(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := t.create(ctx, kindBundle, id, req.Bundle)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.AlreadyExists, "datastore-dynamodb: bundle %q already exists", id)
+	}
+
+	return &datastore.CreateBundleResponse{
+		Bundle: req.Bundle,
+	}, nil
+}
+
+func updateBundle(ctx context.Context, t *table, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	id, err := bundleID(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := new(common.Bundle)
+	err = retryConflicts(ctx, func() error {
+		version, ok, err := t.get(ctx, kindBundle, id, bundle)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return status.Errorf(codes.NotFound, "datastore-dynamodb: bundle %q not found", id)
+		}
+
+		applyBundleMask(bundle, req.Bundle, req.InputMask)
+		if err := checkBundleLimits(bundle, req.Limits); err != nil {
+			return err
+		}
+		return updateRecord(ctx, t, kindBundle, id, version, bundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+// updateRecord replaces the record of the given kind and identifier with
+// msg, as long as the stored record is still at the given version, and fails
+// with errConflict otherwise.
+func updateRecord(ctx context.Context, t *table, kind, id string, version int64, msg proto.Message) error {
+	ok, err := t.update(ctx, kind, id, version, msg)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return errConflict
+	}
+	return nil
+}
+
+func applyBundleMask(bundle, newBundle *common.Bundle, inputMask *common.BundleMask) {
+	if inputMask == nil {
+		inputMask = protoutil.AllTrueCommonBundleMask
+	}
+
+	if inputMask.RefreshHint {
+		bundle.RefreshHint = newBundle.RefreshHint
+	}
+
+	if inputMask.RootCas {
+		bundle.RootCas = newBundle.RootCas
+	}
+
+	if inputMask.JwtSigningKeys {
+		bundle.JwtSigningKeys = newBundle.JwtSigningKeys
+	}
+
+	bundle.SequenceNumber++
+}
+
+func setBundle(ctx context.Context, t *table, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	id, err := bundleID(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	// The bundle is updated, rather than replaced, when it exists so that
+	// its sequence number keeps increasing.
+	var bundle *common.Bundle
+	err = retryConflicts(ctx, func() error {
+		bundle = new(common.Bundle)
+		version, ok, err := t.get(ctx, kindBundle, id, bundle)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			bundle = req.Bundle
+		default:
+			applyBundleMask(bundle, req.Bundle, nil)
+		}
+
+		if err := checkBundleLimits(bundle, req.Limits); err != nil {
+			return err
+		}
+		if !ok {
+			return createRecord(ctx, t, kindBundle, id, bundle)
+		}
+		return updateRecord(ctx, t, kindBundle, id, version, bundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.SetBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+// checkBundleLimits fails with InvalidArgument if the bundle about to be
+// stored exceeds the limits.
+func checkBundleLimits(bundle *common.Bundle, limits *datastore.BundleLimits) error {
+	if err := bundleutil.CheckLimits(bundle, limits); err != nil {
+		return status.Errorf(codes.InvalidArgument, "datastore-dynamodb: %v", err)
+	}
+	return nil
+}
+
+// checkJWTKeyConflicts fails with FailedPrecondition if an appended JWT
+// signing key has the key ID of a different key in the current bundle.
+func checkJWTKeyConflicts(current, appended *common.Bundle) error {
+	if err := bundleutil.CheckJWTKeyConflicts(current, appended); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "datastore-dynamodb: %v", err)
+	}
+	return nil
+}
+
+// createRecord stores msg as the record of the given kind and identifier,
+// and fails with errConflict if the record was concurrently created.
+func createRecord(ctx context.Context, t *table, kind, id string, msg proto.Message) error {
+	ok, err := t.create(ctx, kind, id, msg)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return errConflict
+	}
+	return nil
+}
+
+func appendBundle(ctx context.Context, t *table, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	id, err := bundleID(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle *common.Bundle
+	err = retryConflicts(ctx, func() error {
+		bundle = new(common.Bundle)
+		version, ok, err := t.get(ctx, kindBundle, id, bundle)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			bundle = req.Bundle
+			if req.RefreshHint != nil {
+				bundle = proto.Clone(bundle).(*common.Bundle)
+				bundle.RefreshHint = req.RefreshHint.Value
+			}
+			if err := checkBundleLimits(bundle, req.Limits); err != nil {
+				return err
+			}
+			return createRecord(ctx, t, kindBundle, id, bundle)
+		}
+
+		if err := checkJWTKeyConflicts(bundle, req.Bundle); err != nil {
+			return err
+		}
+
+		var changed bool
+		bundle, changed = bundleutil.MergeBundles(bundle, req.Bundle)
+		if req.RefreshHint != nil && bundle.RefreshHint != req.RefreshHint.Value {
+			bundle.RefreshHint = req.RefreshHint.Value
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		bundle.SequenceNumber++
+		if err := checkBundleLimits(bundle, req.Limits); err != nil {
+			return err
+		}
+		return updateRecord(ctx, t, kindBundle, id, version, bundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.AppendBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+// deleteBundle deletes the bundle after applying the deletion mode to the
+// registration entries that federate with it. DynamoDB does not provide
+// cross-item referential integrity, so entries are updated one at a time
+// before the bundle is removed. The entries are found through the global
+// secondary index, so entries written shortly before may be missed.
+func deleteBundle(ctx context.Context, t *table, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	id, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, dynamoError.Wrap(err)
+	}
+
+	bundle := new(common.Bundle)
+	_, ok, err := t.get(ctx, kindBundle, id, bundle)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.NotFound, "datastore-dynamodb: bundle %q not found", id)
+	}
+
+	federated, err := t.records(ctx, kindEntry, func(data []byte) (bool, error) {
+		entry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+		return containsString(entry.FederatesWith, id), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(federated) > 0 {
+		switch req.Mode {
+		case datastore.DeleteBundleRequest_DELETE, datastore.DeleteBundleRequest_DISSOCIATE:
+			for _, r := range federated {
+				if err := retryConflicts(ctx, func() error {
+					return unfederateEntry(ctx, t, r.id, id, req.Mode)
+				}); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, status.Newf(codes.FailedPrecondition, "datastore-dynamodb: cannot delete bundle; federated with %d registration entries", len(federated)).Err()
+		}
+	}
+
+	if _, err := t.delete(ctx, kindBundle, id, nil); err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeleteBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+// unfederateEntry deletes the registration entry, or dissociates it from the
+// trust domain, according to the deletion mode, as long as it still
+// federates with the trust domain.
+func unfederateEntry(ctx context.Context, t *table, entryID, trustDomainID string, mode datastore.DeleteBundleRequest_Mode) error {
+	entry := new(common.RegistrationEntry)
+	version, ok, err := t.get(ctx, kindEntry, entryID, entry)
+	if err != nil || !ok || !containsString(entry.FederatesWith, trustDomainID) {
+		return err
+	}
+
+	if mode == datastore.DeleteBundleRequest_DELETE {
+		ok, err = t.deleteVersion(ctx, kindEntry, entryID, version)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return errConflict
+		}
+		return nil
+	}
+
+	entry.FederatesWith = removeString(entry.FederatesWith, trustDomainID)
+	return updateRecord(ctx, t, kindEntry, entryID, version, entry)
+}
+
+func fetchBundle(ctx context.Context, t *table, req *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	id, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, dynamoError.Wrap(err)
+	}
+
+	bundle := new(common.Bundle)
+	_, ok, err := t.get(ctx, kindBundle, id, bundle)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchBundleResponse{}, nil
+	}
+
+	return &datastore.FetchBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+func listBundles(ctx context.Context, t *table, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	resp := new(datastore.ListBundlesResponse)
+	pagination, err := t.list(ctx, kindBundle, req.Pagination, func(data []byte) (bool, error) {
+		bundle := new(common.Bundle)
+		if err := proto.Unmarshal(data, bundle); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+		resp.Bundles = append(resp.Bundles, bundle)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Pagination = pagination
+	return resp, nil
+}
+
+func pruneBundle(ctx context.Context, t *table, req *datastore.PruneBundleRequest, log hclog.Logger) (*datastore.PruneBundleResponse, error) {
+	id, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, dynamoError.Wrap(err)
+	}
+
+	var changed bool
+	err = retryConflicts(ctx, func() error {
+		current := new(common.Bundle)
+		version, ok, err := t.get(ctx, kindBundle, id, current)
+		switch {
+		case err != nil:
+			return dynamoError.New("unable to fetch current bundle: %v", err)
+		case !ok:
+			// No bundle to prune
+			changed = false
+			return nil
+		}
+
+		var newBundle *common.Bundle
+		newBundle, changed, err = bundleutil.PruneBundle(current, time.Unix(req.ExpiresBefore, 0), log)
+		if err != nil {
+			return dynamoError.New("prune failed: %v", err)
+		}
+
+		// Update only if bundle was modified
+		if !changed {
+			return nil
+		}
+		applyBundleMask(current, newBundle, nil)
+		return updateRecord(ctx, t, kindBundle, id, version, current)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
+}
+
+func createAttestedNode(ctx context.Context, t *table, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	if req.Node == nil {
+		return nil, dynamoError.New("invalid request: missing attested node")
+	}
+
+	// Node selectors are stored separately (see SetNodeSelectors)
+	node := proto.Clone(req.Node).(*common.AttestedNode)
+	node.Selectors = nil
+
+	ok, err := t.create(ctx, kindNode, node.SpiffeId, node)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.AlreadyExists, "datastore-dynamodb: attested node %q already exists", node.SpiffeId)
+	}
+
+	return &datastore.CreateAttestedNodeResponse{
+		Node: node,
+	}, nil
+}
+
+func listAttestedNodes(ctx context.Context, t *table, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selectors set")
+	}
+
+	resp := new(datastore.ListAttestedNodesResponse)
+	pagination, err := t.list(ctx, kindNode, req.Pagination, func(data []byte) (bool, error) {
+		node := new(common.AttestedNode)
+		if err := proto.Unmarshal(data, node); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+
+		if req.ByExpiresBefore != nil && node.CertNotAfter >= req.ByExpiresBefore.Value {
+			return false, nil
+		}
+		if req.ByExpiresAtOrAfter != nil && node.CertNotAfter < req.ByExpiresAtOrAfter.Value {
+			return false, nil
+		}
+		if req.ByAttestationType != "" && node.AttestationDataType != req.ByAttestationType {
+			return false, nil
+		}
+		if req.ByBanned != nil && req.ByBanned.Value != (node.CertSerialNumber == "") {
+			return false, nil
+		}
+
+		if req.BySelectorMatch != nil || req.FetchSelectors {
+			selectors, err := getNodeSelectors(ctx, t, node.SpiffeId)
+			if err != nil {
+				return false, err
+			}
+			if req.BySelectorMatch != nil && !matchSelectors(selectors, req.BySelectorMatch) {
+				return false, nil
+			}
+			if req.FetchSelectors {
+				node.Selectors = selectors
+			}
+		}
+
+		resp.Nodes = append(resp.Nodes, node)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Pagination = pagination
+	return resp, nil
+}
+
+func updateAttestedNode(ctx context.Context, t *table, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	mask := req.InputMask
+	if mask == nil {
+		mask = protoutil.AllTrueCommonAgentMask
+	}
+
+	node := new(common.AttestedNode)
+	err := retryConflicts(ctx, func() error {
+		version, ok, err := t.get(ctx, kindNode, req.SpiffeId, node)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return status.Errorf(codes.NotFound, "datastore-dynamodb: attested node %q not found", req.SpiffeId)
+		}
+
+		if mask.CertNotAfter {
+			node.CertNotAfter = req.CertNotAfter
+		}
+		if mask.CertSerialNumber {
+			node.CertSerialNumber = req.CertSerialNumber
+		}
+		if mask.NewCertNotAfter {
+			node.NewCertNotAfter = req.NewCertNotAfter
+		}
+		if mask.NewCertSerialNumber {
+			node.NewCertSerialNumber = req.NewCertSerialNumber
+		}
+		return updateRecord(ctx, t, kindNode, req.SpiffeId, version, node)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateAttestedNodeResponse{
+		Node: node,
+	}, nil
+}
+
+func getNodeSelectors(ctx context.Context, t *table, spiffeID string) ([]*common.Selector, error) {
+	nodeSelectors := new(datastore.NodeSelectors)
+	if _, _, err := t.get(ctx, kindNodeSelectors, spiffeID, nodeSelectors); err != nil {
+		return nil, err
+	}
+	return nodeSelectors.Selectors, nil
+}
+
+func listNodeSelectors(ctx context.Context, t *table, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	resp := new(datastore.ListNodeSelectorsResponse)
+	err := t.query(ctx, kindNodeSelectors, "", func(r record) (bool, error) {
+		nodeSelectors := new(datastore.NodeSelectors)
+		if err := proto.Unmarshal(r.data, nodeSelectors); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+
+		if req.ValidAt != nil {
+			node := new(common.AttestedNode)
+			_, ok, err := t.get(ctx, kindNode, nodeSelectors.SpiffeId, node)
+			if err != nil {
+				return false, err
+			}
+			if !ok || node.CertNotAfter <= req.ValidAt.Seconds {
+				return false, nil
+			}
+		}
+
+		resp.Selectors = append(resp.Selectors, nodeSelectors)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func createRegistrationEntry(ctx context.Context, t *table, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	entry, err := newRegistrationEntry(ctx, t, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := t.create(ctx, kindEntry, entry.EntryId, entry)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, status.Errorf(codes.AlreadyExists, "datastore-dynamodb: registration entry %q already exists", entry.EntryId)
+	}
+
+	return &datastore.CreateRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+// newRegistrationEntry validates the entry to create and returns it with a
+// newly assigned entry ID, unless the caller supplied one.
+func newRegistrationEntry(ctx context.Context, t *table, req *datastore.CreateRegistrationEntryRequest) (*common.RegistrationEntry, error) {
+	if err := validateRegistrationEntry(req.Entry); err != nil {
+		return nil, err
+	}
+	if err := validateFederatesWith(ctx, t, req.Entry.FederatesWith); err != nil {
+		return nil, err
+	}
+
+	entry := proto.Clone(req.Entry).(*common.RegistrationEntry)
+	if entry.EntryId == "" {
+		entryID, err := uuid.NewV4()
+		if err != nil {
+			return nil, dynamoError.Wrap(err)
+		}
+		entry.EntryId = entryID.String()
+	}
+	entry.RevisionNumber = 0
+	return entry, nil
+}
+
+func listRegistrationEntries(ctx context.Context, t *table, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+
+	resp := new(datastore.ListRegistrationEntriesResponse)
+	pagination, err := t.list(ctx, kindEntry, req.Pagination, func(data []byte) (bool, error) {
+		entry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+
+		if req.ByParentId != nil && entry.ParentId != req.ByParentId.Value {
+			return false, nil
+		}
+		if req.BySpiffeId != nil && entry.SpiffeId != req.BySpiffeId.Value {
+			return false, nil
+		}
+		if req.BySelectors != nil && !matchSelectors(entry.Selectors, req.BySelectors) {
+			return false, nil
+		}
+		if req.ByMinTtl != nil && entry.Ttl < req.ByMinTtl.Value {
+			return false, nil
+		}
+		if req.ByMaxTtl != nil && entry.Ttl > req.ByMaxTtl.Value {
+			return false, nil
+		}
+		if req.ByExpiresBefore != nil && (entry.EntryExpiry <= 0 || entry.EntryExpiry >= req.ByExpiresBefore.Value) {
+			return false, nil
+		}
+		if req.ByFederatesWith != nil && len(req.ByFederatesWith.TrustDomains) > 0 {
+			if req.ByFederatesWith.Match == datastore.ByFederatesWith_MATCH_ANY {
+				if !matchAny(entry.FederatesWith, req.ByFederatesWith.TrustDomains) {
+					return false, nil
+				}
+			} else if !matchSet(entry.FederatesWith, req.ByFederatesWith.TrustDomains, req.ByFederatesWith.Match == datastore.ByFederatesWith_MATCH_EXACT) {
+				return false, nil
+			}
+		}
+
+		resp.Entries = append(resp.Entries, entry)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Pagination = pagination
+	return resp, nil
+}
+
+func updateRegistrationEntry(ctx context.Context, t *table, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	if err := validateRegistrationEntryForUpdate(req.Entry, req.Mask); err != nil {
+		return nil, err
+	}
+
+	entry := new(common.RegistrationEntry)
+	err := retryConflicts(ctx, func() error {
+		version, ok, err := t.get(ctx, kindEntry, req.Entry.EntryId, entry)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return status.Errorf(codes.NotFound, "datastore-dynamodb: registration entry %q not found", req.Entry.EntryId)
+		}
+
+		if err := applyRegistrationEntryUpdate(ctx, t, entry, req); err != nil {
+			return err
+		}
+		return updateRecord(ctx, t, kindEntry, entry.EntryId, version, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+// entryBatch stages the operations of a registration entry batch against an
+// in-memory view of the entries they touch, so that later operations observe
+// the effects of earlier ones. DynamoDB does not allow a transaction to write
+// the same item more than once, so only the final state of each entry is
+// written when the batch is committed.
+type entryBatch struct {
+	t       *table
+	entries map[string]*stagedEntry
+	ids     []string
+}
+
+type stagedEntry struct {
+	// version is the version of the entry in the table, or zero if it does
+	// not exist.
+	version int64
+	// entry is the staged state of the entry, or nil if it does not exist.
+	entry *common.RegistrationEntry
+	// dirty is true if the entry has been written by the batch.
+	dirty bool
+}
+
+// batchRegistrationEntries stages the operations and commits them, starting
+// over if the entries they read are concurrently modified.
+func batchRegistrationEntries(ctx context.Context, t *table, req *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	var resp *datastore.BatchRegistrationEntriesResponse
+	err := retryConflicts(ctx, func() error {
+		b := &entryBatch{
+			t:       t,
+			entries: make(map[string]*stagedEntry),
+		}
+
+		resp = new(datastore.BatchRegistrationEntriesResponse)
+		for i, op := range req.Operations {
+			entry, err := b.apply(ctx, op)
+			if err != nil {
+				st := status.Convert(err)
+				return status.Errorf(st.Code(), "operation %d: %s", i, st.Message())
+			}
+			resp.Entries = append(resp.Entries, entry)
+		}
+
+		return b.commit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// apply stages the operation and returns the resulting entry.
+func (b *entryBatch) apply(ctx context.Context, op *datastore.RegistrationEntryOperation) (*common.RegistrationEntry, error) {
+	switch {
+	case op.Create != nil && op.IfRevisionNumber != nil:
+		return nil, status.Error(codes.InvalidArgument, "datastore-dynamodb: revision number condition is only valid for updates and deletes")
+	case op.Create != nil && op.Update == nil && op.Delete == nil:
+		entry, err := newRegistrationEntry(ctx, b.t, op.Create)
+		if err != nil {
+			return nil, err
+		}
+		staged, ok := b.entries[entry.EntryId]
+		switch {
+		case !ok:
+			staged = new(stagedEntry)
+			b.entries[entry.EntryId] = staged
+			b.ids = append(b.ids, entry.EntryId)
+		case staged.entry != nil:
+			return nil, status.Errorf(codes.AlreadyExists, "datastore-dynamodb: registration entry %q already exists", entry.EntryId)
+		}
+		staged.entry = entry
+		staged.dirty = true
+		return proto.Clone(entry).(*common.RegistrationEntry), nil
+	case op.Update != nil && op.Create == nil && op.Delete == nil:
+		if err := validateRegistrationEntryForUpdate(op.Update.Entry, op.Update.Mask); err != nil {
+			return nil, err
+		}
+		staged, err := b.get(ctx, op.Update.Entry.EntryId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkRevisionNumber(staged.entry, op); err != nil {
+			return nil, err
+		}
+		if err := applyRegistrationEntryUpdate(ctx, b.t, staged.entry, op.Update); err != nil {
+			return nil, err
+		}
+		staged.dirty = true
+		return proto.Clone(staged.entry).(*common.RegistrationEntry), nil
+	case op.Delete != nil && op.Create == nil && op.Update == nil:
+		staged, err := b.get(ctx, op.Delete.EntryId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkRevisionNumber(staged.entry, op); err != nil {
+			return nil, err
+		}
+		entry := staged.entry
+		staged.entry = nil
+		staged.dirty = true
+		return entry, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "datastore-dynamodb: exactly one of create, update or delete must be set")
+	}
+}
+
+// checkRevisionNumber fails with Aborted if the operation is conditioned on a
+// revision number other than the one of the entry.
+func checkRevisionNumber(entry *common.RegistrationEntry, op *datastore.RegistrationEntryOperation) error {
+	if op.IfRevisionNumber == nil || entry.RevisionNumber == op.IfRevisionNumber.Value {
+		return nil
+	}
+	return status.Errorf(codes.Aborted, "datastore-dynamodb: registration entry %q is at revision %d, not %d", entry.EntryId, entry.RevisionNumber, op.IfRevisionNumber.Value)
+}
+
+// get returns the staged entry with the given ID, loading it from the table
+// if the batch has not touched it yet. It fails with NotFound if the entry
+// does not exist.
+func (b *entryBatch) get(ctx context.Context, entryID string) (*stagedEntry, error) {
+	staged, ok := b.entries[entryID]
+	if !ok {
+		entry := new(common.RegistrationEntry)
+		version, existed, err := b.t.get(ctx, kindEntry, entryID, entry)
+		if err != nil {
+			return nil, err
+		}
+		staged = &stagedEntry{version: version}
+		if existed {
+			staged.entry = entry
+		}
+		b.entries[entryID] = staged
+		b.ids = append(b.ids, entryID)
+	}
+	if staged.entry == nil {
+		return nil, status.Errorf(codes.NotFound, "datastore-dynamodb: registration entry %q not found", entryID)
+	}
+	return staged, nil
+}
+
+// commit writes the final state of every entry written by the batch in a
+// single transaction, as long as none of them was modified since it was
+// read. It fails with errConflict otherwise, or with AlreadyExists if an
+// entry created by the batch already exists.
+func (b *entryBatch) commit(ctx context.Context) error {
+	var writes []tableWrite
+	for _, id := range b.ids {
+		staged := b.entries[id]
+		switch {
+		case !staged.dirty:
+			// Only read by the batch
+		case staged.entry == nil && staged.version == 0:
+			// Created and deleted by the batch
+		case staged.entry == nil:
+			writes = append(writes, tableWrite{kind: kindEntry, id: id, version: staged.version})
+		default:
+			writes = append(writes, tableWrite{kind: kindEntry, id: id, msg: staged.entry, version: staged.version})
+		}
+	}
+
+	switch {
+	case len(writes) == 0:
+		return nil
+	case len(writes) > maxTransactionItems:
+		return status.Errorf(codes.InvalidArgument, "datastore-dynamodb: batch writes %d registration entries; at most %d are supported", len(writes), maxTransactionItems)
+	}
+
+	ok, err := b.t.transactWrite(ctx, writes)
+	var exists *recordExistsError
+	switch {
+	case errors.As(err, &exists):
+		return status.Errorf(codes.AlreadyExists, "datastore-dynamodb: registration entry %q already exists", exists.id)
+	case err != nil:
+		return err
+	case !ok:
+		return errConflict
+	}
+	return nil
+}
+
+// applyRegistrationEntryUpdate applies the fields of the update selected by
+// the mask to the existing entry and bumps its revision number.
+func applyRegistrationEntryUpdate(ctx context.Context, t *table, entry *common.RegistrationEntry, req *datastore.UpdateRegistrationEntryRequest) error {
+	mask := req.Mask
+	if mask == nil || mask.Selectors {
+		entry.Selectors = req.Entry.Selectors
+	}
+	if mask == nil || mask.DnsNames {
+		entry.DnsNames = req.Entry.DnsNames
+	}
+	if mask == nil || mask.SpiffeId {
+		entry.SpiffeId = req.Entry.SpiffeId
+	}
+	if mask == nil || mask.ParentId {
+		entry.ParentId = req.Entry.ParentId
+	}
+	if mask == nil || mask.Ttl {
+		entry.Ttl = req.Entry.Ttl
+	}
+	if mask == nil || mask.Admin {
+		entry.Admin = req.Entry.Admin
+	}
+	if mask == nil || mask.Downstream {
+		entry.Downstream = req.Entry.Downstream
+	}
+	if mask == nil || mask.EntryExpiry {
+		entry.EntryExpiry = req.Entry.EntryExpiry
+	}
+	if mask == nil || mask.X509SvidPrimaryName {
+		entry.X509SvidPrimaryName = req.Entry.X509SvidPrimaryName
+	}
+	if mask == nil || mask.X509SvidKeyType {
+		entry.X509SvidKeyType = req.Entry.X509SvidKeyType
+	}
+	if mask == nil || mask.FederatesWith {
+		if err := validateFederatesWith(ctx, t, req.Entry.FederatesWith); err != nil {
+			return err
+		}
+		entry.FederatesWith = req.Entry.FederatesWith
+	}
+
+	// Provenance of the update is always recorded, regardless of the mask
+	entry.UpdatedBy = req.Entry.UpdatedBy
+	entry.UpdatedAt = req.Entry.UpdatedAt
+
+	// Revision number is increased by 1 on every update call
+	entry.RevisionNumber++
+	return nil
+}
+
+// checkIntegrity looks for registration entries federated with bundles that
+// no longer exist and for attested nodes with a pending SVID serial number
+// but no current one. Selectors and DNS names are stored within their
+// registration entry and cannot be orphaned. Records that change while being
+// repaired are reported as not repaired.
+func checkIntegrity(ctx context.Context, t *table, req *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	bundles := make(map[string]bool)
+	err := t.query(ctx, kindBundle, "", func(r record) (bool, error) {
+		bundles[r.id] = true
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(datastore.CheckIntegrityResponse)
+	err = t.query(ctx, kindEntry, "", func(r record) (bool, error) {
+		entry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(r.data, entry); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+
+		var issues []*datastore.IntegrityIssue
+		var federatesWith []string
+		for _, td := range entry.FederatesWith {
+			if bundles[td] {
+				federatesWith = append(federatesWith, td)
+				continue
+			}
+			issues = append(issues, &datastore.IntegrityIssue{
+				Kind:        datastore.IntegrityIssue_DANGLING_FEDERATION,
+				Description: fmt.Sprintf("registration entry %q is federated with missing bundle %q", r.id, td),
+			})
+		}
+		if len(issues) == 0 {
+			return false, nil
+		}
+
+		if req.Repair {
+			entry.FederatesWith = federatesWith
+			ok, err := t.update(ctx, kindEntry, r.id, r.version, entry)
+			if err != nil {
+				return false, err
+			}
+			for _, issue := range issues {
+				issue.Repaired = ok
+			}
+		}
+		resp.Issues = append(resp.Issues, issues...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.query(ctx, kindNode, "", func(r record) (bool, error) {
+		node := new(common.AttestedNode)
+		if err := proto.Unmarshal(r.data, node); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+		if node.CertSerialNumber != "" || node.NewCertSerialNumber == "" {
+			return false, nil
+		}
+
+		issue := &datastore.IntegrityIssue{
+			Kind:        datastore.IntegrityIssue_NODE_WITHOUT_SERIAL,
+			Description: fmt.Sprintf("attested node %q has a pending SVID serial number but no current one", r.id),
+		}
+		if req.Repair {
+			// The pending SVID is the only one the node could still
+			// authenticate with, so it becomes the current one.
+			node.CertSerialNumber = node.NewCertSerialNumber
+			if node.NewCertNotAfter != 0 {
+				node.CertNotAfter = node.NewCertNotAfter
+			}
+			node.NewCertSerialNumber = ""
+			node.NewCertNotAfter = 0
+			issue.Repaired, err = t.update(ctx, kindNode, r.id, r.version, node)
+			if err != nil {
+				return false, err
+			}
+		}
+		resp.Issues = append(resp.Issues, issue)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// prune deletes the records of the given kind for which expired returns
+// true. msg is used to decode each record before it is passed to expired.
+// Records modified since they were listed are left for the next pruning.
+func prune(ctx context.Context, t *table, kind string, msg proto.Message, expired func(proto.Message) bool) error {
+	records, err := t.records(ctx, kind, func(data []byte) (bool, error) {
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return false, dynamoError.Wrap(err)
+		}
+		return expired(msg), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if _, err := t.deleteVersion(ctx, kind, r.id, r.version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRegistrationEntry(entry *common.RegistrationEntry) error {
+	if entry == nil {
+		return dynamoError.New("invalid request: missing registered entry")
+	}
+
+	if len(entry.Selectors) == 0 {
+		return dynamoError.New("invalid registration entry: missing selector list")
+	}
+
+	if len(entry.SpiffeId) == 0 {
+		return dynamoError.New("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if entry.Ttl < 0 {
+		return dynamoError.New("invalid registration entry: TTL is not set")
+	}
+
+	return nil
+}
+
+func validateRegistrationEntryForUpdate(entry *common.RegistrationEntry, mask *common.RegistrationEntryMask) error {
+	if entry == nil {
+		return dynamoError.New("invalid request: missing registered entry")
+	}
+
+	if (mask == nil || mask.Selectors) && len(entry.Selectors) == 0 {
+		return dynamoError.New("invalid registration entry: missing selector list")
+	}
+
+	if (mask == nil || mask.SpiffeId) && entry.SpiffeId == "" {
+		return dynamoError.New("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if (mask == nil || mask.Ttl) && entry.Ttl < 0 {
+		return dynamoError.New("invalid registration entry: TTL is not set")
+	}
+
+	return nil
+}
+
+// validateFederatesWith makes sure that there is a bundle for each of the
+// given trust domains.
+func validateFederatesWith(ctx context.Context, t *table, ids []string) error {
+	for _, id := range ids {
+		_, ok, err := t.get(ctx, kindBundle, id, new(common.Bundle))
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return dynamoError.New("unable to find federated bundle %q", id)
+		}
+	}
+	return nil
+}
+
+func bundleID(bundle *common.Bundle) (string, error) {
+	if bundle == nil {
+		return "", dynamoError.New("missing bundle in request")
+	}
+	id, err := idutil.NormalizeSpiffeID(bundle.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return "", dynamoError.Wrap(err)
+	}
+	return id, nil
+}
+
+// matchSelectors returns true if the selectors match the requested ones.
+// Like the SQL datastore, a subset match requires the selectors to be a
+// non-empty subset of the requested ones and an exact match requires both
+// sets to be equal.
+func matchSelectors(selectors []*common.Selector, by *datastore.BySelectors) bool {
+	toStrings := func(selectors []*common.Selector) []string {
+		out := make([]string, 0, len(selectors))
+		for _, s := range selectors {
+			out = append(out, s.Type+":"+s.Value)
+		}
+		return out
+	}
+	return matchSet(toStrings(selectors), toStrings(by.Selectors), by.Match == datastore.BySelectors_MATCH_EXACT)
+}
+
+// matchSet returns true if have is a non-empty subset of want. If exact is
+// set, have must also contain every value in want.
+func matchSet(have, want []string, exact bool) bool {
+	if len(have) == 0 {
+		return false
+	}
+
+	wantSet := make(map[string]bool, len(want))
+	for _, value := range want {
+		wantSet[value] = false
+	}
+	for _, value := range have {
+		if _, ok := wantSet[value]; !ok {
+			return false
+		}
+		wantSet[value] = true
+	}
+
+	if exact {
+		for _, found := range wantSet {
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchAny returns true if have contains at least one of the values in want.
+func matchAny(have, want []string) bool {
+	for _, value := range want {
+		if containsString(have, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,246 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/datastoretest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConformance(t *testing.T) {
+	datastoretest.Run(t, newTestPlugin)
+}
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		config       string
+		newClientErr error
+		expectConfig *configuration
+		expectErr    string
+	}{
+		{
+			name:      "malformed configuration",
+			config:    "blah",
+			expectErr: "At 1:",
+		},
+		{
+			name:      "missing table name",
+			config:    `region = "us-east-1"`,
+			expectErr: "datastore-dynamodb: table_name must be set",
+		},
+		{
+			name:         "client failure",
+			config:       `table_name = "spire"`,
+			newClientErr: errors.New("oh no"),
+			expectErr:    "datastore-dynamodb: unable to create DynamoDB client: oh no",
+		},
+		{
+			name: "success",
+			config: `
+				table_name = "spire"
+				region = "us-east-1"
+				endpoint = "http://localhost:8000"
+			`,
+			expectConfig: &configuration{
+				TableName: "spire",
+				Region:    "us-east-1",
+				Endpoint:  "http://localhost:8000",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var config *configuration
+			p := newPlugin(func(c *configuration) (Client, error) {
+				config = c
+				if tt.newClientErr != nil {
+					return nil, tt.newClientErr
+				}
+				return newClientFake(t, c.TableName), nil
+			})
+
+			_, err := p.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.expectErr != "" {
+				spiretest.RequireErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectConfig, config)
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	_, err := New().FetchBundle(context.Background(), &datastore.FetchBundleRequest{TrustDomainId: "spiffe://example.org"})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "datastore-dynamodb: not configured")
+}
+
+func TestDeleteBundleWithFederatedEntries(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name                string
+		mode                datastore.DeleteBundleRequest_Mode
+		expectCode          codes.Code
+		expectEntry         bool
+		expectFederatesWith []string
+	}{
+		{
+			name:                "restrict",
+			mode:                datastore.DeleteBundleRequest_RESTRICT,
+			expectCode:          codes.FailedPrecondition,
+			expectEntry:         true,
+			expectFederatesWith: []string{"spiffe://federated.org"},
+		},
+		{
+			name:        "delete",
+			mode:        datastore.DeleteBundleRequest_DELETE,
+			expectCode:  codes.OK,
+			expectEntry: false,
+		},
+		{
+			name:        "dissociate",
+			mode:        datastore.DeleteBundleRequest_DISSOCIATE,
+			expectCode:  codes.OK,
+			expectEntry: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := newTestPlugin(t)
+
+			_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+				Bundle: &common.Bundle{TrustDomainId: "spiffe://federated.org"},
+			})
+			require.NoError(t, err)
+
+			createResp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+				Entry: &common.RegistrationEntry{
+					SpiffeId:      "spiffe://example.org/workload",
+					ParentId:      "spiffe://example.org/agent",
+					Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+					FederatesWith: []string{"spiffe://federated.org"},
+				},
+			})
+			require.NoError(t, err)
+
+			_, err = ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+				TrustDomainId: "spiffe://federated.org",
+				Mode:          tt.mode,
+			})
+			spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, "")
+
+			fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+				EntryId: createResp.Entry.EntryId,
+			})
+			require.NoError(t, err)
+			if !tt.expectEntry {
+				require.Nil(t, fetchResp.Entry)
+				return
+			}
+			require.NotNil(t, fetchResp.Entry)
+			require.Equal(t, tt.expectFederatesWith, fetchResp.Entry.FederatesWith)
+		})
+	}
+}
+
+func TestBatchRegistrationEntriesTransactionLimit(t *testing.T) {
+	ctx := context.Background()
+	ds := newTestPlugin(t)
+
+	newBatch := func(n int) *datastore.BatchRegistrationEntriesRequest {
+		req := new(datastore.BatchRegistrationEntriesRequest)
+		for i := 0; i < n; i++ {
+			req.Operations = append(req.Operations, &datastore.RegistrationEntryOperation{
+				Create: &datastore.CreateRegistrationEntryRequest{
+					Entry: &common.RegistrationEntry{
+						SpiffeId:  fmt.Sprintf("spiffe://example.org/workload%d", i),
+						ParentId:  "spiffe://example.org/agent",
+						Selectors: []*common.Selector{{Type: "unix", Value: fmt.Sprintf("uid:%d", i)}},
+					},
+				},
+			})
+		}
+		return req
+	}
+
+	_, err := ds.BatchRegistrationEntries(ctx, newBatch(maxTransactionItems+1))
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "datastore-dynamodb: batch writes 101 registration entries; at most 100 are supported")
+
+	resp, err := ds.BatchRegistrationEntries(ctx, newBatch(maxTransactionItems))
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, maxTransactionItems)
+
+	countResp, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(maxTransactionItems), countResp.Entries)
+}
+
+func TestUpdateRetriesConflicts(t *testing.T) {
+	ctx := context.Background()
+	client := newClientFake(t, "spire")
+	p := newPlugin(func(c *configuration) (Client, error) {
+		return client, nil
+	})
+	_, err := p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `table_name = "spire"`,
+	})
+	require.NoError(t, err)
+
+	_, err = p.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:         "spiffe://example.org/agent",
+			CertSerialNumber: "1",
+		},
+	})
+	require.NoError(t, err)
+
+	// The update is retried until it no longer conflicts
+	client.conflicts = maxConflictRetries
+	_, err = p.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         "spiffe://example.org/agent",
+		CertSerialNumber: "2",
+	})
+	require.NoError(t, err)
+
+	fetchResp, err := p.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: "spiffe://example.org/agent",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2", fetchResp.Node.CertSerialNumber)
+
+	// The update gives up when it keeps conflicting
+	client.conflicts = maxConflictRetries + 1
+	_, err = p.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         "spiffe://example.org/agent",
+		CertSerialNumber: "3",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.Aborted, "datastore-dynamodb: records were concurrently modified; giving up")
+
+	fetchResp, err = p.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: "spiffe://example.org/agent",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2", fetchResp.Node.CertSerialNumber)
+}
+
+func newTestPlugin(t *testing.T) datastore.DataStore {
+	p := newPlugin(func(c *configuration) (Client, error) {
+		return newClientFake(t, c.TableName), nil
+	})
+	_, err := p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `table_name = "spire"`,
+	})
+	require.NoError(t, err)
+	return p
+}
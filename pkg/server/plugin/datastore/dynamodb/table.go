@@ -0,0 +1,481 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// All records live in a single table. Every item is keyed by a partition key
+// made of the record kind and the record identifier (e.g. "entry#<id>") and
+// holds the Protobuf encoding of the record. A global secondary index keyed
+// by kind and partition key is used to list the records of a given kind in
+// a stable order, which also makes the partition key usable as the
+// pagination cursor.
+//
+// Every item also holds a version, which starts at 1 and is incremented by
+// every write. Records are modified by reading them, with their version, and
+// writing them back on the condition that the version did not change, so
+// that concurrent modifications are never lost.
+const (
+	attrPK      = "PK"
+	attrKind    = "Kind"
+	attrData    = "Data"
+	attrVersion = "Version"
+
+	kindIndexName = "Kind-PK-index"
+
+	kindBundle        = "bundle"
+	kindEntry         = "entry"
+	kindNode          = "node"
+	kindNodeSelectors = "node_selectors"
+	kindJoinToken     = "join_token"
+
+	conditionNotExists = "attribute_not_exists(PK)"
+	conditionExists    = "attribute_exists(PK)"
+	conditionVersion   = "Version = :version"
+	keyConditionKind   = "Kind = :kind"
+
+	// reasonConditionalCheckFailed is the code of the cancellation reason
+	// of a transaction write whose condition failed.
+	reasonConditionalCheckFailed = "ConditionalCheckFailed"
+)
+
+// maxTransactionItems is the maximum number of items that can be written in
+// a single DynamoDB transaction.
+const maxTransactionItems = 100
+
+// maxConflictRetries is the number of times an operation is retried when the
+// records it read are concurrently modified before it gives up.
+const maxConflictRetries = 5
+
+// errConflict is returned by the operations that lost a race with a
+// concurrent modification of the records they read, so that they can be
+// retried.
+var errConflict = errors.New("records were concurrently modified")
+
+// retryConflicts calls fn until it does not fail with errConflict, up to
+// maxConflictRetries times.
+func retryConflicts(ctx context.Context, fn func() error) error {
+	for i := 0; ; i++ {
+		err := fn()
+		if !errors.Is(err, errConflict) {
+			return err
+		}
+		if i == maxConflictRetries {
+			return status.Error(codes.Aborted, "datastore-dynamodb: records were concurrently modified; giving up")
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// recordExistsError is returned by transactWrite when a write that creates a
+// record fails because the record already exists.
+type recordExistsError struct {
+	kind string
+	id   string
+}
+
+func (e *recordExistsError) Error() string {
+	return fmt.Sprintf("%s %q already exists", e.kind, e.id)
+}
+
+// record is a record read from the table.
+type record struct {
+	id      string
+	data    []byte
+	version int64
+}
+
+type table struct {
+	client Client
+	name   string
+}
+
+func itemKey(kind, id string) string {
+	return kind + "#" + id
+}
+
+func keyAttributes(kind, id string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		attrPK: {S: aws.String(itemKey(kind, id))},
+	}
+}
+
+func versionAttributes(version int64) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		":version": {N: aws.String(strconv.FormatInt(version, 10))},
+	}
+}
+
+// newItem returns the item holding the given record at the given version.
+func newItem(kind, id string, msg proto.Message, version int64) (map[string]*dynamodb.AttributeValue, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, dynamoError.Wrap(err)
+	}
+	return map[string]*dynamodb.AttributeValue{
+		attrPK:      {S: aws.String(itemKey(kind, id))},
+		attrKind:    {S: aws.String(kind)},
+		attrData:    {B: data},
+		attrVersion: {N: aws.String(strconv.FormatInt(version, 10))},
+	}, nil
+}
+
+// get fetches the record of the given kind and identifier into msg and
+// returns its version. It returns false if the record does not exist.
+func (t *table) get(ctx context.Context, kind, id string, msg proto.Message) (int64, bool, error) {
+	out, err := t.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(t.name),
+		Key:            keyAttributes(kind, id),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return 0, false, dynamoError.Wrap(err)
+	}
+	if out.Item == nil {
+		return 0, false, nil
+	}
+	r, err := parseItem(out.Item, kind)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := proto.Unmarshal(r.data, msg); err != nil {
+		return 0, false, dynamoError.Wrap(err)
+	}
+	return r.version, true, nil
+}
+
+// create stores msg as the record of the given kind and identifier. It
+// returns false if the record already exists.
+func (t *table) create(ctx context.Context, kind, id string, msg proto.Message) (bool, error) {
+	item, err := newItem(kind, id, msg, 1)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := t.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(t.name),
+		Item:                item,
+		ConditionExpression: aws.String(conditionNotExists),
+	}); err != nil {
+		if isConditionalCheckFailed(err) {
+			return false, nil
+		}
+		return false, dynamoError.Wrap(err)
+	}
+	return true, nil
+}
+
+// update replaces the record of the given kind and identifier with msg, as
+// long as the stored record is still at the given version. It returns false
+// if the record was modified or deleted since it was read.
+func (t *table) update(ctx context.Context, kind, id string, version int64, msg proto.Message) (bool, error) {
+	item, err := newItem(kind, id, msg, version+1)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := t.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(t.name),
+		Item:                      item,
+		ConditionExpression:       aws.String(conditionVersion),
+		ExpressionAttributeValues: versionAttributes(version),
+	}); err != nil {
+		if isConditionalCheckFailed(err) {
+			return false, nil
+		}
+		return false, dynamoError.Wrap(err)
+	}
+	return true, nil
+}
+
+// deleteVersion removes the record of the given kind and identifier, as long
+// as it is still at the given version. It returns false if the record was
+// modified or deleted since it was read.
+func (t *table) deleteVersion(ctx context.Context, kind, id string, version int64) (bool, error) {
+	if _, err := t.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(t.name),
+		Key:                       keyAttributes(kind, id),
+		ConditionExpression:       aws.String(conditionVersion),
+		ExpressionAttributeValues: versionAttributes(version),
+	}); err != nil {
+		if isConditionalCheckFailed(err) {
+			return false, nil
+		}
+		return false, dynamoError.Wrap(err)
+	}
+	return true, nil
+}
+
+// delete removes the record of the given kind and identifier and, if msg is
+// not nil, fills it with the deleted record. It returns false if the record
+// does not exist.
+func (t *table) delete(ctx context.Context, kind, id string, msg proto.Message) (bool, error) {
+	out, err := t.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(t.name),
+		Key:                 keyAttributes(kind, id),
+		ConditionExpression: aws.String(conditionExists),
+		ReturnValues:        aws.String(dynamodb.ReturnValueAllOld),
+	})
+	switch {
+	case isConditionalCheckFailed(err):
+		return false, nil
+	case err != nil:
+		return false, dynamoError.Wrap(err)
+	case msg == nil:
+		return true, nil
+	}
+	return true, unmarshalItem(out.Attributes, msg)
+}
+
+// tableWrite is a single write of a transaction. The record of the given kind
+// and identifier is replaced with msg, or deleted if msg is nil. The write
+// only succeeds if the stored record is still at the given version, or if
+// the record does not exist when the version is zero.
+type tableWrite struct {
+	kind    string
+	id      string
+	msg     proto.Message
+	version int64
+}
+
+// transactWrite applies the given writes atomically. It returns false if the
+// version of any of the records changed, in which case none of the writes
+// are applied. If a write that creates a record fails because the record
+// already exists, a *recordExistsError is returned instead.
+func (t *table) transactWrite(ctx context.Context, writes []tableWrite) (bool, error) {
+	items := make([]*dynamodb.TransactWriteItem, 0, len(writes))
+	for _, w := range writes {
+		condition := aws.String(conditionVersion)
+		values := versionAttributes(w.version)
+		if w.version == 0 {
+			condition = aws.String(conditionNotExists)
+			values = nil
+		}
+
+		if w.msg == nil {
+			items = append(items, &dynamodb.TransactWriteItem{
+				Delete: &dynamodb.Delete{
+					TableName:                 aws.String(t.name),
+					Key:                       keyAttributes(w.kind, w.id),
+					ConditionExpression:       condition,
+					ExpressionAttributeValues: values,
+				},
+			})
+			continue
+		}
+
+		item, err := newItem(w.kind, w.id, w.msg, w.version+1)
+		if err != nil {
+			return false, err
+		}
+		items = append(items, &dynamodb.TransactWriteItem{
+			Put: &dynamodb.Put{
+				TableName:                 aws.String(t.name),
+				Item:                      item,
+				ConditionExpression:       condition,
+				ExpressionAttributeValues: values,
+			},
+		})
+	}
+
+	if _, err := t.client.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	}); err != nil {
+		if isTransactionCanceled(err) {
+			if w, ok := failedCreate(err, writes); ok {
+				return false, &recordExistsError{kind: w.kind, id: w.id}
+			}
+			return false, nil
+		}
+		return false, dynamoError.Wrap(err)
+	}
+	return true, nil
+}
+
+// failedCreate returns the write that creates a record and whose condition
+// failed, according to the cancellation reasons of the transaction, which
+// DynamoDB reports in the order of the writes.
+func failedCreate(err error, writes []tableWrite) (tableWrite, bool) {
+	canceled, ok := err.(*dynamodb.TransactionCanceledException)
+	if !ok {
+		return tableWrite{}, false
+	}
+	for i, reason := range canceled.CancellationReasons {
+		if i >= len(writes) {
+			break
+		}
+		if w := writes[i]; w.version == 0 && w.msg != nil && aws.StringValue(reason.Code) == reasonConditionalCheckFailed {
+			return w, true
+		}
+	}
+	return tableWrite{}, false
+}
+
+// query calls fn with every record of the given kind, in key order, starting
+// after the given identifier if any. Iteration stops early when fn returns
+// true. Queries read the global secondary index, which DynamoDB only updates
+// asynchronously, so records written shortly before may be missing or stale.
+// Records read by a query must therefore only be modified conditionally on
+// their version.
+func (t *table) query(ctx context.Context, kind, after string, fn func(r record) (bool, error)) error {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(t.name),
+		IndexName:              aws.String(kindIndexName),
+		KeyConditionExpression: aws.String(keyConditionKind),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kind": {S: aws.String(kind)},
+		},
+	}
+	if after != "" {
+		input.ExclusiveStartKey = map[string]*dynamodb.AttributeValue{
+			attrPK:   {S: aws.String(itemKey(kind, after))},
+			attrKind: {S: aws.String(kind)},
+		}
+	}
+
+	for {
+		out, err := t.client.QueryWithContext(ctx, input)
+		if err != nil {
+			return dynamoError.Wrap(err)
+		}
+
+		for _, item := range out.Items {
+			r, err := parseItem(item, kind)
+			if err != nil {
+				return err
+			}
+			stop, err := fn(r)
+			if err != nil {
+				return err
+			}
+			if stop {
+				return nil
+			}
+		}
+
+		if len(out.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// list calls fn with the encoded record of every record of the given kind
+// that follows the pagination token. fn reports whether the record is part
+// of the results. When paginating, listing stops once a page worth of
+// records has been accepted and the returned pagination carries the token
+// to the next page, which is empty when no records were accepted.
+func (t *table) list(ctx context.Context, kind string, p *datastore.Pagination, fn func(data []byte) (bool, error)) (*datastore.Pagination, error) {
+	if p != nil && p.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	var after string
+	if p != nil && p.Token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(p.Token)
+		if err != nil || len(raw) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "could not parse token %q", p.Token)
+		}
+		after = string(raw)
+	}
+
+	var accepted int32
+	var last string
+	err := t.query(ctx, kind, after, func(r record) (bool, error) {
+		ok, err := fn(r.data)
+		if err != nil || !ok {
+			return false, err
+		}
+		accepted++
+		last = r.id
+		return p != nil && accepted >= p.PageSize, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, nil
+	}
+	next := &datastore.Pagination{PageSize: p.PageSize}
+	if accepted > 0 {
+		next.Token = base64.RawURLEncoding.EncodeToString([]byte(last))
+	}
+	return next, nil
+}
+
+// count returns the number of records of the given kind.
+func (t *table) count(ctx context.Context, kind string) (int32, error) {
+	var count int32
+	err := t.query(ctx, kind, "", func(record) (bool, error) {
+		count++
+		return false, nil
+	})
+	return count, err
+}
+
+// records returns the records of the given kind that fn accepts.
+func (t *table) records(ctx context.Context, kind string, fn func(data []byte) (bool, error)) ([]record, error) {
+	var records []record
+	err := t.query(ctx, kind, "", func(r record) (bool, error) {
+		ok, err := fn(r.data)
+		if ok {
+			records = append(records, r)
+		}
+		return false, err
+	})
+	return records, err
+}
+
+// parseItem returns the record held by an item of the given kind.
+func parseItem(item map[string]*dynamodb.AttributeValue, kind string) (record, error) {
+	pk, data, version := item[attrPK], item[attrData], item[attrVersion]
+	if pk == nil || pk.S == nil || data == nil || version == nil || version.N == nil {
+		return record{}, dynamoError.New("malformed %s item", kind)
+	}
+	v, err := strconv.ParseInt(*version.N, 10, 64)
+	if err != nil {
+		return record{}, dynamoError.New("malformed %s item: invalid version: %v", kind, err)
+	}
+	return record{
+		id:      strings.TrimPrefix(*pk.S, itemKey(kind, "")),
+		data:    data.B,
+		version: v,
+	}, nil
+}
+
+func unmarshalItem(item map[string]*dynamodb.AttributeValue, msg proto.Message) error {
+	data := item[attrData]
+	if data == nil {
+		return dynamoError.New("item is missing the %s attribute", attrData)
+	}
+	if err := proto.Unmarshal(data.B, msg); err != nil {
+		return dynamoError.Wrap(err)
+	}
+	return nil
+}
+
+func isConditionalCheckFailed(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+func isTransactionCanceled(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == dynamodb.ErrCodeTransactionCanceledException
+}
@@ -518,7 +518,135 @@ COMMIT;
 		CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
 		COMMIT;
 		`,
-		// future v15 database entry, in which an index was to the attested_nodes_entries expires_at column
+		// v15 database entry, in which an index was added to the attested_node_entries expires_at column
+		`
+		PRAGMA foreign_keys=OFF;
+		BEGIN TRANSACTION;
+		CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+		CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+		CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime );
+		CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint );
+		CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+		CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+		INSERT INTO migrations VALUES(1,'2021-01-12 10:12:03.132953291-06:00','2021-01-12 10:12:03.132953291-06:00',15,'1.0.0-dev-unk');
+		CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+		DELETE FROM sqlite_sequence;
+		INSERT INTO sqlite_sequence VALUES('migrations',1);
+		INSERT INTO sqlite_sequence VALUES('bundles',1);
+		CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+		CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+		CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+		CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+		CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+		CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+		CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+		CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+		CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+		CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+		CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+		CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+		CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+		COMMIT;
+		`,
+		// v16 database entry, in which the table 'registered_entries' gained a `x509_svid_primary_name` column
+		`
+		PRAGMA foreign_keys=OFF;
+		BEGIN TRANSACTION;
+		CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+		CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+		CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime );
+		CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint,"x509_svid_primary_name" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+		CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+		INSERT INTO migrations VALUES(1,'2021-01-12 10:12:03.132953291-06:00','2021-01-12 10:12:03.132953291-06:00',16,'1.0.0-dev-unk');
+		CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+		DELETE FROM sqlite_sequence;
+		INSERT INTO sqlite_sequence VALUES('migrations',1);
+		INSERT INTO sqlite_sequence VALUES('bundles',1);
+		CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+		CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+		CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+		CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+		CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+		CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+		CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+		CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+		CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+		CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+		CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+		CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+		CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+		COMMIT;
+		`,
+		// v17 database entry, in which the table 'registered_entries' gained a `x509_svid_key_type` column
+		`
+		PRAGMA foreign_keys=OFF;
+		BEGIN TRANSACTION;
+		CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+		CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+		CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime );
+		CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint,"x509_svid_primary_name" varchar(255),"x509_svid_key_type" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+		CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+		INSERT INTO migrations VALUES(1,'2021-01-12 10:12:03.132953291-06:00','2021-01-12 10:12:03.132953291-06:00',17,'1.0.0-dev-unk');
+		CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+		DELETE FROM sqlite_sequence;
+		INSERT INTO sqlite_sequence VALUES('migrations',1);
+		INSERT INTO sqlite_sequence VALUES('bundles',1);
+		CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+		CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+		CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+		CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+		CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+		CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+		CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+		CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+		CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+		CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+		CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+		CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+		CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+		COMMIT;
+		`,
+		// v18 database entry, in which the table 'registered_entries' gained the `created_by`, `updated_by`, `entry_created_at` and `entry_updated_at` columns
+		`
+		PRAGMA foreign_keys=OFF;
+		BEGIN TRANSACTION;
+		CREATE TABLE IF NOT EXISTS "federated_registration_entries" ("bundle_id" integer,"registered_entry_id" integer, PRIMARY KEY ("bundle_id","registered_entry_id"));
+		CREATE TABLE IF NOT EXISTS "bundles" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"trust_domain" varchar(255) NOT NULL,"data" blob );
+		CREATE TABLE IF NOT EXISTS "attested_node_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"data_type" varchar(255),"serial_number" varchar(255),"expires_at" datetime,"new_serial_number" varchar(255),"new_expires_at" datetime );
+		CREATE TABLE IF NOT EXISTS "node_resolver_map_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"spiffe_id" varchar(255),"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "registered_entries" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"entry_id" varchar(255),"spiffe_id" varchar(255),"parent_id" varchar(255),"ttl" integer,"admin" bool,"downstream" bool,"expiry" bigint,"revision_number" bigint,"x509_svid_primary_name" varchar(255),"x509_svid_key_type" varchar(255),"created_by" varchar(255),"updated_by" varchar(255),"entry_created_at" bigint,"entry_updated_at" bigint );
+		CREATE TABLE IF NOT EXISTS "join_tokens" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"token" varchar(255),"expiry" bigint );
+		CREATE TABLE IF NOT EXISTS "selectors" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"type" varchar(255),"value" varchar(255) );
+		CREATE TABLE IF NOT EXISTS "migrations" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"version" integer,"code_version" varchar(255) );
+		INSERT INTO migrations VALUES(1,'2021-01-12 10:12:03.132953291-06:00','2021-01-12 10:12:03.132953291-06:00',18,'1.0.0-dev-unk');
+		CREATE TABLE IF NOT EXISTS "dns_names" ("id" integer primary key autoincrement,"created_at" datetime,"updated_at" datetime,"registered_entry_id" integer,"value" varchar(255) );
+		DELETE FROM sqlite_sequence;
+		INSERT INTO sqlite_sequence VALUES('migrations',1);
+		INSERT INTO sqlite_sequence VALUES('bundles',1);
+		CREATE UNIQUE INDEX uix_bundles_trust_domain ON "bundles"(trust_domain) ;
+		CREATE UNIQUE INDEX uix_attested_node_entries_spiffe_id ON "attested_node_entries"(spiffe_id) ;
+		CREATE UNIQUE INDEX idx_node_resolver_map ON "node_resolver_map_entries"(spiffe_id, "type", "value") ;
+		CREATE INDEX idx_registered_entries_spiffe_id ON "registered_entries"(spiffe_id) ;
+		CREATE INDEX idx_registered_entries_parent_id ON "registered_entries"(parent_id) ;
+		CREATE INDEX idx_registered_entries_expiry ON "registered_entries"("expiry") ;
+		CREATE UNIQUE INDEX uix_registered_entries_entry_id ON "registered_entries"(entry_id) ;
+		CREATE UNIQUE INDEX uix_join_tokens_token ON "join_tokens"("token") ;
+		CREATE INDEX idx_selectors_type_value ON "selectors"("type", "value") ;
+		CREATE UNIQUE INDEX idx_selector_entry ON "selectors"(registered_entry_id, "type", "value") ;
+		CREATE UNIQUE INDEX idx_dns_entry ON "dns_names"(registered_entry_id, "value") ;
+		CREATE INDEX idx_federated_registration_entries_registered_entry_id ON "federated_registration_entries"(registered_entry_id) ;
+		CREATE INDEX idx_attested_node_entries_expires_at ON "attested_node_entries"(expires_at) ;
+		COMMIT;
+		`,
+		// future v19 database entry, in which the table 'join_tokens' gained the `used_at` and `ttl` columns
 	}
 )
 
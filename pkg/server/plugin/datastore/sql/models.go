@@ -89,6 +89,23 @@ type RegisteredEntry struct {
 	// RevisionNumber is a counter that is incremented when the entry is
 	// updated.
 	RevisionNumber int64
+
+	// (optional) name presented first in X509-SVIDs
+	X509SVIDPrimaryName string `gorm:"column:x509_svid_primary_name"`
+
+	// (optional) type of key generated by the agent for X509-SVIDs
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+
+	// (optional) SPIFFE IDs of the callers that created and last updated
+	// the entry
+	CreatedBy string
+	UpdatedBy string
+
+	// Times at which the entry was created and last updated through the
+	// API, in seconds since Unix epoch. These are recorded alongside
+	// CreatedBy/UpdatedBy and are distinct from the row timestamps.
+	EntryCreatedAt int64
+	EntryUpdatedAt int64
 }
 
 // JoinToken holds a join token
@@ -97,6 +114,8 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+	UsedAt int64
+	TTL    int32
 }
 
 type Selector struct {
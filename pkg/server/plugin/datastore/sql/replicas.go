@@ -0,0 +1,208 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// replicaRetryInterval is how long a read replica that failed is skipped
+	// before it is tried again
+	replicaRetryInterval = 30 * time.Second
+
+	// replicaLatencyWeight is the weight of each new latency sample in the
+	// moving average of the latency of a read replica
+	replicaLatencyWeight = 0.2
+
+	// roConnectionStringEndpoint is the endpoint name of the read replica
+	// configured through ro_connection_string, which is in the local region
+	roConnectionStringEndpoint = "ro_connection_string"
+
+	// primaryEndpoint is the endpoint name of the primary database
+	primaryEndpoint = "primary"
+)
+
+// readReplicaConfig configures a read-only database endpoint in a region. The
+// read replicas are configured as blocks labeled with the endpoint name.
+type readReplicaConfig struct {
+	Region           string `hcl:"region" json:"region"`
+	ConnectionString string `hcl:"connection_string" json:"connection_string"`
+}
+
+// replica is a read-only database endpoint that serves the reads that
+// tolerate stale data
+type replica struct {
+	endpoint string
+	region   string
+	db       *sqlDB
+
+	mu             sync.Mutex
+	latency        time.Duration
+	unhealthyUntil time.Time
+}
+
+// observe records the latency of a query served by the replica, which is
+// healthy
+func (r *replica) observe(latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latency == 0 {
+		r.latency = latency
+	} else {
+		r.latency = time.Duration(float64(r.latency)*(1-replicaLatencyWeight) + float64(latency)*replicaLatencyWeight)
+	}
+	r.unhealthyUntil = time.Time{}
+}
+
+// fail records that the replica failed to serve a query, so it is skipped
+// until the retry interval elapses
+func (r *replica) fail(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unhealthyUntil = now.Add(replicaRetryInterval)
+}
+
+func (r *replica) state(now time.Time) (latency time.Duration, healthy bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.latency, !now.Before(r.unhealthyUntil)
+}
+
+// orderReplicas returns the healthy replicas in the order in which they are
+// tried: the replicas in the local region first, then the others, each by
+// increasing latency. Replicas that have not served any query yet are tried
+// first so their latency gets measured.
+func orderReplicas(replicas []*replica, region string, now time.Time) []*replica {
+	type candidate struct {
+		r       *replica
+		local   bool
+		latency time.Duration
+	}
+	var candidates []candidate
+	for _, r := range replicas {
+		latency, healthy := r.state(now)
+		if !healthy {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			r:       r,
+			local:   r.region == region,
+			latency: latency,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].local != candidates[j].local {
+			return candidates[i].local
+		}
+		return candidates[i].latency < candidates[j].latency
+	})
+
+	ordered := make([]*replica, 0, len(candidates))
+	for _, c := range candidates {
+		ordered = append(ordered, c.r)
+	}
+	return ordered
+}
+
+// withStaleRead runs a read that tolerates stale data. The read is served by
+// the preferred read replica, failing over to the next ones, and to the
+// primary database when no replica can serve it. Errors returned as a gRPC
+// status come from the request itself and are returned without failing over,
+// as are errors after the context is done.
+func (ds *Plugin) withStaleRead(ctx context.Context, op func(db *sqlDB) error) error {
+	ds.mu.Lock()
+	db := ds.db
+	replicas := ds.replicas
+	region := ds.region
+	ds.mu.Unlock()
+
+	for _, r := range orderReplicas(replicas, region, time.Now()) {
+		start := time.Now()
+		err := ds.measureRead(r.endpoint, r.region, func() error {
+			return op(r.db)
+		})
+		if _, ok := status.FromError(err); ok {
+			r.observe(time.Since(start))
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		r.fail(time.Now())
+		ds.log.Warn("Read replica failed; failing over",
+			telemetry.Endpoint, r.endpoint,
+			telemetry.Region, r.region,
+			telemetry.Error, err.Error(),
+		)
+	}
+
+	return ds.measureRead(primaryEndpoint, region, func() error {
+		return op(db)
+	})
+}
+
+func (ds *Plugin) measureRead(endpoint, region string, op func() error) (err error) {
+	counter := telemetry.StartCall(ds.metrics, telemetry.Datastore, telemetry.ReadReplica)
+	counter.AddLabel(telemetry.Endpoint, endpoint)
+	counter.AddLabel(telemetry.Region, region)
+	defer counter.Done(&err)
+	return op()
+}
+
+// openReadReplicas opens the read replicas configured with read_replica. The
+// replica configured with ro_connection_string, if any, must already be
+// open. The mutex must be held.
+func (ds *Plugin) openReadReplicas(config *configuration) error {
+	ds.closeReadReplicas()
+
+	ds.region = config.Region
+	if ds.roDb != nil {
+		ds.replicas = append(ds.replicas, &replica{
+			endpoint: roConnectionStringEndpoint,
+			region:   config.Region,
+			db:       ds.roDb,
+		})
+	}
+
+	names := make([]string, 0, len(config.ReadReplicas))
+	for name := range config.ReadReplicas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, endpoint := range names {
+		replicaConfig := config.ReadReplicas[endpoint]
+		replicaDBConfig := *config
+		replicaDBConfig.RoConnectionString = replicaConfig.ConnectionString
+		db, err := ds.openSQLDB(&replicaDBConfig, true)
+		if err != nil {
+			ds.closeReadReplicas()
+			return fmt.Errorf("unable to open read replica %q in region %q: %w", endpoint, replicaConfig.Region, err)
+		}
+		ds.replicas = append(ds.replicas, &replica{
+			endpoint: endpoint,
+			region:   replicaConfig.Region,
+			db:       db,
+		})
+	}
+	return nil
+}
+
+// closeReadReplicas closes the read replicas configured with read_replica.
+// The replica configured with ro_connection_string is left open. The mutex
+// must be held.
+func (ds *Plugin) closeReadReplicas() {
+	for _, r := range ds.replicas {
+		if r.endpoint != roConnectionStringEndpoint {
+			r.db.Close()
+		}
+	}
+	ds.replicas = nil
+}
@@ -0,0 +1,146 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderReplicas(t *testing.T) {
+	now := time.Now()
+	localSlow := &replica{endpoint: "local-slow", region: "us", latency: 20 * time.Millisecond}
+	localFast := &replica{endpoint: "local-fast", region: "us", latency: 5 * time.Millisecond}
+	localFailed := &replica{endpoint: "local-failed", region: "us", latency: time.Millisecond}
+	remoteFast := &replica{endpoint: "remote-fast", region: "eu", latency: time.Millisecond}
+	remoteNew := &replica{endpoint: "remote-new", region: "eu"}
+	localFailed.fail(now)
+
+	endpoints := func(replicas []*replica) []string {
+		var names []string
+		for _, r := range replicas {
+			names = append(names, r.endpoint)
+		}
+		return names
+	}
+
+	replicas := []*replica{remoteFast, localSlow, localFailed, remoteNew, localFast}
+	assert.Equal(t, []string{"local-fast", "local-slow", "remote-new", "remote-fast"},
+		endpoints(orderReplicas(replicas, "us", now)))
+
+	// Failed replicas are tried again once the retry interval elapses
+	assert.Equal(t, []string{"local-failed", "local-fast", "local-slow", "remote-new", "remote-fast"},
+		endpoints(orderReplicas(replicas, "us", now.Add(replicaRetryInterval))))
+
+	// Latency is a moving average of the observed latencies
+	localFast.observe(55 * time.Millisecond)
+	assert.Equal(t, 15*time.Millisecond, localFast.latency)
+}
+
+func TestStaleReadsFailOverAcrossReplicas(t *testing.T) {
+	if TestDialect != "" {
+		t.Skip("replica failover is only tested against sqlite3")
+	}
+
+	metrics := fakemetrics.New()
+	p := New()
+	p.SetMetrics(metrics)
+	t.Cleanup(p.closeDB)
+
+	var ds datastore.Plugin
+	spiretest.LoadPlugin(t, builtin(p), &ds)
+
+	dbPath := filepath.Join(spiretest.TempDir(t), "db.sqlite3")
+	_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			database_type = "sqlite3"
+			connection_string = %q
+			region = "us-east-1"
+			read_replica "eu" {
+				region = "eu-west-1"
+				connection_string = %q
+			}
+			read_replica "us" {
+				region = "us-east-1"
+				connection_string = %q
+			}
+			`, dbPath, dbPath, dbPath),
+	})
+	require.NoError(t, err)
+	require.Len(t, p.replicas, 2)
+
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:  "spiffe://example.org/workload",
+			ParentId:  "spiffe://example.org/agent",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+
+	listEntries := func() {
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			TolerateStale: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+	}
+
+	// The replica in the local region is preferred
+	listEntries()
+
+	// When it fails, reads fail over to the replica in the other region
+	require.NoError(t, p.replicas[1].db.Close())
+	listEntries()
+	_, healthy := p.replicas[1].state(time.Now())
+	assert.False(t, healthy)
+
+	// And to the primary database when no replica can serve them
+	require.NoError(t, p.replicas[0].db.Close())
+	listEntries()
+
+	assert.Equal(t, []string{
+		"us:us_east_1:OK",
+		"us:us_east_1:Unknown",
+		"eu:eu_west_1:OK",
+		"eu:eu_west_1:Unknown",
+		"primary:us_east_1:OK",
+	}, replicaReads(metrics))
+}
+
+func TestReadReplicaRequiresConnectionString(t *testing.T) {
+	config := &configuration{
+		DatabaseType:     SQLite,
+		ConnectionString: "db.sqlite3",
+		ReadReplicas:     map[string]readReplicaConfig{"eu": {Region: "eu-west-1"}},
+	}
+	require.EqualError(t, config.Validate(), `read_replica "eu": connection_string must be set`)
+
+	config.ReadReplicas = map[string]readReplicaConfig{"primary": {ConnectionString: "db.sqlite3"}}
+	require.EqualError(t, config.Validate(), `read_replica "primary": name is reserved`)
+}
+
+func replicaReads(metrics *fakemetrics.FakeMetrics) []string {
+	var reads []string
+	for _, item := range metrics.AllMetrics() {
+		if item.Type != fakemetrics.IncrCounterWithLabelsType {
+			continue
+		}
+		labels := make(map[string]string)
+		for _, label := range item.Labels {
+			labels[label.Name] = label.Value
+		}
+		reads = append(reads, fmt.Sprintf("%s:%s:%s", labels[telemetry.Endpoint], labels[telemetry.Region], labels[telemetry.Status]))
+	}
+	return reads
+}
@@ -78,6 +78,11 @@ type configuration struct {
 	MaxIdleConns       *int    `hcl:"max_idle_conns" json:"max_idle_conns"`
 	DisableMigration   bool    `hcl:"disable_migration" json:"disable_migration"`
 
+	// Region of the server, used to prefer the read replicas in the same
+	// region
+	Region       string                       `hcl:"region" json:"region"`
+	ReadReplicas map[string]readReplicaConfig `hcl:"read_replica" json:"read_replica"`
+
 	// Undocumented flags
 	LogSQL bool `hcl:"log_sql" json:"log_sql"`
 }
@@ -109,22 +114,33 @@ func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...interfa
 type Plugin struct {
 	datastore.UnsafeDataStoreServer
 
-	mu   sync.Mutex
-	db   *sqlDB
-	roDb *sqlDB
-	log  hclog.Logger
+	mu       sync.Mutex
+	db       *sqlDB
+	roDb     *sqlDB
+	replicas []*replica
+	region   string
+	log      hclog.Logger
+	metrics  telemetry.Metrics
 }
 
 // New creates a new sql plugin struct. Configure must be called
 // in order to start the db.
 func New() *Plugin {
-	return &Plugin{}
+	return &Plugin{
+		metrics: telemetry.Blackhole{},
+	}
 }
 
 func (ds *Plugin) SetLogger(logger hclog.Logger) {
 	ds.log = logger
 }
 
+// SetMetrics sets the metrics used to report the latency of the read
+// replicas
+func (ds *Plugin) SetMetrics(metrics telemetry.Metrics) {
+	ds.metrics = metrics
+}
+
 // CreateBundle stores the given bundle
 func (ds *Plugin) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (resp *datastore.CreateBundleResponse, err error) {
 	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -160,14 +176,32 @@ func (ds *Plugin) SetBundle(ctx context.Context, req *datastore.SetBundleRequest
 }
 
 // AppendBundle append bundle contents to the existing bundle (by trust domain). If no existing one is present, create it.
+//
+// The existing bundle row is locked for the duration of the transaction so
+// that concurrent appends (e.g. from servers in an HA deployment) are
+// serialized and never drop each other's authorities. If the bundle does not
+// exist yet, a concurrent append may create it first, causing the insert to
+// fail with a constraint violation. In that case, the append is retried once
+// against the bundle created by the other append.
 func (ds *Plugin) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (resp *datastore.AppendBundleResponse, err error) {
-	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
-		resp, err = appendBundle(tx, req)
-		return err
-	}); err != nil {
-		return nil, err
+	ds.mu.Lock()
+	db := ds.db
+	ds.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+			resp, err = appendBundle(tx, db.databaseType, req)
+			return err
+		})
+		switch {
+		case err == nil:
+			return resp, nil
+		case attempt < 2 && status.Code(err) == codes.AlreadyExists:
+			continue
+		default:
+			return nil, err
+		}
 	}
-	return resp, nil
 }
 
 // DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
@@ -321,8 +355,12 @@ func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSe
 // GetNodeSelectors gets node (agent) selectors by SPIFFE ID
 func (ds *Plugin) GetNodeSelectors(ctx context.Context,
 	req *datastore.GetNodeSelectorsRequest) (resp *datastore.GetNodeSelectorsResponse, err error) {
-	if req.TolerateStale && ds.roDb != nil {
-		return getNodeSelectors(ctx, ds.roDb, req)
+	if req.TolerateStale {
+		err = ds.withStaleRead(ctx, func(db *sqlDB) (err error) {
+			resp, err = getNodeSelectors(ctx, db, req)
+			return err
+		})
+		return resp, err
 	}
 	return getNodeSelectors(ctx, ds.db, req)
 }
@@ -330,8 +368,12 @@ func (ds *Plugin) GetNodeSelectors(ctx context.Context,
 // ListNodeSelectors gets node (agent) selectors by SPIFFE ID
 func (ds *Plugin) ListNodeSelectors(ctx context.Context,
 	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
-	if req.TolerateStale && ds.roDb != nil {
-		return listNodeSelectors(ctx, ds.roDb, req)
+	if req.TolerateStale {
+		err = ds.withStaleRead(ctx, func(db *sqlDB) (err error) {
+			resp, err = listNodeSelectors(ctx, db, req)
+			return err
+		})
+		return resp, err
 	}
 	return listNodeSelectors(ctx, ds.db, req)
 }
@@ -375,8 +417,12 @@ func (ds *Plugin) CountRegistrationEntries(ctx context.Context,
 // ListRegistrationEntries lists all registrations (pagination available)
 func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
 	req *datastore.ListRegistrationEntriesRequest) (resp *datastore.ListRegistrationEntriesResponse, err error) {
-	if req.TolerateStale && ds.roDb != nil {
-		return listRegistrationEntries(ctx, ds.roDb, req)
+	if req.TolerateStale {
+		err = ds.withStaleRead(ctx, func(db *sqlDB) (err error) {
+			resp, err = listRegistrationEntries(ctx, db, req)
+			return err
+		})
+		return resp, err
 	}
 	return listRegistrationEntries(ctx, ds.db, req)
 }
@@ -417,6 +463,24 @@ func (ds *Plugin) PruneRegistrationEntries(ctx context.Context, req *datastore.P
 	return resp, nil
 }
 
+// BatchRegistrationEntries applies the given registration entry operations in
+// a single transaction. Either all of the operations are applied or none.
+func (ds *Plugin) BatchRegistrationEntries(ctx context.Context, req *datastore.BatchRegistrationEntriesRequest) (resp *datastore.BatchRegistrationEntriesResponse, err error) {
+	for i, op := range req.Operations {
+		if err := validateRegistrationEntryOperation(op); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "operation %d: %v", i, err)
+		}
+	}
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = ds.batchRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // CreateJoinToken takes a Token message and stores it
 func (ds *Plugin) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (resp *datastore.CreateJoinTokenResponse, err error) {
 	if req.JoinToken == nil || req.JoinToken.Token == "" || req.JoinToken.Expiry == 0 {
@@ -456,6 +520,33 @@ func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoin
 	return resp, nil
 }
 
+// UseJoinToken marks the given join token as used. It fails if the token
+// does not exist or has already been used.
+func (ds *Plugin) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (resp *datastore.UseJoinTokenResponse, err error) {
+	if req.UsedAt == 0 {
+		return nil, sqlError.New("used at is required")
+	}
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = useJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListJoinTokens lists join tokens (optionally filtered and paginated)
+func (ds *Plugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -468,6 +559,22 @@ func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinT
 	return resp, nil
 }
 
+// CheckIntegrity looks for rows left inconsistent by interrupted writes and,
+// if asked to, repairs them
+func (ds *Plugin) CheckIntegrity(ctx context.Context, req *datastore.CheckIntegrityRequest) (resp *datastore.CheckIntegrityResponse, err error) {
+	withTx := ds.withReadTx
+	if req.Repair {
+		withTx = ds.withWriteTx
+	}
+	if err = withTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = checkIntegrity(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -486,11 +593,13 @@ func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*sp
 		return nil, err
 	}
 
-	if config.RoConnectionString == "" {
-		return &spi.ConfigureResponse{}, nil
+	if config.RoConnectionString != "" {
+		if err := ds.openConnection(config, true); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := ds.openConnection(config, true); err != nil {
+	if err := ds.openReadReplicas(config); err != nil {
 		return nil, err
 	}
 
@@ -505,35 +614,15 @@ func (ds *Plugin) openConnection(config *configuration, isReadOnly bool) error {
 	}
 
 	if sqlDb == nil || connectionString != sqlDb.connectionString || config.DatabaseType != ds.db.databaseType {
-		db, version, supportsCTE, dialect, err := ds.openDB(config, isReadOnly)
+		newDb, err := ds.openSQLDB(config, isReadOnly)
 		if err != nil {
 			return err
 		}
 
-		raw := db.DB()
-		if raw == nil {
-			return sqlError.New("unable to get raw database object")
-		}
-
 		if sqlDb != nil {
 			sqlDb.Close()
 		}
-
-		ds.log.Info("Connected to SQL database",
-			"type", config.DatabaseType,
-			"version", version,
-			"read_only", isReadOnly,
-		)
-
-		sqlDb = &sqlDB{
-			DB:               db,
-			raw:              raw,
-			databaseType:     config.DatabaseType,
-			dialect:          dialect,
-			connectionString: connectionString,
-			stmtCache:        newStmtCache(raw),
-			supportsCTE:      supportsCTE,
-		}
+		sqlDb = newDb
 	}
 
 	if isReadOnly {
@@ -546,7 +635,39 @@ func (ds *Plugin) openConnection(config *configuration, isReadOnly bool) error {
 	return nil
 }
 
+func (ds *Plugin) openSQLDB(config *configuration, isReadOnly bool) (*sqlDB, error) {
+	db, version, supportsCTE, dialect, err := ds.openDB(config, isReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := db.DB()
+	if raw == nil {
+		return nil, sqlError.New("unable to get raw database object")
+	}
+
+	ds.log.Info("Connected to SQL database",
+		"type", config.DatabaseType,
+		"version", version,
+		"read_only", isReadOnly,
+	)
+
+	sqlDb := &sqlDB{
+		DB:               db,
+		raw:              raw,
+		databaseType:     config.DatabaseType,
+		dialect:          dialect,
+		connectionString: getConnectionString(config, isReadOnly),
+		stmtCache:        newStmtCache(raw),
+		supportsCTE:      supportsCTE,
+	}
+	sqlDb.LogMode(config.LogSQL)
+	return sqlDb, nil
+}
+
 func (ds *Plugin) closeDB() {
+	ds.closeReadReplicas()
+
 	if ds.db != nil {
 		ds.db.Close()
 	}
@@ -691,6 +812,16 @@ func createBundle(tx *gorm.DB, req *datastore.CreateBundleRequest) (*datastore.C
 	}, nil
 }
 
+// lockForUpdate returns a query that locks the selected rows until the
+// transaction ends. SQLite does not support row locks, but it doesn't need
+// them either since writes are serialized (see withTx).
+func lockForUpdate(tx *gorm.DB, dbType string) *gorm.DB {
+	if dbType == SQLite {
+		return tx
+	}
+	return tx.Set("gorm:query_option", "FOR UPDATE")
+}
+
 func updateBundle(tx *gorm.DB, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
 	newBundle := req.Bundle
 	newModel, err := bundleToModel(newBundle)
@@ -707,6 +838,9 @@ func updateBundle(tx *gorm.DB, req *datastore.UpdateBundleRequest) (*datastore.U
 	if err != nil {
 		return nil, sqlError.Wrap(err)
 	}
+	if err := checkBundleLimits(newBundle, req.Limits); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Save(model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -739,6 +873,8 @@ func applyBundleMask(model *Bundle, newBundle *common.Bundle, inputMask *common.
 		bundle.JwtSigningKeys = newBundle.JwtSigningKeys
 	}
 
+	bundle.SequenceNumber++
+
 	newModel, err := bundleToModel(bundle)
 	if err != nil {
 		return nil, nil, err
@@ -757,6 +893,9 @@ func setBundle(tx *gorm.DB, req *datastore.SetBundleRequest) (*datastore.SetBund
 	model := &Bundle{}
 	result := tx.Find(model, "trust_domain = ?", newModel.TrustDomain)
 	if result.RecordNotFound() {
+		if err := checkBundleLimits(req.Bundle, req.Limits); err != nil {
+			return nil, err
+		}
 		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: req.Bundle})
 		if err != nil {
 			return nil, err
@@ -768,7 +907,7 @@ func setBundle(tx *gorm.DB, req *datastore.SetBundleRequest) (*datastore.SetBund
 		return nil, sqlError.Wrap(result.Error)
 	}
 
-	resp, err := updateBundle(tx, &datastore.UpdateBundleRequest{Bundle: req.Bundle})
+	resp, err := updateBundle(tx, &datastore.UpdateBundleRequest{Bundle: req.Bundle, Limits: req.Limits})
 	if err != nil {
 		return nil, err
 	}
@@ -777,17 +916,25 @@ func setBundle(tx *gorm.DB, req *datastore.SetBundleRequest) (*datastore.SetBund
 	}, nil
 }
 
-func appendBundle(tx *gorm.DB, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+func appendBundle(tx *gorm.DB, dbType string, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
 	newModel, err := bundleToModel(req.Bundle)
 	if err != nil {
 		return nil, err
 	}
 
-	// fetch existing or create new
+	// fetch existing (locking the row until the transaction ends) or create new
 	model := &Bundle{}
-	result := tx.Find(model, "trust_domain = ?", newModel.TrustDomain)
+	result := lockForUpdate(tx, dbType).Find(model, "trust_domain = ?", newModel.TrustDomain)
 	if result.RecordNotFound() {
-		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: req.Bundle})
+		bundle := req.Bundle
+		if req.RefreshHint != nil {
+			bundle = proto.Clone(bundle).(*common.Bundle)
+			bundle.RefreshHint = req.RefreshHint.Value
+		}
+		if err := checkBundleLimits(bundle, req.Limits); err != nil {
+			return nil, err
+		}
+		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: bundle})
 		if err != nil {
 			return nil, err
 		}
@@ -804,8 +951,20 @@ func appendBundle(tx *gorm.DB, req *datastore.AppendBundleRequest) (*datastore.A
 		return nil, err
 	}
 
+	if err := checkJWTKeyConflicts(bundle, req.Bundle); err != nil {
+		return nil, err
+	}
+
 	bundle, changed := bundleutil.MergeBundles(bundle, req.Bundle)
+	if req.RefreshHint != nil && bundle.RefreshHint != req.RefreshHint.Value {
+		bundle.RefreshHint = req.RefreshHint.Value
+		changed = true
+	}
 	if changed {
+		bundle.SequenceNumber++
+		if err := checkBundleLimits(bundle, req.Limits); err != nil {
+			return nil, err
+		}
 		newModel, err := bundleToModel(bundle)
 		if err != nil {
 			return nil, err
@@ -821,6 +980,24 @@ func appendBundle(tx *gorm.DB, req *datastore.AppendBundleRequest) (*datastore.A
 	}, nil
 }
 
+// checkBundleLimits fails with InvalidArgument if the bundle about to be
+// stored exceeds the limits.
+func checkBundleLimits(bundle *common.Bundle, limits *datastore.BundleLimits) error {
+	if err := bundleutil.CheckLimits(bundle, limits); err != nil {
+		return status.Errorf(codes.InvalidArgument, "datastore-sql: %v", err)
+	}
+	return nil
+}
+
+// checkJWTKeyConflicts fails with FailedPrecondition if an appended JWT
+// signing key has the key ID of a different key in the current bundle.
+func checkJWTKeyConflicts(current, appended *common.Bundle) error {
+	if err := bundleutil.CheckJWTKeyConflicts(current, appended); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "datastore-sql: %v", err)
+	}
+	return nil
+}
+
 func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
 	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
 	if err != nil {
@@ -941,7 +1118,7 @@ func listBundles(tx *gorm.DB, req *datastore.ListBundlesRequest) (*datastore.Lis
 		// Set token only if page size is the same than bundles len
 		if len(bundles) > 0 {
 			lastEntry := bundles[len(bundles)-1]
-			p.Token = fmt.Sprint(lastEntry.ID)
+			p.Token = paginationToken(uint64(lastEntry.ID))
 		}
 	}
 
@@ -1038,8 +1215,8 @@ func countAttestedNodes(tx *gorm.DB) (*datastore.CountAttestedNodesResponse, err
 }
 
 func listAttestedNodes(ctx context.Context, db *sqlDB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
-	if req.Pagination != nil && req.Pagination.PageSize == 0 {
-		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	if err := validatePagination(req.Pagination); err != nil {
+		return nil, err
 	}
 	if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selectors set")
@@ -1153,7 +1330,7 @@ func listAttestedNodesOnce(ctx context.Context, db *sqlDB, req *datastore.ListAt
 			PageSize: req.Pagination.PageSize,
 		}
 		if len(resp.Nodes) > 0 {
-			resp.Pagination.Token = strconv.FormatUint(lastEID, 10)
+			resp.Pagination.Token = paginationToken(lastEID)
 		}
 	}
 
@@ -1195,7 +1372,7 @@ func buildListAttestedNodesQueryCTE(req *datastore.ListAttestedNodesRequest, dbT
 
 	// Filter by pagination token
 	if req.Pagination != nil && req.Pagination.Token != "" {
-		token, err := strconv.ParseUint(req.Pagination.Token, 10, 32)
+		token, err := parsePaginationToken(req.Pagination.Token)
 		if err != nil {
 			return "", nil, status.Errorf(codes.InvalidArgument, "could not parse token '%v'", req.Pagination.Token)
 		}
@@ -1208,6 +1385,10 @@ func buildListAttestedNodesQueryCTE(req *datastore.ListAttestedNodesRequest, dbT
 		builder.WriteString("\t\tAND expires_at < ?\n")
 		args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 	}
+	if req.ByExpiresAtOrAfter != nil {
+		builder.WriteString("\t\tAND expires_at >= ?\n")
+		args = append(args, time.Unix(req.ByExpiresAtOrAfter.Value, 0))
+	}
 
 	// Filter by Attestation type
 	if req.ByAttestationType != "" {
@@ -1406,7 +1587,7 @@ FROM attested_node_entries N
 
 		// Filter by paginatioin token
 		if req.Pagination != nil && req.Pagination.Token != "" {
-			token, err := strconv.ParseUint(req.Pagination.Token, 10, 32)
+			token, err := parsePaginationToken(req.Pagination.Token)
 			if err != nil {
 				return status.Errorf(codes.InvalidArgument, "could not parse token '%v'", req.Pagination.Token)
 			}
@@ -1419,6 +1600,10 @@ FROM attested_node_entries N
 			builder.WriteString(" AND N.expires_at < ?")
 			args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 		}
+		if req.ByExpiresAtOrAfter != nil {
+			builder.WriteString(" AND N.expires_at >= ?")
+			args = append(args, time.Unix(req.ByExpiresAtOrAfter.Value, 0))
+		}
 
 		// Filter by Attestation type
 		if req.ByAttestationType != "" {
@@ -1695,19 +1880,31 @@ func buildListNodeSelectorsQuery(req *datastore.ListNodeSelectorsRequest) (query
 }
 
 func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
-	entryID, err := newRegistrationEntryID()
-	if err != nil {
-		return nil, err
+	// The entry ID may be supplied by the caller, in which case the unique
+	// index on the entry ID rejects duplicates.
+	entryID := req.Entry.EntryId
+	if entryID == "" {
+		var err error
+		entryID, err = newRegistrationEntryID()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	newRegisteredEntry := RegisteredEntry{
-		EntryID:    entryID,
-		SpiffeID:   req.Entry.SpiffeId,
-		ParentID:   req.Entry.ParentId,
-		TTL:        req.Entry.Ttl,
-		Admin:      req.Entry.Admin,
-		Downstream: req.Entry.Downstream,
-		Expiry:     req.Entry.EntryExpiry,
+		EntryID:             entryID,
+		SpiffeID:            req.Entry.SpiffeId,
+		ParentID:            req.Entry.ParentId,
+		TTL:                 req.Entry.Ttl,
+		Admin:               req.Entry.Admin,
+		Downstream:          req.Entry.Downstream,
+		Expiry:              req.Entry.EntryExpiry,
+		X509SVIDPrimaryName: req.Entry.X509SvidPrimaryName,
+		X509SVIDKeyType:     req.Entry.X509SvidKeyType,
+		CreatedBy:           req.Entry.CreatedBy,
+		EntryCreatedAt:      req.Entry.CreatedAt,
+		UpdatedBy:           req.Entry.UpdatedBy,
+		EntryUpdatedAt:      req.Entry.UpdatedAt,
 	}
 
 	if err := tx.Create(&newRegisteredEntry).Error; err != nil {
@@ -1831,7 +2028,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1839,7 +2042,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1852,7 +2055,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1860,7 +2063,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1890,7 +2093,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1898,7 +2107,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1911,7 +2120,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1919,7 +2128,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1946,7 +2155,13 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.x509_svid_primary_name,
+	E.x509_svid_key_type,
+	E.created_by,
+	E.entry_created_at,
+	E.updated_by,
+	E.entry_updated_at
 FROM
 	registered_entries E
 LEFT JOIN
@@ -1983,7 +2198,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1991,7 +2212,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2004,7 +2225,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2012,7 +2233,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2035,8 +2256,8 @@ func countRegistrationEntries(tx *gorm.DB) (*datastore.CountRegistrationEntriesR
 }
 
 func listRegistrationEntries(ctx context.Context, db *sqlDB, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
-	if req.Pagination != nil && req.Pagination.PageSize == 0 {
-		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	if err := validatePagination(req.Pagination); err != nil {
+		return nil, err
 	}
 	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
@@ -2159,7 +2380,7 @@ func listRegistrationEntriesOnce(ctx context.Context, db *sqlDB, req *datastore.
 			PageSize: req.Pagination.PageSize,
 		}
 		if len(resp.Entries) > 0 {
-			resp.Pagination.Token = strconv.FormatUint(lastEID, 10)
+			resp.Pagination.Token = paginationToken(lastEID)
 		}
 	}
 
@@ -2213,7 +2434,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 `)
@@ -2224,7 +2451,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2239,7 +2466,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2250,7 +2477,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2291,7 +2518,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 `)
@@ -2302,7 +2535,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2317,7 +2550,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2328,7 +2561,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2373,7 +2606,13 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.x509_svid_primary_name,
+	E.x509_svid_key_type,
+	E.created_by,
+	E.entry_created_at,
+	E.updated_by,
+	E.entry_updated_at
 FROM
 	registered_entries E
 LEFT JOIN
@@ -2427,7 +2666,13 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	x509_svid_primary_name,
+	x509_svid_key_type,
+	created_by,
+	entry_created_at,
+	updated_by,
+	entry_updated_at
 FROM
 	registered_entries
 `)
@@ -2438,7 +2683,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2453,7 +2698,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2464,7 +2709,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2603,22 +2848,32 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 
 	var root idFilterNode
 
-	if req.ByParentId != nil || req.BySpiffeId != nil {
-		subquery := new(strings.Builder)
-		subquery.WriteString("SELECT id FROM registered_entries WHERE ")
-		if req.ByParentId != nil {
-			subquery.WriteString("parent_id = ?")
-			args = append(args, req.ByParentId.Value)
-		}
-		if req.BySpiffeId != nil {
-			if req.ByParentId != nil {
-				subquery.WriteString(" AND ")
-			}
-			subquery.WriteString("spiffe_id = ?")
-			args = append(args, req.BySpiffeId.Value)
-		}
+	// Filters on columns of the registered_entries table are combined into
+	// a single subquery.
+	var conditions []string
+	if req.ByParentId != nil {
+		conditions = append(conditions, "parent_id = ?")
+		args = append(args, req.ByParentId.Value)
+	}
+	if req.BySpiffeId != nil {
+		conditions = append(conditions, "spiffe_id = ?")
+		args = append(args, req.BySpiffeId.Value)
+	}
+	if req.ByMinTtl != nil {
+		conditions = append(conditions, "ttl >= ?")
+		args = append(args, req.ByMinTtl.Value)
+	}
+	if req.ByMaxTtl != nil {
+		conditions = append(conditions, "ttl <= ?")
+		args = append(args, req.ByMaxTtl.Value)
+	}
+	if req.ByExpiresBefore != nil {
+		conditions = append(conditions, "expiry > 0 AND expiry < ?")
+		args = append(args, req.ByExpiresBefore.Value)
+	}
+	if len(conditions) > 0 {
 		root.children = append(root.children, idFilterNode{
-			query: []string{subquery.String()},
+			query: []string{"SELECT id FROM registered_entries WHERE " + strings.Join(conditions, " AND ")},
 		})
 	}
 
@@ -2663,8 +2918,6 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 			trustDomains = append(trustDomains, td)
 		}
 
-		// Exact/subset federates-with matching requires filtering out all registration
-		// entries whose federated trust domains are not fully represented in the request
 		filterNode := idFilterNode{}
 		filterNode.query = append(filterNode.query, "SELECT E.id")
 		filterNode.query = append(filterNode.query, "FROM registered_entries E")
@@ -2672,15 +2925,19 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 		filterNode.query = append(filterNode.query, "INNER JOIN bundles B ON B.id = FE.bundle_id")
 		filterNode.query = append(filterNode.query, "GROUP BY E.id")
 		filterNode.query = append(filterNode.query, "HAVING")
-		sliceArg := buildSliceArg(len(trustDomains))
-		filterNode.query = append(filterNode.query, "\tCOUNT(CASE WHEN B.trust_domain NOT IN "+sliceArg+" THEN B.trust_domain ELSE NULL END) = 0 AND")
-		for _, td := range trustDomains {
-			args = append(args, td)
+		if req.ByFederatesWith.Match != datastore.ByFederatesWith_MATCH_ANY {
+			// Exact/subset federates-with matching requires filtering out all registration
+			// entries whose federated trust domains are not fully represented in the request
+			sliceArg := buildSliceArg(len(trustDomains))
+			filterNode.query = append(filterNode.query, "\tCOUNT(CASE WHEN B.trust_domain NOT IN "+sliceArg+" THEN B.trust_domain ELSE NULL END) = 0 AND")
+			for _, td := range trustDomains {
+				args = append(args, td)
+			}
 		}
 
 		switch req.ByFederatesWith.Match {
-		case datastore.ByFederatesWith_MATCH_SUBSET:
-			// Subset federates-with matching requires filtering out all registration
+		case datastore.ByFederatesWith_MATCH_SUBSET, datastore.ByFederatesWith_MATCH_ANY:
+			// Subset/any federates-with matching requires filtering out all registration
 			// entries that don't federate with even one trust domain in the request
 			sliceArg := buildSliceArg(len(trustDomains))
 			filterNode.query = append(filterNode.query, "\tCOUNT(CASE WHEN B.trust_domain IN "+sliceArg+" THEN B.trust_domain ELSE NULL END) > 0")
@@ -2729,7 +2986,7 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 			builder.WriteString("SELECT id FROM registered_entries")
 		}
 		if len(req.Pagination.Token) > 0 {
-			token, err := strconv.ParseUint(req.Pagination.Token, 10, 32)
+			token, err := parsePaginationToken(req.Pagination.Token)
 			if err != nil {
 				return false, nil, status.Errorf(codes.InvalidArgument, "could not parse token '%v'", req.Pagination.Token)
 			}
@@ -2853,21 +3110,27 @@ func fillNodeSelectorFromRow(nodeSelector *common.Selector, r *nodeSelectorRow)
 }
 
 type entryRow struct {
-	EId            uint64
-	EntryID        sql.NullString
-	SpiffeID       sql.NullString
-	ParentID       sql.NullString
-	RegTTL         sql.NullInt64
-	Admin          sql.NullBool
-	Downstream     sql.NullBool
-	Expiry         sql.NullInt64
-	SelectorID     sql.NullInt64
-	SelectorType   sql.NullString
-	SelectorValue  sql.NullString
-	TrustDomain    sql.NullString
-	DNSNameID      sql.NullInt64
-	DNSName        sql.NullString
-	RevisionNumber sql.NullInt64
+	EId                 uint64
+	EntryID             sql.NullString
+	SpiffeID            sql.NullString
+	ParentID            sql.NullString
+	RegTTL              sql.NullInt64
+	Admin               sql.NullBool
+	Downstream          sql.NullBool
+	Expiry              sql.NullInt64
+	SelectorID          sql.NullInt64
+	SelectorType        sql.NullString
+	SelectorValue       sql.NullString
+	TrustDomain         sql.NullString
+	DNSNameID           sql.NullInt64
+	DNSName             sql.NullString
+	RevisionNumber      sql.NullInt64
+	X509SVIDPrimaryName sql.NullString
+	X509SVIDKeyType     sql.NullString
+	CreatedBy           sql.NullString
+	CreatedAt           sql.NullInt64
+	UpdatedBy           sql.NullString
+	UpdatedAt           sql.NullInt64
 }
 
 func scanEntryRow(rs *sql.Rows, r *entryRow) error {
@@ -2887,6 +3150,12 @@ func scanEntryRow(rs *sql.Rows, r *entryRow) error {
 		&r.DNSNameID,
 		&r.DNSName,
 		&r.RevisionNumber,
+		&r.X509SVIDPrimaryName,
+		&r.X509SVIDKeyType,
+		&r.CreatedBy,
+		&r.CreatedAt,
+		&r.UpdatedBy,
+		&r.UpdatedAt,
 	))
 }
 
@@ -2915,6 +3184,24 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.RevisionNumber.Valid {
 		entry.RevisionNumber = r.RevisionNumber.Int64
 	}
+	if r.X509SVIDPrimaryName.Valid {
+		entry.X509SvidPrimaryName = r.X509SVIDPrimaryName.String
+	}
+	if r.X509SVIDKeyType.Valid {
+		entry.X509SvidKeyType = r.X509SVIDKeyType.String
+	}
+	if r.CreatedBy.Valid {
+		entry.CreatedBy = r.CreatedBy.String
+	}
+	if r.CreatedAt.Valid {
+		entry.CreatedAt = r.CreatedAt.Int64
+	}
+	if r.UpdatedBy.Valid {
+		entry.UpdatedBy = r.UpdatedBy.String
+	}
+	if r.UpdatedAt.Valid {
+		entry.UpdatedAt = r.UpdatedAt.Int64
+	}
 
 	if r.SelectorType.Valid {
 		if !r.SelectorValue.Valid {
@@ -2936,6 +3223,39 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	return nil
 }
 
+// Listings are paginated by keyset: records are ordered by their primary key
+// and the pagination token is the primary key of the last record of the
+// previous page. Since primary keys are unique and never reused, resuming
+// strictly after the token returns every record that exists for the whole
+// iteration exactly once, regardless of concurrent writes. Records created or
+// deleted mid-iteration may or may not be returned, and records updated after
+// being returned are not returned again.
+func paginationToken(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func parsePaginationToken(token string) (uint64, error) {
+	return strconv.ParseUint(token, 10, 64)
+}
+
+// validatePagination validates the pagination of a listing up front, so
+// malformed tokens are reported as invalid arguments instead of query
+// building errors.
+func validatePagination(p *datastore.Pagination) error {
+	if p == nil {
+		return nil
+	}
+	if p.PageSize == 0 {
+		return status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+	if p.Token != "" {
+		if _, err := parsePaginationToken(p.Token); err != nil {
+			return status.Errorf(codes.InvalidArgument, "could not parse token '%v'", p.Token)
+		}
+	}
+	return nil
+}
+
 // applyPagination  add order limit and token to current query
 func applyPagination(p *datastore.Pagination, entryTx *gorm.DB) (*gorm.DB, error) {
 	if p.PageSize == 0 {
@@ -2944,7 +3264,7 @@ func applyPagination(p *datastore.Pagination, entryTx *gorm.DB) (*gorm.DB, error
 	entryTx = entryTx.Order("id asc").Limit(p.PageSize)
 
 	if len(p.Token) > 0 {
-		id, err := strconv.ParseUint(p.Token, 10, 32)
+		id, err := parsePaginationToken(p.Token)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "could not parse token '%v'", p.Token)
 		}
@@ -3017,6 +3337,16 @@ func updateRegistrationEntry(tx *gorm.DB,
 	if req.Mask == nil || req.Mask.EntryExpiry {
 		entry.Expiry = req.Entry.EntryExpiry
 	}
+	if req.Mask == nil || req.Mask.X509SvidPrimaryName {
+		entry.X509SVIDPrimaryName = req.Entry.X509SvidPrimaryName
+	}
+	if req.Mask == nil || req.Mask.X509SvidKeyType {
+		entry.X509SVIDKeyType = req.Entry.X509SvidKeyType
+	}
+
+	// Provenance of the update is always recorded, regardless of the mask
+	entry.UpdatedBy = req.Entry.UpdatedBy
+	entry.EntryUpdatedAt = req.Entry.UpdatedAt
 
 	// Revision number is increased by 1 on every update call
 	entry.RevisionNumber++
@@ -3085,6 +3415,67 @@ func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 	return nil
 }
 
+func (ds *Plugin) batchRegistrationEntries(tx *gorm.DB, req *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	resp := &datastore.BatchRegistrationEntriesResponse{}
+	for i, op := range req.Operations {
+		if op.IfRevisionNumber != nil {
+			if err := checkRevisionNumber(tx, ds.db.databaseType, op); err != nil {
+				return nil, ds.batchOperationError(i, err)
+			}
+		}
+
+		var entry *common.RegistrationEntry
+		switch {
+		case op.Create != nil:
+			createResp, err := createRegistrationEntry(tx, op.Create)
+			if err != nil {
+				return nil, ds.batchOperationError(i, err)
+			}
+			entry = createResp.Entry
+		case op.Update != nil:
+			updateResp, err := updateRegistrationEntry(tx, op.Update)
+			if err != nil {
+				return nil, ds.batchOperationError(i, err)
+			}
+			entry = updateResp.Entry
+		case op.Delete != nil:
+			deleteResp, err := deleteRegistrationEntry(tx, op.Delete)
+			if err != nil {
+				return nil, ds.batchOperationError(i, err)
+			}
+			entry = deleteResp.Entry
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return resp, nil
+}
+
+// checkRevisionNumber fails with Aborted unless the entry updated or deleted
+// by the operation has the revision number the operation is conditioned on.
+// The entry row is locked until the transaction ends.
+func checkRevisionNumber(tx *gorm.DB, dbType string, op *datastore.RegistrationEntryOperation) error {
+	entryID := op.GetDelete().GetEntryId()
+	if op.Update != nil {
+		entryID = op.Update.Entry.EntryId
+	}
+
+	entry := RegisteredEntry{}
+	if err := lockForUpdate(tx, dbType).Find(&entry, "entry_id = ?", entryID).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if entry.RevisionNumber != op.IfRevisionNumber.Value {
+		return status.Errorf(codes.Aborted, "datastore-sql: registration entry %q is at revision %d, not %d", entryID, entry.RevisionNumber, op.IfRevisionNumber.Value)
+	}
+	return nil
+}
+
+// batchOperationError converts the error returned by the operation at the
+// given index of a batch into a gRPC status that identifies the operation.
+func (ds *Plugin) batchOperationError(i int, err error) error {
+	st := status.Convert(ds.gormToGRPCStatus(err))
+	return status.Errorf(st.Code(), "operation %d: %s", i, st.Message())
+}
+
 func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
 	var registrationEntries []RegisteredEntry
 	if err := tx.Where("expiry != 0").Where("expiry < ?", req.ExpiresBefore).Find(&registrationEntries).Error; err != nil {
@@ -3104,6 +3495,8 @@ func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datas
 	t := JoinToken{
 		Token:  req.JoinToken.Token,
 		Expiry: req.JoinToken.Expiry,
+		UsedAt: req.JoinToken.UsedAt,
+		TTL:    req.JoinToken.Ttl,
 	}
 
 	if err := tx.Create(&t).Error; err != nil {
@@ -3144,6 +3537,81 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 	}, nil
 }
 
+func useJoinToken(tx *gorm.DB, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	var model JoinToken
+	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// The used_at condition makes the update a no-op if a concurrent
+	// attestation already consumed the token.
+	result := tx.Model(&JoinToken{}).Where("id = ? AND used_at = 0", model.ID).Update("used_at", req.UsedAt)
+	if err := result.Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if result.RowsAffected == 0 {
+		return nil, sqlError.New("join token has already been used")
+	}
+
+	model.UsedAt = req.UsedAt
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: modelToJoinToken(model),
+	}, nil
+}
+
+func listJoinTokens(tx *gorm.DB, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	if err := validatePagination(req.Pagination); err != nil {
+		return nil, err
+	}
+
+	if req.ByExpiresBefore != nil {
+		tx = tx.Where("expiry < ?", req.ByExpiresBefore.Value)
+	}
+	if req.ByExpiresAtOrAfter != nil {
+		tx = tx.Where("expiry >= ?", req.ByExpiresAtOrAfter.Value)
+	}
+	if req.ByUsed != nil {
+		if req.ByUsed.Value {
+			tx = tx.Where("used_at != 0")
+		} else {
+			tx = tx.Where("used_at = 0")
+		}
+	}
+
+	p := req.Pagination
+	if p != nil {
+		var err error
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx = tx.Order("token")
+	}
+
+	var models []JoinToken
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListJoinTokensResponse{
+		JoinTokens: make([]*datastore.JoinToken, 0, len(models)),
+	}
+	for _, model := range models {
+		resp.JoinTokens = append(resp.JoinTokens, modelToJoinToken(model))
+	}
+
+	if p != nil {
+		resp.Pagination = &datastore.Pagination{
+			PageSize: p.PageSize,
+		}
+		if len(models) > 0 {
+			resp.Pagination.Token = paginationToken(uint64(models[len(models)-1].ID))
+		}
+	}
+	return resp, nil
+}
+
 func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -3152,6 +3620,120 @@ func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datas
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func checkIntegrity(tx *gorm.DB, req *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	resp := new(datastore.CheckIntegrityResponse)
+	addIssue := func(kind datastore.IntegrityIssue_Kind, format string, args ...interface{}) {
+		resp.Issues = append(resp.Issues, &datastore.IntegrityIssue{
+			Kind:        kind,
+			Description: fmt.Sprintf(format, args...),
+			Repaired:    req.Repair,
+		})
+	}
+
+	var selectors []Selector
+	if err := tx.Where("registered_entry_id NOT IN (SELECT id FROM registered_entries)").Order("id").Find(&selectors).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	var selectorIDs []uint
+	for _, selector := range selectors {
+		selectorIDs = append(selectorIDs, selector.ID)
+		addIssue(datastore.IntegrityIssue_ORPHANED_SELECTOR, "selector %s:%s belongs to missing registration entry %d",
+			selector.Type, selector.Value, selector.RegisteredEntryID)
+	}
+
+	var dnsNames []DNSName
+	if err := tx.Where("registered_entry_id NOT IN (SELECT id FROM registered_entries)").Order("id").Find(&dnsNames).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	var dnsNameIDs []uint
+	for _, dnsName := range dnsNames {
+		dnsNameIDs = append(dnsNameIDs, dnsName.ID)
+		addIssue(datastore.IntegrityIssue_ORPHANED_DNS_NAME, "DNS name %q belongs to missing registration entry %d",
+			dnsName.Value, dnsName.RegisteredEntryID)
+	}
+
+	var federations []struct {
+		BundleID          uint
+		RegisteredEntryID uint
+		TrustDomain       sql.NullString
+		EntryID           sql.NullString
+	}
+	if err := tx.Raw(`SELECT
+			F.bundle_id, F.registered_entry_id, B.trust_domain, E.entry_id
+		FROM
+			federated_registration_entries F
+		LEFT JOIN
+			bundles B ON B.id = F.bundle_id
+		LEFT JOIN
+			registered_entries E ON E.id = F.registered_entry_id
+		WHERE
+			B.id IS NULL OR E.id IS NULL
+		ORDER BY
+			F.registered_entry_id, F.bundle_id`).Scan(&federations).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for _, federation := range federations {
+		switch {
+		case !federation.EntryID.Valid && !federation.TrustDomain.Valid:
+			addIssue(datastore.IntegrityIssue_DANGLING_FEDERATION, "missing registration entry %d is federated with missing bundle %d",
+				federation.RegisteredEntryID, federation.BundleID)
+		case !federation.EntryID.Valid:
+			addIssue(datastore.IntegrityIssue_DANGLING_FEDERATION, "missing registration entry %d is federated with %q",
+				federation.RegisteredEntryID, federation.TrustDomain.String)
+		default:
+			addIssue(datastore.IntegrityIssue_DANGLING_FEDERATION, "registration entry %q is federated with missing bundle %d",
+				federation.EntryID.String, federation.BundleID)
+		}
+	}
+
+	var nodes []AttestedNode
+	if err := tx.Where("serial_number = '' AND new_serial_number != ''").Order("id").Find(&nodes).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for _, node := range nodes {
+		addIssue(datastore.IntegrityIssue_NODE_WITHOUT_SERIAL, "attested node %q has a pending SVID serial number but no current one",
+			node.SpiffeID)
+	}
+
+	if !req.Repair {
+		return resp, nil
+	}
+
+	if len(selectorIDs) > 0 {
+		if err := tx.Where("id IN (?)", selectorIDs).Delete(&Selector{}).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+	if len(dnsNameIDs) > 0 {
+		if err := tx.Where("id IN (?)", dnsNameIDs).Delete(&DNSName{}).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+	for _, federation := range federations {
+		if err := tx.Exec(bindVars(tx, "DELETE FROM federated_registration_entries WHERE bundle_id = ? AND registered_entry_id = ?"),
+			federation.BundleID, federation.RegisteredEntryID).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+	// The pending SVID is the only one the node could still authenticate
+	// with, so it becomes the current one.
+	for i, node := range nodes {
+		updates := map[string]interface{}{
+			"serial_number":     node.NewSerialNumber,
+			"new_serial_number": "",
+			"new_expires_at":    nil,
+		}
+		if node.NewExpiresAt != nil {
+			updates["expires_at"] = *node.NewExpiresAt
+		}
+		if err := tx.Model(&nodes[i]).Updates(updates).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	return resp, nil
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -3206,6 +3788,32 @@ func validateRegistrationEntryForUpdate(entry *common.RegistrationEntry, mask *c
 	return nil
 }
 
+func validateRegistrationEntryOperation(op *datastore.RegistrationEntryOperation) error {
+	n := 0
+	if op.Create != nil {
+		n++
+	}
+	if op.Update != nil {
+		n++
+	}
+	if op.Delete != nil {
+		n++
+	}
+	if n != 1 {
+		return sqlError.New("invalid request: exactly one of create, update or delete must be set")
+	}
+
+	switch {
+	case op.Create != nil && op.IfRevisionNumber != nil:
+		return sqlError.New("invalid request: revision number condition is only valid for updates and deletes")
+	case op.Create != nil:
+		return validateRegistrationEntry(op.Create.Entry)
+	case op.Update != nil:
+		return validateRegistrationEntryForUpdate(op.Update.Entry, op.Update.Mask)
+	}
+	return nil
+}
+
 // bundleToModel converts the given Protobuf bundle message to a database model. It
 // performs validation, and fully parses certificates to form CACert embedded models.
 func bundleToModel(pb *common.Bundle) (*Bundle, error) {
@@ -3266,17 +3874,23 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 	}
 
 	return &common.RegistrationEntry{
-		EntryId:        model.EntryID,
-		Selectors:      selectors,
-		SpiffeId:       model.SpiffeID,
-		ParentId:       model.ParentID,
-		Ttl:            model.TTL,
-		FederatesWith:  federatesWith,
-		Admin:          model.Admin,
-		Downstream:     model.Downstream,
-		EntryExpiry:    model.Expiry,
-		DnsNames:       dnsList,
-		RevisionNumber: model.RevisionNumber,
+		EntryId:             model.EntryID,
+		Selectors:           selectors,
+		SpiffeId:            model.SpiffeID,
+		ParentId:            model.ParentID,
+		Ttl:                 model.TTL,
+		FederatesWith:       federatesWith,
+		Admin:               model.Admin,
+		Downstream:          model.Downstream,
+		EntryExpiry:         model.Expiry,
+		DnsNames:            dnsList,
+		RevisionNumber:      model.RevisionNumber,
+		X509SvidPrimaryName: model.X509SVIDPrimaryName,
+		X509SvidKeyType:     model.X509SVIDKeyType,
+		CreatedBy:           model.CreatedBy,
+		CreatedAt:           model.EntryCreatedAt,
+		UpdatedBy:           model.UpdatedBy,
+		UpdatedAt:           model.EntryUpdatedAt,
 	}, nil
 }
 
@@ -3303,6 +3917,8 @@ func modelToJoinToken(model JoinToken) *datastore.JoinToken {
 	return &datastore.JoinToken{
 		Token:  model.Token,
 		Expiry: model.Expiry,
+		UsedAt: model.UsedAt,
+		Ttl:    model.TTL,
 	}
 }
 
@@ -3370,6 +3986,22 @@ func (cfg *configuration) Validate() error {
 		}
 	}
 
+	for name, replica := range cfg.ReadReplicas {
+		if name == roConnectionStringEndpoint || name == primaryEndpoint {
+			return fmt.Errorf("read_replica %q: name is reserved", name)
+		}
+		if replica.ConnectionString == "" {
+			return fmt.Errorf("read_replica %q: connection_string must be set", name)
+		}
+		if cfg.DatabaseType == MySQL {
+			replicaCfg := *cfg
+			replicaCfg.RoConnectionString = replica.ConnectionString
+			if err := validateMySQLConfig(&replicaCfg, true); err != nil {
+				return fmt.Errorf("read_replica %q: %w", name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
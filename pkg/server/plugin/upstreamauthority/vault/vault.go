@@ -44,6 +44,8 @@ type PluginConfig struct {
 	CertAuth *CertAuthConfig `hcl:"cert_auth"`
 	// Configuration for the AppRole authentication method
 	AppRoleAuth *AppRoleAuthConfig `hcl:"approle_auth"`
+	// Configuration for the Kubernetes authentication method
+	K8sAuth *K8sAuthConfig `hcl:"k8s_auth"`
 	// Path to a CA certificate file that the client verifies the server certificate.
 	// Only PEM format is supported.
 	CACertPath string `hcl:"ca_cert_path"`
@@ -87,6 +89,19 @@ type AppRoleAuthConfig struct {
 	SecretID string `hcl:"approle_secret_id"`
 }
 
+// K8sAuthConfig represents parameters for Kubernetes auth method.
+type K8sAuthConfig struct {
+	// Name of the mount point where Kubernetes auth method is mounted. (e.g., /auth/<mount_point>/login)
+	// If the value is empty, use default mount point (/auth/kubernetes)
+	K8sAuthMountPoint string `hcl:"k8s_auth_mount_point"`
+	// Name of the Vault role.
+	// The plugin authenticates against the named role.
+	K8sAuthRoleName string `hcl:"k8s_auth_role_name"`
+	// Path to the Kubernetes Service Account Token to use authentication with the Vault.
+	// If the value is empty, use the default path of the projected service account token.
+	TokenPath string `hcl:"token_path"`
+}
+
 type Plugin struct {
 	upstreamauthority.UnsafeUpstreamAuthorityServer
 
@@ -237,12 +252,21 @@ func parseAuthMethod(config *PluginConfig) (AuthMethod, error) {
 		}
 		authMethod = APPROLE
 	}
+	if config.K8sAuth != nil {
+		if err := checkForAuthMethodConfigured(authMethod); err != nil {
+			return 0, err
+		}
+		if config.K8sAuth.K8sAuthRoleName == "" {
+			return 0, errors.New("k8s_auth_role_name is required")
+		}
+		authMethod = K8S
+	}
 
 	if authMethod != 0 {
 		return authMethod, nil
 	}
 
-	return 0, errors.New("must be configured one of these authentication method 'Token or Cert or AppRole'")
+	return 0, errors.New("must be configured one of these authentication method 'Token or Cert or AppRole or K8s'")
 }
 
 func checkForAuthMethodConfigured(authMethod AuthMethod) error {
@@ -273,6 +297,10 @@ func genClientParams(method AuthMethod, config *PluginConfig) *ClientParams {
 		cp.AppRoleAuthMountPoint = config.AppRoleAuth.AppRoleMountPoint
 		cp.AppRoleID = getEnvOrDefault(envVaultAppRoleID, config.AppRoleAuth.RoleID)
 		cp.AppRoleSecretID = getEnvOrDefault(envVaultAppRoleSecretID, config.AppRoleAuth.SecretID)
+	case K8S:
+		cp.K8sAuthMountPoint = config.K8sAuth.K8sAuthMountPoint
+		cp.K8sAuthRoleName = config.K8sAuth.K8sAuthRoleName
+		cp.K8sAuthTokenPath = config.K8sAuth.TokenPath
 	}
 
 	return cp
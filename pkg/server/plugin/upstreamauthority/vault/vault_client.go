@@ -6,6 +6,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -29,6 +30,8 @@ const (
 	defaultCertMountPoint    = "cert"
 	defaultPKIMountPoint     = "pki"
 	defaultAppRoleMountPoint = "approle"
+	defaultK8sMountPoint     = "kubernetes"
+	defaultK8sTokenPath      = "/var/run/secrets/kubernetes.io/serviceaccount/token" //// #nosec G101
 )
 
 type AuthMethod int
@@ -38,6 +41,7 @@ const (
 	CERT
 	TOKEN
 	APPROLE
+	K8S
 )
 
 type TokenStatus int
@@ -80,6 +84,12 @@ type ClientParams struct {
 	AppRoleID string
 	// A credential set of AppRole
 	AppRoleSecretID string
+	// Name of the mount point where Kubernetes auth method is mounted. (e.g., /auth/<mount_point>/login)
+	K8sAuthMountPoint string
+	// Name of the Vault role to use when auth method is 'k8s'
+	K8sAuthRoleName string
+	// Path to a Kubernetes Service Account Token file to be used when auth method is 'k8s'
+	K8sAuthTokenPath string
 	// If true, client accepts any certificates.
 	// It should be used only test environment so on.
 	TLSSKipVerify bool
@@ -114,6 +124,8 @@ func NewClientConfig(cp *ClientParams, logger hclog.Logger) (*ClientConfig, erro
 	defaultParams := &ClientParams{
 		CertAuthMountPoint:    defaultCertMountPoint,
 		AppRoleAuthMountPoint: defaultAppRoleMountPoint,
+		K8sAuthMountPoint:     defaultK8sMountPoint,
+		K8sAuthTokenPath:      defaultK8sTokenPath,
 		PKIMountPoint:         defaultPKIMountPoint,
 	}
 	if err := mergo.Merge(cp, defaultParams); err != nil {
@@ -187,6 +199,28 @@ func (c *ClientConfig) NewAuthenticatedClient(method AuthMethod) (client *Client
 		if sec == nil {
 			return nil, false, errors.New("approle authentication response is nil")
 		}
+	case K8S:
+		// The token is read at each authentication since Kubernetes rotates
+		// projected service account tokens.
+		jwt, err := ioutil.ReadFile(c.clientParams.K8sAuthTokenPath)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read k8s service account token: %v", err)
+		}
+		if len(jwt) == 0 {
+			return nil, false, fmt.Errorf("k8s service account token file %q is empty", c.clientParams.K8sAuthTokenPath)
+		}
+		path := fmt.Sprintf("auth/%s/login", c.clientParams.K8sAuthMountPoint)
+		body := map[string]interface{}{
+			"role": c.clientParams.K8sAuthRoleName,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		}
+		sec, err = client.Auth(path, body)
+		if err != nil {
+			return nil, false, err
+		}
+		if sec == nil {
+			return nil, false, errors.New("k8s authentication response is nil")
+		}
 	}
 
 	ts, err = handleRenewToken(vc, sec, c.Logger)
@@ -3,9 +3,11 @@ package vault
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
@@ -25,6 +27,7 @@ const (
 	testInvalidClientCert = "_test_data/keys/EC/invalid_client_cert.pem"
 	testInvalidClientKey  = "_test_data/keys/EC/invalid_client_key.pem"
 	testReqCSR            = "_test_data/keys/EC/intermediate_csr.pem"
+	testK8sToken          = "_test_data/k8s/token"
 )
 
 func testClientCertificatePair() (tls.Certificate, error) {
@@ -78,6 +81,8 @@ func (vcs *VaultClientSuite) Test_NewClientConfig_WithDefaultMountPoint() {
 		Token:                 "test-token",
 		CertAuthMountPoint:    "", // Expect the default value to be used.
 		AppRoleAuthMountPoint: "", // Expect the default value to be used.
+		K8sAuthMountPoint:     "", // Expect the default value to be used.
+		K8sAuthTokenPath:      "", // Expect the default value to be used.
 	}
 
 	cc, err := NewClientConfig(p, hclog.Default())
@@ -85,6 +90,8 @@ func (vcs *VaultClientSuite) Test_NewClientConfig_WithDefaultMountPoint() {
 	vcs.Require().Equal(defaultPKIMountPoint, cc.clientParams.PKIMountPoint)
 	vcs.Require().Equal(defaultCertMountPoint, cc.clientParams.CertAuthMountPoint)
 	vcs.Require().Equal(defaultAppRoleMountPoint, cc.clientParams.AppRoleAuthMountPoint)
+	vcs.Require().Equal(defaultK8sMountPoint, cc.clientParams.K8sAuthMountPoint)
+	vcs.Require().Equal(defaultK8sTokenPath, cc.clientParams.K8sAuthTokenPath)
 }
 
 func (vcs *VaultClientSuite) Test_NewClientConfig_WithGivenMontPoint() {
@@ -324,6 +331,117 @@ func (vcs *VaultClientSuite) Test_NewAuthenticatedClient_AppRoleAuthFailed() {
 	vcs.Require().Error(err)
 }
 
+func (vcs *VaultClientSuite) Test_NewAuthenticatedClient_K8sAuth() {
+	for _, c := range []struct {
+		name      string
+		response  []byte
+		reusable  bool
+		namespace string
+	}{
+		{
+			name:     "K8s Authentication success / Token is renewable",
+			response: []byte(testK8sAuthResponse),
+			reusable: true,
+		},
+		{
+			name:     "K8s Authentication success / Token is not renewable",
+			response: []byte(testK8sAuthResponseNotRenewable),
+		},
+		{
+			name:      "K8s Authentication success / Token is renewable / Namespace is given",
+			response:  []byte(testK8sAuthResponse),
+			reusable:  true,
+			namespace: "test-ns",
+		},
+	} {
+		c := c
+		vcs.Run(c.name, func() {
+			var loginBody map[string]interface{}
+			vcs.fakeVaultServer.K8sAuthResponseCode = 200
+			vcs.fakeVaultServer.K8sAuthResponse = c.response
+			vcs.fakeVaultServer.K8sAuthReqEndpoint = "/v1/auth/test-k8s-auth/login"
+			vcs.fakeVaultServer.K8sAuthReqHandler = func(code int, resp []byte) func(http.ResponseWriter, *http.Request) {
+				return func(w http.ResponseWriter, r *http.Request) {
+					_ = json.NewDecoder(r.Body).Decode(&loginBody)
+					defaultReqHandler(code, resp)(w, r)
+				}
+			}
+
+			s, addr, err := vcs.fakeVaultServer.NewTLSServer()
+			vcs.Require().NoError(err)
+
+			s.Start()
+			defer s.Close()
+
+			cp := &ClientParams{
+				VaultAddr:         fmt.Sprintf("https://%v/", addr),
+				Namespace:         c.namespace,
+				CACertPath:        testRootCert,
+				K8sAuthMountPoint: "test-k8s-auth",
+				K8sAuthRoleName:   "my-role",
+				K8sAuthTokenPath:  testK8sToken,
+			}
+			cc, err := NewClientConfig(cp, hclog.Default())
+			vcs.Require().NoError(err)
+
+			client, reusable, err := cc.NewAuthenticatedClient(K8S)
+			vcs.Require().NoError(err)
+			vcs.Require().Equal(c.reusable, reusable)
+
+			token, err := ioutil.ReadFile(testK8sToken)
+			vcs.Require().NoError(err)
+			vcs.Require().Equal(map[string]interface{}{
+				"role": "my-role",
+				"jwt":  strings.TrimSpace(string(token)),
+			}, loginBody)
+
+			if cp.Namespace != "" {
+				headers := client.vaultClient.Headers()
+				vcs.Require().Equal(cp.Namespace, headers.Get(consts.NamespaceHeaderName))
+			}
+		})
+	}
+}
+
+func (vcs *VaultClientSuite) Test_NewAuthenticatedClient_K8sAuthFailed() {
+	vcs.fakeVaultServer.K8sAuthResponseCode = 500
+
+	s, addr, err := vcs.fakeVaultServer.NewTLSServer()
+	vcs.Require().NoError(err)
+
+	s.Start()
+	defer s.Close()
+
+	retry := 0 // Disable retry
+	cp := &ClientParams{
+		MaxRetries:       &retry,
+		VaultAddr:        fmt.Sprintf("https://%v/", addr),
+		CACertPath:       testRootCert,
+		K8sAuthRoleName:  "my-role",
+		K8sAuthTokenPath: testK8sToken,
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	_, _, err = cc.NewAuthenticatedClient(K8S)
+	vcs.Require().Error(err)
+}
+
+func (vcs *VaultClientSuite) Test_NewAuthenticatedClient_K8sAuthInvalidTokenPath() {
+	cp := &ClientParams{
+		VaultAddr:        "https://example.org:8200/",
+		CACertPath:       testRootCert,
+		K8sAuthRoleName:  "my-role",
+		K8sAuthTokenPath: "_test_data/k8s/non-existent-token",
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	_, _, err = cc.NewAuthenticatedClient(K8S)
+	vcs.Require().Error(err)
+	vcs.Require().Contains(err.Error(), "failed to read k8s service account token")
+}
+
 func (vcs *VaultClientSuite) Test_ConfigureTLS_WithCertAuth() {
 	cp := &ClientParams{
 		VaultAddr:      "http://example.org:8200",
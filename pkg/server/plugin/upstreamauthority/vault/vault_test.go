@@ -56,6 +56,9 @@ func (vps *VaultPluginSuite) Test_Configure() {
 	vps.fakeVaultServer.AppRoleAuthResponseCode = 200
 	vps.fakeVaultServer.AppRoleAuthResponse = []byte(testAppRoleAuthResponse)
 	vps.fakeVaultServer.AppRoleAuthReqEndpoint = "/v1/auth/test-approle-auth/login"
+	vps.fakeVaultServer.K8sAuthResponseCode = 200
+	vps.fakeVaultServer.K8sAuthResponse = []byte(testK8sAuthResponse)
+	vps.fakeVaultServer.K8sAuthReqEndpoint = "/v1/auth/test-k8s-auth/login"
 
 	s, addr, err := vps.fakeVaultServer.NewTLSServer()
 	vps.Require().NoError(err)
@@ -112,6 +115,16 @@ func (vps *VaultPluginSuite) Test_Configure() {
 			},
 			wantAuth: APPROLE,
 		},
+		{
+			name:       "Configure plugin with Kubernetes authentication params given in config file",
+			configTmpl: testK8sAuthConfigTpl,
+			wantAuth:   K8S,
+		},
+		{
+			name:       "Configure plugin with Kubernetes authentication without role name",
+			configTmpl: testK8sAuthNoRoleNameConfigTpl,
+			err:        "k8s_auth_role_name is required",
+		},
 		{
 			name:       "Multiple authentication methods configured",
 			configTmpl: testMultipleAuthConfigsTpl,
@@ -166,6 +179,10 @@ func (vps *VaultPluginSuite) Test_Configure() {
 				vps.Require().NotNil(p.cc.clientParams.AppRoleAuthMountPoint)
 				vps.Require().NotNil(p.cc.clientParams.AppRoleID)
 				vps.Require().NotNil(p.cc.clientParams.AppRoleSecretID)
+			case K8S:
+				vps.Require().Equal("test-k8s-auth", p.cc.clientParams.K8sAuthMountPoint)
+				vps.Require().Equal("my-role", p.cc.clientParams.K8sAuthRoleName)
+				vps.Require().Equal("_test_data/k8s/token", p.cc.clientParams.K8sAuthTokenPath)
 			}
 
 			if c.wantNamespaceIsNotNil {
@@ -194,6 +211,7 @@ func (vps *VaultPluginSuite) Test_MintX509CA() {
 		lookupSelfResp       []byte
 		certAuthResp         []byte
 		appRoleAuthResp      []byte
+		k8sAuthResp          []byte
 		signIntermediateResp []byte
 		config               *PluginConfig
 		authMethod           AuthMethod
@@ -274,6 +292,22 @@ func (vps *VaultPluginSuite) Test_MintX509CA() {
 			authMethod: APPROLE,
 			reuseToken: true,
 		},
+		{
+			name:                 "Mint X509CA SVID with K8S authentication",
+			k8sAuthResp:          []byte(testK8sAuthResponse),
+			signIntermediateResp: []byte(testSignIntermediateResponse),
+			config: &PluginConfig{
+				CACertPath:    "_test_data/keys/EC/root_cert.pem",
+				PKIMountPoint: "test-pki",
+				K8sAuth: &K8sAuthConfig{
+					K8sAuthMountPoint: "test-k8s-auth",
+					K8sAuthRoleName:   "my-role",
+					TokenPath:         "_test_data/k8s/token",
+				},
+			},
+			authMethod: K8S,
+			reuseToken: true,
+		},
 		{
 			name:                 "Mint X509CA SVID with TLS cert authentication / Token is not renewable",
 			certAuthResp:         []byte(testCertAuthResponseNotRenewable),
@@ -305,6 +339,21 @@ func (vps *VaultPluginSuite) Test_MintX509CA() {
 			},
 			authMethod: APPROLE,
 		},
+		{
+			name:                 "Mint X509CA SVID with K8S authentication / Token is not renewable",
+			k8sAuthResp:          []byte(testK8sAuthResponseNotRenewable),
+			signIntermediateResp: []byte(testSignIntermediateResponse),
+			config: &PluginConfig{
+				CACertPath:    "_test_data/keys/EC/root_cert.pem",
+				PKIMountPoint: "test-pki",
+				K8sAuth: &K8sAuthConfig{
+					K8sAuthMountPoint: "test-k8s-auth",
+					K8sAuthRoleName:   "my-role",
+					TokenPath:         "_test_data/k8s/token",
+				},
+			},
+			authMethod: K8S,
+		},
 		{
 			name:                 "Mint X509CA SVID with Namespace",
 			lookupSelfResp:       []byte(testLookupSelfResponse),
@@ -343,6 +392,9 @@ func (vps *VaultPluginSuite) Test_MintX509CA() {
 			vps.fakeVaultServer.AppRoleAuthResponseCode = 200
 			vps.fakeVaultServer.AppRoleAuthResponse = c.appRoleAuthResp
 			vps.fakeVaultServer.AppRoleAuthReqEndpoint = "/v1/auth/test-approle-auth/login"
+			vps.fakeVaultServer.K8sAuthResponseCode = 200
+			vps.fakeVaultServer.K8sAuthResponse = c.k8sAuthResp
+			vps.fakeVaultServer.K8sAuthReqEndpoint = "/v1/auth/test-k8s-auth/login"
 			vps.fakeVaultServer.LookupSelfResponse = c.lookupSelfResp
 			vps.fakeVaultServer.LookupSelfResponseCode = 200
 			vps.fakeVaultServer.SignIntermediateResponseCode = 200
@@ -12,9 +12,19 @@ import (
 )
 
 const (
-	_pruningCandence = 5 * time.Minute
+	_pruningCandence       = 5 * time.Minute
+	_integrityCheckCadence = time.Hour
 )
 
+// integrityIssueKinds are the kinds of datastore integrity issues reported
+// after each check, so that kinds no longer found are reported as zero.
+var integrityIssueKinds = []datastore.IntegrityIssue_Kind{
+	datastore.IntegrityIssue_ORPHANED_SELECTOR,
+	datastore.IntegrityIssue_ORPHANED_DNS_NAME,
+	datastore.IntegrityIssue_DANGLING_FEDERATION,
+	datastore.IntegrityIssue_NODE_WITHOUT_SERIAL,
+}
+
 // ManagerConfig is the config for the registration manager
 type ManagerConfig struct {
 	DataStore datastore.DataStore
@@ -45,22 +55,26 @@ func NewManager(c ManagerConfig) *Manager {
 	}
 }
 
-// Run runs the registration manager
+// Run runs the registration manager. Besides pruning expired registration
+// entries, it periodically checks the datastore for inconsistencies, which
+// are reported but not repaired.
 func (m *Manager) Run(ctx context.Context) error {
-	return m.pruneEvery(ctx)
-}
-
-func (m *Manager) pruneEvery(ctx context.Context) error {
-	ticker := m.c.Clock.Ticker(_pruningCandence)
-	defer ticker.Stop()
+	pruneTicker := m.c.Clock.Ticker(_pruningCandence)
+	defer pruneTicker.Stop()
+	integrityTicker := m.c.Clock.Ticker(_integrityCheckCadence)
+	defer integrityTicker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-pruneTicker.C:
 			// Log an error on failure unless we're shutting down
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+		case <-integrityTicker.C:
+			if err := m.checkIntegrity(ctx); err != nil && ctx.Err() == nil {
+				m.c.Log.WithError(err).Error("Failed checking datastore integrity")
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -76,3 +90,26 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	})
 	return err
 }
+
+func (m *Manager) checkIntegrity(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerCheckIntegrityCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	resp, err := m.c.DataStore.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{})
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[datastore.IntegrityIssue_Kind]int)
+	for _, issue := range resp.Issues {
+		counts[issue.Kind]++
+		m.c.Log.WithFields(logrus.Fields{
+			telemetry.Kind:        issue.Kind.String(),
+			telemetry.Description: issue.Description,
+		}).Warn("Datastore integrity issue found; run `spire-server datastore check -fix` to fix it")
+	}
+	for _, kind := range integrityIssueKinds {
+		telemetry_server.SetIntegrityIssuesGauge(m.c.Metrics, kind.String(), counts[kind])
+	}
+	return nil
+}
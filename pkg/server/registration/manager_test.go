@@ -7,6 +7,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
@@ -46,7 +47,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires right on the pruning time
 	entry1 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test1",
 		Selectors: []*common.Selector{
@@ -66,7 +66,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires in pruning time + one minute
 	entry2 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test2",
 		Selectors: []*common.Selector{
@@ -86,7 +85,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires in pruning time + two minutes
 	entry3 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test3",
 		Selectors: []*common.Selector{
@@ -132,6 +130,52 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
+func (s *ManagerSuite) TestIntegrityCheck() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            "spiffe://test.test/spire/agent/test/node",
+			AttestationDataType: "test",
+			NewCertSerialNumber: "1234",
+		},
+	})
+	s.Require().NoError(err)
+
+	s.NoError(s.m.checkIntegrity(context.Background()))
+
+	spiretest.AssertLogs(s.T(), s.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Datastore integrity issue found; run `spire-server datastore check -fix` to fix it",
+			Data: logrus.Fields{
+				telemetry.Kind:        "NODE_WITHOUT_SERIAL",
+				telemetry.Description: `attested node "spiffe://test.test/spire/agent/test/node" has a pending SVID serial number but no current one`,
+			},
+		},
+	})
+
+	gauges := make(map[string]float32)
+	for _, metric := range s.metrics.AllMetrics() {
+		if metric.Type == fakemetrics.SetGaugeWithLabelsType {
+			s.Equal([]string{"registration_entry", "manager", "integrity", "count"}, metric.Key)
+			gauges[metric.Labels[0].Value] = metric.Val
+		}
+	}
+	s.Equal(map[string]float32{
+		"ORPHANED_SELECTOR":   0,
+		"ORPHANED_DNS_NAME":   0,
+		"DANGLING_FEDERATION": 0,
+		"NODE_WITHOUT_SERIAL": 1,
+	}, gauges)
+
+	// Issues are reported, not repaired
+	resp, err := s.ds.CheckIntegrity(context.Background(), &datastore.CheckIntegrityRequest{})
+	s.Require().NoError(err)
+	s.Len(resp.Issues, 1)
+}
+
 func (s *ManagerSuite) setupAndRunManager() func() {
 	s.m = NewManager(ManagerConfig{
 		Clock:     s.clock,
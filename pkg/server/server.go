@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
 	"net/url"
@@ -11,7 +12,11 @@ import (
 	"runtime"
 	"sync"
 
-	"github.com/andres-erbsen/clock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/sirupsen/logrus"
 	server_util "github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
@@ -23,6 +28,7 @@ import (
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/ec2inventory"
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
 	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
@@ -31,6 +37,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/registration"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"google.golang.org/grpc"
 )
 
@@ -75,7 +82,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 
 	metrics, err := telemetry.NewMetrics(&telemetry.MetricsConfig{
 		FileConfig:  s.config.Telemetry,
-		Logger:      s.config.Log.WithField(telemetry.SubsystemName, telemetry.Telemetry),
+		Logger:      s.subsystemLog(telemetry.Telemetry),
 		ServiceName: telemetry.SpireServer,
 	})
 	if err != nil {
@@ -86,6 +93,8 @@ func (s *Server) run(ctx context.Context) (err error) {
 	})
 
 	telemetry.EmitVersion(metrics)
+	s.emitLifecycleStarted(metrics)
+	defer s.emitLifecycleStopped(metrics)
 	uptime.ReportMetrics(ctx, metrics)
 
 	// Create the identity provider host service. It will not be functional
@@ -165,7 +174,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
 
-	err = util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		caManager.Run,
 		svidRotator.Run,
 		endpointsServer.ListenAndServe,
@@ -173,7 +182,18 @@ func (s *Server) run(ctx context.Context) (err error) {
 		bundleManager.Run,
 		registrationManager.Run,
 		healthChecks.ListenAndServe,
-	)
+	}
+
+	if s.config.Experimental.EC2Inventory != nil {
+		ec2Reconciler, closeConn, err := s.newEC2InventoryReconciler(ctx, metrics)
+		if err != nil {
+			return err
+		}
+		defer closeConn()
+		tasks = append(tasks, ec2Reconciler.Run)
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
@@ -239,26 +259,33 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 func (s *Server) loadCatalog(ctx context.Context, metrics telemetry.Metrics, identityProvider hostservices.IdentityProviderServer, agentStore hostservices.AgentStoreServer,
 	metricsService common_services.MetricsServiceServer) (*catalog.Repository, error) {
 	return catalog.Load(ctx, catalog.Config{
-		Log: s.config.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		Log:       s.subsystemLog(telemetry.Catalog),
+		PluginLog: s.pluginLog,
 		GlobalConfig: &catalog.GlobalConfig{
 			TrustDomain: s.config.TrustDomain.String(),
 		},
 		PluginConfig:     s.config.PluginConfigs,
+		PluginPolicy:     s.config.PluginPolicy,
 		Metrics:          metrics,
 		IdentityProvider: identityProvider,
 		AgentStore:       agentStore,
 		MetricsService:   metricsService,
+		Clock:            s.config.Clock,
+		DataStore:        s.config.DataStore,
 	})
 }
 
 func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
 	return ca.NewCA(ca.Config{
-		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
-		Metrics:     metrics,
-		X509SVIDTTL: s.config.SVIDTTL,
-		JWTIssuer:   s.config.JWTIssuer,
-		TrustDomain: s.config.TrustDomain,
-		CASubject:   s.config.CASubject,
+		Log:                 s.subsystemLog(telemetry.CA),
+		Metrics:             metrics,
+		X509SVIDTTL:         s.config.SVIDTTL,
+		X509SVIDPrimaryName: s.config.X509SVIDPrimaryName,
+		JWTIssuer:           s.config.JWTIssuer,
+		TrustDomain:         s.config.TrustDomain,
+		CASubject:           s.config.CASubject,
+		Clock:               s.config.Clock,
+		TTLPolicies:         s.config.SVIDTTLPolicies,
 	})
 }
 
@@ -267,13 +294,17 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 		CA:            serverCA,
 		Catalog:       cat,
 		TrustDomain:   s.config.TrustDomain,
-		Log:           s.config.Log.WithField(telemetry.SubsystemName, telemetry.CAManager),
+		Log:           s.subsystemLog(telemetry.CAManager),
 		Metrics:       metrics,
 		CATTL:         s.config.CATTL,
 		CASubject:     s.config.CASubject,
 		Dir:           s.config.DataDir,
 		X509CAKeyType: s.config.CAKeyType,
 		JWTKeyType:    s.config.CAKeyType,
+		Clock:         s.config.Clock,
+
+		UpstreamBundlePollInterval: s.config.UpstreamBundlePollInterval,
+		CAPrePublicationWindow:     s.config.CAPrePublicationWindow,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -284,8 +315,9 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
 		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Log:       s.subsystemLog(telemetry.RegistrationManager),
 		Metrics:   metrics,
+		Clock:     s.config.Clock,
 	})
 	return registrationManager
 }
@@ -293,9 +325,10 @@ func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.M
 func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metrics telemetry.Metrics) (*svid.Rotator, error) {
 	svidRotator := svid.NewRotator(&svid.RotatorConfig{
 		ServerCA:    serverCA,
-		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.SVIDRotator),
+		Log:         s.subsystemLog(telemetry.SVIDRotator),
 		Metrics:     metrics,
 		TrustDomain: s.config.TrustDomain,
+		Clock:       s.config.Clock,
 	})
 	if err := svidRotator.Initialize(ctx); err != nil {
 		return nil, err
@@ -307,32 +340,95 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 	config := endpoints.Config{
 		TCPAddr:                     s.config.BindAddress,
 		UDSAddr:                     s.config.BindUDSAddress,
+		AdminUDSAddr:                s.config.AdminBindUDSAddress,
+		AdminUDSMode:                s.config.AdminUDSMode,
+		APIGatewayAddr:              s.config.Experimental.APIGatewayAddress,
 		SVIDObserver:                svidObserver,
 		TrustDomain:                 s.config.TrustDomain,
 		Catalog:                     catalog,
 		ServerCA:                    serverCA,
-		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		Log:                         s.subsystemLog(telemetry.Endpoints),
 		Metrics:                     metrics,
+		LogLevels:                   s.config.LogLevels,
 		Manager:                     caManager,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
+		ExperimentalFeatures:        s.config.Experimental.Enabled(),
 		RateLimit:                   s.config.RateLimit,
+		BundleLimits:                s.config.BundleLimits,
+		EntryQuotas:                 s.config.EntryQuotas,
+		DataStoreTimeout:            s.config.DataStoreTimeout,
+		ClockSkewTolerance:          s.config.ClockSkewTolerance,
 		Uptime:                      uptime.Uptime,
-		Clock:                       clock.New(),
+		Clock:                       s.config.Clock,
 	}
 	if s.config.Federation.BundleEndpoint != nil {
 		config.BundleEndpoint.Address = s.config.Federation.BundleEndpoint.Address
 		config.BundleEndpoint.ACME = s.config.Federation.BundleEndpoint.ACME
+		config.BundleEndpoint.MirroredTrustDomains = s.config.Federation.BundleEndpoint.MirroredTrustDomains
 	}
 	return endpoints.New(ctx, config)
 }
 
 func (s *Server) newBundleManager(cat catalog.Catalog, metrics telemetry.Metrics) *bundle_client.Manager {
 	return bundle_client.NewManager(bundle_client.ManagerConfig{
-		Log:          s.config.Log.WithField(telemetry.SubsystemName, "bundle_client"),
+		Log:          s.subsystemLog("bundle_client"),
 		Metrics:      metrics,
 		DataStore:    cat.GetDataStore(),
 		TrustDomains: s.config.Federation.FederatesWith,
+		Clock:        s.config.Clock,
+
+		StaleRefreshHints:      s.config.Federation.StaleBundleRefreshHints,
+		ExpiryWarningThreshold: s.config.Federation.BundleExpiryWarningThreshold,
+	})
+}
+
+// newEC2InventoryReconciler creates the EC2 inventory reconciler. The
+// reconciler manages entries through the Entry API served on the admin UDS
+// endpoint. The connection is established lazily so that the reconciler can
+// be created before the endpoints are serving.
+func (s *Server) newEC2InventoryReconciler(ctx context.Context, metrics telemetry.Metrics) (*ec2inventory.Reconciler, func(), error) {
+	config := s.config.Experimental.EC2Inventory
+
+	ec2Clients := make(map[string]ec2inventory.EC2Client)
+	for _, region := range config.Regions {
+		awsConfig := aws.NewConfig().WithRegion(region)
+		if config.AccessKeyID != "" || config.SecretAccessKey != "" {
+			awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""))
+		}
+		sess, err := session.NewSession(awsConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create AWS session for region %q: %v", region, err)
+		}
+		ec2Clients[region] = ec2.New(sess)
+	}
+
+	conn, err := grpc.DialContext(ctx, s.adminUDSAddress().Name,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial UDS endpoint: %v", err)
+	}
+
+	reconciler, err := ec2inventory.New(ec2inventory.Config{
+		Log:          s.subsystemLog(telemetry.EC2Inventory),
+		Metrics:      metrics,
+		TrustDomain:  s.config.TrustDomain,
+		EntryClient:  entryv1.NewEntryClient(conn),
+		EC2Clients:   ec2Clients,
+		GroupTagKey:  config.GroupTagKey,
+		FilterTags:   config.FilterTags,
+		PathPrefix:   config.PathPrefix,
+		PollInterval: config.PollInterval,
+		Clock:        s.config.Clock,
 	})
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to create EC2 inventory reconciler: %v", err)
+	}
+
+	return reconciler, func() { conn.Close() }, nil
 }
 
 func (s *Server) validateTrustDomain(ctx context.Context, ds datastore.DataStore) error {
@@ -385,9 +481,38 @@ func (s *Server) validateTrustDomain(ctx context.Context, ds datastore.DataStore
 	return nil
 }
 
+// adminUDSAddress returns the address of the UDS endpoint serving the admin
+// APIs.
+// subsystemLog returns the logger of the named subsystem, which logs at the
+// level configured for the subsystem, if any.
+func (s *Server) subsystemLog(subsystem string) logrus.FieldLogger {
+	log := s.config.Log
+	if s.config.LogLevels != nil {
+		log = s.config.LogLevels.Logger(subsystem)
+	}
+	return log.WithField(telemetry.SubsystemName, subsystem)
+}
+
+// pluginLog returns the logger of a plugin, which logs at the level
+// configured for "<type>:<name>" (e.g. "NodeAttestor:join_token"), if any.
+func (s *Server) pluginLog(pluginType, pluginName string) logrus.FieldLogger {
+	log := s.config.Log
+	if s.config.LogLevels != nil {
+		log = s.config.LogLevels.Logger(pluginType + ":" + pluginName)
+	}
+	return log.WithField(telemetry.SubsystemName, telemetry.Catalog)
+}
+
+func (s *Server) adminUDSAddress() *net.UnixAddr {
+	if s.config.AdminBindUDSAddress != nil {
+		return s.config.AdminBindUDSAddress
+	}
+	return s.config.BindUDSAddress
+}
+
 // Status is used as a top-level health check for the Server.
 func (s *Server) Status() (interface{}, error) {
-	client, err := server_util.NewServerClient(s.config.BindUDSAddress.Name)
+	client, err := server_util.NewServerClient(s.adminUDSAddress().Name)
 	if err != nil {
 		return nil, errors.New("cannot create registration client")
 	}
@@ -407,3 +532,18 @@ func (s *Server) Status() (interface{}, error) {
 		Message: "successfully fetched bundle",
 	}, nil
 }
+
+func (s *Server) emitLifecycleStarted(metrics telemetry.Metrics) {
+	buildInfo := telemetry.NewBuildInfo(s.config.Experimental.Enabled()...)
+	telemetry.EmitBuildInfo(metrics, buildInfo)
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStarted)
+	s.config.Log.WithFields(buildInfo.Fields()).WithField(telemetry.Event, telemetry.LifecycleStarted).Info("Server lifecycle event")
+}
+
+func (s *Server) emitLifecycleStopped(metrics telemetry.Metrics) {
+	telemetry.EmitLifecycleEvent(metrics, telemetry.LifecycleStopped)
+	s.config.Log.WithFields(logrus.Fields{
+		telemetry.Event:       telemetry.LifecycleStopped,
+		telemetry.ElapsedTime: uptime.Uptime().String(),
+	}).Info("Server lifecycle event")
+}
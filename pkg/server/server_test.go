@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"testing"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
+	testclock "github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -42,6 +46,29 @@ func TestServerTestSuite(t *testing.T) {
 	suite.Run(t, new(ServerTestSuite))
 }
 
+func TestNewClock(t *testing.T) {
+	t.Run("defaults to the wall clock", func(t *testing.T) {
+		server := New(Config{})
+		require.NotNil(t, server.config.Clock)
+		require.IsType(t, clock.New(), server.config.Clock)
+	})
+
+	t.Run("uses the configured clock", func(t *testing.T) {
+		clk := testclock.NewMock(t)
+		server := New(Config{Clock: clk})
+		require.Equal(t, clk, server.config.Clock)
+	})
+}
+
+func TestExperimentalConfigEnabled(t *testing.T) {
+	require.Empty(t, ExperimentalConfig{}.Enabled())
+	require.Equal(t, []string{"allow_agentless_node_attestors", "ec2_inventory", "api_gateway"}, ExperimentalConfig{
+		AllowAgentlessNodeAttestors: true,
+		EC2Inventory:                &EC2InventoryConfig{},
+		APIGatewayAddress:           &net.TCPAddr{},
+	}.Enabled())
+}
+
 func (suite *ServerTestSuite) TestValidateTrustDomain() {
 	ctx := context.Background()
 	ds := suite.ds
@@ -0,0 +1,182 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	sectionBundles       = "bundles"
+	sectionEntries       = "entries"
+	sectionAttestedNodes = "attested_nodes"
+	sectionNodeSelectors = "node_selectors"
+	sectionJoinTokens    = "join_tokens"
+)
+
+// document is the on-disk representation of a snapshot. Each section holds
+// a JSON array of protobuf messages encoded with protojson. The checksum of
+// each section is the SHA-256 of its compacted JSON encoding.
+type document struct {
+	Version   int                        `json:"version"`
+	CreatedAt time.Time                  `json:"created_at"`
+	Checksums map[string]string          `json:"checksums"`
+	Sections  map[string]json.RawMessage `json:"sections"`
+}
+
+// Write encodes the snapshot into w.
+func Write(w io.Writer, s *Snapshot) error {
+	doc := &document{
+		Version:   Version,
+		CreatedAt: s.CreatedAt,
+		Checksums: make(map[string]string),
+		Sections:  make(map[string]json.RawMessage),
+	}
+
+	sections := map[string][]proto.Message{
+		sectionBundles:       make([]proto.Message, 0, len(s.Bundles)),
+		sectionEntries:       make([]proto.Message, 0, len(s.Entries)),
+		sectionAttestedNodes: make([]proto.Message, 0, len(s.AttestedNodes)),
+		sectionNodeSelectors: make([]proto.Message, 0, len(s.NodeSelectors)),
+		sectionJoinTokens:    make([]proto.Message, 0, len(s.JoinTokens)),
+	}
+	for _, m := range s.Bundles {
+		sections[sectionBundles] = append(sections[sectionBundles], m)
+	}
+	for _, m := range s.Entries {
+		sections[sectionEntries] = append(sections[sectionEntries], m)
+	}
+	for _, m := range s.AttestedNodes {
+		sections[sectionAttestedNodes] = append(sections[sectionAttestedNodes], m)
+	}
+	for _, m := range s.NodeSelectors {
+		sections[sectionNodeSelectors] = append(sections[sectionNodeSelectors], m)
+	}
+	for _, m := range s.JoinTokens {
+		sections[sectionJoinTokens] = append(sections[sectionJoinTokens], m)
+	}
+
+	for name, msgs := range sections {
+		raw, err := marshalSection(msgs)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		doc.Sections[name] = raw
+		doc.Checksums[name] = checksum(raw)
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+// Read decodes a snapshot from r, verifying its version and the checksum of
+// every section.
+func Read(r io.Reader) (*Snapshot, error) {
+	doc := new(document)
+	if err := json.NewDecoder(r).Decode(doc); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if doc.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d; expected %d", doc.Version, Version)
+	}
+
+	s := &Snapshot{
+		CreatedAt: doc.CreatedAt,
+	}
+	for _, section := range []struct {
+		name string
+		add  func(data []byte) error
+	}{
+		{name: sectionBundles, add: func(data []byte) error {
+			m := new(common.Bundle)
+			s.Bundles = append(s.Bundles, m)
+			return protojson.Unmarshal(data, m)
+		}},
+		{name: sectionEntries, add: func(data []byte) error {
+			m := new(common.RegistrationEntry)
+			s.Entries = append(s.Entries, m)
+			return protojson.Unmarshal(data, m)
+		}},
+		{name: sectionAttestedNodes, add: func(data []byte) error {
+			m := new(common.AttestedNode)
+			s.AttestedNodes = append(s.AttestedNodes, m)
+			return protojson.Unmarshal(data, m)
+		}},
+		{name: sectionNodeSelectors, add: func(data []byte) error {
+			m := new(datastore.NodeSelectors)
+			s.NodeSelectors = append(s.NodeSelectors, m)
+			return protojson.Unmarshal(data, m)
+		}},
+		{name: sectionJoinTokens, add: func(data []byte) error {
+			m := new(datastore.JoinToken)
+			s.JoinTokens = append(s.JoinTokens, m)
+			return protojson.Unmarshal(data, m)
+		}},
+	} {
+		raw, ok := doc.Sections[section.name]
+		if !ok {
+			return nil, fmt.Errorf("snapshot is missing the %s section", section.name)
+		}
+
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, raw); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", section.name, err)
+		}
+		if actual := checksum(compacted.Bytes()); actual != doc.Checksums[section.name] {
+			return nil, fmt.Errorf("checksum mismatch for %s: snapshot is corrupted or has been modified", section.name)
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", section.name, err)
+		}
+		for _, item := range items {
+			if err := section.add(item); err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", section.name, err)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func marshalSection(msgs []proto.Message) (json.RawMessage, error) {
+	items := make([]json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		item, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	// protojson randomizes whitespace and the document is indented when
+	// written, so checksums are always computed over the compacted form.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return nil, err
+	}
+	return compacted.Bytes(), nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,259 @@
+// Package snapshot exports the contents of a datastore into a versioned,
+// checksummed document and restores such a document into another datastore.
+// It is used to back up and restore the server state for disaster recovery.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Version is the version of the snapshot format produced by this package.
+const Version = 1
+
+// Snapshot holds the state of a datastore at a point in time.
+type Snapshot struct {
+	// CreatedAt is the time the snapshot was taken
+	CreatedAt time.Time
+
+	Bundles       []*common.Bundle
+	Entries       []*common.RegistrationEntry
+	AttestedNodes []*common.AttestedNode
+	NodeSelectors []*datastore.NodeSelectors
+	JoinTokens    []*datastore.JoinToken
+}
+
+// Export takes a snapshot of the given datastore.
+func Export(ctx context.Context, ds datastore.DataStore) (*Snapshot, error) {
+	bundles, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundles: %w", err)
+	}
+
+	entries, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registration entries: %w", err)
+	}
+
+	nodes, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attested nodes: %w", err)
+	}
+
+	selectors, err := ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node selectors: %w", err)
+	}
+
+	tokens, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join tokens: %w", err)
+	}
+
+	return &Snapshot{
+		CreatedAt:     time.Now().UTC(),
+		Bundles:       bundles.Bundles,
+		Entries:       entries.Entries,
+		AttestedNodes: nodes.Nodes,
+		NodeSelectors: selectors.Selectors,
+		JoinTokens:    tokens.JoinTokens,
+	}, nil
+}
+
+// Import restores the snapshot into the given datastore, which must be
+// empty. Registration entries are created in a single transaction and keep
+// their IDs. If any record cannot be restored, the records restored so far
+// are removed, leaving the datastore empty so the import can be retried.
+func Import(ctx context.Context, ds datastore.DataStore, s *Snapshot) error {
+	if err := checkEmpty(ctx, ds); err != nil {
+		return err
+	}
+
+	r := &restorer{ds: ds}
+	if err := r.restore(ctx, s); err != nil {
+		// The rollback runs even if the import was canceled, so it does not
+		// use the import context.
+		if rollbackErr := r.rollback(context.Background()); rollbackErr != nil {
+			return fmt.Errorf("%v; failed to roll back the import, the datastore must be emptied before retrying: %w", err, rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// restorer restores a snapshot and keeps track of the restored records so
+// they can be removed if the import fails.
+type restorer struct {
+	ds datastore.DataStore
+
+	bundles   []string
+	entries   []string
+	nodes     []string
+	selectors []string
+	tokens    []string
+}
+
+func (r *restorer) restore(ctx context.Context, s *Snapshot) error {
+	for _, bundle := range s.Bundles {
+		if _, err := r.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: bundle,
+		}); err != nil {
+			return fmt.Errorf("failed to create bundle %q: %w", bundle.TrustDomainId, err)
+		}
+		r.bundles = append(r.bundles, bundle.TrustDomainId)
+	}
+
+	if err := r.restoreEntries(ctx, s.Entries); err != nil {
+		return err
+	}
+
+	for _, node := range s.AttestedNodes {
+		if _, err := r.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: node,
+		}); err != nil {
+			return fmt.Errorf("failed to create attested node %q: %w", node.SpiffeId, err)
+		}
+		r.nodes = append(r.nodes, node.SpiffeId)
+	}
+
+	for _, selectors := range s.NodeSelectors {
+		if _, err := r.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: selectors,
+		}); err != nil {
+			return fmt.Errorf("failed to set node selectors for %q: %w", selectors.SpiffeId, err)
+		}
+		r.selectors = append(r.selectors, selectors.SpiffeId)
+	}
+
+	for _, token := range s.JoinTokens {
+		if _, err := r.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: token,
+		}); err != nil {
+			return fmt.Errorf("failed to create join token: %w", err)
+		}
+		r.tokens = append(r.tokens, token.Token)
+	}
+
+	return nil
+}
+
+// restoreEntries creates the registration entries in a single transaction
+// and fails if the datastore did not keep the ID of an entry.
+func (r *restorer) restoreEntries(ctx context.Context, entries []*common.RegistrationEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ops := make([]*datastore.RegistrationEntryOperation, 0, len(entries))
+	for _, entry := range entries {
+		ops = append(ops, &datastore.RegistrationEntryOperation{
+			Create: &datastore.CreateRegistrationEntryRequest{Entry: entry},
+		})
+	}
+
+	resp, err := r.ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: ops,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create registration entries: %w", err)
+	}
+
+	for _, created := range resp.Entries {
+		r.entries = append(r.entries, created.EntryId)
+	}
+	for i, created := range resp.Entries {
+		if created.EntryId != entries[i].EntryId {
+			return fmt.Errorf("datastore did not keep the ID of registration entry %q", entries[i].EntryId)
+		}
+	}
+	return nil
+}
+
+// rollback removes the restored records in the reverse order they were
+// created in.
+func (r *restorer) rollback(ctx context.Context) error {
+	for _, token := range r.tokens {
+		if _, err := r.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+			Token: token,
+		}); err != nil {
+			return fmt.Errorf("failed to delete join token: %w", err)
+		}
+	}
+
+	for _, spiffeID := range r.selectors {
+		if _, err := r.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{SpiffeId: spiffeID},
+		}); err != nil {
+			return fmt.Errorf("failed to delete node selectors for %q: %w", spiffeID, err)
+		}
+	}
+
+	for _, spiffeID := range r.nodes {
+		if _, err := r.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+			SpiffeId: spiffeID,
+		}); err != nil {
+			return fmt.Errorf("failed to delete attested node %q: %w", spiffeID, err)
+		}
+	}
+
+	if len(r.entries) > 0 {
+		ops := make([]*datastore.RegistrationEntryOperation, 0, len(r.entries))
+		for _, entryID := range r.entries {
+			ops = append(ops, &datastore.RegistrationEntryOperation{
+				Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: entryID},
+			})
+		}
+		if _, err := r.ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+			Operations: ops,
+		}); err != nil {
+			return fmt.Errorf("failed to delete registration entries: %w", err)
+		}
+	}
+
+	for _, trustDomainID := range r.bundles {
+		if _, err := r.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+			TrustDomainId: trustDomainID,
+		}); err != nil {
+			return fmt.Errorf("failed to delete bundle %q: %w", trustDomainID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkEmpty fails if the datastore already holds any of the records a
+// snapshot restores, since merging a snapshot into an existing state is not
+// supported.
+func checkEmpty(ctx context.Context, ds datastore.DataStore) error {
+	bundles, err := ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to count bundles: %w", err)
+	}
+	entries, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to count registration entries: %w", err)
+	}
+	nodes, err := ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to count attested nodes: %w", err)
+	}
+	selectors, err := ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list node selectors: %w", err)
+	}
+	tokens, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		Pagination: &datastore.Pagination{PageSize: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list join tokens: %w", err)
+	}
+	if bundles.Bundles > 0 || entries.Entries > 0 || nodes.Nodes > 0 || len(selectors.Selectors) > 0 || len(tokens.JoinTokens) > 0 {
+		return errors.New("datastore is not empty; snapshots can only be imported into a fresh datastore")
+	}
+	return nil
+}
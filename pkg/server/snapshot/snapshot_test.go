@@ -0,0 +1,212 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := populate(t)
+
+	s, err := Export(ctx, source)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, s))
+
+	restored, err := Read(&buf)
+	require.NoError(t, err)
+	require.True(t, s.CreatedAt.Equal(restored.CreatedAt))
+	spiretest.RequireProtoListEqual(t, s.Bundles, restored.Bundles)
+	spiretest.RequireProtoListEqual(t, s.Entries, restored.Entries)
+	spiretest.RequireProtoListEqual(t, s.AttestedNodes, restored.AttestedNodes)
+	spiretest.RequireProtoListEqual(t, s.NodeSelectors, restored.NodeSelectors)
+	spiretest.RequireProtoListEqual(t, s.JoinTokens, restored.JoinTokens)
+
+	target := fakedatastore.New(t)
+	require.NoError(t, Import(ctx, target, restored))
+
+	imported, err := Export(ctx, target)
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, s.Bundles, imported.Bundles)
+	spiretest.RequireProtoListEqual(t, s.AttestedNodes, imported.AttestedNodes)
+	spiretest.RequireProtoListEqual(t, s.NodeSelectors, imported.NodeSelectors)
+	spiretest.RequireProtoListEqual(t, s.JoinTokens, imported.JoinTokens)
+
+	require.Len(t, imported.Entries, 1)
+	require.Equal(t, s.Entries[0].EntryId, imported.Entries[0].EntryId)
+	require.Equal(t, s.Entries[0].SpiffeId, imported.Entries[0].SpiffeId)
+	require.Equal(t, s.Entries[0].ParentId, imported.Entries[0].ParentId)
+	spiretest.RequireProtoListEqual(t, s.Entries[0].Selectors, imported.Entries[0].Selectors)
+}
+
+func TestImportRejectsNonEmptyDataStore(t *testing.T) {
+	ctx := context.Background()
+	ds := populate(t)
+
+	s, err := Export(ctx, ds)
+	require.NoError(t, err)
+
+	err = Import(ctx, ds, s)
+	require.EqualError(t, err, "datastore is not empty; snapshots can only be imported into a fresh datastore")
+}
+
+func TestImportRejectsDataStoreWithOnlyTokensOrSelectors(t *testing.T) {
+	ctx := context.Background()
+	s, err := Export(ctx, populate(t))
+	require.NoError(t, err)
+
+	withToken := fakedatastore.New(t)
+	_, err = withToken.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{Token: "other", Expiry: 1000},
+	})
+	require.NoError(t, err)
+	err = Import(ctx, withToken, s)
+	require.EqualError(t, err, "datastore is not empty; snapshots can only be imported into a fresh datastore")
+
+	withSelectors := fakedatastore.New(t)
+	_, err = withSelectors.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  "spiffe://example.org/other",
+			Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		},
+	})
+	require.NoError(t, err)
+	err = Import(ctx, withSelectors, s)
+	require.EqualError(t, err, "datastore is not empty; snapshots can only be imported into a fresh datastore")
+}
+
+func TestImportRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	s, err := Export(ctx, populate(t))
+	require.NoError(t, err)
+
+	target := fakedatastore.New(t)
+	// Let the emptiness check and the creation of every record but the
+	// join token through.
+	for i := 0; i < 9; i++ {
+		target.AppendNextError(nil)
+	}
+	target.AppendNextError(errors.New("oh no"))
+
+	err = Import(ctx, target, s)
+	require.EqualError(t, err, "failed to create join token: oh no")
+
+	empty, err := Export(ctx, target)
+	require.NoError(t, err)
+	require.Empty(t, empty.Bundles)
+	require.Empty(t, empty.Entries)
+	require.Empty(t, empty.AttestedNodes)
+	require.Empty(t, empty.NodeSelectors)
+	require.Empty(t, empty.JoinTokens)
+
+	// The import can be retried once the cause of the failure is gone
+	require.NoError(t, Import(ctx, target, s))
+	imported, err := Export(ctx, target)
+	require.NoError(t, err)
+	require.Len(t, imported.Entries, 1)
+	require.Equal(t, s.Entries[0].EntryId, imported.Entries[0].EntryId)
+	spiretest.RequireProtoListEqual(t, s.JoinTokens, imported.JoinTokens)
+}
+
+func TestReadDetectsTampering(t *testing.T) {
+	s, err := Export(context.Background(), populate(t))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, s))
+
+	tampered := strings.Replace(buf.String(), "spiffe://example.org/workload", "spiffe://example.org/evil", 1)
+	_, err = Read(strings.NewReader(tampered))
+	require.EqualError(t, err, "checksum mismatch for entries: snapshot is corrupted or has been modified")
+}
+
+func TestReadValidatesDocument(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "malformed",
+			in:   "{",
+			err:  "failed to decode snapshot: unexpected EOF",
+		},
+		{
+			name: "unsupported version",
+			in:   `{"version": 2}`,
+			err:  "unsupported snapshot version 2; expected 1",
+		},
+		{
+			name: "missing section",
+			in:   `{"version": 1, "sections": {}}`,
+			err:  "snapshot is missing the bundles section",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.in))
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func populate(t *testing.T) datastore.DataStore {
+	ctx := context.Background()
+	ds := fakedatastore.New(t)
+
+	_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: "spiffe://example.org",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("root")}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:  "spiffe://example.org/workload",
+			ParentId:  "spiffe://example.org/node",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/node",
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "1234",
+			CertNotAfter:        1000,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  "spiffe://example.org/node",
+			Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  "token",
+			Expiry: 1000,
+		},
+	})
+	require.NoError(t, err)
+
+	return ds
+}
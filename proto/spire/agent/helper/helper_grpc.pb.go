@@ -0,0 +1,101 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package helper
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// HelperClient is the client API for Helper service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HelperClient interface {
+	// Returns a list of selectors resolved for a given PID by the workload
+	// attestor run by the helper
+	Attest(ctx context.Context, in *AttestRequest, opts ...grpc.CallOption) (*AttestResponse, error)
+}
+
+type helperClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHelperClient(cc grpc.ClientConnInterface) HelperClient {
+	return &helperClient{cc}
+}
+
+func (c *helperClient) Attest(ctx context.Context, in *AttestRequest, opts ...grpc.CallOption) (*AttestResponse, error) {
+	out := new(AttestResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.helper.Helper/Attest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelperServer is the server API for Helper service.
+// All implementations must embed UnimplementedHelperServer
+// for forward compatibility
+type HelperServer interface {
+	// Returns a list of selectors resolved for a given PID by the workload
+	// attestor run by the helper
+	Attest(context.Context, *AttestRequest) (*AttestResponse, error)
+	mustEmbedUnimplementedHelperServer()
+}
+
+// UnimplementedHelperServer must be embedded to have forward compatible implementations.
+type UnimplementedHelperServer struct {
+}
+
+func (UnimplementedHelperServer) Attest(context.Context, *AttestRequest) (*AttestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Attest not implemented")
+}
+func (UnimplementedHelperServer) mustEmbedUnimplementedHelperServer() {}
+
+// UnsafeHelperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HelperServer will
+// result in compilation errors.
+type UnsafeHelperServer interface {
+	mustEmbedUnimplementedHelperServer()
+}
+
+func RegisterHelperServer(s grpc.ServiceRegistrar, srv HelperServer) {
+	s.RegisterService(&_Helper_serviceDesc, srv)
+}
+
+func _Helper_Attest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelperServer).Attest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.helper.Helper/Attest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelperServer).Attest(ctx, req.(*AttestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Helper_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.agent.helper.Helper",
+	HandlerType: (*HelperServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Attest",
+			Handler:    _Helper_Attest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/agent/helper/helper.proto",
+}
@@ -77,6 +77,13 @@ type GetInfoResponse struct {
 	SvidsCount int32 `protobuf:"varint,3,opt,name=svids_count,json=svidsCount,proto3" json:"svids_count,omitempty"`
 	// last successful sync with server (in seconds since unix epoch)
 	LastSyncSuccess int64 `protobuf:"varint,4,opt,name=last_sync_success,json=lastSyncSuccess,proto3" json:"last_sync_success,omitempty"`
+	// time from which the agent SVID is rotated (in seconds since unix epoch)
+	SvidRotationDeadline int64 `protobuf:"varint,5,opt,name=svid_rotation_deadline,json=svidRotationDeadline,proto3" json:"svid_rotation_deadline,omitempty"`
+	// last successful agent SVID rotation (in seconds since unix epoch), zero
+	// if the agent has not rotated its SVID since it started
+	LastSvidRotation int64 `protobuf:"varint,6,opt,name=last_svid_rotation,json=lastSvidRotation,proto3" json:"last_svid_rotation,omitempty"`
+	// what triggered the last agent SVID rotation
+	LastSvidRotationReason string `protobuf:"bytes,7,opt,name=last_svid_rotation_reason,json=lastSvidRotationReason,proto3" json:"last_svid_rotation_reason,omitempty"`
 }
 
 func (x *GetInfoResponse) Reset() {
@@ -139,6 +146,122 @@ func (x *GetInfoResponse) GetLastSyncSuccess() int64 {
 	return 0
 }
 
+func (x *GetInfoResponse) GetSvidRotationDeadline() int64 {
+	if x != nil {
+		return x.SvidRotationDeadline
+	}
+	return 0
+}
+
+func (x *GetInfoResponse) GetLastSvidRotation() int64 {
+	if x != nil {
+		return x.LastSvidRotation
+	}
+	return 0
+}
+
+func (x *GetInfoResponse) GetLastSvidRotationReason() string {
+	if x != nil {
+		return x.LastSvidRotationReason
+	}
+	return ""
+}
+
+type GetHealthStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetHealthStatusRequest) Reset() {
+	*x = GetHealthStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthStatusRequest) ProtoMessage() {}
+
+func (x *GetHealthStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetHealthStatusRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_agent_debug_v1_debug_proto_rawDescGZIP(), []int{2}
+}
+
+type GetHealthStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether all the components are healthy
+	Healthy bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// Status of each agent component
+	Components []*GetHealthStatusResponse_Component `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+}
+
+func (x *GetHealthStatusResponse) Reset() {
+	*x = GetHealthStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthStatusResponse) ProtoMessage() {}
+
+func (x *GetHealthStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetHealthStatusResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_agent_debug_v1_debug_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetHealthStatusResponse) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *GetHealthStatusResponse) GetComponents() []*GetHealthStatusResponse_Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
 type GetInfoResponse_Cert struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -155,7 +278,7 @@ type GetInfoResponse_Cert struct {
 func (x *GetInfoResponse_Cert) Reset() {
 	*x = GetInfoResponse_Cert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[2]
+		mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -168,7 +291,7 @@ func (x *GetInfoResponse_Cert) String() string {
 func (*GetInfoResponse_Cert) ProtoMessage() {}
 
 func (x *GetInfoResponse_Cert) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[2]
+	mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,6 +328,72 @@ func (x *GetInfoResponse_Cert) GetSubject() string {
 	return ""
 }
 
+type GetHealthStatusResponse_Component struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Component name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the component is healthy
+	Healthy bool `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// Details about the component status
+	Details string `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *GetHealthStatusResponse_Component) Reset() {
+	*x = GetHealthStatusResponse_Component{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthStatusResponse_Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthStatusResponse_Component) ProtoMessage() {}
+
+func (x *GetHealthStatusResponse_Component) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_agent_debug_v1_debug_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthStatusResponse_Component.ProtoReflect.Descriptor instead.
+func (*GetHealthStatusResponse_Component) Descriptor() ([]byte, []int) {
+	return file_spire_api_agent_debug_v1_debug_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *GetHealthStatusResponse_Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetHealthStatusResponse_Component) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *GetHealthStatusResponse_Component) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
 var File_spire_api_agent_debug_v1_debug_proto protoreflect.FileDescriptor
 
 var file_spire_api_agent_debug_v1_debug_proto_rawDesc = []byte{
@@ -214,7 +403,7 @@ var file_spire_api_agent_debug_v1_debug_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x1a, 0x73, 0x70,
 	0x69, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65,
 	0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x10, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa9, 0x02, 0x0a, 0x0f, 0x47,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc8, 0x03, 0x0a, 0x0f, 0x47,
 	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49,
 	0x0a, 0x0a, 0x73, 0x76, 0x69, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
@@ -226,24 +415,57 @@ var file_spire_api_agent_debug_v1_debug_proto_rawDesc = []byte{
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x76, 0x69, 0x64, 0x73, 0x43, 0x6f, 0x75,
 	0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f,
 	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c,
-	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x66,
-	0x0a, 0x04, 0x43, 0x65, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a,
-	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
-	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x32, 0x5f, 0x0a, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12,
-	0x56, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64,
-	0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x76,
-	0x31, 0x3b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x34,
+	0x0a, 0x16, 0x73, 0x76, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14,
+	0x73, 0x76, 0x69, 0x64, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x76, 0x69,
+	0x64, 0x5f, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x76, 0x69, 0x64, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x19, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x76, 0x69, 0x64, 0x5f,
+	0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x76, 0x69, 0x64, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x66, 0x0a,
+	0x04, 0x43, 0x65, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xe1, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x57, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x53,
+	0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x32, 0xcf, 0x01, 0x0a, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x56, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x76, 0x31, 0x3b,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -258,23 +480,29 @@ func file_spire_api_agent_debug_v1_debug_proto_rawDescGZIP() []byte {
 	return file_spire_api_agent_debug_v1_debug_proto_rawDescData
 }
 
-var file_spire_api_agent_debug_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_spire_api_agent_debug_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_spire_api_agent_debug_v1_debug_proto_goTypes = []interface{}{
-	(*GetInfoRequest)(nil),       // 0: spire.agent.debug.v1.GetInfoRequest
-	(*GetInfoResponse)(nil),      // 1: spire.agent.debug.v1.GetInfoResponse
-	(*GetInfoResponse_Cert)(nil), // 2: spire.agent.debug.v1.GetInfoResponse.Cert
-	(*types.SPIFFEID)(nil),       // 3: spire.types.SPIFFEID
+	(*GetInfoRequest)(nil),                    // 0: spire.agent.debug.v1.GetInfoRequest
+	(*GetInfoResponse)(nil),                   // 1: spire.agent.debug.v1.GetInfoResponse
+	(*GetHealthStatusRequest)(nil),            // 2: spire.agent.debug.v1.GetHealthStatusRequest
+	(*GetHealthStatusResponse)(nil),           // 3: spire.agent.debug.v1.GetHealthStatusResponse
+	(*GetInfoResponse_Cert)(nil),              // 4: spire.agent.debug.v1.GetInfoResponse.Cert
+	(*GetHealthStatusResponse_Component)(nil), // 5: spire.agent.debug.v1.GetHealthStatusResponse.Component
+	(*types.SPIFFEID)(nil),                    // 6: spire.types.SPIFFEID
 }
 var file_spire_api_agent_debug_v1_debug_proto_depIdxs = []int32{
-	2, // 0: spire.agent.debug.v1.GetInfoResponse.svid_chain:type_name -> spire.agent.debug.v1.GetInfoResponse.Cert
-	3, // 1: spire.agent.debug.v1.GetInfoResponse.Cert.id:type_name -> spire.types.SPIFFEID
-	0, // 2: spire.agent.debug.v1.Debug.GetInfo:input_type -> spire.agent.debug.v1.GetInfoRequest
-	1, // 3: spire.agent.debug.v1.Debug.GetInfo:output_type -> spire.agent.debug.v1.GetInfoResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: spire.agent.debug.v1.GetInfoResponse.svid_chain:type_name -> spire.agent.debug.v1.GetInfoResponse.Cert
+	5, // 1: spire.agent.debug.v1.GetHealthStatusResponse.components:type_name -> spire.agent.debug.v1.GetHealthStatusResponse.Component
+	6, // 2: spire.agent.debug.v1.GetInfoResponse.Cert.id:type_name -> spire.types.SPIFFEID
+	0, // 3: spire.agent.debug.v1.Debug.GetInfo:input_type -> spire.agent.debug.v1.GetInfoRequest
+	2, // 4: spire.agent.debug.v1.Debug.GetHealthStatus:input_type -> spire.agent.debug.v1.GetHealthStatusRequest
+	1, // 5: spire.agent.debug.v1.Debug.GetInfo:output_type -> spire.agent.debug.v1.GetInfoResponse
+	3, // 6: spire.agent.debug.v1.Debug.GetHealthStatus:output_type -> spire.agent.debug.v1.GetHealthStatusResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_spire_api_agent_debug_v1_debug_proto_init() }
@@ -308,6 +536,30 @@ func file_spire_api_agent_debug_v1_debug_proto_init() {
 			}
 		}
 		file_spire_api_agent_debug_v1_debug_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_agent_debug_v1_debug_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_agent_debug_v1_debug_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetInfoResponse_Cert); i {
 			case 0:
 				return &v.state
@@ -319,6 +571,18 @@ func file_spire_api_agent_debug_v1_debug_proto_init() {
 				return nil
 			}
 		}
+		file_spire_api_agent_debug_v1_debug_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthStatusResponse_Component); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -326,7 +590,7 @@ func file_spire_api_agent_debug_v1_debug_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_spire_api_agent_debug_v1_debug_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,6 +19,8 @@ const _ = grpc.SupportPackageIsVersion7
 type DebugClient interface {
 	// Get information about SPIRE agent
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Get the health status of the SPIRE agent components
+	GetHealthStatus(ctx context.Context, in *GetHealthStatusRequest, opts ...grpc.CallOption) (*GetHealthStatusResponse, error)
 }
 
 type debugClient struct {
@@ -38,12 +40,23 @@ func (c *debugClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...g
 	return out, nil
 }
 
+func (c *debugClient) GetHealthStatus(ctx context.Context, in *GetHealthStatusRequest, opts ...grpc.CallOption) (*GetHealthStatusResponse, error) {
+	out := new(GetHealthStatusResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.debug.v1.Debug/GetHealthStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 // All implementations must embed UnimplementedDebugServer
 // for forward compatibility
 type DebugServer interface {
 	// Get information about SPIRE agent
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// Get the health status of the SPIRE agent components
+	GetHealthStatus(context.Context, *GetHealthStatusRequest) (*GetHealthStatusResponse, error)
 	mustEmbedUnimplementedDebugServer()
 }
 
@@ -54,6 +67,9 @@ type UnimplementedDebugServer struct {
 func (UnimplementedDebugServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (UnimplementedDebugServer) GetHealthStatus(context.Context, *GetHealthStatusRequest) (*GetHealthStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHealthStatus not implemented")
+}
 func (UnimplementedDebugServer) mustEmbedUnimplementedDebugServer() {}
 
 // UnsafeDebugServer may be embedded to opt out of forward compatibility for this service.
@@ -85,6 +101,24 @@ func _Debug_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_GetHealthStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHealthStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).GetHealthStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.debug.v1.Debug/GetHealthStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).GetHealthStatus(ctx, req.(*GetHealthStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.agent.debug.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -93,6 +127,10 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Debug_GetInfo_Handler,
 		},
+		{
+			MethodName: "GetHealthStatus",
+			Handler:    _Debug_GetHealthStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/api/agent/debug/v1/debug.proto",
@@ -275,6 +275,16 @@ type BanAgentRequest struct {
 
 	// Required. The SPIFFE ID of the agent.
 	Id *types.SPIFFEID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Optional. If true, the node alias entries that map to no other agent
+	// that is neither banned nor expired are deleted immediately, and the
+	// entries parented by the agent or by those node aliases are scheduled
+	// for deletion after the grace period.
+	RevokeEntries bool `protobuf:"varint,2,opt,name=revoke_entries,json=revokeEntries,proto3" json:"revoke_entries,omitempty"`
+	// Optional. How long (in seconds) the entries scheduled for deletion are
+	// kept before being deleted. Only valid if revoke_entries is set. If
+	// unset, the entries are deleted when the server next prunes expired
+	// entries.
+	EntryDeletionGracePeriod int32 `protobuf:"varint,3,opt,name=entry_deletion_grace_period,json=entryDeletionGracePeriod,proto3" json:"entry_deletion_grace_period,omitempty"`
 }
 
 func (x *BanAgentRequest) Reset() {
@@ -316,6 +326,99 @@ func (x *BanAgentRequest) GetId() *types.SPIFFEID {
 	return nil
 }
 
+func (x *BanAgentRequest) GetRevokeEntries() bool {
+	if x != nil {
+		return x.RevokeEntries
+	}
+	return false
+}
+
+func (x *BanAgentRequest) GetEntryDeletionGracePeriod() int32 {
+	if x != nil {
+		return x.EntryDeletionGracePeriod
+	}
+	return 0
+}
+
+type BanAgentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The IDs of the node alias entries that were deleted.
+	RevokedEntryIds []string `protobuf:"bytes,1,rep,name=revoked_entry_ids,json=revokedEntryIds,proto3" json:"revoked_entry_ids,omitempty"`
+	// The IDs of the entries scheduled for deletion.
+	ScheduledEntryIds []string `protobuf:"bytes,2,rep,name=scheduled_entry_ids,json=scheduledEntryIds,proto3" json:"scheduled_entry_ids,omitempty"`
+	// When the scheduled entries expire and are deleted (seconds since
+	// Unix epoch). Unset if no entries were scheduled for deletion.
+	ScheduledDeletionAt int64 `protobuf:"varint,3,opt,name=scheduled_deletion_at,json=scheduledDeletionAt,proto3" json:"scheduled_deletion_at,omitempty"`
+	// The status of the revocation of the entries. Only set if
+	// revoke_entries was set. The agent is banned even if the revocation
+	// fails, in which case no entry is revoked and the revocation can be
+	// retried by banning the agent again.
+	RevokeEntriesStatus *types.Status `protobuf:"bytes,4,opt,name=revoke_entries_status,json=revokeEntriesStatus,proto3" json:"revoke_entries_status,omitempty"`
+}
+
+func (x *BanAgentResponse) Reset() {
+	*x = BanAgentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BanAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanAgentResponse) ProtoMessage() {}
+
+func (x *BanAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanAgentResponse.ProtoReflect.Descriptor instead.
+func (*BanAgentResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BanAgentResponse) GetRevokedEntryIds() []string {
+	if x != nil {
+		return x.RevokedEntryIds
+	}
+	return nil
+}
+
+func (x *BanAgentResponse) GetScheduledEntryIds() []string {
+	if x != nil {
+		return x.ScheduledEntryIds
+	}
+	return nil
+}
+
+func (x *BanAgentResponse) GetScheduledDeletionAt() int64 {
+	if x != nil {
+		return x.ScheduledDeletionAt
+	}
+	return 0
+}
+
+func (x *BanAgentResponse) GetRevokeEntriesStatus() *types.Status {
+	if x != nil {
+		return x.RevokeEntriesStatus
+	}
+	return nil
+}
+
 type AttestAgentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -332,7 +435,7 @@ type AttestAgentRequest struct {
 func (x *AttestAgentRequest) Reset() {
 	*x = AttestAgentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[5]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -345,7 +448,7 @@ func (x *AttestAgentRequest) String() string {
 func (*AttestAgentRequest) ProtoMessage() {}
 
 func (x *AttestAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[5]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +461,7 @@ func (x *AttestAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestAgentRequest.ProtoReflect.Descriptor instead.
 func (*AttestAgentRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{5}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *AttestAgentRequest) GetStep() isAttestAgentRequest_Step {
@@ -415,7 +518,7 @@ type AttestAgentResponse struct {
 func (x *AttestAgentResponse) Reset() {
 	*x = AttestAgentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[6]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -428,7 +531,7 @@ func (x *AttestAgentResponse) String() string {
 func (*AttestAgentResponse) ProtoMessage() {}
 
 func (x *AttestAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[6]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +544,7 @@ func (x *AttestAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestAgentResponse.ProtoReflect.Descriptor instead.
 func (*AttestAgentResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{6}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *AttestAgentResponse) GetStep() isAttestAgentResponse_Step {
@@ -496,7 +599,7 @@ type RenewAgentRequest struct {
 func (x *RenewAgentRequest) Reset() {
 	*x = RenewAgentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[7]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -509,7 +612,7 @@ func (x *RenewAgentRequest) String() string {
 func (*RenewAgentRequest) ProtoMessage() {}
 
 func (x *RenewAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[7]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,7 +625,7 @@ func (x *RenewAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenewAgentRequest.ProtoReflect.Descriptor instead.
 func (*RenewAgentRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{7}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RenewAgentRequest) GetParams() *AgentX509SVIDParams {
@@ -539,12 +642,17 @@ type RenewAgentResponse struct {
 
 	// The renewed X509-SVID
 	Svid *types.X509SVID `protobuf:"bytes,1,opt,name=svid,proto3" json:"svid,omitempty"`
+	// A hint of when the agent should renew the X509-SVID (in seconds since
+	// Unix epoch). The server derives it from the agent ID so that renewals
+	// are spread over time across the fleet instead of happening all at once,
+	// e.g. after a CA rotation. Zero if the server does not provide a hint.
+	RotateAfter int64 `protobuf:"varint,2,opt,name=rotate_after,json=rotateAfter,proto3" json:"rotate_after,omitempty"`
 }
 
 func (x *RenewAgentResponse) Reset() {
 	*x = RenewAgentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[8]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +665,7 @@ func (x *RenewAgentResponse) String() string {
 func (*RenewAgentResponse) ProtoMessage() {}
 
 func (x *RenewAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[8]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +678,7 @@ func (x *RenewAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenewAgentResponse.ProtoReflect.Descriptor instead.
 func (*RenewAgentResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{8}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RenewAgentResponse) GetSvid() *types.X509SVID {
@@ -580,6 +688,13 @@ func (x *RenewAgentResponse) GetSvid() *types.X509SVID {
 	return nil
 }
 
+func (x *RenewAgentResponse) GetRotateAfter() int64 {
+	if x != nil {
+		return x.RotateAfter
+	}
+	return 0
+}
+
 type CreateJoinTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -592,14 +707,22 @@ type CreateJoinTokenRequest struct {
 	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	// An optional SPIFFE ID to assign to the agent beyond that given by
 	// join token attestation. If set, this results in an entry being created
-	// that maps the attestation assigned agent ID to this ID.
+	// that maps the attestation assigned agent ID to this ID. The path is
+	// a Go text/template that is evaluated with the token value available
+	// as `.Token` (e.g. "/host/{{ .Token }}").
 	AgentId *types.SPIFFEID `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Optional selectors added to the registration entry created for
+	// agent_id, in addition to the one matching the join token agent ID.
+	AgentSelectors []*types.Selector `protobuf:"bytes,4,rep,name=agent_selectors,json=agentSelectors,proto3" json:"agent_selectors,omitempty"`
+	// Optional X509-SVID TTL (in seconds) of the registration entry created
+	// for agent_id.
+	AgentTtl int32 `protobuf:"varint,5,opt,name=agent_ttl,json=agentTtl,proto3" json:"agent_ttl,omitempty"`
 }
 
 func (x *CreateJoinTokenRequest) Reset() {
 	*x = CreateJoinTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[9]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -612,7 +735,7 @@ func (x *CreateJoinTokenRequest) String() string {
 func (*CreateJoinTokenRequest) ProtoMessage() {}
 
 func (x *CreateJoinTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[9]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +748,7 @@ func (x *CreateJoinTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateJoinTokenRequest.ProtoReflect.Descriptor instead.
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{9}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateJoinTokenRequest) GetTtl() int32 {
@@ -649,6 +772,283 @@ func (x *CreateJoinTokenRequest) GetAgentId() *types.SPIFFEID {
 	return nil
 }
 
+func (x *CreateJoinTokenRequest) GetAgentSelectors() []*types.Selector {
+	if x != nil {
+		return x.AgentSelectors
+	}
+	return nil
+}
+
+func (x *CreateJoinTokenRequest) GetAgentTtl() int32 {
+	if x != nil {
+		return x.AgentTtl
+	}
+	return 0
+}
+
+type ListJoinTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filters the join tokens returned in the response.
+	Filter *ListJoinTokensRequest_Filter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The maximum number of results to return. The server may further
+	// constrain this value, or if zero, choose its own.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous request, if any.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Whether or not to include the secret token values in the response.
+	// By default only the token identifiers are returned.
+	IncludeValues bool `protobuf:"varint,4,opt,name=include_values,json=includeValues,proto3" json:"include_values,omitempty"`
+}
+
+func (x *ListJoinTokensRequest) Reset() {
+	*x = ListJoinTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensRequest) ProtoMessage() {}
+
+func (x *ListJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListJoinTokensRequest) GetFilter() *ListJoinTokensRequest_Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListJoinTokensRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJoinTokensRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListJoinTokensRequest) GetIncludeValues() bool {
+	if x != nil {
+		return x.IncludeValues
+	}
+	return false
+}
+
+type JoinTokenInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The identifier of the token: a prefix of the hex encoded SHA-256
+	// digest of the token value. It can be shown without disclosing the
+	// token.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The token value. Only set when include_values was requested.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// When the token expires (seconds since Unix epoch).
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// When the token was used to attest an agent (seconds since Unix
+	// epoch). Zero if the token has not been used.
+	UsedAt int64 `protobuf:"varint,4,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`
+}
+
+func (x *JoinTokenInfo) Reset() {
+	*x = JoinTokenInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinTokenInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTokenInfo) ProtoMessage() {}
+
+func (x *JoinTokenInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTokenInfo.ProtoReflect.Descriptor instead.
+func (*JoinTokenInfo) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *JoinTokenInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JoinTokenInfo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *JoinTokenInfo) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *JoinTokenInfo) GetUsedAt() int64 {
+	if x != nil {
+		return x.UsedAt
+	}
+	return 0
+}
+
+type ListJoinTokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The join tokens.
+	JoinTokens []*JoinTokenInfo `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	// The page token for the next request. Empty if there are no more results.
+	// This field should be checked by clients even when a page_size was not
+	// requested, since the server may choose its own (see page_size).
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListJoinTokensResponse) Reset() {
+	*x = ListJoinTokensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJoinTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensResponse) ProtoMessage() {}
+
+func (x *ListJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListJoinTokensResponse) GetJoinTokens() []*JoinTokenInfo {
+	if x != nil {
+		return x.JoinTokens
+	}
+	return nil
+}
+
+func (x *ListJoinTokensResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PruneJoinTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Join tokens that expired before this time (seconds since Unix epoch)
+	// are deleted. If zero, the current server time is used.
+	ExpiresBefore int64 `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+}
+
+func (x *PruneJoinTokensRequest) Reset() {
+	*x = PruneJoinTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PruneJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneJoinTokensRequest) ProtoMessage() {}
+
+func (x *PruneJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PruneJoinTokensRequest) GetExpiresBefore() int64 {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return 0
+}
+
 type AgentX509SVIDParams struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -663,7 +1063,7 @@ type AgentX509SVIDParams struct {
 func (x *AgentX509SVIDParams) Reset() {
 	*x = AgentX509SVIDParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[10]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -676,7 +1076,7 @@ func (x *AgentX509SVIDParams) String() string {
 func (*AgentX509SVIDParams) ProtoMessage() {}
 
 func (x *AgentX509SVIDParams) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[10]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +1089,7 @@ func (x *AgentX509SVIDParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentX509SVIDParams.ProtoReflect.Descriptor instead.
 func (*AgentX509SVIDParams) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{10}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AgentX509SVIDParams) GetCsr() []byte {
@@ -710,12 +1110,18 @@ type ListAgentsRequest_Filter struct {
 	BySelectorMatch *types.SelectorMatch `protobuf:"bytes,2,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
 	// Filters agents to those that are banned.
 	ByBanned *wrapperspb.BoolValue `protobuf:"bytes,3,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// Filters agents to those whose X509-SVID expires before the given
+	// time (seconds since Unix epoch).
+	ByExpiresBefore *wrapperspb.Int64Value `protobuf:"bytes,4,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	// Filters agents to those whose X509-SVID expires at or after the
+	// given time (seconds since Unix epoch).
+	ByExpiresAtOrAfter *wrapperspb.Int64Value `protobuf:"bytes,5,opt,name=by_expires_at_or_after,json=byExpiresAtOrAfter,proto3" json:"by_expires_at_or_after,omitempty"`
 }
 
 func (x *ListAgentsRequest_Filter) Reset() {
 	*x = ListAgentsRequest_Filter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[11]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -728,7 +1134,7 @@ func (x *ListAgentsRequest_Filter) String() string {
 func (*ListAgentsRequest_Filter) ProtoMessage() {}
 
 func (x *ListAgentsRequest_Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[11]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,6 +1171,20 @@ func (x *ListAgentsRequest_Filter) GetByBanned() *wrapperspb.BoolValue {
 	return nil
 }
 
+func (x *ListAgentsRequest_Filter) GetByExpiresBefore() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresBefore
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest_Filter) GetByExpiresAtOrAfter() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresAtOrAfter
+	}
+	return nil
+}
+
 type AttestAgentRequest_Params struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -779,7 +1199,7 @@ type AttestAgentRequest_Params struct {
 func (x *AttestAgentRequest_Params) Reset() {
 	*x = AttestAgentRequest_Params{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[12]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -792,7 +1212,7 @@ func (x *AttestAgentRequest_Params) String() string {
 func (*AttestAgentRequest_Params) ProtoMessage() {}
 
 func (x *AttestAgentRequest_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[12]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,7 +1225,7 @@ func (x *AttestAgentRequest_Params) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestAgentRequest_Params.ProtoReflect.Descriptor instead.
 func (*AttestAgentRequest_Params) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{5, 0}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *AttestAgentRequest_Params) GetData() *types.AttestationData {
@@ -834,7 +1254,7 @@ type AttestAgentResponse_Result struct {
 func (x *AttestAgentResponse_Result) Reset() {
 	*x = AttestAgentResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[13]
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -847,7 +1267,7 @@ func (x *AttestAgentResponse_Result) String() string {
 func (*AttestAgentResponse_Result) ProtoMessage() {}
 
 func (x *AttestAgentResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[13]
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1280,7 @@ func (x *AttestAgentResponse_Result) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestAgentResponse_Result.ProtoReflect.Descriptor instead.
 func (*AttestAgentResponse_Result) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{6, 0}
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *AttestAgentResponse_Result) GetSvid() *types.X509SVID {
@@ -870,6 +1290,65 @@ func (x *AttestAgentResponse_Result) GetSvid() *types.X509SVID {
 	return nil
 }
 
+type ListJoinTokensRequest_Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filters join tokens to those that are expired (true) or still
+	// valid (false).
+	ByExpired *wrapperspb.BoolValue `protobuf:"bytes,1,opt,name=by_expired,json=byExpired,proto3" json:"by_expired,omitempty"`
+	// Filters join tokens to those that have (true) or have not (false)
+	// been used to attest an agent.
+	ByUsed *wrapperspb.BoolValue `protobuf:"bytes,2,opt,name=by_used,json=byUsed,proto3" json:"by_used,omitempty"`
+}
+
+func (x *ListJoinTokensRequest_Filter) Reset() {
+	*x = ListJoinTokensRequest_Filter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJoinTokensRequest_Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensRequest_Filter) ProtoMessage() {}
+
+func (x *ListJoinTokensRequest_Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_agent_v1_agent_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensRequest_Filter.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensRequest_Filter) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_agent_v1_agent_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *ListJoinTokensRequest_Filter) GetByExpired() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.ByExpired
+	}
+	return nil
+}
+
+func (x *ListJoinTokensRequest_Filter) GetByUsed() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.ByUsed
+	}
+	return nil
+}
+
 var File_spire_api_server_agent_v1_agent_proto protoreflect.FileDescriptor
 
 var file_spire_api_server_agent_v1_agent_proto_rawDesc = []byte{
@@ -889,106 +1368,187 @@ var file_spire_api_server_agent_v1_agent_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65,
 	0x73, 0x2f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x1a, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x73, 0x70,
-	0x69, 0x66, 0x66, 0x65, 0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x78, 0x35, 0x30, 0x39, 0x73, 0x76,
-	0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x91, 0x03, 0x0a, 0x11, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b,
-	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33,
+	0x69, 0x66, 0x66, 0x65, 0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x78, 0x35, 0x30, 0x39, 0x73, 0x76, 0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xab, 0x04, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4d, 0x61,
+	0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0xd3, 0x02, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x62, 0x79, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0f, 0x62, 0x79,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a,
+	0x09, 0x62, 0x79, 0x5f, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x62, 0x79,
+	0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f,
+	0x62, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12,
+	0x4f, 0x0a, 0x16, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74,
+	0x5f, 0x6f, 0x72, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x12, 0x62, 0x79,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4f, 0x72, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x22, 0x68, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49, 0x44,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x37, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x73,
+	0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x3b, 0x0a,
+	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50,
+	0x49, 0x46, 0x46, 0x45, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0f, 0x42,
+	0x61, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49,
+	0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x1b,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x67,
+	0x72, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x18, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e,
+	0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xeb, 0x01, 0x0a, 0x10,
+	0x42, 0x61, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x15,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74,
+	0x12, 0x47, 0x0a, 0x15, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x13, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa2, 0x02, 0x0a, 0x12, 0x41, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x4e, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x2f, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x11,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x1a, 0x82, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x30, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46,
+	0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x0b, 0x6f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x1a, 0xb9, 0x01, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x62,
-	0x79, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x79, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x62,
-	0x79, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74,
-	0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x52, 0x0f, 0x62, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x08, 0x62, 0x79, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x22, 0x68, 0x0a, 0x12,
-	0x4c, 0x69, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26,
-	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
-	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x37, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x3b, 0x0a, 0x12, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x25, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45,
-	0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x22, 0x38, 0x0a, 0x0f, 0x42, 0x61, 0x6e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0xc3,
+	0x01, 0x0a, 0x13, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x1a, 0x33, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x76, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x58, 0x35,
+	0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x52, 0x04, 0x73, 0x76, 0x69, 0x64, 0x42, 0x06, 0x0a, 0x04,
+	0x73, 0x74, 0x65, 0x70, 0x22, 0x5b, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x58, 0x35, 0x30, 0x39, 0x53,
+	0x56, 0x49, 0x44, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x22, 0x62, 0x0a, 0x12, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x76, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0xa2, 0x02, 0x0a, 0x12, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52,
-	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x2f, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6c, 0x6c,
-	0x65, 0x6e, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x11, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x82, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x12, 0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x46, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x42, 0x06, 0x0a,
-	0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0xc3, 0x01, 0x0a, 0x13, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a,
-	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e,
-	0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x1a, 0x33,
-	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x76, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74,
-	0x79, 0x70, 0x65, 0x73, 0x2e, 0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x52, 0x04, 0x73,
-	0x76, 0x69, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0x5b, 0x0a, 0x11, 0x52,
-	0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x46, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x3f, 0x0a, 0x12, 0x52, 0x65, 0x6e, 0x65,
-	0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29,
-	0x0a, 0x04, 0x73, 0x76, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x58, 0x35, 0x30, 0x39, 0x53,
-	0x56, 0x49, 0x44, 0x52, 0x04, 0x73, 0x76, 0x69, 0x64, 0x22, 0x72, 0x0a, 0x16, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x30, 0x0a, 0x08, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46,
-	0x46, 0x45, 0x49, 0x44, 0x52, 0x07, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x27, 0x0a,
+	0x70, 0x65, 0x73, 0x2e, 0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x52, 0x04, 0x73, 0x76,
+	0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0xcf, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74,
+	0x74, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x30, 0x0a, 0x08, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x49,
+	0x44, 0x52, 0x07, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x0f, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x54, 0x74, 0x6c, 0x22, 0xc5, 0x02, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x4f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x78, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x39, 0x0a, 0x0a, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x09, 0x62, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x62, 0x79,
+	0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x62, 0x79, 0x55, 0x73, 0x65, 0x64, 0x22,
+	0x6d, 0x0a, 0x0d, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x64, 0x41, 0x74, 0x22, 0x8b,
+	0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0b, 0x6a, 0x6f, 0x69,
+	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3f, 0x0a, 0x16,
+	0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x27, 0x0a,
 	0x13, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x58, 0x35, 0x30, 0x39, 0x53, 0x56, 0x49, 0x44, 0x50, 0x61,
 	0x72, 0x61, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x73, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x03, 0x63, 0x73, 0x72, 0x32, 0x9f, 0x05, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x0c, 0x52, 0x03, 0x63, 0x73, 0x72, 0x32, 0x89, 0x07, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74,
 	0x12, 0x69, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2c,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
 	0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41,
@@ -1005,37 +1565,51 @@ var file_spire_api_server_agent_v1_agent_proto_rawDesc = []byte{
 	0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
 	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x63, 0x0a,
 	0x08, 0x42, 0x61, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72,
 	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65,
 	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x70, 0x0a,
-	0x0b, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12,
-	0x69, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x31, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4a,
-	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2f, 0x76, 0x31, 0x3b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x70, 0x0a, 0x0b, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x30, 0x01, 0x12, 0x69, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x12, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6e, 0x65, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e,
+	0x65, 0x77, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5c, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x75, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12,
+	0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69,
+	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1050,69 +1624,90 @@ func file_spire_api_server_agent_v1_agent_proto_rawDescGZIP() []byte {
 	return file_spire_api_server_agent_v1_agent_proto_rawDescData
 }
 
-var file_spire_api_server_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_spire_api_server_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_spire_api_server_agent_v1_agent_proto_goTypes = []interface{}{
-	(*ListAgentsRequest)(nil),          // 0: spire.api.server.agent.v1.ListAgentsRequest
-	(*ListAgentsResponse)(nil),         // 1: spire.api.server.agent.v1.ListAgentsResponse
-	(*GetAgentRequest)(nil),            // 2: spire.api.server.agent.v1.GetAgentRequest
-	(*DeleteAgentRequest)(nil),         // 3: spire.api.server.agent.v1.DeleteAgentRequest
-	(*BanAgentRequest)(nil),            // 4: spire.api.server.agent.v1.BanAgentRequest
-	(*AttestAgentRequest)(nil),         // 5: spire.api.server.agent.v1.AttestAgentRequest
-	(*AttestAgentResponse)(nil),        // 6: spire.api.server.agent.v1.AttestAgentResponse
-	(*RenewAgentRequest)(nil),          // 7: spire.api.server.agent.v1.RenewAgentRequest
-	(*RenewAgentResponse)(nil),         // 8: spire.api.server.agent.v1.RenewAgentResponse
-	(*CreateJoinTokenRequest)(nil),     // 9: spire.api.server.agent.v1.CreateJoinTokenRequest
-	(*AgentX509SVIDParams)(nil),        // 10: spire.api.server.agent.v1.AgentX509SVIDParams
-	(*ListAgentsRequest_Filter)(nil),   // 11: spire.api.server.agent.v1.ListAgentsRequest.Filter
-	(*AttestAgentRequest_Params)(nil),  // 12: spire.api.server.agent.v1.AttestAgentRequest.Params
-	(*AttestAgentResponse_Result)(nil), // 13: spire.api.server.agent.v1.AttestAgentResponse.Result
-	(*types.AgentMask)(nil),            // 14: spire.types.AgentMask
-	(*types.Agent)(nil),                // 15: spire.types.Agent
-	(*types.SPIFFEID)(nil),             // 16: spire.types.SPIFFEID
-	(*types.X509SVID)(nil),             // 17: spire.types.X509SVID
-	(*types.SelectorMatch)(nil),        // 18: spire.types.SelectorMatch
-	(*wrapperspb.BoolValue)(nil),       // 19: google.protobuf.BoolValue
-	(*types.AttestationData)(nil),      // 20: spire.types.AttestationData
-	(*emptypb.Empty)(nil),              // 21: google.protobuf.Empty
-	(*types.JoinToken)(nil),            // 22: spire.types.JoinToken
+	(*ListAgentsRequest)(nil),            // 0: spire.api.server.agent.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),           // 1: spire.api.server.agent.v1.ListAgentsResponse
+	(*GetAgentRequest)(nil),              // 2: spire.api.server.agent.v1.GetAgentRequest
+	(*DeleteAgentRequest)(nil),           // 3: spire.api.server.agent.v1.DeleteAgentRequest
+	(*BanAgentRequest)(nil),              // 4: spire.api.server.agent.v1.BanAgentRequest
+	(*BanAgentResponse)(nil),             // 5: spire.api.server.agent.v1.BanAgentResponse
+	(*AttestAgentRequest)(nil),           // 6: spire.api.server.agent.v1.AttestAgentRequest
+	(*AttestAgentResponse)(nil),          // 7: spire.api.server.agent.v1.AttestAgentResponse
+	(*RenewAgentRequest)(nil),            // 8: spire.api.server.agent.v1.RenewAgentRequest
+	(*RenewAgentResponse)(nil),           // 9: spire.api.server.agent.v1.RenewAgentResponse
+	(*CreateJoinTokenRequest)(nil),       // 10: spire.api.server.agent.v1.CreateJoinTokenRequest
+	(*ListJoinTokensRequest)(nil),        // 11: spire.api.server.agent.v1.ListJoinTokensRequest
+	(*JoinTokenInfo)(nil),                // 12: spire.api.server.agent.v1.JoinTokenInfo
+	(*ListJoinTokensResponse)(nil),       // 13: spire.api.server.agent.v1.ListJoinTokensResponse
+	(*PruneJoinTokensRequest)(nil),       // 14: spire.api.server.agent.v1.PruneJoinTokensRequest
+	(*AgentX509SVIDParams)(nil),          // 15: spire.api.server.agent.v1.AgentX509SVIDParams
+	(*ListAgentsRequest_Filter)(nil),     // 16: spire.api.server.agent.v1.ListAgentsRequest.Filter
+	(*AttestAgentRequest_Params)(nil),    // 17: spire.api.server.agent.v1.AttestAgentRequest.Params
+	(*AttestAgentResponse_Result)(nil),   // 18: spire.api.server.agent.v1.AttestAgentResponse.Result
+	(*ListJoinTokensRequest_Filter)(nil), // 19: spire.api.server.agent.v1.ListJoinTokensRequest.Filter
+	(*types.AgentMask)(nil),              // 20: spire.types.AgentMask
+	(*types.Agent)(nil),                  // 21: spire.types.Agent
+	(*types.SPIFFEID)(nil),               // 22: spire.types.SPIFFEID
+	(*types.Status)(nil),                 // 23: spire.types.Status
+	(*types.X509SVID)(nil),               // 24: spire.types.X509SVID
+	(*types.Selector)(nil),               // 25: spire.types.Selector
+	(*types.SelectorMatch)(nil),          // 26: spire.types.SelectorMatch
+	(*wrapperspb.BoolValue)(nil),         // 27: google.protobuf.BoolValue
+	(*wrapperspb.Int64Value)(nil),        // 28: google.protobuf.Int64Value
+	(*types.AttestationData)(nil),        // 29: spire.types.AttestationData
+	(*emptypb.Empty)(nil),                // 30: google.protobuf.Empty
+	(*types.JoinToken)(nil),              // 31: spire.types.JoinToken
 }
 var file_spire_api_server_agent_v1_agent_proto_depIdxs = []int32{
-	11, // 0: spire.api.server.agent.v1.ListAgentsRequest.filter:type_name -> spire.api.server.agent.v1.ListAgentsRequest.Filter
-	14, // 1: spire.api.server.agent.v1.ListAgentsRequest.output_mask:type_name -> spire.types.AgentMask
-	15, // 2: spire.api.server.agent.v1.ListAgentsResponse.agents:type_name -> spire.types.Agent
-	16, // 3: spire.api.server.agent.v1.GetAgentRequest.id:type_name -> spire.types.SPIFFEID
-	14, // 4: spire.api.server.agent.v1.GetAgentRequest.output_mask:type_name -> spire.types.AgentMask
-	16, // 5: spire.api.server.agent.v1.DeleteAgentRequest.id:type_name -> spire.types.SPIFFEID
-	16, // 6: spire.api.server.agent.v1.BanAgentRequest.id:type_name -> spire.types.SPIFFEID
-	12, // 7: spire.api.server.agent.v1.AttestAgentRequest.params:type_name -> spire.api.server.agent.v1.AttestAgentRequest.Params
-	13, // 8: spire.api.server.agent.v1.AttestAgentResponse.result:type_name -> spire.api.server.agent.v1.AttestAgentResponse.Result
-	10, // 9: spire.api.server.agent.v1.RenewAgentRequest.params:type_name -> spire.api.server.agent.v1.AgentX509SVIDParams
-	17, // 10: spire.api.server.agent.v1.RenewAgentResponse.svid:type_name -> spire.types.X509SVID
-	16, // 11: spire.api.server.agent.v1.CreateJoinTokenRequest.agent_id:type_name -> spire.types.SPIFFEID
-	18, // 12: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_selector_match:type_name -> spire.types.SelectorMatch
-	19, // 13: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_banned:type_name -> google.protobuf.BoolValue
-	20, // 14: spire.api.server.agent.v1.AttestAgentRequest.Params.data:type_name -> spire.types.AttestationData
-	10, // 15: spire.api.server.agent.v1.AttestAgentRequest.Params.params:type_name -> spire.api.server.agent.v1.AgentX509SVIDParams
-	17, // 16: spire.api.server.agent.v1.AttestAgentResponse.Result.svid:type_name -> spire.types.X509SVID
-	0,  // 17: spire.api.server.agent.v1.Agent.ListAgents:input_type -> spire.api.server.agent.v1.ListAgentsRequest
-	2,  // 18: spire.api.server.agent.v1.Agent.GetAgent:input_type -> spire.api.server.agent.v1.GetAgentRequest
-	3,  // 19: spire.api.server.agent.v1.Agent.DeleteAgent:input_type -> spire.api.server.agent.v1.DeleteAgentRequest
-	4,  // 20: spire.api.server.agent.v1.Agent.BanAgent:input_type -> spire.api.server.agent.v1.BanAgentRequest
-	5,  // 21: spire.api.server.agent.v1.Agent.AttestAgent:input_type -> spire.api.server.agent.v1.AttestAgentRequest
-	7,  // 22: spire.api.server.agent.v1.Agent.RenewAgent:input_type -> spire.api.server.agent.v1.RenewAgentRequest
-	9,  // 23: spire.api.server.agent.v1.Agent.CreateJoinToken:input_type -> spire.api.server.agent.v1.CreateJoinTokenRequest
-	1,  // 24: spire.api.server.agent.v1.Agent.ListAgents:output_type -> spire.api.server.agent.v1.ListAgentsResponse
-	15, // 25: spire.api.server.agent.v1.Agent.GetAgent:output_type -> spire.types.Agent
-	21, // 26: spire.api.server.agent.v1.Agent.DeleteAgent:output_type -> google.protobuf.Empty
-	21, // 27: spire.api.server.agent.v1.Agent.BanAgent:output_type -> google.protobuf.Empty
-	6,  // 28: spire.api.server.agent.v1.Agent.AttestAgent:output_type -> spire.api.server.agent.v1.AttestAgentResponse
-	8,  // 29: spire.api.server.agent.v1.Agent.RenewAgent:output_type -> spire.api.server.agent.v1.RenewAgentResponse
-	22, // 30: spire.api.server.agent.v1.Agent.CreateJoinToken:output_type -> spire.types.JoinToken
-	24, // [24:31] is the sub-list for method output_type
-	17, // [17:24] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	16, // 0: spire.api.server.agent.v1.ListAgentsRequest.filter:type_name -> spire.api.server.agent.v1.ListAgentsRequest.Filter
+	20, // 1: spire.api.server.agent.v1.ListAgentsRequest.output_mask:type_name -> spire.types.AgentMask
+	21, // 2: spire.api.server.agent.v1.ListAgentsResponse.agents:type_name -> spire.types.Agent
+	22, // 3: spire.api.server.agent.v1.GetAgentRequest.id:type_name -> spire.types.SPIFFEID
+	20, // 4: spire.api.server.agent.v1.GetAgentRequest.output_mask:type_name -> spire.types.AgentMask
+	22, // 5: spire.api.server.agent.v1.DeleteAgentRequest.id:type_name -> spire.types.SPIFFEID
+	22, // 6: spire.api.server.agent.v1.BanAgentRequest.id:type_name -> spire.types.SPIFFEID
+	23, // 7: spire.api.server.agent.v1.BanAgentResponse.revoke_entries_status:type_name -> spire.types.Status
+	17, // 8: spire.api.server.agent.v1.AttestAgentRequest.params:type_name -> spire.api.server.agent.v1.AttestAgentRequest.Params
+	18, // 9: spire.api.server.agent.v1.AttestAgentResponse.result:type_name -> spire.api.server.agent.v1.AttestAgentResponse.Result
+	15, // 10: spire.api.server.agent.v1.RenewAgentRequest.params:type_name -> spire.api.server.agent.v1.AgentX509SVIDParams
+	24, // 11: spire.api.server.agent.v1.RenewAgentResponse.svid:type_name -> spire.types.X509SVID
+	22, // 12: spire.api.server.agent.v1.CreateJoinTokenRequest.agent_id:type_name -> spire.types.SPIFFEID
+	25, // 13: spire.api.server.agent.v1.CreateJoinTokenRequest.agent_selectors:type_name -> spire.types.Selector
+	19, // 14: spire.api.server.agent.v1.ListJoinTokensRequest.filter:type_name -> spire.api.server.agent.v1.ListJoinTokensRequest.Filter
+	12, // 15: spire.api.server.agent.v1.ListJoinTokensResponse.join_tokens:type_name -> spire.api.server.agent.v1.JoinTokenInfo
+	26, // 16: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_selector_match:type_name -> spire.types.SelectorMatch
+	27, // 17: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_banned:type_name -> google.protobuf.BoolValue
+	28, // 18: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_expires_before:type_name -> google.protobuf.Int64Value
+	28, // 19: spire.api.server.agent.v1.ListAgentsRequest.Filter.by_expires_at_or_after:type_name -> google.protobuf.Int64Value
+	29, // 20: spire.api.server.agent.v1.AttestAgentRequest.Params.data:type_name -> spire.types.AttestationData
+	15, // 21: spire.api.server.agent.v1.AttestAgentRequest.Params.params:type_name -> spire.api.server.agent.v1.AgentX509SVIDParams
+	24, // 22: spire.api.server.agent.v1.AttestAgentResponse.Result.svid:type_name -> spire.types.X509SVID
+	27, // 23: spire.api.server.agent.v1.ListJoinTokensRequest.Filter.by_expired:type_name -> google.protobuf.BoolValue
+	27, // 24: spire.api.server.agent.v1.ListJoinTokensRequest.Filter.by_used:type_name -> google.protobuf.BoolValue
+	0,  // 25: spire.api.server.agent.v1.Agent.ListAgents:input_type -> spire.api.server.agent.v1.ListAgentsRequest
+	2,  // 26: spire.api.server.agent.v1.Agent.GetAgent:input_type -> spire.api.server.agent.v1.GetAgentRequest
+	3,  // 27: spire.api.server.agent.v1.Agent.DeleteAgent:input_type -> spire.api.server.agent.v1.DeleteAgentRequest
+	4,  // 28: spire.api.server.agent.v1.Agent.BanAgent:input_type -> spire.api.server.agent.v1.BanAgentRequest
+	6,  // 29: spire.api.server.agent.v1.Agent.AttestAgent:input_type -> spire.api.server.agent.v1.AttestAgentRequest
+	8,  // 30: spire.api.server.agent.v1.Agent.RenewAgent:input_type -> spire.api.server.agent.v1.RenewAgentRequest
+	10, // 31: spire.api.server.agent.v1.Agent.CreateJoinToken:input_type -> spire.api.server.agent.v1.CreateJoinTokenRequest
+	11, // 32: spire.api.server.agent.v1.Agent.ListJoinTokens:input_type -> spire.api.server.agent.v1.ListJoinTokensRequest
+	14, // 33: spire.api.server.agent.v1.Agent.PruneJoinTokens:input_type -> spire.api.server.agent.v1.PruneJoinTokensRequest
+	1,  // 34: spire.api.server.agent.v1.Agent.ListAgents:output_type -> spire.api.server.agent.v1.ListAgentsResponse
+	21, // 35: spire.api.server.agent.v1.Agent.GetAgent:output_type -> spire.types.Agent
+	30, // 36: spire.api.server.agent.v1.Agent.DeleteAgent:output_type -> google.protobuf.Empty
+	5,  // 37: spire.api.server.agent.v1.Agent.BanAgent:output_type -> spire.api.server.agent.v1.BanAgentResponse
+	7,  // 38: spire.api.server.agent.v1.Agent.AttestAgent:output_type -> spire.api.server.agent.v1.AttestAgentResponse
+	9,  // 39: spire.api.server.agent.v1.Agent.RenewAgent:output_type -> spire.api.server.agent.v1.RenewAgentResponse
+	31, // 40: spire.api.server.agent.v1.Agent.CreateJoinToken:output_type -> spire.types.JoinToken
+	13, // 41: spire.api.server.agent.v1.Agent.ListJoinTokens:output_type -> spire.api.server.agent.v1.ListJoinTokensResponse
+	30, // 42: spire.api.server.agent.v1.Agent.PruneJoinTokens:output_type -> google.protobuf.Empty
+	34, // [34:43] is the sub-list for method output_type
+	25, // [25:34] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_spire_api_server_agent_v1_agent_proto_init() }
@@ -1182,7 +1777,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AttestAgentRequest); i {
+			switch v := v.(*BanAgentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1194,7 +1789,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AttestAgentResponse); i {
+			switch v := v.(*AttestAgentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1206,7 +1801,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RenewAgentRequest); i {
+			switch v := v.(*AttestAgentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1218,7 +1813,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RenewAgentResponse); i {
+			switch v := v.(*RenewAgentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1230,7 +1825,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateJoinTokenRequest); i {
+			switch v := v.(*RenewAgentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1242,7 +1837,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentX509SVIDParams); i {
+			switch v := v.(*CreateJoinTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1254,7 +1849,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAgentsRequest_Filter); i {
+			switch v := v.(*ListJoinTokensRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1266,7 +1861,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AttestAgentRequest_Params); i {
+			switch v := v.(*JoinTokenInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1278,6 +1873,66 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			}
 		}
 		file_spire_api_server_agent_v1_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJoinTokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneJoinTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentX509SVIDParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAgentsRequest_Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttestAgentRequest_Params); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AttestAgentResponse_Result); i {
 			case 0:
 				return &v.state
@@ -1289,12 +1944,24 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 				return nil
 			}
 		}
+		file_spire_api_server_agent_v1_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJoinTokensRequest_Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_spire_api_server_agent_v1_agent_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_spire_api_server_agent_v1_agent_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*AttestAgentRequest_Params_)(nil),
 		(*AttestAgentRequest_ChallengeResponse)(nil),
 	}
-	file_spire_api_server_agent_v1_agent_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_spire_api_server_agent_v1_agent_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*AttestAgentResponse_Result_)(nil),
 		(*AttestAgentResponse_Challenge)(nil),
 	}
@@ -1304,7 +1971,7 @@ func file_spire_api_server_agent_v1_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_spire_api_server_agent_v1_agent_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -36,8 +36,12 @@ type AgentClient interface {
 	// trust domain through attestation until the ban is lifted via a call to
 	// DeleteAgent.
 	//
+	// Optionally, the entries of the agent can be revoked as part of the ban
+	// so that a node compromise can be contained in one call. See
+	// BanAgentRequest for details.
+	//
 	// The caller must be local or present an admin X509-SVID.
-	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error)
 	// Attests the agent via node attestation, using a bidirectional stream to
 	// faciliate attestation methods that require challenge/response.
 	//
@@ -54,6 +58,17 @@ type AgentClient interface {
 	//
 	// The caller must be local or present an admin X509-SVID.
 	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*types.JoinToken, error)
+	// Lists join tokens. Join tokens are single use; a used token is kept,
+	// marked with the time it was used, until it is pruned. Token values are
+	// only returned when explicitly requested.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// Deletes the join tokens, used or not, that expired before the given
+	// time.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type agentClient struct {
@@ -91,8 +106,8 @@ func (c *agentClient) DeleteAgent(ctx context.Context, in *DeleteAgentRequest, o
 	return out, nil
 }
 
-func (c *agentClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *agentClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error) {
+	out := new(BanAgentResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/BanAgent", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -149,6 +164,24 @@ func (c *agentClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRe
 	return out, nil
 }
 
+func (c *agentClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/PruneJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations must embed UnimplementedAgentServer
 // for forward compatibility
@@ -170,8 +203,12 @@ type AgentServer interface {
 	// trust domain through attestation until the ban is lifted via a call to
 	// DeleteAgent.
 	//
+	// Optionally, the entries of the agent can be revoked as part of the ban
+	// so that a node compromise can be contained in one call. See
+	// BanAgentRequest for details.
+	//
 	// The caller must be local or present an admin X509-SVID.
-	BanAgent(context.Context, *BanAgentRequest) (*emptypb.Empty, error)
+	BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error)
 	// Attests the agent via node attestation, using a bidirectional stream to
 	// faciliate attestation methods that require challenge/response.
 	//
@@ -188,6 +225,17 @@ type AgentServer interface {
 	//
 	// The caller must be local or present an admin X509-SVID.
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*types.JoinToken, error)
+	// Lists join tokens. Join tokens are single use; a used token is kept,
+	// marked with the time it was used, until it is pruned. Token values are
+	// only returned when explicitly requested.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	// Deletes the join tokens, used or not, that expired before the given
+	// time.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAgentServer()
 }
 
@@ -204,7 +252,7 @@ func (UnimplementedAgentServer) GetAgent(context.Context, *GetAgentRequest) (*ty
 func (UnimplementedAgentServer) DeleteAgent(context.Context, *DeleteAgentRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAgent not implemented")
 }
-func (UnimplementedAgentServer) BanAgent(context.Context, *BanAgentRequest) (*emptypb.Empty, error) {
+func (UnimplementedAgentServer) BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BanAgent not implemented")
 }
 func (UnimplementedAgentServer) AttestAgent(Agent_AttestAgentServer) error {
@@ -216,6 +264,12 @@ func (UnimplementedAgentServer) RenewAgent(context.Context, *RenewAgentRequest)
 func (UnimplementedAgentServer) CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*types.JoinToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateJoinToken not implemented")
 }
+func (UnimplementedAgentServer) ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinTokens not implemented")
+}
+func (UnimplementedAgentServer) PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
+}
 func (UnimplementedAgentServer) mustEmbedUnimplementedAgentServer() {}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
@@ -363,6 +417,42 @@ func _Agent_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agent.v1.Agent/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PruneJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agent.v1.Agent/PruneJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PruneJoinTokens(ctx, req.(*PruneJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.server.agent.v1.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -391,6 +481,14 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateJoinToken",
 			Handler:    _Agent_CreateJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _Agent_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "PruneJoinTokens",
+			Handler:    _Agent_PruneJoinTokens_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -77,7 +77,7 @@ func (x BatchDeleteFederatedBundleRequest_Mode) Number() protoreflect.EnumNumber
 
 // Deprecated: Use BatchDeleteFederatedBundleRequest_Mode.Descriptor instead.
 func (BatchDeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{13, 0}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{16, 0}
 }
 
 type GetBundleRequest struct {
@@ -128,6 +128,54 @@ func (x *GetBundleRequest) GetOutputMask() *types.BundleMask {
 	return nil
 }
 
+type WatchBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An output mask indicating which bundle fields are set in the responses.
+	OutputMask *types.BundleMask `protobuf:"bytes,1,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+}
+
+func (x *WatchBundleRequest) Reset() {
+	*x = WatchBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBundleRequest) ProtoMessage() {}
+
+func (x *WatchBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBundleRequest.ProtoReflect.Descriptor instead.
+func (*WatchBundleRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchBundleRequest) GetOutputMask() *types.BundleMask {
+	if x != nil {
+		return x.OutputMask
+	}
+	return nil
+}
+
 type AppendBundleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -139,12 +187,18 @@ type AppendBundleRequest struct {
 	JwtAuthorities []*types.JWTKey `protobuf:"bytes,2,rep,name=jwt_authorities,json=jwtAuthorities,proto3" json:"jwt_authorities,omitempty"`
 	// An output mask indicating which bundle fields are set in the response.
 	OutputMask *types.BundleMask `protobuf:"bytes,3,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+	// The refresh hint, in seconds, to set on the bundle. It is only set if
+	// the input mask selects the refresh_hint field.
+	RefreshHint int64 `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	// An input mask indicating which bundle fields, other than the
+	// authorities, should be updated. Only refresh_hint is supported.
+	InputMask *types.BundleMask `protobuf:"bytes,5,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
 }
 
 func (x *AppendBundleRequest) Reset() {
 	*x = AppendBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[1]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -157,7 +211,7 @@ func (x *AppendBundleRequest) String() string {
 func (*AppendBundleRequest) ProtoMessage() {}
 
 func (x *AppendBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[1]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +224,7 @@ func (x *AppendBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendBundleRequest.ProtoReflect.Descriptor instead.
 func (*AppendBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{1}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *AppendBundleRequest) GetX509Authorities() []*types.X509Certificate {
@@ -194,6 +248,20 @@ func (x *AppendBundleRequest) GetOutputMask() *types.BundleMask {
 	return nil
 }
 
+func (x *AppendBundleRequest) GetRefreshHint() int64 {
+	if x != nil {
+		return x.RefreshHint
+	}
+	return 0
+}
+
+func (x *AppendBundleRequest) GetInputMask() *types.BundleMask {
+	if x != nil {
+		return x.InputMask
+	}
+	return nil
+}
+
 type PublishJWTAuthorityRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -206,7 +274,7 @@ type PublishJWTAuthorityRequest struct {
 func (x *PublishJWTAuthorityRequest) Reset() {
 	*x = PublishJWTAuthorityRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[2]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -219,7 +287,7 @@ func (x *PublishJWTAuthorityRequest) String() string {
 func (*PublishJWTAuthorityRequest) ProtoMessage() {}
 
 func (x *PublishJWTAuthorityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[2]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,7 +300,7 @@ func (x *PublishJWTAuthorityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishJWTAuthorityRequest.ProtoReflect.Descriptor instead.
 func (*PublishJWTAuthorityRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{2}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PublishJWTAuthorityRequest) GetJwtAuthority() *types.JWTKey {
@@ -254,7 +322,7 @@ type PublishJWTAuthorityResponse struct {
 func (x *PublishJWTAuthorityResponse) Reset() {
 	*x = PublishJWTAuthorityResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[3]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -267,7 +335,7 @@ func (x *PublishJWTAuthorityResponse) String() string {
 func (*PublishJWTAuthorityResponse) ProtoMessage() {}
 
 func (x *PublishJWTAuthorityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[3]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +348,7 @@ func (x *PublishJWTAuthorityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishJWTAuthorityResponse.ProtoReflect.Descriptor instead.
 func (*PublishJWTAuthorityResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{3}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PublishJWTAuthorityResponse) GetJwtAuthorities() []*types.JWTKey {
@@ -307,7 +375,7 @@ type ListFederatedBundlesRequest struct {
 func (x *ListFederatedBundlesRequest) Reset() {
 	*x = ListFederatedBundlesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[4]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -320,7 +388,7 @@ func (x *ListFederatedBundlesRequest) String() string {
 func (*ListFederatedBundlesRequest) ProtoMessage() {}
 
 func (x *ListFederatedBundlesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[4]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,7 +401,7 @@ func (x *ListFederatedBundlesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFederatedBundlesRequest.ProtoReflect.Descriptor instead.
 func (*ListFederatedBundlesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{4}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListFederatedBundlesRequest) GetOutputMask() *types.BundleMask {
@@ -373,7 +441,7 @@ type ListFederatedBundlesResponse struct {
 func (x *ListFederatedBundlesResponse) Reset() {
 	*x = ListFederatedBundlesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[5]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -386,7 +454,7 @@ func (x *ListFederatedBundlesResponse) String() string {
 func (*ListFederatedBundlesResponse) ProtoMessage() {}
 
 func (x *ListFederatedBundlesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[5]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +467,7 @@ func (x *ListFederatedBundlesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFederatedBundlesResponse.ProtoReflect.Descriptor instead.
 func (*ListFederatedBundlesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{5}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListFederatedBundlesResponse) GetBundles() []*types.Bundle {
@@ -416,6 +484,92 @@ func (x *ListFederatedBundlesResponse) GetNextPageToken() string {
 	return ""
 }
 
+type CountBundlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CountBundlesRequest) Reset() {
+	*x = CountBundlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountBundlesRequest) ProtoMessage() {}
+
+func (x *CountBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountBundlesRequest.ProtoReflect.Descriptor instead.
+func (*CountBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{7}
+}
+
+type CountBundlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of bundles.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CountBundlesResponse) Reset() {
+	*x = CountBundlesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountBundlesResponse) ProtoMessage() {}
+
+func (x *CountBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountBundlesResponse.ProtoReflect.Descriptor instead.
+func (*CountBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CountBundlesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type GetFederatedBundleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -430,7 +584,7 @@ type GetFederatedBundleRequest struct {
 func (x *GetFederatedBundleRequest) Reset() {
 	*x = GetFederatedBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[6]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -443,7 +597,7 @@ func (x *GetFederatedBundleRequest) String() string {
 func (*GetFederatedBundleRequest) ProtoMessage() {}
 
 func (x *GetFederatedBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[6]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,7 +610,7 @@ func (x *GetFederatedBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFederatedBundleRequest.ProtoReflect.Descriptor instead.
 func (*GetFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{6}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetFederatedBundleRequest) GetTrustDomain() string {
@@ -487,7 +641,7 @@ type BatchCreateFederatedBundleRequest struct {
 func (x *BatchCreateFederatedBundleRequest) Reset() {
 	*x = BatchCreateFederatedBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[7]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -500,7 +654,7 @@ func (x *BatchCreateFederatedBundleRequest) String() string {
 func (*BatchCreateFederatedBundleRequest) ProtoMessage() {}
 
 func (x *BatchCreateFederatedBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[7]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,7 +667,7 @@ func (x *BatchCreateFederatedBundleRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchCreateFederatedBundleRequest.ProtoReflect.Descriptor instead.
 func (*BatchCreateFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{7}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BatchCreateFederatedBundleRequest) GetBundle() []*types.Bundle {
@@ -542,7 +696,7 @@ type BatchCreateFederatedBundleResponse struct {
 func (x *BatchCreateFederatedBundleResponse) Reset() {
 	*x = BatchCreateFederatedBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[8]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -555,7 +709,7 @@ func (x *BatchCreateFederatedBundleResponse) String() string {
 func (*BatchCreateFederatedBundleResponse) ProtoMessage() {}
 
 func (x *BatchCreateFederatedBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[8]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +722,7 @@ func (x *BatchCreateFederatedBundleResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchCreateFederatedBundleResponse.ProtoReflect.Descriptor instead.
 func (*BatchCreateFederatedBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{8}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BatchCreateFederatedBundleResponse) GetResults() []*BatchCreateFederatedBundleResponse_Result {
@@ -594,7 +748,7 @@ type BatchUpdateFederatedBundleRequest struct {
 func (x *BatchUpdateFederatedBundleRequest) Reset() {
 	*x = BatchUpdateFederatedBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[9]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -607,7 +761,7 @@ func (x *BatchUpdateFederatedBundleRequest) String() string {
 func (*BatchUpdateFederatedBundleRequest) ProtoMessage() {}
 
 func (x *BatchUpdateFederatedBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[9]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +774,7 @@ func (x *BatchUpdateFederatedBundleRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchUpdateFederatedBundleRequest.ProtoReflect.Descriptor instead.
 func (*BatchUpdateFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{9}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BatchUpdateFederatedBundleRequest) GetBundle() []*types.Bundle {
@@ -656,7 +810,7 @@ type BatchUpdateFederatedBundleResponse struct {
 func (x *BatchUpdateFederatedBundleResponse) Reset() {
 	*x = BatchUpdateFederatedBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[10]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -669,7 +823,7 @@ func (x *BatchUpdateFederatedBundleResponse) String() string {
 func (*BatchUpdateFederatedBundleResponse) ProtoMessage() {}
 
 func (x *BatchUpdateFederatedBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[10]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +836,7 @@ func (x *BatchUpdateFederatedBundleResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchUpdateFederatedBundleResponse.ProtoReflect.Descriptor instead.
 func (*BatchUpdateFederatedBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{10}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BatchUpdateFederatedBundleResponse) GetResults() []*BatchUpdateFederatedBundleResponse_Result {
@@ -706,7 +860,7 @@ type BatchSetFederatedBundleRequest struct {
 func (x *BatchSetFederatedBundleRequest) Reset() {
 	*x = BatchSetFederatedBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[11]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -719,7 +873,7 @@ func (x *BatchSetFederatedBundleRequest) String() string {
 func (*BatchSetFederatedBundleRequest) ProtoMessage() {}
 
 func (x *BatchSetFederatedBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[11]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +886,7 @@ func (x *BatchSetFederatedBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchSetFederatedBundleRequest.ProtoReflect.Descriptor instead.
 func (*BatchSetFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{11}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BatchSetFederatedBundleRequest) GetBundle() []*types.Bundle {
@@ -761,7 +915,7 @@ type BatchSetFederatedBundleResponse struct {
 func (x *BatchSetFederatedBundleResponse) Reset() {
 	*x = BatchSetFederatedBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[12]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -774,7 +928,7 @@ func (x *BatchSetFederatedBundleResponse) String() string {
 func (*BatchSetFederatedBundleResponse) ProtoMessage() {}
 
 func (x *BatchSetFederatedBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[12]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +941,7 @@ func (x *BatchSetFederatedBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchSetFederatedBundleResponse.ProtoReflect.Descriptor instead.
 func (*BatchSetFederatedBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{12}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BatchSetFederatedBundleResponse) GetResults() []*BatchSetFederatedBundleResponse_Result {
@@ -811,7 +965,7 @@ type BatchDeleteFederatedBundleRequest struct {
 func (x *BatchDeleteFederatedBundleRequest) Reset() {
 	*x = BatchDeleteFederatedBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[13]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -824,7 +978,7 @@ func (x *BatchDeleteFederatedBundleRequest) String() string {
 func (*BatchDeleteFederatedBundleRequest) ProtoMessage() {}
 
 func (x *BatchDeleteFederatedBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[13]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,7 +991,7 @@ func (x *BatchDeleteFederatedBundleRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchDeleteFederatedBundleRequest.ProtoReflect.Descriptor instead.
 func (*BatchDeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{13}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *BatchDeleteFederatedBundleRequest) GetTrustDomains() []string {
@@ -866,7 +1020,7 @@ type BatchDeleteFederatedBundleResponse struct {
 func (x *BatchDeleteFederatedBundleResponse) Reset() {
 	*x = BatchDeleteFederatedBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[14]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -879,7 +1033,7 @@ func (x *BatchDeleteFederatedBundleResponse) String() string {
 func (*BatchDeleteFederatedBundleResponse) ProtoMessage() {}
 
 func (x *BatchDeleteFederatedBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[14]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,7 +1046,7 @@ func (x *BatchDeleteFederatedBundleResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BatchDeleteFederatedBundleResponse.ProtoReflect.Descriptor instead.
 func (*BatchDeleteFederatedBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{14}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BatchDeleteFederatedBundleResponse) GetResults() []*BatchDeleteFederatedBundleResponse_Result {
@@ -916,7 +1070,7 @@ type BatchCreateFederatedBundleResponse_Result struct {
 func (x *BatchCreateFederatedBundleResponse_Result) Reset() {
 	*x = BatchCreateFederatedBundleResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[15]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -929,7 +1083,7 @@ func (x *BatchCreateFederatedBundleResponse_Result) String() string {
 func (*BatchCreateFederatedBundleResponse_Result) ProtoMessage() {}
 
 func (x *BatchCreateFederatedBundleResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[15]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -942,7 +1096,7 @@ func (x *BatchCreateFederatedBundleResponse_Result) ProtoReflect() protoreflect.
 
 // Deprecated: Use BatchCreateFederatedBundleResponse_Result.ProtoReflect.Descriptor instead.
 func (*BatchCreateFederatedBundleResponse_Result) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{8, 0}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *BatchCreateFederatedBundleResponse_Result) GetStatus() *types.Status {
@@ -973,7 +1127,7 @@ type BatchUpdateFederatedBundleResponse_Result struct {
 func (x *BatchUpdateFederatedBundleResponse_Result) Reset() {
 	*x = BatchUpdateFederatedBundleResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[16]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -986,7 +1140,7 @@ func (x *BatchUpdateFederatedBundleResponse_Result) String() string {
 func (*BatchUpdateFederatedBundleResponse_Result) ProtoMessage() {}
 
 func (x *BatchUpdateFederatedBundleResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[16]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1153,7 @@ func (x *BatchUpdateFederatedBundleResponse_Result) ProtoReflect() protoreflect.
 
 // Deprecated: Use BatchUpdateFederatedBundleResponse_Result.ProtoReflect.Descriptor instead.
 func (*BatchUpdateFederatedBundleResponse_Result) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{10, 0}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{13, 0}
 }
 
 func (x *BatchUpdateFederatedBundleResponse_Result) GetStatus() *types.Status {
@@ -1030,7 +1184,7 @@ type BatchSetFederatedBundleResponse_Result struct {
 func (x *BatchSetFederatedBundleResponse_Result) Reset() {
 	*x = BatchSetFederatedBundleResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[17]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1043,7 +1197,7 @@ func (x *BatchSetFederatedBundleResponse_Result) String() string {
 func (*BatchSetFederatedBundleResponse_Result) ProtoMessage() {}
 
 func (x *BatchSetFederatedBundleResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[17]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,7 +1210,7 @@ func (x *BatchSetFederatedBundleResponse_Result) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use BatchSetFederatedBundleResponse_Result.ProtoReflect.Descriptor instead.
 func (*BatchSetFederatedBundleResponse_Result) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{12, 0}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *BatchSetFederatedBundleResponse_Result) GetStatus() *types.Status {
@@ -1088,7 +1242,7 @@ type BatchDeleteFederatedBundleResponse_Result struct {
 func (x *BatchDeleteFederatedBundleResponse_Result) Reset() {
 	*x = BatchDeleteFederatedBundleResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[18]
+		mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1101,7 +1255,7 @@ func (x *BatchDeleteFederatedBundleResponse_Result) String() string {
 func (*BatchDeleteFederatedBundleResponse_Result) ProtoMessage() {}
 
 func (x *BatchDeleteFederatedBundleResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[18]
+	mi := &file_spire_api_server_bundle_v1_bundle_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1268,7 @@ func (x *BatchDeleteFederatedBundleResponse_Result) ProtoReflect() protoreflect.
 
 // Deprecated: Use BatchDeleteFederatedBundleResponse_Result.ProtoReflect.Descriptor instead.
 func (*BatchDeleteFederatedBundleResponse_Result) Descriptor() ([]byte, []int) {
-	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{14, 0}
+	return file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *BatchDeleteFederatedBundleResponse_Result) GetStatus() *types.Status {
@@ -1146,7 +1300,12 @@ var file_spire_api_server_bundle_v1_bundle_proto_rawDesc = []byte{
 	0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
 	0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xd6, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65,
+	0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x4e, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a,
+	0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xb1, 0x02, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65,
 	0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x47, 0x0a, 0x10, 0x78, 0x35, 0x30, 0x39, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
 	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x70, 0x69, 0x72,
@@ -1160,35 +1319,45 @@ var file_spire_api_server_bundle_v1_bundle_proto_rawDesc = []byte{
 	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70,
 	0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
 	0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b,
-	0x22, 0x56, 0x0a, 0x1a, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38,
-	0x0a, 0x0d, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x4a, 0x57, 0x54, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x6a, 0x77, 0x74, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x5b, 0x0a, 0x1b, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0f, 0x6a, 0x77, 0x74, 0x5f, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x68, 0x69, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x48,
+	0x69, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b,
+	0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x56, 0x0a, 0x1a, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0d, 0x6a, 0x77, 0x74,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4a,
-	0x57, 0x54, 0x4b, 0x65, 0x79, 0x52, 0x0e, 0x6a, 0x77, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65,
+	0x57, 0x54, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x6a, 0x77, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x22, 0x5b, 0x0a, 0x1b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57,
+	0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0f, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4a, 0x57, 0x54, 0x4b, 0x65, 0x79,
+	0x52, 0x0e, 0x6a, 0x77, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x22, 0x93, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x38, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x75, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65,
 	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
-	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d,
-	0x61, 0x73, 0x6b, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x12,
-	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x75, 0x0a, 0x1c, 0x4c,
-	0x69, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x62,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x52, 0x07, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
-	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x22, 0x78, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x07, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x15, 0x0a,
+	0x13, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x2c, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x78, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74,
 	0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x21, 0x0a, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
@@ -1296,85 +1465,98 @@ var file_spire_api_server_bundle_v1_bundle_proto_rawDesc = []byte{
 	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
 	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72,
 	0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x32, 0x94, 0x09,
+	0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x32, 0xdd, 0x0a,
 	0x0a, 0x06, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x4e, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x42,
 	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70,
 	0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e,
 	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x54, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x65,
-	0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x86,
-	0x01, 0x0a, 0x13, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37,
+	0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x30, 0x01, 0x12, 0x54,
+	0x0a, 0x0c, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2f,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x89, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
-	0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73,
-	0x12, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x86, 0x01, 0x0a, 0x13, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x4a, 0x57, 0x54, 0x41, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x89, 0x01,
+	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72,
 	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x9b, 0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x12, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x9b,
+	0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x65,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3d, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75,
-	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x9b, 0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64,
+	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x9b, 0x01, 0x0a,
+	0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3d, 0x2e, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64,
 	0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x12, 0x3d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x92, 0x01, 0x0a, 0x17, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x3e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x92, 0x01, 0x0a, 0x17, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x46, 0x65,
-	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3a, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x9b, 0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46,
+	0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3d,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e,
 	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
 	0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x53, 0x65, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x46,
-	0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x9b, 0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68,
 	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x65,
-	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x65, 0x64,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2f, 0x76, 0x31,
-	0x3b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a,
+	0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66,
+	0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1390,86 +1572,95 @@ func file_spire_api_server_bundle_v1_bundle_proto_rawDescGZIP() []byte {
 }
 
 var file_spire_api_server_bundle_v1_bundle_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_spire_api_server_bundle_v1_bundle_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_spire_api_server_bundle_v1_bundle_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_spire_api_server_bundle_v1_bundle_proto_goTypes = []interface{}{
 	(BatchDeleteFederatedBundleRequest_Mode)(0),       // 0: spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest.Mode
 	(*GetBundleRequest)(nil),                          // 1: spire.api.server.bundle.v1.GetBundleRequest
-	(*AppendBundleRequest)(nil),                       // 2: spire.api.server.bundle.v1.AppendBundleRequest
-	(*PublishJWTAuthorityRequest)(nil),                // 3: spire.api.server.bundle.v1.PublishJWTAuthorityRequest
-	(*PublishJWTAuthorityResponse)(nil),               // 4: spire.api.server.bundle.v1.PublishJWTAuthorityResponse
-	(*ListFederatedBundlesRequest)(nil),               // 5: spire.api.server.bundle.v1.ListFederatedBundlesRequest
-	(*ListFederatedBundlesResponse)(nil),              // 6: spire.api.server.bundle.v1.ListFederatedBundlesResponse
-	(*GetFederatedBundleRequest)(nil),                 // 7: spire.api.server.bundle.v1.GetFederatedBundleRequest
-	(*BatchCreateFederatedBundleRequest)(nil),         // 8: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest
-	(*BatchCreateFederatedBundleResponse)(nil),        // 9: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse
-	(*BatchUpdateFederatedBundleRequest)(nil),         // 10: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest
-	(*BatchUpdateFederatedBundleResponse)(nil),        // 11: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse
-	(*BatchSetFederatedBundleRequest)(nil),            // 12: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest
-	(*BatchSetFederatedBundleResponse)(nil),           // 13: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse
-	(*BatchDeleteFederatedBundleRequest)(nil),         // 14: spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest
-	(*BatchDeleteFederatedBundleResponse)(nil),        // 15: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse
-	(*BatchCreateFederatedBundleResponse_Result)(nil), // 16: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result
-	(*BatchUpdateFederatedBundleResponse_Result)(nil), // 17: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result
-	(*BatchSetFederatedBundleResponse_Result)(nil),    // 18: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result
-	(*BatchDeleteFederatedBundleResponse_Result)(nil), // 19: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result
-	(*types.BundleMask)(nil),                          // 20: spire.types.BundleMask
-	(*types.X509Certificate)(nil),                     // 21: spire.types.X509Certificate
-	(*types.JWTKey)(nil),                              // 22: spire.types.JWTKey
-	(*types.Bundle)(nil),                              // 23: spire.types.Bundle
-	(*types.Status)(nil),                              // 24: spire.types.Status
+	(*WatchBundleRequest)(nil),                        // 2: spire.api.server.bundle.v1.WatchBundleRequest
+	(*AppendBundleRequest)(nil),                       // 3: spire.api.server.bundle.v1.AppendBundleRequest
+	(*PublishJWTAuthorityRequest)(nil),                // 4: spire.api.server.bundle.v1.PublishJWTAuthorityRequest
+	(*PublishJWTAuthorityResponse)(nil),               // 5: spire.api.server.bundle.v1.PublishJWTAuthorityResponse
+	(*ListFederatedBundlesRequest)(nil),               // 6: spire.api.server.bundle.v1.ListFederatedBundlesRequest
+	(*ListFederatedBundlesResponse)(nil),              // 7: spire.api.server.bundle.v1.ListFederatedBundlesResponse
+	(*CountBundlesRequest)(nil),                       // 8: spire.api.server.bundle.v1.CountBundlesRequest
+	(*CountBundlesResponse)(nil),                      // 9: spire.api.server.bundle.v1.CountBundlesResponse
+	(*GetFederatedBundleRequest)(nil),                 // 10: spire.api.server.bundle.v1.GetFederatedBundleRequest
+	(*BatchCreateFederatedBundleRequest)(nil),         // 11: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest
+	(*BatchCreateFederatedBundleResponse)(nil),        // 12: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse
+	(*BatchUpdateFederatedBundleRequest)(nil),         // 13: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest
+	(*BatchUpdateFederatedBundleResponse)(nil),        // 14: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse
+	(*BatchSetFederatedBundleRequest)(nil),            // 15: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest
+	(*BatchSetFederatedBundleResponse)(nil),           // 16: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse
+	(*BatchDeleteFederatedBundleRequest)(nil),         // 17: spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest
+	(*BatchDeleteFederatedBundleResponse)(nil),        // 18: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse
+	(*BatchCreateFederatedBundleResponse_Result)(nil), // 19: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result
+	(*BatchUpdateFederatedBundleResponse_Result)(nil), // 20: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result
+	(*BatchSetFederatedBundleResponse_Result)(nil),    // 21: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result
+	(*BatchDeleteFederatedBundleResponse_Result)(nil), // 22: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result
+	(*types.BundleMask)(nil),                          // 23: spire.types.BundleMask
+	(*types.X509Certificate)(nil),                     // 24: spire.types.X509Certificate
+	(*types.JWTKey)(nil),                              // 25: spire.types.JWTKey
+	(*types.Bundle)(nil),                              // 26: spire.types.Bundle
+	(*types.Status)(nil),                              // 27: spire.types.Status
 }
 var file_spire_api_server_bundle_v1_bundle_proto_depIdxs = []int32{
-	20, // 0: spire.api.server.bundle.v1.GetBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	21, // 1: spire.api.server.bundle.v1.AppendBundleRequest.x509_authorities:type_name -> spire.types.X509Certificate
-	22, // 2: spire.api.server.bundle.v1.AppendBundleRequest.jwt_authorities:type_name -> spire.types.JWTKey
-	20, // 3: spire.api.server.bundle.v1.AppendBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	22, // 4: spire.api.server.bundle.v1.PublishJWTAuthorityRequest.jwt_authority:type_name -> spire.types.JWTKey
-	22, // 5: spire.api.server.bundle.v1.PublishJWTAuthorityResponse.jwt_authorities:type_name -> spire.types.JWTKey
-	20, // 6: spire.api.server.bundle.v1.ListFederatedBundlesRequest.output_mask:type_name -> spire.types.BundleMask
-	23, // 7: spire.api.server.bundle.v1.ListFederatedBundlesResponse.bundles:type_name -> spire.types.Bundle
-	20, // 8: spire.api.server.bundle.v1.GetFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	23, // 9: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
-	20, // 10: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	16, // 11: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result
-	23, // 12: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
-	20, // 13: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.input_mask:type_name -> spire.types.BundleMask
-	20, // 14: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	17, // 15: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result
-	23, // 16: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
-	20, // 17: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
-	18, // 18: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result
-	0,  // 19: spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest.mode:type_name -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest.Mode
-	19, // 20: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result
-	24, // 21: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result.status:type_name -> spire.types.Status
-	23, // 22: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
-	24, // 23: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result.status:type_name -> spire.types.Status
-	23, // 24: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
-	24, // 25: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result.status:type_name -> spire.types.Status
-	23, // 26: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
-	24, // 27: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result.status:type_name -> spire.types.Status
-	1,  // 28: spire.api.server.bundle.v1.Bundle.GetBundle:input_type -> spire.api.server.bundle.v1.GetBundleRequest
-	2,  // 29: spire.api.server.bundle.v1.Bundle.AppendBundle:input_type -> spire.api.server.bundle.v1.AppendBundleRequest
-	3,  // 30: spire.api.server.bundle.v1.Bundle.PublishJWTAuthority:input_type -> spire.api.server.bundle.v1.PublishJWTAuthorityRequest
-	5,  // 31: spire.api.server.bundle.v1.Bundle.ListFederatedBundles:input_type -> spire.api.server.bundle.v1.ListFederatedBundlesRequest
-	7,  // 32: spire.api.server.bundle.v1.Bundle.GetFederatedBundle:input_type -> spire.api.server.bundle.v1.GetFederatedBundleRequest
-	8,  // 33: spire.api.server.bundle.v1.Bundle.BatchCreateFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest
-	10, // 34: spire.api.server.bundle.v1.Bundle.BatchUpdateFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest
-	12, // 35: spire.api.server.bundle.v1.Bundle.BatchSetFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchSetFederatedBundleRequest
-	14, // 36: spire.api.server.bundle.v1.Bundle.BatchDeleteFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest
-	23, // 37: spire.api.server.bundle.v1.Bundle.GetBundle:output_type -> spire.types.Bundle
-	23, // 38: spire.api.server.bundle.v1.Bundle.AppendBundle:output_type -> spire.types.Bundle
-	4,  // 39: spire.api.server.bundle.v1.Bundle.PublishJWTAuthority:output_type -> spire.api.server.bundle.v1.PublishJWTAuthorityResponse
-	6,  // 40: spire.api.server.bundle.v1.Bundle.ListFederatedBundles:output_type -> spire.api.server.bundle.v1.ListFederatedBundlesResponse
-	23, // 41: spire.api.server.bundle.v1.Bundle.GetFederatedBundle:output_type -> spire.types.Bundle
-	9,  // 42: spire.api.server.bundle.v1.Bundle.BatchCreateFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse
-	11, // 43: spire.api.server.bundle.v1.Bundle.BatchUpdateFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse
-	13, // 44: spire.api.server.bundle.v1.Bundle.BatchSetFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchSetFederatedBundleResponse
-	15, // 45: spire.api.server.bundle.v1.Bundle.BatchDeleteFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse
-	37, // [37:46] is the sub-list for method output_type
-	28, // [28:37] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	23, // 0: spire.api.server.bundle.v1.GetBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	23, // 1: spire.api.server.bundle.v1.WatchBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	24, // 2: spire.api.server.bundle.v1.AppendBundleRequest.x509_authorities:type_name -> spire.types.X509Certificate
+	25, // 3: spire.api.server.bundle.v1.AppendBundleRequest.jwt_authorities:type_name -> spire.types.JWTKey
+	23, // 4: spire.api.server.bundle.v1.AppendBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	23, // 5: spire.api.server.bundle.v1.AppendBundleRequest.input_mask:type_name -> spire.types.BundleMask
+	25, // 6: spire.api.server.bundle.v1.PublishJWTAuthorityRequest.jwt_authority:type_name -> spire.types.JWTKey
+	25, // 7: spire.api.server.bundle.v1.PublishJWTAuthorityResponse.jwt_authorities:type_name -> spire.types.JWTKey
+	23, // 8: spire.api.server.bundle.v1.ListFederatedBundlesRequest.output_mask:type_name -> spire.types.BundleMask
+	26, // 9: spire.api.server.bundle.v1.ListFederatedBundlesResponse.bundles:type_name -> spire.types.Bundle
+	23, // 10: spire.api.server.bundle.v1.GetFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	26, // 11: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
+	23, // 12: spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	19, // 13: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result
+	26, // 14: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
+	23, // 15: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.input_mask:type_name -> spire.types.BundleMask
+	23, // 16: spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	20, // 17: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result
+	26, // 18: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest.bundle:type_name -> spire.types.Bundle
+	23, // 19: spire.api.server.bundle.v1.BatchSetFederatedBundleRequest.output_mask:type_name -> spire.types.BundleMask
+	21, // 20: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result
+	0,  // 21: spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest.mode:type_name -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest.Mode
+	22, // 22: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.results:type_name -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result
+	27, // 23: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result.status:type_name -> spire.types.Status
+	26, // 24: spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
+	27, // 25: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result.status:type_name -> spire.types.Status
+	26, // 26: spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
+	27, // 27: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result.status:type_name -> spire.types.Status
+	26, // 28: spire.api.server.bundle.v1.BatchSetFederatedBundleResponse.Result.bundle:type_name -> spire.types.Bundle
+	27, // 29: spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse.Result.status:type_name -> spire.types.Status
+	1,  // 30: spire.api.server.bundle.v1.Bundle.GetBundle:input_type -> spire.api.server.bundle.v1.GetBundleRequest
+	2,  // 31: spire.api.server.bundle.v1.Bundle.WatchBundle:input_type -> spire.api.server.bundle.v1.WatchBundleRequest
+	3,  // 32: spire.api.server.bundle.v1.Bundle.AppendBundle:input_type -> spire.api.server.bundle.v1.AppendBundleRequest
+	4,  // 33: spire.api.server.bundle.v1.Bundle.PublishJWTAuthority:input_type -> spire.api.server.bundle.v1.PublishJWTAuthorityRequest
+	6,  // 34: spire.api.server.bundle.v1.Bundle.ListFederatedBundles:input_type -> spire.api.server.bundle.v1.ListFederatedBundlesRequest
+	8,  // 35: spire.api.server.bundle.v1.Bundle.CountBundles:input_type -> spire.api.server.bundle.v1.CountBundlesRequest
+	10, // 36: spire.api.server.bundle.v1.Bundle.GetFederatedBundle:input_type -> spire.api.server.bundle.v1.GetFederatedBundleRequest
+	11, // 37: spire.api.server.bundle.v1.Bundle.BatchCreateFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchCreateFederatedBundleRequest
+	13, // 38: spire.api.server.bundle.v1.Bundle.BatchUpdateFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleRequest
+	15, // 39: spire.api.server.bundle.v1.Bundle.BatchSetFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchSetFederatedBundleRequest
+	17, // 40: spire.api.server.bundle.v1.Bundle.BatchDeleteFederatedBundle:input_type -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest
+	26, // 41: spire.api.server.bundle.v1.Bundle.GetBundle:output_type -> spire.types.Bundle
+	26, // 42: spire.api.server.bundle.v1.Bundle.WatchBundle:output_type -> spire.types.Bundle
+	26, // 43: spire.api.server.bundle.v1.Bundle.AppendBundle:output_type -> spire.types.Bundle
+	5,  // 44: spire.api.server.bundle.v1.Bundle.PublishJWTAuthority:output_type -> spire.api.server.bundle.v1.PublishJWTAuthorityResponse
+	7,  // 45: spire.api.server.bundle.v1.Bundle.ListFederatedBundles:output_type -> spire.api.server.bundle.v1.ListFederatedBundlesResponse
+	9,  // 46: spire.api.server.bundle.v1.Bundle.CountBundles:output_type -> spire.api.server.bundle.v1.CountBundlesResponse
+	26, // 47: spire.api.server.bundle.v1.Bundle.GetFederatedBundle:output_type -> spire.types.Bundle
+	12, // 48: spire.api.server.bundle.v1.Bundle.BatchCreateFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchCreateFederatedBundleResponse
+	14, // 49: spire.api.server.bundle.v1.Bundle.BatchUpdateFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchUpdateFederatedBundleResponse
+	16, // 50: spire.api.server.bundle.v1.Bundle.BatchSetFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchSetFederatedBundleResponse
+	18, // 51: spire.api.server.bundle.v1.Bundle.BatchDeleteFederatedBundle:output_type -> spire.api.server.bundle.v1.BatchDeleteFederatedBundleResponse
+	41, // [41:52] is the sub-list for method output_type
+	30, // [30:41] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_spire_api_server_bundle_v1_bundle_proto_init() }
@@ -1491,7 +1682,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendBundleRequest); i {
+			switch v := v.(*WatchBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1503,7 +1694,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublishJWTAuthorityRequest); i {
+			switch v := v.(*AppendBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1515,7 +1706,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublishJWTAuthorityResponse); i {
+			switch v := v.(*PublishJWTAuthorityRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1527,7 +1718,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListFederatedBundlesRequest); i {
+			switch v := v.(*PublishJWTAuthorityResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1539,7 +1730,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListFederatedBundlesResponse); i {
+			switch v := v.(*ListFederatedBundlesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1551,7 +1742,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFederatedBundleRequest); i {
+			switch v := v.(*ListFederatedBundlesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1563,7 +1754,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchCreateFederatedBundleRequest); i {
+			switch v := v.(*CountBundlesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1575,7 +1766,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchCreateFederatedBundleResponse); i {
+			switch v := v.(*CountBundlesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1587,7 +1778,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchUpdateFederatedBundleRequest); i {
+			switch v := v.(*GetFederatedBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1599,7 +1790,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchUpdateFederatedBundleResponse); i {
+			switch v := v.(*BatchCreateFederatedBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1611,7 +1802,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchSetFederatedBundleRequest); i {
+			switch v := v.(*BatchCreateFederatedBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1623,7 +1814,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchSetFederatedBundleResponse); i {
+			switch v := v.(*BatchUpdateFederatedBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1635,7 +1826,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchDeleteFederatedBundleRequest); i {
+			switch v := v.(*BatchUpdateFederatedBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1647,7 +1838,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchDeleteFederatedBundleResponse); i {
+			switch v := v.(*BatchSetFederatedBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1659,7 +1850,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchCreateFederatedBundleResponse_Result); i {
+			switch v := v.(*BatchSetFederatedBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1671,7 +1862,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchUpdateFederatedBundleResponse_Result); i {
+			switch v := v.(*BatchDeleteFederatedBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1683,7 +1874,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchSetFederatedBundleResponse_Result); i {
+			switch v := v.(*BatchDeleteFederatedBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1695,6 +1886,42 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			}
 		}
 		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateFederatedBundleResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchUpdateFederatedBundleResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetFederatedBundleResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_bundle_v1_bundle_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchDeleteFederatedBundleResponse_Result); i {
 			case 0:
 				return &v.state
@@ -1713,7 +1940,7 @@ func file_spire_api_server_bundle_v1_bundle_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_spire_api_server_bundle_v1_bundle_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   19,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,13 @@ type BundleClient interface {
 	//
 	// The RPC does not require authentication.
 	GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*types.Bundle, error)
+	// Watches the bundle for the trust domain of the server. The current
+	// bundle is sent when the stream is opened, and the bundle is sent again
+	// every time it changes, until the caller closes the stream. If the
+	// bundle does not exist, NOT_FOUND is returned.
+	//
+	// The RPC does not require authentication.
+	WatchBundle(ctx context.Context, in *WatchBundleRequest, opts ...grpc.CallOption) (Bundle_WatchBundleClient, error)
 	// Append to the bundle. Items specified in the bundle in the request are
 	// appended to the existing bundle. If the bundle does not exist, NOT_FOUND
 	// is returned. This is the only RPC that can be used to update the
@@ -42,6 +49,11 @@ type BundleClient interface {
 	//
 	// The caller must be local or present an admin X509-SVID.
 	ListFederatedBundles(ctx context.Context, in *ListFederatedBundlesRequest, opts ...grpc.CallOption) (*ListFederatedBundlesResponse, error)
+	// Counts the bundles, including the bundle for the trust domain of the
+	// server, without listing them.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error)
 	// Gets a federated bundle. If the bundle does not exist, NOT_FOUND is returned.
 	//
 	// The caller must be local or present an admin or an active agent X509-SVID.
@@ -81,6 +93,38 @@ func (c *bundleClient) GetBundle(ctx context.Context, in *GetBundleRequest, opts
 	return out, nil
 }
 
+func (c *bundleClient) WatchBundle(ctx context.Context, in *WatchBundleRequest, opts ...grpc.CallOption) (Bundle_WatchBundleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Bundle_serviceDesc.Streams[0], "/spire.api.server.bundle.v1.Bundle/WatchBundle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bundleWatchBundleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Bundle_WatchBundleClient interface {
+	Recv() (*types.Bundle, error)
+	grpc.ClientStream
+}
+
+type bundleWatchBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *bundleWatchBundleClient) Recv() (*types.Bundle, error) {
+	m := new(types.Bundle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *bundleClient) AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*types.Bundle, error) {
 	out := new(types.Bundle)
 	err := c.cc.Invoke(ctx, "/spire.api.server.bundle.v1.Bundle/AppendBundle", in, out, opts...)
@@ -108,6 +152,15 @@ func (c *bundleClient) ListFederatedBundles(ctx context.Context, in *ListFederat
 	return out, nil
 }
 
+func (c *bundleClient) CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error) {
+	out := new(CountBundlesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.bundle.v1.Bundle/CountBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bundleClient) GetFederatedBundle(ctx context.Context, in *GetFederatedBundleRequest, opts ...grpc.CallOption) (*types.Bundle, error) {
 	out := new(types.Bundle)
 	err := c.cc.Invoke(ctx, "/spire.api.server.bundle.v1.Bundle/GetFederatedBundle", in, out, opts...)
@@ -161,6 +214,13 @@ type BundleServer interface {
 	//
 	// The RPC does not require authentication.
 	GetBundle(context.Context, *GetBundleRequest) (*types.Bundle, error)
+	// Watches the bundle for the trust domain of the server. The current
+	// bundle is sent when the stream is opened, and the bundle is sent again
+	// every time it changes, until the caller closes the stream. If the
+	// bundle does not exist, NOT_FOUND is returned.
+	//
+	// The RPC does not require authentication.
+	WatchBundle(*WatchBundleRequest, Bundle_WatchBundleServer) error
 	// Append to the bundle. Items specified in the bundle in the request are
 	// appended to the existing bundle. If the bundle does not exist, NOT_FOUND
 	// is returned. This is the only RPC that can be used to update the
@@ -181,6 +241,11 @@ type BundleServer interface {
 	//
 	// The caller must be local or present an admin X509-SVID.
 	ListFederatedBundles(context.Context, *ListFederatedBundlesRequest) (*ListFederatedBundlesResponse, error)
+	// Counts the bundles, including the bundle for the trust domain of the
+	// server, without listing them.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
 	// Gets a federated bundle. If the bundle does not exist, NOT_FOUND is returned.
 	//
 	// The caller must be local or present an admin or an active agent X509-SVID.
@@ -211,6 +276,9 @@ type UnimplementedBundleServer struct {
 func (UnimplementedBundleServer) GetBundle(context.Context, *GetBundleRequest) (*types.Bundle, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBundle not implemented")
 }
+func (UnimplementedBundleServer) WatchBundle(*WatchBundleRequest, Bundle_WatchBundleServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBundle not implemented")
+}
 func (UnimplementedBundleServer) AppendBundle(context.Context, *AppendBundleRequest) (*types.Bundle, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AppendBundle not implemented")
 }
@@ -220,6 +288,9 @@ func (UnimplementedBundleServer) PublishJWTAuthority(context.Context, *PublishJW
 func (UnimplementedBundleServer) ListFederatedBundles(context.Context, *ListFederatedBundlesRequest) (*ListFederatedBundlesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFederatedBundles not implemented")
 }
+func (UnimplementedBundleServer) CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountBundles not implemented")
+}
 func (UnimplementedBundleServer) GetFederatedBundle(context.Context, *GetFederatedBundleRequest) (*types.Bundle, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFederatedBundle not implemented")
 }
@@ -266,6 +337,27 @@ func _Bundle_GetBundle_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bundle_WatchBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BundleServer).WatchBundle(m, &bundleWatchBundleServer{stream})
+}
+
+type Bundle_WatchBundleServer interface {
+	Send(*types.Bundle) error
+	grpc.ServerStream
+}
+
+type bundleWatchBundleServer struct {
+	grpc.ServerStream
+}
+
+func (x *bundleWatchBundleServer) Send(m *types.Bundle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Bundle_AppendBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AppendBundleRequest)
 	if err := dec(in); err != nil {
@@ -320,6 +412,24 @@ func _Bundle_ListFederatedBundles_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bundle_CountBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).CountBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.bundle.v1.Bundle/CountBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).CountBundles(ctx, req.(*CountBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bundle_GetFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFederatedBundleRequest)
 	if err := dec(in); err != nil {
@@ -430,6 +540,10 @@ var _Bundle_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListFederatedBundles",
 			Handler:    _Bundle_ListFederatedBundles_Handler,
 		},
+		{
+			MethodName: "CountBundles",
+			Handler:    _Bundle_CountBundles_Handler,
+		},
 		{
 			MethodName: "GetFederatedBundle",
 			Handler:    _Bundle_GetFederatedBundle_Handler,
@@ -451,6 +565,12 @@ var _Bundle_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Bundle_BatchDeleteFederatedBundle_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBundle",
+			Handler:       _Bundle_WatchBundle_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "spire/api/server/bundle/v1/bundle.proto",
 }
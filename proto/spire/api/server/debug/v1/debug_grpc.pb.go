@@ -19,6 +19,11 @@ const _ = grpc.SupportPackageIsVersion7
 type DebugClient interface {
 	// Get information about SPIRE server
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Get the log level of the server and of the subsystems that have a
+	// logger of their own
+	GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error)
+	// Set the log level of the server or of a subsystem
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
 }
 
 type debugClient struct {
@@ -38,12 +43,35 @@ func (c *debugClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...g
 	return out, nil
 }
 
+func (c *debugClient) GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error) {
+	out := new(GetLogLevelsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/GetLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 // All implementations must embed UnimplementedDebugServer
 // for forward compatibility
 type DebugServer interface {
 	// Get information about SPIRE server
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// Get the log level of the server and of the subsystems that have a
+	// logger of their own
+	GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error)
+	// Set the log level of the server or of a subsystem
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 	mustEmbedUnimplementedDebugServer()
 }
 
@@ -54,6 +82,12 @@ type UnimplementedDebugServer struct {
 func (UnimplementedDebugServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (UnimplementedDebugServer) GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedDebugServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
 func (UnimplementedDebugServer) mustEmbedUnimplementedDebugServer() {}
 
 // UnsafeDebugServer may be embedded to opt out of forward compatibility for this service.
@@ -85,6 +119,42 @@ func _Debug_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/GetLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).GetLogLevels(ctx, req.(*GetLogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.server.debug.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -93,6 +163,14 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Debug_GetInfo_Handler,
 		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _Debug_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Debug_SetLogLevel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/api/server/debug/v1/debug.proto",
@@ -0,0 +1,240 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: spire/api/server/info/v1/info.proto
+
+package info
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type GetBuildInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetBuildInfoRequest) Reset() {
+	*x = GetBuildInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_info_v1_info_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildInfoRequest) ProtoMessage() {}
+
+func (x *GetBuildInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_info_v1_info_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_info_v1_info_proto_rawDescGZIP(), []int{0}
+}
+
+type GetBuildInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Server version
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Experimental features enabled on the server (e.g. "api_gateway")
+	ExperimentalFeatures []string `protobuf:"bytes,2,rep,name=experimental_features,json=experimentalFeatures,proto3" json:"experimental_features,omitempty"`
+	// API versions served by the server, as protobuf package names (e.g.
+	// "spire.api.server.agent.v1")
+	ApiVersions []string `protobuf:"bytes,3,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"`
+}
+
+func (x *GetBuildInfoResponse) Reset() {
+	*x = GetBuildInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_info_v1_info_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildInfoResponse) ProtoMessage() {}
+
+func (x *GetBuildInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_info_v1_info_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_info_v1_info_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBuildInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetBuildInfoResponse) GetExperimentalFeatures() []string {
+	if x != nil {
+		return x.ExperimentalFeatures
+	}
+	return nil
+}
+
+func (x *GetBuildInfoResponse) GetApiVersions() []string {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+var File_spire_api_server_info_v1_info_proto protoreflect.FileDescriptor
+
+var file_spire_api_server_info_v1_info_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x22,
+	0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x15, 0x65, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x32, 0x75, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x6d, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x69, 0x6e, 0x66,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x69, 0x6e, 0x66, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x2f,
+	0x76, 0x31, 0x3b, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_spire_api_server_info_v1_info_proto_rawDescOnce sync.Once
+	file_spire_api_server_info_v1_info_proto_rawDescData = file_spire_api_server_info_v1_info_proto_rawDesc
+)
+
+func file_spire_api_server_info_v1_info_proto_rawDescGZIP() []byte {
+	file_spire_api_server_info_v1_info_proto_rawDescOnce.Do(func() {
+		file_spire_api_server_info_v1_info_proto_rawDescData = protoimpl.X.CompressGZIP(file_spire_api_server_info_v1_info_proto_rawDescData)
+	})
+	return file_spire_api_server_info_v1_info_proto_rawDescData
+}
+
+var file_spire_api_server_info_v1_info_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_spire_api_server_info_v1_info_proto_goTypes = []interface{}{
+	(*GetBuildInfoRequest)(nil),  // 0: spire.api.server.info.v1.GetBuildInfoRequest
+	(*GetBuildInfoResponse)(nil), // 1: spire.api.server.info.v1.GetBuildInfoResponse
+}
+var file_spire_api_server_info_v1_info_proto_depIdxs = []int32{
+	0, // 0: spire.api.server.info.v1.Info.GetBuildInfo:input_type -> spire.api.server.info.v1.GetBuildInfoRequest
+	1, // 1: spire.api.server.info.v1.Info.GetBuildInfo:output_type -> spire.api.server.info.v1.GetBuildInfoResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_spire_api_server_info_v1_info_proto_init() }
+func file_spire_api_server_info_v1_info_proto_init() {
+	if File_spire_api_server_info_v1_info_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_spire_api_server_info_v1_info_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_info_v1_info_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_spire_api_server_info_v1_info_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_api_server_info_v1_info_proto_goTypes,
+		DependencyIndexes: file_spire_api_server_info_v1_info_proto_depIdxs,
+		MessageInfos:      file_spire_api_server_info_v1_info_proto_msgTypes,
+	}.Build()
+	File_spire_api_server_info_v1_info_proto = out.File
+	file_spire_api_server_info_v1_info_proto_rawDesc = nil
+	file_spire_api_server_info_v1_info_proto_goTypes = nil
+	file_spire_api_server_info_v1_info_proto_depIdxs = nil
+}
@@ -80,7 +80,7 @@ func (x DeleteBundleRequest_Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeleteBundleRequest_Mode.Descriptor instead.
 func (DeleteBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{14, 0}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{15, 0}
 }
 
 type BySelectors_MatchBehavior int32
@@ -126,7 +126,7 @@ func (x BySelectors_MatchBehavior) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BySelectors_MatchBehavior.Descriptor instead.
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{41, 0}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{42, 0}
 }
 
 type ByFederatesWith_MatchBehavior int32
@@ -134,6 +134,7 @@ type ByFederatesWith_MatchBehavior int32
 const (
 	ByFederatesWith_MATCH_EXACT  ByFederatesWith_MatchBehavior = 0
 	ByFederatesWith_MATCH_SUBSET ByFederatesWith_MatchBehavior = 1
+	ByFederatesWith_MATCH_ANY    ByFederatesWith_MatchBehavior = 2
 )
 
 // Enum value maps for ByFederatesWith_MatchBehavior.
@@ -141,10 +142,12 @@ var (
 	ByFederatesWith_MatchBehavior_name = map[int32]string{
 		0: "MATCH_EXACT",
 		1: "MATCH_SUBSET",
+		2: "MATCH_ANY",
 	}
 	ByFederatesWith_MatchBehavior_value = map[string]int32{
 		"MATCH_EXACT":  0,
 		"MATCH_SUBSET": 1,
+		"MATCH_ANY":    2,
 	}
 )
 
@@ -172,7 +175,65 @@ func (x ByFederatesWith_MatchBehavior) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ByFederatesWith_MatchBehavior.Descriptor instead.
 func (ByFederatesWith_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{42, 0}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{43, 0}
+}
+
+type IntegrityIssue_Kind int32
+
+const (
+	// A registration entry selector whose entry no longer exists
+	IntegrityIssue_ORPHANED_SELECTOR IntegrityIssue_Kind = 0
+	// A registration entry DNS name whose entry no longer exists
+	IntegrityIssue_ORPHANED_DNS_NAME IntegrityIssue_Kind = 1
+	// A federation relationship between a registration entry and a
+	// bundle, where either of them no longer exists
+	IntegrityIssue_DANGLING_FEDERATION IntegrityIssue_Kind = 2
+	// An attested node that has a pending SVID serial number but no
+	// current one. Banned nodes, which have neither, are not reported.
+	IntegrityIssue_NODE_WITHOUT_SERIAL IntegrityIssue_Kind = 3
+)
+
+// Enum value maps for IntegrityIssue_Kind.
+var (
+	IntegrityIssue_Kind_name = map[int32]string{
+		0: "ORPHANED_SELECTOR",
+		1: "ORPHANED_DNS_NAME",
+		2: "DANGLING_FEDERATION",
+		3: "NODE_WITHOUT_SERIAL",
+	}
+	IntegrityIssue_Kind_value = map[string]int32{
+		"ORPHANED_SELECTOR":   0,
+		"ORPHANED_DNS_NAME":   1,
+		"DANGLING_FEDERATION": 2,
+		"NODE_WITHOUT_SERIAL": 3,
+	}
+)
+
+func (x IntegrityIssue_Kind) Enum() *IntegrityIssue_Kind {
+	p := new(IntegrityIssue_Kind)
+	*p = x
+	return p
+}
+
+func (x IntegrityIssue_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IntegrityIssue_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_server_datastore_datastore_proto_enumTypes[3].Descriptor()
+}
+
+func (IntegrityIssue_Kind) Type() protoreflect.EnumType {
+	return &file_spire_server_datastore_datastore_proto_enumTypes[3]
+}
+
+func (x IntegrityIssue_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IntegrityIssue_Kind.Descriptor instead.
+func (IntegrityIssue_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{71, 0}
 }
 
 type CreateBundleRequest struct {
@@ -550,6 +611,74 @@ func (x *ListBundlesResponse) GetPagination() *Pagination {
 	return nil
 }
 
+// Limits on the contents of a bundle. A value of zero disables the
+// corresponding limit.
+type BundleLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of X.509 authorities (root CAs) in the bundle.
+	MaxX509Authorities int32 `protobuf:"varint,1,opt,name=max_x509_authorities,json=maxX509Authorities,proto3" json:"max_x509_authorities,omitempty"`
+	// Maximum number of JWT authorities (signing keys) in the bundle.
+	MaxJwtAuthorities int32 `protobuf:"varint,2,opt,name=max_jwt_authorities,json=maxJwtAuthorities,proto3" json:"max_jwt_authorities,omitempty"`
+	// Maximum size in bytes of the serialized bundle.
+	MaxBundleBytes int32 `protobuf:"varint,3,opt,name=max_bundle_bytes,json=maxBundleBytes,proto3" json:"max_bundle_bytes,omitempty"`
+}
+
+func (x *BundleLimits) Reset() {
+	*x = BundleLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BundleLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleLimits) ProtoMessage() {}
+
+func (x *BundleLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleLimits.ProtoReflect.Descriptor instead.
+func (*BundleLimits) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BundleLimits) GetMaxX509Authorities() int32 {
+	if x != nil {
+		return x.MaxX509Authorities
+	}
+	return 0
+}
+
+func (x *BundleLimits) GetMaxJwtAuthorities() int32 {
+	if x != nil {
+		return x.MaxJwtAuthorities
+	}
+	return 0
+}
+
+func (x *BundleLimits) GetMaxBundleBytes() int32 {
+	if x != nil {
+		return x.MaxBundleBytes
+	}
+	return 0
+}
+
 type UpdateBundleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -557,12 +686,15 @@ type UpdateBundleRequest struct {
 
 	Bundle    *common.Bundle     `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	InputMask *common.BundleMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	// If set, the update fails with InvalidArgument if the updated bundle
+	// exceeds the limits.
+	Limits *BundleLimits `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
 func (x *UpdateBundleRequest) Reset() {
 	*x = UpdateBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[8]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -575,7 +707,7 @@ func (x *UpdateBundleRequest) String() string {
 func (*UpdateBundleRequest) ProtoMessage() {}
 
 func (x *UpdateBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[8]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +720,7 @@ func (x *UpdateBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBundleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{8}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateBundleRequest) GetBundle() *common.Bundle {
@@ -605,6 +737,13 @@ func (x *UpdateBundleRequest) GetInputMask() *common.BundleMask {
 	return nil
 }
 
+func (x *UpdateBundleRequest) GetLimits() *BundleLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
 type UpdateBundleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -616,7 +755,7 @@ type UpdateBundleResponse struct {
 func (x *UpdateBundleResponse) Reset() {
 	*x = UpdateBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[9]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -629,7 +768,7 @@ func (x *UpdateBundleResponse) String() string {
 func (*UpdateBundleResponse) ProtoMessage() {}
 
 func (x *UpdateBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[9]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +781,7 @@ func (x *UpdateBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBundleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{9}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateBundleResponse) GetBundle() *common.Bundle {
@@ -658,12 +797,15 @@ type SetBundleRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// If set, the set fails with InvalidArgument if the stored bundle would
+	// exceed the limits.
+	Limits *BundleLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
 func (x *SetBundleRequest) Reset() {
 	*x = SetBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[10]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -676,7 +818,7 @@ func (x *SetBundleRequest) String() string {
 func (*SetBundleRequest) ProtoMessage() {}
 
 func (x *SetBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[10]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +831,7 @@ func (x *SetBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetBundleRequest.ProtoReflect.Descriptor instead.
 func (*SetBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{10}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SetBundleRequest) GetBundle() *common.Bundle {
@@ -699,6 +841,13 @@ func (x *SetBundleRequest) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (x *SetBundleRequest) GetLimits() *BundleLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
 type SetBundleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -710,7 +859,7 @@ type SetBundleResponse struct {
 func (x *SetBundleResponse) Reset() {
 	*x = SetBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[11]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -723,7 +872,7 @@ func (x *SetBundleResponse) String() string {
 func (*SetBundleResponse) ProtoMessage() {}
 
 func (x *SetBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[11]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,7 +885,7 @@ func (x *SetBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetBundleResponse.ProtoReflect.Descriptor instead.
 func (*SetBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{11}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SetBundleResponse) GetBundle() *common.Bundle {
@@ -752,12 +901,20 @@ type AppendBundleRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// If set, the append fails with InvalidArgument if the bundle resulting
+	// from the append exceeds the limits. The limits are checked in the same
+	// transaction as the append, so concurrent appends cannot together
+	// exceed them.
+	Limits *BundleLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	// If set, the refresh hint of the bundle is set to this value in the
+	// same write as the append.
+	RefreshHint *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
 }
 
 func (x *AppendBundleRequest) Reset() {
 	*x = AppendBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[12]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -770,7 +927,7 @@ func (x *AppendBundleRequest) String() string {
 func (*AppendBundleRequest) ProtoMessage() {}
 
 func (x *AppendBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[12]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +940,7 @@ func (x *AppendBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendBundleRequest.ProtoReflect.Descriptor instead.
 func (*AppendBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{12}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AppendBundleRequest) GetBundle() *common.Bundle {
@@ -793,6 +950,20 @@ func (x *AppendBundleRequest) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (x *AppendBundleRequest) GetLimits() *BundleLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *AppendBundleRequest) GetRefreshHint() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.RefreshHint
+	}
+	return nil
+}
+
 type AppendBundleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -804,7 +975,7 @@ type AppendBundleResponse struct {
 func (x *AppendBundleResponse) Reset() {
 	*x = AppendBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[13]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -817,7 +988,7 @@ func (x *AppendBundleResponse) String() string {
 func (*AppendBundleResponse) ProtoMessage() {}
 
 func (x *AppendBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[13]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1001,7 @@ func (x *AppendBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendBundleResponse.ProtoReflect.Descriptor instead.
 func (*AppendBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{13}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AppendBundleResponse) GetBundle() *common.Bundle {
@@ -852,7 +1023,7 @@ type DeleteBundleRequest struct {
 func (x *DeleteBundleRequest) Reset() {
 	*x = DeleteBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[14]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -865,7 +1036,7 @@ func (x *DeleteBundleRequest) String() string {
 func (*DeleteBundleRequest) ProtoMessage() {}
 
 func (x *DeleteBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[14]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,7 +1049,7 @@ func (x *DeleteBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBundleRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{14}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteBundleRequest) GetTrustDomainId() string {
@@ -906,7 +1077,7 @@ type DeleteBundleResponse struct {
 func (x *DeleteBundleResponse) Reset() {
 	*x = DeleteBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[15]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -919,7 +1090,7 @@ func (x *DeleteBundleResponse) String() string {
 func (*DeleteBundleResponse) ProtoMessage() {}
 
 func (x *DeleteBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[15]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -932,7 +1103,7 @@ func (x *DeleteBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBundleResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{15}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteBundleResponse) GetBundle() *common.Bundle {
@@ -956,7 +1127,7 @@ type PruneBundleRequest struct {
 func (x *PruneBundleRequest) Reset() {
 	*x = PruneBundleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[16]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -969,7 +1140,7 @@ func (x *PruneBundleRequest) String() string {
 func (*PruneBundleRequest) ProtoMessage() {}
 
 func (x *PruneBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[16]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -982,7 +1153,7 @@ func (x *PruneBundleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruneBundleRequest.ProtoReflect.Descriptor instead.
 func (*PruneBundleRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{16}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PruneBundleRequest) GetTrustDomainId() string {
@@ -1010,7 +1181,7 @@ type PruneBundleResponse struct {
 func (x *PruneBundleResponse) Reset() {
 	*x = PruneBundleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[17]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1023,7 +1194,7 @@ func (x *PruneBundleResponse) String() string {
 func (*PruneBundleResponse) ProtoMessage() {}
 
 func (x *PruneBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[17]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,7 +1207,7 @@ func (x *PruneBundleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruneBundleResponse.ProtoReflect.Descriptor instead.
 func (*PruneBundleResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{17}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *PruneBundleResponse) GetBundleChanged() bool {
@@ -1060,7 +1231,7 @@ type NodeSelectors struct {
 func (x *NodeSelectors) Reset() {
 	*x = NodeSelectors{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[18]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1073,7 +1244,7 @@ func (x *NodeSelectors) String() string {
 func (*NodeSelectors) ProtoMessage() {}
 
 func (x *NodeSelectors) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[18]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1086,7 +1257,7 @@ func (x *NodeSelectors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeSelectors.ProtoReflect.Descriptor instead.
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{18}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *NodeSelectors) GetSpiffeId() string {
@@ -1114,7 +1285,7 @@ type SetNodeSelectorsRequest struct {
 func (x *SetNodeSelectorsRequest) Reset() {
 	*x = SetNodeSelectorsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[19]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1127,7 +1298,7 @@ func (x *SetNodeSelectorsRequest) String() string {
 func (*SetNodeSelectorsRequest) ProtoMessage() {}
 
 func (x *SetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[19]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,7 +1311,7 @@ func (x *SetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNodeSelectorsRequest.ProtoReflect.Descriptor instead.
 func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{19}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *SetNodeSelectorsRequest) GetSelectors() *NodeSelectors {
@@ -1159,7 +1330,7 @@ type SetNodeSelectorsResponse struct {
 func (x *SetNodeSelectorsResponse) Reset() {
 	*x = SetNodeSelectorsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[20]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1172,7 +1343,7 @@ func (x *SetNodeSelectorsResponse) String() string {
 func (*SetNodeSelectorsResponse) ProtoMessage() {}
 
 func (x *SetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[20]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +1356,7 @@ func (x *SetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetNodeSelectorsResponse.ProtoReflect.Descriptor instead.
 func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{20}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{21}
 }
 
 type GetNodeSelectorsRequest struct {
@@ -1201,7 +1372,7 @@ type GetNodeSelectorsRequest struct {
 func (x *GetNodeSelectorsRequest) Reset() {
 	*x = GetNodeSelectorsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[21]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1214,7 +1385,7 @@ func (x *GetNodeSelectorsRequest) String() string {
 func (*GetNodeSelectorsRequest) ProtoMessage() {}
 
 func (x *GetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[21]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1398,7 @@ func (x *GetNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeSelectorsRequest.ProtoReflect.Descriptor instead.
 func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{21}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetNodeSelectorsRequest) GetSpiffeId() string {
@@ -1255,7 +1426,7 @@ type GetNodeSelectorsResponse struct {
 func (x *GetNodeSelectorsResponse) Reset() {
 	*x = GetNodeSelectorsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[22]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1268,7 +1439,7 @@ func (x *GetNodeSelectorsResponse) String() string {
 func (*GetNodeSelectorsResponse) ProtoMessage() {}
 
 func (x *GetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[22]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1281,7 +1452,7 @@ func (x *GetNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeSelectorsResponse.ProtoReflect.Descriptor instead.
 func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{22}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
@@ -1304,7 +1475,7 @@ type ListNodeSelectorsRequest struct {
 func (x *ListNodeSelectorsRequest) Reset() {
 	*x = ListNodeSelectorsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[23]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1317,7 +1488,7 @@ func (x *ListNodeSelectorsRequest) String() string {
 func (*ListNodeSelectorsRequest) ProtoMessage() {}
 
 func (x *ListNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[23]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1330,7 +1501,7 @@ func (x *ListNodeSelectorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodeSelectorsRequest.ProtoReflect.Descriptor instead.
 func (*ListNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{23}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListNodeSelectorsRequest) GetTolerateStale() bool {
@@ -1358,7 +1529,7 @@ type ListNodeSelectorsResponse struct {
 func (x *ListNodeSelectorsResponse) Reset() {
 	*x = ListNodeSelectorsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[24]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1371,7 +1542,7 @@ func (x *ListNodeSelectorsResponse) String() string {
 func (*ListNodeSelectorsResponse) ProtoMessage() {}
 
 func (x *ListNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[24]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,7 +1555,7 @@ func (x *ListNodeSelectorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodeSelectorsResponse.ProtoReflect.Descriptor instead.
 func (*ListNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{24}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListNodeSelectorsResponse) GetSelectors() []*NodeSelectors {
@@ -1405,7 +1576,7 @@ type CreateAttestedNodeResponse struct {
 func (x *CreateAttestedNodeResponse) Reset() {
 	*x = CreateAttestedNodeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[25]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1418,7 +1589,7 @@ func (x *CreateAttestedNodeResponse) String() string {
 func (*CreateAttestedNodeResponse) ProtoMessage() {}
 
 func (x *CreateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[25]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1431,7 +1602,7 @@ func (x *CreateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAttestedNodeResponse.ProtoReflect.Descriptor instead.
 func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{25}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CreateAttestedNodeResponse) GetNode() *common.AttestedNode {
@@ -1452,7 +1623,7 @@ type FetchAttestedNodeRequest struct {
 func (x *FetchAttestedNodeRequest) Reset() {
 	*x = FetchAttestedNodeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[26]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1465,7 +1636,7 @@ func (x *FetchAttestedNodeRequest) String() string {
 func (*FetchAttestedNodeRequest) ProtoMessage() {}
 
 func (x *FetchAttestedNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[26]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1478,7 +1649,7 @@ func (x *FetchAttestedNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchAttestedNodeRequest.ProtoReflect.Descriptor instead.
 func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{26}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FetchAttestedNodeRequest) GetSpiffeId() string {
@@ -1499,7 +1670,7 @@ type FetchAttestedNodeResponse struct {
 func (x *FetchAttestedNodeResponse) Reset() {
 	*x = FetchAttestedNodeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[27]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1512,7 +1683,7 @@ func (x *FetchAttestedNodeResponse) String() string {
 func (*FetchAttestedNodeResponse) ProtoMessage() {}
 
 func (x *FetchAttestedNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[27]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,7 +1696,7 @@ func (x *FetchAttestedNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchAttestedNodeResponse.ProtoReflect.Descriptor instead.
 func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{27}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
@@ -1544,7 +1715,7 @@ type CountAttestedNodesRequest struct {
 func (x *CountAttestedNodesRequest) Reset() {
 	*x = CountAttestedNodesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[28]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1557,7 +1728,7 @@ func (x *CountAttestedNodesRequest) String() string {
 func (*CountAttestedNodesRequest) ProtoMessage() {}
 
 func (x *CountAttestedNodesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[28]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1570,7 +1741,7 @@ func (x *CountAttestedNodesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountAttestedNodesRequest.ProtoReflect.Descriptor instead.
 func (*CountAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{28}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{29}
 }
 
 type CountAttestedNodesResponse struct {
@@ -1584,7 +1755,7 @@ type CountAttestedNodesResponse struct {
 func (x *CountAttestedNodesResponse) Reset() {
 	*x = CountAttestedNodesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[29]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1597,7 +1768,7 @@ func (x *CountAttestedNodesResponse) String() string {
 func (*CountAttestedNodesResponse) ProtoMessage() {}
 
 func (x *CountAttestedNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[29]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1610,7 +1781,7 @@ func (x *CountAttestedNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountAttestedNodesResponse.ProtoReflect.Descriptor instead.
 func (*CountAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{29}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CountAttestedNodesResponse) GetNodes() int32 {
@@ -1631,7 +1802,7 @@ type CreateAttestedNodeRequest struct {
 func (x *CreateAttestedNodeRequest) Reset() {
 	*x = CreateAttestedNodeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[30]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1644,7 +1815,7 @@ func (x *CreateAttestedNodeRequest) String() string {
 func (*CreateAttestedNodeRequest) ProtoMessage() {}
 
 func (x *CreateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[30]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,7 +1828,7 @@ func (x *CreateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAttestedNodeRequest.ProtoReflect.Descriptor instead.
 func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{30}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CreateAttestedNodeRequest) GetNode() *common.AttestedNode {
@@ -1678,12 +1849,14 @@ type ListAttestedNodesRequest struct {
 	BySelectorMatch   *BySelectors           `protobuf:"bytes,4,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
 	ByBanned          *wrapperspb.BoolValue  `protobuf:"bytes,5,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
 	FetchSelectors    bool                   `protobuf:"varint,6,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	// Filters nodes to those whose SVID expires at or after the given time (seconds since Unix epoch).
+	ByExpiresAtOrAfter *wrapperspb.Int64Value `protobuf:"bytes,7,opt,name=by_expires_at_or_after,json=byExpiresAtOrAfter,proto3" json:"by_expires_at_or_after,omitempty"`
 }
 
 func (x *ListAttestedNodesRequest) Reset() {
 	*x = ListAttestedNodesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[31]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1696,7 +1869,7 @@ func (x *ListAttestedNodesRequest) String() string {
 func (*ListAttestedNodesRequest) ProtoMessage() {}
 
 func (x *ListAttestedNodesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[31]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +1882,7 @@ func (x *ListAttestedNodesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttestedNodesRequest.ProtoReflect.Descriptor instead.
 func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{31}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ListAttestedNodesRequest) GetByExpiresBefore() *wrapperspb.Int64Value {
@@ -1754,6 +1927,13 @@ func (x *ListAttestedNodesRequest) GetFetchSelectors() bool {
 	return false
 }
 
+func (x *ListAttestedNodesRequest) GetByExpiresAtOrAfter() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresAtOrAfter
+	}
+	return nil
+}
+
 type ListAttestedNodesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1766,7 +1946,7 @@ type ListAttestedNodesResponse struct {
 func (x *ListAttestedNodesResponse) Reset() {
 	*x = ListAttestedNodesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[32]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1779,7 +1959,7 @@ func (x *ListAttestedNodesResponse) String() string {
 func (*ListAttestedNodesResponse) ProtoMessage() {}
 
 func (x *ListAttestedNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[32]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1792,7 +1972,7 @@ func (x *ListAttestedNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttestedNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{32}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListAttestedNodesResponse) GetNodes() []*common.AttestedNode {
@@ -1825,7 +2005,7 @@ type UpdateAttestedNodeRequest struct {
 func (x *UpdateAttestedNodeRequest) Reset() {
 	*x = UpdateAttestedNodeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[33]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1838,7 +2018,7 @@ func (x *UpdateAttestedNodeRequest) String() string {
 func (*UpdateAttestedNodeRequest) ProtoMessage() {}
 
 func (x *UpdateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[33]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1851,7 +2031,7 @@ func (x *UpdateAttestedNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAttestedNodeRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{33}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *UpdateAttestedNodeRequest) GetSpiffeId() string {
@@ -1907,7 +2087,7 @@ type UpdateAttestedNodeResponse struct {
 func (x *UpdateAttestedNodeResponse) Reset() {
 	*x = UpdateAttestedNodeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[34]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1920,7 +2100,7 @@ func (x *UpdateAttestedNodeResponse) String() string {
 func (*UpdateAttestedNodeResponse) ProtoMessage() {}
 
 func (x *UpdateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[34]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1933,7 +2113,7 @@ func (x *UpdateAttestedNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAttestedNodeResponse.ProtoReflect.Descriptor instead.
 func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{34}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
@@ -1954,7 +2134,7 @@ type DeleteAttestedNodeRequest struct {
 func (x *DeleteAttestedNodeRequest) Reset() {
 	*x = DeleteAttestedNodeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[35]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1967,7 +2147,7 @@ func (x *DeleteAttestedNodeRequest) String() string {
 func (*DeleteAttestedNodeRequest) ProtoMessage() {}
 
 func (x *DeleteAttestedNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[35]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1980,7 +2160,7 @@ func (x *DeleteAttestedNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAttestedNodeRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{35}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *DeleteAttestedNodeRequest) GetSpiffeId() string {
@@ -2001,7 +2181,7 @@ type DeleteAttestedNodeResponse struct {
 func (x *DeleteAttestedNodeResponse) Reset() {
 	*x = DeleteAttestedNodeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[36]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2014,7 +2194,7 @@ func (x *DeleteAttestedNodeResponse) String() string {
 func (*DeleteAttestedNodeResponse) ProtoMessage() {}
 
 func (x *DeleteAttestedNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[36]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2027,7 +2207,7 @@ func (x *DeleteAttestedNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAttestedNodeResponse.ProtoReflect.Descriptor instead.
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{36}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *DeleteAttestedNodeResponse) GetNode() *common.AttestedNode {
@@ -2048,7 +2228,7 @@ type CreateRegistrationEntryRequest struct {
 func (x *CreateRegistrationEntryRequest) Reset() {
 	*x = CreateRegistrationEntryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[37]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2061,7 +2241,7 @@ func (x *CreateRegistrationEntryRequest) String() string {
 func (*CreateRegistrationEntryRequest) ProtoMessage() {}
 
 func (x *CreateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[37]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2074,7 +2254,7 @@ func (x *CreateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRegistrationEntryRequest.ProtoReflect.Descriptor instead.
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{37}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CreateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
@@ -2095,7 +2275,7 @@ type CreateRegistrationEntryResponse struct {
 func (x *CreateRegistrationEntryResponse) Reset() {
 	*x = CreateRegistrationEntryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[38]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2108,7 +2288,7 @@ func (x *CreateRegistrationEntryResponse) String() string {
 func (*CreateRegistrationEntryResponse) ProtoMessage() {}
 
 func (x *CreateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[38]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2121,7 +2301,7 @@ func (x *CreateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRegistrationEntryResponse.ProtoReflect.Descriptor instead.
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{38}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *CreateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
@@ -2142,7 +2322,7 @@ type FetchRegistrationEntryRequest struct {
 func (x *FetchRegistrationEntryRequest) Reset() {
 	*x = FetchRegistrationEntryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[39]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2155,7 +2335,7 @@ func (x *FetchRegistrationEntryRequest) String() string {
 func (*FetchRegistrationEntryRequest) ProtoMessage() {}
 
 func (x *FetchRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[39]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2168,7 +2348,7 @@ func (x *FetchRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchRegistrationEntryRequest.ProtoReflect.Descriptor instead.
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{39}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *FetchRegistrationEntryRequest) GetEntryId() string {
@@ -2189,7 +2369,7 @@ type FetchRegistrationEntryResponse struct {
 func (x *FetchRegistrationEntryResponse) Reset() {
 	*x = FetchRegistrationEntryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[40]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2202,7 +2382,7 @@ func (x *FetchRegistrationEntryResponse) String() string {
 func (*FetchRegistrationEntryResponse) ProtoMessage() {}
 
 func (x *FetchRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[40]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2215,7 +2395,7 @@ func (x *FetchRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchRegistrationEntryResponse.ProtoReflect.Descriptor instead.
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{40}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *FetchRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
@@ -2237,7 +2417,7 @@ type BySelectors struct {
 func (x *BySelectors) Reset() {
 	*x = BySelectors{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[41]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2250,7 +2430,7 @@ func (x *BySelectors) String() string {
 func (*BySelectors) ProtoMessage() {}
 
 func (x *BySelectors) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[41]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2263,7 +2443,7 @@ func (x *BySelectors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BySelectors.ProtoReflect.Descriptor instead.
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{41}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *BySelectors) GetSelectors() []*common.Selector {
@@ -2292,7 +2472,7 @@ type ByFederatesWith struct {
 func (x *ByFederatesWith) Reset() {
 	*x = ByFederatesWith{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[42]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2305,7 +2485,7 @@ func (x *ByFederatesWith) String() string {
 func (*ByFederatesWith) ProtoMessage() {}
 
 func (x *ByFederatesWith) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[42]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2318,7 +2498,7 @@ func (x *ByFederatesWith) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ByFederatesWith.ProtoReflect.Descriptor instead.
 func (*ByFederatesWith) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{42}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ByFederatesWith) GetTrustDomains() []string {
@@ -2335,11 +2515,19 @@ func (x *ByFederatesWith) GetMatch() ByFederatesWith_MatchBehavior {
 	return ByFederatesWith_MATCH_EXACT
 }
 
+// Pagination is keyset based: records are listed in the order of a unique
+// key and each page resumes strictly after the last record of the previous
+// page. Records that exist for the whole iteration are returned exactly once,
+// even under concurrent writes. Records created or deleted mid-iteration may
+// or may not be returned.
 type Pagination struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Opaque token identifying the last record of the previous page. Empty
+	// for the first page. An empty token in a response means no records were
+	// returned.
 	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	PageSize int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 }
@@ -2347,7 +2535,7 @@ type Pagination struct {
 func (x *Pagination) Reset() {
 	*x = Pagination{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[43]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2360,7 +2548,7 @@ func (x *Pagination) String() string {
 func (*Pagination) ProtoMessage() {}
 
 func (x *Pagination) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[43]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2373,7 +2561,7 @@ func (x *Pagination) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{43}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *Pagination) GetToken() string {
@@ -2399,7 +2587,7 @@ type CountRegistrationEntriesRequest struct {
 func (x *CountRegistrationEntriesRequest) Reset() {
 	*x = CountRegistrationEntriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[44]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2412,7 +2600,7 @@ func (x *CountRegistrationEntriesRequest) String() string {
 func (*CountRegistrationEntriesRequest) ProtoMessage() {}
 
 func (x *CountRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[44]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2425,7 +2613,7 @@ func (x *CountRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
 func (*CountRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{44}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{45}
 }
 
 type CountRegistrationEntriesResponse struct {
@@ -2439,7 +2627,7 @@ type CountRegistrationEntriesResponse struct {
 func (x *CountRegistrationEntriesResponse) Reset() {
 	*x = CountRegistrationEntriesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[45]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2452,7 +2640,7 @@ func (x *CountRegistrationEntriesResponse) String() string {
 func (*CountRegistrationEntriesResponse) ProtoMessage() {}
 
 func (x *CountRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[45]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2465,7 +2653,7 @@ func (x *CountRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
 func (*CountRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{45}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *CountRegistrationEntriesResponse) GetEntries() int32 {
@@ -2487,12 +2675,18 @@ type ListRegistrationEntriesRequest struct {
 	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
 	TolerateStale   bool             `protobuf:"varint,5,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
 	ByFederatesWith *ByFederatesWith `protobuf:"bytes,6,opt,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	// Filters entries to those with a TTL greater than or equal to the value.
+	ByMinTtl *wrapperspb.Int32Value `protobuf:"bytes,7,opt,name=by_min_ttl,json=byMinTtl,proto3" json:"by_min_ttl,omitempty"`
+	// Filters entries to those with a TTL less than or equal to the value.
+	ByMaxTtl *wrapperspb.Int32Value `protobuf:"bytes,8,opt,name=by_max_ttl,json=byMaxTtl,proto3" json:"by_max_ttl,omitempty"`
+	// Filters entries to those with a non-zero expiry before the value.
+	ByExpiresBefore *wrapperspb.Int64Value `protobuf:"bytes,9,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
 }
 
 func (x *ListRegistrationEntriesRequest) Reset() {
 	*x = ListRegistrationEntriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[46]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2505,7 +2699,7 @@ func (x *ListRegistrationEntriesRequest) String() string {
 func (*ListRegistrationEntriesRequest) ProtoMessage() {}
 
 func (x *ListRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[46]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2518,7 +2712,7 @@ func (x *ListRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{46}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListRegistrationEntriesRequest) GetByParentId() *wrapperspb.StringValue {
@@ -2563,6 +2757,27 @@ func (x *ListRegistrationEntriesRequest) GetByFederatesWith() *ByFederatesWith {
 	return nil
 }
 
+func (x *ListRegistrationEntriesRequest) GetByMinTtl() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.ByMinTtl
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesRequest) GetByMaxTtl() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.ByMaxTtl
+	}
+	return nil
+}
+
+func (x *ListRegistrationEntriesRequest) GetByExpiresBefore() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresBefore
+	}
+	return nil
+}
+
 type ListRegistrationEntriesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2575,7 +2790,7 @@ type ListRegistrationEntriesResponse struct {
 func (x *ListRegistrationEntriesResponse) Reset() {
 	*x = ListRegistrationEntriesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[47]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2588,7 +2803,7 @@ func (x *ListRegistrationEntriesResponse) String() string {
 func (*ListRegistrationEntriesResponse) ProtoMessage() {}
 
 func (x *ListRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[47]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2601,7 +2816,7 @@ func (x *ListRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
 func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{47}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *ListRegistrationEntriesResponse) GetEntries() []*common.RegistrationEntry {
@@ -2630,7 +2845,7 @@ type UpdateRegistrationEntryRequest struct {
 func (x *UpdateRegistrationEntryRequest) Reset() {
 	*x = UpdateRegistrationEntryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[48]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2643,7 +2858,7 @@ func (x *UpdateRegistrationEntryRequest) String() string {
 func (*UpdateRegistrationEntryRequest) ProtoMessage() {}
 
 func (x *UpdateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[48]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2656,7 +2871,7 @@ func (x *UpdateRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRegistrationEntryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{48}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *UpdateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
@@ -2684,7 +2899,7 @@ type UpdateRegistrationEntryResponse struct {
 func (x *UpdateRegistrationEntryResponse) Reset() {
 	*x = UpdateRegistrationEntryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[49]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2697,7 +2912,7 @@ func (x *UpdateRegistrationEntryResponse) String() string {
 func (*UpdateRegistrationEntryResponse) ProtoMessage() {}
 
 func (x *UpdateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[49]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2710,7 +2925,7 @@ func (x *UpdateRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRegistrationEntryResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{49}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *UpdateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
@@ -2731,7 +2946,7 @@ type DeleteRegistrationEntryRequest struct {
 func (x *DeleteRegistrationEntryRequest) Reset() {
 	*x = DeleteRegistrationEntryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[50]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2744,7 +2959,7 @@ func (x *DeleteRegistrationEntryRequest) String() string {
 func (*DeleteRegistrationEntryRequest) ProtoMessage() {}
 
 func (x *DeleteRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[50]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2757,7 +2972,7 @@ func (x *DeleteRegistrationEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRegistrationEntryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{50}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *DeleteRegistrationEntryRequest) GetEntryId() string {
@@ -2778,7 +2993,7 @@ type DeleteRegistrationEntryResponse struct {
 func (x *DeleteRegistrationEntryResponse) Reset() {
 	*x = DeleteRegistrationEntryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[51]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2791,7 +3006,7 @@ func (x *DeleteRegistrationEntryResponse) String() string {
 func (*DeleteRegistrationEntryResponse) ProtoMessage() {}
 
 func (x *DeleteRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[51]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2804,7 +3019,7 @@ func (x *DeleteRegistrationEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRegistrationEntryResponse.ProtoReflect.Descriptor instead.
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{51}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *DeleteRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
@@ -2825,7 +3040,7 @@ type PruneRegistrationEntriesRequest struct {
 func (x *PruneRegistrationEntriesRequest) Reset() {
 	*x = PruneRegistrationEntriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[52]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2838,7 +3053,7 @@ func (x *PruneRegistrationEntriesRequest) String() string {
 func (*PruneRegistrationEntriesRequest) ProtoMessage() {}
 
 func (x *PruneRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[52]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2851,7 +3066,7 @@ func (x *PruneRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruneRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
 func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{52}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *PruneRegistrationEntriesRequest) GetExpiresBefore() int64 {
@@ -2870,7 +3085,7 @@ type PruneRegistrationEntriesResponse struct {
 func (x *PruneRegistrationEntriesResponse) Reset() {
 	*x = PruneRegistrationEntriesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[53]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2883,7 +3098,7 @@ func (x *PruneRegistrationEntriesResponse) String() string {
 func (*PruneRegistrationEntriesResponse) ProtoMessage() {}
 
 func (x *PruneRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[53]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2896,7 +3111,7 @@ func (x *PruneRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PruneRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
 func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{53}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{54}
 }
 
 type JoinToken struct {
@@ -2908,12 +3123,17 @@ type JoinToken struct {
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// Expiration in seconds since unix epoch
 	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Time the token was used to attest an agent, in seconds since unix
+	// epoch. Zero if the token has not been used.
+	UsedAt int64 `protobuf:"varint,3,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`
+	// TTL the token was created with, in seconds. Zero if unknown.
+	Ttl int32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
 func (x *JoinToken) Reset() {
 	*x = JoinToken{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[54]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2926,7 +3146,7 @@ func (x *JoinToken) String() string {
 func (*JoinToken) ProtoMessage() {}
 
 func (x *JoinToken) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[54]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2939,7 +3159,7 @@ func (x *JoinToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinToken.ProtoReflect.Descriptor instead.
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{54}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *JoinToken) GetToken() string {
@@ -2956,6 +3176,20 @@ func (x *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+func (x *JoinToken) GetUsedAt() int64 {
+	if x != nil {
+		return x.UsedAt
+	}
+	return 0
+}
+
+func (x *JoinToken) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 type CreateJoinTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2967,7 +3201,7 @@ type CreateJoinTokenRequest struct {
 func (x *CreateJoinTokenRequest) Reset() {
 	*x = CreateJoinTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[55]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2980,7 +3214,7 @@ func (x *CreateJoinTokenRequest) String() string {
 func (*CreateJoinTokenRequest) ProtoMessage() {}
 
 func (x *CreateJoinTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[55]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2993,7 +3227,7 @@ func (x *CreateJoinTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateJoinTokenRequest.ProtoReflect.Descriptor instead.
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{55}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *CreateJoinTokenRequest) GetJoinToken() *JoinToken {
@@ -3014,7 +3248,7 @@ type CreateJoinTokenResponse struct {
 func (x *CreateJoinTokenResponse) Reset() {
 	*x = CreateJoinTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[56]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3027,7 +3261,7 @@ func (x *CreateJoinTokenResponse) String() string {
 func (*CreateJoinTokenResponse) ProtoMessage() {}
 
 func (x *CreateJoinTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[56]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3040,7 +3274,7 @@ func (x *CreateJoinTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateJoinTokenResponse.ProtoReflect.Descriptor instead.
 func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{56}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *CreateJoinTokenResponse) GetJoinToken() *JoinToken {
@@ -3061,7 +3295,7 @@ type FetchJoinTokenRequest struct {
 func (x *FetchJoinTokenRequest) Reset() {
 	*x = FetchJoinTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[57]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3074,7 +3308,7 @@ func (x *FetchJoinTokenRequest) String() string {
 func (*FetchJoinTokenRequest) ProtoMessage() {}
 
 func (x *FetchJoinTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[57]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3087,7 +3321,7 @@ func (x *FetchJoinTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchJoinTokenRequest.ProtoReflect.Descriptor instead.
 func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{57}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *FetchJoinTokenRequest) GetToken() string {
@@ -3108,7 +3342,7 @@ type FetchJoinTokenResponse struct {
 func (x *FetchJoinTokenResponse) Reset() {
 	*x = FetchJoinTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[58]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3121,7 +3355,7 @@ func (x *FetchJoinTokenResponse) String() string {
 func (*FetchJoinTokenResponse) ProtoMessage() {}
 
 func (x *FetchJoinTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[58]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3134,7 +3368,7 @@ func (x *FetchJoinTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchJoinTokenResponse.ProtoReflect.Descriptor instead.
 func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{58}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *FetchJoinTokenResponse) GetJoinToken() *JoinToken {
@@ -3155,7 +3389,7 @@ type DeleteJoinTokenRequest struct {
 func (x *DeleteJoinTokenRequest) Reset() {
 	*x = DeleteJoinTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[59]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3168,7 +3402,7 @@ func (x *DeleteJoinTokenRequest) String() string {
 func (*DeleteJoinTokenRequest) ProtoMessage() {}
 
 func (x *DeleteJoinTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[59]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3181,7 +3415,7 @@ func (x *DeleteJoinTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteJoinTokenRequest.ProtoReflect.Descriptor instead.
 func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{59}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *DeleteJoinTokenRequest) GetToken() string {
@@ -3202,7 +3436,7 @@ type DeleteJoinTokenResponse struct {
 func (x *DeleteJoinTokenResponse) Reset() {
 	*x = DeleteJoinTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[60]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[61]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3215,7 +3449,7 @@ func (x *DeleteJoinTokenResponse) String() string {
 func (*DeleteJoinTokenResponse) ProtoMessage() {}
 
 func (x *DeleteJoinTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[60]
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[61]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3228,7 +3462,7 @@ func (x *DeleteJoinTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteJoinTokenResponse.ProtoReflect.Descriptor instead.
 func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{60}
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
@@ -3238,31 +3472,33 @@ func (x *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
-type PruneJoinTokensRequest struct {
+type UseJoinTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ExpiresBefore int64 `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Time the token was used, in seconds since unix epoch
+	UsedAt int64 `protobuf:"varint,2,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`
 }
 
-func (x *PruneJoinTokensRequest) Reset() {
-	*x = PruneJoinTokensRequest{}
+func (x *UseJoinTokenRequest) Reset() {
+	*x = UseJoinTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[61]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[62]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PruneJoinTokensRequest) String() string {
+func (x *UseJoinTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PruneJoinTokensRequest) ProtoMessage() {}
+func (*UseJoinTokenRequest) ProtoMessage() {}
 
-func (x *PruneJoinTokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[61]
+func (x *UseJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[62]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3273,41 +3509,50 @@ func (x *PruneJoinTokensRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PruneJoinTokensRequest.ProtoReflect.Descriptor instead.
-func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{61}
+// Deprecated: Use UseJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*UseJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *PruneJoinTokensRequest) GetExpiresBefore() int64 {
+func (x *UseJoinTokenRequest) GetToken() string {
 	if x != nil {
-		return x.ExpiresBefore
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UseJoinTokenRequest) GetUsedAt() int64 {
+	if x != nil {
+		return x.UsedAt
 	}
 	return 0
 }
 
-type PruneJoinTokensResponse struct {
+type UseJoinTokenResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	JoinToken *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
 }
 
-func (x *PruneJoinTokensResponse) Reset() {
-	*x = PruneJoinTokensResponse{}
+func (x *UseJoinTokenResponse) Reset() {
+	*x = UseJoinTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_spire_server_datastore_datastore_proto_msgTypes[62]
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PruneJoinTokensResponse) String() string {
+func (x *UseJoinTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PruneJoinTokensResponse) ProtoMessage() {}
+func (*UseJoinTokenResponse) ProtoMessage() {}
 
-func (x *PruneJoinTokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_spire_server_datastore_datastore_proto_msgTypes[62]
+func (x *UseJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3318,41 +3563,593 @@ func (x *PruneJoinTokensResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PruneJoinTokensResponse.ProtoReflect.Descriptor instead.
-func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{62}
+// Deprecated: Use UseJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*UseJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{63}
 }
 
-var File_spire_server_datastore_datastore_proto protoreflect.FileDescriptor
+func (x *UseJoinTokenResponse) GetJoinToken() *JoinToken {
+	if x != nil {
+		return x.JoinToken
+	}
+	return nil
+}
 
-var file_spire_server_datastore_datastore_proto_rawDesc = []byte{
-	0x0a, 0x26, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x20, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x43,
-	0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x22, 0x44, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x3c, 0x0a, 0x12, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x26, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x13, 0x46, 0x65, 0x74, 0x63, 0x68,
-	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
-	0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+type PruneJoinTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpiresBefore int64 `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+}
+
+func (x *PruneJoinTokensRequest) Reset() {
+	*x = PruneJoinTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PruneJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneJoinTokensRequest) ProtoMessage() {}
+
+func (x *PruneJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *PruneJoinTokensRequest) GetExpiresBefore() int64 {
+	if x != nil {
+		return x.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneJoinTokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PruneJoinTokensResponse) Reset() {
+	*x = PruneJoinTokensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PruneJoinTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneJoinTokensResponse) ProtoMessage() {}
+
+func (x *PruneJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{65}
+}
+
+type RegistrationEntryOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Exactly one of create, update or delete must be set
+	Create *CreateRegistrationEntryRequest `protobuf:"bytes,1,opt,name=create,proto3" json:"create,omitempty"`
+	Update *UpdateRegistrationEntryRequest `protobuf:"bytes,2,opt,name=update,proto3" json:"update,omitempty"`
+	Delete *DeleteRegistrationEntryRequest `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	// If set, the update or delete only applies if the entry still has the
+	// given revision number. Otherwise the batch fails with Aborted and none
+	// of its operations are applied. Not valid for creates.
+	IfRevisionNumber *wrapperspb.Int64Value `protobuf:"bytes,4,opt,name=if_revision_number,json=ifRevisionNumber,proto3" json:"if_revision_number,omitempty"`
+}
+
+func (x *RegistrationEntryOperation) Reset() {
+	*x = RegistrationEntryOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationEntryOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationEntryOperation) ProtoMessage() {}
+
+func (x *RegistrationEntryOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationEntryOperation.ProtoReflect.Descriptor instead.
+func (*RegistrationEntryOperation) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RegistrationEntryOperation) GetCreate() *CreateRegistrationEntryRequest {
+	if x != nil {
+		return x.Create
+	}
+	return nil
+}
+
+func (x *RegistrationEntryOperation) GetUpdate() *UpdateRegistrationEntryRequest {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *RegistrationEntryOperation) GetDelete() *DeleteRegistrationEntryRequest {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+func (x *RegistrationEntryOperation) GetIfRevisionNumber() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.IfRevisionNumber
+	}
+	return nil
+}
+
+type BatchRegistrationEntriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operations []*RegistrationEntryOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *BatchRegistrationEntriesRequest) Reset() {
+	*x = BatchRegistrationEntriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchRegistrationEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRegistrationEntriesRequest) ProtoMessage() {}
+
+func (x *BatchRegistrationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRegistrationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*BatchRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *BatchRegistrationEntriesRequest) GetOperations() []*RegistrationEntryOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type BatchRegistrationEntriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The created, updated or deleted entries, in the same order as the
+	// operations in the request
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *BatchRegistrationEntriesResponse) Reset() {
+	*x = BatchRegistrationEntriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchRegistrationEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRegistrationEntriesResponse) ProtoMessage() {}
+
+func (x *BatchRegistrationEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRegistrationEntriesResponse.ProtoReflect.Descriptor instead.
+func (*BatchRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *BatchRegistrationEntriesResponse) GetEntries() []*common.RegistrationEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ListJoinTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pagination *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Filters tokens to those that expire before the given time (seconds since Unix epoch).
+	ByExpiresBefore *wrapperspb.Int64Value `protobuf:"bytes,2,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	// Filters tokens to those that expire at or after the given time (seconds since Unix epoch).
+	ByExpiresAtOrAfter *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=by_expires_at_or_after,json=byExpiresAtOrAfter,proto3" json:"by_expires_at_or_after,omitempty"`
+	// Filters tokens to those that have (true) or have not (false) been used.
+	ByUsed *wrapperspb.BoolValue `protobuf:"bytes,4,opt,name=by_used,json=byUsed,proto3" json:"by_used,omitempty"`
+}
+
+func (x *ListJoinTokensRequest) Reset() {
+	*x = ListJoinTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensRequest) ProtoMessage() {}
+
+func (x *ListJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListJoinTokensRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListJoinTokensRequest) GetByExpiresBefore() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresBefore
+	}
+	return nil
+}
+
+func (x *ListJoinTokensRequest) GetByExpiresAtOrAfter() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.ByExpiresAtOrAfter
+	}
+	return nil
+}
+
+func (x *ListJoinTokensRequest) GetByUsed() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.ByUsed
+	}
+	return nil
+}
+
+type ListJoinTokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JoinTokens []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	Pagination *Pagination  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *ListJoinTokensResponse) Reset() {
+	*x = ListJoinTokensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJoinTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinTokensResponse) ProtoMessage() {}
+
+func (x *ListJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if x != nil {
+		return x.JoinTokens
+	}
+	return nil
+}
+
+func (x *ListJoinTokensResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type IntegrityIssue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind IntegrityIssue_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=spire.server.datastore.IntegrityIssue_Kind" json:"kind,omitempty"`
+	// Human readable description of the issue
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Whether the issue was repaired
+	Repaired bool `protobuf:"varint,3,opt,name=repaired,proto3" json:"repaired,omitempty"`
+}
+
+func (x *IntegrityIssue) Reset() {
+	*x = IntegrityIssue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrityIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityIssue) ProtoMessage() {}
+
+func (x *IntegrityIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityIssue.ProtoReflect.Descriptor instead.
+func (*IntegrityIssue) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *IntegrityIssue) GetKind() IntegrityIssue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return IntegrityIssue_ORPHANED_SELECTOR
+}
+
+func (x *IntegrityIssue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *IntegrityIssue) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+type CheckIntegrityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Repairs the issues that are found
+	Repair bool `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+}
+
+func (x *CheckIntegrityRequest) Reset() {
+	*x = CheckIntegrityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckIntegrityRequest) ProtoMessage() {}
+
+func (x *CheckIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*CheckIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CheckIntegrityRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+type CheckIntegrityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issues []*IntegrityIssue `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`
+}
+
+func (x *CheckIntegrityResponse) Reset() {
+	*x = CheckIntegrityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_server_datastore_datastore_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckIntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckIntegrityResponse) ProtoMessage() {}
+
+func (x *CheckIntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_server_datastore_datastore_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckIntegrityResponse.ProtoReflect.Descriptor instead.
+func (*CheckIntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_spire_server_datastore_datastore_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CheckIntegrityResponse) GetIssues() []*IntegrityIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+var File_spire_server_datastore_datastore_proto protoreflect.FileDescriptor
+
+var file_spire_server_datastore_datastore_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x20, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x43,
+	0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x22, 0x44, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x3c, 0x0a, 0x12, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x13, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75,
 	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x15, 0x0a, 0x13,
 	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
@@ -3373,589 +4170,763 @@ var file_spire_server_datastore_datastore_proto_rawDesc = []byte{
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70,
 	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
 	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x13, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
-	0x12, 0x37, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x44, 0x0a, 0x14, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22,
-	0x40, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x22, 0x41, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75,
-	0x6e, 0x64, 0x6c, 0x65, 0x22, 0x43, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75,
-	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x44, 0x0a, 0x14, 0x41, 0x70, 0x70,
-	0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22,
-	0xb5, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74,
-	0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12,
-	0x44, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52,
-	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x30, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0c, 0x0a,
-	0x08, 0x52, 0x45, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44,
-	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x53, 0x4f,
-	0x43, 0x49, 0x41, 0x54, 0x45, 0x10, 0x02, 0x22, 0x44, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9a, 0x01, 0x0a, 0x0c,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x14,
+	0x6d, 0x61, 0x78, 0x5f, 0x78, 0x35, 0x30, 0x39, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x58,
+	0x35, 0x30, 0x39, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78,
+	0x4a, 0x77, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xba, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x37,
+	0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x3c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x44, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x7e, 0x0a, 0x10, 0x53,
+	0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x63, 0x0a,
-	0x12, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72,
-	0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f,
-	0x72, 0x65, 0x22, 0x3c, 0x0a, 0x13, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0d, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
-	0x22, 0x62, 0x0a, 0x0d, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x12, 0x34,
-	0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x73, 0x22, 0x5e, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x43, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x73, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3c, 0x0a,
+	0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x41, 0x0a, 0x11, 0x53,
+	0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0xc1,
+	0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x68, 0x69,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x48, 0x69,
+	0x6e, 0x74, 0x22, 0x44, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x44, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x30,
+	0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x54, 0x52, 0x49,
+	0x43, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01,
+	0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x53, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x54, 0x45, 0x10, 0x02,
+	0x22, 0x44, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x63, 0x0a, 0x12, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x3c, 0x0a, 0x13, 0x50,
+	0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x62, 0x0a, 0x0d, 0x4e, 0x6f, 0x64,
+	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70,
+	0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x5e, 0x0a,
+	0x17, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x1a, 0x0a,
+	0x18, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74,
+	0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22, 0x5f, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4e,
+	0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x09,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x78, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x41, 0x74, 0x22, 0x60, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53,
 	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x5d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73,
-	0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x6c, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0d, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22,
-	0x5f, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x73,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
-	0x22, 0x78, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e,
-	0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74,
-	0x61, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x07, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x74, 0x22, 0x60, 0x0a, 0x19, 0x4c, 0x69,
-	0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x73, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x1a,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f,
-	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x37, 0x0a, 0x18, 0x46, 0x65,
-	0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66,
-	0x65, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x19, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x12, 0x43, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e,
+	0x6f, 0x64, 0x65, 0x22, 0x37, 0x0a, 0x18, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x19,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x32, 0x0a, 0x1a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x19, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xdb, 0x03, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f, 0x62, 0x79,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x42, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x62, 0x79, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x4f, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x0f, 0x62, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x08, 0x62, 0x79, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x66, 0x65, 0x74, 0x63, 0x68, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x4f, 0x0a, 0x16, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x61, 0x74, 0x5f, 0x6f, 0x72, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x12, 0x62, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4f, 0x72,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x91, 0x01, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70,
+	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xad, 0x02, 0x0a, 0x19, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66,
+	0x66, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x63, 0x65, 0x72, 0x74, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x65, 0x72, 0x74,
+	0x4e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x16, 0x6e, 0x65, 0x77, 0x5f,
+	0x63, 0x65, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6e, 0x65, 0x77, 0x43, 0x65, 0x72,
+	0x74, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2b, 0x0a,
+	0x12, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66,
+	0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6e, 0x65, 0x77, 0x43, 0x65,
+	0x72, 0x74, 0x4e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0a, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74,
-	0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65,
-	0x22, 0x1b, 0x0a, 0x19, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x32, 0x0a,
-	0x1a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e,
-	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65,
-	0x73, 0x22, 0x4b, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e,
-	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x8a,
-	0x03, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
-	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x11, 0x62,
-	0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x0f, 0x62, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65,
-	0x66, 0x6f, 0x72, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61,
-	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x79, 0x5f, 0x61,
-	0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x79, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4f, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x73,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x53,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x0f, 0x62, 0x79, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x62, 0x79, 0x5f,
-	0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
-	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x62, 0x79, 0x42, 0x61, 0x6e, 0x6e,
-	0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x66, 0x65, 0x74,
-	0x63, 0x68, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x19,
-	0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70,
-	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0xad, 0x02, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
-	0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x65,
-	0x72, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x65, 0x72, 0x74, 0x53, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x65, 0x72, 0x74,
-	0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x4e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x33,
-	0x0a, 0x16, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
-	0x6e, 0x65, 0x77, 0x43, 0x65, 0x72, 0x74, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x12, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f,
-	0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0f, 0x6e, 0x65, 0x77, 0x43, 0x65, 0x72, 0x74, 0x4e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72,
-	0x12, 0x3d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22,
-	0x4c, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x38, 0x0a,
-	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70,
-	0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
-	0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x22, 0x4c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x57, 0x0a, 0x1e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x58,
-	0x0a, 0x1f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x09,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x4c, 0x0a, 0x1a, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x38, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49,
+	0x64, 0x22, 0x4c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22,
+	0x57, 0x0a, 0x1e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
 	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x3a, 0x0a, 0x1d, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x49, 0x64, 0x22, 0x57, 0x0a, 0x1e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xc0, 0x01,
-	0x0a, 0x0b, 0x42, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x34, 0x0a,
-	0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x73, 0x12, 0x47, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x53, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x65, 0x68,
-	0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x32, 0x0a, 0x0d,
-	0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x0f, 0x0a,
-	0x0b, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x10,
-	0x0a, 0x0c, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x45, 0x54, 0x10, 0x01,
-	0x22, 0xb7, 0x01, 0x0a, 0x0f, 0x42, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73,
-	0x57, 0x69, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x75,
-	0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x4b, 0x0a, 0x05, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x42, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x57, 0x69, 0x74,
-	0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52,
+	0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x58, 0x0a, 0x1f, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x22, 0x3a, 0x0a, 0x1d, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x22, 0x57,
+	0x0a, 0x1e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xc0, 0x01, 0x0a, 0x0b, 0x42, 0x79, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x47, 0x0a,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52,
 	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x32, 0x0a, 0x0d, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42,
 	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x54, 0x43, 0x48,
 	0x5f, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x54, 0x43,
-	0x48, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x45, 0x54, 0x10, 0x01, 0x22, 0x3f, 0x0a, 0x0a, 0x50, 0x61,
-	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x21, 0x0a, 0x1f, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c,
-	0x0a, 0x20, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xa8, 0x03, 0x0a,
-	0x1e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x3e, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x0a, 0x62, 0x79, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x46, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42,
-	0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x0b, 0x62, 0x79, 0x53, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x3e, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x73, 0x70,
-	0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x62, 0x79, 0x53,
-	0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74,
-	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61,
-	0x6c, 0x65, 0x12, 0x53, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x52, 0x0f, 0x62, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x22, 0xa0, 0x01, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74,
+	0x48, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x45, 0x54, 0x10, 0x01, 0x22, 0xc6, 0x01, 0x0a, 0x0f, 0x42,
+	0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x73, 0x12, 0x4b, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x46, 0x65,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x2e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x22, 0x41, 0x0a, 0x0d, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
+	0x72, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x58, 0x41, 0x43, 0x54,
+	0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x53, 0x55, 0x42, 0x53,
+	0x45, 0x54, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41, 0x4e,
+	0x59, 0x10, 0x02, 0x22, 0x3f, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x21, 0x0a, 0x1f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x20, 0x43, 0x6f, 0x75, 0x6e, 0x74,
 	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
-	0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x90, 0x01, 0x0a, 0x1e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a,
-	0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x22, 0x58, 0x0a,
-	0x1f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xe7, 0x04, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x62, 0x79,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x46, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x0b, 0x62, 0x79, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x3e, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x62, 0x79, 0x53, 0x70, 0x69, 0x66, 0x66, 0x65, 0x49, 0x64,
+	0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6f,
+	0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x53, 0x0a, 0x11, 0x62,
+	0x79, 0x5f, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x42, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x52,
+	0x0f, 0x62, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68,
+	0x12, 0x39, 0x0a, 0x0a, 0x62, 0x79, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x08, 0x62, 0x79, 0x4d, 0x69, 0x6e, 0x54, 0x74, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x62,
+	0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x62, 0x79,
+	0x4d, 0x61, 0x78, 0x54, 0x74, 0x6c, 0x12, 0x47, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f,
+	0x62, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22,
+	0xa0, 0x01, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x42,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x61, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x90, 0x01, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x04,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x61, 0x73, 0x6b, 0x52,
+	0x04, 0x6d, 0x61, 0x73, 0x6b, 0x22, 0x58, 0x0a, 0x1f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
 	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x3b, 0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x49, 0x64, 0x22, 0x58, 0x0a, 0x1f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22,
+	0x3b, 0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x22, 0x58, 0x0a, 0x1f,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65,
 	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x48,
-	0x0a, 0x1f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66,
-	0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x22, 0x0a, 0x20, 0x50, 0x72, 0x75, 0x6e,
-	0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x39, 0x0a, 0x09,
-	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x5a, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x48, 0x0a, 0x1f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x22, 0x22, 0x0a, 0x20, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x64, 0x0a, 0x09, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x64, 0x41, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x5a, 0x0a, 0x16, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x09, 0x6a, 0x6f, 0x69,
+	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5b, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
 	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a,
 	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x22, 0x5b, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69,
-	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40,
-	0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0x2d, 0x0a, 0x15, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
-	0x5a, 0x0a, 0x16, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6b, 0x65, 0x6e, 0x22, 0x2d, 0x0a, 0x15, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x5a, 0x0a, 0x16, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a,
+	0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2e,
+	0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5b,
+	0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
 	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69,
 	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
 	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
 	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2e, 0x0a, 0x16, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5b, 0x0a, 0x17, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x09, 0x6a,
-	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3f, 0x0a, 0x16, 0x50, 0x72, 0x75, 0x6e,
-	0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x65,
-	0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x50, 0x72, 0x75,
-	0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xfb, 0x1c, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a,
-	0x0b, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2a, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x44, 0x0a, 0x13, 0x55,
+	0x73, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x58, 0x0a, 0x14, 0x55, 0x73, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x6a, 0x6f, 0x69,
+	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3f, 0x0a, 0x16, 0x50,
+	0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x19, 0x0a, 0x17,
+	0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd7, 0x02, 0x0a, 0x1a, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x12, 0x69, 0x66, 0x5f, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x10, 0x69, 0x66, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x22, 0x75, 0x0a, 0x1f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x52, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
 	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75,
-	0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x66, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12,
-	0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x65, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5d, 0x0a, 0x20, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xaa, 0x02, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50,
+	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x11, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f, 0x62,
+	0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x4f,
+	0x0a, 0x16, 0x62, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x5f,
+	0x6f, 0x72, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x12, 0x62, 0x79, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4f, 0x72, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12,
+	0x33, 0x0a, 0x07, 0x62, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x62, 0x79,
+	0x55, 0x73, 0x65, 0x64, 0x22, 0xa0, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x69,
+	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x42, 0x0a, 0x0b, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4a, 0x6f,
+	0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xf7, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x74, 0x65,
+	0x67, 0x72, 0x69, 0x74, 0x79, 0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x3f, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
 	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
-	0x12, 0x28, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x73, 0x70, 0x69,
+	0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x49, 0x73, 0x73, 0x75, 0x65,
+	0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x22, 0x66, 0x0a, 0x04, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x52, 0x50, 0x48, 0x41, 0x4e, 0x45, 0x44, 0x5f, 0x53, 0x45,
+	0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x52, 0x50, 0x48,
+	0x41, 0x4e, 0x45, 0x44, 0x5f, 0x44, 0x4e, 0x53, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x01, 0x12,
+	0x17, 0x0a, 0x13, 0x44, 0x41, 0x4e, 0x47, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x45, 0x44, 0x45,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x4f, 0x44, 0x45,
+	0x5f, 0x57, 0x49, 0x54, 0x48, 0x4f, 0x55, 0x54, 0x5f, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x10,
+	0x03, 0x22, 0x2f, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x22, 0x58, 0x0a, 0x16, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x32, 0xd8, 0x20, 0x0a,
+	0x09, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0b, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
+	0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x2b, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69,
 	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41,
-	0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x65,
-	0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x69, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x69, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
 	0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e,
 	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0b, 0x50,
-	0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2a, 0x2e, 0x73, 0x70, 0x69,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x09, 0x53,
+	0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x53, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
+	0x0c, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69,
 	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74,
-	0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x78, 0x0a, 0x11, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41,
-	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x30, 0x2e, 0x73,
-	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31,
+	0x6f, 0x72, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0b, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x12, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e,
+	0x65, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x7b, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70, 0x69,
-	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7b,
-	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x10, 0x53,
-	0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12,
-	0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x75, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x11, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x30, 0x2e,
+	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x41, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70,
+	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7b, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x32, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
 	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73,
-	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x30,
+	0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2f,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x87, 0x01, 0x0a, 0x16, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x35, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x30, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37,
+	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x87, 0x01, 0x0a, 0x16, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x35, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x36,
 	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
 	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69,
 	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x8a, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x2e, 0x73, 0x70,
 	0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73,
 	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a,
+	0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65,
 	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72,
 	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x37,
-	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x72, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x0e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f,
-	0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x50, 0x72,
+	0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72,
-	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x0f, 0x50, 0x72,
-	0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2e, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e,
-	0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a,
-	0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x25, 0x2e, 0x73, 0x70,
-	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x47, 0x65,
-	0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x29, 0x2e, 0x73, 0x70,
+	0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x37, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x0f, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x73,
+	0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a,
+	0x0e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f,
+	0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e,
+	0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x69,
+	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72,
+	0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x69, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x4a,
+	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x4a, 0x6f, 0x69, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a,
+	0x0f, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x12, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a,
+	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2f, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x4a,
+	0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6f, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x6f, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x2d, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x12, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x66, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x29, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x73, 0x70,
 	0x69, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
 	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74,
-	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3970,212 +4941,256 @@ func file_spire_server_datastore_datastore_proto_rawDescGZIP() []byte {
 	return file_spire_server_datastore_datastore_proto_rawDescData
 }
 
-var file_spire_server_datastore_datastore_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_spire_server_datastore_datastore_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
+var file_spire_server_datastore_datastore_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_spire_server_datastore_datastore_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_spire_server_datastore_datastore_proto_goTypes = []interface{}{
 	(DeleteBundleRequest_Mode)(0),            // 0: spire.server.datastore.DeleteBundleRequest.Mode
 	(BySelectors_MatchBehavior)(0),           // 1: spire.server.datastore.BySelectors.MatchBehavior
 	(ByFederatesWith_MatchBehavior)(0),       // 2: spire.server.datastore.ByFederatesWith.MatchBehavior
-	(*CreateBundleRequest)(nil),              // 3: spire.server.datastore.CreateBundleRequest
-	(*CreateBundleResponse)(nil),             // 4: spire.server.datastore.CreateBundleResponse
-	(*FetchBundleRequest)(nil),               // 5: spire.server.datastore.FetchBundleRequest
-	(*FetchBundleResponse)(nil),              // 6: spire.server.datastore.FetchBundleResponse
-	(*CountBundlesRequest)(nil),              // 7: spire.server.datastore.CountBundlesRequest
-	(*CountBundlesResponse)(nil),             // 8: spire.server.datastore.CountBundlesResponse
-	(*ListBundlesRequest)(nil),               // 9: spire.server.datastore.ListBundlesRequest
-	(*ListBundlesResponse)(nil),              // 10: spire.server.datastore.ListBundlesResponse
-	(*UpdateBundleRequest)(nil),              // 11: spire.server.datastore.UpdateBundleRequest
-	(*UpdateBundleResponse)(nil),             // 12: spire.server.datastore.UpdateBundleResponse
-	(*SetBundleRequest)(nil),                 // 13: spire.server.datastore.SetBundleRequest
-	(*SetBundleResponse)(nil),                // 14: spire.server.datastore.SetBundleResponse
-	(*AppendBundleRequest)(nil),              // 15: spire.server.datastore.AppendBundleRequest
-	(*AppendBundleResponse)(nil),             // 16: spire.server.datastore.AppendBundleResponse
-	(*DeleteBundleRequest)(nil),              // 17: spire.server.datastore.DeleteBundleRequest
-	(*DeleteBundleResponse)(nil),             // 18: spire.server.datastore.DeleteBundleResponse
-	(*PruneBundleRequest)(nil),               // 19: spire.server.datastore.PruneBundleRequest
-	(*PruneBundleResponse)(nil),              // 20: spire.server.datastore.PruneBundleResponse
-	(*NodeSelectors)(nil),                    // 21: spire.server.datastore.NodeSelectors
-	(*SetNodeSelectorsRequest)(nil),          // 22: spire.server.datastore.SetNodeSelectorsRequest
-	(*SetNodeSelectorsResponse)(nil),         // 23: spire.server.datastore.SetNodeSelectorsResponse
-	(*GetNodeSelectorsRequest)(nil),          // 24: spire.server.datastore.GetNodeSelectorsRequest
-	(*GetNodeSelectorsResponse)(nil),         // 25: spire.server.datastore.GetNodeSelectorsResponse
-	(*ListNodeSelectorsRequest)(nil),         // 26: spire.server.datastore.ListNodeSelectorsRequest
-	(*ListNodeSelectorsResponse)(nil),        // 27: spire.server.datastore.ListNodeSelectorsResponse
-	(*CreateAttestedNodeResponse)(nil),       // 28: spire.server.datastore.CreateAttestedNodeResponse
-	(*FetchAttestedNodeRequest)(nil),         // 29: spire.server.datastore.FetchAttestedNodeRequest
-	(*FetchAttestedNodeResponse)(nil),        // 30: spire.server.datastore.FetchAttestedNodeResponse
-	(*CountAttestedNodesRequest)(nil),        // 31: spire.server.datastore.CountAttestedNodesRequest
-	(*CountAttestedNodesResponse)(nil),       // 32: spire.server.datastore.CountAttestedNodesResponse
-	(*CreateAttestedNodeRequest)(nil),        // 33: spire.server.datastore.CreateAttestedNodeRequest
-	(*ListAttestedNodesRequest)(nil),         // 34: spire.server.datastore.ListAttestedNodesRequest
-	(*ListAttestedNodesResponse)(nil),        // 35: spire.server.datastore.ListAttestedNodesResponse
-	(*UpdateAttestedNodeRequest)(nil),        // 36: spire.server.datastore.UpdateAttestedNodeRequest
-	(*UpdateAttestedNodeResponse)(nil),       // 37: spire.server.datastore.UpdateAttestedNodeResponse
-	(*DeleteAttestedNodeRequest)(nil),        // 38: spire.server.datastore.DeleteAttestedNodeRequest
-	(*DeleteAttestedNodeResponse)(nil),       // 39: spire.server.datastore.DeleteAttestedNodeResponse
-	(*CreateRegistrationEntryRequest)(nil),   // 40: spire.server.datastore.CreateRegistrationEntryRequest
-	(*CreateRegistrationEntryResponse)(nil),  // 41: spire.server.datastore.CreateRegistrationEntryResponse
-	(*FetchRegistrationEntryRequest)(nil),    // 42: spire.server.datastore.FetchRegistrationEntryRequest
-	(*FetchRegistrationEntryResponse)(nil),   // 43: spire.server.datastore.FetchRegistrationEntryResponse
-	(*BySelectors)(nil),                      // 44: spire.server.datastore.BySelectors
-	(*ByFederatesWith)(nil),                  // 45: spire.server.datastore.ByFederatesWith
-	(*Pagination)(nil),                       // 46: spire.server.datastore.Pagination
-	(*CountRegistrationEntriesRequest)(nil),  // 47: spire.server.datastore.CountRegistrationEntriesRequest
-	(*CountRegistrationEntriesResponse)(nil), // 48: spire.server.datastore.CountRegistrationEntriesResponse
-	(*ListRegistrationEntriesRequest)(nil),   // 49: spire.server.datastore.ListRegistrationEntriesRequest
-	(*ListRegistrationEntriesResponse)(nil),  // 50: spire.server.datastore.ListRegistrationEntriesResponse
-	(*UpdateRegistrationEntryRequest)(nil),   // 51: spire.server.datastore.UpdateRegistrationEntryRequest
-	(*UpdateRegistrationEntryResponse)(nil),  // 52: spire.server.datastore.UpdateRegistrationEntryResponse
-	(*DeleteRegistrationEntryRequest)(nil),   // 53: spire.server.datastore.DeleteRegistrationEntryRequest
-	(*DeleteRegistrationEntryResponse)(nil),  // 54: spire.server.datastore.DeleteRegistrationEntryResponse
-	(*PruneRegistrationEntriesRequest)(nil),  // 55: spire.server.datastore.PruneRegistrationEntriesRequest
-	(*PruneRegistrationEntriesResponse)(nil), // 56: spire.server.datastore.PruneRegistrationEntriesResponse
-	(*JoinToken)(nil),                        // 57: spire.server.datastore.JoinToken
-	(*CreateJoinTokenRequest)(nil),           // 58: spire.server.datastore.CreateJoinTokenRequest
-	(*CreateJoinTokenResponse)(nil),          // 59: spire.server.datastore.CreateJoinTokenResponse
-	(*FetchJoinTokenRequest)(nil),            // 60: spire.server.datastore.FetchJoinTokenRequest
-	(*FetchJoinTokenResponse)(nil),           // 61: spire.server.datastore.FetchJoinTokenResponse
-	(*DeleteJoinTokenRequest)(nil),           // 62: spire.server.datastore.DeleteJoinTokenRequest
-	(*DeleteJoinTokenResponse)(nil),          // 63: spire.server.datastore.DeleteJoinTokenResponse
-	(*PruneJoinTokensRequest)(nil),           // 64: spire.server.datastore.PruneJoinTokensRequest
-	(*PruneJoinTokensResponse)(nil),          // 65: spire.server.datastore.PruneJoinTokensResponse
-	(*common.Bundle)(nil),                    // 66: spire.common.Bundle
-	(*common.BundleMask)(nil),                // 67: spire.common.BundleMask
-	(*common.Selector)(nil),                  // 68: spire.common.Selector
-	(*timestamppb.Timestamp)(nil),            // 69: google.protobuf.Timestamp
-	(*common.AttestedNode)(nil),              // 70: spire.common.AttestedNode
-	(*wrapperspb.Int64Value)(nil),            // 71: google.protobuf.Int64Value
-	(*wrapperspb.BoolValue)(nil),             // 72: google.protobuf.BoolValue
-	(*common.AttestedNodeMask)(nil),          // 73: spire.common.AttestedNodeMask
-	(*common.RegistrationEntry)(nil),         // 74: spire.common.RegistrationEntry
-	(*wrapperspb.StringValue)(nil),           // 75: google.protobuf.StringValue
-	(*common.RegistrationEntryMask)(nil),     // 76: spire.common.RegistrationEntryMask
-	(*plugin.ConfigureRequest)(nil),          // 77: spire.common.plugin.ConfigureRequest
-	(*plugin.GetPluginInfoRequest)(nil),      // 78: spire.common.plugin.GetPluginInfoRequest
-	(*plugin.ConfigureResponse)(nil),         // 79: spire.common.plugin.ConfigureResponse
-	(*plugin.GetPluginInfoResponse)(nil),     // 80: spire.common.plugin.GetPluginInfoResponse
+	(IntegrityIssue_Kind)(0),                 // 3: spire.server.datastore.IntegrityIssue.Kind
+	(*CreateBundleRequest)(nil),              // 4: spire.server.datastore.CreateBundleRequest
+	(*CreateBundleResponse)(nil),             // 5: spire.server.datastore.CreateBundleResponse
+	(*FetchBundleRequest)(nil),               // 6: spire.server.datastore.FetchBundleRequest
+	(*FetchBundleResponse)(nil),              // 7: spire.server.datastore.FetchBundleResponse
+	(*CountBundlesRequest)(nil),              // 8: spire.server.datastore.CountBundlesRequest
+	(*CountBundlesResponse)(nil),             // 9: spire.server.datastore.CountBundlesResponse
+	(*ListBundlesRequest)(nil),               // 10: spire.server.datastore.ListBundlesRequest
+	(*ListBundlesResponse)(nil),              // 11: spire.server.datastore.ListBundlesResponse
+	(*BundleLimits)(nil),                     // 12: spire.server.datastore.BundleLimits
+	(*UpdateBundleRequest)(nil),              // 13: spire.server.datastore.UpdateBundleRequest
+	(*UpdateBundleResponse)(nil),             // 14: spire.server.datastore.UpdateBundleResponse
+	(*SetBundleRequest)(nil),                 // 15: spire.server.datastore.SetBundleRequest
+	(*SetBundleResponse)(nil),                // 16: spire.server.datastore.SetBundleResponse
+	(*AppendBundleRequest)(nil),              // 17: spire.server.datastore.AppendBundleRequest
+	(*AppendBundleResponse)(nil),             // 18: spire.server.datastore.AppendBundleResponse
+	(*DeleteBundleRequest)(nil),              // 19: spire.server.datastore.DeleteBundleRequest
+	(*DeleteBundleResponse)(nil),             // 20: spire.server.datastore.DeleteBundleResponse
+	(*PruneBundleRequest)(nil),               // 21: spire.server.datastore.PruneBundleRequest
+	(*PruneBundleResponse)(nil),              // 22: spire.server.datastore.PruneBundleResponse
+	(*NodeSelectors)(nil),                    // 23: spire.server.datastore.NodeSelectors
+	(*SetNodeSelectorsRequest)(nil),          // 24: spire.server.datastore.SetNodeSelectorsRequest
+	(*SetNodeSelectorsResponse)(nil),         // 25: spire.server.datastore.SetNodeSelectorsResponse
+	(*GetNodeSelectorsRequest)(nil),          // 26: spire.server.datastore.GetNodeSelectorsRequest
+	(*GetNodeSelectorsResponse)(nil),         // 27: spire.server.datastore.GetNodeSelectorsResponse
+	(*ListNodeSelectorsRequest)(nil),         // 28: spire.server.datastore.ListNodeSelectorsRequest
+	(*ListNodeSelectorsResponse)(nil),        // 29: spire.server.datastore.ListNodeSelectorsResponse
+	(*CreateAttestedNodeResponse)(nil),       // 30: spire.server.datastore.CreateAttestedNodeResponse
+	(*FetchAttestedNodeRequest)(nil),         // 31: spire.server.datastore.FetchAttestedNodeRequest
+	(*FetchAttestedNodeResponse)(nil),        // 32: spire.server.datastore.FetchAttestedNodeResponse
+	(*CountAttestedNodesRequest)(nil),        // 33: spire.server.datastore.CountAttestedNodesRequest
+	(*CountAttestedNodesResponse)(nil),       // 34: spire.server.datastore.CountAttestedNodesResponse
+	(*CreateAttestedNodeRequest)(nil),        // 35: spire.server.datastore.CreateAttestedNodeRequest
+	(*ListAttestedNodesRequest)(nil),         // 36: spire.server.datastore.ListAttestedNodesRequest
+	(*ListAttestedNodesResponse)(nil),        // 37: spire.server.datastore.ListAttestedNodesResponse
+	(*UpdateAttestedNodeRequest)(nil),        // 38: spire.server.datastore.UpdateAttestedNodeRequest
+	(*UpdateAttestedNodeResponse)(nil),       // 39: spire.server.datastore.UpdateAttestedNodeResponse
+	(*DeleteAttestedNodeRequest)(nil),        // 40: spire.server.datastore.DeleteAttestedNodeRequest
+	(*DeleteAttestedNodeResponse)(nil),       // 41: spire.server.datastore.DeleteAttestedNodeResponse
+	(*CreateRegistrationEntryRequest)(nil),   // 42: spire.server.datastore.CreateRegistrationEntryRequest
+	(*CreateRegistrationEntryResponse)(nil),  // 43: spire.server.datastore.CreateRegistrationEntryResponse
+	(*FetchRegistrationEntryRequest)(nil),    // 44: spire.server.datastore.FetchRegistrationEntryRequest
+	(*FetchRegistrationEntryResponse)(nil),   // 45: spire.server.datastore.FetchRegistrationEntryResponse
+	(*BySelectors)(nil),                      // 46: spire.server.datastore.BySelectors
+	(*ByFederatesWith)(nil),                  // 47: spire.server.datastore.ByFederatesWith
+	(*Pagination)(nil),                       // 48: spire.server.datastore.Pagination
+	(*CountRegistrationEntriesRequest)(nil),  // 49: spire.server.datastore.CountRegistrationEntriesRequest
+	(*CountRegistrationEntriesResponse)(nil), // 50: spire.server.datastore.CountRegistrationEntriesResponse
+	(*ListRegistrationEntriesRequest)(nil),   // 51: spire.server.datastore.ListRegistrationEntriesRequest
+	(*ListRegistrationEntriesResponse)(nil),  // 52: spire.server.datastore.ListRegistrationEntriesResponse
+	(*UpdateRegistrationEntryRequest)(nil),   // 53: spire.server.datastore.UpdateRegistrationEntryRequest
+	(*UpdateRegistrationEntryResponse)(nil),  // 54: spire.server.datastore.UpdateRegistrationEntryResponse
+	(*DeleteRegistrationEntryRequest)(nil),   // 55: spire.server.datastore.DeleteRegistrationEntryRequest
+	(*DeleteRegistrationEntryResponse)(nil),  // 56: spire.server.datastore.DeleteRegistrationEntryResponse
+	(*PruneRegistrationEntriesRequest)(nil),  // 57: spire.server.datastore.PruneRegistrationEntriesRequest
+	(*PruneRegistrationEntriesResponse)(nil), // 58: spire.server.datastore.PruneRegistrationEntriesResponse
+	(*JoinToken)(nil),                        // 59: spire.server.datastore.JoinToken
+	(*CreateJoinTokenRequest)(nil),           // 60: spire.server.datastore.CreateJoinTokenRequest
+	(*CreateJoinTokenResponse)(nil),          // 61: spire.server.datastore.CreateJoinTokenResponse
+	(*FetchJoinTokenRequest)(nil),            // 62: spire.server.datastore.FetchJoinTokenRequest
+	(*FetchJoinTokenResponse)(nil),           // 63: spire.server.datastore.FetchJoinTokenResponse
+	(*DeleteJoinTokenRequest)(nil),           // 64: spire.server.datastore.DeleteJoinTokenRequest
+	(*DeleteJoinTokenResponse)(nil),          // 65: spire.server.datastore.DeleteJoinTokenResponse
+	(*UseJoinTokenRequest)(nil),              // 66: spire.server.datastore.UseJoinTokenRequest
+	(*UseJoinTokenResponse)(nil),             // 67: spire.server.datastore.UseJoinTokenResponse
+	(*PruneJoinTokensRequest)(nil),           // 68: spire.server.datastore.PruneJoinTokensRequest
+	(*PruneJoinTokensResponse)(nil),          // 69: spire.server.datastore.PruneJoinTokensResponse
+	(*RegistrationEntryOperation)(nil),       // 70: spire.server.datastore.RegistrationEntryOperation
+	(*BatchRegistrationEntriesRequest)(nil),  // 71: spire.server.datastore.BatchRegistrationEntriesRequest
+	(*BatchRegistrationEntriesResponse)(nil), // 72: spire.server.datastore.BatchRegistrationEntriesResponse
+	(*ListJoinTokensRequest)(nil),            // 73: spire.server.datastore.ListJoinTokensRequest
+	(*ListJoinTokensResponse)(nil),           // 74: spire.server.datastore.ListJoinTokensResponse
+	(*IntegrityIssue)(nil),                   // 75: spire.server.datastore.IntegrityIssue
+	(*CheckIntegrityRequest)(nil),            // 76: spire.server.datastore.CheckIntegrityRequest
+	(*CheckIntegrityResponse)(nil),           // 77: spire.server.datastore.CheckIntegrityResponse
+	(*common.Bundle)(nil),                    // 78: spire.common.Bundle
+	(*common.BundleMask)(nil),                // 79: spire.common.BundleMask
+	(*wrapperspb.Int64Value)(nil),            // 80: google.protobuf.Int64Value
+	(*common.Selector)(nil),                  // 81: spire.common.Selector
+	(*timestamppb.Timestamp)(nil),            // 82: google.protobuf.Timestamp
+	(*common.AttestedNode)(nil),              // 83: spire.common.AttestedNode
+	(*wrapperspb.BoolValue)(nil),             // 84: google.protobuf.BoolValue
+	(*common.AttestedNodeMask)(nil),          // 85: spire.common.AttestedNodeMask
+	(*common.RegistrationEntry)(nil),         // 86: spire.common.RegistrationEntry
+	(*wrapperspb.StringValue)(nil),           // 87: google.protobuf.StringValue
+	(*wrapperspb.Int32Value)(nil),            // 88: google.protobuf.Int32Value
+	(*common.RegistrationEntryMask)(nil),     // 89: spire.common.RegistrationEntryMask
+	(*plugin.ConfigureRequest)(nil),          // 90: spire.common.plugin.ConfigureRequest
+	(*plugin.GetPluginInfoRequest)(nil),      // 91: spire.common.plugin.GetPluginInfoRequest
+	(*plugin.ConfigureResponse)(nil),         // 92: spire.common.plugin.ConfigureResponse
+	(*plugin.GetPluginInfoResponse)(nil),     // 93: spire.common.plugin.GetPluginInfoResponse
 }
 var file_spire_server_datastore_datastore_proto_depIdxs = []int32{
-	66, // 0: spire.server.datastore.CreateBundleRequest.bundle:type_name -> spire.common.Bundle
-	66, // 1: spire.server.datastore.CreateBundleResponse.bundle:type_name -> spire.common.Bundle
-	66, // 2: spire.server.datastore.FetchBundleResponse.bundle:type_name -> spire.common.Bundle
-	46, // 3: spire.server.datastore.ListBundlesRequest.pagination:type_name -> spire.server.datastore.Pagination
-	66, // 4: spire.server.datastore.ListBundlesResponse.bundles:type_name -> spire.common.Bundle
-	46, // 5: spire.server.datastore.ListBundlesResponse.pagination:type_name -> spire.server.datastore.Pagination
-	66, // 6: spire.server.datastore.UpdateBundleRequest.bundle:type_name -> spire.common.Bundle
-	67, // 7: spire.server.datastore.UpdateBundleRequest.input_mask:type_name -> spire.common.BundleMask
-	66, // 8: spire.server.datastore.UpdateBundleResponse.bundle:type_name -> spire.common.Bundle
-	66, // 9: spire.server.datastore.SetBundleRequest.bundle:type_name -> spire.common.Bundle
-	66, // 10: spire.server.datastore.SetBundleResponse.bundle:type_name -> spire.common.Bundle
-	66, // 11: spire.server.datastore.AppendBundleRequest.bundle:type_name -> spire.common.Bundle
-	66, // 12: spire.server.datastore.AppendBundleResponse.bundle:type_name -> spire.common.Bundle
-	0,  // 13: spire.server.datastore.DeleteBundleRequest.mode:type_name -> spire.server.datastore.DeleteBundleRequest.Mode
-	66, // 14: spire.server.datastore.DeleteBundleResponse.bundle:type_name -> spire.common.Bundle
-	68, // 15: spire.server.datastore.NodeSelectors.selectors:type_name -> spire.common.Selector
-	21, // 16: spire.server.datastore.SetNodeSelectorsRequest.selectors:type_name -> spire.server.datastore.NodeSelectors
-	21, // 17: spire.server.datastore.GetNodeSelectorsResponse.selectors:type_name -> spire.server.datastore.NodeSelectors
-	69, // 18: spire.server.datastore.ListNodeSelectorsRequest.valid_at:type_name -> google.protobuf.Timestamp
-	21, // 19: spire.server.datastore.ListNodeSelectorsResponse.selectors:type_name -> spire.server.datastore.NodeSelectors
-	70, // 20: spire.server.datastore.CreateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
-	70, // 21: spire.server.datastore.FetchAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
-	70, // 22: spire.server.datastore.CreateAttestedNodeRequest.node:type_name -> spire.common.AttestedNode
-	71, // 23: spire.server.datastore.ListAttestedNodesRequest.by_expires_before:type_name -> google.protobuf.Int64Value
-	46, // 24: spire.server.datastore.ListAttestedNodesRequest.pagination:type_name -> spire.server.datastore.Pagination
-	44, // 25: spire.server.datastore.ListAttestedNodesRequest.by_selector_match:type_name -> spire.server.datastore.BySelectors
-	72, // 26: spire.server.datastore.ListAttestedNodesRequest.by_banned:type_name -> google.protobuf.BoolValue
-	70, // 27: spire.server.datastore.ListAttestedNodesResponse.nodes:type_name -> spire.common.AttestedNode
-	46, // 28: spire.server.datastore.ListAttestedNodesResponse.pagination:type_name -> spire.server.datastore.Pagination
-	73, // 29: spire.server.datastore.UpdateAttestedNodeRequest.input_mask:type_name -> spire.common.AttestedNodeMask
-	70, // 30: spire.server.datastore.UpdateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
-	70, // 31: spire.server.datastore.DeleteAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
-	74, // 32: spire.server.datastore.CreateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
-	74, // 33: spire.server.datastore.CreateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
-	74, // 34: spire.server.datastore.FetchRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
-	68, // 35: spire.server.datastore.BySelectors.selectors:type_name -> spire.common.Selector
-	1,  // 36: spire.server.datastore.BySelectors.match:type_name -> spire.server.datastore.BySelectors.MatchBehavior
-	2,  // 37: spire.server.datastore.ByFederatesWith.match:type_name -> spire.server.datastore.ByFederatesWith.MatchBehavior
-	75, // 38: spire.server.datastore.ListRegistrationEntriesRequest.by_parent_id:type_name -> google.protobuf.StringValue
-	44, // 39: spire.server.datastore.ListRegistrationEntriesRequest.by_selectors:type_name -> spire.server.datastore.BySelectors
-	75, // 40: spire.server.datastore.ListRegistrationEntriesRequest.by_spiffe_id:type_name -> google.protobuf.StringValue
-	46, // 41: spire.server.datastore.ListRegistrationEntriesRequest.pagination:type_name -> spire.server.datastore.Pagination
-	45, // 42: spire.server.datastore.ListRegistrationEntriesRequest.by_federates_with:type_name -> spire.server.datastore.ByFederatesWith
-	74, // 43: spire.server.datastore.ListRegistrationEntriesResponse.entries:type_name -> spire.common.RegistrationEntry
-	46, // 44: spire.server.datastore.ListRegistrationEntriesResponse.pagination:type_name -> spire.server.datastore.Pagination
-	74, // 45: spire.server.datastore.UpdateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
-	76, // 46: spire.server.datastore.UpdateRegistrationEntryRequest.mask:type_name -> spire.common.RegistrationEntryMask
-	74, // 47: spire.server.datastore.UpdateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
-	74, // 48: spire.server.datastore.DeleteRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
-	57, // 49: spire.server.datastore.CreateJoinTokenRequest.join_token:type_name -> spire.server.datastore.JoinToken
-	57, // 50: spire.server.datastore.CreateJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
-	57, // 51: spire.server.datastore.FetchJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
-	57, // 52: spire.server.datastore.DeleteJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
-	3,  // 53: spire.server.datastore.DataStore.CreateBundle:input_type -> spire.server.datastore.CreateBundleRequest
-	5,  // 54: spire.server.datastore.DataStore.FetchBundle:input_type -> spire.server.datastore.FetchBundleRequest
-	7,  // 55: spire.server.datastore.DataStore.CountBundles:input_type -> spire.server.datastore.CountBundlesRequest
-	9,  // 56: spire.server.datastore.DataStore.ListBundles:input_type -> spire.server.datastore.ListBundlesRequest
-	11, // 57: spire.server.datastore.DataStore.UpdateBundle:input_type -> spire.server.datastore.UpdateBundleRequest
-	13, // 58: spire.server.datastore.DataStore.SetBundle:input_type -> spire.server.datastore.SetBundleRequest
-	15, // 59: spire.server.datastore.DataStore.AppendBundle:input_type -> spire.server.datastore.AppendBundleRequest
-	17, // 60: spire.server.datastore.DataStore.DeleteBundle:input_type -> spire.server.datastore.DeleteBundleRequest
-	19, // 61: spire.server.datastore.DataStore.PruneBundle:input_type -> spire.server.datastore.PruneBundleRequest
-	33, // 62: spire.server.datastore.DataStore.CreateAttestedNode:input_type -> spire.server.datastore.CreateAttestedNodeRequest
-	29, // 63: spire.server.datastore.DataStore.FetchAttestedNode:input_type -> spire.server.datastore.FetchAttestedNodeRequest
-	31, // 64: spire.server.datastore.DataStore.CountAttestedNodes:input_type -> spire.server.datastore.CountAttestedNodesRequest
-	34, // 65: spire.server.datastore.DataStore.ListAttestedNodes:input_type -> spire.server.datastore.ListAttestedNodesRequest
-	36, // 66: spire.server.datastore.DataStore.UpdateAttestedNode:input_type -> spire.server.datastore.UpdateAttestedNodeRequest
-	38, // 67: spire.server.datastore.DataStore.DeleteAttestedNode:input_type -> spire.server.datastore.DeleteAttestedNodeRequest
-	22, // 68: spire.server.datastore.DataStore.SetNodeSelectors:input_type -> spire.server.datastore.SetNodeSelectorsRequest
-	24, // 69: spire.server.datastore.DataStore.GetNodeSelectors:input_type -> spire.server.datastore.GetNodeSelectorsRequest
-	26, // 70: spire.server.datastore.DataStore.ListNodeSelectors:input_type -> spire.server.datastore.ListNodeSelectorsRequest
-	40, // 71: spire.server.datastore.DataStore.CreateRegistrationEntry:input_type -> spire.server.datastore.CreateRegistrationEntryRequest
-	42, // 72: spire.server.datastore.DataStore.FetchRegistrationEntry:input_type -> spire.server.datastore.FetchRegistrationEntryRequest
-	47, // 73: spire.server.datastore.DataStore.CountRegistrationEntries:input_type -> spire.server.datastore.CountRegistrationEntriesRequest
-	49, // 74: spire.server.datastore.DataStore.ListRegistrationEntries:input_type -> spire.server.datastore.ListRegistrationEntriesRequest
-	51, // 75: spire.server.datastore.DataStore.UpdateRegistrationEntry:input_type -> spire.server.datastore.UpdateRegistrationEntryRequest
-	53, // 76: spire.server.datastore.DataStore.DeleteRegistrationEntry:input_type -> spire.server.datastore.DeleteRegistrationEntryRequest
-	55, // 77: spire.server.datastore.DataStore.PruneRegistrationEntries:input_type -> spire.server.datastore.PruneRegistrationEntriesRequest
-	58, // 78: spire.server.datastore.DataStore.CreateJoinToken:input_type -> spire.server.datastore.CreateJoinTokenRequest
-	60, // 79: spire.server.datastore.DataStore.FetchJoinToken:input_type -> spire.server.datastore.FetchJoinTokenRequest
-	62, // 80: spire.server.datastore.DataStore.DeleteJoinToken:input_type -> spire.server.datastore.DeleteJoinTokenRequest
-	64, // 81: spire.server.datastore.DataStore.PruneJoinTokens:input_type -> spire.server.datastore.PruneJoinTokensRequest
-	77, // 82: spire.server.datastore.DataStore.Configure:input_type -> spire.common.plugin.ConfigureRequest
-	78, // 83: spire.server.datastore.DataStore.GetPluginInfo:input_type -> spire.common.plugin.GetPluginInfoRequest
-	4,  // 84: spire.server.datastore.DataStore.CreateBundle:output_type -> spire.server.datastore.CreateBundleResponse
-	6,  // 85: spire.server.datastore.DataStore.FetchBundle:output_type -> spire.server.datastore.FetchBundleResponse
-	8,  // 86: spire.server.datastore.DataStore.CountBundles:output_type -> spire.server.datastore.CountBundlesResponse
-	10, // 87: spire.server.datastore.DataStore.ListBundles:output_type -> spire.server.datastore.ListBundlesResponse
-	12, // 88: spire.server.datastore.DataStore.UpdateBundle:output_type -> spire.server.datastore.UpdateBundleResponse
-	14, // 89: spire.server.datastore.DataStore.SetBundle:output_type -> spire.server.datastore.SetBundleResponse
-	16, // 90: spire.server.datastore.DataStore.AppendBundle:output_type -> spire.server.datastore.AppendBundleResponse
-	18, // 91: spire.server.datastore.DataStore.DeleteBundle:output_type -> spire.server.datastore.DeleteBundleResponse
-	20, // 92: spire.server.datastore.DataStore.PruneBundle:output_type -> spire.server.datastore.PruneBundleResponse
-	28, // 93: spire.server.datastore.DataStore.CreateAttestedNode:output_type -> spire.server.datastore.CreateAttestedNodeResponse
-	30, // 94: spire.server.datastore.DataStore.FetchAttestedNode:output_type -> spire.server.datastore.FetchAttestedNodeResponse
-	32, // 95: spire.server.datastore.DataStore.CountAttestedNodes:output_type -> spire.server.datastore.CountAttestedNodesResponse
-	35, // 96: spire.server.datastore.DataStore.ListAttestedNodes:output_type -> spire.server.datastore.ListAttestedNodesResponse
-	37, // 97: spire.server.datastore.DataStore.UpdateAttestedNode:output_type -> spire.server.datastore.UpdateAttestedNodeResponse
-	39, // 98: spire.server.datastore.DataStore.DeleteAttestedNode:output_type -> spire.server.datastore.DeleteAttestedNodeResponse
-	23, // 99: spire.server.datastore.DataStore.SetNodeSelectors:output_type -> spire.server.datastore.SetNodeSelectorsResponse
-	25, // 100: spire.server.datastore.DataStore.GetNodeSelectors:output_type -> spire.server.datastore.GetNodeSelectorsResponse
-	27, // 101: spire.server.datastore.DataStore.ListNodeSelectors:output_type -> spire.server.datastore.ListNodeSelectorsResponse
-	41, // 102: spire.server.datastore.DataStore.CreateRegistrationEntry:output_type -> spire.server.datastore.CreateRegistrationEntryResponse
-	43, // 103: spire.server.datastore.DataStore.FetchRegistrationEntry:output_type -> spire.server.datastore.FetchRegistrationEntryResponse
-	48, // 104: spire.server.datastore.DataStore.CountRegistrationEntries:output_type -> spire.server.datastore.CountRegistrationEntriesResponse
-	50, // 105: spire.server.datastore.DataStore.ListRegistrationEntries:output_type -> spire.server.datastore.ListRegistrationEntriesResponse
-	52, // 106: spire.server.datastore.DataStore.UpdateRegistrationEntry:output_type -> spire.server.datastore.UpdateRegistrationEntryResponse
-	54, // 107: spire.server.datastore.DataStore.DeleteRegistrationEntry:output_type -> spire.server.datastore.DeleteRegistrationEntryResponse
-	56, // 108: spire.server.datastore.DataStore.PruneRegistrationEntries:output_type -> spire.server.datastore.PruneRegistrationEntriesResponse
-	59, // 109: spire.server.datastore.DataStore.CreateJoinToken:output_type -> spire.server.datastore.CreateJoinTokenResponse
-	61, // 110: spire.server.datastore.DataStore.FetchJoinToken:output_type -> spire.server.datastore.FetchJoinTokenResponse
-	63, // 111: spire.server.datastore.DataStore.DeleteJoinToken:output_type -> spire.server.datastore.DeleteJoinTokenResponse
-	65, // 112: spire.server.datastore.DataStore.PruneJoinTokens:output_type -> spire.server.datastore.PruneJoinTokensResponse
-	79, // 113: spire.server.datastore.DataStore.Configure:output_type -> spire.common.plugin.ConfigureResponse
-	80, // 114: spire.server.datastore.DataStore.GetPluginInfo:output_type -> spire.common.plugin.GetPluginInfoResponse
-	84, // [84:115] is the sub-list for method output_type
-	53, // [53:84] is the sub-list for method input_type
-	53, // [53:53] is the sub-list for extension type_name
-	53, // [53:53] is the sub-list for extension extendee
-	0,  // [0:53] is the sub-list for field type_name
+	78,  // 0: spire.server.datastore.CreateBundleRequest.bundle:type_name -> spire.common.Bundle
+	78,  // 1: spire.server.datastore.CreateBundleResponse.bundle:type_name -> spire.common.Bundle
+	78,  // 2: spire.server.datastore.FetchBundleResponse.bundle:type_name -> spire.common.Bundle
+	48,  // 3: spire.server.datastore.ListBundlesRequest.pagination:type_name -> spire.server.datastore.Pagination
+	78,  // 4: spire.server.datastore.ListBundlesResponse.bundles:type_name -> spire.common.Bundle
+	48,  // 5: spire.server.datastore.ListBundlesResponse.pagination:type_name -> spire.server.datastore.Pagination
+	78,  // 6: spire.server.datastore.UpdateBundleRequest.bundle:type_name -> spire.common.Bundle
+	79,  // 7: spire.server.datastore.UpdateBundleRequest.input_mask:type_name -> spire.common.BundleMask
+	12,  // 8: spire.server.datastore.UpdateBundleRequest.limits:type_name -> spire.server.datastore.BundleLimits
+	78,  // 9: spire.server.datastore.UpdateBundleResponse.bundle:type_name -> spire.common.Bundle
+	78,  // 10: spire.server.datastore.SetBundleRequest.bundle:type_name -> spire.common.Bundle
+	12,  // 11: spire.server.datastore.SetBundleRequest.limits:type_name -> spire.server.datastore.BundleLimits
+	78,  // 12: spire.server.datastore.SetBundleResponse.bundle:type_name -> spire.common.Bundle
+	78,  // 13: spire.server.datastore.AppendBundleRequest.bundle:type_name -> spire.common.Bundle
+	12,  // 14: spire.server.datastore.AppendBundleRequest.limits:type_name -> spire.server.datastore.BundleLimits
+	80,  // 15: spire.server.datastore.AppendBundleRequest.refresh_hint:type_name -> google.protobuf.Int64Value
+	78,  // 16: spire.server.datastore.AppendBundleResponse.bundle:type_name -> spire.common.Bundle
+	0,   // 17: spire.server.datastore.DeleteBundleRequest.mode:type_name -> spire.server.datastore.DeleteBundleRequest.Mode
+	78,  // 18: spire.server.datastore.DeleteBundleResponse.bundle:type_name -> spire.common.Bundle
+	81,  // 19: spire.server.datastore.NodeSelectors.selectors:type_name -> spire.common.Selector
+	23,  // 20: spire.server.datastore.SetNodeSelectorsRequest.selectors:type_name -> spire.server.datastore.NodeSelectors
+	23,  // 21: spire.server.datastore.GetNodeSelectorsResponse.selectors:type_name -> spire.server.datastore.NodeSelectors
+	82,  // 22: spire.server.datastore.ListNodeSelectorsRequest.valid_at:type_name -> google.protobuf.Timestamp
+	23,  // 23: spire.server.datastore.ListNodeSelectorsResponse.selectors:type_name -> spire.server.datastore.NodeSelectors
+	83,  // 24: spire.server.datastore.CreateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	83,  // 25: spire.server.datastore.FetchAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	83,  // 26: spire.server.datastore.CreateAttestedNodeRequest.node:type_name -> spire.common.AttestedNode
+	80,  // 27: spire.server.datastore.ListAttestedNodesRequest.by_expires_before:type_name -> google.protobuf.Int64Value
+	48,  // 28: spire.server.datastore.ListAttestedNodesRequest.pagination:type_name -> spire.server.datastore.Pagination
+	46,  // 29: spire.server.datastore.ListAttestedNodesRequest.by_selector_match:type_name -> spire.server.datastore.BySelectors
+	84,  // 30: spire.server.datastore.ListAttestedNodesRequest.by_banned:type_name -> google.protobuf.BoolValue
+	80,  // 31: spire.server.datastore.ListAttestedNodesRequest.by_expires_at_or_after:type_name -> google.protobuf.Int64Value
+	83,  // 32: spire.server.datastore.ListAttestedNodesResponse.nodes:type_name -> spire.common.AttestedNode
+	48,  // 33: spire.server.datastore.ListAttestedNodesResponse.pagination:type_name -> spire.server.datastore.Pagination
+	85,  // 34: spire.server.datastore.UpdateAttestedNodeRequest.input_mask:type_name -> spire.common.AttestedNodeMask
+	83,  // 35: spire.server.datastore.UpdateAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	83,  // 36: spire.server.datastore.DeleteAttestedNodeResponse.node:type_name -> spire.common.AttestedNode
+	86,  // 37: spire.server.datastore.CreateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
+	86,  // 38: spire.server.datastore.CreateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	86,  // 39: spire.server.datastore.FetchRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	81,  // 40: spire.server.datastore.BySelectors.selectors:type_name -> spire.common.Selector
+	1,   // 41: spire.server.datastore.BySelectors.match:type_name -> spire.server.datastore.BySelectors.MatchBehavior
+	2,   // 42: spire.server.datastore.ByFederatesWith.match:type_name -> spire.server.datastore.ByFederatesWith.MatchBehavior
+	87,  // 43: spire.server.datastore.ListRegistrationEntriesRequest.by_parent_id:type_name -> google.protobuf.StringValue
+	46,  // 44: spire.server.datastore.ListRegistrationEntriesRequest.by_selectors:type_name -> spire.server.datastore.BySelectors
+	87,  // 45: spire.server.datastore.ListRegistrationEntriesRequest.by_spiffe_id:type_name -> google.protobuf.StringValue
+	48,  // 46: spire.server.datastore.ListRegistrationEntriesRequest.pagination:type_name -> spire.server.datastore.Pagination
+	47,  // 47: spire.server.datastore.ListRegistrationEntriesRequest.by_federates_with:type_name -> spire.server.datastore.ByFederatesWith
+	88,  // 48: spire.server.datastore.ListRegistrationEntriesRequest.by_min_ttl:type_name -> google.protobuf.Int32Value
+	88,  // 49: spire.server.datastore.ListRegistrationEntriesRequest.by_max_ttl:type_name -> google.protobuf.Int32Value
+	80,  // 50: spire.server.datastore.ListRegistrationEntriesRequest.by_expires_before:type_name -> google.protobuf.Int64Value
+	86,  // 51: spire.server.datastore.ListRegistrationEntriesResponse.entries:type_name -> spire.common.RegistrationEntry
+	48,  // 52: spire.server.datastore.ListRegistrationEntriesResponse.pagination:type_name -> spire.server.datastore.Pagination
+	86,  // 53: spire.server.datastore.UpdateRegistrationEntryRequest.entry:type_name -> spire.common.RegistrationEntry
+	89,  // 54: spire.server.datastore.UpdateRegistrationEntryRequest.mask:type_name -> spire.common.RegistrationEntryMask
+	86,  // 55: spire.server.datastore.UpdateRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	86,  // 56: spire.server.datastore.DeleteRegistrationEntryResponse.entry:type_name -> spire.common.RegistrationEntry
+	59,  // 57: spire.server.datastore.CreateJoinTokenRequest.join_token:type_name -> spire.server.datastore.JoinToken
+	59,  // 58: spire.server.datastore.CreateJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
+	59,  // 59: spire.server.datastore.FetchJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
+	59,  // 60: spire.server.datastore.DeleteJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
+	59,  // 61: spire.server.datastore.UseJoinTokenResponse.join_token:type_name -> spire.server.datastore.JoinToken
+	42,  // 62: spire.server.datastore.RegistrationEntryOperation.create:type_name -> spire.server.datastore.CreateRegistrationEntryRequest
+	53,  // 63: spire.server.datastore.RegistrationEntryOperation.update:type_name -> spire.server.datastore.UpdateRegistrationEntryRequest
+	55,  // 64: spire.server.datastore.RegistrationEntryOperation.delete:type_name -> spire.server.datastore.DeleteRegistrationEntryRequest
+	80,  // 65: spire.server.datastore.RegistrationEntryOperation.if_revision_number:type_name -> google.protobuf.Int64Value
+	70,  // 66: spire.server.datastore.BatchRegistrationEntriesRequest.operations:type_name -> spire.server.datastore.RegistrationEntryOperation
+	86,  // 67: spire.server.datastore.BatchRegistrationEntriesResponse.entries:type_name -> spire.common.RegistrationEntry
+	48,  // 68: spire.server.datastore.ListJoinTokensRequest.pagination:type_name -> spire.server.datastore.Pagination
+	80,  // 69: spire.server.datastore.ListJoinTokensRequest.by_expires_before:type_name -> google.protobuf.Int64Value
+	80,  // 70: spire.server.datastore.ListJoinTokensRequest.by_expires_at_or_after:type_name -> google.protobuf.Int64Value
+	84,  // 71: spire.server.datastore.ListJoinTokensRequest.by_used:type_name -> google.protobuf.BoolValue
+	59,  // 72: spire.server.datastore.ListJoinTokensResponse.join_tokens:type_name -> spire.server.datastore.JoinToken
+	48,  // 73: spire.server.datastore.ListJoinTokensResponse.pagination:type_name -> spire.server.datastore.Pagination
+	3,   // 74: spire.server.datastore.IntegrityIssue.kind:type_name -> spire.server.datastore.IntegrityIssue.Kind
+	75,  // 75: spire.server.datastore.CheckIntegrityResponse.issues:type_name -> spire.server.datastore.IntegrityIssue
+	4,   // 76: spire.server.datastore.DataStore.CreateBundle:input_type -> spire.server.datastore.CreateBundleRequest
+	6,   // 77: spire.server.datastore.DataStore.FetchBundle:input_type -> spire.server.datastore.FetchBundleRequest
+	8,   // 78: spire.server.datastore.DataStore.CountBundles:input_type -> spire.server.datastore.CountBundlesRequest
+	10,  // 79: spire.server.datastore.DataStore.ListBundles:input_type -> spire.server.datastore.ListBundlesRequest
+	13,  // 80: spire.server.datastore.DataStore.UpdateBundle:input_type -> spire.server.datastore.UpdateBundleRequest
+	15,  // 81: spire.server.datastore.DataStore.SetBundle:input_type -> spire.server.datastore.SetBundleRequest
+	17,  // 82: spire.server.datastore.DataStore.AppendBundle:input_type -> spire.server.datastore.AppendBundleRequest
+	19,  // 83: spire.server.datastore.DataStore.DeleteBundle:input_type -> spire.server.datastore.DeleteBundleRequest
+	21,  // 84: spire.server.datastore.DataStore.PruneBundle:input_type -> spire.server.datastore.PruneBundleRequest
+	35,  // 85: spire.server.datastore.DataStore.CreateAttestedNode:input_type -> spire.server.datastore.CreateAttestedNodeRequest
+	31,  // 86: spire.server.datastore.DataStore.FetchAttestedNode:input_type -> spire.server.datastore.FetchAttestedNodeRequest
+	33,  // 87: spire.server.datastore.DataStore.CountAttestedNodes:input_type -> spire.server.datastore.CountAttestedNodesRequest
+	36,  // 88: spire.server.datastore.DataStore.ListAttestedNodes:input_type -> spire.server.datastore.ListAttestedNodesRequest
+	38,  // 89: spire.server.datastore.DataStore.UpdateAttestedNode:input_type -> spire.server.datastore.UpdateAttestedNodeRequest
+	40,  // 90: spire.server.datastore.DataStore.DeleteAttestedNode:input_type -> spire.server.datastore.DeleteAttestedNodeRequest
+	24,  // 91: spire.server.datastore.DataStore.SetNodeSelectors:input_type -> spire.server.datastore.SetNodeSelectorsRequest
+	26,  // 92: spire.server.datastore.DataStore.GetNodeSelectors:input_type -> spire.server.datastore.GetNodeSelectorsRequest
+	28,  // 93: spire.server.datastore.DataStore.ListNodeSelectors:input_type -> spire.server.datastore.ListNodeSelectorsRequest
+	42,  // 94: spire.server.datastore.DataStore.CreateRegistrationEntry:input_type -> spire.server.datastore.CreateRegistrationEntryRequest
+	44,  // 95: spire.server.datastore.DataStore.FetchRegistrationEntry:input_type -> spire.server.datastore.FetchRegistrationEntryRequest
+	49,  // 96: spire.server.datastore.DataStore.CountRegistrationEntries:input_type -> spire.server.datastore.CountRegistrationEntriesRequest
+	51,  // 97: spire.server.datastore.DataStore.ListRegistrationEntries:input_type -> spire.server.datastore.ListRegistrationEntriesRequest
+	53,  // 98: spire.server.datastore.DataStore.UpdateRegistrationEntry:input_type -> spire.server.datastore.UpdateRegistrationEntryRequest
+	55,  // 99: spire.server.datastore.DataStore.DeleteRegistrationEntry:input_type -> spire.server.datastore.DeleteRegistrationEntryRequest
+	57,  // 100: spire.server.datastore.DataStore.PruneRegistrationEntries:input_type -> spire.server.datastore.PruneRegistrationEntriesRequest
+	71,  // 101: spire.server.datastore.DataStore.BatchRegistrationEntries:input_type -> spire.server.datastore.BatchRegistrationEntriesRequest
+	60,  // 102: spire.server.datastore.DataStore.CreateJoinToken:input_type -> spire.server.datastore.CreateJoinTokenRequest
+	62,  // 103: spire.server.datastore.DataStore.FetchJoinToken:input_type -> spire.server.datastore.FetchJoinTokenRequest
+	64,  // 104: spire.server.datastore.DataStore.DeleteJoinToken:input_type -> spire.server.datastore.DeleteJoinTokenRequest
+	66,  // 105: spire.server.datastore.DataStore.UseJoinToken:input_type -> spire.server.datastore.UseJoinTokenRequest
+	68,  // 106: spire.server.datastore.DataStore.PruneJoinTokens:input_type -> spire.server.datastore.PruneJoinTokensRequest
+	73,  // 107: spire.server.datastore.DataStore.ListJoinTokens:input_type -> spire.server.datastore.ListJoinTokensRequest
+	76,  // 108: spire.server.datastore.DataStore.CheckIntegrity:input_type -> spire.server.datastore.CheckIntegrityRequest
+	90,  // 109: spire.server.datastore.DataStore.Configure:input_type -> spire.common.plugin.ConfigureRequest
+	91,  // 110: spire.server.datastore.DataStore.GetPluginInfo:input_type -> spire.common.plugin.GetPluginInfoRequest
+	5,   // 111: spire.server.datastore.DataStore.CreateBundle:output_type -> spire.server.datastore.CreateBundleResponse
+	7,   // 112: spire.server.datastore.DataStore.FetchBundle:output_type -> spire.server.datastore.FetchBundleResponse
+	9,   // 113: spire.server.datastore.DataStore.CountBundles:output_type -> spire.server.datastore.CountBundlesResponse
+	11,  // 114: spire.server.datastore.DataStore.ListBundles:output_type -> spire.server.datastore.ListBundlesResponse
+	14,  // 115: spire.server.datastore.DataStore.UpdateBundle:output_type -> spire.server.datastore.UpdateBundleResponse
+	16,  // 116: spire.server.datastore.DataStore.SetBundle:output_type -> spire.server.datastore.SetBundleResponse
+	18,  // 117: spire.server.datastore.DataStore.AppendBundle:output_type -> spire.server.datastore.AppendBundleResponse
+	20,  // 118: spire.server.datastore.DataStore.DeleteBundle:output_type -> spire.server.datastore.DeleteBundleResponse
+	22,  // 119: spire.server.datastore.DataStore.PruneBundle:output_type -> spire.server.datastore.PruneBundleResponse
+	30,  // 120: spire.server.datastore.DataStore.CreateAttestedNode:output_type -> spire.server.datastore.CreateAttestedNodeResponse
+	32,  // 121: spire.server.datastore.DataStore.FetchAttestedNode:output_type -> spire.server.datastore.FetchAttestedNodeResponse
+	34,  // 122: spire.server.datastore.DataStore.CountAttestedNodes:output_type -> spire.server.datastore.CountAttestedNodesResponse
+	37,  // 123: spire.server.datastore.DataStore.ListAttestedNodes:output_type -> spire.server.datastore.ListAttestedNodesResponse
+	39,  // 124: spire.server.datastore.DataStore.UpdateAttestedNode:output_type -> spire.server.datastore.UpdateAttestedNodeResponse
+	41,  // 125: spire.server.datastore.DataStore.DeleteAttestedNode:output_type -> spire.server.datastore.DeleteAttestedNodeResponse
+	25,  // 126: spire.server.datastore.DataStore.SetNodeSelectors:output_type -> spire.server.datastore.SetNodeSelectorsResponse
+	27,  // 127: spire.server.datastore.DataStore.GetNodeSelectors:output_type -> spire.server.datastore.GetNodeSelectorsResponse
+	29,  // 128: spire.server.datastore.DataStore.ListNodeSelectors:output_type -> spire.server.datastore.ListNodeSelectorsResponse
+	43,  // 129: spire.server.datastore.DataStore.CreateRegistrationEntry:output_type -> spire.server.datastore.CreateRegistrationEntryResponse
+	45,  // 130: spire.server.datastore.DataStore.FetchRegistrationEntry:output_type -> spire.server.datastore.FetchRegistrationEntryResponse
+	50,  // 131: spire.server.datastore.DataStore.CountRegistrationEntries:output_type -> spire.server.datastore.CountRegistrationEntriesResponse
+	52,  // 132: spire.server.datastore.DataStore.ListRegistrationEntries:output_type -> spire.server.datastore.ListRegistrationEntriesResponse
+	54,  // 133: spire.server.datastore.DataStore.UpdateRegistrationEntry:output_type -> spire.server.datastore.UpdateRegistrationEntryResponse
+	56,  // 134: spire.server.datastore.DataStore.DeleteRegistrationEntry:output_type -> spire.server.datastore.DeleteRegistrationEntryResponse
+	58,  // 135: spire.server.datastore.DataStore.PruneRegistrationEntries:output_type -> spire.server.datastore.PruneRegistrationEntriesResponse
+	72,  // 136: spire.server.datastore.DataStore.BatchRegistrationEntries:output_type -> spire.server.datastore.BatchRegistrationEntriesResponse
+	61,  // 137: spire.server.datastore.DataStore.CreateJoinToken:output_type -> spire.server.datastore.CreateJoinTokenResponse
+	63,  // 138: spire.server.datastore.DataStore.FetchJoinToken:output_type -> spire.server.datastore.FetchJoinTokenResponse
+	65,  // 139: spire.server.datastore.DataStore.DeleteJoinToken:output_type -> spire.server.datastore.DeleteJoinTokenResponse
+	67,  // 140: spire.server.datastore.DataStore.UseJoinToken:output_type -> spire.server.datastore.UseJoinTokenResponse
+	69,  // 141: spire.server.datastore.DataStore.PruneJoinTokens:output_type -> spire.server.datastore.PruneJoinTokensResponse
+	74,  // 142: spire.server.datastore.DataStore.ListJoinTokens:output_type -> spire.server.datastore.ListJoinTokensResponse
+	77,  // 143: spire.server.datastore.DataStore.CheckIntegrity:output_type -> spire.server.datastore.CheckIntegrityResponse
+	92,  // 144: spire.server.datastore.DataStore.Configure:output_type -> spire.common.plugin.ConfigureResponse
+	93,  // 145: spire.server.datastore.DataStore.GetPluginInfo:output_type -> spire.common.plugin.GetPluginInfoResponse
+	111, // [111:146] is the sub-list for method output_type
+	76,  // [76:111] is the sub-list for method input_type
+	76,  // [76:76] is the sub-list for extension type_name
+	76,  // [76:76] is the sub-list for extension extendee
+	0,   // [0:76] is the sub-list for field type_name
 }
 
 func init() { file_spire_server_datastore_datastore_proto_init() }
@@ -4281,7 +5296,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateBundleRequest); i {
+			switch v := v.(*BundleLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4293,7 +5308,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateBundleResponse); i {
+			switch v := v.(*UpdateBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4305,7 +5320,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetBundleRequest); i {
+			switch v := v.(*UpdateBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4317,7 +5332,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetBundleResponse); i {
+			switch v := v.(*SetBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4329,7 +5344,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendBundleRequest); i {
+			switch v := v.(*SetBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4341,7 +5356,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendBundleResponse); i {
+			switch v := v.(*AppendBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4353,7 +5368,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteBundleRequest); i {
+			switch v := v.(*AppendBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4365,7 +5380,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteBundleResponse); i {
+			switch v := v.(*DeleteBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4377,7 +5392,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruneBundleRequest); i {
+			switch v := v.(*DeleteBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4389,7 +5404,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruneBundleResponse); i {
+			switch v := v.(*PruneBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4401,7 +5416,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeSelectors); i {
+			switch v := v.(*PruneBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4413,7 +5428,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetNodeSelectorsRequest); i {
+			switch v := v.(*NodeSelectors); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4425,7 +5440,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetNodeSelectorsResponse); i {
+			switch v := v.(*SetNodeSelectorsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4437,7 +5452,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodeSelectorsRequest); i {
+			switch v := v.(*SetNodeSelectorsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4449,7 +5464,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodeSelectorsResponse); i {
+			switch v := v.(*GetNodeSelectorsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4461,7 +5476,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListNodeSelectorsRequest); i {
+			switch v := v.(*GetNodeSelectorsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4473,7 +5488,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListNodeSelectorsResponse); i {
+			switch v := v.(*ListNodeSelectorsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4485,7 +5500,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAttestedNodeResponse); i {
+			switch v := v.(*ListNodeSelectorsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4497,7 +5512,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchAttestedNodeRequest); i {
+			switch v := v.(*CreateAttestedNodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4509,7 +5524,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchAttestedNodeResponse); i {
+			switch v := v.(*FetchAttestedNodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4521,7 +5536,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountAttestedNodesRequest); i {
+			switch v := v.(*FetchAttestedNodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4533,7 +5548,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountAttestedNodesResponse); i {
+			switch v := v.(*CountAttestedNodesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4545,7 +5560,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAttestedNodeRequest); i {
+			switch v := v.(*CountAttestedNodesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4557,7 +5572,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAttestedNodesRequest); i {
+			switch v := v.(*CreateAttestedNodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4569,7 +5584,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAttestedNodesResponse); i {
+			switch v := v.(*ListAttestedNodesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4581,7 +5596,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAttestedNodeRequest); i {
+			switch v := v.(*ListAttestedNodesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4593,7 +5608,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAttestedNodeResponse); i {
+			switch v := v.(*UpdateAttestedNodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4605,7 +5620,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAttestedNodeRequest); i {
+			switch v := v.(*UpdateAttestedNodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4617,7 +5632,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAttestedNodeResponse); i {
+			switch v := v.(*DeleteAttestedNodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4629,7 +5644,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRegistrationEntryRequest); i {
+			switch v := v.(*DeleteAttestedNodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4641,7 +5656,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRegistrationEntryResponse); i {
+			switch v := v.(*CreateRegistrationEntryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4653,7 +5668,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchRegistrationEntryRequest); i {
+			switch v := v.(*CreateRegistrationEntryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4665,7 +5680,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchRegistrationEntryResponse); i {
+			switch v := v.(*FetchRegistrationEntryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4677,7 +5692,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BySelectors); i {
+			switch v := v.(*FetchRegistrationEntryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4689,7 +5704,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ByFederatesWith); i {
+			switch v := v.(*BySelectors); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4701,7 +5716,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pagination); i {
+			switch v := v.(*ByFederatesWith); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4713,7 +5728,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountRegistrationEntriesRequest); i {
+			switch v := v.(*Pagination); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4725,7 +5740,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountRegistrationEntriesResponse); i {
+			switch v := v.(*CountRegistrationEntriesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4737,7 +5752,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListRegistrationEntriesRequest); i {
+			switch v := v.(*CountRegistrationEntriesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4749,7 +5764,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListRegistrationEntriesResponse); i {
+			switch v := v.(*ListRegistrationEntriesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4761,7 +5776,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRegistrationEntryRequest); i {
+			switch v := v.(*ListRegistrationEntriesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4773,7 +5788,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRegistrationEntryResponse); i {
+			switch v := v.(*UpdateRegistrationEntryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4785,7 +5800,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRegistrationEntryRequest); i {
+			switch v := v.(*UpdateRegistrationEntryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4797,7 +5812,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRegistrationEntryResponse); i {
+			switch v := v.(*DeleteRegistrationEntryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4809,7 +5824,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruneRegistrationEntriesRequest); i {
+			switch v := v.(*DeleteRegistrationEntryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4821,7 +5836,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruneRegistrationEntriesResponse); i {
+			switch v := v.(*PruneRegistrationEntriesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4833,7 +5848,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinToken); i {
+			switch v := v.(*PruneRegistrationEntriesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4845,7 +5860,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateJoinTokenRequest); i {
+			switch v := v.(*JoinToken); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4857,7 +5872,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateJoinTokenResponse); i {
+			switch v := v.(*CreateJoinTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4869,7 +5884,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchJoinTokenRequest); i {
+			switch v := v.(*CreateJoinTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4881,7 +5896,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchJoinTokenResponse); i {
+			switch v := v.(*FetchJoinTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4893,7 +5908,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteJoinTokenRequest); i {
+			switch v := v.(*FetchJoinTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4905,7 +5920,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteJoinTokenResponse); i {
+			switch v := v.(*DeleteJoinTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4917,7 +5932,7 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PruneJoinTokensRequest); i {
+			switch v := v.(*DeleteJoinTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4929,6 +5944,42 @@ func file_spire_server_datastore_datastore_proto_init() {
 			}
 		}
 		file_spire_server_datastore_datastore_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UseJoinTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UseJoinTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneJoinTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PruneJoinTokensResponse); i {
 			case 0:
 				return &v.state
@@ -4940,14 +5991,110 @@ func file_spire_server_datastore_datastore_proto_init() {
 				return nil
 			}
 		}
+		file_spire_server_datastore_datastore_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistrationEntryOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRegistrationEntriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRegistrationEntriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJoinTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJoinTokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegrityIssue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckIntegrityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_server_datastore_datastore_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckIntegrityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_spire_server_datastore_datastore_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   63,
+			NumEnums:      4,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
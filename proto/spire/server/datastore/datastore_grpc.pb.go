@@ -68,14 +68,24 @@ type DataStoreClient interface {
 	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
 	// Prunes all registration entries that expire before the specified timestamp
 	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
+	// Applies a batch of registration entry creates, updates and deletes in a
+	// single transaction. Either all of the operations are applied or none.
+	BatchRegistrationEntries(ctx context.Context, in *BatchRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchRegistrationEntriesResponse, error)
 	// Creates a join token
 	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
 	// Fetches a specific join token
 	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Marks a specific join token as used. Fails if the token does not
+	// exist or has already been used.
+	UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Lists join tokens (optionally filtered)
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// Checks the datastore for inconsistencies, optionally repairing them
+	CheckIntegrity(ctx context.Context, in *CheckIntegrityRequest, opts ...grpc.CallOption) (*CheckIntegrityResponse, error)
 	// Applies the plugin configuration
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -315,6 +325,15 @@ func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *Prun
 	return out, nil
 }
 
+func (c *dataStoreClient) BatchRegistrationEntries(ctx context.Context, in *BatchRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchRegistrationEntriesResponse, error) {
+	out := new(BatchRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/BatchRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
 	out := new(CreateJoinTokenResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
@@ -342,6 +361,15 @@ func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTok
 	return out, nil
 }
 
+func (c *dataStoreClient) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error) {
+	out := new(UseJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UseJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
 	out := new(PruneJoinTokensResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
@@ -351,6 +379,24 @@ func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinToke
 	return out, nil
 }
 
+func (c *dataStoreClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CheckIntegrity(ctx context.Context, in *CheckIntegrityRequest, opts ...grpc.CallOption) (*CheckIntegrityResponse, error) {
+	out := new(CheckIntegrityResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CheckIntegrity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, opts...)
@@ -423,14 +469,24 @@ type DataStoreServer interface {
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	// Prunes all registration entries that expire before the specified timestamp
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	// Applies a batch of registration entry creates, updates and deletes in a
+	// single transaction. Either all of the operations are applied or none.
+	BatchRegistrationEntries(context.Context, *BatchRegistrationEntriesRequest) (*BatchRegistrationEntriesResponse, error)
 	// Creates a join token
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	// Fetches a specific join token
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Marks a specific join token as used. Fails if the token does not
+	// exist or has already been used.
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Lists join tokens (optionally filtered)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	// Checks the datastore for inconsistencies, optionally repairing them
+	CheckIntegrity(context.Context, *CheckIntegrityRequest) (*CheckIntegrityResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -517,6 +573,9 @@ func (UnimplementedDataStoreServer) DeleteRegistrationEntry(context.Context, *De
 func (UnimplementedDataStoreServer) PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneRegistrationEntries not implemented")
 }
+func (UnimplementedDataStoreServer) BatchRegistrationEntries(context.Context, *BatchRegistrationEntriesRequest) (*BatchRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRegistrationEntries not implemented")
+}
 func (UnimplementedDataStoreServer) CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateJoinToken not implemented")
 }
@@ -526,9 +585,18 @@ func (UnimplementedDataStoreServer) FetchJoinToken(context.Context, *FetchJoinTo
 func (UnimplementedDataStoreServer) DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
 }
+func (UnimplementedDataStoreServer) UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseJoinToken not implemented")
+}
 func (UnimplementedDataStoreServer) PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
 }
+func (UnimplementedDataStoreServer) ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinTokens not implemented")
+}
+func (UnimplementedDataStoreServer) CheckIntegrity(context.Context, *CheckIntegrityRequest) (*CheckIntegrityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIntegrity not implemented")
+}
 func (UnimplementedDataStoreServer) Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
@@ -998,6 +1066,24 @@ func _DataStore_PruneRegistrationEntries_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_BatchRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).BatchRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/BatchRegistrationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).BatchRegistrationEntries(ctx, req.(*BatchRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateJoinTokenRequest)
 	if err := dec(in); err != nil {
@@ -1052,6 +1138,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UseJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UseJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UseJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UseJoinToken(ctx, req.(*UseJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -1070,6 +1174,42 @@ func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CheckIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CheckIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CheckIntegrity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CheckIntegrity(ctx, req.(*CheckIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -1210,6 +1350,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PruneRegistrationEntries",
 			Handler:    _DataStore_PruneRegistrationEntries_Handler,
 		},
+		{
+			MethodName: "BatchRegistrationEntries",
+			Handler:    _DataStore_BatchRegistrationEntries_Handler,
+		},
 		{
 			MethodName: "CreateJoinToken",
 			Handler:    _DataStore_CreateJoinToken_Handler,
@@ -1222,10 +1366,22 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "UseJoinToken",
+			Handler:    _DataStore_UseJoinToken_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _DataStore_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "CheckIntegrity",
+			Handler:    _DataStore_CheckIntegrity_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
@@ -0,0 +1,1115 @@
+// Package datastoretest provides a conformance test suite for datastore
+// implementations. Authors of datastore plugins can run the suite against
+// their implementation to verify that it has the semantics expected by the
+// rest of SPIRE server, e.g.:
+//
+//	func TestConformance(t *testing.T) {
+//		datastoretest.Run(t, func(t *testing.T) datastore.DataStore {
+//			return newConfiguredPlugin(t)
+//		})
+//	}
+//
+// The suite only asserts the behavior SPIRE server relies on. In particular,
+// error messages and pagination tokens are treated as opaque; only gRPC
+// status codes and the presence or absence of a token are checked.
+package datastoretest
+
+import (
+	"context"
+	"crypto/x509"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// NewDataStoreFunc returns a new, empty and fully configured datastore. It is
+// called once for each test in the suite. Any cleanup should be registered
+// with t.Cleanup.
+type NewDataStoreFunc = func(t *testing.T) datastore.DataStore
+
+// Run runs the conformance test suite against the datastores returned by
+// newDataStore.
+func Run(t *testing.T, newDataStore NewDataStoreFunc) {
+	for _, tt := range []struct {
+		name string
+		fn   func(t *testing.T, ds datastore.DataStore)
+	}{
+		{name: "BundleCRUD", fn: testBundleCRUD},
+		{name: "BundleLimits", fn: testBundleLimits},
+		{name: "BundleJWTKeyConflicts", fn: testBundleJWTKeyConflicts},
+		{name: "BundlePagination", fn: testBundlePagination},
+		{name: "EntryCRUD", fn: testEntryCRUD},
+		{name: "EntryWithCallerSuppliedID", fn: testEntryWithCallerSuppliedID},
+		{name: "EntryFilters", fn: testEntryFilters},
+		{name: "EntryPagination", fn: testEntryPagination},
+		{name: "EntryBatch", fn: testEntryBatch},
+		{name: "NodeCRUD", fn: testNodeCRUD},
+		{name: "NodeSelectors", fn: testNodeSelectors},
+		{name: "NodePagination", fn: testNodePagination},
+		{name: "JoinTokens", fn: testJoinTokens},
+		{name: "Integrity", fn: testIntegrity},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newDataStore(t))
+		})
+	}
+}
+
+func testBundleCRUD(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+	certA, certB := newCertificate(t), newCertificate(t)
+	bundle := bundleutil.BundleProtoFromRootCA("spiffe://example.org", certA)
+
+	// Fetching a bundle that does not exist is not an error
+	fetchResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Bundle)
+
+	// Updating or deleting a bundle that does not exist fails with NotFound
+	_, err = ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: bundle})
+	requireCode(t, err, codes.NotFound)
+	_, err = ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	requireCode(t, err, codes.NotFound)
+
+	// Create, then create again fails with AlreadyExists
+	createResp, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, createResp.Bundle)
+	_, err = ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	requireCode(t, err, codes.AlreadyExists)
+
+	fetchResp, err = ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, fetchResp.Bundle)
+
+	// Appending adds new root CAs only once, incrementing the sequence
+	// number only when the bundle changes
+	appended := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId, []*x509.Certificate{certA, certB})
+	appended.SequenceNumber = 1
+	for i := 0; i < 2; i++ {
+		appendResp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+			Bundle: bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certB),
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoEqual(t, appended, appendResp.Bundle)
+	}
+
+	// Appending to a bundle that does not exist creates it
+	other := bundleutil.BundleProtoFromRootCA("spiffe://other.org", certB)
+	appendResp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: other})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, other, appendResp.Bundle)
+
+	// Update honors the mask
+	update := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certA)
+	update.RefreshHint = 60
+	updateResp, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle:    update,
+		InputMask: &common.BundleMask{RefreshHint: true},
+	})
+	require.NoError(t, err)
+	appended.RefreshHint = 60
+	appended.SequenceNumber = 2
+	spiretest.RequireProtoEqual(t, appended, updateResp.Bundle)
+
+	// Appending with a refresh hint sets it in the same write, incrementing
+	// the sequence number once
+	appendResp, err = ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle:      bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certB),
+		RefreshHint: wrapperspb.Int64(120),
+	})
+	require.NoError(t, err)
+	appended.RefreshHint = 120
+	appended.SequenceNumber = 3
+	spiretest.RequireProtoEqual(t, appended, appendResp.Bundle)
+
+	// Set overwrites the bundle, except for the sequence number, which keeps
+	// increasing
+	setResp, err := ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+	bundle = bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certA)
+	bundle.SequenceNumber = 4
+	spiretest.RequireProtoEqual(t, bundle, setResp.Bundle)
+
+	countResp, err := ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), countResp.Bundles)
+
+	deleteResp, err := ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: other.TrustDomainId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, other, deleteResp.Bundle)
+
+	listResp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{bundle}, listResp.Bundles)
+}
+
+func testBundleLimits(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+	certA, certB := newCertificate(t), newCertificate(t)
+	bundle := bundleutil.BundleProtoFromRootCA("spiffe://example.org", certA)
+	both := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId, []*x509.Certificate{certA, certB})
+	limits := &datastore.BundleLimits{MaxX509Authorities: 1}
+
+	// Bundles created by appends and sets are checked
+	_, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: both, Limits: limits})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: both, Limits: limits})
+	requireCode(t, err, codes.InvalidArgument)
+
+	_, err = ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: bundle, Limits: limits})
+	require.NoError(t, err)
+
+	// Appends are checked against the bundle resulting from the append, so
+	// appending authorities already in the bundle is allowed. The refresh
+	// hint of a rejected append is not set.
+	_, err = ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle:      bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, certB),
+		Limits:      limits,
+		RefreshHint: wrapperspb.Int64(60),
+	})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: bundle, Limits: limits})
+	require.NoError(t, err)
+
+	// Updates are checked against the updated bundle, including the fields
+	// not in the mask
+	_, err = ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  bundle.TrustDomainId,
+			JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key")}},
+		},
+		InputMask: &common.BundleMask{JwtSigningKeys: true},
+		Limits:    &datastore.BundleLimits{MaxBundleBytes: int32(proto.Size(bundle)) + 8},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: both, Limits: limits})
+	requireCode(t, err, codes.InvalidArgument)
+
+	fetchResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, fetchResp.Bundle)
+}
+
+func testBundleJWTKeyConflicts(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+	bundle := &common.Bundle{
+		TrustDomainId:  "spiffe://example.org",
+		JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key"), NotAfter: 1}},
+	}
+	_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+
+	// Appending a different key with the key ID of an existing one fails
+	_, err = ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  bundle.TrustDomainId,
+			JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("other")}},
+		},
+	})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	// Appending an existing key does not change the bundle, even if its
+	// expiration differs
+	appendResp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  bundle.TrustDomainId,
+			JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key"), NotAfter: 2}},
+		},
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, appendResp.Bundle)
+
+	fetchResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, fetchResp.Bundle)
+}
+
+func testBundlePagination(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+	cert := newCertificate(t)
+
+	var expected []*common.Bundle
+	for _, td := range []string{"spiffe://a.org", "spiffe://b.org", "spiffe://c.org"} {
+		bundle := bundleutil.BundleProtoFromRootCA(td, cert)
+		_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+		require.NoError(t, err)
+		expected = append(expected, bundle)
+	}
+
+	actual := paginate(t, func(pagination *datastore.Pagination) (*datastore.Pagination, int, error) {
+		resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{Pagination: pagination})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Pagination, len(resp.Bundles), nil
+	})
+	assert.Equal(t, len(expected), actual)
+
+	_, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{PageSize: 0},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{Token: "invalid token", PageSize: 1},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func testEntryCRUD(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	// Fetching an entry that does not exist is not an error
+	fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: "missing"})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Entry)
+
+	// Updating or deleting an entry that does not exist fails with NotFound
+	_, err = ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:   "missing",
+			SpiffeId:  "spiffe://example.org/workload",
+			ParentId:  "spiffe://example.org/agent",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	requireCode(t, err, codes.NotFound)
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: "missing"})
+	requireCode(t, err, codes.NotFound)
+
+	// Entries without selectors are invalid
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId: "spiffe://example.org/workload",
+			ParentId: "spiffe://example.org/agent",
+		},
+	})
+	require.Error(t, err)
+
+	entry := &common.RegistrationEntry{
+		SpiffeId:    "spiffe://example.org/workload",
+		ParentId:    "spiffe://example.org/agent",
+		Selectors:   []*common.Selector{{Type: "unix", Value: "uid:1000"}, {Type: "unix", Value: "gid:1000"}},
+		Ttl:         60,
+		DnsNames:    []string{"workload.example.org"},
+		Admin:       true,
+		EntryExpiry: time.Now().Add(time.Hour).Unix(),
+	}
+	createResp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: entry})
+	require.NoError(t, err)
+	created := createResp.Entry
+	require.NotEmpty(t, created.EntryId, "created entry has no ID")
+
+	fetchResp, err = ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: created.EntryId})
+	require.NoError(t, err)
+	requireEntryEqual(t, created, fetchResp.Entry)
+
+	// Update honors the mask
+	update := cloneEntry(created)
+	update.Ttl = 120
+	update.Admin = false
+	updateResp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: update,
+		Mask:  &common.RegistrationEntryMask{Ttl: true},
+	})
+	require.NoError(t, err)
+	expected := cloneEntry(created)
+	expected.Ttl = 120
+	expected.RevisionNumber = updateResp.Entry.RevisionNumber
+	requireEntryEqual(t, expected, updateResp.Entry)
+	require.Greater(t, updateResp.Entry.RevisionNumber, created.RevisionNumber, "revision number was not incremented")
+
+	countResp, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), countResp.Entries)
+
+	// Pruning only removes expired entries
+	_, err = ds.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{ExpiresBefore: time.Now().Unix()})
+	require.NoError(t, err)
+	fetchResp, err = ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: created.EntryId})
+	require.NoError(t, err)
+	require.NotNil(t, fetchResp.Entry, "unexpired entry was pruned")
+
+	deleteResp, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: created.EntryId})
+	require.NoError(t, err)
+	requireEntryEqual(t, expected, deleteResp.Entry)
+
+	fetchResp, err = ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: created.EntryId})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Entry)
+}
+
+func testEntryWithCallerSuppliedID(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	entry := &common.RegistrationEntry{
+		EntryId:   "0e4e3ac8-3a5c-4b8b-9d4c-3f2a5f1c7a01",
+		SpiffeId:  "spiffe://example.org/workload",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: unixSelectors("uid:1000"),
+	}
+	created := createEntryFromProto(t, ds, entry)
+	require.Equal(t, entry.EntryId, created.EntryId)
+
+	// The entry ID is unique
+	other := cloneEntry(entry)
+	other.SpiffeId = "spiffe://example.org/other"
+	_, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: other})
+	requireCode(t, err, codes.AlreadyExists)
+
+	// Also when created in a batch, whether the entry already exists or is
+	// created earlier in the same batch
+	_, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: other}},
+		},
+	})
+	requireCode(t, err, codes.AlreadyExists)
+
+	batched := cloneEntry(other)
+	batched.EntryId = "0e4e3ac8-3a5c-4b8b-9d4c-3f2a5f1c7a02"
+	_, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: batched}},
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: batched}},
+		},
+	})
+	requireCode(t, err, codes.AlreadyExists)
+
+	fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: batched.EntryId})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Entry)
+
+	fetchResp, err = ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	require.NoError(t, err)
+	requireEntryEqual(t, created, fetchResp.Entry)
+}
+
+func testEntryFilters(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	a := createEntry(t, ds, "spiffe://example.org/a", "spiffe://example.org/agent1", "uid:1")
+	b := createEntry(t, ds, "spiffe://example.org/b", "spiffe://example.org/agent1", "uid:1", "gid:1")
+	c := createEntry(t, ds, "spiffe://example.org/c", "spiffe://example.org/agent2", "uid:2")
+
+	for _, td := range []string{"spiffe://fed1.test", "spiffe://fed2.test"} {
+		_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: bundleutil.BundleProtoFromRootCA(td, newCertificate(t)),
+		})
+		require.NoError(t, err)
+	}
+	expiry := time.Now().Add(time.Hour).Unix()
+	d := createEntryFromProto(t, ds, &common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/d",
+		ParentId:      "spiffe://example.org/agent3",
+		Selectors:     unixSelectors("uid:3"),
+		Ttl:           60,
+		EntryExpiry:   expiry,
+		FederatesWith: []string{"spiffe://fed1.test"},
+	})
+	e := createEntryFromProto(t, ds, &common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/e",
+		ParentId:      "spiffe://example.org/agent3",
+		Selectors:     unixSelectors("uid:4"),
+		Ttl:           3600,
+		FederatesWith: []string{"spiffe://fed1.test", "spiffe://fed2.test"},
+	})
+
+	for _, tt := range []struct {
+		name   string
+		req    *datastore.ListRegistrationEntriesRequest
+		expect []*common.RegistrationEntry
+	}{
+		{
+			name:   "all",
+			req:    &datastore.ListRegistrationEntriesRequest{},
+			expect: []*common.RegistrationEntry{a, b, c, d, e},
+		},
+		{
+			name: "by parent ID",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId: &wrapperspb.StringValue{Value: "spiffe://example.org/agent1"},
+			},
+			expect: []*common.RegistrationEntry{a, b},
+		},
+		{
+			name: "by SPIFFE ID",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySpiffeId: &wrapperspb.StringValue{Value: "spiffe://example.org/c"},
+			},
+			expect: []*common.RegistrationEntry{c},
+		},
+		{
+			name: "by exact selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: unixSelectors("uid:1"),
+					Match:     datastore.BySelectors_MATCH_EXACT,
+				},
+			},
+			expect: []*common.RegistrationEntry{a},
+		},
+		{
+			name: "by subset selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: unixSelectors("uid:1", "gid:1"),
+					Match:     datastore.BySelectors_MATCH_SUBSET,
+				},
+			},
+			expect: []*common.RegistrationEntry{a, b},
+		},
+		{
+			name: "by parent ID and selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId: &wrapperspb.StringValue{Value: "spiffe://example.org/agent2"},
+				BySelectors: &datastore.BySelectors{
+					Selectors: unixSelectors("uid:1"),
+					Match:     datastore.BySelectors_MATCH_SUBSET,
+				},
+			},
+			expect: nil,
+		},
+		{
+			name: "by exact federates with",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: &datastore.ByFederatesWith{
+					TrustDomains: []string{"spiffe://fed1.test"},
+					Match:        datastore.ByFederatesWith_MATCH_EXACT,
+				},
+			},
+			expect: []*common.RegistrationEntry{d},
+		},
+		{
+			name: "by subset federates with",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: &datastore.ByFederatesWith{
+					TrustDomains: []string{"spiffe://fed1.test", "spiffe://fed2.test"},
+					Match:        datastore.ByFederatesWith_MATCH_SUBSET,
+				},
+			},
+			expect: []*common.RegistrationEntry{d, e},
+		},
+		{
+			name: "by any federates with",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: &datastore.ByFederatesWith{
+					TrustDomains: []string{"spiffe://fed2.test"},
+					Match:        datastore.ByFederatesWith_MATCH_ANY,
+				},
+			},
+			expect: []*common.RegistrationEntry{e},
+		},
+		{
+			name: "by min TTL",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByMinTtl: &wrapperspb.Int32Value{Value: 60},
+			},
+			expect: []*common.RegistrationEntry{d, e},
+		},
+		{
+			name: "by max TTL",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByMaxTtl: &wrapperspb.Int32Value{Value: 60},
+			},
+			expect: []*common.RegistrationEntry{a, b, c, d},
+		},
+		{
+			name: "by TTL range",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByMinTtl: &wrapperspb.Int32Value{Value: 61},
+				ByMaxTtl: &wrapperspb.Int32Value{Value: 3600},
+			},
+			expect: []*common.RegistrationEntry{e},
+		},
+		{
+			name: "by expires before",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByExpiresBefore: &wrapperspb.Int64Value{Value: expiry + 1},
+			},
+			expect: []*common.RegistrationEntry{d},
+		},
+		{
+			name: "by expires before is exclusive",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByExpiresBefore: &wrapperspb.Int64Value{Value: expiry},
+			},
+			expect: nil,
+		},
+		{
+			name: "by parent ID and any federates with",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId: &wrapperspb.StringValue{Value: "spiffe://example.org/agent3"},
+				ByFederatesWith: &datastore.ByFederatesWith{
+					TrustDomains: []string{"spiffe://fed1.test"},
+					Match:        datastore.ByFederatesWith_MATCH_ANY,
+				},
+				ByMinTtl: &wrapperspb.Int32Value{Value: 3600},
+			},
+			expect: []*common.RegistrationEntry{e},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ds.ListRegistrationEntries(ctx, tt.req)
+			require.NoError(t, err)
+			requireEntriesEqual(t, tt.expect, resp.Entries)
+		})
+	}
+
+	_, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySelectors: &datastore.BySelectors{},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func testEntryPagination(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	expected := []*common.RegistrationEntry{
+		createEntry(t, ds, "spiffe://example.org/a", "spiffe://example.org/agent", "uid:1"),
+		createEntry(t, ds, "spiffe://example.org/b", "spiffe://example.org/agent", "uid:1"),
+		createEntry(t, ds, "spiffe://example.org/c", "spiffe://example.org/agent", "uid:1"),
+	}
+
+	var actual []*common.RegistrationEntry
+	paginate(t, func(pagination *datastore.Pagination) (*datastore.Pagination, int, error) {
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySelectors: &datastore.BySelectors{
+				Selectors: unixSelectors("uid:1"),
+				Match:     datastore.BySelectors_MATCH_EXACT,
+			},
+			Pagination: pagination,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		actual = append(actual, resp.Entries...)
+		return resp.Pagination, len(resp.Entries), nil
+	})
+	requireEntriesEqual(t, expected, actual)
+
+	_, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{PageSize: 0},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{Token: "invalid token", PageSize: 1},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func testEntryBatch(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	entry1 := createEntry(t, ds, "spiffe://example.org/workload1", "spiffe://example.org/agent", "uid:1001")
+	entry2 := createEntry(t, ds, "spiffe://example.org/workload2", "spiffe://example.org/agent", "uid:1002")
+
+	newEntry := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload3",
+		ParentId:  "spiffe://example.org/agent",
+		Selectors: unixSelectors("uid:1003"),
+	}
+
+	// A failing operation prevents all of the operations from being applied
+	_, err := ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: newEntry}},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: entry2.EntryId}},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: "missing"}},
+		},
+	})
+	requireCode(t, err, codes.NotFound)
+
+	listResp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	requireEntriesEqual(t, []*common.RegistrationEntry{entry1, entry2}, listResp.Entries)
+
+	// Otherwise all of the operations are applied and the resulting entries
+	// are returned in the order of the operations. Deletes return the deleted
+	// entry.
+	update := cloneEntry(entry1)
+	update.Ttl = 120
+	batchResp, err := ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: newEntry}},
+			{Update: &datastore.UpdateRegistrationEntryRequest{
+				Entry: update,
+				Mask:  &common.RegistrationEntryMask{Ttl: true},
+			}},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: entry2.EntryId}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, batchResp.Entries, 3)
+
+	created := batchResp.Entries[0]
+	require.NotEmpty(t, created.EntryId, "created entry has no ID")
+	expectedCreated := cloneEntry(newEntry)
+	expectedCreated.EntryId = created.EntryId
+	requireEntryEqual(t, expectedCreated, created)
+
+	updated := batchResp.Entries[1]
+	require.Equal(t, int32(120), updated.Ttl)
+	require.Greater(t, updated.RevisionNumber, entry1.RevisionNumber, "revision number was not incremented")
+
+	requireEntryEqual(t, entry2, batchResp.Entries[2])
+
+	listResp, err = ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	requireEntriesEqual(t, []*common.RegistrationEntry{created, updated}, listResp.Entries)
+
+	// Later operations observe the effects of earlier ones
+	_, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: created.EntryId}},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: created.EntryId}},
+		},
+	})
+	requireCode(t, err, codes.NotFound)
+
+	batchResp, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Update: &datastore.UpdateRegistrationEntryRequest{
+				Entry: &common.RegistrationEntry{EntryId: created.EntryId, Admin: true},
+				Mask:  &common.RegistrationEntryMask{Admin: true},
+			}},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: created.EntryId}},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, batchResp.Entries[1].Admin, "delete did not observe the update")
+
+	fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: created.EntryId})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Entry)
+
+	// Updates and deletes can be conditioned on the revision number of the
+	// entry
+	_, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Create: &datastore.CreateRegistrationEntryRequest{Entry: newEntry}, IfRevisionNumber: wrapperspb.Int64(0)},
+		},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+
+	_, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: updated.EntryId}, IfRevisionNumber: wrapperspb.Int64(entry1.RevisionNumber)},
+		},
+	})
+	requireCode(t, err, codes.Aborted)
+
+	batchResp, err = ds.BatchRegistrationEntries(ctx, &datastore.BatchRegistrationEntriesRequest{
+		Operations: []*datastore.RegistrationEntryOperation{
+			{Update: &datastore.UpdateRegistrationEntryRequest{
+				Entry: &common.RegistrationEntry{EntryId: updated.EntryId, Ttl: 60},
+				Mask:  &common.RegistrationEntryMask{Ttl: true},
+			}, IfRevisionNumber: wrapperspb.Int64(updated.RevisionNumber)},
+			{Delete: &datastore.DeleteRegistrationEntryRequest{EntryId: updated.EntryId}, IfRevisionNumber: wrapperspb.Int64(updated.RevisionNumber + 1)},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(60), batchResp.Entries[1].Ttl)
+}
+
+func testNodeCRUD(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	// Fetching a node that does not exist is not an error
+	fetchResp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: "spiffe://example.org/missing"})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Node)
+
+	// Updating or deleting a node that does not exist fails with NotFound
+	_, err = ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{SpiffeId: "spiffe://example.org/missing"})
+	requireCode(t, err, codes.NotFound)
+	_, err = ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: "spiffe://example.org/missing"})
+	requireCode(t, err, codes.NotFound)
+
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/test/node",
+		AttestationDataType: "test",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	createResp, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, node, createResp.Node)
+
+	fetchResp, err = ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, node, fetchResp.Node)
+
+	// Update honors the mask
+	updateResp, err := ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         node.SpiffeId,
+		CertSerialNumber: "5678",
+		CertNotAfter:     node.CertNotAfter + 60,
+		InputMask:        &common.AttestedNodeMask{CertSerialNumber: true},
+	})
+	require.NoError(t, err)
+	node.CertSerialNumber = "5678"
+	spiretest.RequireProtoEqual(t, node, updateResp.Node)
+
+	countResp, err := ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), countResp.Nodes)
+
+	// Only nodes that expire before the given time are listed
+	listResp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByExpiresBefore: &wrapperspb.Int64Value{Value: time.Now().Unix()},
+	})
+	require.NoError(t, err)
+	require.Empty(t, listResp.Nodes)
+
+	// Only nodes that expire at or after the given time are listed
+	listResp, err = ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByExpiresAtOrAfter: &wrapperspb.Int64Value{Value: node.CertNotAfter},
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.Nodes, 1)
+
+	listResp, err = ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByExpiresAtOrAfter: &wrapperspb.Int64Value{Value: node.CertNotAfter + 1},
+	})
+	require.NoError(t, err)
+	require.Empty(t, listResp.Nodes)
+
+	deleteResp, err := ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, node, deleteResp.Node)
+
+	fetchResp, err = ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Node)
+}
+
+func testNodeSelectors(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	getSelectors := func(spiffeID string) []*common.Selector {
+		resp, err := ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: spiffeID})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Selectors)
+		return resp.Selectors.Selectors
+	}
+	setSelectors := func(spiffeID string, selectors []*common.Selector) {
+		_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{SpiffeId: spiffeID, Selectors: selectors},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, getSelectors("spiffe://example.org/node1"))
+
+	setSelectors("spiffe://example.org/node1", unixSelectors("a"))
+	setSelectors("spiffe://example.org/node2", unixSelectors("b"))
+	spiretest.RequireProtoListEqual(t, unixSelectors("a"), getSelectors("spiffe://example.org/node1"))
+
+	// Setting selectors replaces the existing ones
+	setSelectors("spiffe://example.org/node1", unixSelectors("c"))
+	spiretest.RequireProtoListEqual(t, unixSelectors("c"), getSelectors("spiffe://example.org/node1"))
+
+	// Setting no selectors removes the existing ones without affecting
+	// other nodes
+	setSelectors("spiffe://example.org/node1", nil)
+	require.Empty(t, getSelectors("spiffe://example.org/node1"))
+	spiretest.RequireProtoListEqual(t, unixSelectors("b"), getSelectors("spiffe://example.org/node2"))
+
+	listResp, err := ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Selectors, 1)
+	spiretest.RequireProtoEqual(t, &datastore.NodeSelectors{
+		SpiffeId:  "spiffe://example.org/node2",
+		Selectors: unixSelectors("b"),
+	}, listResp.Selectors[0])
+}
+
+func testNodePagination(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            "spiffe://example.org/spire/agent/test/" + id,
+				AttestationDataType: "test",
+				CertSerialNumber:    id,
+				CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	actual := paginate(t, func(pagination *datastore.Pagination) (*datastore.Pagination, int, error) {
+		resp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{Pagination: pagination})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Pagination, len(resp.Nodes), nil
+	})
+	assert.Equal(t, 3, actual)
+
+	_, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		Pagination: &datastore.Pagination{PageSize: 0},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func testJoinTokens(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	fetchResp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "missing"})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.JoinToken)
+
+	token1 := &datastore.JoinToken{Token: "token1", Expiry: now, Ttl: 60}
+	token2 := &datastore.JoinToken{Token: "token2", Expiry: now + 60}
+	for _, token := range []*datastore.JoinToken{token1, token2} {
+		_, err := ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: token})
+		require.NoError(t, err)
+	}
+
+	// Tokens are unique
+	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: token1})
+	require.Error(t, err)
+
+	fetchResp, err = ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: token1.Token})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, token1, fetchResp.JoinToken)
+
+	listResp, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.JoinTokens, 2)
+	sort.Slice(listResp.JoinTokens, func(i, j int) bool {
+		return listResp.JoinTokens[i].Token < listResp.JoinTokens[j].Token
+	})
+	spiretest.RequireProtoEqual(t, token1, listResp.JoinTokens[0])
+	spiretest.RequireProtoEqual(t, token2, listResp.JoinTokens[1])
+
+	listResp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		ByExpiresBefore: &wrapperspb.Int64Value{Value: now + 60},
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.JoinTokens, 1)
+	spiretest.RequireProtoEqual(t, token1, listResp.JoinTokens[0])
+
+	listResp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		ByExpiresAtOrAfter: &wrapperspb.Int64Value{Value: now + 60},
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.JoinTokens, 1)
+	spiretest.RequireProtoEqual(t, token2, listResp.JoinTokens[0])
+
+	actual := paginate(t, func(pagination *datastore.Pagination) (*datastore.Pagination, int, error) {
+		resp, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{Pagination: pagination})
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Pagination, len(resp.JoinTokens), nil
+	})
+	assert.Equal(t, 2, actual)
+
+	_, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		Pagination: &datastore.Pagination{PageSize: 0},
+	})
+	requireCode(t, err, codes.InvalidArgument)
+
+	// Tokens can only be used once
+	_, err = ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "missing", UsedAt: now})
+	require.Error(t, err)
+	useResp, err := ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: token2.Token, UsedAt: now})
+	require.NoError(t, err)
+	token2.UsedAt = now
+	spiretest.RequireProtoEqual(t, token2, useResp.JoinToken)
+	_, err = ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: token2.Token, UsedAt: now + 1})
+	require.Error(t, err)
+	fetchResp, err = ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: token2.Token})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, token2, fetchResp.JoinToken)
+
+	listResp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		ByUsed: &wrapperspb.BoolValue{Value: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.JoinTokens, 1)
+	spiretest.RequireProtoEqual(t, token2, listResp.JoinTokens[0])
+
+	listResp, err = ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{
+		ByUsed: &wrapperspb.BoolValue{Value: false},
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.JoinTokens, 1)
+	spiretest.RequireProtoEqual(t, token1, listResp.JoinTokens[0])
+
+	// Tokens expiring exactly at the prune time are kept
+	_, err = ds.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{ExpiresBefore: now})
+	require.NoError(t, err)
+	fetchResp, err = ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: token1.Token})
+	require.NoError(t, err)
+	require.NotNil(t, fetchResp.JoinToken, "token expiring at the prune time was pruned")
+
+	_, err = ds.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{ExpiresBefore: now + 1})
+	require.NoError(t, err)
+	fetchResp, err = ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: token1.Token})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.JoinToken)
+
+	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{Token: token2.Token})
+	require.NoError(t, err)
+	fetchResp, err = ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: token2.Token})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.JoinToken)
+}
+
+// paginate lists with a page size of one until the returned token is empty
+// and returns the total number of items listed. Every page but the last must
+// contain exactly one item.
+func testIntegrity(t *testing.T, ds datastore.DataStore) {
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	for _, node := range []*common.AttestedNode{
+		{SpiffeId: "spiffe://example.org/spire/agent/test/ok", CertSerialNumber: "1", NewCertSerialNumber: "2"},
+		{SpiffeId: "spiffe://example.org/spire/agent/test/banned"},
+		{SpiffeId: "spiffe://example.org/spire/agent/test/broken", NewCertSerialNumber: "3", NewCertNotAfter: expiresAt},
+	} {
+		node.AttestationDataType = "test"
+		_, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		require.NoError(t, err)
+	}
+	createEntry(t, ds, "spiffe://example.org/workload", "spiffe://example.org/spire/agent/test/ok", "a")
+
+	// Only the node with a pending serial number but no current one is
+	// reported, and nothing is changed unless asked to
+	expected := &datastore.IntegrityIssue{
+		Kind:        datastore.IntegrityIssue_NODE_WITHOUT_SERIAL,
+		Description: `attested node "spiffe://example.org/spire/agent/test/broken" has a pending SVID serial number but no current one`,
+	}
+	resp, err := ds.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*datastore.IntegrityIssue{expected}, resp.Issues)
+
+	resp, err = ds.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Issues, 1)
+
+	// Repairing promotes the pending serial number
+	expected.Repaired = true
+	resp, err = ds.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{Repair: true})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*datastore.IntegrityIssue{expected}, resp.Issues)
+
+	fetchResp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: "spiffe://example.org/spire/agent/test/broken"})
+	require.NoError(t, err)
+	require.Equal(t, "3", fetchResp.Node.CertSerialNumber)
+	require.Equal(t, expiresAt, fetchResp.Node.CertNotAfter)
+	require.Empty(t, fetchResp.Node.NewCertSerialNumber)
+	require.Zero(t, fetchResp.Node.NewCertNotAfter)
+
+	resp, err = ds.CheckIntegrity(ctx, &datastore.CheckIntegrityRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Issues)
+}
+
+func paginate(t *testing.T, list func(*datastore.Pagination) (*datastore.Pagination, int, error)) int {
+	total := 0
+	pagination := &datastore.Pagination{PageSize: 1}
+	for i := 0; ; i++ {
+		require.Less(t, i, 100, "pagination did not terminate")
+
+		next, count, err := list(pagination)
+		require.NoError(t, err)
+		require.NotNil(t, next, "pagination missing from response")
+		total += count
+		if next.Token == "" {
+			require.Zero(t, count, "last page should be empty")
+			return total
+		}
+		require.Equal(t, 1, count, "page has unexpected number of items")
+		pagination = &datastore.Pagination{Token: next.Token, PageSize: 1}
+	}
+}
+
+func createEntry(t *testing.T, ds datastore.DataStore, spiffeID, parentID string, selectorValues ...string) *common.RegistrationEntry {
+	resp, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:  spiffeID,
+			ParentId:  parentID,
+			Selectors: unixSelectors(selectorValues...),
+		},
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+func createEntryFromProto(t *testing.T, ds datastore.DataStore, entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+func unixSelectors(values ...string) []*common.Selector {
+	var selectors []*common.Selector
+	for _, value := range values {
+		selectors = append(selectors, &common.Selector{Type: "unix", Value: value})
+	}
+	return selectors
+}
+
+func cloneEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	return proto.Clone(entry).(*common.RegistrationEntry)
+}
+
+// requireEntryEqual compares entries regardless of the order of the
+// selectors, which implementations are not required to preserve.
+func requireEntryEqual(t *testing.T, expected, actual *common.RegistrationEntry) {
+	requireEntriesEqual(t, []*common.RegistrationEntry{expected}, []*common.RegistrationEntry{actual})
+}
+
+// requireEntriesEqual compares entry lists regardless of the order of the
+// entries or their selectors.
+func requireEntriesEqual(t *testing.T, expected, actual []*common.RegistrationEntry) {
+	expected = cloneEntries(expected)
+	actual = cloneEntries(actual)
+	util.SortRegistrationEntries(expected)
+	util.SortRegistrationEntries(actual)
+	spiretest.RequireProtoListEqual(t, expected, actual)
+}
+
+func cloneEntries(entries []*common.RegistrationEntry) []*common.RegistrationEntry {
+	clones := make([]*common.RegistrationEntry, 0, len(entries))
+	for _, entry := range entries {
+		clone := cloneEntry(entry)
+		util.SortSelectors(clone.Selectors)
+		clones = append(clones, clone)
+	}
+	return clones
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), "unexpected status code: %v", err)
+}
+
+func newCertificate(t *testing.T) *x509.Certificate {
+	return testca.New(t, spiffeid.RequireTrustDomainFromString("example.org")).X509Authorities()[0]
+}
@@ -239,6 +239,13 @@ func (s *DataStore) PruneRegistrationEntries(ctx context.Context, req *datastore
 	return s.ds.PruneRegistrationEntries(ctx, req)
 }
 
+func (s *DataStore) BatchRegistrationEntries(ctx context.Context, req *datastore.BatchRegistrationEntriesRequest) (*datastore.BatchRegistrationEntriesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.BatchRegistrationEntries(ctx, req)
+}
+
 func (s *DataStore) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -260,6 +267,27 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	return s.ds.DeleteJoinToken(ctx, req)
 }
 
+func (s *DataStore) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.UseJoinToken(ctx, req)
+}
+
+func (s *DataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	resp, err := s.ds.ListJoinTokens(ctx, req)
+	if err == nil {
+		// Sorting helps unit-tests have deterministic assertions.
+		sort.Slice(resp.JoinTokens, func(i, j int) bool {
+			return resp.JoinTokens[i].Token < resp.JoinTokens[j].Token
+		})
+	}
+	return resp, err
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -267,6 +295,13 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return s.ds.PruneJoinTokens(ctx, req)
 }
 
+func (s *DataStore) CheckIntegrity(ctx context.Context, req *datastore.CheckIntegrityRequest) (*datastore.CheckIntegrityResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.CheckIntegrity(ctx, req)
+}
+
 func (s *DataStore) SetNextError(err error) {
 	s.errs = []error{err}
 }
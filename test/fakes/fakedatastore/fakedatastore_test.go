@@ -0,0 +1,14 @@
+package fakedatastore
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/test/datastoretest"
+)
+
+func TestConformance(t *testing.T) {
+	datastoretest.Run(t, func(t *testing.T) datastore.DataStore {
+		return New(t)
+	})
+}
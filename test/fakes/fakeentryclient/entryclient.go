@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
@@ -51,6 +52,7 @@ func New(t *testing.T, trustDomain spiffeid.TrustDomain, ds datastore.DataStore,
 	server := entryv1.New(entryv1.Config{
 		TrustDomain: trustDomain,
 		DataStore:   ds,
+		Clock:       clock.New(),
 		//EntryFetcher: authorizedEntryFetcherWithCache,
 	})
 
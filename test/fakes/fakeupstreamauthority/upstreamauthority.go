@@ -31,6 +31,7 @@ type Config struct {
 	TrustDomain                 spiffeid.TrustDomain
 	UseIntermediate             bool
 	DisallowPublishJWTKey       bool
+	DisableMintX509CAStreaming  bool
 	MutateMintX509CAResponse    func(*upstreamauthority.MintX509CAResponse)
 	MutatePublishJWTKeyResponse func(*upstreamauthority.PublishJWTKeyResponse)
 }
@@ -85,6 +86,10 @@ func (ua *UpstreamAuthority) MintX509CA(request *upstreamauthority.MintX509CAReq
 		return err
 	}
 
+	if ua.config.DisableMintX509CAStreaming {
+		return nil
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
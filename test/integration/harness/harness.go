@@ -0,0 +1,362 @@
+// Package harness boots a SPIRE server and agents in-process so end-to-end
+// flows can be exercised from Go tests, without docker or prebuilt binaries.
+//
+// The server uses a fake in-memory datastore, an in-memory key manager and
+// the join_token node attestor. Agents attest with join tokens and run the
+// unix workload attestor, so the test process itself can be attested as a
+// workload by registering an entry for its UID.
+//
+// The server and its agents share a mock clock. The harness moves it forward
+// by the poll interval while it waits for a condition, so that scheduled
+// tasks keep running, and tests advance it explicitly to skip over TTLs.
+// TLS handshakes still check certificates against the wall clock, so tests
+// must not advance the clock so far that server or agent SVIDs are renewed
+// with a validity period that starts ahead of it.
+package harness
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	agent_run "github.com/spiffe/spire/cmd/spire-agent/cli/run"
+	server_run "github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/server"
+	agentv1 "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	bundlev1 "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TrustDomain is the trust domain of the servers and agents booted by
+	// the harness.
+	TrustDomain = "example.org"
+
+	// startTimeout bounds how long the harness waits for a server or an
+	// agent to become ready.
+	startTimeout = time.Minute
+
+	// pollInterval is how often the harness checks a condition it waits
+	// for, and how far it advances the clock between checks.
+	pollInterval = 100 * time.Millisecond
+)
+
+var (
+	serverConfigTemplate = template.Must(template.New("server").Parse(`
+server {
+    bind_address = "127.0.0.1"
+    bind_port = "{{ .Port }}"
+    registration_uds_path = "{{ .SocketPath }}"
+    trust_domain = "{{ .TrustDomain }}"
+    data_dir = "{{ .Dir }}/data"
+    log_level = "DEBUG"
+    log_file = "{{ .Dir }}/server.log"
+    default_svid_ttl = "{{ .SVIDTTL }}"
+}
+
+plugins {
+    KeyManager "memory" {
+        plugin_data {}
+    }
+    NodeAttestor "join_token" {
+        plugin_data {}
+    }
+}
+`))
+
+	agentConfigTemplate = template.Must(template.New("agent").Parse(`
+agent {
+    data_dir = "{{ .Dir }}/data"
+    log_level = "DEBUG"
+    log_file = "{{ .Dir }}/agent.log"
+    server_address = "127.0.0.1"
+    server_port = "{{ .ServerPort }}"
+    socket_path = "{{ .SocketPath }}"
+    trust_bundle_path = "{{ .Dir }}/bundle.pem"
+    trust_domain = "{{ .TrustDomain }}"
+    join_token = "{{ .JoinToken }}"
+
+    experimental {
+        sync_interval = "500ms"
+    }
+}
+
+plugins {
+    KeyManager "memory" {
+        plugin_data {}
+    }
+    NodeAttestor "join_token" {
+        plugin_data {}
+    }
+    WorkloadAttestor "unix" {
+        plugin_data {}
+    }
+}
+`))
+)
+
+// ServerOptions configures a server started with StartServer.
+type ServerOptions struct {
+	// SVIDTTL is the default X509-SVID TTL. Defaults to one hour.
+	SVIDTTL time.Duration
+}
+
+// Server is a SPIRE server running in-process.
+type Server struct {
+	// Dir is the directory holding the server configuration, data and logs.
+	Dir string
+
+	// SocketPath is the path of the server UDS.
+	SocketPath string
+
+	// Port is the TCP port the server listens on for agents.
+	Port int
+
+	// Clock is the clock shared by the server and its agents.
+	Clock *clock.Mock
+
+	conn *grpc.ClientConn
+}
+
+// StartServer starts a server and waits until its APIs are ready. The server
+// is stopped when the test finishes.
+func StartServer(t *testing.T, options ServerOptions) *Server {
+	if options.SVIDTTL == 0 {
+		options.SVIDTTL = time.Hour
+	}
+
+	dir := tempDir(t, "server")
+	s := &Server{
+		Dir:        dir,
+		SocketPath: filepath.Join(dir, "server.sock"),
+		Port:       freePort(t),
+		Clock:      clock.NewMock(t),
+	}
+
+	configPath := filepath.Join(dir, "server.conf")
+	writeConfig(t, configPath, serverConfigTemplate, map[string]interface{}{
+		"Dir":         dir,
+		"Port":        s.Port,
+		"SocketPath":  s.SocketPath,
+		"TrustDomain": TrustDomain,
+		"SVIDTTL":     options.SVIDTTL.String(),
+	})
+
+	config, err := server_run.LoadConfig("run", []string{"-config", configPath}, nil, ioutil.Discard, false)
+	require.NoError(t, err, "failed to load server configuration")
+	config.Clock = s.Clock
+	config.DataStore = fakedatastore.New(t)
+	run(t, "server", server.New(*config).Run)
+
+	s.conn, err = grpc.Dial("unix://"+s.SocketPath, grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { s.conn.Close() })
+
+	// The APIs are served once the server CA is ready. Use the bundle as
+	// the readiness probe, like the server health check does.
+	eventually(t, s.Clock, "server to be ready", func(ctx context.Context) error {
+		_, err := s.BundleClient().GetBundle(ctx, &bundlev1.GetBundleRequest{})
+		return err
+	})
+	return s
+}
+
+// AgentClient returns a client for the agent API of the server.
+func (s *Server) AgentClient() agentv1.AgentClient {
+	return agentv1.NewAgentClient(s.conn)
+}
+
+// BundleClient returns a client for the bundle API of the server.
+func (s *Server) BundleClient() bundlev1.BundleClient {
+	return bundlev1.NewBundleClient(s.conn)
+}
+
+// EntryClient returns a client for the entry API of the server.
+func (s *Server) EntryClient() entryv1.EntryClient {
+	return entryv1.NewEntryClient(s.conn)
+}
+
+// CreateEntry creates a registration entry through the entry API.
+func (s *Server) CreateEntry(t *testing.T, entry *types.Entry) *types.Entry {
+	resp, err := s.EntryClient().BatchCreateEntry(context.Background(), &entryv1.BatchCreateEntryRequest{
+		Entries: []*types.Entry{entry},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, int32(codes.OK), resp.Results[0].Status.Code, resp.Results[0].Status.Message)
+	return resp.Results[0].Entry
+}
+
+// Agent is a SPIRE agent running in-process.
+type Agent struct {
+	// Dir is the directory holding the agent configuration, data and logs.
+	Dir string
+
+	// ID is the SPIFFE ID the agent attested with.
+	ID spiffeid.ID
+
+	// SocketPath is the path of the agent Workload API socket.
+	SocketPath string
+
+	clk *clock.Mock
+}
+
+// StartAgent starts an agent that attests to the server with a fresh join
+// token, and waits until the Workload API is served. The agent is stopped
+// when the test finishes.
+func (s *Server) StartAgent(t *testing.T) *Agent {
+	ctx := context.Background()
+
+	token, err := s.AgentClient().CreateJoinToken(ctx, &agentv1.CreateJoinTokenRequest{
+		Ttl: int32(startTimeout / time.Second),
+	})
+	require.NoError(t, err)
+
+	bundle, err := s.BundleClient().GetBundle(ctx, &bundlev1.GetBundleRequest{})
+	require.NoError(t, err)
+
+	dir := tempDir(t, "agent")
+	a := &Agent{
+		Dir:        dir,
+		ID:         spiffeid.Must(TrustDomain, "spire", "agent", "join_token", token.Value),
+		SocketPath: filepath.Join(dir, "agent.sock"),
+		clk:        s.Clock,
+	}
+
+	var rootCAs []*x509.Certificate
+	for _, authority := range bundle.X509Authorities {
+		cert, err := x509.ParseCertificate(authority.Asn1)
+		require.NoError(t, err)
+		rootCAs = append(rootCAs, cert)
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bundle.pem"), pemutil.EncodeCertificates(rootCAs), 0600))
+
+	configPath := filepath.Join(dir, "agent.conf")
+	writeConfig(t, configPath, agentConfigTemplate, map[string]interface{}{
+		"Dir":         dir,
+		"ServerPort":  s.Port,
+		"SocketPath":  a.SocketPath,
+		"TrustDomain": TrustDomain,
+		"JoinToken":   token.Value,
+	})
+
+	config, err := agent_run.LoadConfig("run", []string{"-config", configPath}, nil, ioutil.Discard, false)
+	require.NoError(t, err, "failed to load agent configuration")
+	config.Clock = s.Clock
+	run(t, "agent", agent.New(config).Run)
+
+	eventually(t, s.Clock, "agent to be attested", func(ctx context.Context) error {
+		resp, err := s.AgentClient().GetAgent(ctx, &agentv1.GetAgentRequest{
+			Id: &types.SPIFFEID{TrustDomain: a.ID.TrustDomain().String(), Path: a.ID.Path()},
+		})
+		if err != nil {
+			return err
+		}
+		if resp.X509SvidSerialNumber == "" {
+			return status.Error(codes.Unavailable, "agent SVID not issued yet")
+		}
+		return nil
+	})
+	eventually(t, s.Clock, "agent Workload API to be served", func(ctx context.Context) error {
+		_, err := os.Stat(a.SocketPath)
+		return err
+	})
+	return a
+}
+
+// FetchX509SVID fetches the default X509-SVID of the calling process from
+// the Workload API of the agent.
+func (a *Agent) FetchX509SVID(ctx context.Context) (*x509svid.SVID, error) {
+	return workloadapi.FetchX509SVID(ctx, workloadapi.WithAddr("unix://"+a.SocketPath))
+}
+
+// WaitForX509SVID waits until the Workload API of the agent serves an
+// X509-SVID to the calling process and returns it.
+func (a *Agent) WaitForX509SVID(t *testing.T) *x509svid.SVID {
+	var svid *x509svid.SVID
+	eventually(t, a.clk, "X509-SVID to be served", func(ctx context.Context) (err error) {
+		svid, err = a.FetchX509SVID(ctx)
+		return err
+	})
+	return svid
+}
+
+// run runs fn in the background until the test finishes.
+func run(t *testing.T, name string, fn func(context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-errCh:
+			if err != nil {
+				t.Errorf("%s failed: %v", name, err)
+			}
+		case <-time.After(startTimeout):
+			t.Errorf("timed out waiting for %s to stop", name)
+		}
+	})
+}
+
+// eventually calls fn until it succeeds or the start timeout elapses. The
+// clock is advanced by the poll interval between calls.
+func eventually(t *testing.T, clk *clock.Mock, what string, fn func(ctx context.Context) error) {
+	deadline := time.Now().Add(startTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := fn(ctx)
+		cancel()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			require.FailNowf(t, "timed out", "timed out waiting for %s: %v", what, err)
+		}
+		time.Sleep(pollInterval)
+		clk.Add(pollInterval)
+	}
+}
+
+func tempDir(t *testing.T, prefix string) string {
+	dir, err := ioutil.TempDir("", "spire-harness-"+prefix)
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func writeConfig(t *testing.T, path string, tmpl *template.Template, data interface{}) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, tmpl.Execute(f, data), fmt.Sprintf("failed to render %s", path))
+}
@@ -0,0 +1,55 @@
+// +build linux
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadX509SVID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode")
+	}
+
+	server := StartServer(t, ServerOptions{})
+	agent := server.StartAgent(t)
+
+	workloadID := spiffeid.Must(TrustDomain, "workload")
+	server.CreateEntry(t, &types.Entry{
+		ParentId: &types.SPIFFEID{TrustDomain: TrustDomain, Path: agent.ID.Path()},
+		SpiffeId: &types.SPIFFEID{TrustDomain: TrustDomain, Path: workloadID.Path()},
+		Selectors: []*types.Selector{
+			{Type: "unix", Value: fmt.Sprintf("uid:%d", os.Getuid())},
+		},
+		Ttl: 20,
+	})
+
+	// The server reloads its entry cache every five seconds.
+	server.Clock.Add(5 * time.Second)
+
+	svid := agent.WaitForX509SVID(t)
+	require.Equal(t, workloadID, svid.ID)
+
+	// The agent rotates SVIDs once half of their lifetime has elapsed. The
+	// lifetime includes the ten seconds the CA backdates them by.
+	first := svid.Certificates[0].SerialNumber
+	server.Clock.Add(6 * time.Second)
+	eventually(t, server.Clock, "X509-SVID to be rotated", func(ctx context.Context) error {
+		svid, err := agent.FetchX509SVID(ctx)
+		if err != nil {
+			return err
+		}
+		if svid.Certificates[0].SerialNumber.Cmp(first) == 0 {
+			return fmt.Errorf("X509-SVID %s not rotated yet", first)
+		}
+		return nil
+	})
+}
@@ -85,6 +85,7 @@ func main() {
 	testRPC("BatchUpdateFederatedBundle", batchUpdateFederatedBundle)
 	testRPC("BatchSetFederatedBundle", batchSetFederatedBundle)
 	testRPC("ListFederatedBundles", listFederatedBundles)
+	testRPC("CountBundles", countBundles)
 	testRPC("GetFederatedBundle", getFederatedBundle)
 	testRPC("BatchDeleteFederatedBundle", batchDeleteFederatedBundle)
 	// Entry client tests
@@ -368,6 +369,20 @@ func batchSetFederatedBundle(ctx context.Context, c *itclient.Client) error {
 	return nil
 }
 
+func countBundles(ctx context.Context, c *itclient.Client) error {
+	resp, err := c.BundleClient().CountBundles(ctx, &bundle.CountBundlesRequest{})
+	switch {
+	case c.ExpectErrors:
+		return validatePermissionError(err)
+	case err != nil:
+		return err
+	case resp.Count != 3:
+		// The server bundle and the "foo" and "bar" federated bundles
+		return fmt.Errorf("unexpected bundle count: %d", resp.Count)
+	}
+	return nil
+}
+
 func listFederatedBundles(ctx context.Context, c *itclient.Client) error {
 	resp, err := c.BundleClient().ListFederatedBundles(ctx, &bundle.ListFederatedBundlesRequest{})
 	switch {
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	client "github.com/spiffe/spire/pkg/agent/client"
 	node "github.com/spiffe/spire/proto/spire/api/node"
+	common "github.com/spiffe/spire/proto/spire/common"
 	reflect "reflect"
 )
 
@@ -35,6 +36,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// FetchBundles mocks base method
+func (m *MockClient) FetchBundles(arg0 context.Context, arg1 []string) (map[string]*common.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBundles", arg0, arg1)
+	ret0, _ := ret[0].(map[string]*common.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBundles indicates an expected call of FetchBundles
+func (mr *MockClientMockRecorder) FetchBundles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundles", reflect.TypeOf((*MockClient)(nil).FetchBundles), arg0, arg1)
+}
+
 // FetchUpdates mocks base method
 func (m *MockClient) FetchUpdates(arg0 context.Context) (*client.Update, error) {
 	m.ctrl.T.Helper()
@@ -93,10 +109,10 @@ func (mr *MockClientMockRecorder) Release() *gomock.Call {
 }
 
 // RenewSVID mocks base method
-func (m *MockClient) RenewSVID(arg0 context.Context, arg1 []byte) (*node.X509SVID, error) {
+func (m *MockClient) RenewSVID(arg0 context.Context, arg1 []byte) (*client.AgentSVID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RenewSVID", arg0, arg1)
-	ret0, _ := ret[0].(*node.X509SVID)
+	ret0, _ := ret[0].(*client.AgentSVID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }